@@ -618,7 +618,7 @@ func spansForAllRestoreTableIndexes(
 		// entire interval. DROPPED tables should never later become PUBLIC.
 		// TODO(pbardea): Consider and test the interaction between revision_history
 		// backups and OFFLINE tables.
-		rawTbl, _, _, _ := descpb.FromDescriptor(rev.Desc)
+		rawTbl, _, _, _, _ := descpb.FromDescriptor(rev.Desc)
 		if rawTbl != nil && !rawTbl.Dropped() {
 			tbl := tabledesc.NewBuilder(rawTbl).BuildImmutableTable()
 			// We only import spans for physical tables.
@@ -1004,6 +1004,32 @@ func createImportingDescriptors(
 					}
 				}
 			}
+			// Likewise, restored composite types could have fields of existing
+			// types.
+			for _, typ := range typesToWrite {
+				for _, e := range typ.CompositeElements {
+					if !e.Type.UserDefined() {
+						continue
+					}
+					id, err := typedesc.GetUserDefinedTypeDescID(e.Type)
+					if err != nil {
+						return err
+					}
+					if _, ok := existingTypeIDs[id]; !ok {
+						continue
+					}
+					typDesc, err := descsCol.GetMutableTypeVersionByID(ctx, txn, id)
+					if err != nil {
+						return err
+					}
+					typDesc.AddReferencingTypeID(typ.GetID())
+					if err := descsCol.WriteDescToBatch(
+						ctx, false /* kvTrace */, typDesc, b,
+					); err != nil {
+						return err
+					}
+				}
+			}
 			if err := txn.Run(ctx, b); err != nil {
 				return err
 			}
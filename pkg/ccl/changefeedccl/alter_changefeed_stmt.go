@@ -80,6 +80,15 @@ func alterChangefeedPlanHook(
 			return errors.Errorf(`job %d is not paused`, jobID)
 		}
 
+		if prevDetails.Select != `` {
+			return errors.Errorf(
+				`job %d was created with CREATE CHANGEFEED AS SELECT and cannot be altered`, jobID)
+		}
+		if prevDetails.DatabaseID != descpb.InvalidID {
+			return errors.Errorf(
+				`job %d was created with CREATE CHANGEFEED FOR DATABASE or FOR SCHEMA and cannot be altered`, jobID)
+		}
+
 		newChangefeedStmt := &tree.CreateChangefeed{}
 
 		prevOpts, err := getPrevOpts(job.Payload().Description, prevDetails.Opts)
@@ -17,8 +17,11 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowexec"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -110,7 +113,11 @@ func distChangefeedFlow(
 			spansTS = spansTS.Next()
 		}
 		var err error
-		trackedSpans, err = fetchSpansForTargets(ctx, execCfg, AllTargets(details), spansTS)
+		if details.DatabaseID != descpb.InvalidID {
+			trackedSpans, err = fetchSpansForWatchedTables(ctx, execCfg, AllTargets(details), spansTS)
+		} else {
+			trackedSpans, err = fetchSpansForTargets(ctx, execCfg, AllTargets(details), spansTS)
+		}
 		if err != nil {
 			return err
 		}
@@ -168,3 +175,49 @@ func fetchSpansForTargets(
 	}
 	return spans, nil
 }
+
+// fetchSpansForWatchedTables is like fetchSpansForTargets for a changefeed on
+// a database or schema, whose targets may include tables which were created
+// after ts while the changefeed was waiting for its first table. Those tables
+// have no data as of ts, so their spans are resolved from their current
+// descriptors.
+func fetchSpansForWatchedTables(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	targets []jobspb.ChangefeedTargetSpecification,
+	ts hlc.Timestamp,
+) ([]roachpb.Span, error) {
+	var existing, created []jobspb.ChangefeedTargetSpecification
+	partition := func(ctx context.Context, txn *kv.Txn, descriptors *descs.Collection) error {
+		existing, created = nil, nil
+		if err := txn.SetFixedTimestamp(ctx, ts); err != nil {
+			return err
+		}
+		for _, target := range targets {
+			flags := tree.ObjectLookupFlagsWithRequired()
+			flags.AvoidLeased = true
+			if _, err := descriptors.GetImmutableTableByID(ctx, txn, target.TableID, flags); err != nil {
+				if pgerror.GetPGCode(err) != pgcode.UndefinedTable && !catalog.HasAddingTableError(err) {
+					return err
+				}
+				created = append(created, target)
+				continue
+			}
+			existing = append(existing, target)
+		}
+		return nil
+	}
+	if err := sql.DescsTxn(ctx, execCfg, partition); err != nil {
+		return nil, err
+	}
+
+	spans, err := fetchSpansForTargets(ctx, execCfg, existing, ts)
+	if err != nil || len(created) == 0 {
+		return spans, err
+	}
+	createdSpans, err := fetchSpansForTargets(ctx, execCfg, created, execCfg.Clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	return append(spans, createdSpans...), nil
+}
@@ -72,6 +72,14 @@ type changeAggregator struct {
 	// sink is the Sink to write rows to. Resolved timestamps are never written
 	// by changeAggregator.
 	sink Sink
+	// txnSink is set if sink delivers rows transactionally, in which case it
+	// is flushed along with the resolved timestamp of the local frontier.
+	txnSink transactionalSink
+	// committed, if set, is the frontier of the rows previously committed to
+	// txnSink. Rows at or below it are not emitted again. It is cleared once
+	// the local frontier passes committedHighWater.
+	committed          *span.Frontier
+	committedHighWater hlc.Timestamp
 	// changedRowBuf, if non-nil, contains changed rows to be emitted. Anything
 	// queued in `resolvedSpanBuf` is dependent on these having been emitted, so
 	// this one must be empty before moving on to that one.
@@ -288,6 +296,23 @@ func (ca *changeAggregator) Start(ctx context.Context) {
 	if b, ok := ca.sink.(*bufferSink); ok {
 		ca.changedRowBuf = &b.buf
 	}
+	if txnSink, ok := ca.sink.(transactionalSink); ok {
+		ca.committed, err = txnSink.StartTransactions(
+			ctx, spans, ca.spec.AggregatorIndex, ca.spec.NumAggregators)
+		if err != nil {
+			ca.MoveToDraining(changefeedbase.MarkRetryableError(err))
+			ca.cancel()
+			return
+		}
+		ca.committed.Entries(func(_ roachpb.Span, ts hlc.Timestamp) span.OpResult {
+			ca.committedHighWater.Forward(ts)
+			return span.ContinueMatch
+		})
+		if ca.committedHighWater.IsEmpty() {
+			ca.committed = nil
+		}
+		ca.txnSink = txnSink
+	}
 
 	ca.sink = &errorWrapperSink{wrapped: ca.sink}
 
@@ -303,7 +328,7 @@ func (ca *changeAggregator) Start(ctx context.Context) {
 		ca.eventConsumer = newNativeKVConsumer(ca.sink)
 	} else {
 		ca.eventConsumer = newKVEventToRowConsumer(
-			ctx, ca.flowCtx.Cfg, ca.frontier.SpanFrontier(), initialHighWater,
+			ctx, ca.flowCtx.Cfg, ca.flowCtx.NewEvalCtx(), ca.frontier.SpanFrontier(), initialHighWater,
 			ca.sink, ca.encoder, ca.spec.Feed, ca.knobs, ca.topicNamer)
 	}
 }
@@ -372,7 +397,8 @@ func (ca *changeAggregator) makeKVFeedCfg(
 		sf = schemafeed.DoNothingSchemaFeed
 	} else {
 		sf = schemafeed.New(ctx, cfg, schemaChangeEvents, AllTargets(ca.spec.Feed),
-			initialHighWater, &ca.metrics.SchemaFeedMetrics, ca.spec.Feed.Opts)
+			ca.spec.Feed.DatabaseID, ca.spec.Feed.SchemaID, initialHighWater,
+			&ca.metrics.SchemaFeedMetrics, ca.spec.Feed.Opts)
 	}
 
 	return kvfeed.Config{
@@ -396,7 +422,11 @@ func (ca *changeAggregator) makeKVFeedCfg(
 		SchemaChangeEvents:      schemaChangeEvents,
 		SchemaChangePolicy:      schemaChangePolicy,
 		SchemaFeed:              sf,
-		Knobs:                   ca.knobs.FeedKnobs,
+		// The tables created in the database or schema watched by the changefeed
+		// are added to the spans of the first aggregator, until the changefeed
+		// restarts and distributes the spans of all its tables again.
+		WatchCreatedTables: ca.spec.Feed.DatabaseID != descpb.InvalidID && ca.spec.AggregatorIndex == 0,
+		Knobs:              ca.knobs.FeedKnobs,
 	}
 }
 
@@ -544,6 +574,11 @@ func (ca *changeAggregator) tick() error {
 
 	switch event.Type() {
 	case kvevent.TypeKV:
+		if ca.alreadyCommitted(event) {
+			a := event.DetachAlloc()
+			a.Release(ca.Ctx)
+			return nil
+		}
 		// Keep track of SLI latency for non-backfill/rangefeed KV events.
 		if event.BackfillTimestamp().IsEmpty() {
 			ca.sliMetrics.AdmitLatency.RecordValue(timeutil.Since(event.Timestamp().GoTime()).Nanoseconds())
@@ -558,16 +593,52 @@ func (ca *changeAggregator) tick() error {
 			return ca.noteResolvedSpan(resolved)
 		}
 	case kvevent.TypeFlush:
+		if ca.txnSink != nil {
+			// Transactional sinks only commit rows along with a record of the
+			// resolved timestamp they cover, so checkpoint the local frontier.
+			if err := ca.flushFrontier(); err != nil {
+				return err
+			}
+		}
 		return ca.sink.Flush(ca.Ctx)
 	}
 
 	return nil
 }
 
+// alreadyCommitted returns whether the row for the KV event was committed to
+// the transactional sink before the changefeed restarted.
+func (ca *changeAggregator) alreadyCommitted(event kvevent.Event) bool {
+	if ca.committed == nil {
+		return false
+	}
+	if ca.committedHighWater.Less(ca.frontier.Frontier()) {
+		ca.committed = nil
+		return false
+	}
+
+	updated := event.BackfillTimestamp()
+	if updated.IsEmpty() {
+		updated = event.Timestamp()
+	}
+	key := event.KV().Key
+	committed := false
+	ca.committed.SpanEntries(roachpb.Span{Key: key, EndKey: key.Next()},
+		func(_ roachpb.Span, ts hlc.Timestamp) span.OpResult {
+			committed = updated.LessEq(ts)
+			return span.StopMatch
+		})
+	return committed
+}
+
 // noteResolvedSpan periodically flushes Frontier progress from the current
 // changeAggregator node to the changeFrontier node to allow the changeFrontier
 // to persist the overall changefeed's progress
 func (ca *changeAggregator) noteResolvedSpan(resolved *jobspb.ResolvedSpan) error {
+	if resolved.SpanChange != jobspb.ResolvedSpan_UNCHANGED {
+		return ca.noteSpanChange(resolved)
+	}
+
 	advanced, err := ca.frontier.ForwardResolvedSpan(*resolved)
 	if err != nil {
 		return err
@@ -575,8 +646,12 @@ func (ca *changeAggregator) noteResolvedSpan(resolved *jobspb.ResolvedSpan) erro
 
 	forceFlush := resolved.BoundaryType != jobspb.ResolvedSpan_NONE
 
+	// Transactional sinks hold on to the memory of the rows they buffer until
+	// a checkpoint commits them.
+	flushTxnSink := ca.txnSink != nil && ca.txnSink.ShouldFlushResolved()
+
 	checkpointFrontier := advanced &&
-		(forceFlush || timeutil.Since(ca.lastFlush) > ca.flushFrequency)
+		(forceFlush || flushTxnSink || timeutil.Since(ca.lastFlush) > ca.flushFrequency)
 
 	// If backfilling we must also consider the Backfill Checkpointing frequency
 	checkpointBackfill := ca.spec.JobID != 0 && /* enterprise changefeed */
@@ -593,13 +668,49 @@ func (ca *changeAggregator) noteResolvedSpan(resolved *jobspb.ResolvedSpan) erro
 	return nil
 }
 
+// noteSpanChange adds a span to, or removes it from, the spans watched by the
+// changeAggregator, as tables are created in or dropped from the database or
+// schema watched by a changefeed created with CREATE CHANGEFEED FOR DATABASE
+// or FOR SCHEMA, and forwards the change to the changeFrontier.
+func (ca *changeAggregator) noteSpanChange(change *jobspb.ResolvedSpan) error {
+	if c, ok := ca.eventConsumer.(*kvEventToRowConsumer); ok &&
+		change.SpanChange == jobspb.ResolvedSpan_ADDED {
+		// The table of the added span becomes public right after the change.
+		if err := c.addWatchedTable(ca.Ctx, change.Span, change.Timestamp.Next()); err != nil {
+			return err
+		}
+	}
+	if ca.txnSink != nil {
+		if err := ca.txnSink.NoteSpanChange(*change); err != nil {
+			return err
+		}
+	}
+	if _, err := ca.frontier.ForwardResolvedSpan(*change); err != nil {
+		return err
+	}
+
+	// The changeFrontier stops waiting for a removed span as soon as it gets
+	// the change, so the rows of the span are flushed first.
+	if err := ca.flushFrontier(); err != nil {
+		return err
+	}
+	ca.lastFlush = timeutil.Now()
+	return ca.emitResolved(jobspb.ResolvedSpans{ResolvedSpans: []jobspb.ResolvedSpan{*change}})
+}
+
 // flushFrontier flushes sink and emits resolved timestamp if needed.
 func (ca *changeAggregator) flushFrontier() error {
 	// Make sure to flush the sink before forwarding resolved spans,
 	// otherwise, we could lose buffered messages and violate the
 	// at-least-once guarantee. This is also true for checkpointing the
 	// resolved spans in the job progress.
-	if err := ca.sink.Flush(ca.Ctx); err != nil {
+	if ca.txnSink != nil {
+		// Transactional sinks only commit the rows covered by the local
+		// frontier, recording it so those rows are not emitted again.
+		if err := ca.txnSink.FlushResolved(ca.Ctx, ca.frontier.Frontier()); err != nil {
+			return changefeedbase.MarkRetryableError(err)
+		}
+	} else if err := ca.sink.Flush(ca.Ctx); err != nil {
 		return err
 	}
 
@@ -690,6 +801,10 @@ type kvEventToRowConsumer struct {
 	kvFetcher            row.SpanKVFetcher
 	topicDescriptorCache map[TopicIdentifier]TopicDescriptor
 	topicNamer           *TopicNamer
+
+	// evaluators is set for changefeeds created with CREATE CHANGEFEED AS
+	// SELECT, and evaluates their query for each row.
+	evaluators *exprEvaluatorCache
 }
 
 var _ kvEventConsumer = &kvEventToRowConsumer{}
@@ -697,6 +812,7 @@ var _ kvEventConsumer = &kvEventToRowConsumer{}
 func newKVEventToRowConsumer(
 	ctx context.Context,
 	cfg *execinfra.ServerConfig,
+	evalCtx *tree.EvalContext,
 	frontier *span.Frontier,
 	cursor hlc.Timestamp,
 	sink Sink,
@@ -714,6 +830,16 @@ func newKVEventToRowConsumer(
 		details,
 	)
 
+	var evaluators *exprEvaluatorCache
+	if details.Select != `` {
+		_, withDiff := details.Opts[changefeedbase.OptDiff]
+		evaluators = &exprEvaluatorCache{
+			query:    details.Select,
+			evalCtx:  evalCtx,
+			withDiff: withDiff,
+		}
+	}
+
 	return &kvEventToRowConsumer{
 		frontier:             frontier,
 		encoder:              encoder,
@@ -724,6 +850,7 @@ func newKVEventToRowConsumer(
 		knobs:                knobs,
 		topicDescriptorCache: make(map[TopicIdentifier]TopicDescriptor),
 		topicNamer:           topicNamer,
+		evaluators:           evaluators,
 	}
 }
 
@@ -873,6 +1000,57 @@ func (c *kvEventToRowConsumer) topicForRow(r encodeRow) (TopicDescriptor, error)
 	return noTopic{}, errors.AssertionFailedf("no TargetSpecification for row %v", r)
 }
 
+// addWatchedTable adds the table of a span added to the spans watched by a
+// changefeed created with CREATE CHANGEFEED FOR DATABASE or FOR SCHEMA to the
+// targets of the consumer. The table is public as of ts.
+func (c *kvEventToRowConsumer) addWatchedTable(
+	ctx context.Context, sp roachpb.Span, ts hlc.Timestamp,
+) error {
+	_, id, err := c.rfCache.codec.DecodeTablePrefix(sp.Key)
+	if err != nil {
+		return err
+	}
+	tableID := descpb.ID(id)
+	for _, s := range c.details.TargetSpecifications {
+		if s.TableID == tableID {
+			return nil
+		}
+	}
+
+	var target jobspb.ChangefeedTargetSpecification
+	if err := c.rfCache.db.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		if err := txn.SetFixedTimestamp(ctx, ts); err != nil {
+			return err
+		}
+		flags := tree.ObjectLookupFlagsWithRequired()
+		flags.AvoidLeased = true
+		tableDesc, err := c.rfCache.collection.GetImmutableTableByID(ctx, txn, tableID, flags)
+		if err != nil {
+			return err
+		}
+		name := tableDesc.GetName()
+		if _, qualified := c.details.Opts[changefeedbase.OptFullTableName]; qualified {
+			tn, err := qualifiedTableNameObj(ctx, c.rfCache.codec, c.rfCache.collection, txn, tableDesc)
+			if err != nil {
+				return err
+			}
+			name = tn.String()
+		}
+		target = changefeedbase.WatchedTableTarget(tableDesc, name)
+		return nil
+	}); err != nil {
+		return changefeedbase.MarkRetryableError(err)
+	}
+	c.rfCache.collection.ReleaseAll(ctx)
+
+	c.details.TargetSpecifications = append(c.details.TargetSpecifications, target)
+	c.rfCache.watchedFamilies[watchedFamily{tableID: tableID}] = struct{}{}
+	if e, ok := c.encoder.(targetAdder); ok {
+		e.addTarget(target)
+	}
+	return nil
+}
+
 // ConsumeEvent implements kvEventConsumer interface
 func (c *kvEventToRowConsumer) ConsumeEvent(ctx context.Context, ev kvevent.Event) error {
 	if ev.Type() != kvevent.TypeKV {
@@ -889,6 +1067,17 @@ func (c *kvEventToRowConsumer) ConsumeEvent(ctx context.Context, ev kvevent.Even
 		return err
 	}
 
+	if c.evaluators != nil {
+		// Rows that are filtered out by the query are not encoded or emitted.
+		evaluator, err := c.evaluators.forDesc(ctx, r.tableDesc)
+		if err != nil {
+			return err
+		}
+		if emit, err := evaluator.evalRow(ctx, &r); err != nil || !emit {
+			return err
+		}
+	}
+
 	topic, err := c.topicForRow(r)
 	if err != nil {
 		return err
@@ -1922,8 +2111,16 @@ func makeSchemaChangeFrontier(
 }
 
 // ForwardResolvedSpan advances the timestamp for a resolved span, taking care
-// of updating schema change boundary information.
+// of updating schema change boundary information. Resolved spans which add or
+// remove a span add it to or remove it from the frontier instead.
 func (f *schemaChangeFrontier) ForwardResolvedSpan(r jobspb.ResolvedSpan) (bool, error) {
+	switch r.SpanChange {
+	case jobspb.ResolvedSpan_ADDED:
+		return false, f.AddSpansAt(r.Timestamp, r.Span)
+	case jobspb.ResolvedSpan_REMOVED:
+		return f.RemoveSpans(r.Span)
+	}
+
 	if r.BoundaryType != jobspb.ResolvedSpan_NONE {
 		if !f.boundaryTime.IsEmpty() && r.Timestamp.Less(f.boundaryTime) {
 			// Boundary resolved events should be ingested from the schema feed
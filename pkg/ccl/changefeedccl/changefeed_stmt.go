@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/featureflag"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts"
@@ -35,6 +36,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
 	"github.com/cockroachdb/cockroach/pkg/sql/flowinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -345,18 +347,50 @@ func createChangefeedJobRecord(
 		}
 	}
 
-	tableOnlyTargetList := tree.TargetList{}
-	for _, t := range changefeedStmt.Targets {
-		tableOnlyTargetList.Tables = append(tableOnlyTargetList.Tables, t.TableName)
-	}
+	rawTargets := changefeedStmt.Targets
+	var query string
+	if changefeedStmt.Select != nil {
+		target, err := changefeedTargetFromSelect(changefeedStmt.Select)
+		if err != nil {
+			return nil, err
+		}
+		rawTargets = tree.ChangefeedTargets{target}
+		query = tree.AsString(changefeedStmt.Select)
+	}
+
+	var databaseID, schemaID descpb.ID
+	var targetDescs map[tree.TablePattern]catalog.Descriptor
+	if changefeedStmt.Database != "" || changefeedStmt.Schema != nil {
+		// Following the tables which are created in a database requires a job,
+		// and would be defeated by ignoring schema changes.
+		if unspecifiedSink {
+			return nil, errors.Errorf(
+				`CREATE CHANGEFEED FOR DATABASE or FOR SCHEMA requires a sink`)
+		}
+		if policy := opts[changefeedbase.OptSchemaChangePolicy]; policy == string(changefeedbase.OptSchemaChangePolicyIgnore) {
+			return nil, errors.Errorf(
+				`CREATE CHANGEFEED FOR DATABASE or FOR SCHEMA is incompatible with %s=%s`,
+				changefeedbase.OptSchemaChangePolicy, policy)
+		}
+		databaseID, schemaID, rawTargets, targetDescs, err = getDatabaseTargets(
+			ctx, p, changefeedStmt.CreateChangefeed, statementTime, initialHighWater)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		tableOnlyTargetList := tree.TargetList{}
+		for _, t := range rawTargets {
+			tableOnlyTargetList.Tables = append(tableOnlyTargetList.Tables, t.TableName)
+		}
 
-	// This grabs table descriptors once to get their ids.
-	targetDescs, err := getTableDescriptors(ctx, p, &tableOnlyTargetList, statementTime, initialHighWater)
-	if err != nil {
-		return nil, err
+		// This grabs table descriptors once to get their ids.
+		targetDescs, err = getTableDescriptors(ctx, p, &tableOnlyTargetList, statementTime, initialHighWater)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	targets, tables, err := getTargetsAndTables(ctx, p, targetDescs, changefeedStmt.Targets, changefeedStmt.originalSpecs, opts)
+	targets, tables, err := getTargetsAndTables(ctx, p, targetDescs, rawTargets, changefeedStmt.originalSpecs, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -368,6 +402,11 @@ func createChangefeedJobRecord(
 			for _, warning := range changefeedbase.WarningsForTable(tables, table, opts) {
 				p.BufferClientNotice(ctx, pgnotice.Newf("%s", warning))
 			}
+			if query != `` {
+				if err := validateChangefeedSelect(ctx, p, query, table, opts); err != nil {
+					return nil, err
+				}
+			}
 		}
 	}
 
@@ -378,6 +417,9 @@ func createChangefeedJobRecord(
 		StatementTime:        statementTime,
 		EndTime:              endTime,
 		TargetSpecifications: targets,
+		Select:               query,
+		DatabaseID:           databaseID,
+		SchemaID:             schemaID,
 	}
 
 	// TODO(dan): In an attempt to present the most helpful error message to the
@@ -428,6 +470,14 @@ func createChangefeedJobRecord(
 		return nil, err
 	}
 
+	switch format := changefeedbase.FormatType(details.Opts[changefeedbase.OptFormat]); format {
+	case changefeedbase.OptFormatCSV, changefeedbase.OptFormatParquet:
+		if !isCloudStorageSink(parsedSink) {
+			return nil, errors.Errorf(`%s=%s is only usable with cloud storage sinks`,
+				changefeedbase.OptFormat, format)
+		}
+	}
+
 	if isCloudStorageSink(parsedSink) || isWebhookSink(parsedSink) {
 		details.Opts[changefeedbase.OptKeyInValue] = ``
 	}
@@ -503,6 +553,9 @@ func createChangefeedJobRecord(
 		Description: jobDescription,
 		Username:    p.User(),
 		DescriptorIDs: func() (sqlDescIDs []descpb.ID) {
+			if databaseID != descpb.InvalidID {
+				sqlDescIDs = append(sqlDescIDs, databaseID)
+			}
 			for _, desc := range targetDescs {
 				sqlDescIDs = append(sqlDescIDs, desc.GetID())
 			}
@@ -584,6 +637,98 @@ func getTableDescriptors(
 	return targetDescs, err
 }
 
+// getDatabaseTargets resolves the database, and schema if any, targeted by a
+// CREATE CHANGEFEED FOR DATABASE or FOR SCHEMA statement, along with the
+// tables in it as of the statement time, of which there may be none. The
+// returned targets and descriptors have the same shape as those resolved for a
+// list of tables.
+func getDatabaseTargets(
+	ctx context.Context,
+	p sql.PlanHookState,
+	stmt *tree.CreateChangefeed,
+	statementTime hlc.Timestamp,
+	initialHighWater hlc.Timestamp,
+) (
+	databaseID, schemaID descpb.ID,
+	rawTargets tree.ChangefeedTargets,
+	targetDescs map[tree.TablePattern]catalog.Descriptor,
+	err error,
+) {
+	dbName := string(stmt.Database)
+	if stmt.Schema != nil {
+		dbName = p.SessionData().Database
+		if stmt.Schema.ExplicitCatalog {
+			dbName = stmt.Schema.Catalog()
+		}
+	}
+	resolveTargets := func(ctx context.Context, txn *kv.Txn, descriptors *descs.Collection) error {
+		rawTargets, targetDescs = nil, make(map[tree.TablePattern]catalog.Descriptor)
+		if err := txn.SetFixedTimestamp(ctx, statementTime); err != nil {
+			return err
+		}
+		flags := tree.DatabaseLookupFlags{Required: true, AvoidLeased: true}
+		dbDesc, err := descriptors.GetImmutableDatabaseByName(ctx, txn, dbName, flags)
+		if err != nil {
+			return err
+		}
+		databaseID = dbDesc.GetID()
+		if stmt.Schema != nil {
+			scDesc, err := descriptors.GetImmutableSchemaByName(ctx, txn, dbDesc, stmt.Schema.Schema(), flags)
+			if err != nil {
+				return err
+			}
+			if kind := scDesc.SchemaKind(); kind == catalog.SchemaVirtual || kind == catalog.SchemaTemporary {
+				return errors.Errorf(`CHANGEFEED cannot target %s`, tree.AsString(stmt.Schema))
+			}
+			schemaID = scDesc.GetID()
+		}
+
+		tables, err := getWatchedTables(ctx, txn, descriptors, databaseID, schemaID)
+		if err != nil {
+			return err
+		}
+		for _, table := range tables {
+			tn, err := getQualifiedTableNameObj(ctx, p.ExecCfg(), txn, table)
+			if err != nil {
+				return err
+			}
+			rawTargets = append(rawTargets, tree.ChangefeedTarget{TableName: &tn})
+			targetDescs[&tn] = table
+		}
+		return nil
+	}
+	if err := sql.DescsTxn(ctx, p.ExecCfg(), resolveTargets); err != nil {
+		err = errors.Wrap(err, "failed to resolve targets in the CHANGEFEED stmt")
+		if !initialHighWater.IsEmpty() {
+			err = errors.WithHintf(err,
+				"do the targets exist at the specified cursor time %s?", initialHighWater)
+		}
+		return 0, 0, nil, nil, err
+	}
+	return databaseID, schemaID, rawTargets, targetDescs, nil
+}
+
+// getWatchedTables returns the public tables in the given database, or in the
+// given schema if schemaID is set, which are followed by a changefeed on it.
+func getWatchedTables(
+	ctx context.Context,
+	txn *kv.Txn,
+	descriptors *descs.Collection,
+	databaseID, schemaID descpb.ID,
+) ([]catalog.TableDescriptor, error) {
+	all, err := descriptors.GetAllTableDescriptorsInDatabase(ctx, txn, databaseID)
+	if err != nil {
+		return nil, err
+	}
+	var tables []catalog.TableDescriptor
+	for _, table := range all {
+		if table.Public() && changefeedbase.WatchesTable(databaseID, schemaID, table) {
+			tables = append(tables, table)
+		}
+	}
+	return tables, nil
+}
+
 func getTargetsAndTables(
 	ctx context.Context,
 	p sql.PlanHookState,
@@ -698,6 +843,35 @@ func validateSink(
 	return nil
 }
 
+// validateChangefeedSelect returns an error if the query of a changefeed
+// created with CREATE CHANGEFEED AS SELECT cannot be evaluated against the
+// given target table.
+func validateChangefeedSelect(
+	ctx context.Context,
+	p sql.PlanHookState,
+	query string,
+	table catalog.TableDescriptor,
+	opts map[string]string,
+) error {
+	if format := opts[changefeedbase.OptFormat]; format != `` &&
+		format != string(changefeedbase.OptFormatJSON) {
+		return errors.Errorf(
+			`%s=%s is not supported by CREATE CHANGEFEED AS SELECT`, changefeedbase.OptFormat, format)
+	}
+	if table.NumFamilies() > 1 {
+		return errors.Errorf(
+			`CREATE CHANGEFEED AS SELECT does not support table %s with multiple column families`,
+			table.GetName())
+	}
+	sel, err := parseChangefeedSelect(query)
+	if err != nil {
+		return err
+	}
+	_, withDiff := opts[changefeedbase.OptDiff]
+	_, err = newExprEvaluator(ctx, &p.ExtendedEvalContext().EvalContext, sel, table, withDiff)
+	return err
+}
+
 func changefeedJobDescription(
 	p sql.PlanHookState, changefeed *tree.CreateChangefeed, sinkURI string, opts map[string]string,
 ) (string, error) {
@@ -705,6 +879,7 @@ func changefeedJobDescription(
 		changefeedbase.SinkParamSASLPassword,
 		changefeedbase.SinkParamCACert,
 		changefeedbase.SinkParamClientCert,
+		changefeedbase.SinkParamNATSCredentials,
 	})
 
 	if err != nil {
@@ -714,8 +889,18 @@ func changefeedJobDescription(
 	cleanedSinkURI = redactUser(cleanedSinkURI)
 
 	c := &tree.CreateChangefeed{
-		Targets: changefeed.Targets,
-		SinkURI: tree.NewDString(cleanedSinkURI),
+		Targets:  changefeed.Targets,
+		SinkURI:  tree.NewDString(cleanedSinkURI),
+		Select:   changefeed.Select,
+		Database: changefeed.Database,
+	}
+	if changefeed.Schema != nil {
+		schema := *changefeed.Schema
+		if !schema.ExplicitCatalog {
+			schema.CatalogName = tree.Name(p.SessionData().Database)
+			schema.ExplicitCatalog = true
+		}
+		c.Schema = &schema
 	}
 	for k, v := range opts {
 		if k == changefeedbase.OptWebhookAuthHeader {
@@ -808,7 +993,16 @@ func validateDetails(details jobspb.ChangefeedDetails) (jobspb.ChangefeedDetails
 			details.Opts[opt] = string(changefeedbase.OptEnvelopeRow)
 		case changefeedbase.OptEnvelopeKeyOnly:
 			details.Opts[opt] = string(changefeedbase.OptEnvelopeKeyOnly)
-		case ``, changefeedbase.OptEnvelopeWrapped:
+		case ``:
+			switch changefeedbase.FormatType(details.Opts[changefeedbase.OptFormat]) {
+			case changefeedbase.OptFormatCSV, changefeedbase.OptFormatParquet:
+				// Files in these formats only have a column for each column of the
+				// table, so there is nowhere to wrap the rows.
+				details.Opts[opt] = string(changefeedbase.OptEnvelopeRow)
+			default:
+				details.Opts[opt] = string(changefeedbase.OptEnvelopeWrapped)
+			}
+		case changefeedbase.OptEnvelopeWrapped:
 			details.Opts[opt] = string(changefeedbase.OptEnvelopeWrapped)
 		default:
 			return jobspb.ChangefeedDetails{}, errors.Errorf(
@@ -822,6 +1016,8 @@ func validateDetails(details jobspb.ChangefeedDetails) (jobspb.ChangefeedDetails
 			details.Opts[opt] = string(changefeedbase.OptFormatJSON)
 		case changefeedbase.OptFormatAvro, changefeedbase.DeprecatedOptFormatAvro:
 			// No-op.
+		case changefeedbase.OptFormatCSV, changefeedbase.OptFormatParquet:
+			// No-op.
 		default:
 			return jobspb.ChangefeedDetails{}, errors.Errorf(
 				`unknown %s: %s`, opt, v)
@@ -979,6 +1175,17 @@ func (b *changefeedResumer) resumeWithRetries(
 		// a dummy channel.
 		startedCh := make(chan tree.Datums, 1)
 
+		// The flow of a changefeed on a database or schema adds the spans of
+		// the tables created in it to its first aggregator, and removes those
+		// of the dropped tables, while it runs. Its targets are only updated
+		// in the job details when the changefeed resumes, which distributes
+		// the spans of all its tables among the aggregators again.
+		if details.DatabaseID != descpb.InvalidID {
+			if err := b.refreshWatchedTables(ctx, execCfg, &details, &progress); err != nil {
+				return err
+			}
+		}
+
 		if err = distChangefeedFlow(ctx, jobExec, jobID, details, progress, startedCh); err == nil {
 			return nil
 		}
@@ -1034,6 +1241,149 @@ func (b *changefeedResumer) resumeWithRetries(
 	return errors.Wrap(err, `ran out of retries`)
 }
 
+// refreshWatchedTables updates the targets of a changefeed on a database or
+// schema to the tables in it as of the time the changefeed resumes from. The
+// targets of tables which are still present are left untouched, new tables are
+// added, and dropped tables are removed. Changed targets are persisted in the
+// job details.
+//
+// If the database or schema has no tables, refreshWatchedTables waits for one
+// to be created, see waitForWatchedTables.
+func (b *changefeedResumer) refreshWatchedTables(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	details *jobspb.ChangefeedDetails,
+	progress *jobspb.Progress,
+) error {
+	ts := details.StatementTime
+	if h := progress.GetHighWater(); h != nil && !h.IsEmpty() {
+		ts = h.Next()
+	}
+	tables, added, err := resolveWatchedTables(ctx, execCfg, *details, ts)
+	if err != nil {
+		return err
+	}
+	if len(tables) == 0 {
+		tables, added, err = b.waitForWatchedTables(ctx, execCfg, *details, progress)
+		if err != nil {
+			return err
+		}
+	}
+
+	var targets []jobspb.ChangefeedTargetSpecification
+	for _, spec := range details.TargetSpecifications {
+		if _, ok := tables[spec.TableID]; ok {
+			targets = append(targets, spec)
+		}
+	}
+	if len(added) == 0 && len(targets) == len(details.TargetSpecifications) {
+		return nil
+	}
+	details.Tables = tables
+	details.TargetSpecifications = append(targets, added...)
+	return b.job.SetDetails(ctx, nil /* txn */, *details)
+}
+
+// waitForWatchedTables waits for a table to be created in the database or
+// schema of a changefeed which has no tables, and returns the targets as of
+// then. The flow of such a changefeed would have no spans to watch, and so
+// could not notice tables being created. While waiting, the high-water of the
+// changefeed is advanced, without emitting resolved timestamps, so that the
+// tables are scanned when they become public, like those created while the
+// flow runs.
+func (b *changefeedResumer) waitForWatchedTables(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	details jobspb.ChangefeedDetails,
+	progress *jobspb.Progress,
+) (jobspb.ChangefeedTargets, []jobspb.ChangefeedTargetSpecification, error) {
+	lastRunStatusUpdate := b.setJobRunningStatus(ctx, time.Time{}, "waiting for tables to be created")
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(changefeedbase.TableDescriptorPollInterval.Get(&execCfg.Settings.SV)):
+		}
+
+		now := execCfg.Clock.Now()
+		tables, added, err := resolveWatchedTables(ctx, execCfg, details, now)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(tables) > 0 {
+			return tables, added, nil
+		}
+
+		highWater := now
+		if err := b.job.Update(ctx, nil /* txn */, func(
+			txn *kv.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
+		) error {
+			if err := md.CheckRunningOrReverting(); err != nil {
+				return err
+			}
+			if recordID := md.Progress.GetChangefeed().ProtectedTimestampRecord; recordID != uuid.Nil {
+				if err := execCfg.ProtectedTimestampProvider.UpdateTimestamp(ctx, txn, recordID, highWater); err != nil {
+					return err
+				}
+			}
+			md.Progress.Progress = &jobspb.Progress_HighWater{HighWater: &highWater}
+			ju.UpdateProgress(md.Progress)
+			return nil
+		}); err != nil {
+			return nil, nil, err
+		}
+		progress.Progress = &jobspb.Progress_HighWater{HighWater: &highWater}
+		lastRunStatusUpdate = b.setJobRunningStatus(ctx, lastRunStatusUpdate,
+			"waiting for tables to be created: resolved=%s", highWater)
+	}
+}
+
+// resolveWatchedTables returns the targets of a changefeed on a database or
+// schema as of the given timestamp, along with the specifications of the
+// targets which are not yet in the job details.
+func resolveWatchedTables(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	details jobspb.ChangefeedDetails,
+	ts hlc.Timestamp,
+) (jobspb.ChangefeedTargets, []jobspb.ChangefeedTargetSpecification, error) {
+	_, qualified := details.Opts[changefeedbase.OptFullTableName]
+
+	var added []jobspb.ChangefeedTargetSpecification
+	tables := make(jobspb.ChangefeedTargets)
+	resolve := func(ctx context.Context, txn *kv.Txn, descriptors *descs.Collection) error {
+		added, tables = nil, make(jobspb.ChangefeedTargets)
+		if err := txn.SetFixedTimestamp(ctx, ts); err != nil {
+			return err
+		}
+		watched, err := getWatchedTables(ctx, txn, descriptors, details.DatabaseID, details.SchemaID)
+		if err != nil {
+			return err
+		}
+		for _, table := range watched {
+			if t, ok := details.Tables[table.GetID()]; ok {
+				tables[table.GetID()] = t
+				continue
+			}
+			name, err := getChangefeedTargetName(ctx, table, execCfg, txn, qualified)
+			if err != nil {
+				return err
+			}
+			tables[table.GetID()] = jobspb.ChangefeedTargetTable{StatementTimeName: name}
+			spec := changefeedbase.WatchedTableTarget(table, name)
+			if err := changefeedbase.ValidateTable([]jobspb.ChangefeedTargetSpecification{spec}, table, details.Opts); err != nil {
+				return err
+			}
+			added = append(added, spec)
+		}
+		return nil
+	}
+	if err := sql.DescsTxn(ctx, execCfg, resolve); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to resolve the tables watched by the changefeed")
+	}
+	return tables, added, nil
+}
+
 // OnFailOrCancel is part of the jobs.Resumer interface.
 func (b *changefeedResumer) OnFailOrCancel(ctx context.Context, jobExec interface{}) error {
 	exec := jobExec.(sql.JobExecContext)
@@ -1128,12 +1478,25 @@ func getQualifiedTableNameObj(
 	ctx context.Context, execCfg *sql.ExecutorConfig, txn *kv.Txn, desc catalog.TableDescriptor,
 ) (tree.TableName, error) {
 	col := execCfg.CollectionFactory.MakeCollection(ctx, nil /* TemporarySchemaProvider */, nil /* monitor */)
+	return qualifiedTableNameObj(ctx, execCfg.Codec, col, txn, desc)
+}
+
+// qualifiedTableNameObj is like getQualifiedTableNameObj, for callers such as
+// the processors of a changefeed which have a descs.Collection rather than an
+// ExecutorConfig.
+func qualifiedTableNameObj(
+	ctx context.Context,
+	codec keys.SQLCodec,
+	col *descs.Collection,
+	txn *kv.Txn,
+	desc catalog.TableDescriptor,
+) (tree.TableName, error) {
 	dbDesc, err := col.Direct().MustGetDatabaseDescByID(ctx, txn, desc.GetParentID())
 	if err != nil {
 		return tree.TableName{}, err
 	}
 	schemaID := desc.GetParentSchemaID()
-	schemaName, err := resolver.ResolveSchemaNameByID(ctx, txn, execCfg.Codec, dbDesc, schemaID)
+	schemaName, err := resolver.ResolveSchemaNameByID(ctx, txn, codec, dbDesc, schemaID)
 	if err != nil {
 		return tree.TableName{}, err
 	}
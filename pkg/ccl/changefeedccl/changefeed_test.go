@@ -17,6 +17,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strconv"
@@ -356,6 +357,173 @@ func TestChangefeedDiff(t *testing.T) {
 	t.Run(`pubsub`, pubsubTest(testFn))
 }
 
+func TestChangefeedSelect(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(db)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING, c INT)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (0, 'zero', 0), (1, 'one', 10)`)
+
+		foo := feed(t, f, `CREATE CHANGEFEED AS SELECT a, c * 2 AS c2 FROM foo WHERE c > 5`)
+		defer closeFeed(t, foo)
+
+		assertPayloads(t, foo, []string{
+			`foo: [1]->{"after": {"a": 1, "c2": 20}}`,
+		})
+
+		// Rows that do not match the predicate are never emitted.
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (2, 'two', 1), (3, 'three', 30)`)
+		sqlDB.Exec(t, `UPDATE foo SET b = 'uno' WHERE a = 1`)
+		assertPayloads(t, foo, []string{
+			`foo: [3]->{"after": {"a": 3, "c2": 60}}`,
+			`foo: [1]->{"after": {"a": 1, "c2": 20}}`,
+		})
+	}
+
+	t.Run(`sinkless`, sinklessTest(testFn))
+	t.Run(`enterprise`, enterpriseTest(testFn))
+	t.Run(`kafka`, kafkaTest(testFn))
+}
+
+func TestChangefeedSelectWithDiff(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(db)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, status STRING)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (0, 'open'), (1, 'open')`)
+
+		foo := feed(t, f, `CREATE CHANGEFEED WITH diff, no_initial_scan AS `+
+			`SELECT a, status FROM foo WHERE cdc_prev.status IS DISTINCT FROM status`)
+		defer closeFeed(t, foo)
+
+		// Only the changes to the status column are emitted.
+		sqlDB.Exec(t, `UPDATE foo SET status = 'open' WHERE a = 0`)
+		sqlDB.Exec(t, `UPDATE foo SET status = 'closed' WHERE a = 1`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (2, 'open')`)
+		assertPayloads(t, foo, []string{
+			`foo: [1]->{"after": {"a": 1, "status": "closed"}, "before": {"a": 1, "status": "open"}}`,
+			`foo: [2]->{"after": {"a": 2, "status": "open"}, "before": null}`,
+		})
+	}
+
+	t.Run(`sinkless`, sinklessTest(testFn))
+	t.Run(`enterprise`, enterpriseTest(testFn))
+	t.Run(`kafka`, kafkaTest(testFn))
+}
+
+func TestChangefeedSelectErrors(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	_, db, cleanup := startTestServer(t, feedTestOptions{})
+	defer cleanup()
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING, v INT AS (a + 1) VIRTUAL)`)
+	sqlDB.Exec(t, `CREATE TABLE fams (a INT PRIMARY KEY, b STRING, FAMILY (a), FAMILY (b))`)
+
+	for _, tc := range []struct {
+		stmt string
+		err  string
+	}{
+		{`CREATE CHANGEFEED AS SELECT nope FROM foo`, `column "nope" does not exist`},
+		{`CREATE CHANGEFEED AS SELECT v FROM foo`, `cannot reference virtual computed column "v"`},
+		{`CREATE CHANGEFEED AS SELECT a FROM foo WHERE b`, `argument of WHERE must be type bool`},
+		{`CREATE CHANGEFEED AS SELECT now() FROM foo`, `context-dependent operators are not allowed`},
+		{`CREATE CHANGEFEED AS SELECT random() FROM foo`, `volatile functions are not allowed`},
+		{`CREATE CHANGEFEED AS SELECT cdc_prev.a FROM foo`, `cdc_prev is only usable with diff`},
+		{`CREATE CHANGEFEED WITH format = avro AS SELECT a FROM foo`, `format=avro is not supported`},
+		{`CREATE CHANGEFEED AS SELECT a FROM fams`, `multiple column families`},
+	} {
+		sqlDB.ExpectErr(t, tc.err, tc.stmt)
+	}
+}
+
+func TestChangefeedCSVRoundTripsThroughImport(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	dir, dirCleanupFn := testutils.TempDir(t)
+	defer dirCleanupFn()
+
+	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(db)
+		const cols = `a INT PRIMARY KEY, b STRING, c DECIMAL, d TIMESTAMPTZ, e INT[], v INT AS (a + 1) VIRTUAL`
+		sqlDB.Exec(t, `CREATE TABLE foo (`+cols+`)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES
+			(1, 'one', 1.5, '2022-01-02 03:04:05+00', ARRAY[1, 2]),
+			(2, 'a "quoted", string', NULL, NULL, ARRAY[]::INT[])`)
+
+		var jobID jobspb.JobID
+		sqlDB.QueryRow(t, `CREATE CHANGEFEED FOR foo INTO 'nodelocal://0/csv' `+
+			`WITH format = csv, initial_scan = 'only'`).Scan(&jobID)
+		waitForJobStatus(sqlDB, t, jobID, `succeeded`)
+
+		paths, err := filepath.Glob(filepath.Join(dir, `csv`, `*`, `*.csv`))
+		require.NoError(t, err)
+		require.NotEmpty(t, paths)
+
+		sqlDB.Exec(t, `CREATE TABLE foo_imported (`+cols+`)`)
+		for _, path := range paths {
+			rel, err := filepath.Rel(dir, path)
+			require.NoError(t, err)
+			sqlDB.Exec(t, `IMPORT INTO foo_imported (a, b, c, d, e) CSV DATA ($1) WITH nullif = ''`,
+				`nodelocal://0/`+filepath.ToSlash(rel))
+		}
+		sqlDB.CheckQueryResults(t, `SELECT * FROM foo_imported ORDER BY a`,
+			sqlDB.QueryStr(t, `SELECT * FROM foo ORDER BY a`))
+	}
+
+	t.Run(`cloudstorage`, cloudStorageTestWithOptions(testFn, feedTestOptions{externalIODir: dir}))
+}
+
+func TestChangefeedParquetRoundTripsThroughImport(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	dir, dirCleanupFn := testutils.TempDir(t)
+	defer dirCleanupFn()
+
+	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(db)
+		const cols = `a INT PRIMARY KEY, b STRING, c DECIMAL, d TIMESTAMPTZ, e INT[], v INT AS (a + 1) VIRTUAL`
+		sqlDB.Exec(t, `CREATE TABLE foo (`+cols+`)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES
+			(1, 'one', 1.5, '2022-01-02 03:04:05+00', ARRAY[1, 2]),
+			(2, 'two', NULL, NULL, ARRAY[]::INT[])`)
+
+		var jobID jobspb.JobID
+		sqlDB.QueryRow(t, `CREATE CHANGEFEED FOR foo INTO 'nodelocal://0/parquet' `+
+			`WITH format = parquet, initial_scan = 'only'`).Scan(&jobID)
+		waitForJobStatus(sqlDB, t, jobID, `succeeded`)
+
+		paths, err := filepath.Glob(filepath.Join(dir, `parquet`, `*`, `*.parquet`))
+		require.NoError(t, err)
+		require.NotEmpty(t, paths)
+
+		sqlDB.Exec(t, `CREATE TABLE foo_imported (`+cols+`)`)
+		for _, path := range paths {
+			rel, err := filepath.Rel(dir, path)
+			require.NoError(t, err)
+			sqlDB.Exec(t, `IMPORT INTO foo_imported (a, b, c, d, e) PARQUET DATA ($1)`,
+				`nodelocal://0/`+filepath.ToSlash(rel))
+		}
+		sqlDB.CheckQueryResults(t, `SELECT * FROM foo_imported ORDER BY a`,
+			sqlDB.QueryStr(t, `SELECT * FROM foo ORDER BY a`))
+
+		// The deleted column of the files must not collide with the columns of
+		// the table.
+		sqlDB.Exec(t, `CREATE TABLE bar (a INT PRIMARY KEY, __crdb__deleted BOOL)`)
+		sqlDB.ExpectErr(t, `cannot target a table with a column named __crdb__deleted: bar`,
+			`CREATE CHANGEFEED FOR bar INTO 'nodelocal://0/bar' WITH format = parquet`)
+	}
+
+	t.Run(`cloudstorage`, cloudStorageTestWithOptions(testFn, feedTestOptions{externalIODir: dir}))
+}
+
 func TestChangefeedTenants(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -558,6 +726,141 @@ func TestChangefeedMultiTable(t *testing.T) {
 	t.Run(`pubsub`, pubsubTest(testFn))
 }
 
+func TestChangefeedDatabaseTarget(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(db)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (1, 'a')`)
+		sqlDB.Exec(t, `CREATE SCHEMA sc`)
+		sqlDB.Exec(t, `CREATE TABLE sc.baz (a INT PRIMARY KEY)`)
+		sqlDB.Exec(t, `INSERT INTO sc.baz VALUES (1)`)
+
+		dbFeed := feed(t, f, `CREATE CHANGEFEED FOR DATABASE d`)
+		defer closeFeed(t, dbFeed)
+		scFeed := feed(t, f, `CREATE CHANGEFEED FOR SCHEMA sc`)
+		defer closeFeed(t, scFeed)
+
+		assertPayloads(t, dbFeed, []string{
+			`foo: [1]->{"after": {"a": 1, "b": "a"}}`,
+			`baz: [1]->{"after": {"a": 1}}`,
+		})
+		assertPayloads(t, scFeed, []string{
+			`baz: [1]->{"after": {"a": 1}}`,
+		})
+
+		// Tables created after the changefeed are scanned once they become
+		// public, and followed from then on.
+		sqlDB.Exec(t, `CREATE TABLE bar (a PRIMARY KEY, b) AS SELECT 2, 'b'`)
+		sqlDB.Exec(t, `CREATE TABLE sc.qux (a PRIMARY KEY) AS SELECT 3`)
+		assertPayloads(t, dbFeed, []string{
+			`bar: [2]->{"after": {"a": 2, "b": "b"}}`,
+			`qux: [3]->{"after": {"a": 3}}`,
+		})
+		assertPayloads(t, scFeed, []string{
+			`qux: [3]->{"after": {"a": 3}}`,
+		})
+
+		// Dropped tables are no longer followed, without failing the
+		// changefeed.
+		sqlDB.Exec(t, `DROP TABLE sc.baz`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (4, 'd')`)
+		sqlDB.Exec(t, `INSERT INTO sc.qux VALUES (4)`)
+		assertPayloads(t, dbFeed, []string{
+			`foo: [4]->{"after": {"a": 4, "b": "d"}}`,
+			`qux: [4]->{"after": {"a": 4}}`,
+		})
+		assertPayloads(t, scFeed, []string{
+			`qux: [4]->{"after": {"a": 4}}`,
+		})
+	}
+
+	t.Run(`enterprise`, enterpriseTest(testFn))
+	t.Run(`kafka`, kafkaTest(testFn))
+	t.Run(`webhook`, webhookTest(testFn))
+	t.Run(`pubsub`, pubsubTest(testFn))
+}
+
+func TestChangefeedDatabaseTargetWithoutTables(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(db)
+		sqlDB.Exec(t, `CREATE SCHEMA sc`)
+
+		// Changefeeds on databases and schemas without tables wait for tables
+		// to be created.
+		dbFeed := feed(t, f, `CREATE CHANGEFEED FOR DATABASE d`)
+		defer closeFeed(t, dbFeed)
+		scFeed := feed(t, f, `CREATE CHANGEFEED FOR SCHEMA sc`)
+		defer closeFeed(t, scFeed)
+
+		sqlDB.Exec(t, `CREATE TABLE sc.foo (a INT PRIMARY KEY)`)
+		sqlDB.Exec(t, `INSERT INTO sc.foo VALUES (1)`)
+		assertPayloads(t, dbFeed, []string{
+			`foo: [1]->{"after": {"a": 1}}`,
+		})
+		assertPayloads(t, scFeed, []string{
+			`foo: [1]->{"after": {"a": 1}}`,
+		})
+
+		// They keep running once all of their tables are dropped.
+		sqlDB.Exec(t, `DROP TABLE sc.foo`)
+		sqlDB.Exec(t, `CREATE TABLE sc.bar (a INT PRIMARY KEY)`)
+		sqlDB.Exec(t, `INSERT INTO sc.bar VALUES (2)`)
+		assertPayloads(t, dbFeed, []string{
+			`bar: [2]->{"after": {"a": 2}}`,
+		})
+		assertPayloads(t, scFeed, []string{
+			`bar: [2]->{"after": {"a": 2}}`,
+		})
+	}
+
+	t.Run(`enterprise`, enterpriseTest(testFn))
+	t.Run(`kafka`, kafkaTest(testFn))
+}
+
+func TestChangefeedDatabaseTargetManyTables(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const numTables = 20
+	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(db)
+		var expected []string
+		for i := 0; i < numTables/2; i++ {
+			sqlDB.Exec(t, fmt.Sprintf(`CREATE TABLE t%d (a INT PRIMARY KEY)`, i))
+			sqlDB.Exec(t, fmt.Sprintf(`INSERT INTO t%d VALUES (%d)`, i, i))
+			expected = append(expected, fmt.Sprintf(`t%d: [%d]->{"after": {"a": %d}}`, i, i, i))
+		}
+
+		dbFeed := feed(t, f, `CREATE CHANGEFEED FOR DATABASE d`)
+		defer closeFeed(t, dbFeed)
+		assertPayloads(t, dbFeed, expected)
+
+		// Each created table is added to the running changefeed from the time
+		// it was created, so the changes to the tables it already follows are
+		// neither lost nor emitted again.
+		expected = expected[:0]
+		for i := numTables / 2; i < numTables; i++ {
+			sqlDB.Exec(t, fmt.Sprintf(`CREATE TABLE t%d (a INT PRIMARY KEY)`, i))
+			sqlDB.Exec(t, fmt.Sprintf(`INSERT INTO t%d VALUES (%d)`, i, i))
+			sqlDB.Exec(t, fmt.Sprintf(`INSERT INTO t0 VALUES (%d)`, i))
+			expected = append(expected,
+				fmt.Sprintf(`t%d: [%d]->{"after": {"a": %d}}`, i, i, i),
+				fmt.Sprintf(`t0: [%d]->{"after": {"a": %d}}`, i, i),
+			)
+		}
+		assertPayloads(t, dbFeed, expected)
+	}
+
+	t.Run(`enterprise`, enterpriseTest(testFn))
+	t.Run(`kafka`, kafkaTest(testFn))
+}
+
 func TestChangefeedCursor(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -1744,7 +2047,7 @@ func fetchDescVersionModificationTime(
 			if err := value.GetProto(&desc); err != nil {
 				t.Fatal(err)
 			}
-			if tableDesc, _, _, _ := descpb.FromDescriptorWithMVCCTimestamp(&desc, k.Timestamp); tableDesc != nil {
+			if tableDesc, _, _, _, _ := descpb.FromDescriptorWithMVCCTimestamp(&desc, k.Timestamp); tableDesc != nil {
 				if int(tableDesc.Version) == version {
 					return tableDesc.ModificationTime
 				}
@@ -3930,6 +4233,22 @@ func TestChangefeedErrors(t *testing.T) {
 		`webhook-https://fake-host?client_key=Zm9v`,
 	)
 
+	// Changefeeds on a database or schema require a job, follow schema
+	// changes, and need at least one table to start with.
+	sqlDB.ExpectErr(
+		t, `CREATE CHANGEFEED FOR DATABASE or FOR SCHEMA requires a sink`,
+		`CREATE CHANGEFEED FOR DATABASE defaultdb`,
+	)
+	sqlDB.ExpectErr(
+		t, `CREATE CHANGEFEED FOR DATABASE or FOR SCHEMA is incompatible with schema_change_policy=ignore`,
+		`CREATE CHANGEFEED FOR DATABASE defaultdb INTO $1 WITH schema_change_policy='ignore'`,
+		`kafka://nope`,
+	)
+	sqlDB.ExpectErr(
+		t, `CHANGEFEED cannot target SCHEMA defaultdb.pg_catalog`,
+		`CREATE CHANGEFEED FOR SCHEMA defaultdb.pg_catalog INTO $1`, `kafka://nope`,
+	)
+
 	// Sanity check on_error option
 	sqlDB.ExpectErr(
 		t, `option "on_error" requires a value`,
@@ -11,9 +11,14 @@ package changefeedbase
 import (
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/errors"
 )
 
+// ParquetDeletedColumnName is the name of the column of the parquet files
+// written by changefeeds that is true for the rows that were deleted.
+const ParquetDeletedColumnName = `__crdb__deleted`
+
 // ValidateTable validates that a table descriptor can be watched by a CHANGEFEED.
 func ValidateTable(
 	targets []jobspb.ChangefeedTargetSpecification,
@@ -74,6 +79,18 @@ func ValidateTable(
 			}
 		}
 
+		if opts[OptFormat] == string(OptFormatParquet) {
+			// Parquet files have an additional column for whether the row was
+			// deleted, which must not collide with the columns of the table.
+			for _, col := range tableDesc.PublicColumns() {
+				if col.GetName() == ParquetDeletedColumnName {
+					return errors.Errorf(
+						`CHANGEFEED with format=%s cannot target a table with a column named %s: %s`,
+						OptFormatParquet, ParquetDeletedColumnName, tableDesc.GetName())
+				}
+			}
+		}
+
 		if tableDesc.Dropped() {
 			return errors.Errorf(`"%s" was dropped`, t.StatementTimeName)
 		}
@@ -89,6 +106,39 @@ func ValidateTable(
 	return nil
 }
 
+// WatchesTable returns true if the table belongs to the database with ID
+// databaseID and, if schemaID is non-zero, to the schema with ID schemaID, and
+// is targeted by a changefeed created with CREATE CHANGEFEED FOR DATABASE or
+// FOR SCHEMA on that database or schema whenever the table is public. It
+// returns false for every table if databaseID is zero.
+func WatchesTable(databaseID, schemaID descpb.ID, tableDesc catalog.TableDescriptor) bool {
+	if databaseID == descpb.InvalidID || tableDesc.GetParentID() != databaseID {
+		return false
+	}
+	if schemaID != descpb.InvalidID && tableDesc.GetParentSchemaID() != schemaID {
+		return false
+	}
+	// Views, sequences and temporary tables are never targeted.
+	return tableDesc.IsTable() && !tableDesc.IsTemporary()
+}
+
+// WatchedTableTarget returns the target specification of a table watched by a
+// changefeed created with CREATE CHANGEFEED FOR DATABASE or FOR SCHEMA, which
+// is named name in the messages of the changefeed.
+func WatchedTableTarget(
+	tableDesc catalog.TableDescriptor, name string,
+) jobspb.ChangefeedTargetSpecification {
+	typ := jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY
+	if tableDesc.NumFamilies() > 1 {
+		typ = jobspb.ChangefeedTargetSpecification_EACH_FAMILY
+	}
+	return jobspb.ChangefeedTargetSpecification{
+		Type:              typ,
+		TableID:           tableDesc.GetID(),
+		StatementTimeName: name,
+	}
+}
+
 // WarningsForTable returns any known nonfatal issues with running a changefeed on this kind of table.
 func WarningsForTable(
 	targets jobspb.ChangefeedTargets, tableDesc catalog.TableDescriptor, opts map[string]string,
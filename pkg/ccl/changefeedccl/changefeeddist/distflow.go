@@ -81,11 +81,13 @@ func StartDistChangefeed(
 		}
 
 		aggregatorSpecs[i] = &execinfrapb.ChangeAggregatorSpec{
-			Watches:    watches,
-			Checkpoint: aggregatorCheckpoint,
-			Feed:       details,
-			UserProto:  execCtx.User().EncodeProto(),
-			JobID:      jobID,
+			Watches:         watches,
+			Checkpoint:      aggregatorCheckpoint,
+			Feed:            details,
+			UserProto:       execCtx.User().EncodeProto(),
+			JobID:           jobID,
+			AggregatorIndex: int32(i),
+			NumAggregators:  int32(len(spanPartitions)),
 		}
 	}
 
@@ -66,6 +66,15 @@ type encodeRow struct {
 	prevTableDesc catalog.TableDescriptor
 	// prevFamilyID indicates which column family is populated in prevDatums.
 	prevFamilyID descpb.FamilyID
+	// projection holds the columns selected by the query of a changefeed
+	// created with CREATE CHANGEFEED AS SELECT, which are encoded in place of
+	// the columns of `datums`. It is nil for other changefeeds and for
+	// deletions.
+	projection *projectedRow
+	// prevProjection holds the columns selected by the query for `prevDatums`.
+	// It is nil for other changefeeds and if `prevDatums` is not set or is a
+	// deletion.
+	prevProjection *projectedRow
 	// topic is set to the string to be included if TopicInValue is true
 	topic string
 }
@@ -89,6 +98,13 @@ type Encoder interface {
 	EncodeResolvedTimestamp(context.Context, string, hlc.Timestamp) ([]byte, error)
 }
 
+// targetAdder is implemented by the encoders which depend on the targets of the
+// changefeed. Tables are added to the targets of a changefeed created with
+// CREATE CHANGEFEED FOR DATABASE or FOR SCHEMA as they are created.
+type targetAdder interface {
+	addTarget(jobspb.ChangefeedTargetSpecification)
+}
+
 func getEncoder(
 	opts map[string]string, targets []jobspb.ChangefeedTargetSpecification,
 ) (Encoder, error) {
@@ -97,6 +113,10 @@ func getEncoder(
 		return makeJSONEncoder(opts, targets)
 	case changefeedbase.OptFormatAvro, changefeedbase.DeprecatedOptFormatAvro:
 		return newConfluentAvroEncoder(opts, targets)
+	case changefeedbase.OptFormatCSV:
+		return newCSVEncoder(opts)
+	case changefeedbase.OptFormatParquet:
+		return newParquetEncoder(opts)
 	case changefeedbase.OptFormatNative:
 		return &nativeEncoder{}, nil
 	default:
@@ -104,6 +124,35 @@ func getEncoder(
 	}
 }
 
+// validateFileFormatOpts returns an error if the options are not usable with
+// the given file format. The csv and parquet formats write files with a column
+// for each column of the table, so they only support the row envelope and
+// cannot carry the metadata added by other options.
+func validateFileFormatOpts(format changefeedbase.FormatType, opts map[string]string) error {
+	if envelope := changefeedbase.EnvelopeType(opts[changefeedbase.OptEnvelope]); envelope != changefeedbase.OptEnvelopeRow {
+		return errors.Errorf(`%s=%s is only usable with %s=%s`,
+			changefeedbase.OptFormat, format, changefeedbase.OptEnvelope, changefeedbase.OptEnvelopeRow)
+	}
+	for _, opt := range []string{
+		changefeedbase.OptDiff,
+		changefeedbase.OptUpdatedTimestamps,
+		changefeedbase.OptMVCCTimestamps,
+		changefeedbase.OptTopicInValue,
+	} {
+		if _, ok := opts[opt]; ok {
+			return errors.Errorf(`%s is not supported with %s=%s`, opt, changefeedbase.OptFormat, format)
+		}
+	}
+	return nil
+}
+
+// isFileFormatColumn returns whether the column is written by the csv and
+// parquet formats. Virtual columns are not decoded by changefeeds, so they are
+// omitted.
+func isFileFormatColumn(col catalog.Column) bool {
+	return !col.IsVirtual()
+}
+
 // jsonEncoder encodes changefeed entries as JSON. Keys are the primary key
 // columns in a JSON array. Values are a JSON object mapping every column name
 // to its value. Updated timestamps in rows and resolved timestamp payloads are
@@ -210,7 +259,12 @@ func (e *jsonEncoder) EncodeValue(_ context.Context, row encodeRow) ([]byte, err
 	}
 
 	var after map[string]interface{}
-	if !row.deleted {
+	if row.projection != nil {
+		var err error
+		if after, err = row.projection.asJSONMap(); err != nil {
+			return nil, err
+		}
+	} else if !row.deleted {
 		family, err := row.tableDesc.FindFamilyByID(row.familyID)
 		if err != nil {
 			return nil, err
@@ -242,7 +296,12 @@ func (e *jsonEncoder) EncodeValue(_ context.Context, row encodeRow) ([]byte, err
 	}
 
 	var before map[string]interface{}
-	if row.prevDatums != nil && !row.prevDeleted {
+	if row.prevProjection != nil {
+		var err error
+		if before, err = row.prevProjection.asJSONMap(); err != nil {
+			return nil, err
+		}
+	} else if row.prevDatums != nil && !row.prevDeleted {
 		family, err := row.prevTableDesc.FindFamilyByID(row.prevFamilyID)
 		if err != nil {
 			return nil, err
@@ -330,9 +389,7 @@ func (e *jsonEncoder) EncodeValue(_ context.Context, row encodeRow) ([]byte, err
 func (e *jsonEncoder) EncodeResolvedTimestamp(
 	_ context.Context, _ string, resolved hlc.Timestamp,
 ) ([]byte, error) {
-	meta := map[string]interface{}{
-		`resolved`: tree.TimestampToDecimalDatum(resolved).Decimal.String(),
-	}
+	meta := resolvedTimestampJSON(resolved)
 	var jsonEntries interface{}
 	if e.wrapped {
 		jsonEntries = meta
@@ -344,6 +401,14 @@ func (e *jsonEncoder) EncodeResolvedTimestamp(
 	return gojson.Marshal(jsonEntries)
 }
 
+// resolvedTimestampJSON returns the JSON object of a resolved timestamp
+// payload.
+func resolvedTimestampJSON(resolved hlc.Timestamp) map[string]interface{} {
+	return map[string]interface{}{
+		`resolved`: tree.TimestampToDecimalDatum(resolved).Decimal.String(),
+	}
+}
+
 // getTableColMap gets the TableColMap for the provided table descriptor,
 // optionally consulting its cache.
 func (e *jsonEncoder) getTableColMap(desc catalog.TableDescriptor) catalog.TableColMap {
@@ -403,6 +468,7 @@ type confluentRegisteredEnvelopeSchema struct {
 }
 
 var _ Encoder = &confluentAvroEncoder{}
+var _ targetAdder = &confluentAvroEncoder{}
 
 var encoderCacheConfig = cache.Config{
 	Policy: cache.CacheFIFO,
@@ -465,6 +531,11 @@ func newConfluentAvroEncoder(
 	return e, nil
 }
 
+// addTarget implements the targetAdder interface.
+func (e *confluentAvroEncoder) addTarget(target jobspb.ChangefeedTargetSpecification) {
+	e.targets = append(e.targets, target)
+}
+
 // Get the raw SQL-formatted string for a table name
 // and apply full_table_name and avro_schema_prefix options
 func (e *confluentAvroEncoder) rawTableName(
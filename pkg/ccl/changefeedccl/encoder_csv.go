@@ -0,0 +1,115 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	gojson "encoding/json"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+)
+
+// csvEncoder encodes changefeed rows as CSV records with a field for each
+// non-virtual column of the table, formatted like EXPORT does, so that the
+// files of a cloud storage sink can be loaded with IMPORT. NULL values are
+// encoded as empty fields, which IMPORT reads back with `nullif = ''`.
+//
+// A CSV file has no way to express that a row was deleted, so the format is
+// only usable by changefeeds that only perform an initial scan.
+type csvEncoder struct {
+	alloc  tree.DatumAlloc
+	buf    bytes.Buffer
+	writer *csv.Writer
+	fmtCtx *tree.FmtCtx
+	record []string
+}
+
+var _ Encoder = &csvEncoder{}
+
+func newCSVEncoder(opts map[string]string) (*csvEncoder, error) {
+	if err := validateFileFormatOpts(changefeedbase.OptFormatCSV, opts); err != nil {
+		return nil, err
+	}
+	initialScanType, err := initialScanTypeFromOpts(opts)
+	if err != nil {
+		return nil, err
+	}
+	if initialScanType != changefeedbase.OnlyInitialScan {
+		return nil, errors.Errorf(`%s=%s is only usable with %s='only'`,
+			changefeedbase.OptFormat, changefeedbase.OptFormatCSV, changefeedbase.OptInitialScan)
+	}
+	e := &csvEncoder{fmtCtx: tree.NewFmtCtx(tree.FmtExport)}
+	e.writer = csv.NewWriter(&e.buf)
+	return e, nil
+}
+
+// EncodeKey implements the Encoder interface. The key is a CSV record of the
+// primary key columns of the row.
+func (e *csvEncoder) EncodeKey(_ context.Context, row encodeRow) ([]byte, error) {
+	keyCols := row.tableDesc.GetPrimaryIndex().CollectKeyColumnIDs()
+	return e.encode(row, func(col catalog.Column) bool {
+		return keyCols.Contains(col.GetID())
+	})
+}
+
+// EncodeValue implements the Encoder interface.
+func (e *csvEncoder) EncodeValue(_ context.Context, row encodeRow) ([]byte, error) {
+	if row.deleted {
+		return nil, errors.Errorf(`%s=%s cannot encode deleted rows`,
+			changefeedbase.OptFormat, changefeedbase.OptFormatCSV)
+	}
+	return e.encode(row, isFileFormatColumn)
+}
+
+// encode returns a CSV record of the columns of the row that pass the filter,
+// without the record terminator, which is added by the sink.
+func (e *csvEncoder) encode(row encodeRow, filter func(catalog.Column) bool) ([]byte, error) {
+	e.record = e.record[:0]
+	for i, col := range row.tableDesc.PublicColumns() {
+		if !filter(col) {
+			continue
+		}
+		datum := row.datums[i]
+		if err := datum.EnsureDecoded(col.GetType(), &e.alloc); err != nil {
+			return nil, err
+		}
+		if datum.Datum == tree.DNull {
+			e.record = append(e.record, ``)
+			continue
+		}
+		datum.Datum.Format(e.fmtCtx)
+		e.record = append(e.record, e.fmtCtx.String())
+		e.fmtCtx.Reset()
+	}
+
+	e.buf.Reset()
+	if err := e.writer.Write(e.record); err != nil {
+		return nil, err
+	}
+	e.writer.Flush()
+	if err := e.writer.Error(); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(e.buf.Bytes(), []byte{'\n'}), nil
+}
+
+// EncodeResolvedTimestamp implements the Encoder interface. Resolved
+// timestamps are written to their own files, so they use the same JSON
+// payload as the wrapped JSON format.
+func (e *csvEncoder) EncodeResolvedTimestamp(
+	_ context.Context, _ string, resolved hlc.Timestamp,
+) ([]byte, error) {
+	return gojson.Marshal(resolvedTimestampJSON(resolved))
+}
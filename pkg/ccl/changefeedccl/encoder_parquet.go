@@ -0,0 +1,184 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+	gojson "encoding/json"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/importer"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+)
+
+// parquetEncoder encodes changefeed rows for parquet files. The rows of a
+// parquet file are not independently encoded, so the encoder only value
+// encodes the datums of the non-virtual columns of each row, followed by
+// whether it was deleted, and the cloud storage sink writes them to a parquet
+// file for each table version with a parquetFileWriter.
+//
+// The files can be loaded with IMPORT INTO ... PARQUET DATA, which ignores the
+// __crdb__deleted column, so only the files of changefeeds without deletions
+// and with a single version of each row (e.g. initial_scan = 'only') round-trip
+// through IMPORT.
+type parquetEncoder struct {
+	alloc tree.DatumAlloc
+	buf   []byte
+}
+
+var _ Encoder = &parquetEncoder{}
+
+func newParquetEncoder(opts map[string]string) (*parquetEncoder, error) {
+	if err := validateFileFormatOpts(changefeedbase.OptFormatParquet, opts); err != nil {
+		return nil, err
+	}
+	return &parquetEncoder{}, nil
+}
+
+// EncodeKey implements the Encoder interface. The key holds the value encoded
+// datums of the primary key columns of the row.
+func (e *parquetEncoder) EncodeKey(_ context.Context, row encodeRow) ([]byte, error) {
+	keyCols := row.tableDesc.GetPrimaryIndex().CollectKeyColumnIDs()
+	e.buf = e.buf[:0]
+	for i, col := range row.tableDesc.PublicColumns() {
+		if !keyCols.Contains(col.GetID()) {
+			continue
+		}
+		if err := e.appendDatum(row, i, col); err != nil {
+			return nil, err
+		}
+	}
+	return e.buf, nil
+}
+
+// EncodeValue implements the Encoder interface.
+func (e *parquetEncoder) EncodeValue(_ context.Context, row encodeRow) ([]byte, error) {
+	// Only the primary key columns are set for deleted rows.
+	var keyCols catalog.TableColSet
+	if row.deleted {
+		keyCols = row.tableDesc.GetPrimaryIndex().CollectKeyColumnIDs()
+	}
+	e.buf = e.buf[:0]
+	for i, col := range row.tableDesc.PublicColumns() {
+		if !isFileFormatColumn(col) {
+			continue
+		}
+		if row.deleted && !keyCols.Contains(col.GetID()) {
+			e.buf = append(e.buf, encodedNullValue...)
+			continue
+		}
+		if err := e.appendDatum(row, i, col); err != nil {
+			return nil, err
+		}
+	}
+	var err error
+	e.buf, err = valueside.Encode(e.buf, valueside.NoColumnID, tree.MakeDBool(tree.DBool(row.deleted)), nil)
+	return e.buf, err
+}
+
+func (e *parquetEncoder) appendDatum(row encodeRow, ord int, col catalog.Column) error {
+	datum := row.datums[ord]
+	if err := datum.EnsureDecoded(col.GetType(), &e.alloc); err != nil {
+		return err
+	}
+	var err error
+	e.buf, err = valueside.Encode(e.buf, valueside.NoColumnID, datum.Datum, nil /* scratch */)
+	return err
+}
+
+// EncodeResolvedTimestamp implements the Encoder interface. Resolved
+// timestamps are written to their own files, so they use the same JSON
+// payload as the wrapped JSON format.
+func (e *parquetEncoder) EncodeResolvedTimestamp(
+	_ context.Context, _ string, resolved hlc.Timestamp,
+) ([]byte, error) {
+	return gojson.Marshal(resolvedTimestampJSON(resolved))
+}
+
+var encodedNullValue, _ = valueside.Encode(nil, valueside.NoColumnID, tree.DNull, nil)
+
+// parquetFileWriter writes the rows encoded by a parquetEncoder for a single
+// version of a table to a parquet file. The file has a column for each
+// non-virtual column of the table, followed by the __crdb__deleted column.
+type parquetFileWriter struct {
+	writer *importer.ParquetWriter
+	typs   []*types.T
+	datums tree.Datums
+	alloc  tree.DatumAlloc
+}
+
+func newParquetFileWriter(
+	topic TopicDescriptor, compression roachpb.IOFileFormat_Compression,
+) (*parquetFileWriter, error) {
+	desc, err := topicTableDescriptor(topic)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	var typs []*types.T
+	for _, col := range desc.PublicColumns() {
+		if isFileFormatColumn(col) {
+			names = append(names, col.GetName())
+			typs = append(typs, col.GetType())
+		}
+	}
+	names = append(names, changefeedbase.ParquetDeletedColumnName)
+	typs = append(typs, types.Bool)
+
+	writer, err := importer.NewParquetWriter(names, typs, compression)
+	if err != nil {
+		return nil, errors.Wrapf(err, `table %s`, desc.GetName())
+	}
+	return &parquetFileWriter{
+		writer: writer,
+		typs:   typs,
+		datums: make(tree.Datums, len(typs)),
+	}, nil
+}
+
+// write decodes a value encoded by a parquetEncoder and appends it to the
+// file.
+func (w *parquetFileWriter) write(value []byte) error {
+	for i, typ := range w.typs {
+		var err error
+		if w.datums[i], value, err = valueside.Decode(&w.alloc, typ, value); err != nil {
+			return err
+		}
+	}
+	if len(value) != 0 {
+		return errors.AssertionFailedf(`%d unexpected trailing bytes in parquet row`, len(value))
+	}
+	return w.writer.AddRow(w.datums)
+}
+
+// close finishes the file and returns its contents.
+func (w *parquetFileWriter) close() ([]byte, error) {
+	if err := w.writer.Close(); err != nil {
+		return nil, err
+	}
+	return w.writer.Bytes(), nil
+}
+
+// topicTableDescriptor returns the descriptor of the table of the topic.
+func topicTableDescriptor(topic TopicDescriptor) (catalog.TableDescriptor, error) {
+	switch t := topic.(type) {
+	case *tableDescriptorTopic:
+		return t.tableDesc, nil
+	case *columnFamilyTopic:
+		return t.tableDesc, nil
+	default:
+		return nil, errors.AssertionFailedf(`unexpected topic %T`, topic)
+	}
+}
@@ -9,9 +9,11 @@
 package changefeedccl
 
 import (
+	"bytes"
 	"context"
 	gosql "database/sql"
 	"fmt"
+	"io"
 	"net/url"
 	"testing"
 
@@ -19,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdctest"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
@@ -28,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/workload/ledger"
 	"github.com/cockroachdb/cockroach/pkg/workload/workloadsql"
+	goparquet "github.com/fraugster/parquet-go"
 	"github.com/stretchr/testify/require"
 )
 
@@ -259,6 +263,167 @@ func TestEncoders(t *testing.T) {
 	}
 }
 
+func TestCSVEncoder(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	tableDesc, err := parseTableDesc(
+		`CREATE TABLE foo (a INT PRIMARY KEY, b STRING, c INT, v INT AS (a + 1) VIRTUAL)`)
+	require.NoError(t, err)
+	baseOpts := map[string]string{
+		changefeedbase.OptFormat:      string(changefeedbase.OptFormatCSV),
+		changefeedbase.OptEnvelope:    string(changefeedbase.OptEnvelopeRow),
+		changefeedbase.OptInitialScan: `only`,
+	}
+	withOpt := func(k, v string) map[string]string {
+		opts := map[string]string{k: v}
+		for k, v := range baseOpts {
+			if _, ok := opts[k]; !ok {
+				opts[k] = v
+			}
+		}
+		return opts
+	}
+
+	for _, tc := range []struct {
+		opts map[string]string
+		err  string
+	}{
+		{withOpt(changefeedbase.OptEnvelope, string(changefeedbase.OptEnvelopeWrapped)),
+			`format=csv is only usable with envelope=row`},
+		{withOpt(changefeedbase.OptDiff, ``), `diff is not supported with format=csv`},
+		{withOpt(changefeedbase.OptInitialScan, `yes`), `format=csv is only usable with initial_scan='only'`},
+	} {
+		_, err := getEncoder(tc.opts, nil)
+		require.EqualError(t, err, tc.err)
+	}
+
+	e, err := getEncoder(baseOpts, nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+	for _, tc := range []struct {
+		row      rowenc.EncDatumRow
+		key, val string
+	}{
+		{
+			row: rowenc.EncDatumRow{
+				rowenc.EncDatum{Datum: tree.NewDInt(1)},
+				rowenc.EncDatum{Datum: tree.NewDString(`bar`)},
+				rowenc.EncDatum{Datum: tree.NewDInt(2)},
+				rowenc.EncDatum{Datum: tree.DNull},
+			},
+			key: `1`,
+			val: `1,bar,2`,
+		},
+		{
+			row: rowenc.EncDatumRow{
+				rowenc.EncDatum{Datum: tree.NewDInt(2)},
+				rowenc.EncDatum{Datum: tree.NewDString(`a "b", c`)},
+				rowenc.EncDatum{Datum: tree.DNull},
+				rowenc.EncDatum{Datum: tree.DNull},
+			},
+			key: `2`,
+			val: `2,"a ""b"", c",`,
+		},
+	} {
+		row := encodeRow{datums: tc.row, tableDesc: tableDesc}
+		key, err := e.EncodeKey(ctx, row)
+		require.NoError(t, err)
+		require.Equal(t, tc.key, string(key))
+		val, err := e.EncodeValue(ctx, row)
+		require.NoError(t, err)
+		require.Equal(t, tc.val, string(val))
+	}
+}
+
+func TestParquetEncoder(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	tableDesc, err := parseTableDesc(`CREATE TABLE foo (a INT PRIMARY KEY, b STRING, c BOOL)`)
+	require.NoError(t, err)
+	opts := map[string]string{
+		changefeedbase.OptFormat:   string(changefeedbase.OptFormatParquet),
+		changefeedbase.OptEnvelope: string(changefeedbase.OptEnvelopeRow),
+	}
+	_, err = getEncoder(map[string]string{
+		changefeedbase.OptFormat:   string(changefeedbase.OptFormatParquet),
+		changefeedbase.OptEnvelope: string(changefeedbase.OptEnvelopeWrapped),
+	}, nil)
+	require.EqualError(t, err, `format=parquet is only usable with envelope=row`)
+
+	e, err := getEncoder(opts, nil)
+	require.NoError(t, err)
+	w, err := newParquetFileWriter(&tableDescriptorTopic{tableDesc: tableDesc}, roachpb.IOFileFormat_Auto)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for _, row := range []encodeRow{
+		{
+			datums: rowenc.EncDatumRow{
+				rowenc.EncDatum{Datum: tree.NewDInt(1)},
+				rowenc.EncDatum{Datum: tree.NewDString(`bar`)},
+				rowenc.EncDatum{Datum: tree.DBoolTrue},
+			},
+			tableDesc: tableDesc,
+		},
+		{
+			datums: rowenc.EncDatumRow{
+				rowenc.EncDatum{Datum: tree.NewDInt(2)},
+				rowenc.EncDatum{Datum: tree.DNull},
+				rowenc.EncDatum{Datum: tree.DBoolFalse},
+			},
+			tableDesc: tableDesc,
+		},
+		{
+			datums: rowenc.EncDatumRow{
+				rowenc.EncDatum{Datum: tree.NewDInt(1)},
+				rowenc.EncDatum{Datum: tree.NewDString(`bar`)},
+				rowenc.EncDatum{Datum: tree.DBoolTrue},
+			},
+			deleted:   true,
+			tableDesc: tableDesc,
+		},
+	} {
+		val, err := e.EncodeValue(ctx, row)
+		require.NoError(t, err)
+		require.NoError(t, w.write(val))
+	}
+	contents, err := w.close()
+	require.NoError(t, err)
+
+	fr, err := goparquet.NewFileReader(bytes.NewReader(contents))
+	require.NoError(t, err)
+	var cols []string
+	for _, col := range fr.GetSchemaDefinition().RootColumn.Children {
+		cols = append(cols, col.SchemaElement.Name)
+	}
+	require.Equal(t, []string{`a`, `b`, `c`, `__crdb__deleted`}, cols)
+
+	// NULL values are missing from the rows read from the file.
+	str := func(v interface{}) string {
+		if b, ok := v.([]byte); ok {
+			return string(b)
+		}
+		return fmt.Sprint(v)
+	}
+	var rows []string
+	for {
+		row, err := fr.NextRow()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		rows = append(rows, fmt.Sprintf(`%s %s %s %s`,
+			str(row[`a`]), str(row[`b`]), str(row[`c`]), str(row[`__crdb__deleted`])))
+	}
+	require.Equal(t, []string{
+		`1 bar true false`,
+		`2 <nil> false false`,
+		`1 <nil> <nil> true`,
+	}, rows)
+}
+
 func TestAvroEncoder(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -0,0 +1,459 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// cdcPrevName is the table name used by the query of a changefeed created
+// with CREATE CHANGEFEED AS SELECT to reference the previous value of the
+// changed row, e.g. cdc_prev.status. It requires the diff option.
+const cdcPrevName = `cdc_prev`
+
+// projectedRow holds the columns selected by the query of a changefeed
+// created with CREATE CHANGEFEED AS SELECT for a single row.
+type projectedRow struct {
+	names  []string
+	datums tree.Datums
+}
+
+// asJSONMap returns the projected columns as a map from column name to JSON
+// value.
+func (p *projectedRow) asJSONMap() (map[string]interface{}, error) {
+	m := make(map[string]interface{}, len(p.names))
+	for i, name := range p.names {
+		j, err := tree.AsJSON(p.datums[i], sessiondatapb.DataConversionConfig{}, time.UTC)
+		if err != nil {
+			return nil, err
+		}
+		m[name] = j
+	}
+	return m, nil
+}
+
+// parseChangefeedSelect parses the query of a changefeed created with CREATE
+// CHANGEFEED AS SELECT, as stored in ChangefeedDetails.Select.
+func parseChangefeedSelect(query string) (*tree.SelectClause, error) {
+	stmt, err := parser.ParseOne(query)
+	if err != nil {
+		return nil, err
+	}
+	if sel, ok := stmt.AST.(*tree.Select); ok {
+		if clause, ok := sel.Select.(*tree.SelectClause); ok && len(clause.From.Tables) == 1 {
+			return clause, nil
+		}
+	}
+	return nil, errors.AssertionFailedf("unexpected changefeed query: %s", query)
+}
+
+// changefeedTargetFromSelect returns the table targeted by the query of a
+// changefeed created with CREATE CHANGEFEED AS SELECT.
+func changefeedTargetFromSelect(sel *tree.SelectClause) (tree.ChangefeedTarget, error) {
+	if len(sel.From.Tables) != 1 {
+		return tree.ChangefeedTarget{}, errors.Errorf(
+			`CHANGEFEED query must select from exactly one table`)
+	}
+	tableExpr := sel.From.Tables[0]
+	if aliased, ok := tableExpr.(*tree.AliasedTableExpr); ok {
+		tableExpr = aliased.Expr
+	}
+	tn, ok := tableExpr.(*tree.TableName)
+	if !ok {
+		return tree.ChangefeedTarget{}, errors.Errorf(
+			`CHANGEFEED cannot target %s`, tree.AsString(tableExpr))
+	}
+	return tree.ChangefeedTarget{TableName: tn.ToUnresolvedObjectName().ToUnresolvedName()}, nil
+}
+
+// exprEvaluator evaluates the projection and the predicate of the query of a
+// changefeed created with CREATE CHANGEFEED AS SELECT against changed rows.
+// The query is resolved against a single version of the target table
+// descriptor, so a new exprEvaluator must be created when the version of the
+// changed rows changes.
+//
+// Columns are referenced by name, optionally qualified by the table name or
+// its alias. The previous value of a column is referenced by qualifying it
+// with cdc_prev instead, which requires the diff option. Only immutable
+// expressions are allowed, and virtual computed columns cannot be referenced
+// because they are not decoded by the changefeed.
+type exprEvaluator struct {
+	tableDesc catalog.TableDescriptor
+	tableName tree.Name
+	withDiff  bool
+
+	// cols are the public columns of tableDesc. The ordinals of the
+	// IndexedVars in the expressions refer to the current value of cols[i] if
+	// they are lower than len(cols), and to the previous value of
+	// cols[i-len(cols)] otherwise.
+	cols []catalog.Column
+
+	names []string
+	exprs []tree.TypedExpr
+	where tree.TypedExpr
+
+	evalCtx *tree.EvalContext
+	alloc   tree.DatumAlloc
+
+	// cur and prev are the rows that the IndexedVars are bound to while
+	// evaluating the expressions.
+	cur, prev rowBinding
+}
+
+var _ tree.IndexedVarContainer = &exprEvaluator{}
+
+// rowBinding is a row, along with the descriptor version used to decode it.
+type rowBinding struct {
+	datums rowenc.EncDatumRow
+	desc   catalog.TableDescriptor
+	colMap catalog.TableColMap
+}
+
+// bind binds the row to the given datums, interpreted with the given
+// descriptor. A nil descriptor binds the row to NULL values.
+func (b *rowBinding) bind(datums rowenc.EncDatumRow, desc catalog.TableDescriptor) {
+	if desc != nil && (b.desc == nil ||
+		b.desc.GetID() != desc.GetID() || b.desc.GetVersion() != desc.GetVersion()) {
+		b.colMap = catalog.ColumnIDToOrdinalMap(desc.PublicColumns())
+	}
+	if desc != nil {
+		b.desc = desc
+	}
+	b.datums = datums
+}
+
+// newExprEvaluator resolves and type checks the given query against the given
+// version of the target table descriptor.
+func newExprEvaluator(
+	ctx context.Context,
+	evalCtx *tree.EvalContext,
+	sel *tree.SelectClause,
+	tableDesc catalog.TableDescriptor,
+	withDiff bool,
+) (*exprEvaluator, error) {
+	e := &exprEvaluator{
+		tableDesc: tableDesc,
+		tableName: tree.Name(tableDesc.GetName()),
+		withDiff:  withDiff,
+		cols:      tableDesc.PublicColumns(),
+		evalCtx:   evalCtx,
+	}
+	if aliased, ok := sel.From.Tables[0].(*tree.AliasedTableExpr); ok {
+		if aliased.As.Alias != "" {
+			e.tableName = aliased.As.Alias
+		} else if tn, ok := aliased.Expr.(*tree.TableName); ok {
+			e.tableName = tn.ObjectName
+		}
+	} else if tn, ok := sel.From.Tables[0].(*tree.TableName); ok {
+		e.tableName = tn.ObjectName
+	}
+
+	semaCtx := tree.MakeSemaContext()
+	semaCtx.IVarContainer = e
+	semaCtx.SearchPath = evalCtx.SessionData().SearchPath
+	const rejectFlags = tree.RejectSpecial | tree.RejectStableOperators |
+		tree.RejectVolatileFunctions | tree.RejectSubqueries
+
+	for _, target := range sel.Exprs {
+		if vn, ok := target.Expr.(tree.VarName); ok {
+			v, err := vn.NormalizeVarName()
+			if err != nil {
+				return nil, err
+			}
+			if star, isStar := e.starTable(v); isStar {
+				if target.As != "" {
+					return nil, pgerror.Newf(pgcode.Syntax, "%q cannot be aliased", tree.AsString(v))
+				}
+				if err := e.expandStar(star); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+
+		expr, err := e.resolveNames(target.Expr)
+		if err != nil {
+			return nil, err
+		}
+		semaCtx.Properties.Require("CHANGEFEED query", rejectFlags)
+		typedExpr, err := tree.TypeCheck(ctx, expr, &semaCtx, types.Any)
+		if err != nil {
+			return nil, err
+		}
+		name := string(target.As)
+		if name == "" {
+			if name, err = tree.GetRenderColName(semaCtx.SearchPath, target); err != nil {
+				return nil, err
+			}
+		}
+		e.names = append(e.names, name)
+		e.exprs = append(e.exprs, typedExpr)
+	}
+
+	if sel.Where != nil {
+		expr, err := e.resolveNames(sel.Where.Expr)
+		if err != nil {
+			return nil, err
+		}
+		semaCtx.Properties.Require("WHERE", rejectFlags)
+		if e.where, err = tree.TypeCheckAndRequire(
+			ctx, expr, &semaCtx, types.Bool, "WHERE",
+		); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// starTable returns whether the given name is a star, and if so, the name of
+// the table that qualifies it, if any.
+func (e *exprEvaluator) starTable(v tree.VarName) (tableName tree.Name, isStar bool) {
+	switch t := v.(type) {
+	case tree.UnqualifiedStar:
+		return "", true
+	case *tree.AllColumnsSelector:
+		return tree.Name(t.TableName.Parts[0]), true
+	}
+	return "", false
+}
+
+// expandStar adds the columns selected by * or <table>.* to the projection.
+// Hidden and virtual columns are not selected.
+func (e *exprEvaluator) expandStar(tableName tree.Name) error {
+	ordOffset := 0
+	switch tableName {
+	case "", e.tableName:
+	case cdcPrevName:
+		if !e.withDiff {
+			return errors.Errorf(`%s is only usable with %s`, cdcPrevName, changefeedbase.OptDiff)
+		}
+		ordOffset = len(e.cols)
+	default:
+		return pgerror.Newf(pgcode.UndefinedTable, "no data source matches pattern: %s.*", tableName)
+	}
+	for i, col := range e.cols {
+		if col.IsHidden() || col.IsVirtual() {
+			continue
+		}
+		e.names = append(e.names, col.GetName())
+		e.exprs = append(e.exprs, tree.NewTypedOrdinalReference(ordOffset+i, col.GetType()))
+	}
+	return nil
+}
+
+// resolveNames replaces the column references in the given expression with
+// IndexedVars.
+func (e *exprEvaluator) resolveNames(expr tree.Expr) (tree.Expr, error) {
+	return tree.SimpleVisit(expr, func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		vn, ok := expr.(tree.VarName)
+		if !ok {
+			return true, expr, nil
+		}
+		v, err := vn.NormalizeVarName()
+		if err != nil {
+			return false, nil, err
+		}
+		c, ok := v.(*tree.ColumnItem)
+		if !ok {
+			return false, nil, pgerror.Newf(pgcode.Syntax,
+				"%q is not allowed in this context", tree.AsString(v))
+		}
+		ivar, err := e.resolveColumn(c)
+		return false, ivar, err
+	})
+}
+
+// resolveColumn returns the IndexedVar referencing the given column.
+func (e *exprEvaluator) resolveColumn(c *tree.ColumnItem) (*tree.IndexedVar, error) {
+	ordOffset := 0
+	if c.TableName != nil {
+		switch tree.Name(c.TableName.Parts[0]) {
+		case e.tableName:
+		case cdcPrevName:
+			if !e.withDiff {
+				return nil, errors.Errorf(`%s is only usable with %s`, cdcPrevName, changefeedbase.OptDiff)
+			}
+			ordOffset = len(e.cols)
+		default:
+			return nil, pgerror.Newf(pgcode.UndefinedTable,
+				"no data source matches prefix: %s", tree.AsString(c.TableName))
+		}
+	}
+	for i, col := range e.cols {
+		if col.GetName() != string(c.ColumnName) {
+			continue
+		}
+		if col.IsVirtual() {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"CHANGEFEED query cannot reference virtual computed column %q", col.GetName())
+		}
+		return tree.NewTypedOrdinalReference(ordOffset+i, col.GetType()), nil
+	}
+	return nil, pgerror.Newf(pgcode.UndefinedColumn,
+		"column %q does not exist", tree.ErrString(&c.ColumnName))
+}
+
+// IndexedVarEval implements the tree.IndexedVarContainer interface.
+func (e *exprEvaluator) IndexedVarEval(idx int, _ *tree.EvalContext) (tree.Datum, error) {
+	b := &e.cur
+	if idx >= len(e.cols) {
+		b = &e.prev
+		idx -= len(e.cols)
+	}
+	if b.datums == nil {
+		return tree.DNull, nil
+	}
+	col := e.cols[idx]
+	ord, ok := b.colMap.Get(col.GetID())
+	if !ok {
+		// The column was added or dropped between the version of the descriptor
+		// used to decode the row and the version of the query.
+		return tree.DNull, nil
+	}
+	datum := &b.datums[ord]
+	if err := datum.EnsureDecoded(col.GetType(), &e.alloc); err != nil {
+		return nil, err
+	}
+	return datum.Datum, nil
+}
+
+// IndexedVarResolvedType implements the tree.IndexedVarContainer interface.
+func (e *exprEvaluator) IndexedVarResolvedType(idx int) *types.T {
+	return e.cols[idx%len(e.cols)].GetType()
+}
+
+// IndexedVarNodeFormatter implements the tree.IndexedVarContainer interface.
+func (e *exprEvaluator) IndexedVarNodeFormatter(idx int) tree.NodeFormatter {
+	name := tree.Name(e.cols[idx%len(e.cols)].GetName())
+	if idx >= len(e.cols) {
+		return &tree.ColumnItem{
+			TableName:  &tree.UnresolvedObjectName{NumParts: 1, Parts: [3]string{cdcPrevName}},
+			ColumnName: name,
+		}
+	}
+	return &name
+}
+
+// evalRow evaluates the query for the given row. It returns false if the row
+// is filtered out by the WHERE clause. Otherwise, it sets the projection of
+// the row and, if the previous value of the row is known, the projection of
+// the previous value.
+//
+// Deleted rows only contain their primary key, so the WHERE clause is
+// evaluated against their previous value instead if the diff option is set,
+// and they are never filtered out otherwise. When evaluating the query for
+// the previous value of a row, the cdc_prev columns are NULL.
+func (e *exprEvaluator) evalRow(ctx context.Context, r *encodeRow) (bool, error) {
+	hasPrev := r.prevDatums != nil && !r.prevDeleted
+	if !r.deleted {
+		e.cur.bind(r.datums, r.tableDesc)
+		if hasPrev {
+			e.prev.bind(r.prevDatums, r.prevTableDesc)
+		} else {
+			e.prev.bind(nil, nil)
+		}
+		if ok, err := e.matches(); err != nil || !ok {
+			return false, err
+		}
+		projection, err := e.project()
+		if err != nil {
+			return false, err
+		}
+		r.projection = projection
+	}
+
+	if hasPrev {
+		e.cur.bind(r.prevDatums, r.prevTableDesc)
+		e.prev.bind(nil, nil)
+		if r.deleted {
+			if ok, err := e.matches(); err != nil || !ok {
+				return false, err
+			}
+		}
+		projection, err := e.project()
+		if err != nil {
+			return false, err
+		}
+		r.prevProjection = projection
+	}
+	return true, nil
+}
+
+// matches returns whether the bound rows satisfy the WHERE clause.
+func (e *exprEvaluator) matches() (bool, error) {
+	if e.where == nil {
+		return true, nil
+	}
+	e.evalCtx.PushIVarContainer(e)
+	defer e.evalCtx.PopIVarContainer()
+	d, err := e.where.Eval(e.evalCtx)
+	if err != nil {
+		return false, err
+	}
+	return d == tree.DBoolTrue, nil
+}
+
+// project evaluates the projection for the bound rows.
+func (e *exprEvaluator) project() (*projectedRow, error) {
+	e.evalCtx.PushIVarContainer(e)
+	defer e.evalCtx.PopIVarContainer()
+	row := &projectedRow{names: e.names, datums: make(tree.Datums, len(e.exprs))}
+	for i, expr := range e.exprs {
+		d, err := expr.Eval(e.evalCtx)
+		if err != nil {
+			return nil, err
+		}
+		row.datums[i] = d
+	}
+	return row, nil
+}
+
+// exprEvaluatorCache returns an exprEvaluator for the version of the target
+// table descriptor of each changed row.
+type exprEvaluatorCache struct {
+	query    string
+	evalCtx  *tree.EvalContext
+	withDiff bool
+
+	evaluator *exprEvaluator
+}
+
+// forDesc returns the exprEvaluator for the given descriptor version.
+func (c *exprEvaluatorCache) forDesc(
+	ctx context.Context, desc catalog.TableDescriptor,
+) (*exprEvaluator, error) {
+	if e := c.evaluator; e != nil &&
+		e.tableDesc.GetID() == desc.GetID() && e.tableDesc.GetVersion() == desc.GetVersion() {
+		return e, nil
+	}
+	// The query is parsed again for every descriptor version because
+	// resolving and type checking it may modify its nodes.
+	sel, err := parseChangefeedSelect(c.query)
+	if err != nil {
+		return nil, err
+	}
+	e, err := newExprEvaluator(ctx, c.evalCtx, sel, desc, c.withDiff)
+	if err != nil {
+		return nil, errors.Wrapf(err, "table %s at version %d", desc.GetName(), desc.GetVersion())
+	}
+	c.evaluator = e
+	return e, nil
+}
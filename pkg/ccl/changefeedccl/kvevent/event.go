@@ -212,6 +212,21 @@ func MakeResolvedEvent(
 	}
 }
 
+// MakeSpanChangeEvent returns a resolved event which adds the span to, or
+// removes it from, the spans watched by the changefeed at the timestamp.
+func MakeSpanChangeEvent(
+	span roachpb.Span, ts hlc.Timestamp, change jobspb.ResolvedSpan_SpanChange,
+) Event {
+	return Event{
+		resolved: &jobspb.ResolvedSpan{
+			Span:       span,
+			Timestamp:  ts,
+			SpanChange: change,
+		},
+		approxSize: span.Size() + ts.Size() + 8,
+	}
+}
+
 // MakeKVEvent returns KV event.
 func MakeKVEvent(
 	kv roachpb.KeyValue, prevVal roachpb.Value, backfillTimestamp hlc.Timestamp,
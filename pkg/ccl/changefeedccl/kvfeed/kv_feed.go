@@ -26,6 +26,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvcoord"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -69,6 +70,13 @@ type Config struct {
 	// time, the changefeed job will end with a successful status.
 	EndTime hlc.Timestamp
 
+	// If true, the spans of the tables created in the database or schema
+	// watched by a changefeed created with CREATE CHANGEFEED FOR DATABASE or
+	// FOR SCHEMA are added to the feed, which scans them. It is set for a
+	// single kvfeed of such a changefeed. The spans of dropped tables are
+	// removed from every kvfeed.
+	WatchCreatedTables bool
+
 	// Knobs are kvfeed testing knobs.
 	Knobs TestingKnobs
 }
@@ -107,6 +115,8 @@ func Run(ctx context.Context, cfg Config) error {
 		cfg.SchemaFeed,
 		sc, pff, bf, cfg.Knobs)
 	f.onBackfillCallback = cfg.OnBackfillCallback
+	f.targets = cfg.Targets
+	f.watchCreatedTables = cfg.WatchCreatedTables
 
 	g := ctxgroup.WithContext(ctx)
 	g.GoCtx(cfg.SchemaFeed.Run)
@@ -171,6 +181,8 @@ type kvFeed struct {
 	codec               keys.SQLCodec
 
 	onBackfillCallback func() func()
+	targets            []jobspb.ChangefeedTargetSpecification
+	watchCreatedTables bool
 	schemaChangeEvents changefeedbase.SchemaChangeEventClass
 	schemaChangePolicy changefeedbase.SchemaChangePolicy
 
@@ -261,10 +273,14 @@ func (f *kvFeed) run(ctx context.Context) (err error) {
 		boundaryType := jobspb.ResolvedSpan_BACKFILL
 		if f.schemaChangePolicy == changefeedbase.OptSchemaChangePolicyStop {
 			boundaryType = jobspb.ResolvedSpan_EXIT
-		} else if events, err := f.tableFeed.Peek(ctx, highWater.Next()); err == nil && isPrimaryKeyChange(events) {
+		} else if events, err := f.tableFeed.Peek(ctx, highWater.Next()); err != nil {
+			return err
+		} else if isPrimaryKeyChange(events) {
 			boundaryType = jobspb.ResolvedSpan_RESTART
-		} else if err != nil {
+		} else if restart, err := f.changeSpans(ctx, events, highWater); err != nil {
 			return err
+		} else if restart {
+			boundaryType = jobspb.ResolvedSpan_RESTART
 		}
 		// Resolve all of the spans as a boundary if the policy indicates that
 		// we should do so.
@@ -282,6 +298,83 @@ func (f *kvFeed) run(ctx context.Context) (err error) {
 	}
 }
 
+// changeSpans adds the spans of the tables created in the database or schema
+// watched by a changefeed created with CREATE CHANGEFEED FOR DATABASE or FOR
+// SCHEMA to the spans of the feed, if it watches created tables, and removes
+// those of the dropped tables. The changes are emitted at highWater, and the
+// added spans are then scanned by scanIfShould, without restarting the
+// changefeed. The changefeed must restart instead, which is indicated by the
+// returned boolean, if the feed would be left without spans, since it could not
+// notice further schema changes.
+func (f *kvFeed) changeSpans(
+	ctx context.Context, events []schemafeed.TableEvent, highWater hlc.Timestamp,
+) (restart bool, _ error) {
+	var added, removed []roachpb.Span
+	spans := f.spans
+	for _, ev := range events {
+		tablePrefix := f.codec.TablePrefix(uint32(ev.After.GetID()))
+		tableSpan := roachpb.Span{Key: tablePrefix, EndKey: tablePrefix.PrefixEnd()}
+		switch {
+		case schemafeed.IsTableDrop(ev):
+			var remaining []roachpb.Span
+			for _, sp := range spans {
+				if tableSpan.Overlaps(sp) {
+					removed = append(removed, sp)
+				} else {
+					remaining = append(remaining, sp)
+				}
+			}
+			spans = remaining
+			// The table is added again if it moves back into the database or
+			// schema.
+			f.removeTarget(ev.After.GetID())
+		case schemafeed.IsTableCreation(ev) && f.watchCreatedTables && !f.isTarget(ev.After.GetID()):
+			// The spans of the targets the changefeed started with, even those
+			// which only became public since, were all assigned to the kvfeeds of
+			// the changefeed already.
+			sp := ev.After.PrimaryIndexSpan(f.codec)
+			added = append(added, sp)
+			spans = append(spans, sp)
+		}
+	}
+	if len(spans) == 0 {
+		return true, nil
+	}
+	for _, sp := range removed {
+		if err := f.writer.Add(ctx, kvevent.MakeSpanChangeEvent(
+			sp, highWater, jobspb.ResolvedSpan_REMOVED)); err != nil {
+			return false, err
+		}
+	}
+	for _, sp := range added {
+		if err := f.writer.Add(ctx, kvevent.MakeSpanChangeEvent(
+			sp, highWater, jobspb.ResolvedSpan_ADDED)); err != nil {
+			return false, err
+		}
+	}
+	f.spans = spans
+	return false, nil
+}
+
+func (f *kvFeed) isTarget(id descpb.ID) bool {
+	for _, t := range f.targets {
+		if t.TableID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *kvFeed) removeTarget(id descpb.ID) {
+	var targets []jobspb.ChangefeedTargetSpecification
+	for _, t := range f.targets {
+		if t.TableID != id {
+			targets = append(targets, t)
+		}
+	}
+	f.targets = targets
+}
+
 func isPrimaryKeyChange(events []schemafeed.TableEvent) bool {
 	for _, ev := range events {
 		if schemafeed.IsPrimaryIndexChange(ev) {
@@ -333,6 +426,17 @@ func (f *kvFeed) scanIfShould(
 			if schemafeed.IsOnlyPrimaryIndexChange(ev) {
 				continue
 			}
+			if !scanTime.Equal(ev.After.GetModificationTime()) {
+				return errors.AssertionFailedf("found event in shouldScan which did not occur at the scan time %v: %v",
+					scanTime, ev)
+			}
+			// Tables which are added to the targets of a changefeed on a database
+			// or schema are scanned at the time they become public, regardless of
+			// the schema change policy.
+			if f.schemaChangePolicy == changefeedbase.OptSchemaChangePolicyNoBackfill &&
+				!schemafeed.IsTableCreation(ev) {
+				continue
+			}
 			tablePrefix := f.codec.TablePrefix(uint32(ev.After.GetID()))
 			tableSpan := roachpb.Span{Key: tablePrefix, EndKey: tablePrefix.PrefixEnd()}
 			for _, sp := range f.spans {
@@ -340,10 +444,6 @@ func (f *kvFeed) scanIfShould(
 					spansToBackfill = append(spansToBackfill, sp)
 				}
 			}
-			if !scanTime.Equal(ev.After.GetModificationTime()) {
-				return errors.AssertionFailedf("found event in shouldScan which did not occur at the scan time %v: %v",
-					scanTime, ev)
-			}
 		}
 	} else {
 		return nil
@@ -358,8 +458,7 @@ func (f *kvFeed) scanIfShould(
 	// spans which we no longer need to scan.
 	spansToBackfill = filterCheckpointSpans(spansToBackfill, f.checkpoint)
 
-	if (!isInitialScan && f.schemaChangePolicy == changefeedbase.OptSchemaChangePolicyNoBackfill) ||
-		len(spansToBackfill) == 0 {
+	if len(spansToBackfill) == 0 {
 		return nil
 	}
 
@@ -55,6 +55,8 @@ type AggMetrics struct {
 	ErrorRetries          *aggmetric.AggCounter
 	AdmitLatency          *aggmetric.AggHistogram
 	RunningCount          *aggmetric.AggGauge
+	NATSPendingAcks       *aggmetric.AggGauge
+	NATSPublishErrors     *aggmetric.AggCounter
 
 	// There is always at least 1 sliMetrics created for defaultSLI scope.
 	mu struct {
@@ -81,6 +83,8 @@ type sliMetrics struct {
 	BackfillCount         *aggmetric.Gauge
 	BackfillPendingRanges *aggmetric.Gauge
 	RunningCount          *aggmetric.Gauge
+	NATSPendingAcks       *aggmetric.Gauge
+	NATSPublishErrors     *aggmetric.Counter
 }
 
 // sinkDoesNotCompress is a sentinel value indicating the sink
@@ -152,6 +156,22 @@ func (m *sliMetrics) recordFlushRequestCallback() func() {
 	}
 }
 
+// recordNATSPublish records a message published to NATS JetStream and returns
+// a callback to be invoked once the publish is acknowledged or fails.
+func (m *sliMetrics) recordNATSPublish() func(err error) {
+	if m == nil {
+		return func(err error) {}
+	}
+
+	m.NATSPendingAcks.Inc(1)
+	return func(err error) {
+		m.NATSPendingAcks.Dec(1)
+		if err != nil {
+			m.NATSPublishErrors.Inc(1)
+		}
+	}
+}
+
 func (m *sliMetrics) getBackfillCallback() func() func() {
 	return func() func() {
 		m.BackfillCount.Inc(1)
@@ -318,6 +338,18 @@ func newAggregateMetrics(histogramWindow time.Duration) *AggMetrics {
 		Measurement: "Changefeeds",
 		Unit:        metric.Unit_COUNT,
 	}
+	metaChangefeedNATSPendingAcks := metric.Metadata{
+		Name:        "changefeed.nats.pending_acks",
+		Help:        "Number of messages published to NATS JetStream that have not yet been acknowledged",
+		Measurement: "Messages",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaChangefeedNATSPublishErrors := metric.Metadata{
+		Name:        "changefeed.nats.publish_errors",
+		Help:        "Number of messages published to NATS JetStream that failed to be acknowledged",
+		Measurement: "Messages",
+		Unit:        metric.Unit_COUNT,
+	}
 	metaMessageSize := metric.Metadata{
 		Name:        "changefeed.message_size_hist",
 		Help:        "Message size histogram",
@@ -347,6 +379,8 @@ func newAggregateMetrics(histogramWindow time.Duration) *AggMetrics {
 		BackfillCount:         b.Gauge(metaChangefeedBackfillCount),
 		BackfillPendingRanges: b.Gauge(metaChangefeedBackfillPendingRanges),
 		RunningCount:          b.Gauge(metaChangefeedRunning),
+		NATSPendingAcks:       b.Gauge(metaChangefeedNATSPendingAcks),
+		NATSPublishErrors:     b.Counter(metaChangefeedNATSPublishErrors),
 	}
 	a.mu.sliMetrics = make(map[string]*sliMetrics)
 	_, err := a.getOrCreateScope(defaultSLIScope)
@@ -404,6 +438,8 @@ func (a *AggMetrics) getOrCreateScope(scope string) (*sliMetrics, error) {
 		BackfillCount:         a.BackfillCount.AddChild(scope),
 		BackfillPendingRanges: a.BackfillPendingRanges.AddChild(scope),
 		RunningCount:          a.RunningCount.AddChild(scope),
+		NATSPendingAcks:       a.NATSPendingAcks.AddChild(scope),
+		NATSPublishErrors:     a.NATSPublishErrors.AddChild(scope),
 	}
 
 	a.mu.sliMetrics[scope] = sm
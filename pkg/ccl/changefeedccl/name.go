@@ -19,6 +19,7 @@ import (
 var escapeRE = regexp.MustCompile(`_u[0-9a-fA-F]{2,8}_`)
 var kafkaDisallowedRE = regexp.MustCompile(`[^a-zA-Z0-9\._\-]`)
 var avroDisallowedRE = regexp.MustCompile(`[^A-Za-z0-9_]`)
+var natsDisallowedRE = regexp.MustCompile(`[^!-~]|[*>]`)
 
 func escapeRune(r rune) string {
 	if r <= 1<<16 {
@@ -55,6 +56,25 @@ func KafkaNameToSQLName(s string) string {
 	return unescapeSQLName(s)
 }
 
+// SQLNameToNATSSubject escapes a sql table name into a valid NATS subject.
+// This is reversible by NATSSubjectToSQLName.
+//
+// NATS allows subjects made of printable characters other than the `*` and `>`
+// wildcards, with `.` separating the tokens of the subject. Dots are left
+// alone, so a fully qualified table name is published to a subject with a
+// token for each part of the name.
+//
+// Runes are escaped with _u<hex>_ in an attempt to look like U+0021. For
+// example `*` escapes to `_u002a_`.
+func SQLNameToNATSSubject(s string) string {
+	return escapeSQLName(s, natsDisallowedRE)
+}
+
+// NATSSubjectToSQLName is the inverse of SQLNameToNATSSubject.
+func NATSSubjectToSQLName(s string) string {
+	return unescapeSQLName(s)
+}
+
 // SQLNameToAvroName escapes a sql table name into a valid avro record or field
 // name. This is reversible by AvroNameToSQLName.
 //
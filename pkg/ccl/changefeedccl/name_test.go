@@ -55,6 +55,38 @@ func TestSQLNameToKafkaName(t *testing.T) {
 	require.Equal(t, `/`, KafkaNameToSQLName(`_u2F_`))
 }
 
+func TestSQLNameToNATSSubject(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	tests := []struct {
+		sql, nats string
+	}{
+		{`foo`, `foo`},
+		{`abcdefghijklmnopqrstuvwxyz`, `abcdefghijklmnopqrstuvwxyz`},
+		{`ABCDEFGHIJKLMNOPQRSTUVWXYZ`, `ABCDEFGHIJKLMNOPQRSTUVWXYZ`},
+		{`0123456789_-.`, `0123456789_-.`},
+		{`!@#$%^&()/`, `!@#$%^&()/`},
+		{`db.public.foo`, `db.public.foo`},
+		{`*`, `_u002a_`},
+		{`>`, `_u003e_`},
+		{`foo bar`, `foo_u0020_bar`},
+		{"foo\tbar", `foo_u0009_bar`},
+		{`foo_u0021_bar`, `foo_u005f__u0075__u0030__u0030__u0032__u0031__u005f_bar`},
+		{`☃`, `_u2603_`},
+		{"\x00", `_u0000_`},
+		{string(rune(utf8.RuneSelf)), `_u0080_`},
+	}
+	for i, test := range tests {
+		if n := SQLNameToNATSSubject(test.sql); n != test.nats {
+			t.Errorf(`%d: %s did not escape to %s got %s`, i, test.sql, test.nats, n)
+		}
+		if s := NATSSubjectToSQLName(test.nats); s != test.sql {
+			t.Errorf(`%d: %s did not unescape to %s got %s`, i, test.nats, test.sql, s)
+		}
+	}
+}
+
 func TestSQLNameToAvroName(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -28,6 +28,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/lease"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
@@ -71,6 +73,16 @@ type SchemaFeed interface {
 
 // New creates SchemaFeed tracking 'targets' and emitting specified 'events'.
 //
+// If databaseID is non-zero, the targets are the tables of that database or,
+// if schemaID is also non-zero, of that schema, as targeted by changefeeds
+// created with CREATE CHANGEFEED FOR DATABASE or FOR SCHEMA. The SchemaFeed
+// then also emits an event when a table of the database or schema which is
+// not one of the targets becomes public, and when a target is dropped or
+// moved elsewhere (see IsTableCreation and IsTableDrop). Tables become targets
+// of the SchemaFeed with their table creation event. Targets which are not
+// public at the initial high-water yet get a table creation event once they
+// are.
+//
 // initialHighwater is the timestamp after which events should occur.
 // NB: When clients want to create a changefeed which has a resolved timestamp
 // of ts1, they care about write which occur at ts1.Next() and later but they
@@ -81,6 +93,7 @@ func New(
 	cfg *execinfra.ServerConfig,
 	events changefeedbase.SchemaChangeEventClass,
 	targets []jobspb.ChangefeedTargetSpecification,
+	databaseID, schemaID descpb.ID,
 	initialHighwater hlc.Timestamp,
 	metrics *Metrics,
 	changefeedOpts map[string]string,
@@ -91,6 +104,8 @@ func New(
 		clock:             cfg.DB.Clock(),
 		settings:          cfg.Settings,
 		targets:           targets,
+		databaseID:        databaseID,
+		schemaID:          schemaID,
 		leaseMgr:          cfg.LeaseManager.(*lease.Manager),
 		ie:                cfg.SessionBoundInternalExecutorFactory(ctx, &sessiondata.SessionData{}),
 		collectionFactory: cfg.CollectionFactory,
@@ -123,6 +138,10 @@ type schemaFeed struct {
 	metrics        *Metrics
 	changefeedOpts map[string]string
 
+	// databaseID and schemaID identify the database or schema whose tables
+	// are the targets, if any. See New.
+	databaseID, schemaID descpb.ID
+
 	// TODO(ajwerner): Should this live underneath the FilterFunc?
 	// Should there be another function to decide whether to update the
 	// lease manager?
@@ -201,7 +220,7 @@ func (t *typeDependencyTracker) removeDependency(typeID, tableID descpb.ID) {
 
 func (t *typeDependencyTracker) purgeTable(tbl catalog.TableDescriptor) error {
 	for _, col := range tbl.UserDefinedTypeColumns() {
-		id, err := typedesc.UserDefinedTypeOIDToID(col.GetType().Oid())
+		id, err := typedesc.GetUserDefinedTypeDescID(col.GetType())
 		if err != nil {
 			return err
 		}
@@ -213,7 +232,7 @@ func (t *typeDependencyTracker) purgeTable(tbl catalog.TableDescriptor) error {
 
 func (t *typeDependencyTracker) ingestTable(tbl catalog.TableDescriptor) error {
 	for _, col := range tbl.UserDefinedTypeColumns() {
-		id, err := typedesc.UserDefinedTypeOIDToID(col.GetType().Oid())
+		id, err := typedesc.GetUserDefinedTypeDescID(col.GetType())
 		if err != nil {
 			return err
 		}
@@ -293,8 +312,16 @@ func (tf *schemaFeed) primeInitialTableDescs(ctx context.Context) error {
 			flags.AvoidLeased = true
 			tableDesc, err := descriptors.GetImmutableTableByID(ctx, txn, table.TableID, flags)
 			if err != nil {
+				if tf.watchesDatabase() && isInactiveTableError(err) {
+					// The table was created or became public after the initial
+					// high-water. It is tracked from its table creation event.
+					continue
+				}
 				return err
 			}
+			if tf.watchesDatabase() && !tf.watchesTable(tableDesc) {
+				continue
+			}
 			initialDescs = append(initialDescs, tableDesc)
 		}
 		return nil
@@ -518,6 +545,9 @@ func formatDesc(desc catalog.TableDescriptor) string {
 }
 
 func formatEvent(e TableEvent) string {
+	if IsTableCreation(e) {
+		return fmt.Sprintf("nil->%v", formatDesc(e.After))
+	}
 	return fmt.Sprintf("%v->%v", formatDesc(e.Before), formatDesc(e.After))
 }
 
@@ -535,6 +565,11 @@ func (tf *schemaFeed) validateDescriptor(
 		// manager to acquire the freshest version of the type.
 		return tf.leaseMgr.AcquireFreshestFromStore(ctx, desc.GetID())
 	case catalog.TableDescriptor:
+		if tf.watchesDatabase() {
+			if done, err := tf.validateWatchedTableLocked(earliestTsBeingIngested, desc); done || err != nil {
+				return err
+			}
+		}
 		if err := changefeedbase.ValidateTable(tf.targets, desc, tf.changefeedOpts); err != nil {
 			return err
 		}
@@ -571,17 +606,7 @@ func (tf *schemaFeed) validateDescriptor(
 				return err
 			}
 			if !shouldFilter {
-				// Only sort the tail of the events from earliestTsBeingIngested.
-				// The head could already have been handed out and sorting is not
-				// stable.
-				idxToSort := sort.Search(len(tf.mu.events), func(i int) bool {
-					return !tf.mu.events[i].After.GetModificationTime().Less(earliestTsBeingIngested)
-				})
-				tf.mu.events = append(tf.mu.events, e)
-				toSort := tf.mu.events[idxToSort:]
-				sort.Slice(toSort, func(i, j int) bool {
-					return descLess(toSort[i].After, toSort[j].After)
-				})
+				tf.addEventLocked(earliestTsBeingIngested, e)
 			}
 		}
 		// Add the types used by the table into the dependency tracker.
@@ -595,6 +620,94 @@ func (tf *schemaFeed) validateDescriptor(
 	}
 }
 
+// addEventLocked adds an event to the queue of events.
+func (tf *schemaFeed) addEventLocked(earliestTsBeingIngested hlc.Timestamp, e TableEvent) {
+	// Only sort the tail of the events from earliestTsBeingIngested.
+	// The head could already have been handed out and sorting is not
+	// stable.
+	idxToSort := sort.Search(len(tf.mu.events), func(i int) bool {
+		return !tf.mu.events[i].After.GetModificationTime().Less(earliestTsBeingIngested)
+	})
+	tf.mu.events = append(tf.mu.events, e)
+	toSort := tf.mu.events[idxToSort:]
+	sort.Slice(toSort, func(i, j int) bool {
+		return descLess(toSort[i].After, toSort[j].After)
+	})
+}
+
+// watchesDatabase returns true if the targets are the tables of a database or
+// schema.
+func (tf *schemaFeed) watchesDatabase() bool {
+	return tf.databaseID != descpb.InvalidID
+}
+
+// watchesTable returns true if the table is public and belongs to the
+// database or schema whose tables are the targets.
+func (tf *schemaFeed) watchesTable(desc catalog.TableDescriptor) bool {
+	return desc.Public() && changefeedbase.WatchesTable(tf.databaseID, tf.schemaID, desc)
+}
+
+func (tf *schemaFeed) isTarget(id descpb.ID) bool {
+	for _, cts := range tf.targets {
+		if cts.TableID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// validateWatchedTableLocked emits the table creation and table drop events of
+// a SchemaFeed whose targets are the tables of a database or schema. It
+// returns true if the descriptor needs no further validation.
+func (tf *schemaFeed) validateWatchedTableLocked(
+	earliestTsBeingIngested hlc.Timestamp, desc catalog.TableDescriptor,
+) (done bool, _ error) {
+	lastVersion, ok := tf.mu.previousTableVersion[desc.GetID()]
+	if !ok && earliestTsBeingIngested.IsEmpty() {
+		// The initial descriptors of the targets are being ingested.
+		return false, nil
+	}
+	if !ok || (!lastVersion.Offline() && !tf.watchesTable(lastVersion)) {
+		// The table is not a target, is a target which was not public yet at the
+		// initial high-water, or was moved out of the database or schema.
+		if !tf.watchesTable(desc) {
+			return true, nil
+		}
+		if ok && desc.GetModificationTime().LessEq(lastVersion.GetModificationTime()) {
+			return true, nil
+		}
+		tf.addEventLocked(earliestTsBeingIngested, TableEvent{After: desc})
+		// The table is tracked from then on, like the other targets.
+		if !tf.isTarget(desc.GetID()) {
+			tf.targets = append(tf.targets, changefeedbase.WatchedTableTarget(desc, desc.GetName()))
+		}
+		delete(tf.mu.previousTableVersion, desc.GetID())
+		return false, nil
+	}
+	// Offline targets are validated like those of any other changefeed.
+	if desc.Offline() || tf.watchesTable(desc) {
+		return false, nil
+	}
+	if desc.GetModificationTime().LessEq(lastVersion.GetModificationTime()) {
+		return true, nil
+	}
+	if tf.watchesTable(lastVersion) {
+		tf.addEventLocked(earliestTsBeingIngested, TableEvent{Before: lastVersion, After: desc})
+		if err := tf.mu.typeDeps.purgeTable(lastVersion); err != nil {
+			return true, err
+		}
+	}
+	tf.mu.previousTableVersion[desc.GetID()] = desc
+	return true, nil
+}
+
+// isInactiveTableError returns true if the error indicates that a table does
+// not exist or is not public.
+func isInactiveTableError(err error) bool {
+	return pgerror.GetPGCode(err) == pgcode.UndefinedTable ||
+		catalog.HasAddingTableError(err) || catalog.HasInactiveDescriptorError(err)
+}
+
 var highPriorityAfter = settings.RegisterDurationSetting(
 	settings.TenantWritable,
 	"changefeed.schema_feed.read_with_priority_after",
@@ -705,13 +818,20 @@ func (tf *schemaFeed) fetchDescriptorVersions(
 					}
 				}
 				isType := tf.mu.typeDeps.containsType(descpb.ID(id))
-				// Check if the descriptor is an interesting table or type.
-				if !(isTable || isType) {
+				// Check if the descriptor is an interesting table or type. Any table
+				// may be interesting if the targets are the tables of a database or
+				// schema, which is determined once the descriptor is decoded.
+				if !(isTable || isType || tf.watchesDatabase()) {
 					// Uninteresting descriptor.
 					continue
 				}
 
 				unsafeValue := it.UnsafeValue()
+				if unsafeValue == nil && tf.watchesDatabase() && !isType {
+					// Targets are dropped before their descriptor is deleted, and
+					// validateDescriptor stops tracking them then.
+					continue
+				}
 				if unsafeValue == nil {
 					name := origName
 					if name == "" {
@@ -70,6 +70,9 @@ func (e tableEventType) Clear(event tableEventType) tableEventType {
 
 func classifyTableEvent(e TableEvent) tableEventType {
 	et := tableEventTypeUnknown
+	if IsTableCreation(e) {
+		return et
+	}
 	if primaryKeyChanged(e) {
 		et = et | tableEventPrimaryKeyChange
 	}
@@ -215,3 +218,20 @@ func IsRegionalByRowChange(e TableEvent) bool {
 	et := classifyTableEvent(e)
 	return et.Contains(tableEventLocalityRegionalByRowChange)
 }
+
+// IsTableCreation returns true if the event corresponds to a table of the
+// database or schema targeted by a changefeed created with CREATE CHANGEFEED
+// FOR DATABASE or FOR SCHEMA becoming public. Such events have no Before
+// descriptor.
+func IsTableCreation(e TableEvent) bool {
+	return e.Before == nil
+}
+
+// IsTableDrop returns true if the event corresponds to a target of a
+// changefeed created with CREATE CHANGEFEED FOR DATABASE or FOR SCHEMA being
+// dropped or moved out of its database or schema.
+func IsTableDrop(e TableEvent) bool {
+	return e.Before != nil && (e.After.Dropped() ||
+		e.Before.GetParentID() != e.After.GetParentID() ||
+		e.Before.GetParentSchemaID() != e.After.GetParentSchemaID())
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
@@ -24,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/bufalloc"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/span"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 )
@@ -61,6 +63,36 @@ type SinkWithTopics interface {
 	Topics() []string
 }
 
+// transactionalSink is implemented by sinks which can atomically commit the
+// rows emitted into them along with the resolved timestamp those rows cover,
+// so that rows already delivered are not emitted again when a changefeed
+// restarts.
+type transactionalSink interface {
+	Sink
+	// StartTransactions switches the sink to transactional delivery of the
+	// rows in the given spans, which are those of the change aggregator with
+	// the given index among the numAggregators aggregators of the changefeed,
+	// and returns the frontier of those spans as committed by previous
+	// incarnations of the changefeed. Rows at or below the returned frontier
+	// must not be emitted again.
+	StartTransactions(
+		ctx context.Context, spans []roachpb.Span, aggregatorIndex, numAggregators int32,
+	) (*span.Frontier, error)
+	// FlushResolved commits every emitted row whose updated timestamp is at
+	// or below resolved, along with a record that the spans have been
+	// delivered up to resolved. Later rows stay buffered in the sink.
+	FlushResolved(ctx context.Context, resolved hlc.Timestamp) error
+	// ShouldFlushResolved returns whether so many rows are buffered that
+	// FlushResolved should be called as soon as the resolved timestamp
+	// advances.
+	ShouldFlushResolved() bool
+	// NoteSpanChange adds the span of the resolved span to, or removes it
+	// from, the spans whose rows are delivered by the sink, as indicated by
+	// its SpanChange. An added span has no rows at or below the timestamp of
+	// the resolved span.
+	NoteSpanChange(change jobspb.ResolvedSpan) error
+}
+
 func getSink(
 	ctx context.Context,
 	serverCfg *execinfra.ServerConfig,
@@ -97,13 +129,17 @@ func getSink(
 			return makeNullSink(sinkURL{URL: u}, m)
 		case u.Scheme == changefeedbase.SinkSchemeKafka:
 			return validateOptionsAndMakeSink(changefeedbase.KafkaValidOptions, func() (Sink, error) {
-				return makeKafkaSink(ctx, sinkURL{URL: u}, AllTargets(feedCfg), feedCfg.Opts, m)
+				return makeKafkaSink(ctx, sinkURL{URL: u}, AllTargets(feedCfg), feedCfg.Opts, jobID, m)
 			})
 		case isWebhookSink(u):
 			return validateOptionsAndMakeSink(changefeedbase.WebhookValidOptions, func() (Sink, error) {
 				return makeWebhookSink(ctx, sinkURL{URL: u}, feedCfg.Opts,
 					defaultWorkerCount(), timeutil.DefaultTimeSource{}, m)
 			})
+		case isNATSSink(u):
+			return validateOptionsAndMakeSink(changefeedbase.NATSValidOptions, func() (Sink, error) {
+				return makeNATSSink(ctx, sinkURL{URL: u}, AllTargets(feedCfg), feedCfg.Opts, m)
+			})
 		case isPubsubSink(u):
 			// TODO: add metrics to pubsubsink
 			return validateOptionsAndMakeSink(changefeedbase.PubsubValidOptions, func() (Sink, error) {
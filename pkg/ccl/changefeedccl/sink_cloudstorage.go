@@ -27,6 +27,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
 	"github.com/cockroachdb/cockroach/pkg/cloud"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -69,6 +70,10 @@ type cloudStorageSinkFile struct {
 	buf         bytes.Buffer
 	alloc       kvevent.Alloc
 	oldestMVCC  hlc.Timestamp
+
+	// parquet is set when the sink writes parquet files, in which case rows
+	// are written to it instead of buf.
+	parquet *parquetFileWriter
 }
 
 var _ io.Writer = &cloudStorageSinkFile{}
@@ -76,12 +81,25 @@ var _ io.Writer = &cloudStorageSinkFile{}
 func (f *cloudStorageSinkFile) Write(p []byte) (int, error) {
 	f.rawSize += len(p)
 	f.numMessages++
+	if f.parquet != nil {
+		return len(p), f.parquet.write(p)
+	}
 	if f.codec != nil {
 		return f.codec.Write(p)
 	}
 	return f.buf.Write(p)
 }
 
+// size returns the size of the file used to decide when to flush it. Parquet
+// files are only written out when they are closed, so the size of the rows
+// written to them is used instead.
+func (f *cloudStorageSinkFile) size() int64 {
+	if f.parquet != nil {
+		return int64(f.rawSize)
+	}
+	return int64(f.buf.Len())
+}
+
 // cloudStorageSink writes changefeed output to files in a cloud storage bucket
 // (S3/GCS/HTTP) maintaining CDC's ordering guarantees (see below) for each
 // row through lexicographical filename ordering.
@@ -175,9 +193,9 @@ func (f *cloudStorageSinkFile) Write(p []byte) (int, error) {
 // by a given `<sink_id>` and <session_id> is a unique identifying string for the job
 // session running the `changeAggregator` that owns this sink.
 //
-// `<ext>` implies the format of the file: currently the only option is
-// `ndjson`, which means a text file conforming to the "Newline Delimited JSON"
-// spec.
+// `<ext>` implies the format of the file: `ndjson`, which means a text file
+// conforming to the "Newline Delimited JSON" spec, `csv` or `parquet`. Parquet
+// files have a schema, which is the schema of the table version `<schema_id>`.
 //
 // This naming convention of data files is carefully chosen in order to preserve
 // the external ordering guarantees of CDC. Naming output files in this fashion
@@ -288,8 +306,10 @@ type cloudStorageSink struct {
 
 	ext          string
 	rowDelimiter []byte
+	format       changefeedbase.FormatType
 
-	compression string
+	compression        string
+	parquetCompression roachpb.IOFileFormat_Compression
 
 	es cloud.ExternalStorage
 
@@ -386,34 +406,46 @@ func makeCloudStorageSink(
 		s.dataFilePartition = s.timestampOracle.inclusiveLowerBoundTS().GoTime().Format(s.partitionFormat)
 	}
 
-	switch changefeedbase.FormatType(opts[changefeedbase.OptFormat]) {
+	s.format = changefeedbase.FormatType(opts[changefeedbase.OptFormat])
+	expectedEnvelope := changefeedbase.OptEnvelopeRow
+	switch s.format {
 	case changefeedbase.OptFormatJSON:
 		// TODO(dan): It seems like these should be on the encoder, but that
 		// would require a bit of refactoring.
 		s.ext = `.ndjson`
 		s.rowDelimiter = []byte{'\n'}
+		expectedEnvelope = changefeedbase.OptEnvelopeWrapped
+	case changefeedbase.OptFormatCSV:
+		s.ext = `.csv`
+		s.rowDelimiter = []byte{'\n'}
+	case changefeedbase.OptFormatParquet:
+		s.ext = `.parquet`
 	default:
 		return nil, errors.Errorf(`this sink is incompatible with %s=%s`,
 			changefeedbase.OptFormat, opts[changefeedbase.OptFormat])
 	}
 
-	switch changefeedbase.EnvelopeType(opts[changefeedbase.OptEnvelope]) {
-	case changefeedbase.OptEnvelopeWrapped:
-	default:
+	if envelope := changefeedbase.EnvelopeType(opts[changefeedbase.OptEnvelope]); envelope != expectedEnvelope {
 		return nil, errors.Errorf(`this sink is incompatible with %s=%s`,
 			changefeedbase.OptEnvelope, opts[changefeedbase.OptEnvelope])
 	}
 
-	if _, ok := opts[changefeedbase.OptKeyInValue]; !ok {
+	// Rows in the csv and parquet formats have every column of the table, so
+	// they do not need the key in the value.
+	if _, ok := opts[changefeedbase.OptKeyInValue]; !ok && s.format == changefeedbase.OptFormatJSON {
 		return nil, errors.Errorf(`this sink requires the WITH %s option`, changefeedbase.OptKeyInValue)
 	}
 
 	if codec, ok := opts[changefeedbase.OptCompression]; ok && codec != "" {
-		if strings.EqualFold(codec, "gzip") {
+		if !strings.EqualFold(codec, "gzip") {
+			return nil, errors.Errorf(`unsupported compression codec %q`, codec)
+		}
+		if s.format == changefeedbase.OptFormatParquet {
+			// Parquet files compress their pages themselves.
+			s.parquetCompression = roachpb.IOFileFormat_Gzip
+		} else {
 			s.compression = sinkCompressionGzip
 			s.ext = s.ext + ".gz"
-		} else {
-			return nil, errors.Errorf(`unsupported compression codec %q`, codec)
 		}
 	}
 
@@ -426,7 +458,7 @@ func makeCloudStorageSink(
 
 func (s *cloudStorageSink) getOrCreateFile(
 	topic TopicDescriptor, eventMVCC hlc.Timestamp,
-) *cloudStorageSinkFile {
+) (*cloudStorageSinkFile, error) {
 	name, _ := s.topicNamer.Name(topic)
 	key := cloudStorageSinkKey{name, int64(topic.GetVersion())}
 	if item := s.files.Get(key); item != nil {
@@ -434,7 +466,7 @@ func (s *cloudStorageSink) getOrCreateFile(
 		if eventMVCC.Less(f.oldestMVCC) {
 			f.oldestMVCC = eventMVCC
 		}
-		return f
+		return f, nil
 	}
 	f := &cloudStorageSinkFile{
 		created:             timeutil.Now(),
@@ -445,8 +477,14 @@ func (s *cloudStorageSink) getOrCreateFile(
 	case sinkCompressionGzip:
 		f.codec = gzip.NewWriter(&f.buf)
 	}
+	if s.format == changefeedbase.OptFormatParquet {
+		var err error
+		if f.parquet, err = newParquetFileWriter(topic, s.parquetCompression); err != nil {
+			return nil, err
+		}
+	}
 	s.files.ReplaceOrInsert(f)
-	return f
+	return f, nil
 }
 
 // EmitRow implements the Sink interface.
@@ -462,17 +500,22 @@ func (s *cloudStorageSink) EmitRow(
 	}
 
 	s.metrics.recordMessageSize(int64(len(key) + len(value)))
-	file := s.getOrCreateFile(topic, mvcc)
+	file, err := s.getOrCreateFile(topic, mvcc)
+	if err != nil {
+		return err
+	}
 	file.alloc.Merge(&alloc)
 
 	if _, err := file.Write(value); err != nil {
 		return err
 	}
-	if _, err := file.Write(s.rowDelimiter); err != nil {
-		return err
+	if s.rowDelimiter != nil {
+		if _, err := file.Write(s.rowDelimiter); err != nil {
+			return err
+		}
 	}
 
-	if int64(file.buf.Len()) > s.targetMaxFileSize {
+	if file.size() > s.targetMaxFileSize {
 		if err := s.flushTopicVersions(ctx, file.topic, file.schemaID); err != nil {
 			return err
 		}
@@ -582,6 +625,13 @@ func (s *cloudStorageSink) flushFile(ctx context.Context, file *cloudStorageSink
 			return err
 		}
 	}
+	contents := file.buf.Bytes()
+	if file.parquet != nil {
+		var err error
+		if contents, err = file.parquet.close(); err != nil {
+			return err
+		}
+	}
 
 	// We use this monotonically increasing fileID to ensure correct ordering
 	// among files emitted at the same timestamp during the same job session.
@@ -598,8 +648,8 @@ func (s *cloudStorageSink) flushFile(ctx context.Context, file *cloudStorageSink
 			"precedes a file emitted before: %s", filename, s.prevFilename)
 	}
 	s.prevFilename = filename
-	compressedBytes := file.buf.Len()
-	if err := cloud.WriteFile(ctx, s.es, filepath.Join(s.dataFilePartition, filename), bytes.NewReader(file.buf.Bytes())); err != nil {
+	compressedBytes := len(contents)
+	if err := cloud.WriteFile(ctx, s.es, filepath.Join(s.dataFilePartition, filename), bytes.NewReader(contents)); err != nil {
 		return err
 	}
 	s.metrics.recordEmittedBatch(file.created, file.numMessages, file.oldestMVCC, file.rawSize, compressedBytes)
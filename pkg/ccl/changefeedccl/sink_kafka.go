@@ -120,6 +120,18 @@ type saramaConfig struct {
 	RequiredAcks string `json:",omitempty"`
 
 	Version string `json:",omitempty"`
+
+	// Transactions configures exactly-once delivery. When enabled, rows are
+	// written by an idempotent, transactional producer and every changefeed
+	// checkpoint commits a kafka transaction which also records the resolved
+	// frontier in ProgressTopic. Once more than CommitBytes of rows are
+	// buffered, a checkpoint is taken as soon as possible. See
+	// transactionalKafkaSink.
+	Transactions struct {
+		Enabled       bool   `json:",omitempty"`
+		ProgressTopic string `json:",omitempty"`
+		CommitBytes   int64  `json:",omitempty"`
+	}
 }
 
 func (c saramaConfig) Validate() error {
@@ -131,6 +143,22 @@ func (c saramaConfig) Validate() error {
 	if (c.Flush.Bytes > 0 || c.Flush.Messages > 1) && c.Flush.Frequency == 0 {
 		return errors.New("Flush.Frequency must be > 0 when Flush.Bytes > 0 or Flush.Messages > 1")
 	}
+	if c.Transactions.Enabled {
+		// Idempotent producers, which transactions are built on, require every
+		// in-sync replica to acknowledge a write.
+		if c.RequiredAcks != "" {
+			if acks, err := parseRequiredAcks(c.RequiredAcks); err == nil && acks != sarama.WaitForAll {
+				return errors.New(`Transactions require RequiredAcks to be "ALL"`)
+			}
+		}
+		if c.Transactions.CommitBytes < 0 {
+			return errors.New("Transactions.CommitBytes must be >= 0")
+		}
+	} else if c.Transactions.ProgressTopic != "" {
+		return errors.New("Transactions.ProgressTopic requires Transactions.Enabled")
+	} else if c.Transactions.CommitBytes != 0 {
+		return errors.New("Transactions.CommitBytes requires Transactions.Enabled")
+	}
 	return nil
 }
 
@@ -220,10 +248,22 @@ func (s *kafkaSink) EmitRow(
 	alloc kvevent.Alloc,
 ) error {
 
-	topic, err := s.topics.Name(topicDescr)
+	msg, err := s.makeRowMessage(topicDescr, key, value, mvcc, alloc)
 	if err != nil {
 		return err
 	}
+	return s.emitMessage(ctx, msg)
+}
+
+// makeRowMessage returns the message for a row, accounting for it in the sink
+// stats.
+func (s *kafkaSink) makeRowMessage(
+	topicDescr TopicDescriptor, key, value []byte, mvcc hlc.Timestamp, alloc kvevent.Alloc,
+) (*sarama.ProducerMessage, error) {
+	topic, err := s.topics.Name(topicDescr)
+	if err != nil {
+		return nil, err
+	}
 
 	msg := &sarama.ProducerMessage{
 		Topic:    topic,
@@ -232,7 +272,7 @@ func (s *kafkaSink) EmitRow(
 		Metadata: messageMetadata{alloc: alloc, mvcc: mvcc, updateMetrics: s.metrics.recordOneMessage()},
 	}
 	s.stats.startMessage(int64(msg.Key.Length() + msg.Value.Length()))
-	return s.emitMessage(ctx, msg)
+	return msg, nil
 }
 
 // EmitResolvedTimestamp implements the Sink interface.
@@ -289,7 +329,12 @@ func (s *kafkaSink) EmitResolvedTimestamp(
 // Flush implements the Sink interface.
 func (s *kafkaSink) Flush(ctx context.Context) error {
 	defer s.metrics.recordFlushRequestCallback()()
+	return s.waitForInflight(ctx)
+}
 
+// waitForInflight blocks until every emitted message has been acknowledged,
+// returning the first error encountered since the last call.
+func (s *kafkaSink) waitForInflight(ctx context.Context) error {
 	flushCh := make(chan struct{}, 1)
 
 	s.mu.Lock()
@@ -418,6 +463,9 @@ func (p *changefeedPartitioner) RequiresConsistency() bool { return true }
 func (p *changefeedPartitioner) Partition(
 	message *sarama.ProducerMessage, numPartitions int32,
 ) (int32, error) {
+	if m, ok := message.Metadata.(progressMetadata); ok {
+		return p.hash.Partition(&sarama.ProducerMessage{Key: m.partitionKey}, numPartitions)
+	}
 	if message.Key == nil {
 		return message.Partition, nil
 	}
@@ -465,6 +513,17 @@ func (c *saramaConfig) Apply(kafka *sarama.Config) error {
 		}
 		kafka.Producer.RequiredAcks = parsedAcks
 	}
+	if c.Transactions.Enabled {
+		if !kafka.Version.IsAtLeast(sarama.V0_11_0_0) {
+			return errors.Errorf("Transactions require kafka Version %s or later", sarama.V0_11_0_0)
+		}
+		kafka.Producer.Idempotent = true
+		kafka.Producer.RequiredAcks = sarama.WaitForAll
+		kafka.Net.MaxOpenRequests = 1
+		// The progress topic is read back when a changefeed resumes; only
+		// records of committed transactions may be taken into account.
+		kafka.Consumer.IsolationLevel = sarama.ReadCommitted
+	}
 	return nil
 }
 
@@ -643,6 +702,7 @@ func makeKafkaSink(
 	u sinkURL,
 	targets []jobspb.ChangefeedTargetSpecification,
 	opts map[string]string,
+	jobID jobspb.JobID,
 	m *sliMetrics,
 ) (Sink, error) {
 	kafkaTopicPrefix := u.consumeParam(changefeedbase.SinkParamTopicPrefix)
@@ -677,6 +737,13 @@ func makeKafkaSink(
 			`unknown kafka sink query parameters: %s`, strings.Join(unknownParams, ", "))
 	}
 
+	// The sarama config was validated by buildKafkaConfig above.
+	saramaCfg, _ := getSaramaConfig(opts)
+	if saramaCfg.Transactions.Enabled {
+		return makeTransactionalKafkaSink(
+			sink, saramaCfg.Transactions.ProgressTopic, saramaCfg.Transactions.CommitBytes, jobID,
+		), nil
+	}
 	return sink, nil
 }
 
@@ -0,0 +1,519 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Shopify/sarama"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/cockroach/pkg/util/span"
+	"github.com/cockroachdb/errors"
+)
+
+// defaultKafkaProgressTopic is the topic in which a transactional kafka sink
+// records the resolved timestamps covered by its committed transactions, if
+// the kafka_sink_config does not name one.
+const defaultKafkaProgressTopic = `crdb_changefeed_progress`
+
+// defaultKafkaCommitBytes is the size of the buffered rows above which a
+// transactional kafka sink asks for a checkpoint as soon as the resolved
+// timestamp advances, if the kafka_sink_config does not set one.
+const defaultKafkaCommitBytes = 64 << 20
+
+// transactionalKafkaSink is a kafkaSink which delivers every row exactly once
+// to consumers reading with the read_committed isolation level.
+//
+// Once StartTransactions has been called, which only the changeAggregator
+// does, emitted rows are buffered in the sink. Every changefeed checkpoint
+// calls FlushResolved, which commits a kafka transaction containing the
+// buffered rows at or below the aggregator's resolved timestamp along with a
+// progress record stating up to which timestamp the aggregator's spans have
+// been delivered. Later rows stay buffered for a following transaction, and
+// keep their memory allocations until they are committed. Once the buffered
+// rows exceed commitBytes, the aggregator checkpoints as soon as its resolved
+// timestamp advances rather than waiting for the checkpoint frequency. If the
+// changefeed runs out of memory while rows above the resolved timestamp are
+// buffered, it is restarted, since those rows cannot be committed until more
+// resolved timestamps are received.
+//
+// Each change aggregator of a changefeed uses its own transactional ID,
+// derived from the job ID and the index of the aggregator in the changefeed
+// flow, and its own progress record key, "<job ID>/<aggregator index>". When
+// the changefeed resumes, StartTransactions fences off the producers of the
+// previous incarnation which used the same index, as well as those whose
+// index is no longer used if the changefeed now runs fewer aggregators, and
+// reads the committed progress records back so that the aggregator skips
+// rows which were already delivered.
+//
+// All the progress records of a changefeed are written to the same partition
+// of the progress topic, which is read from its oldest offset. Since every
+// progress record covers all the spans of its aggregator, the progress topic
+// may be compacted (cleanup.policy=compact), which retains the latest record
+// of each aggregator. Otherwise, its retention must exceed the longest time a
+// changefeed may be paused, or the changefeed will deliver rows again once it
+// resumes.
+//
+// Sinks which never start transactions, such as the changeFrontier's, behave
+// like a kafkaSink using an idempotent producer. Resolved timestamps are only
+// emitted after the rows they cover have been committed, but may be repeated
+// after a restart.
+type transactionalKafkaSink struct {
+	*kafkaSink
+
+	jobID         jobspb.JobID
+	progressTopic string
+	commitBytes   int64
+	// progressKey is the key of the progress records of the aggregator, and
+	// markerKey that of the markers committed by StartTransactions.
+	progressKey []byte
+	markerKey   []byte
+
+	// newProducer and newConsumer connect to kafka. They are overridden in
+	// tests.
+	newProducer func(cfg *sarama.Config) (sarama.AsyncProducer, error)
+	newConsumer func(cfg *sarama.Config) (sarama.Consumer, error)
+
+	// started is set once StartTransactions has switched the sink to a
+	// transactional producer.
+	started bool
+	// spans are the spans whose rows are emitted into the sink, and progress
+	// is the frontier of those spans as recorded in the progress topic.
+	spans    []roachpb.Span
+	progress *span.Frontier
+	// buffered contains the rows emitted since the last commit, in the order
+	// they were emitted, and bufferedBytes their size.
+	buffered      []bufferedKafkaMessage
+	bufferedBytes int64
+}
+
+type bufferedKafkaMessage struct {
+	msg     *sarama.ProducerMessage
+	updated hlc.Timestamp
+}
+
+func (m bufferedKafkaMessage) size() int64 {
+	return int64(m.msg.Key.Length() + m.msg.Value.Length())
+}
+
+// progressMetadata is the metadata of the messages produced to the progress
+// topic. They are partitioned by partitionKey, which is the same for all the
+// records of a changefeed, rather than by their key.
+type progressMetadata struct {
+	partitionKey sarama.Encoder
+}
+
+var _ transactionalSink = (*transactionalKafkaSink)(nil)
+
+func makeTransactionalKafkaSink(
+	s *kafkaSink, progressTopic string, commitBytes int64, jobID jobspb.JobID,
+) *transactionalKafkaSink {
+	if progressTopic == "" {
+		progressTopic = defaultKafkaProgressTopic
+	}
+	if commitBytes == 0 {
+		commitBytes = defaultKafkaCommitBytes
+	}
+	addrs := strings.Split(s.bootstrapAddrs, `,`)
+	return &transactionalKafkaSink{
+		kafkaSink:     s,
+		jobID:         jobID,
+		progressTopic: progressTopic,
+		commitBytes:   commitBytes,
+		newProducer: func(cfg *sarama.Config) (sarama.AsyncProducer, error) {
+			return sarama.NewAsyncProducer(addrs, cfg)
+		},
+		newConsumer: func(cfg *sarama.Config) (sarama.Consumer, error) {
+			return sarama.NewConsumer(addrs, cfg)
+		},
+	}
+}
+
+// kafkaTransactionalID returns the transactional ID of the producer of the
+// change aggregator with the given index.
+func kafkaTransactionalID(jobID jobspb.JobID, aggregatorIndex int32) string {
+	return fmt.Sprintf("crdb-changefeed-%d-%d", jobID, aggregatorIndex)
+}
+
+// progressKeyPrefix returns the prefix of the keys of all the progress records
+// and markers of the changefeed.
+func (s *transactionalKafkaSink) progressKeyPrefix() string {
+	return strconv.FormatInt(int64(s.jobID), 10) + `/`
+}
+
+// parseAggregatorIndex returns the index of the aggregator which produced the
+// progress record or marker with the given key, and false if the key does not
+// belong to the changefeed.
+func (s *transactionalKafkaSink) parseAggregatorIndex(key []byte) (int32, bool) {
+	prefix := s.progressKeyPrefix()
+	if !bytes.HasPrefix(key, []byte(prefix)) {
+		return 0, false
+	}
+	rest := string(key[len(prefix):])
+	if i := strings.IndexByte(rest, '/'); i >= 0 {
+		rest = rest[:i]
+	}
+	idx, err := strconv.ParseInt(rest, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return int32(idx), true
+}
+
+// newTransactionalProducer returns a producer using the given transactional
+// ID. Creating it fences off any other producer using the same transactional
+// ID and aborts its open transaction.
+func (s *transactionalKafkaSink) newTransactionalProducer(
+	transactionalID string,
+) (sarama.AsyncProducer, error) {
+	cfg := *s.kafkaCfg
+	cfg.Producer.Transaction.ID = transactionalID
+	producer, err := s.newProducer(&cfg)
+	if err != nil {
+		return nil, pgerror.Wrapf(err, pgcode.CannotConnectNow,
+			`connecting to kafka: %s`, s.bootstrapAddrs)
+	}
+	return producer, nil
+}
+
+// StartTransactions implements the transactionalSink interface.
+func (s *transactionalKafkaSink) StartTransactions(
+	ctx context.Context, spans []roachpb.Span, aggregatorIndex, numAggregators int32,
+) (*span.Frontier, error) {
+	if numAggregators <= 0 {
+		return nil, errors.AssertionFailedf("unknown number of change aggregators")
+	}
+	producer, err := s.newTransactionalProducer(kafkaTransactionalID(s.jobID, aggregatorIndex))
+	if err != nil {
+		return nil, err
+	}
+
+	// Nothing has been emitted yet, so the producer created by Dial can simply
+	// be replaced.
+	close(s.stopWorkerCh)
+	s.worker.Wait()
+	_ = s.producer.Close()
+	s.producer = producer
+	s.start()
+	s.started = true
+	s.spans = spans
+	s.progressKey = []byte(fmt.Sprintf("%s%d", s.progressKeyPrefix(), aggregatorIndex))
+	s.markerKey = []byte(fmt.Sprintf("%s%d/start", s.progressKeyPrefix(), aggregatorIndex))
+
+	fenced := make(map[int32]struct{})
+	for {
+		// Commit a marker to the progress topic. Since a read_committed
+		// consumer only returns the marker once every transaction writing
+		// before it has completed, reading up to the marker yields all the
+		// progress recorded so far.
+		marker := s.makeProgressMessage(s.markerKey, nil)
+		if err := s.commitTxn(ctx, []bufferedKafkaMessage{{msg: marker}}, nil); err != nil {
+			return nil, err
+		}
+		progress, indexes, err := s.readProgress(ctx, marker.Partition, marker.Offset)
+		if err != nil {
+			return nil, err
+		}
+
+		// The producers of previous incarnations of the changefeed which used
+		// an index no longer in use have not been fenced off by any current
+		// aggregator. Each aggregator fences off those whose index is
+		// congruent to its own, and then reads the progress again since they
+		// may have committed before being fenced.
+		var stale []int32
+		for _, idx := range indexes {
+			if _, ok := fenced[idx]; !ok && idx >= numAggregators && idx%numAggregators == aggregatorIndex {
+				stale = append(stale, idx)
+			}
+		}
+		if len(stale) == 0 {
+			s.progress = progress
+			return copyFrontier(progress)
+		}
+		for _, idx := range stale {
+			p, err := s.newTransactionalProducer(kafkaTransactionalID(s.jobID, idx))
+			if err != nil {
+				return nil, err
+			}
+			_ = p.Close()
+			fenced[idx] = struct{}{}
+		}
+	}
+}
+
+// copyFrontier returns a frontier with the same spans and timestamps as f.
+func copyFrontier(f *span.Frontier) (*span.Frontier, error) {
+	var spans []roachpb.Span
+	f.Entries(func(sp roachpb.Span, _ hlc.Timestamp) span.OpResult {
+		spans = append(spans, sp)
+		return span.ContinueMatch
+	})
+	c, err := span.MakeFrontier(spans...)
+	if err != nil {
+		return nil, err
+	}
+	var forwardErr error
+	f.Entries(func(sp roachpb.Span, ts hlc.Timestamp) span.OpResult {
+		if _, forwardErr = c.Forward(sp, ts); forwardErr != nil {
+			return span.StopMatch
+		}
+		return span.ContinueMatch
+	})
+	return c, forwardErr
+}
+
+// readProgress returns the frontier of the sink's spans recorded by the
+// progress records of the changefeed in the given partition, up to the marker
+// offset, along with the indexes of the aggregators which wrote them.
+func (s *transactionalKafkaSink) readProgress(
+	ctx context.Context, partition int32, markerOffset int64,
+) (*span.Frontier, []int32, error) {
+	committed, err := span.MakeFrontier(s.spans...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	consumer, err := s.newConsumer(s.kafkaCfg)
+	if err != nil {
+		return nil, nil, pgerror.Wrapf(err, pgcode.CannotConnectNow,
+			`connecting to kafka: %s`, s.bootstrapAddrs)
+	}
+	defer func() { _ = consumer.Close() }()
+	pc, err := consumer.ConsumePartition(s.progressTopic, partition, sarama.OffsetOldest)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, `reading kafka topic %s`, s.progressTopic)
+	}
+	defer func() { _ = pc.Close() }()
+
+	seen := make(map[int32]struct{})
+	var indexes []int32
+	for {
+		var msg *sarama.ConsumerMessage
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case m, ok := <-pc.Messages():
+			if !ok {
+				return nil, nil, errors.Newf(`reading kafka topic %s: consumer closed`, s.progressTopic)
+			}
+			msg = m
+		}
+		if msg.Offset >= markerOffset {
+			return committed, indexes, nil
+		}
+		idx, ok := s.parseAggregatorIndex(msg.Key)
+		if !ok {
+			continue
+		}
+		if _, ok := seen[idx]; !ok {
+			seen[idx] = struct{}{}
+			indexes = append(indexes, idx)
+		}
+		if len(msg.Value) == 0 {
+			continue
+		}
+		var progress jobspb.ResolvedSpans
+		if err := protoutil.Unmarshal(msg.Value, &progress); err != nil {
+			return nil, nil, errors.Wrapf(err, `decoding progress record at offset %d of kafka topic %s`,
+				msg.Offset, s.progressTopic)
+		}
+		for _, resolved := range progress.ResolvedSpans {
+			if _, err := committed.Forward(resolved.Span, resolved.Timestamp); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+}
+
+// makeProgressMessage returns a message for the progress topic.
+func (s *transactionalKafkaSink) makeProgressMessage(key, value []byte) *sarama.ProducerMessage {
+	msg := &sarama.ProducerMessage{
+		Topic:    s.progressTopic,
+		Key:      sarama.ByteEncoder(key),
+		Metadata: progressMetadata{partitionKey: sarama.StringEncoder(s.progressKeyPrefix())},
+	}
+	if value != nil {
+		msg.Value = sarama.ByteEncoder(value)
+	}
+	return msg
+}
+
+// EmitRow implements the Sink interface.
+func (s *transactionalKafkaSink) EmitRow(
+	ctx context.Context,
+	topicDescr TopicDescriptor,
+	key, value []byte,
+	updated, mvcc hlc.Timestamp,
+	alloc kvevent.Alloc,
+) error {
+	if !s.started {
+		return s.kafkaSink.EmitRow(ctx, topicDescr, key, value, updated, mvcc, alloc)
+	}
+	msg, err := s.makeRowMessage(topicDescr, key, value, mvcc, alloc)
+	if err != nil {
+		return err
+	}
+	m := bufferedKafkaMessage{msg: msg, updated: updated}
+	s.buffered = append(s.buffered, m)
+	s.bufferedBytes += m.size()
+	return nil
+}
+
+// ShouldFlushResolved implements the transactionalSink interface.
+func (s *transactionalKafkaSink) ShouldFlushResolved() bool {
+	return s.started && s.bufferedBytes >= s.commitBytes
+}
+
+// NoteSpanChange implements the transactionalSink interface.
+func (s *transactionalKafkaSink) NoteSpanChange(change jobspb.ResolvedSpan) error {
+	if !s.started {
+		return nil
+	}
+	switch change.SpanChange {
+	case jobspb.ResolvedSpan_ADDED:
+		s.spans = append(s.spans, change.Span)
+		return s.progress.AddSpansAt(change.Timestamp, change.Span)
+	case jobspb.ResolvedSpan_REMOVED:
+		var spans roachpb.SpanGroup
+		spans.Add(s.spans...)
+		spans.Sub(change.Span)
+		s.spans = spans.Slice()
+		_, err := s.progress.RemoveSpans(change.Span)
+		return err
+	}
+	return nil
+}
+
+// FlushResolved implements the transactionalSink interface.
+func (s *transactionalKafkaSink) FlushResolved(ctx context.Context, resolved hlc.Timestamp) error {
+	defer s.metrics.recordFlushRequestCallback()()
+
+	if resolved.IsEmpty() {
+		return nil
+	}
+
+	var committing, remaining []bufferedKafkaMessage
+	var remainingBytes int64
+	for _, m := range s.buffered {
+		if m.updated.LessEq(resolved) {
+			committing = append(committing, m)
+		} else {
+			remaining = append(remaining, m)
+			remainingBytes += m.size()
+		}
+	}
+
+	// The progress record covers all the spans of the aggregator, including
+	// those whose committed progress is ahead of resolved, so that it
+	// supersedes the aggregator's previous records.
+	for _, sp := range s.spans {
+		if _, err := s.progress.Forward(sp, resolved); err != nil {
+			return err
+		}
+	}
+	progress := &jobspb.ResolvedSpans{}
+	s.progress.Entries(func(sp roachpb.Span, ts hlc.Timestamp) span.OpResult {
+		progress.ResolvedSpans = append(progress.ResolvedSpans,
+			jobspb.ResolvedSpan{Span: sp, Timestamp: ts})
+		return span.ContinueMatch
+	})
+	if err := s.commitTxn(ctx, committing, progress); err != nil {
+		return err
+	}
+	s.buffered, s.bufferedBytes = remaining, remainingBytes
+	return nil
+}
+
+// Flush implements the Sink interface.
+//
+// Rows are only committed along with a progress record by FlushResolved, so
+// the buffered rows, which were all emitted after the last resolved timestamp,
+// stay buffered until the next checkpoint. The changeAggregator only flushes
+// the sink when it runs out of memory, after checkpointing its resolved
+// timestamp. If rows are still buffered, their memory cannot be released
+// until the resolved timestamp advances, which itself requires memory, so
+// Flush returns a retryable error to restart the changefeed.
+func (s *transactionalKafkaSink) Flush(ctx context.Context) error {
+	if !s.started {
+		return s.kafkaSink.Flush(ctx)
+	}
+	if len(s.buffered) > 0 {
+		return changefeedbase.MarkRetryableError(errors.Newf(
+			`out of memory with %d rows (%d bytes) above the resolved timestamp %s buffered in the kafka sink`,
+			len(s.buffered), s.bufferedBytes, s.progress.Frontier()))
+	}
+	return nil
+}
+
+// Close implements the Sink interface.
+func (s *transactionalKafkaSink) Close() error {
+	for _, m := range s.buffered {
+		if md, ok := m.msg.Metadata.(messageMetadata); ok {
+			md.alloc.Release(s.ctx)
+		}
+	}
+	s.buffered, s.bufferedBytes = nil, 0
+	return s.kafkaSink.Close()
+}
+
+// commitTxn produces the messages, followed by the progress record if it is
+// non-nil, in a single kafka transaction.
+func (s *transactionalKafkaSink) commitTxn(
+	ctx context.Context, msgs []bufferedKafkaMessage, progress *jobspb.ResolvedSpans,
+) error {
+	if len(msgs) == 0 && progress == nil {
+		return nil
+	}
+
+	if err := s.producer.BeginTxn(); err != nil {
+		return errors.Wrap(err, `beginning kafka transaction`)
+	}
+	err := func() error {
+		for _, m := range msgs {
+			if err := s.emitMessage(ctx, m.msg); err != nil {
+				return err
+			}
+		}
+		if progress != nil {
+			value, err := protoutil.Marshal(progress)
+			if err != nil {
+				return err
+			}
+			if err := s.emitMessage(ctx, s.makeProgressMessage(s.progressKey, value)); err != nil {
+				return err
+			}
+		}
+		if err := s.waitForInflight(ctx); err != nil {
+			return err
+		}
+		return s.producer.CommitTxn()
+	}()
+	if err != nil {
+		// Transactions which are left open are aborted when the changefeed
+		// restarts and its new producer fences this one off.
+		if s.producer.TxnStatus()&sarama.ProducerTxnFlagAbortableError != 0 {
+			if abortErr := s.producer.AbortTxn(); abortErr != nil {
+				err = errors.CombineErrors(err, abortErr)
+			}
+		}
+		return errors.Wrap(err, `committing kafka transaction`)
+	}
+	return nil
+}
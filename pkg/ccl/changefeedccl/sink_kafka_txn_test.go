@@ -0,0 +1,347 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/cockroach/pkg/util/span"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingConsumer consumes and acknowledges the messages sent to an
+// asyncProducerMock, assigning them consecutive offsets.
+type recordingConsumer struct {
+	syncutil.Mutex
+	nextOffset int64
+	produced   []*sarama.ProducerMessage
+}
+
+func (r *recordingConsumer) start(p *asyncProducerMock) (cleanup func()) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	done := make(chan struct{})
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			case m := <-p.inputCh:
+				r.Lock()
+				m.Offset = r.nextOffset
+				r.nextOffset++
+				r.produced = append(r.produced, m)
+				r.Unlock()
+				p.successesCh <- m
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+// take returns the messages produced since the last call.
+func (r *recordingConsumer) take() []*sarama.ProducerMessage {
+	r.Lock()
+	defer r.Unlock()
+	produced := r.produced
+	r.produced = nil
+	return produced
+}
+
+type consumerMock struct {
+	sarama.Consumer
+	topic     string
+	partition int32
+	messages  []*sarama.ConsumerMessage
+}
+
+func (c *consumerMock) ConsumePartition(
+	topic string, partition int32, _ int64,
+) (sarama.PartitionConsumer, error) {
+	c.topic, c.partition = topic, partition
+	ch := make(chan *sarama.ConsumerMessage, len(c.messages))
+	for _, m := range c.messages {
+		ch <- m
+	}
+	return &partitionConsumerMock{messages: ch}, nil
+}
+
+func (c *consumerMock) Close() error { return nil }
+
+type partitionConsumerMock struct {
+	sarama.PartitionConsumer
+	messages chan *sarama.ConsumerMessage
+}
+
+func (pc *partitionConsumerMock) Messages() <-chan *sarama.ConsumerMessage { return pc.messages }
+func (pc *partitionConsumerMock) Close() error                             { return nil }
+
+func makeTestTransactionalKafkaSink(
+	t *testing.T, p *asyncProducerMock, spans ...roachpb.Span,
+) *transactionalKafkaSink {
+	sink, _ := makeTestKafkaSink(t, noTopicPrefix, defaultTopicName, p, `t`)
+	s := makeTransactionalKafkaSink(sink, ``, 0 /* commitBytes */, 1)
+	s.started = true
+	s.spans = spans
+	s.progressKey = []byte(`1/0`)
+	var err error
+	s.progress, err = span.MakeFrontier(spans...)
+	require.NoError(t, err)
+	return s
+}
+
+func decodeProgress(t *testing.T, msg *sarama.ProducerMessage) jobspb.ResolvedSpans {
+	require.Equal(t, defaultKafkaProgressTopic, msg.Topic)
+	key, err := msg.Key.Encode()
+	require.NoError(t, err)
+	require.Equal(t, `1/0`, string(key))
+	value, err := msg.Value.Encode()
+	require.NoError(t, err)
+	var progress jobspb.ResolvedSpans
+	require.NoError(t, protoutil.Unmarshal(value, &progress))
+	return progress
+}
+
+func TestTransactionalKafkaSink(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	p := newAsyncProducerMock(unbuffered)
+	var r recordingConsumer
+	stopConsume := r.start(p)
+
+	sp := roachpb.Span{Key: roachpb.Key(`a`), EndKey: roachpb.Key(`b`)}
+	s := makeTestTransactionalKafkaSink(t, p, sp)
+
+	// Rows are buffered until they are committed.
+	var pool testAllocPool
+	for i := 1; i <= 3; i++ {
+		ts := hlc.Timestamp{WallTime: int64(i)}
+		require.NoError(t, s.EmitRow(ctx, topic(`t`), []byte{byte(i)}, nil, ts, ts, pool.alloc()))
+	}
+	require.Empty(t, r.take())
+	require.EqualValues(t, 3, pool.used())
+
+	// Rows at or below the resolved timestamp are committed along with a
+	// progress record.
+	resolved := hlc.Timestamp{WallTime: 2}
+	require.NoError(t, s.FlushResolved(ctx, resolved))
+	produced := r.take()
+	require.Len(t, produced, 3)
+	for i, msg := range produced[:2] {
+		require.Equal(t, sarama.ByteEncoder{byte(i + 1)}, msg.Key)
+	}
+	require.Equal(t, jobspb.ResolvedSpans{
+		ResolvedSpans: []jobspb.ResolvedSpan{{Span: sp, Timestamp: resolved}},
+	}, decodeProgress(t, produced[2]))
+	require.Equal(t, 1, p.mu.committed)
+	require.EqualValues(t, 1, pool.used())
+
+	// Flush does not commit the remaining rows, which are not covered by a
+	// progress record, and cannot release their memory.
+	require.Regexp(t, `out of memory with 1 rows \(1 bytes\) above the resolved timestamp`, s.Flush(ctx))
+	require.Empty(t, r.take())
+	require.Equal(t, 1, p.mu.committed)
+	require.EqualValues(t, 1, pool.used())
+
+	// The rows are committed by the next checkpoint.
+	resolved = hlc.Timestamp{WallTime: 3}
+	require.NoError(t, s.FlushResolved(ctx, resolved))
+	produced = r.take()
+	require.Len(t, produced, 2)
+	require.Equal(t, sarama.ByteEncoder{3}, produced[0].Key)
+	require.Equal(t, jobspb.ResolvedSpans{
+		ResolvedSpans: []jobspb.ResolvedSpan{{Span: sp, Timestamp: resolved}},
+	}, decodeProgress(t, produced[1]))
+	require.Equal(t, 2, p.mu.committed)
+	require.EqualValues(t, 0, pool.used())
+	require.NoError(t, s.Flush(ctx))
+
+	// Closing the sink releases the rows which are still buffered.
+	ts := hlc.Timestamp{WallTime: 4}
+	require.NoError(t, s.EmitRow(ctx, topic(`t`), []byte{4}, nil, ts, ts, pool.alloc()))
+	require.EqualValues(t, 1, pool.used())
+	stopConsume()
+	require.NoError(t, s.Close())
+	require.EqualValues(t, 0, pool.used())
+}
+
+func TestTransactionalKafkaSinkShouldFlushResolved(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	p := newAsyncProducerMock(unbuffered)
+	var r recordingConsumer
+	stopConsume := r.start(p)
+
+	s := makeTestTransactionalKafkaSink(t, p, roachpb.Span{Key: roachpb.Key(`a`), EndKey: roachpb.Key(`b`)})
+	defer func() {
+		stopConsume()
+		require.NoError(t, s.Close())
+	}()
+	s.commitBytes = 2
+
+	// A checkpoint is requested once the buffered rows reach commitBytes.
+	for i := 1; i <= 2; i++ {
+		require.False(t, s.ShouldFlushResolved())
+		ts := hlc.Timestamp{WallTime: int64(i)}
+		require.NoError(t, s.EmitRow(ctx, topic(`t`), []byte{byte(i)}, nil, ts, ts, zeroAlloc))
+	}
+	require.True(t, s.ShouldFlushResolved())
+
+	// Only the rows which remain buffered after a commit count.
+	require.NoError(t, s.FlushResolved(ctx, hlc.Timestamp{WallTime: 1}))
+	require.False(t, s.ShouldFlushResolved())
+	require.NoError(t, s.FlushResolved(ctx, hlc.Timestamp{WallTime: 2}))
+	require.False(t, s.ShouldFlushResolved())
+	require.Len(t, r.take(), 4)
+}
+
+func TestTransactionalKafkaSinkAbortsFailedTransactions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	p := newAsyncProducerMock(unbuffered)
+	var r recordingConsumer
+	stopConsume := r.start(p)
+
+	s := makeTestTransactionalKafkaSink(t, p, roachpb.Span{Key: roachpb.Key(`a`), EndKey: roachpb.Key(`b`)})
+	defer func() {
+		stopConsume()
+		require.NoError(t, s.Close())
+	}()
+
+	p.mu.commitErr = errors.New(`boom`)
+	ts := hlc.Timestamp{WallTime: 1}
+	require.NoError(t, s.EmitRow(ctx, topic(`t`), []byte{1}, nil, ts, ts, zeroAlloc))
+	require.Regexp(t, `committing kafka transaction: boom`, s.FlushResolved(ctx, ts))
+	require.Equal(t, 0, p.mu.committed)
+	require.Equal(t, 1, p.mu.aborted)
+}
+
+func TestTransactionalKafkaSinkStartTransactions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	progressRecord := func(offset int64, key string, ts int64, spans ...roachpb.Span) *sarama.ConsumerMessage {
+		var progress jobspb.ResolvedSpans
+		for _, sp := range spans {
+			progress.ResolvedSpans = append(progress.ResolvedSpans,
+				jobspb.ResolvedSpan{Span: sp, Timestamp: hlc.Timestamp{WallTime: ts}})
+		}
+		value, err := protoutil.Marshal(&progress)
+		require.NoError(t, err)
+		return &sarama.ConsumerMessage{Offset: offset, Key: []byte(key), Value: value}
+	}
+	mkSpan := func(start, end string) roachpb.Span {
+		return roachpb.Span{Key: roachpb.Key(start), EndKey: roachpb.Key(end)}
+	}
+	consumer := &consumerMock{messages: []*sarama.ConsumerMessage{
+		progressRecord(0, `1/0`, 5, mkSpan(`a`, `c`)),
+		// Records of other changefeeds are ignored.
+		progressRecord(1, `2/0`, 100, mkSpan(`a`, `z`)),
+		// Records of the other aggregators are taken into account, since the
+		// spans may have been assigned to them by a previous incarnation.
+		progressRecord(2, `1/1`, 7, mkSpan(`b`, `d`)),
+		// The changefeed previously ran a third aggregator, which is fenced
+		// off by this one.
+		{Offset: 3, Key: []byte(`1/2/start`)},
+		// The markers committed by StartTransactions.
+		{Offset: 4, Key: []byte(`1/0/start`)},
+		{Offset: 5, Key: []byte(`1/0/start`)},
+	}}
+
+	initial := newAsyncProducerMock(unbuffered)
+	sink, _ := makeTestKafkaSink(t, noTopicPrefix, defaultTopicName, initial, `t`)
+	sink.kafkaCfg = sarama.NewConfig()
+	s := makeTransactionalKafkaSink(sink, ``, 0 /* commitBytes */, 1)
+
+	p := newAsyncProducerMock(unbuffered)
+	r := recordingConsumer{nextOffset: 4}
+	stopConsume := r.start(p)
+	defer func() {
+		stopConsume()
+		require.NoError(t, s.Close())
+	}()
+	var transactionalIDs []string
+	s.newProducer = func(cfg *sarama.Config) (sarama.AsyncProducer, error) {
+		transactionalIDs = append(transactionalIDs, cfg.Producer.Transaction.ID)
+		if len(transactionalIDs) == 1 {
+			return p, nil
+		}
+		return newAsyncProducerMock(unbuffered), nil
+	}
+	s.newConsumer = func(*sarama.Config) (sarama.Consumer, error) {
+		return consumer, nil
+	}
+
+	committed, err := s.StartTransactions(ctx, []roachpb.Span{mkSpan(`a`, `e`)}, 0 /* aggregatorIndex */, 2 /* numAggregators */)
+	require.NoError(t, err)
+	require.Equal(t, []string{`crdb-changefeed-1-0`, `crdb-changefeed-1-2`}, transactionalIDs)
+	// The progress is read again once the stale producer has been fenced off.
+	require.Equal(t, 2, p.mu.committed)
+	require.Equal(t, defaultKafkaProgressTopic, consumer.topic)
+	for _, marker := range r.take() {
+		require.Equal(t, sarama.ByteEncoder(`1/0/start`), marker.Key)
+	}
+
+	type entry struct {
+		span roachpb.Span
+		ts   int64
+	}
+	entries := func(f *span.Frontier) []entry {
+		var entries []entry
+		f.Entries(func(sp roachpb.Span, ts hlc.Timestamp) span.OpResult {
+			entries = append(entries, entry{span: sp, ts: ts.WallTime})
+			return span.ContinueMatch
+		})
+		return entries
+	}
+	require.Equal(t, []entry{
+		{span: mkSpan(`a`, `b`), ts: 5},
+		{span: mkSpan(`b`, `d`), ts: 7},
+		{span: mkSpan(`d`, `e`), ts: 0},
+	}, entries(committed))
+
+	// The progress records of the aggregator cover the progress previously
+	// committed for its spans, so that they supersede every earlier record.
+	require.NoError(t, s.FlushResolved(ctx, hlc.Timestamp{WallTime: 6}))
+	produced := r.take()
+	require.Len(t, produced, 1)
+	require.Equal(t, jobspb.ResolvedSpans{ResolvedSpans: []jobspb.ResolvedSpan{
+		{Span: mkSpan(`a`, `b`), Timestamp: hlc.Timestamp{WallTime: 6}},
+		{Span: mkSpan(`b`, `d`), Timestamp: hlc.Timestamp{WallTime: 7}},
+		{Span: mkSpan(`d`, `e`), Timestamp: hlc.Timestamp{WallTime: 6}},
+	}}, decodeProgress(t, produced[0]))
+	// The frontier returned to the aggregator is not affected.
+	require.Equal(t, []entry{
+		{span: mkSpan(`a`, `b`), ts: 5},
+		{span: mkSpan(`b`, `d`), ts: 7},
+		{span: mkSpan(`d`, `e`), ts: 0},
+	}, entries(committed))
+}
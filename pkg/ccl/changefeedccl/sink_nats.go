@@ -0,0 +1,468 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nkeys"
+)
+
+const (
+	// natsKeyHeader is the header of NATS messages holding the encoded key of
+	// the row.
+	natsKeyHeader = `Crdb-Key`
+
+	// natsMaxPendingAcks is the maximum number of messages that may be
+	// published without having been acknowledged by JetStream. Emitting
+	// blocks once it is reached.
+	natsMaxPendingAcks = 4096
+
+	// natsAckTimeout is how long to wait for JetStream to acknowledge a
+	// published message before failing the flush.
+	natsAckTimeout = 30 * time.Second
+)
+
+func isNATSSink(u *url.URL) bool {
+	return u.Scheme == changefeedbase.SinkSchemeNATS
+}
+
+// natsSink emits to NATS JetStream asynchronously. Each topic is published to
+// its own subject, which must be captured by a JetStream stream, and Flush
+// waits until JetStream has acknowledged every published message. It is not
+// concurrency-safe; all calls to Emit and Flush should be from the same
+// goroutine.
+type natsSink struct {
+	ctx        context.Context
+	servers    string
+	connOpts   []nats.Option
+	ackTimeout time.Duration
+	topics     *TopicNamer
+	metrics    *sliMetrics
+
+	conn *nats.Conn
+	js   nats.JetStreamContext
+
+	// pendingCh holds the messages awaiting an ack, in publish order.
+	pendingCh    chan natsPendingMessage
+	stopWorkerCh chan struct{}
+	worker       sync.WaitGroup
+
+	// Only synchronized between the client goroutine and the worker goroutine.
+	mu struct {
+		syncutil.Mutex
+		inflight int64
+		flushErr error
+		flushCh  chan struct{}
+	}
+}
+
+var _ SinkWithTopics = (*natsSink)(nil)
+
+// natsPendingMessage is a message published to JetStream that has not yet
+// been acknowledged.
+type natsPendingMessage struct {
+	future        nats.PubAckFuture
+	alloc         kvevent.Alloc
+	mvcc          hlc.Timestamp
+	updateMetrics recordOneMessageCallback
+	finishPublish func(error)
+}
+
+func makeNATSSink(
+	ctx context.Context,
+	u sinkURL,
+	targets []jobspb.ChangefeedTargetSpecification,
+	opts map[string]string,
+	m *sliMetrics,
+) (Sink, error) {
+	switch changefeedbase.FormatType(opts[changefeedbase.OptFormat]) {
+	case changefeedbase.OptFormatJSON:
+	default:
+		return nil, errors.Errorf(`this sink is incompatible with %s=%s`,
+			changefeedbase.OptFormat, opts[changefeedbase.OptFormat])
+	}
+
+	topicPrefix := u.consumeParam(changefeedbase.SinkParamTopicPrefix)
+	topicName := u.consumeParam(changefeedbase.SinkParamTopicName)
+	if schemaTopic := u.consumeParam(changefeedbase.SinkParamSchemaTopic); schemaTopic != `` {
+		return nil, errors.Errorf(`%s is not yet supported`, changefeedbase.SinkParamSchemaTopic)
+	}
+
+	connOpts, err := buildNATSOptions(u)
+	if err != nil {
+		return nil, err
+	}
+
+	topics, err := MakeTopicNamer(
+		targets,
+		WithPrefix(topicPrefix), WithSingleName(topicName), WithSanitizeFn(SQLNameToNATSSubject))
+	if err != nil {
+		return nil, err
+	}
+
+	if unknownParams := u.remainingQueryParams(); len(unknownParams) > 0 {
+		return nil, errors.Errorf(
+			`unknown nats sink query parameters: %s`, strings.Join(unknownParams, ", "))
+	}
+
+	var servers []string
+	for _, host := range strings.Split(u.Host, `,`) {
+		servers = append(servers, changefeedbase.SinkSchemeNATS+`://`+host)
+	}
+
+	return &natsSink{
+		ctx:        ctx,
+		servers:    strings.Join(servers, `,`),
+		connOpts:   connOpts,
+		ackTimeout: natsAckTimeout,
+		topics:     topics,
+		metrics:    m,
+	}, nil
+}
+
+// buildNATSOptions returns the connection options for the TLS and credentials
+// parameters of the sink URL. The user info of the URL is either a user and a
+// password or, when there is no password, an authentication token.
+func buildNATSOptions(u sinkURL) ([]nats.Option, error) {
+	dialConfig := struct {
+		tlsEnabled    bool
+		tlsSkipVerify bool
+		caCert        []byte
+		clientCert    []byte
+		clientKey     []byte
+		creds         []byte
+	}{}
+
+	if _, err := u.consumeBool(changefeedbase.SinkParamTLSEnabled, &dialConfig.tlsEnabled); err != nil {
+		return nil, err
+	}
+	if _, err := u.consumeBool(changefeedbase.SinkParamSkipTLSVerify, &dialConfig.tlsSkipVerify); err != nil {
+		return nil, err
+	}
+	if err := u.decodeBase64(changefeedbase.SinkParamCACert, &dialConfig.caCert); err != nil {
+		return nil, err
+	}
+	if err := u.decodeBase64(changefeedbase.SinkParamClientCert, &dialConfig.clientCert); err != nil {
+		return nil, err
+	}
+	if err := u.decodeBase64(changefeedbase.SinkParamClientKey, &dialConfig.clientKey); err != nil {
+		return nil, err
+	}
+	if err := u.decodeBase64(changefeedbase.SinkParamNATSCredentials, &dialConfig.creds); err != nil {
+		return nil, err
+	}
+
+	opts := []nats.Option{nats.Name(`CockroachDB`)}
+
+	if dialConfig.tlsEnabled {
+		tlsConfig := &tls.Config{
+			InsecureSkipVerify: dialConfig.tlsSkipVerify,
+		}
+
+		if dialConfig.caCert != nil {
+			caCertPool := x509.NewCertPool()
+			caCertPool.AppendCertsFromPEM(dialConfig.caCert)
+			tlsConfig.RootCAs = caCertPool
+		}
+
+		if dialConfig.clientCert != nil && dialConfig.clientKey == nil {
+			return nil, errors.Errorf(`%s requires %s to be set`, changefeedbase.SinkParamClientCert, changefeedbase.SinkParamClientKey)
+		} else if dialConfig.clientKey != nil && dialConfig.clientCert == nil {
+			return nil, errors.Errorf(`%s requires %s to be set`, changefeedbase.SinkParamClientKey, changefeedbase.SinkParamClientCert)
+		}
+
+		if dialConfig.clientCert != nil && dialConfig.clientKey != nil {
+			cert, err := tls.X509KeyPair(dialConfig.clientCert, dialConfig.clientKey)
+			if err != nil {
+				return nil, errors.Wrap(err, `invalid client certificate data provided`)
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+		opts = append(opts, nats.Secure(tlsConfig))
+	} else {
+		if dialConfig.caCert != nil {
+			return nil, errors.Errorf(`%s requires %s=true`, changefeedbase.SinkParamCACert, changefeedbase.SinkParamTLSEnabled)
+		}
+		if dialConfig.clientCert != nil {
+			return nil, errors.Errorf(`%s requires %s=true`, changefeedbase.SinkParamClientCert, changefeedbase.SinkParamTLSEnabled)
+		}
+	}
+
+	if dialConfig.creds != nil {
+		if u.User != nil {
+			return nil, errors.Errorf(`%s cannot be used with a user or token in the sink URI`,
+				changefeedbase.SinkParamNATSCredentials)
+		}
+		credsOpt, err := natsCredentialsOption(dialConfig.creds)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, credsOpt)
+	} else if u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			opts = append(opts, nats.UserInfo(u.User.Username(), password))
+		} else {
+			opts = append(opts, nats.Token(u.User.Username()))
+		}
+	}
+	return opts, nil
+}
+
+// natsCredentialsOption returns a connection option authenticating with the
+// user JWT and the nkey seed of the contents of a NATS credentials file.
+func natsCredentialsOption(creds []byte) (nats.Option, error) {
+	userJWT, err := nkeys.ParseDecoratedJWT(creds)
+	if err != nil {
+		return nil, errors.Wrapf(err, `invalid %s`, changefeedbase.SinkParamNATSCredentials)
+	}
+	keyPair, err := nkeys.ParseDecoratedNKey(creds)
+	if err != nil {
+		return nil, errors.Wrapf(err, `invalid %s`, changefeedbase.SinkParamNATSCredentials)
+	}
+	return nats.UserJWT(
+		func() (string, error) { return userJWT, nil },
+		keyPair.Sign,
+	), nil
+}
+
+func (s *natsSink) start() {
+	s.pendingCh = make(chan natsPendingMessage, natsMaxPendingAcks)
+	s.stopWorkerCh = make(chan struct{})
+	s.worker.Add(1)
+	go s.workerLoop()
+}
+
+// Dial implements the Sink interface.
+func (s *natsSink) Dial() error {
+	conn, err := nats.Connect(s.servers, s.connOpts...)
+	if err != nil {
+		return pgerror.Wrapf(err, pgcode.CannotConnectNow,
+			`connecting to nats: %s`, s.servers)
+	}
+	// The worker bounds the number of pending acks to natsMaxPendingAcks,
+	// leave JetStream some slack so that publishing never stalls.
+	js, err := conn.JetStream(nats.PublishAsyncMaxPending(2 * natsMaxPendingAcks))
+	if err != nil {
+		conn.Close()
+		return pgerror.Wrapf(err, pgcode.CannotConnectNow,
+			`connecting to nats jetstream: %s`, s.servers)
+	}
+	s.conn = conn
+	s.js = js
+	s.start()
+	return nil
+}
+
+// Close implements the Sink interface.
+func (s *natsSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	close(s.stopWorkerCh)
+	s.worker.Wait()
+	// If we're shutting down, we don't care what happens to the outstanding
+	// messages.
+	s.conn.Close()
+	return nil
+}
+
+// EmitRow implements the Sink interface.
+func (s *natsSink) EmitRow(
+	ctx context.Context,
+	topicDescr TopicDescriptor,
+	key, value []byte,
+	updated, mvcc hlc.Timestamp,
+	alloc kvevent.Alloc,
+) error {
+	subject, err := s.topics.Name(topicDescr)
+	if err != nil {
+		return err
+	}
+
+	msg := &nats.Msg{
+		Subject: subject,
+		Header:  nats.Header{natsKeyHeader: []string{string(key)}},
+		Data:    value,
+	}
+	return s.emitMessage(ctx, msg, natsPendingMessage{
+		alloc:         alloc,
+		mvcc:          mvcc,
+		updateMetrics: s.metrics.recordOneMessage(),
+	})
+}
+
+// EmitResolvedTimestamp implements the Sink interface.
+func (s *natsSink) EmitResolvedTimestamp(
+	ctx context.Context, encoder Encoder, resolved hlc.Timestamp,
+) error {
+	defer s.metrics.recordResolvedCallback()()
+
+	return s.topics.Each(func(subject string) error {
+		payload, err := encoder.EncodeResolvedTimestamp(ctx, subject, resolved)
+		if err != nil {
+			return err
+		}
+		return s.emitMessage(ctx, &nats.Msg{Subject: subject, Data: payload}, natsPendingMessage{})
+	})
+}
+
+// Flush implements the Sink interface.
+func (s *natsSink) Flush(ctx context.Context) error {
+	defer s.metrics.recordFlushRequestCallback()()
+
+	flushCh := make(chan struct{}, 1)
+
+	s.mu.Lock()
+	inflight := s.mu.inflight
+	flushErr := s.mu.flushErr
+	s.mu.flushErr = nil
+	immediateFlush := inflight == 0 || flushErr != nil
+	if !immediateFlush {
+		s.mu.flushCh = flushCh
+	}
+	s.mu.Unlock()
+
+	if immediateFlush {
+		return flushErr
+	}
+
+	if log.V(1) {
+		log.Infof(ctx, "flush waiting for %d unacknowledged messages", inflight)
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-flushCh:
+		s.mu.Lock()
+		flushErr := s.mu.flushErr
+		s.mu.flushErr = nil
+		s.mu.Unlock()
+		return flushErr
+	}
+}
+
+// emitMessage publishes the message to JetStream and hands it to the worker,
+// which waits for it to be acknowledged.
+func (s *natsSink) emitMessage(
+	ctx context.Context, msg *nats.Msg, pending natsPendingMessage,
+) error {
+	s.mu.Lock()
+	s.mu.inflight++
+	s.mu.Unlock()
+
+	pending.finishPublish = s.metrics.recordNATSPublish()
+	future, err := s.js.PublishMsgAsync(msg)
+	if err != nil {
+		s.abandonMessage(pending, err)
+		return errors.Wrapf(err, `publishing to nats subject %s`, msg.Subject)
+	}
+	pending.future = future
+
+	select {
+	case <-ctx.Done():
+		// The worker will never see the message, so its acknowledgement is not
+		// waited for.
+		s.abandonMessage(pending, ctx.Err())
+		return ctx.Err()
+	case s.pendingCh <- pending:
+	}
+	return nil
+}
+
+// abandonMessage undoes the accounting of a message which is not handed to
+// the worker.
+func (s *natsSink) abandonMessage(pending natsPendingMessage, err error) {
+	pending.finishPublish(err)
+	pending.alloc.Release(s.ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mu.inflight--
+	if s.mu.inflight == 0 && s.mu.flushCh != nil {
+		s.mu.flushCh <- struct{}{}
+		s.mu.flushCh = nil
+	}
+}
+
+// awaitAck waits for JetStream to acknowledge the message.
+func (s *natsSink) awaitAck(future nats.PubAckFuture) error {
+	var timer timeutil.Timer
+	defer timer.Stop()
+	timer.Reset(s.ackTimeout)
+
+	select {
+	case <-future.Ok():
+		return nil
+	case err := <-future.Err():
+		return errors.Wrapf(err, `publishing to nats subject %s`, future.Msg().Subject)
+	case <-timer.C:
+		timer.Read = true
+		return errors.Errorf(`timed out after %s waiting for nats to acknowledge message published to subject %s`,
+			s.ackTimeout, future.Msg().Subject)
+	case <-s.stopWorkerCh:
+		return errors.New(`nats sink closed`)
+	}
+}
+
+func (s *natsSink) workerLoop() {
+	defer s.worker.Done()
+
+	for {
+		var m natsPendingMessage
+		select {
+		case <-s.stopWorkerCh:
+			return
+		case m = <-s.pendingCh:
+		}
+
+		ackErr := s.awaitAck(m.future)
+		m.finishPublish(ackErr)
+		if ackErr == nil && m.updateMetrics != nil {
+			msg := m.future.Msg()
+			m.updateMetrics(m.mvcc, len(msg.Header.Get(natsKeyHeader))+len(msg.Data), sinkDoesNotCompress)
+		}
+		m.alloc.Release(s.ctx)
+
+		s.mu.Lock()
+		s.mu.inflight--
+		if s.mu.flushErr == nil && ackErr != nil {
+			s.mu.flushErr = ackErr
+		}
+
+		if s.mu.inflight == 0 && s.mu.flushCh != nil {
+			s.mu.flushCh <- struct{}{}
+			s.mu.flushCh = nil
+		}
+		s.mu.Unlock()
+	}
+}
+
+// Topics gives the names of all topics that have been initialized
+// and will receive resolved timestamps.
+func (s *natsSink) Topics() []string {
+	return s.topics.DisplayNamesSlice()
+}
@@ -0,0 +1,257 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+)
+
+// startTestNATSServer starts an embedded NATS server with JetStream enabled.
+func startTestNATSServer(t *testing.T, opts server.Options) (*server.Server, func()) {
+	dir, dirCleanupFn := testutils.TempDir(t)
+	opts.Host = `127.0.0.1`
+	opts.Port = server.RANDOM_PORT
+	opts.NoLog = true
+	opts.NoSigs = true
+	opts.JetStream = true
+	opts.StoreDir = dir
+
+	s, err := server.NewServer(&opts)
+	require.NoError(t, err)
+	go s.Start()
+	if !s.ReadyForConnections(10 * time.Second) {
+		t.Fatal(`nats server not ready for connections`)
+	}
+	return s, func() {
+		s.Shutdown()
+		s.WaitForShutdown()
+		dirCleanupFn()
+	}
+}
+
+// addTestNATSStream adds a JetStream stream capturing the subjects prefixed by
+// `cdc.`.
+func addTestNATSStream(t *testing.T, serverURL string, connOpts ...nats.Option) {
+	conn, err := nats.Connect(serverURL, connOpts...)
+	require.NoError(t, err)
+	defer conn.Close()
+	js, err := conn.JetStream()
+	require.NoError(t, err)
+	_, err = js.AddStream(&nats.StreamConfig{Name: `CDC`, Subjects: []string{`cdc.>`}})
+	require.NoError(t, err)
+}
+
+func makeTestNATSSink(
+	t *testing.T, sinkURI string, targetNames ...string,
+) (*natsSink, func()) {
+	u, err := url.Parse(sinkURI)
+	require.NoError(t, err)
+	opts := map[string]string{
+		changefeedbase.OptFormat:   string(changefeedbase.OptFormatJSON),
+		changefeedbase.OptEnvelope: string(changefeedbase.OptEnvelopeWrapped),
+	}
+	s, err := makeNATSSink(
+		context.Background(), sinkURL{URL: u}, makeChangefeedTargets(targetNames...), opts, nil)
+	require.NoError(t, err)
+	require.NoError(t, s.Dial())
+	return s.(*natsSink), func() {
+		require.NoError(t, s.Close())
+	}
+}
+
+func TestNATSSink(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	srv, cleanup := startTestNATSServer(t, server.Options{})
+	defer cleanup()
+	addTestNATSStream(t, srv.ClientURL())
+
+	ctx := context.Background()
+	sink, sinkCleanup := makeTestNATSSink(t, `nats://`+srv.Addr().String()+`?topic_prefix=cdc.`, `t`, `☃`)
+	defer sinkCleanup()
+	require.ElementsMatch(t, []string{`cdc.t`, `cdc._u2603_`}, sink.Topics())
+
+	conn, err := nats.Connect(srv.ClientURL())
+	require.NoError(t, err)
+	defer conn.Close()
+	js, err := conn.JetStream()
+	require.NoError(t, err)
+	sub, err := js.SubscribeSync(`cdc.>`)
+	require.NoError(t, err)
+
+	var pool testAllocPool
+	require.NoError(t, sink.EmitRow(ctx, topic(`t`), []byte(`[1]`), []byte(`{"a":1}`), zeroTS, zeroTS, pool.alloc()))
+	require.NoError(t, sink.EmitRow(ctx, topic(`t`), []byte(`[2]`), []byte(`{"a":2}`), zeroTS, zeroTS, pool.alloc()))
+	require.NoError(t, sink.Flush(ctx))
+	require.EqualValues(t, 0, pool.used())
+
+	for _, expected := range []struct {
+		subject, key, value string
+	}{
+		{`cdc.t`, `[1]`, `{"a":1}`},
+		{`cdc.t`, `[2]`, `{"a":2}`},
+	} {
+		msg, err := sub.NextMsg(10 * time.Second)
+		require.NoError(t, err)
+		require.Equal(t, expected.subject, msg.Subject)
+		require.Equal(t, expected.key, msg.Header.Get(natsKeyHeader))
+		require.Equal(t, expected.value, string(msg.Data))
+	}
+
+	// Resolved timestamps are published to every subject.
+	resolved := hlc.Timestamp{WallTime: 1}
+	require.NoError(t, sink.EmitResolvedTimestamp(ctx, testEncoder{}, resolved))
+	require.NoError(t, sink.Flush(ctx))
+	var subjects []string
+	for i := 0; i < 2; i++ {
+		msg, err := sub.NextMsg(10 * time.Second)
+		require.NoError(t, err)
+		require.Equal(t, resolved.String(), string(msg.Data))
+		subjects = append(subjects, msg.Subject)
+	}
+	require.ElementsMatch(t, []string{`cdc.t`, `cdc._u2603_`}, subjects)
+}
+
+func TestNATSSinkFlushReturnsPublishErrors(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	srv, cleanup := startTestNATSServer(t, server.Options{})
+	defer cleanup()
+	addTestNATSStream(t, srv.ClientURL())
+
+	ctx := context.Background()
+	// No stream captures the subject of this sink, so its messages are
+	// rejected.
+	sink, sinkCleanup := makeTestNATSSink(t, `nats://`+srv.Addr().String()+`?topic_name=nostream`, `t`)
+	defer sinkCleanup()
+
+	var pool testAllocPool
+	require.NoError(t, sink.EmitRow(ctx, topic(`t`), []byte(`[1]`), []byte(`{"a":1}`), zeroTS, zeroTS, pool.alloc()))
+	require.Regexp(t, `publishing to nats subject nostream`, sink.Flush(ctx))
+	require.EqualValues(t, 0, pool.used())
+
+	// The error is only returned once.
+	require.NoError(t, sink.Flush(ctx))
+}
+
+func TestNATSSinkEmitReleasesCancelledMessages(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	srv, cleanup := startTestNATSServer(t, server.Options{})
+	defer cleanup()
+	addTestNATSStream(t, srv.ClientURL())
+
+	sink, _ := makeTestNATSSink(t, `nats://`+srv.Addr().String()+`?topic_prefix=cdc.`, `t`)
+	defer sink.conn.Close()
+
+	// Stop the worker and fill its queue, so that emitting blocks until the
+	// context is cancelled.
+	close(sink.stopWorkerCh)
+	sink.worker.Wait()
+	for i := 0; i < natsMaxPendingAcks; i++ {
+		sink.pendingCh <- natsPendingMessage{}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var pool testAllocPool
+	require.Equal(t, context.Canceled,
+		sink.EmitRow(ctx, topic(`t`), []byte(`[1]`), []byte(`{"a":1}`), zeroTS, zeroTS, pool.alloc()))
+	require.EqualValues(t, 0, pool.used())
+
+	// The abandoned message is not waited for.
+	require.NoError(t, sink.Flush(context.Background()))
+}
+
+func TestNATSSinkAuthentication(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	srv, cleanup := startTestNATSServer(t, server.Options{Username: `user`, Password: `secret`})
+	defer cleanup()
+	addTestNATSStream(t, srv.ClientURL(), nats.UserInfo(`user`, `secret`))
+
+	ctx := context.Background()
+	sink, sinkCleanup := makeTestNATSSink(t, `nats://user:secret@`+srv.Addr().String()+`?topic_prefix=cdc.`, `t`)
+	defer sinkCleanup()
+	require.NoError(t, sink.EmitRow(ctx, topic(`t`), []byte(`[1]`), []byte(`{"a":1}`), zeroTS, zeroTS, zeroAlloc))
+	require.NoError(t, sink.Flush(ctx))
+
+	u, err := url.Parse(`nats://user:wrong@` + srv.Addr().String())
+	require.NoError(t, err)
+	opts := map[string]string{changefeedbase.OptFormat: string(changefeedbase.OptFormatJSON)}
+	badSink, err := makeNATSSink(ctx, sinkURL{URL: u}, makeChangefeedTargets(`t`), opts, nil)
+	require.NoError(t, err)
+	require.Regexp(t, `connecting to nats`, badSink.Dial())
+}
+
+func TestNATSSinkParams(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	jsonOpts := map[string]string{changefeedbase.OptFormat: string(changefeedbase.OptFormatJSON)}
+	for _, tc := range []struct {
+		uri  string
+		opts map[string]string
+		err  string
+	}{
+		{
+			uri:  `nats://localhost:4222?foo=bar`,
+			opts: jsonOpts,
+			err:  `unknown nats sink query parameters: foo`,
+		},
+		{
+			uri:  `nats://localhost:4222?ca_cert=Zm9v`,
+			opts: jsonOpts,
+			err:  `ca_cert requires tls_enabled=true`,
+		},
+		{
+			uri:  `nats://localhost:4222?tls_enabled=true&client_cert=Zm9v`,
+			opts: jsonOpts,
+			err:  `client_cert requires client_key to be set`,
+		},
+		{
+			uri:  `nats://localhost:4222?creds=Zm9v`,
+			opts: jsonOpts,
+			err:  `invalid creds`,
+		},
+		{
+			uri:  `nats://token@localhost:4222?creds=Zm9v`,
+			opts: jsonOpts,
+			err:  `creds cannot be used with a user or token in the sink URI`,
+		},
+		{
+			uri:  `nats://localhost:4222`,
+			opts: map[string]string{changefeedbase.OptFormat: string(changefeedbase.OptFormatAvro)},
+			err:  `this sink is incompatible with format=avro`,
+		},
+	} {
+		t.Run(tc.uri, func(t *testing.T) {
+			u, err := url.Parse(tc.uri)
+			require.NoError(t, err)
+			_, err = makeNATSSink(context.Background(), sinkURL{URL: u}, makeChangefeedTargets(`t`), tc.opts, nil)
+			require.Regexp(t, tc.err, err)
+		})
+	}
+}
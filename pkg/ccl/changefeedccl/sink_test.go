@@ -45,6 +45,11 @@ type asyncProducerMock struct {
 	mu          struct {
 		syncutil.Mutex
 		outstanding []*sarama.ProducerMessage
+
+		// Transaction state, used by transactionalKafkaSink tests.
+		txnStatus          sarama.ProducerTxnStatusFlag
+		commitErr          error
+		committed, aborted int
 	}
 }
 
@@ -69,6 +74,51 @@ func (p *asyncProducerMock) Close() error {
 	return nil
 }
 
+func (p *asyncProducerMock) IsTransactional() bool { return true }
+
+func (p *asyncProducerMock) TxnStatus() sarama.ProducerTxnStatusFlag {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.mu.txnStatus
+}
+
+func (p *asyncProducerMock) BeginTxn() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.mu.txnStatus = sarama.ProducerTxnFlagInTransaction
+	return nil
+}
+
+func (p *asyncProducerMock) CommitTxn() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.mu.commitErr != nil {
+		p.mu.txnStatus = sarama.ProducerTxnFlagInError | sarama.ProducerTxnFlagAbortableError
+		return p.mu.commitErr
+	}
+	p.mu.txnStatus = sarama.ProducerTxnFlagReady
+	p.mu.committed++
+	return nil
+}
+
+func (p *asyncProducerMock) AbortTxn() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.mu.txnStatus = sarama.ProducerTxnFlagReady
+	p.mu.aborted++
+	return nil
+}
+
+func (p *asyncProducerMock) AddOffsetsToTxn(
+	map[string][]*sarama.PartitionOffsetMetadata, string,
+) error {
+	panic(`unimplemented`)
+}
+
+func (p *asyncProducerMock) AddMessageToTxn(*sarama.ConsumerMessage, string, *string) error {
+	panic(`unimplemented`)
+}
+
 // consumeAndSucceed consumes input messages and sends them to successes channel.
 // Returns function that must be called to stop this consumer
 // to clean up. The cleanup function must be called before closing asyncProducerMock.
@@ -651,6 +701,42 @@ func TestSaramaConfigOptionParsing(t *testing.T) {
 		require.Error(t, err)
 
 	})
+	t.Run("validate checks transactions configuration", func(t *testing.T) {
+		opts := make(map[string]string)
+		opts[changefeedbase.OptKafkaSinkConfig] = `{"Transactions": {"Enabled": true}, "RequiredAcks": "ALL"}`
+		cfg, err := getSaramaConfig(opts)
+		require.NoError(t, err)
+		require.NoError(t, cfg.Validate())
+
+		opts[changefeedbase.OptKafkaSinkConfig] = `{"Transactions": {"Enabled": true}, "RequiredAcks": "ONE"}`
+		cfg, err = getSaramaConfig(opts)
+		require.NoError(t, err)
+		require.Regexp(t, `Transactions require RequiredAcks to be "ALL"`, cfg.Validate())
+
+		opts[changefeedbase.OptKafkaSinkConfig] = `{"Transactions": {"ProgressTopic": "progress"}}`
+		cfg, err = getSaramaConfig(opts)
+		require.NoError(t, err)
+		require.Regexp(t, `requires Transactions.Enabled`, cfg.Validate())
+	})
+	t.Run("apply configures transactions", func(t *testing.T) {
+		opts := make(map[string]string)
+		opts[changefeedbase.OptKafkaSinkConfig] = `{"Transactions": {"Enabled": true}}`
+
+		cfg, err := getSaramaConfig(opts)
+		require.NoError(t, err)
+
+		saramaCfg := sarama.NewConfig()
+		require.NoError(t, cfg.Apply(saramaCfg))
+		require.True(t, saramaCfg.Producer.Idempotent)
+		require.Equal(t, sarama.WaitForAll, saramaCfg.Producer.RequiredAcks)
+		require.Equal(t, 1, saramaCfg.Net.MaxOpenRequests)
+		require.Equal(t, sarama.ReadCommitted, saramaCfg.Consumer.IsolationLevel)
+
+		opts[changefeedbase.OptKafkaSinkConfig] = `{"Transactions": {"Enabled": true}, "Version": "0.10.2.0"}`
+		cfg, err = getSaramaConfig(opts)
+		require.NoError(t, err)
+		require.Regexp(t, `Transactions require kafka Version 0.11.0.0 or later`, cfg.Apply(sarama.NewConfig()))
+	})
 }
 
 func TestKafkaSinkTracksMemory(t *testing.T) {
@@ -0,0 +1,307 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package ldapccl
+
+import (
+	"context"
+	"crypto/tls"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/hba"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/identmap"
+	"github.com/cockroachdb/errors"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// usernamePlaceholder is replaced with the name of the connecting user in the
+// ldapsearchfilter option.
+const usernamePlaceholder = "$username"
+
+// ldapConf is the configuration of the LDAP authentication method, taken
+// from the options of an HBA entry:
+//
+//   ldapserver            host name of the LDAP server (required)
+//   ldapport              port of the LDAP server (389, or 636 for ldaps)
+//   ldapscheme            "ldap" (default) or "ldaps"
+//   ldaptls               "1" to upgrade the connection with StartTLS
+//   ldapbasedn            DN under which users and groups are searched (required)
+//   ldapbinddn            DN to bind as when searching (anonymous if unset)
+//   ldapbindpasswd        password for ldapbinddn
+//   ldapsearchattribute   attribute matched against the user name (default uid)
+//   ldapsearchfilter      filter to find the user, in which $username is
+//                         replaced with the user name
+//   ldapgrouplistfilter   filter that lists the groups that are synchronized
+//                         to SQL roles; enables group synchronization
+//   ldapgroupmemberattribute  attribute of the groups that lists the DNs of
+//                             their members (default member)
+//   ldapgroupmap          identity map that maps group names to SQL roles
+//   ldapsyncadmin         "1" to allow groups to be synchronized to the admin
+//                         role
+//   map                   identity map that maps user names to SQL users
+type ldapConf struct {
+	server   string
+	port     string
+	scheme   string
+	startTLS bool
+
+	baseDN          string
+	bindDN          string
+	bindPassword    string
+	searchAttribute string
+	searchFilter    string
+
+	groupListFilter      string
+	groupMemberAttribute string
+	groupMap             string
+	syncAdmin            bool
+}
+
+// parseLDAPConf extracts and validates the LDAP configuration of the given
+// HBA entry.
+func parseLDAPConf(entry hba.Entry) (*ldapConf, error) {
+	conf := &ldapConf{
+		scheme:               "ldap",
+		groupMemberAttribute: "member",
+	}
+	var tlsOpt, syncAdminOpt string
+	for _, op := range entry.Options {
+		switch op[0] {
+		case "ldapserver":
+			conf.server = op[1]
+		case "ldapport":
+			conf.port = op[1]
+		case "ldapscheme":
+			conf.scheme = op[1]
+		case "ldaptls":
+			tlsOpt = op[1]
+		case "ldapbasedn":
+			conf.baseDN = op[1]
+		case "ldapbinddn":
+			conf.bindDN = op[1]
+		case "ldapbindpasswd":
+			conf.bindPassword = op[1]
+		case "ldapsearchattribute":
+			conf.searchAttribute = op[1]
+		case "ldapsearchfilter":
+			conf.searchFilter = op[1]
+		case "ldapgrouplistfilter":
+			conf.groupListFilter = op[1]
+		case "ldapgroupmemberattribute":
+			conf.groupMemberAttribute = op[1]
+		case "ldapgroupmap":
+			conf.groupMap = op[1]
+		case "ldapsyncadmin":
+			syncAdminOpt = op[1]
+		case "map":
+		// OK.
+		default:
+			return nil, errors.Errorf("unsupported option %s", op[0])
+		}
+	}
+
+	if conf.server == "" {
+		return nil, errors.New(`the "ldapserver" option is required`)
+	}
+	if conf.baseDN == "" {
+		return nil, errors.New(`the "ldapbasedn" option is required`)
+	}
+	if _, err := ldap.ParseDN(conf.baseDN); err != nil {
+		return nil, errors.Wrap(err, "invalid ldapbasedn")
+	}
+	if conf.bindDN != "" {
+		if _, err := ldap.ParseDN(conf.bindDN); err != nil {
+			return nil, errors.Wrap(err, "invalid ldapbinddn")
+		}
+	} else if conf.bindPassword != "" {
+		return nil, errors.New(`the "ldapbindpasswd" option requires "ldapbinddn"`)
+	}
+
+	switch conf.scheme {
+	case "ldap":
+		if conf.port == "" {
+			conf.port = "389"
+		}
+	case "ldaps":
+		if conf.port == "" {
+			conf.port = "636"
+		}
+	default:
+		return nil, errors.Errorf(`ldapscheme must be "ldap" or "ldaps": %s`, conf.scheme)
+	}
+	if port, err := strconv.Atoi(conf.port); err != nil || port <= 0 || port > 65535 {
+		return nil, errors.Errorf("invalid ldapport: %s", conf.port)
+	}
+	switch tlsOpt {
+	case "", "0":
+	case "1":
+		if conf.scheme == "ldaps" {
+			return nil, errors.New(`"ldaptls=1" cannot be combined with "ldapscheme=ldaps"`)
+		}
+		conf.startTLS = true
+	default:
+		return nil, errors.Errorf("ldaptls must be set to 0 or 1: %s", tlsOpt)
+	}
+
+	if conf.searchFilter != "" {
+		if conf.searchAttribute != "" {
+			return nil, errors.New(
+				`the "ldapsearchattribute" and "ldapsearchfilter" options cannot be combined`)
+		}
+		if !strings.Contains(conf.searchFilter, usernamePlaceholder) {
+			return nil, errors.Newf("ldapsearchfilter must contain %s", usernamePlaceholder)
+		}
+		if _, err := ldap.CompileFilter(conf.userFilter("user")); err != nil {
+			return nil, errors.Wrap(err, "invalid ldapsearchfilter")
+		}
+	} else if conf.searchAttribute == "" {
+		conf.searchAttribute = "uid"
+	}
+
+	if conf.groupListFilter != "" {
+		if _, err := ldap.CompileFilter(conf.groupListFilter); err != nil {
+			return nil, errors.Wrap(err, "invalid ldapgrouplistfilter")
+		}
+	} else if conf.groupMap != "" {
+		return nil, errors.New(`the "ldapgroupmap" option requires "ldapgrouplistfilter"`)
+	}
+	switch syncAdminOpt {
+	case "", "0":
+	case "1":
+		if conf.groupListFilter == "" {
+			return nil, errors.New(`the "ldapsyncadmin" option requires "ldapgrouplistfilter"`)
+		}
+		conf.syncAdmin = true
+	default:
+		return nil, errors.Errorf("ldapsyncadmin must be set to 0 or 1: %s", syncAdminOpt)
+	}
+	return conf, nil
+}
+
+// checkHBAEntry validates the options of an HBA entry that uses the LDAP
+// authentication method.
+func checkHBAEntry(_ *settings.Values, entry hba.Entry) error {
+	_, err := parseLDAPConf(entry)
+	return err
+}
+
+// authLDAP is the AuthMethod constructor for HBA method "ldap":
+// authenticate using a cleartext password received from the client, which is
+// verified by binding to an LDAP server as the directory entry of the user.
+//
+// The entry is found with a search under ldapbasedn, so that users can be
+// located anywhere in the directory. If group synchronization is enabled,
+// the SQL roles that the user's groups map to are granted to the user and
+// the roles of the other synchronized groups are revoked.
+func authLDAP(
+	_ context.Context,
+	c pgwire.AuthConn,
+	_ tls.ConnectionState,
+	execCfg *sql.ExecutorConfig,
+	entry *hba.Entry,
+	identMap *identmap.Conf,
+) (*pgwire.AuthBehaviors, error) {
+	b := &pgwire.AuthBehaviors{}
+	conf, err := parseLDAPConf(*entry)
+	if err != nil {
+		return b, err
+	}
+
+	mapper := pgwire.HbaMapper(entry, identMap)
+	b.SetRoleMapper(mapper)
+	b.SetAuthenticator(func(
+		ctx context.Context,
+		systemIdentity security.SQLUsername,
+		_ bool,
+		_ pgwire.PasswordRetrievalFn,
+	) error {
+		password, err := pgwire.GetCleartextPassword(ctx, c)
+		if err != nil {
+			return err
+		}
+		// An empty password would result in an unauthenticated bind, which
+		// LDAP servers accept for any DN.
+		if password == "" {
+			c.LogAuthInfof(ctx, "empty password")
+			return security.NewErrPasswordUserAuthFailed(systemIdentity)
+		}
+
+		groups, err := ldapAuthenticate(ctx, execCfg, conf, systemIdentity.Normalized(), password)
+		if err != nil {
+			c.LogAuthInfof(ctx, "LDAP authentication failed: %v", err)
+			return security.NewErrPasswordUserAuthFailed(systemIdentity)
+		}
+
+		// Do the license check only once the directory has accepted the
+		// credentials, so that administrators are able to test whether their
+		// LDAP configuration is correct.
+		if err := utilccl.CheckEnterpriseEnabled(
+			execCfg.Settings, execCfg.LogicalClusterID(), execCfg.Organization(), "LDAP authentication",
+		); err != nil {
+			return err
+		}
+
+		if conf.groupListFilter == "" {
+			return nil
+		}
+		dbUsers, err := mapper(ctx, systemIdentity)
+		if err != nil {
+			return err
+		}
+		return syncGroupRoles(ctx, execCfg, identMap, conf, dbUsers[0], groups)
+	})
+	return b, nil
+}
+
+// ldapAuthenticate verifies the password of the given user with the LDAP
+// server. If group synchronization is enabled, it returns the groups that
+// are synchronized.
+func ldapAuthenticate(
+	ctx context.Context, execCfg *sql.ExecutorConfig, conf *ldapConf, user, password string,
+) ([]ldapGroup, error) {
+	sv := &execCfg.Settings.SV
+	conn, err := ldapDialer(ctx, conf, LDAPCACertificate.Get(sv), LDAPTimeout.Get(sv))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if conf.bindDN != "" {
+		if err := conn.Bind(conf.bindDN, conf.bindPassword); err != nil {
+			return nil, errors.Wrap(err, "binding as ldapbinddn")
+		}
+	}
+	userDN, err := searchUserDN(conn, conf, user)
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.Bind(userDN, password); err != nil {
+		return nil, errors.Wrapf(err, "binding as %q", userDN)
+	}
+
+	if conf.groupListFilter == "" {
+		return nil, nil
+	}
+	// List the groups with the same privileges as the user search.
+	if conf.bindDN != "" {
+		if err := conn.Bind(conf.bindDN, conf.bindPassword); err != nil {
+			return nil, errors.Wrap(err, "binding as ldapbinddn")
+		}
+	}
+	return searchGroups(conn, conf, userDN)
+}
+
+func init() {
+	pgwire.RegisterAuthMethod("ldap", authLDAP, hba.ConnAny, checkHBAEntry)
+}
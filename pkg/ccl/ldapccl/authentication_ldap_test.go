@@ -0,0 +1,295 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package ldapccl
+
+import (
+	"context"
+	gosql "database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/security/securitytest"
+	"github.com/cockroachdb/cockroach/pkg/server"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/hba"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/randutil"
+	"github.com/cockroachdb/errors"
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	defer utilccl.TestingEnableEnterprise()()
+	security.SetAssetLoader(securitytest.EmbeddedAssets)
+	randutil.SeedForTests()
+	serverutils.InitTestServerFactory(server.TestServerFactory)
+	os.Exit(m.Run())
+}
+
+// fakeDirectory is an in-process stand-in for an LDAP server.
+type fakeDirectory struct {
+	entries   []*ldap.Entry
+	passwords map[string]string
+}
+
+func (d *fakeDirectory) addEntry(dn, password string, attrs map[string][]string) {
+	d.entries = append(d.entries, ldap.NewEntry(dn, attrs))
+	if password != "" {
+		d.passwords[dn] = password
+	}
+}
+
+func (d *fakeDirectory) dial(context.Context, *ldapConf, string, time.Duration) (ldapConn, error) {
+	return &fakeConn{dir: d}, nil
+}
+
+type fakeConn struct {
+	dir *fakeDirectory
+}
+
+var _ ldapConn = &fakeConn{}
+
+// Bind implements the ldapConn interface.
+func (c *fakeConn) Bind(username, password string) error {
+	if pw, ok := c.dir.passwords[username]; !ok || pw != password {
+		return ldap.NewError(ldap.LDAPResultInvalidCredentials, errors.New("invalid credentials"))
+	}
+	return nil
+}
+
+// Search implements the ldapConn interface.
+func (c *fakeConn) Search(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	baseDN, err := ldap.ParseDN(req.BaseDN)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := ldap.CompileFilter(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	res := &ldap.SearchResult{}
+	for _, entry := range c.dir.entries {
+		dn, err := ldap.ParseDN(entry.DN)
+		if err != nil {
+			return nil, err
+		}
+		if !baseDN.AncestorOfFold(dn) || !matchesFilter(filter, entry) {
+			continue
+		}
+		if req.SizeLimit > 0 && len(res.Entries) == req.SizeLimit {
+			return res, ldap.NewError(ldap.LDAPResultSizeLimitExceeded, errors.New("size limit exceeded"))
+		}
+		res.Entries = append(res.Entries, entry)
+	}
+	return res, nil
+}
+
+// Close implements the ldapConn interface.
+func (c *fakeConn) Close() {}
+
+// matchesFilter evaluates the subset of LDAP filters used by the tests.
+func matchesFilter(filter *ber.Packet, entry *ldap.Entry) bool {
+	switch filter.Tag {
+	case ldap.FilterAnd:
+		for _, child := range filter.Children {
+			if !matchesFilter(child, entry) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterOr:
+		for _, child := range filter.Children {
+			if matchesFilter(child, entry) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterNot:
+		return !matchesFilter(filter.Children[0], entry)
+	case ldap.FilterPresent:
+		return len(entry.GetEqualFoldAttributeValues(filter.Data.String())) > 0
+	case ldap.FilterEqualityMatch:
+		attr := filter.Children[0].Data.String()
+		value := filter.Children[1].Data.String()
+		for _, v := range entry.GetEqualFoldAttributeValues(attr) {
+			if strings.EqualFold(v, value) {
+				return true
+			}
+		}
+		return false
+	}
+	panic(errors.AssertionFailedf("unsupported filter %v", filter.Description))
+}
+
+func TestLDAPCheckHBAEntry(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const prefix = `host all all all ldap ldapserver=localhost "ldapbasedn=dc=example,dc=com" `
+	for _, tc := range []struct {
+		options string
+		err     string
+	}{
+		{options: ``},
+		{options: `ldapport=1636 ldapscheme=ldaps ` +
+			`"ldapsearchfilter=(&(objectClass=person)(mail=$username))"`},
+		{options: `ldaptls=1 "ldapbinddn=cn=search,dc=example,dc=com" ldapbindpasswd=secret`},
+		{options: `"ldapgrouplistfilter=(objectClass=groupOfNames)" ldapgroupmap=groups map=users`},
+		{options: `krb_realm=EXAMPLE.COM`, err: `unsupported option krb_realm`},
+		{options: `ldapscheme=ldapx`, err: `ldapscheme must be "ldap" or "ldaps"`},
+		{options: `ldapport=http`, err: `invalid ldapport`},
+		{options: `ldaptls=yes`, err: `ldaptls must be set to 0 or 1`},
+		{options: `ldaptls=1 ldapscheme=ldaps`, err: `cannot be combined`},
+		{options: `ldapbindpasswd=secret`, err: `requires "ldapbinddn"`},
+		{
+			options: `ldapsearchattribute=mail "ldapsearchfilter=(mail=$username)"`,
+			err:     `cannot be combined`,
+		},
+		{options: `ldapsearchfilter=(mail=alice)`, err: `ldapsearchfilter must contain $username`},
+		{options: `ldapsearchfilter=(mail=$username`, err: `invalid ldapsearchfilter`},
+		{options: `ldapgroupmap=groups`, err: `requires "ldapgrouplistfilter"`},
+		{options: `"ldapgrouplistfilter=(objectClass=groupOfNames)" ldapsyncadmin=1`},
+		{options: `ldapsyncadmin=1`, err: `requires "ldapgrouplistfilter"`},
+		{
+			options: `"ldapgrouplistfilter=(objectClass=groupOfNames)" ldapsyncadmin=yes`,
+			err:     `ldapsyncadmin must be set to 0 or 1`,
+		},
+	} {
+		t.Run(tc.options, func(t *testing.T) {
+			conf, err := hba.ParseAndNormalize(prefix + tc.options)
+			require.NoError(t, err)
+			err = checkHBAEntry(nil /* values */, conf.Entries[0])
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+			}
+		})
+	}
+
+	conf, err := hba.ParseAndNormalize(`host all all all ldap "ldapbasedn=dc=example,dc=com"`)
+	require.NoError(t, err)
+	require.EqualError(t, checkHBAEntry(nil /* values */, conf.Entries[0]),
+		`the "ldapserver" option is required`)
+}
+
+func TestLDAPMapGroup(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	conf := &ldapConf{groupListFilter: "(objectClass=groupOfNames)"}
+	roles, err := mapGroup(nil /* identMap */, conf, "Devs")
+	require.NoError(t, err)
+	require.Equal(t, []security.SQLUsername{security.MakeSQLUsernameFromPreNormalizedString("devs")}, roles)
+
+	_, err = mapGroup(nil /* identMap */, conf, "root")
+	require.Regexp(t, `LDAP group "root" mapped to reserved database role "root"`, err)
+
+	// The admin role is only synchronized if the ldapsyncadmin option is set.
+	_, err = mapGroup(nil /* identMap */, conf, "admin")
+	require.Regexp(t, `LDAP group "admin" mapped to the admin role`, err)
+	conf.syncAdmin = true
+	roles, err = mapGroup(nil /* identMap */, conf, "admin")
+	require.NoError(t, err)
+	require.Equal(t, []security.SQLUsername{security.AdminRoleName()}, roles)
+}
+
+func TestLDAPAuthentication(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	dir := &fakeDirectory{passwords: make(map[string]string)}
+	dir.addEntry("cn=search,dc=example,dc=com", "search-secret", nil)
+	dir.addEntry("uid=alice,ou=people,dc=example,dc=com", "alice-secret", map[string][]string{
+		"objectClass": {"person"}, "uid": {"alice"},
+	})
+	dir.addEntry("uid=bob,ou=people,dc=example,dc=com", "bob-secret", map[string][]string{
+		"objectClass": {"person"}, "uid": {"bob"},
+	})
+	dir.addEntry("cn=devs,ou=groups,dc=example,dc=com", "", map[string][]string{
+		"objectClass": {"groupOfNames"}, "cn": {"devs"},
+		"member": {"uid=alice,ou=people,dc=example,dc=com"},
+	})
+	dir.addEntry("cn=ops,ou=groups,dc=example,dc=com", "", map[string][]string{
+		"objectClass": {"groupOfNames"}, "cn": {"ops"},
+		"member": {"uid=bob,ou=people,dc=example,dc=com"},
+	})
+	defer func(prev func(context.Context, *ldapConf, string, time.Duration) (ldapConn, error)) {
+		ldapDialer = prev
+	}(ldapDialer)
+	ldapDialer = dir.dial
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	sqlDB.Exec(t, `CREATE USER alice`)
+	sqlDB.Exec(t, `CREATE USER bob`)
+	sqlDB.Exec(t, `CREATE ROLE sql_devs`)
+	sqlDB.Exec(t, `CREATE ROLE sql_ops`)
+	sqlDB.Exec(t, `GRANT sql_ops TO alice`)
+	sqlDB.Exec(t, `SET CLUSTER SETTING server.identity_map.configuration = 'groups /^(.*)$ sql_\1'`)
+	sqlDB.Exec(t, fmt.Sprintf(
+		`SET CLUSTER SETTING server.host_based_authentication.configuration = '%s'`,
+		`host all all all ldap ldapserver=localhost "ldapbasedn=dc=example,dc=com" `+
+			`"ldapbinddn=cn=search,dc=example,dc=com" ldapbindpasswd=search-secret `+
+			`"ldapsearchfilter=(&(objectClass=person)(uid=$username))" `+
+			`"ldapgrouplistfilter=(objectClass=groupOfNames)" ldapgroupmap=groups`,
+	))
+
+	connect := func(user, password string) error {
+		pgURL, cleanup := sqlutils.PGUrlWithOptionalClientCerts(
+			t, s.ServingSQLAddr(), t.Name(), url.UserPassword(user, password), false, /* withClientCerts */
+		)
+		defer cleanup()
+		conn, err := gosql.Open("postgres", pgURL.String())
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		return conn.Ping()
+	}
+	roles := func(user string) [][]string {
+		return sqlDB.QueryStr(t,
+			`SELECT "role" FROM system.role_members WHERE "member" = $1 ORDER BY 1`, user)
+	}
+
+	t.Run("wrong password", func(t *testing.T) {
+		require.Regexp(t, "password authentication failed", connect("alice", "bob-secret"))
+	})
+
+	t.Run("empty password", func(t *testing.T) {
+		require.Regexp(t, "password authentication failed", connect("alice", ""))
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		require.Regexp(t, "password authentication failed", connect("carol", "alice-secret"))
+	})
+
+	t.Run("group synchronization", func(t *testing.T) {
+		require.NoError(t, connect("alice", "alice-secret"))
+		require.Equal(t, [][]string{{"sql_devs"}}, roles("alice"))
+
+		require.NoError(t, connect("bob", "bob-secret"))
+		require.Equal(t, [][]string{{"sql_ops"}}, roles("bob"))
+	})
+}
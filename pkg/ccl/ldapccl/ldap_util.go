@@ -0,0 +1,147 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package ldapccl
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// ldapConn is the subset of the LDAP client API used for authentication. It
+// is implemented by *ldap.Conn, and by an in-process directory in tests.
+type ldapConn interface {
+	Bind(username, password string) error
+	Search(searchRequest *ldap.SearchRequest) (*ldap.SearchResult, error)
+	Close()
+}
+
+var _ ldapConn = (*ldap.Conn)(nil)
+
+// ldapDialer opens a connection to the LDAP server described by the given
+// configuration. It is overridden in tests.
+var ldapDialer = dialLDAP
+
+// dialLDAP connects to the LDAP server, using TLS for the "ldaps" scheme or
+// upgrading the connection with StartTLS if requested.
+func dialLDAP(
+	_ context.Context, conf *ldapConf, caCert string, timeout time.Duration,
+) (ldapConn, error) {
+	tlsConf := &tls.Config{ServerName: conf.server}
+	if caCert != "" {
+		tlsConf.RootCAs = x509.NewCertPool()
+		if !tlsConf.RootCAs.AppendCertsFromPEM([]byte(caCert)) {
+			return nil, errors.New("invalid LDAP CA certificate")
+		}
+	}
+
+	u := url.URL{Scheme: conf.scheme, Host: net.JoinHostPort(conf.server, conf.port)}
+	conn, err := ldap.DialURL(
+		u.String(),
+		ldap.DialWithDialer(&net.Dialer{Timeout: timeout}),
+		ldap.DialWithTLSConfig(tlsConf),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "connecting to LDAP server %s", u.Host)
+	}
+	conn.SetTimeout(timeout)
+	if conf.startTLS {
+		if err := conn.StartTLS(tlsConf); err != nil {
+			conn.Close()
+			return nil, errors.Wrapf(err, "starting TLS with LDAP server %s", u.Host)
+		}
+	}
+	return conn, nil
+}
+
+// searchUserDN returns the distinguished name of the single directory entry
+// that matches the search filter for the given user.
+func searchUserDN(conn ldapConn, conf *ldapConf, user string) (string, error) {
+	req := ldap.NewSearchRequest(
+		conf.baseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		2 /* sizeLimit */, 0 /* timeLimit */, false, /* typesOnly */
+		conf.userFilter(user),
+		[]string{"dn"},
+		nil, /* controls */
+	)
+	res, err := conn.Search(req)
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return "", errors.Wrap(err, "searching for LDAP user")
+	}
+	switch {
+	case err != nil || len(res.Entries) > 1:
+		return "", errors.Newf("LDAP user %q is not unique", user)
+	case len(res.Entries) == 0:
+		return "", errors.Newf("LDAP user %q does not exist", user)
+	}
+	return res.Entries[0].DN, nil
+}
+
+// ldapGroup is a group entry of the directory.
+type ldapGroup struct {
+	// name is the common name of the group, which is mapped to SQL roles.
+	name string
+	// isMember is true if the authenticated user is a member of the group.
+	isMember bool
+}
+
+// searchGroups returns the groups that match the group list filter, noting
+// which of them have the given user as a member.
+func searchGroups(conn ldapConn, conf *ldapConf, userDN string) ([]ldapGroup, error) {
+	parsedUserDN, err := ldap.ParseDN(userDN)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing LDAP user DN %q", userDN)
+	}
+	req := ldap.NewSearchRequest(
+		conf.baseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		0 /* sizeLimit */, 0 /* timeLimit */, false, /* typesOnly */
+		conf.groupListFilter,
+		[]string{"cn", conf.groupMemberAttribute},
+		nil, /* controls */
+	)
+	res, err := conn.Search(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "searching for LDAP groups")
+	}
+	groups := make([]ldapGroup, 0, len(res.Entries))
+	for _, entry := range res.Entries {
+		group := ldapGroup{name: entry.GetAttributeValue("cn")}
+		if group.name == "" {
+			return nil, errors.Newf("LDAP group %q has no common name", entry.DN)
+		}
+		for _, member := range entry.GetEqualFoldAttributeValues(conf.groupMemberAttribute) {
+			if dn, err := ldap.ParseDN(member); err == nil && dn.EqualFold(parsedUserDN) {
+				group.isMember = true
+				break
+			}
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// userFilter returns the filter used to search for the directory entry of
+// the given user.
+func (conf *ldapConf) userFilter(user string) string {
+	escaped := ldap.EscapeFilter(user)
+	if conf.searchFilter != "" {
+		return strings.ReplaceAll(conf.searchFilter, usernamePlaceholder, escaped)
+	}
+	return fmt.Sprintf("(%s=%s)", conf.searchAttribute, escaped)
+}
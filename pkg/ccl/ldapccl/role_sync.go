@@ -0,0 +1,122 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package ldapccl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/identmap"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/errors"
+)
+
+// mapGroup returns the SQL roles that the LDAP group with the given name maps
+// to. Without an ldapgroupmap option, a group maps to the role with the same
+// name. Groups may only map to the admin role if the ldapsyncadmin option is
+// set, so that a directory group which happens to be named admin does not
+// grant admin privileges on its own.
+func mapGroup(
+	identMap *identmap.Conf, conf *ldapConf, name string,
+) ([]security.SQLUsername, error) {
+	var roles []security.SQLUsername
+	if conf.groupMap != "" {
+		var err error
+		if roles, err = identMap.Map(conf.groupMap, name); err != nil {
+			return nil, err
+		}
+	} else {
+		role, err := security.MakeSQLUsernameFromUserInput(name, security.UsernameValidation)
+		if err != nil {
+			return nil, err
+		}
+		roles = []security.SQLUsername{role}
+	}
+	for _, role := range roles {
+		if role.IsRootUser() || role.IsReserved() {
+			return nil, errors.Newf("LDAP group %q mapped to reserved database role %q",
+				name, role.Normalized())
+		}
+		if role.IsAdminRole() && !conf.syncAdmin {
+			return nil, errors.WithHint(
+				errors.Newf("LDAP group %q mapped to the %s role", name, role.Normalized()),
+				`set the "ldapsyncadmin=1" option to synchronize the admin role from LDAP`)
+		}
+	}
+	return roles, nil
+}
+
+// syncGroupRoles makes the membership of the given user in the roles that the
+// synchronized LDAP groups map to match the membership of the user in those
+// groups. Roles that do not exist are ignored, and memberships in roles that
+// no synchronized group maps to are left untouched.
+func syncGroupRoles(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	identMap *identmap.Conf,
+	conf *ldapConf,
+	user security.SQLUsername,
+	groups []ldapGroup,
+) error {
+	synced := make(map[security.SQLUsername]bool)
+	for _, group := range groups {
+		roles, err := mapGroup(identMap, conf, group.name)
+		if err != nil {
+			return err
+		}
+		for _, role := range roles {
+			if role != user {
+				synced[role] = synced[role] || group.isMember
+			}
+		}
+	}
+	if len(synced) == 0 {
+		return nil
+	}
+
+	ie := execCfg.InternalExecutor
+	override := sessiondata.InternalExecutorOverride{User: security.RootUserName()}
+	rows, err := ie.QueryBufferedEx(
+		ctx, "ldap-read-role-members", nil /* txn */, override,
+		`SELECT "role" FROM system.role_members WHERE "member" = $1`, user,
+	)
+	if err != nil {
+		return err
+	}
+	current := make(map[security.SQLUsername]bool, len(rows))
+	for _, row := range rows {
+		role := security.MakeSQLUsernameFromPreNormalizedString(string(tree.MustBeDString(row[0])))
+		current[role] = true
+	}
+
+	for role, isMember := range synced {
+		var stmt string
+		switch {
+		case isMember && !current[role]:
+			if exists, err := sql.RoleExists(ctx, execCfg, nil /* txn */, role); err != nil {
+				return err
+			} else if !exists {
+				continue
+			}
+			stmt = fmt.Sprintf("GRANT %s TO %s", role.SQLIdentifier(), user.SQLIdentifier())
+		case !isMember && current[role]:
+			stmt = fmt.Sprintf("REVOKE %s FROM %s", role.SQLIdentifier(), user.SQLIdentifier())
+		default:
+			continue
+		}
+		if _, err := ie.ExecEx(ctx, "ldap-sync-role", nil /* txn */, override, stmt); err != nil {
+			return errors.Wrapf(err, "synchronizing membership of %q in role %q",
+				user.Normalized(), role.Normalized())
+		}
+	}
+	return nil
+}
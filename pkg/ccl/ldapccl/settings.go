@@ -0,0 +1,55 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package ldapccl
+
+import (
+	"crypto/x509"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/errors"
+)
+
+// All cluster settings necessary for the LDAP authentication method.
+const (
+	baseLDAPSettingName          = "server.ldap_authentication."
+	LDAPTimeoutSettingName       = baseLDAPSettingName + "client.timeout"
+	LDAPCACertificateSettingName = baseLDAPSettingName + "client.tls_ca_certificate"
+)
+
+// LDAPTimeout is the timeout for connecting to the LDAP server and for each
+// of the requests sent to it.
+var LDAPTimeout = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	LDAPTimeoutSettingName,
+	"sets the timeout for connections and requests to the LDAP server",
+	10*time.Second,
+	settings.PositiveDuration,
+)
+
+// LDAPCACertificate is the PEM-encoded CA certificate used to verify the
+// certificate of the LDAP server when TLS is used.
+var LDAPCACertificate = settings.RegisterValidatedStringSetting(
+	settings.TenantWritable,
+	LDAPCACertificateSettingName,
+	"sets the PEM-encoded CA certificate used to verify the LDAP server's certificate "+
+		"(the system root CAs are used if empty)",
+	"",
+	validateCACertificate,
+)
+
+func validateCACertificate(_ *settings.Values, s string) error {
+	if s == "" {
+		return nil
+	}
+	if !x509.NewCertPool().AppendCertsFromPEM([]byte(s)) {
+		return errors.New("LDAP CA certificate is not a valid PEM-encoded certificate")
+	}
+	return nil
+}
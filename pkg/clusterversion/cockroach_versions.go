@@ -345,6 +345,9 @@ const (
 	// SeedSpanCountTable seeds system.span_count with the number of committed
 	// tenant spans.
 	SeedSpanCountTable
+	// NotificationsTable adds the system.notifications table, used by LISTEN
+	// and NOTIFY.
+	NotificationsTable
 
 	// *************************************************
 	// Step (1): Add new versions here.
@@ -596,6 +599,10 @@ var versionsSingleton = keyedVersions{
 		Key:     SeedSpanCountTable,
 		Version: roachpb.Version{Major: 21, Minor: 2, Internal: 112},
 	},
+	{
+		Key:     NotificationsTable,
+		Version: roachpb.Version{Major: 21, Minor: 2, Internal: 114},
+	},
 
 	// *************************************************
 	// Step (2): Add new versions here.
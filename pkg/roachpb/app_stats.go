@@ -174,6 +174,9 @@ func (s *StatementStatistics) Add(other *StatementStatistics) {
 
 	if s.SensitiveInfo.MostRecentPlanTimestamp.Before(other.SensitiveInfo.MostRecentPlanTimestamp) {
 		s.SensitiveInfo = other.SensitiveInfo
+		// Index recommendations are generated along with the sampled plan, so
+		// keep the ones from the most recent sample.
+		s.IndexRecommendations = other.IndexRecommendations
 	}
 
 	if s.LastExecTimestamp.Before(other.LastExecTimestamp) {
@@ -218,7 +218,11 @@ func (s *SQLWatcher) watchForDescriptorUpdates(
 			return
 		}
 
-		table, database, typ, schema := descpb.FromDescriptorWithMVCCTimestamp(&descriptor, ev.Value.Timestamp)
+		table, database, typ, schema, function := descpb.FromDescriptorWithMVCCTimestamp(&descriptor, ev.Value.Timestamp)
+		if function != nil {
+			// Functions do not have span configurations.
+			return
+		}
 
 		var id descpb.ID
 		var descType catalog.DescriptorType
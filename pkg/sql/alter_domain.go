@@ -0,0 +1,182 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type alterDomainNode struct {
+	n    *tree.AlterDomain
+	desc *typedesc.Mutable
+}
+
+// alterDomainNode implements planNode. We set n here to satisfy the linter.
+var _ planNode = &alterDomainNode{n: nil}
+
+func (p *planner) AlterDomain(ctx context.Context, n *tree.AlterDomain) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER DOMAIN",
+	); err != nil {
+		return nil, err
+	}
+
+	// Resolve the domain.
+	_, desc, err := p.ResolveMutableTypeDescriptor(ctx, n.Domain, true /* required */)
+	if err != nil {
+		return nil, err
+	}
+	if desc.Kind != descpb.TypeDescriptor_DOMAIN {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"%q is not a domain", tree.AsStringWithFQNames(n.Domain, &p.semaCtx.Annotations))
+	}
+
+	// The user needs ownership privilege to alter the domain.
+	if err := p.canModifyType(ctx, desc); err != nil {
+		return nil, err
+	}
+	sqltelemetry.IncrementDomainCounter(sqltelemetry.DomainAlter)
+
+	return &alterDomainNode{
+		n:    n,
+		desc: desc,
+	}, nil
+}
+
+func (n *alterDomainNode) startExec(params runParams) error {
+	telemetry.Inc(n.n.Cmd.TelemetryCounter())
+
+	var err error
+	switch t := n.n.Cmd.(type) {
+	case *tree.AlterDomainSetDefault:
+		err = params.p.setDomainDefault(params.ctx, n.desc, t.Default)
+	case *tree.AlterDomainSetNotNull:
+		err = params.p.addDomainConstraint(
+			params.ctx, n.desc, &tree.DomainConstraint{NotNull: true}, tree.ValidationDefault,
+		)
+	case *tree.AlterDomainDropNotNull:
+		for i := len(n.desc.DomainConstraints) - 1; i >= 0; i-- {
+			if c := n.desc.DomainConstraints[i]; c.NotNull {
+				n.desc.RemoveDomainConstraint(c.Name)
+			}
+		}
+	case *tree.AlterDomainAddConstraint:
+		err = params.p.addDomainConstraint(params.ctx, n.desc, &t.Constraint, t.ValidationBehavior)
+	case *tree.AlterDomainDropConstraint:
+		if n.desc.FindDomainConstraint(string(t.Constraint)) == nil {
+			if !t.IfExists {
+				return pgerror.Newf(pgcode.UndefinedObject,
+					"constraint %q of domain %q does not exist", t.Constraint, n.desc.Name)
+			}
+			params.p.BufferClientNotice(
+				params.ctx,
+				pgnotice.Newf("constraint %q of domain %q does not exist, skipping", t.Constraint, n.desc.Name),
+			)
+			return nil
+		}
+		n.desc.RemoveDomainConstraint(string(t.Constraint))
+	case *tree.AlterDomainValidateConstraint:
+		c := n.desc.FindDomainConstraint(string(t.Constraint))
+		if c == nil {
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"constraint %q of domain %q does not exist", t.Constraint, n.desc.Name)
+		}
+		// Validated constraints do not need to be validated again.
+		if c.Validity == descpb.ConstraintValidity_Unvalidated {
+			c.Validity = descpb.ConstraintValidity_Validating
+		}
+	default:
+		err = errors.AssertionFailedf("unknown alter domain cmd %s", t)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Constraints being validated are validated against the existing values of
+	// the domain by the type schema changer.
+	if err := params.p.writeTypeSchemaChange(
+		params.ctx, n.desc, tree.AsStringWithFQNames(n.n, params.p.Ann()),
+	); err != nil {
+		return err
+	}
+
+	// Write a log event.
+	return params.p.logEvent(params.ctx,
+		n.desc.ID,
+		&eventpb.AlterType{
+			TypeName: tree.AsStringWithFQNames(n.n.Domain, params.p.Ann()),
+		})
+}
+
+// setDomainDefault sets the default expression of the domain, or removes it
+// if expr is nil.
+func (p *planner) setDomainDefault(
+	ctx context.Context, desc *typedesc.Mutable, expr tree.Expr,
+) error {
+	if expr == nil {
+		desc.DomainDefaultExpr = nil
+		return nil
+	}
+	s, err := schemaexpr.ValidateDomainDefaultExpr(ctx, expr, desc.DomainBaseType, p.SemaCtx())
+	if err != nil {
+		return err
+	}
+	desc.DomainDefaultExpr = &s
+	return nil
+}
+
+// addDomainConstraint adds the given constraint to the domain. Unless the
+// constraint is NOT VALID, it is added in the VALIDATING state, so that the
+// type schema changer validates the existing values of the domain.
+func (p *planner) addDomainConstraint(
+	ctx context.Context,
+	desc *typedesc.Mutable,
+	d *tree.DomainConstraint,
+	validationBehavior tree.ValidationBehavior,
+) error {
+	if d.NotNull {
+		// Domains have at most one NOT NULL constraint.
+		for i := range desc.DomainConstraints {
+			if desc.DomainConstraints[i].NotNull {
+				return nil
+			}
+		}
+	}
+	c, err := p.makeDomainConstraint(ctx, desc.Name, desc.DomainBaseType, d, desc.DomainConstraints)
+	if err != nil {
+		return err
+	}
+	c.Validity = descpb.ConstraintValidity_Validating
+	if validationBehavior == tree.ValidationSkip {
+		c.Validity = descpb.ConstraintValidity_Unvalidated
+	}
+	desc.AddDomainConstraint(c)
+	return nil
+}
+
+func (n *alterDomainNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *alterDomainNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *alterDomainNode) Close(ctx context.Context)           {}
+func (n *alterDomainNode) ReadingOwnWrites()                   {}
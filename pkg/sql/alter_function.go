@@ -0,0 +1,231 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/errors"
+)
+
+type alterFunctionNode struct {
+	n    *tree.AlterFunction
+	desc *funcdesc.Mutable
+}
+
+// AlterFunction applies a schema change on a user-defined function.
+// Privileges: ownership of the function.
+func (p *planner) AlterFunction(ctx context.Context, n *tree.AlterFunction) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER FUNCTION",
+	); err != nil {
+		return nil, err
+	}
+
+	desc, err := p.getMutableFunctionDescriptor(ctx, &n.Function, true /* required */)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.canModifyFunction(ctx, desc); err != nil {
+		return nil, err
+	}
+	return &alterFunctionNode{n: n, desc: desc}, nil
+}
+
+func (n *alterFunctionNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeAlterCounter("function"))
+
+	desc := n.desc
+	jobDesc := tree.AsStringWithFQNames(n.n, params.Ann())
+	switch t := n.n.Cmd.(type) {
+	case *tree.AlterFunctionOptions:
+		lang, body, err := setFunctionOptions(&desc.FunctionDescriptor, t.Options)
+		if err != nil {
+			return err
+		}
+		if lang != "" || body != "" {
+			return pgerror.New(pgcode.InvalidFunctionDefinition,
+				"cannot change the language or body of a function with ALTER FUNCTION")
+		}
+		return params.p.writeFunctionDescChange(params.ctx, desc, jobDesc)
+
+	case *tree.AlterFunctionRename:
+		newName := string(t.NewName)
+		if newName == desc.GetName() {
+			return nil
+		}
+		// The bodies of other functions and triggers call functions by name.
+		if by := desc.GetDependedOnBy(); len(by) > 0 {
+			return params.p.functionReferenceError(params.ctx, "function", desc.GetName(), &by[0], "rename")
+		}
+		if err := checkBuiltinFunctionConflict(newName, desc.GetParamTypes()); err != nil {
+			return err
+		}
+		scDesc, err := params.p.getMutableFunctionSchema(params.ctx, desc)
+		if err != nil {
+			return err
+		}
+		if err := params.p.checkFunctionSignatureConflict(
+			params.ctx, scDesc, newName, desc,
+		); err != nil {
+			return err
+		}
+		scDesc.RemoveFunction(desc.GetName(), desc.GetID())
+		scDesc.AddFunction(newName, desc.GetID())
+		if err := params.p.writeSchemaDescChange(params.ctx, scDesc, jobDesc); err != nil {
+			return err
+		}
+		desc.SetName(newName)
+		return params.p.writeFunctionDescChange(params.ctx, desc, jobDesc)
+
+	case *tree.AlterFunctionOwner:
+		newOwner, err := t.Owner.ToSQLUsername(params.SessionData(), security.UsernameValidation)
+		if err != nil {
+			return err
+		}
+		return params.p.alterFunctionOwner(params.ctx, desc, newOwner, jobDesc)
+
+	case *tree.AlterFunctionSetSchema:
+		if by := desc.GetDependedOnBy(); len(by) > 0 {
+			return params.p.functionReferenceError(
+				params.ctx, "function", desc.GetName(), &by[0], "set schema on",
+			)
+		}
+		return params.p.setFunctionSchema(params.ctx, desc, string(t.Schema), jobDesc)
+
+	default:
+		return errors.AssertionFailedf("unknown alter function cmd %T", t)
+	}
+}
+
+func (n *alterFunctionNode) Next(runParams) (bool, error) { return false, nil }
+func (n *alterFunctionNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *alterFunctionNode) Close(context.Context)        {}
+
+// alterFunctionOwner changes the owner of a function.
+func (p *planner) alterFunctionOwner(
+	ctx context.Context, desc *funcdesc.Mutable, newOwner security.SQLUsername, jobDesc string,
+) error {
+	if err := p.checkCanAlterToNewOwner(ctx, desc, newOwner); err != nil {
+		return err
+	}
+	// Ensure the new owner has CREATE privilege on the function's schema.
+	if err := p.canCreateOnSchema(
+		ctx, desc.GetParentSchemaID(), desc.GetParentID(), newOwner, checkPublicSchema,
+	); err != nil {
+		return err
+	}
+	// If the owner we want to set to is the current owner, do a no-op.
+	if newOwner == desc.GetPrivileges().Owner() {
+		return nil
+	}
+	desc.GetPrivileges().SetOwner(newOwner)
+	return p.writeFunctionDescChange(ctx, desc, jobDesc)
+}
+
+// setFunctionSchema moves a function to another schema of its database.
+func (p *planner) setFunctionSchema(
+	ctx context.Context, desc *funcdesc.Mutable, schema string, jobDesc string,
+) error {
+	_, db, err := p.Descriptors().GetImmutableDatabaseByID(
+		ctx, p.txn, desc.GetParentID(), tree.DatabaseLookupFlags{Required: true})
+	if err != nil {
+		return err
+	}
+	res, err := p.Descriptors().GetMutableSchemaByName(
+		ctx, p.txn, db, schema, tree.SchemaLookupFlags{
+			Required:       true,
+			RequireMutable: true,
+		})
+	if err != nil {
+		return err
+	}
+	switch res.SchemaKind() {
+	case catalog.SchemaTemporary:
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot move objects into or out of temporary schemas")
+	case catalog.SchemaVirtual:
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot move objects into or out of virtual schemas")
+	case catalog.SchemaPublic:
+		// We do not need to check for privileges on the public schema.
+	default:
+		// The user needs CREATE privilege on the target schema to move an object
+		// to the schema.
+		if err := p.CheckPrivilege(ctx, res, privilege.CREATE); err != nil {
+			return err
+		}
+	}
+	if res.GetID() == desc.GetParentSchemaID() {
+		return nil
+	}
+	newScDesc, ok := res.(*schemadesc.Mutable)
+	if !ok {
+		return errFunctionInSyntheticSchema(schema)
+	}
+	if err := p.checkFunctionSignatureConflict(ctx, newScDesc, desc.GetName(), desc); err != nil {
+		return err
+	}
+	oldScDesc, err := p.getMutableFunctionSchema(ctx, desc)
+	if err != nil {
+		return err
+	}
+
+	oldScDesc.RemoveFunction(desc.GetName(), desc.GetID())
+	if err := p.writeSchemaDescChange(ctx, oldScDesc, jobDesc); err != nil {
+		return err
+	}
+	newScDesc.AddFunction(desc.GetName(), desc.GetID())
+	if err := p.writeSchemaDescChange(ctx, newScDesc, jobDesc); err != nil {
+		return err
+	}
+	desc.SetParentSchemaID(newScDesc.GetID())
+	return p.writeFunctionDescChange(ctx, desc, jobDesc)
+}
+
+// checkFunctionSignatureConflict returns an error if the given schema already
+// contains a function with the given name and the parameter types of desc.
+func (p *planner) checkFunctionSignatureConflict(
+	ctx context.Context, scDesc catalog.SchemaDescriptor, name string, desc *funcdesc.Mutable,
+) error {
+	fn, ok := scDesc.GetFunction(name)
+	if !ok {
+		return nil
+	}
+	for _, ol := range fn.Overloads {
+		if ol.ID == desc.GetID() {
+			continue
+		}
+		other, err := p.Descriptors().GetImmutableFunctionByID(ctx, p.txn, ol.ID,
+			tree.ObjectLookupFlags{CommonLookupFlags: tree.CommonLookupFlags{Required: true}})
+		if err != nil {
+			return err
+		}
+		if funcParamTypesIdentical(other.GetParamTypes(), desc.GetParamTypes()) {
+			return pgerror.Newf(pgcode.DuplicateFunction,
+				"function %q already exists with same argument types in schema %q",
+				name, scDesc.GetName())
+		}
+	}
+	return nil
+}
@@ -331,6 +331,18 @@ func (n *alterTableNode) startExec(params runParams) error {
 						return err
 					}
 				}
+			case *tree.ExclusionConstraintTableDef:
+				if err := addExclusionConstraintTableDef(
+					params.ctx,
+					d,
+					n.tableDesc,
+					*tn,
+					NonEmptyTable,
+					t.ValidationBehavior,
+					params.p.SemaCtx(),
+				); err != nil {
+					return err
+				}
 			case *tree.CheckConstraintTableDef:
 				var err error
 				params.p.runWithOptions(resolveFlags{contextDatabaseID: n.tableDesc.ParentID}, func() {
@@ -598,7 +610,7 @@ func (n *alterTableNode) startExec(params runParams) error {
 					if err := validateUniqueWithoutIndexConstraintInTxn(
 						params.ctx, params.ExecCfg().InternalExecutorFactory(
 							params.ctx, params.SessionData(),
-						), n.tableDesc, params.EvalContext().Txn, name,
+						), n.tableDesc, params.EvalContext().Txn, name, false, /* preExisting */
 					); err != nil {
 						return err
 					}
@@ -696,6 +708,19 @@ func (n *alterTableNode) startExec(params runParams) error {
 				}
 			}
 
+		case *tree.AlterTableDropPartition:
+			changed, err := alterTableDropPartition(params, n.tableDesc, t)
+			if err != nil {
+				return err
+			}
+			descriptorChanged = descriptorChanged || changed
+
+		case *tree.AlterTableDetachPartition:
+			if err := alterTableDetachPartition(params, n.tableDesc, t); err != nil {
+				return err
+			}
+			descriptorChanged = true
+
 		case *tree.AlterTableSetAudit:
 			changed, err := params.p.setAuditMode(params.ctx, n.tableDesc, t.Mode)
 			if err != nil {
@@ -773,6 +798,19 @@ func (n *alterTableNode) startExec(params runParams) error {
 			}
 			descriptorChanged = descriptorChanged || descChanged
 
+		case *tree.AlterTableRowLevelSecurity:
+			switch t.Action {
+			case tree.RowLevelSecurityEnable:
+				n.tableDesc.RowLevelSecurity = true
+			case tree.RowLevelSecurityDisable:
+				n.tableDesc.RowLevelSecurity = false
+			case tree.RowLevelSecurityForce:
+				n.tableDesc.ForceRowLevelSecurity = true
+			case tree.RowLevelSecurityNoForce:
+				n.tableDesc.ForceRowLevelSecurity = false
+			}
+			descriptorChanged = true
+
 		case *tree.AlterTableRenameConstraint:
 			info, err := n.tableDesc.GetConstraintInfo()
 			if err != nil {
@@ -1385,6 +1423,9 @@ func validateConstraintNameIsNotUsed(
 	case *tree.ForeignKeyConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
+	case *tree.ExclusionConstraintTableDef:
+		name = d.Name
+		hasIfNotExists = d.IfNotExists
 	case *tree.UniqueConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
@@ -1618,6 +1659,27 @@ func dropColumnImpl(
 		droppedViews = append(droppedViews, qualifiedView.FQString())
 	}
 
+	// You can't drop a column depended on by a trigger unless CASCADE was
+	// specified.
+	if err := params.p.canRemoveDependentTriggers(
+		params.ctx, "column", string(t.Column), tableDesc, colToDrop.GetID(), t.DropBehavior, nil, /* skip */
+	); err != nil {
+		return nil, err
+	}
+	if err := params.p.dropDependentTriggers(params.ctx, tableDesc, colToDrop.GetID()); err != nil {
+		return nil, err
+	}
+
+	// Likewise for a column depended on by a function.
+	if err := params.p.canRemoveDependentFunctions(
+		params.ctx, "column", string(t.Column), tableDesc, colToDrop.GetID(), t.DropBehavior,
+	); err != nil {
+		return nil, err
+	}
+	if err := params.p.dropDependentFunctions(params.ctx, tableDesc, colToDrop.GetID()); err != nil {
+		return nil, err
+	}
+
 	// We cannot remove this column if there are computed columns that use it.
 	if err := schemaexpr.ValidateColumnHasNoDependents(tableDesc, colToDrop); err != nil {
 		return nil, err
@@ -1733,6 +1795,43 @@ func dropColumnImpl(
 	}
 	tableDesc.UniqueWithoutIndexConstraints = tableDesc.UniqueWithoutIndexConstraints[:sliceIdx]
 
+	// Drop row-level security policies that reference the column. Since the
+	// policies restrict access to the table, they are only dropped with
+	// CASCADE.
+	sliceIdx = 0
+	for i := range tableDesc.Policies {
+		policy := &tableDesc.Policies[i]
+		tableDesc.Policies[sliceIdx] = *policy
+		sliceIdx++
+		for _, exprStr := range []string{policy.UsingExpr, policy.WithCheckExpr} {
+			if exprStr == "" {
+				continue
+			}
+			expr, err := parser.ParseExpr(exprStr)
+			if err != nil {
+				return nil, err
+			}
+			colIDs, err := schemaexpr.ExtractColumnIDs(tableDesc, expr)
+			if err != nil {
+				return nil, err
+			}
+			if !colIDs.Contains(colToDrop.GetID()) {
+				continue
+			}
+			if t.DropBehavior != tree.DropCascade {
+				return nil, errors.WithHint(
+					pgerror.Newf(pgcode.DependentObjectsStillExist,
+						"cannot drop column %q because policy %q on table %q depends on it",
+						colToDrop.GetName(), policy.Name, tableDesc.GetName()),
+					"use CASCADE to drop the policy as well",
+				)
+			}
+			sliceIdx--
+			break
+		}
+	}
+	tableDesc.Policies = tableDesc.Policies[:sliceIdx]
+
 	// Drop check constraints which reference the column.
 	constraintsToDrop := make([]string, 0, len(tableDesc.Checks))
 	constraintInfo, err := tableDesc.GetConstraintInfo()
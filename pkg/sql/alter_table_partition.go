@@ -0,0 +1,488 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descidgen"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scexec"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/errors"
+)
+
+// detachPartitionPageSize is the number of keys read at a time when moving
+// the rows of a partition detached by DETACH PARTITION.
+const detachPartitionPageSize = 10000
+
+// alterTableDropPartition implements ALTER TABLE ... DROP PARTITION. The
+// partition is removed from every index of the table which has a top-level
+// partition of that name, along with its zone configs. With WITH DATA, the
+// rows of the partition are deleted in the transaction with range deletions
+// over the spans of the partition in every index, rather than one row at a
+// time (see deletePartitionRows).
+func alterTableDropPartition(
+	params runParams, tableDesc *tabledesc.Mutable, t *tree.AlterTableDropPartition,
+) (descriptorChanged bool, _ error) {
+	name := string(t.Partition)
+	if err := checkCanAlterPartitions(tableDesc, "DROP PARTITION"); err != nil {
+		return false, err
+	}
+	indexes := indexesWithPartition(tableDesc, name)
+	if len(indexes) == 0 {
+		err := partitionNotFoundError(tableDesc, name)
+		if t.IfExists && pgerror.GetPGCode(err) == pgcode.UndefinedObject {
+			return false, nil
+		}
+		return false, err
+	}
+	if t.WithData {
+		if err := params.p.CheckPrivilege(params.ctx, tableDesc, privilege.DELETE); err != nil {
+			return false, err
+		}
+		if err := checkCanMovePartitionData(tableDesc, name, "drop"); err != nil {
+			return false, err
+		}
+		spans, err := partitionSpans(params.ExecCfg().Codec, tableDesc, name)
+		if err != nil {
+			return false, err
+		}
+		if err := deletePartitionRows(
+			params.ctx, params.p.txn, spans, params.p.ExtendedEvalContext().Tracing.KVTracingEnabled(),
+		); err != nil {
+			return false, err
+		}
+	}
+	if err := removePartition(params, tableDesc, indexes, name); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// alterTableDetachPartition implements ALTER TABLE ... DETACH PARTITION ...
+// INTO. A new table with the same columns, indexes and constraints as the
+// partitioned table is created, and the partition is removed from the
+// partitioned table like with DROP PARTITION. The keys of the partition are
+// moved into the new table in the transaction: since both tables have the
+// same column and index IDs, this only requires replacing the table prefix of
+// each key (see movePartitionRows).
+func alterTableDetachPartition(
+	params runParams, tableDesc *tabledesc.Mutable, t *tree.AlterTableDetachPartition,
+) error {
+	name := string(t.Partition)
+	if err := checkCanAlterPartitions(tableDesc, "DETACH PARTITION"); err != nil {
+		return err
+	}
+	indexes := indexesWithPartition(tableDesc, name)
+	if len(indexes) == 0 {
+		return partitionNotFoundError(tableDesc, name)
+	}
+	if err := params.p.CheckPrivilege(params.ctx, tableDesc, privilege.DELETE); err != nil {
+		return err
+	}
+	if err := checkCanMovePartitionData(tableDesc, name, "detach"); err != nil {
+		return err
+	}
+	if len(tableDesc.OutboundFKs) > 0 {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot detach partition %q of table %q which has foreign keys", name, tableDesc.GetName())
+	}
+	if tableDesc.HasRowLevelTTL() {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot detach partition %q of table %q with row-level TTL", name, tableDesc.GetName())
+	}
+	for _, col := range tableDesc.PublicColumns() {
+		if col.NumUsesSequences() > 0 || col.NumOwnsSequences() > 0 {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot detach partition %q of table %q: column %q uses a sequence",
+				name, tableDesc.GetName(), col.GetName())
+		}
+	}
+
+	newDesc, tn, err := createDetachedPartitionTable(params, tableDesc, t.Into)
+	if err != nil {
+		return err
+	}
+	spans, err := partitionSpans(params.ExecCfg().Codec, tableDesc, name)
+	if err != nil {
+		return err
+	}
+	if err := movePartitionRows(
+		params.ctx,
+		params.p.txn,
+		params.ExecCfg().Codec,
+		tableDesc.GetID(),
+		newDesc.GetID(),
+		spans,
+		params.p.ExtendedEvalContext().Tracing.KVTracingEnabled(),
+	); err != nil {
+		return err
+	}
+
+	if err := removePartition(params, tableDesc, indexes, name); err != nil {
+		return err
+	}
+	return params.p.logEvent(params.ctx,
+		newDesc.ID,
+		&eventpb.CreateTable{
+			TableName: tn.FQString(),
+		})
+}
+
+// createDetachedPartitionTable creates the table into which DETACH PARTITION
+// moves the rows of a partition of tableDesc. The new table has the same
+// columns, families, indexes and check and unique constraints as tableDesc,
+// and it is not partitioned.
+func createDetachedPartitionTable(
+	params runParams, tableDesc *tabledesc.Mutable, into *tree.UnresolvedObjectName,
+) (*tabledesc.Mutable, *tree.TableName, error) {
+	db, sc, prefix, err := params.p.ResolveTargetObject(params.ctx, into)
+	if err != nil {
+		return nil, nil, err
+	}
+	if db.GetID() != tableDesc.GetParentID() {
+		return nil, nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot detach a partition into a table of another database")
+	}
+	if sc.SchemaKind() == catalog.SchemaTemporary {
+		return nil, nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot detach a partition into a temporary table")
+	}
+	tn := tree.MakeTableNameFromPrefix(prefix, tree.Name(into.Object()))
+	schema, err := getSchemaForCreateTable(params, db, tree.PersistencePermanent, &tn,
+		tree.ResolveRequireTableDesc, false /* ifNotExists */)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	id, err := descidgen.GenerateUniqueDescID(params.ctx, params.ExecCfg().DB, params.ExecCfg().Codec)
+	if err != nil {
+		return nil, nil, err
+	}
+	privs := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		db.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		db.GetID(),
+		params.SessionData().User(),
+		tree.Tables,
+		db.GetPrivileges(),
+	)
+	src := protoutil.Clone(tableDesc.TableDesc()).(*descpb.TableDescriptor)
+	desc := tabledesc.NewBuilder(&descpb.TableDescriptor{
+		Name:                          tn.Table(),
+		ID:                            id,
+		ParentID:                      db.GetID(),
+		UnexposedParentSchemaID:       schema.GetID(),
+		Version:                       1,
+		FormatVersion:                 src.FormatVersion,
+		Columns:                       src.Columns,
+		NextColumnID:                  src.NextColumnID,
+		Families:                      src.Families,
+		NextFamilyID:                  src.NextFamilyID,
+		PrimaryIndex:                  src.PrimaryIndex,
+		Indexes:                       src.Indexes,
+		NextIndexID:                   src.NextIndexID,
+		NextMutationID:                1,
+		Checks:                        src.Checks,
+		UniqueWithoutIndexConstraints: src.UniqueWithoutIndexConstraints,
+		NextConstraintID:              src.NextConstraintID,
+		Privileges:                    privs,
+	}).BuildCreatedMutableTable()
+	if desc.PrimaryIndex.Name == tabledesc.PrimaryKeyIndexName(tableDesc.GetName()) {
+		desc.PrimaryIndex.Name = tabledesc.PrimaryKeyIndexName(tn.Table())
+	}
+	desc.PrimaryIndex.Partitioning = catpb.PartitioningDescriptor{}
+	for i := range desc.Indexes {
+		desc.Indexes[i].Partitioning = catpb.PartitioningDescriptor{}
+	}
+
+	if err := params.p.createDescriptorWithID(
+		params.ctx,
+		catalogkeys.MakeObjectNameKey(params.ExecCfg().Codec, db.GetID(), schema.GetID(), tn.Table()),
+		id,
+		desc,
+		tree.AsStringWithFQNames(&tn, params.Ann()),
+	); err != nil {
+		return nil, nil, err
+	}
+	if err := params.p.addBackRefsFromAllTypesInTable(params.ctx, desc); err != nil {
+		return nil, nil, err
+	}
+	if err := validateDescriptor(params.ctx, params.p, desc); err != nil {
+		return nil, nil, err
+	}
+	return desc, &tn, nil
+}
+
+// deletePartitionRows deletes the rows in the given spans of a partition in
+// the transaction. The range deletions leave MVCC tombstones like any other
+// deletion, so the table stays online, and the rows are removed atomically
+// with the partition.
+func deletePartitionRows(ctx context.Context, txn *kv.Txn, spans roachpb.Spans, traceKV bool) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	b := txn.NewBatch()
+	for _, sp := range spans {
+		if traceKV {
+			log.VEventf(ctx, 2, "DelRange %s - %s", sp.Key, sp.EndKey)
+		}
+		b.DelRange(sp.Key, sp.EndKey, false /* returnKeys */)
+	}
+	return txn.Run(ctx, b)
+}
+
+// movePartitionRows moves the keys of the table fromID in the given spans into
+// the table toID, created by DETACH PARTITION, in the transaction. The keys are
+// read a page at a time and written with their table prefix replaced, and then
+// deleted from fromID with deletePartitionRows.
+func movePartitionRows(
+	ctx context.Context,
+	txn *kv.Txn,
+	codec keys.SQLCodec,
+	fromID, toID descpb.ID,
+	spans roachpb.Spans,
+	traceKV bool,
+) error {
+	oldPrefix := codec.TablePrefix(uint32(fromID))
+	newPrefix := codec.TablePrefix(uint32(toID))
+	for _, sp := range spans {
+		if err := txn.Iterate(ctx, sp.Key, sp.EndKey, detachPartitionPageSize,
+			func(kvs []kv.KeyValue) error {
+				b := txn.NewBatch()
+				for _, row := range kvs {
+					key := make(roachpb.Key, 0, len(newPrefix)+len(row.Key)-len(oldPrefix))
+					key = append(append(key, newPrefix...), row.Key[len(oldPrefix):]...)
+					// The checksum of a value covers its key, so it is recomputed
+					// for the new key when the request is made.
+					value := roachpb.Value{RawBytes: row.Value.RawBytes}
+					value.ClearChecksum()
+					if traceKV {
+						log.VEventf(ctx, 2, "CPut %s -> %s", key, value.PrettyPrint())
+					}
+					b.CPut(key, &value, nil /* expValue */)
+				}
+				return txn.Run(ctx, b)
+			}); err != nil {
+			return err
+		}
+	}
+	return deletePartitionRows(ctx, txn, spans, traceKV)
+}
+
+// checkCanAlterPartitions returns an error if the partitions of the table
+// cannot be dropped or detached.
+func checkCanAlterPartitions(tableDesc catalog.TableDescriptor, op string) error {
+	if tableDesc.GetLocalityConfig() != nil {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot %s on a table in a multi-region enabled database", op)
+	}
+	if len(tableDesc.AllMutations()) > 0 {
+		return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"cannot %s on table %q while it is undergoing a schema change", op, tableDesc.GetName())
+	}
+	for _, idx := range tableDesc.ActiveIndexes() {
+		if idx.GetPartitioning().NumImplicitColumns() > 0 {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot %s on table %q which has implicit column partitioning", op, tableDesc.GetName())
+		}
+	}
+	return nil
+}
+
+// checkCanMovePartitionData returns an error if the rows of the partition
+// cannot be deleted or moved by clearing the spans of the partition. This
+// requires all indexes of the table to be partitioned identically, so that the
+// spans of the partition in every index contain exactly the entries of the
+// rows of the partition, and no foreign keys to reference the rows.
+func checkCanMovePartitionData(tableDesc *tabledesc.Mutable, name string, verb string) error {
+	if len(tableDesc.InboundFKs) > 0 {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot %s the rows of partition %q of table %q which is referenced by foreign keys",
+			verb, name, tableDesc.GetName())
+	}
+	primary := tableDesc.GetPrimaryIndex()
+	for _, idx := range tableDesc.PublicNonPrimaryIndexes() {
+		if !samePartitioning(primary, idx) {
+			return errors.WithHint(
+				pgerror.Newf(pgcode.FeatureNotSupported,
+					"cannot %s the rows of partition %q of table %q: index %q is not partitioned like the primary index",
+					verb, name, tableDesc.GetName(), idx.GetName()),
+				"Partition all indexes of the table with PARTITION ALL BY.",
+			)
+		}
+	}
+	return nil
+}
+
+// samePartitioning returns whether the two indexes have the same top-level
+// partitions over the same columns.
+func samePartitioning(a, b catalog.Index) bool {
+	ap, bp := a.GetPartitioning(), b.GetPartitioning()
+	if !ap.PartitioningDesc().Equal(bp.PartitioningDesc()) {
+		return false
+	}
+	for i := 0; i < ap.NumColumns(); i++ {
+		if i >= b.NumKeyColumns() || a.GetKeyColumnID(i) != b.GetKeyColumnID(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// indexesWithPartition returns the active indexes which have a top-level
+// partition with the given name.
+func indexesWithPartition(tableDesc catalog.TableDescriptor, name string) []catalog.Index {
+	var indexes []catalog.Index
+	for _, idx := range tableDesc.ActiveIndexes() {
+		if hasTopLevelPartition(idx.GetPartitioning(), name) {
+			indexes = append(indexes, idx)
+		}
+	}
+	return indexes
+}
+
+// partitionNotFoundError returns the error for a partition which is not a
+// top-level partition of any index of the table.
+func partitionNotFoundError(tableDesc catalog.TableDescriptor, name string) error {
+	for _, idx := range tableDesc.ActiveIndexes() {
+		if idx.GetPartitioning().FindPartitionByName(name) != nil {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"partition %q of table %q is a subpartition", name, tableDesc.GetName())
+		}
+	}
+	return pgerror.Newf(pgcode.UndefinedObject,
+		"partition %q does not exist on table %q", name, tableDesc.GetName())
+}
+
+func hasTopLevelPartition(part catalog.Partitioning, name string) bool {
+	desc := part.PartitioningDesc()
+	for i := range desc.List {
+		if desc.List[i].Name == name {
+			return true
+		}
+	}
+	for i := range desc.Range {
+		if desc.Range[i].Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// removePartition removes the top-level partition with the given name from
+// the indexes, along with the zone configs of the partition and of its
+// subpartitions.
+func removePartition(
+	params runParams, tableDesc *tabledesc.Mutable, indexes []catalog.Index, name string,
+) error {
+	for _, idx := range indexes {
+		oldPartitioning := idx.GetPartitioning().DeepCopy()
+		newIndexDesc := idx.IndexDescDeepCopy()
+		newIndexDesc.Partitioning = tabledesc.WithoutPartition(newIndexDesc.Partitioning, name)
+		if idx.Primary() {
+			tableDesc.SetPrimaryIndex(newIndexDesc)
+		} else {
+			tableDesc.SetPublicNonPrimaryIndex(idx.Ordinal(), newIndexDesc)
+		}
+		newIdx, err := tableDesc.FindIndexWithID(idx.GetID())
+		if err != nil {
+			return err
+		}
+		if err := deleteRemovedPartitionZoneConfigs(
+			params.ctx,
+			params.p.txn,
+			tableDesc,
+			idx.GetID(),
+			oldPartitioning,
+			newIdx.GetPartitioning(),
+			params.extendedEvalCtx.ExecCfg,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// schemaChangerPartitionRemover implements scexec.IndexPartitionRemover for
+// the removal of partitions by the declarative schema changer.
+type schemaChangerPartitionRemover struct {
+	txn     *kv.Txn
+	execCfg *ExecutorConfig
+	kvTrace bool
+}
+
+var _ scexec.IndexPartitionRemover = (*schemaChangerPartitionRemover)(nil)
+
+// NewSchemaChangerPartitionRemover returns a scexec.IndexPartitionRemover
+// implementation.
+func NewSchemaChangerPartitionRemover(
+	txn *kv.Txn, execCfg *ExecutorConfig, kvTrace bool,
+) scexec.IndexPartitionRemover {
+	return &schemaChangerPartitionRemover{
+		txn:     txn,
+		execCfg: execCfg,
+		kvTrace: kvTrace,
+	}
+}
+
+// RemoveIndexPartition implements the scexec.IndexPartitionRemover interface.
+func (r schemaChangerPartitionRemover) RemoveIndexPartition(
+	ctx context.Context,
+	oldTable, table catalog.TableDescriptor,
+	indexID descpb.IndexID,
+	name string,
+	dropRows bool,
+) error {
+	oldIdx, err := oldTable.FindIndexWithID(indexID)
+	if err != nil {
+		return err
+	}
+	idx, err := table.FindIndexWithID(indexID)
+	if err != nil {
+		return err
+	}
+	if dropRows {
+		spans, err := indexPartitionSpans(
+			r.execCfg.Codec, oldTable, oldIdx, oldIdx.GetPartitioning(), name,
+		)
+		if err != nil {
+			return err
+		}
+		if err := deletePartitionRows(ctx, r.txn, spans, r.kvTrace); err != nil {
+			return err
+		}
+	}
+	return deleteRemovedPartitionZoneConfigs(
+		ctx,
+		r.txn,
+		table,
+		indexID,
+		oldIdx.GetPartitioning(),
+		idx.GetPartitioning(),
+		r.execCfg,
+	)
+}
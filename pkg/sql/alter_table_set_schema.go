@@ -86,6 +86,18 @@ func (p *planner) AlterTableSetSchema(
 			)
 		}
 	}
+	if len(tableDesc.DependedOnByTriggers) > 0 {
+		return nil, p.dependentTriggerError(
+			ctx, string(tableDesc.DescriptorType()), tableDesc.Name,
+			&tableDesc.DependedOnByTriggers[0], "set schema on",
+		)
+	}
+	if len(tableDesc.DependedOnByFunctions) > 0 {
+		return nil, p.dependentFunctionError(
+			ctx, string(tableDesc.DescriptorType()), tableDesc.Name,
+			tableDesc.DependedOnByFunctions[0].FunctionID, "set schema on",
+		)
+	}
 
 	return &alterTableSetSchemaNode{
 		newSchema: string(n.Schema),
@@ -76,6 +76,23 @@ func (p *planner) AlterType(ctx context.Context, n *tree.AlterType) (planNode, e
 		}
 	case descpb.TypeDescriptor_ENUM:
 		sqltelemetry.IncrementEnumCounter(sqltelemetry.EnumAlter)
+	case descpb.TypeDescriptor_DOMAIN:
+		return nil, errors.WithHint(
+			pgerror.Newf(
+				pgcode.WrongObjectType,
+				"%q is a domain",
+				tree.AsStringWithFQNames(n.Type, &p.semaCtx.Annotations)),
+			"use ALTER DOMAIN instead")
+	case descpb.TypeDescriptor_COMPOSITE:
+		// Composite types support the generic ALTER TYPE commands, but they have
+		// no values to add, drop or rename.
+		switch n.Cmd.(type) {
+		case *tree.AlterTypeAddValue, *tree.AlterTypeDropValue, *tree.AlterTypeRenameValue:
+			return nil, pgerror.Newf(
+				pgcode.WrongObjectType,
+				"%q is not an enum",
+				tree.AsStringWithFQNames(n.Type, &p.semaCtx.Annotations))
+		}
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
 		return nil, pgerror.Newf(
 			pgcode.WrongObjectType,
@@ -199,6 +216,11 @@ func (p *planner) dropEnumValue(
 	if !found {
 		return pgerror.Newf(pgcode.UndefinedObject, "enum value %q does not exist", val)
 	}
+	// The value could be stored in the fields of composite types, which are
+	// not checked for usages of the value.
+	if len(desc.ReferencingTypeIDs) > 0 {
+		return p.dependentTypeError(ctx, desc.Name, desc.ReferencingTypeIDs[0], "drop a value of")
+	}
 	// Do not allow drops if the enum value isn't public yet.
 	if enumMemberIsRemoving(member) {
 		return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
@@ -214,6 +236,12 @@ func (p *planner) dropEnumValue(
 }
 
 func (p *planner) renameType(ctx context.Context, n *alterTypeNode, newName string) error {
+	// Function bodies reference types by name.
+	if len(n.desc.ReferencingFunctionIDs) > 0 {
+		return p.dependentFunctionError(
+			ctx, "type", n.desc.Name, n.desc.ReferencingFunctionIDs[0], "rename",
+		)
+	}
 	err := p.Descriptors().Direct().CheckObjectCollision(
 		ctx,
 		p.txn,
@@ -341,6 +369,11 @@ func (p *planner) renameTypeValue(
 func (p *planner) setTypeSchema(ctx context.Context, n *alterTypeNode, schema string) error {
 	typeDesc := n.desc
 	schemaID := typeDesc.GetParentSchemaID()
+	if len(typeDesc.ReferencingFunctionIDs) > 0 {
+		return p.dependentFunctionError(
+			ctx, "type", typeDesc.Name, typeDesc.ReferencingFunctionIDs[0], "set schema on",
+		)
+	}
 
 	oldName, err := p.getQualifiedTypeName(ctx, typeDesc)
 	if err != nil {
@@ -737,7 +737,9 @@ func (sc *SchemaChanger) validateConstraints(
 						return err
 					}
 				} else if c.IsUniqueWithoutIndex() {
-					if err := validateUniqueWithoutIndexConstraintInTxn(ctx, sc.ieFactory(ctx, evalCtx.SessionData()), desc, txn, c.GetName()); err != nil {
+					if err := validateUniqueWithoutIndexConstraintInTxn(
+						ctx, sc.ieFactory(ctx, evalCtx.SessionData()), desc, txn, c.GetName(), false, /* preExisting */
+					); err != nil {
 						return err
 					}
 				} else if c.IsNotNull() {
@@ -2349,6 +2351,7 @@ func runSchemaChangesInTxn(
 			if uwi.Validity == descpb.ConstraintValidity_Validating {
 				if err := validateUniqueWithoutIndexConstraintInTxn(
 					ctx, planner.ExecCfg().InternalExecutor, tableDesc, planner.txn, c.GetName(),
+					false, /* preExisting */
 				); err != nil {
 					return err
 				}
@@ -2500,6 +2503,9 @@ func validateFkInTxn(
 // still runs in the user transaction instead of a step in the schema changer.
 // When that's no longer true, this function should be updated.
 //
+// preExisting indicates whether the constraint was already in place when the
+// rows were written, and informs the error message that gets produced.
+//
 // It operates entirely on the current goroutine and is thus able to
 // reuse an existing kv.Txn safely.
 func validateUniqueWithoutIndexConstraintInTxn(
@@ -2508,6 +2514,7 @@ func validateUniqueWithoutIndexConstraintInTxn(
 	tableDesc *tabledesc.Mutable,
 	txn *kv.Txn,
 	constraintName string,
+	preExisting bool,
 ) error {
 	var syntheticDescs []catalog.Descriptor
 	if tableDesc.Version > tableDesc.ClusterVersion().Version {
@@ -2527,8 +2534,8 @@ func validateUniqueWithoutIndexConstraintInTxn(
 	}
 
 	return ie.WithSyntheticDescriptors(syntheticDescs, func() error {
-		return validateUniqueConstraint(
-			ctx, tableDesc, uc.Name, uc.ColumnIDs, uc.Predicate, ie, txn, false, /* preExisting */
+		return validateUniqueWithoutIndexConstraint(
+			ctx, tableDesc, uc, ie, txn, preExisting,
 		)
 	})
 }
@@ -17,6 +17,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/geo/geoindex"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -215,6 +216,10 @@ func FormatIndexElements(
 		} else {
 			f.FormatNameP(&index.KeyColumnNames[i])
 		}
+		if i == n-1 && index.Type == descpb.IndexDescriptor_INVERTED &&
+			index.InvertedColumnKind() == catpb.InvertedIndexColumnKind_TRIGRAM {
+			f.WriteString(" gin_trgm_ops")
+		}
 		f.WriteByte(' ')
 		f.WriteString(index.KeyColumnDirections[i].String())
 	}
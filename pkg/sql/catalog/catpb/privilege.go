@@ -193,6 +193,14 @@ func NewBaseDatabasePrivilegeDescriptor(owner security.SQLUsername) *PrivilegeDe
 	return p
 }
 
+// NewBaseFunctionPrivilegeDescriptor creates default privileges for a
+// function. As in Postgres, the public role is granted the EXECUTE privilege.
+func NewBaseFunctionPrivilegeDescriptor(owner security.SQLUsername) *PrivilegeDescriptor {
+	p := NewBasePrivilegeDescriptor(owner)
+	p.Grant(security.PublicRoleName(), privilege.List{privilege.EXECUTE}, false /* withGrantOption */)
+	return p
+}
+
 // NewPublicSchemaPrivilegeDescriptor is used to construct a privilege
 // descriptor owned by the admin user which has CREATE and USAGE privilege for
 // the public role, and ALL privileges for superusers. It is used for the
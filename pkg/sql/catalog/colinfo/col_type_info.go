@@ -102,10 +102,24 @@ func ValidateColumnDefType(t *types.T) error {
 		}
 		return ValidateColumnDefType(t.ArrayContents())
 
+	case types.TupleFamily:
+		// Anonymous tuples and the implicit record types of tables cannot be
+		// used for columns, but composite types can.
+		if !t.IsComposite() {
+			return pgerror.Newf(pgcode.InvalidTableDefinition,
+				"value type %s cannot be used for table columns", t.String())
+		}
+		for _, typ := range t.TupleContents() {
+			if err := ValidateColumnDefType(typ); err != nil {
+				return err
+			}
+		}
+
 	case types.BitFamily, types.IntFamily, types.FloatFamily, types.BoolFamily, types.BytesFamily, types.DateFamily,
 		types.INetFamily, types.IntervalFamily, types.JsonFamily, types.OidFamily, types.TimeFamily,
 		types.TimestampFamily, types.TimestampTZFamily, types.UuidFamily, types.TimeTZFamily,
-		types.GeographyFamily, types.GeometryFamily, types.EnumFamily, types.Box2DFamily:
+		types.GeographyFamily, types.GeometryFamily, types.EnumFamily, types.Box2DFamily,
+		types.RangeFamily, types.TSQueryFamily, types.TSVectorFamily:
 		// These types are OK.
 
 	default:
@@ -118,9 +132,21 @@ func ValidateColumnDefType(t *types.T) error {
 
 // ColumnTypeIsIndexable returns whether the type t is valid as an indexed column.
 func ColumnTypeIsIndexable(t *types.T) bool {
-	if t.IsAmbiguous() || t.Family() == types.TupleFamily {
+	if t.IsAmbiguous() {
 		return false
 	}
+	if t.Family() == types.TupleFamily {
+		// Composite types are indexable if all of their fields are.
+		if !t.IsComposite() {
+			return false
+		}
+		for _, typ := range t.TupleContents() {
+			if !ColumnTypeIsIndexable(typ) {
+				return false
+			}
+		}
+		return true
+	}
 	// Some inverted index types also have a key encoding, but we don't
 	// want to support those yet. See #50659.
 	return !MustBeValueEncoded(t) && !ColumnTypeIsInvertedIndexable(t)
@@ -134,7 +160,15 @@ func ColumnTypeIsInvertedIndexable(t *types.T) bool {
 	}
 	family := t.Family()
 	return family == types.JsonFamily || family == types.ArrayFamily ||
-		family == types.GeographyFamily || family == types.GeometryFamily
+		family == types.GeographyFamily || family == types.GeometryFamily ||
+		family == types.TSVectorFamily
+}
+
+// ColumnTypeIsTrigramIndexable returns whether the type t is valid to be
+// indexed using a trigram inverted index, which is created by specifying the
+// gin_trgm_ops operator class for the column.
+func ColumnTypeIsTrigramIndexable(t *types.T) bool {
+	return t.Family() == types.StringFamily
 }
 
 // MustBeValueEncoded returns true if columns of the given kind can only be value
@@ -148,7 +182,18 @@ func MustBeValueEncoded(semanticType *types.T) bool {
 		default:
 			return MustBeValueEncoded(semanticType.ArrayContents())
 		}
-	case types.JsonFamily, types.TupleFamily, types.GeographyFamily, types.GeometryFamily:
+	case types.TupleFamily:
+		// Composite types can be key encoded if all of their fields can be.
+		if !semanticType.IsComposite() {
+			return true
+		}
+		for _, typ := range semanticType.TupleContents() {
+			if MustBeValueEncoded(typ) {
+				return true
+			}
+		}
+	case types.JsonFamily, types.GeographyFamily, types.GeometryFamily,
+		types.TSQueryFamily, types.TSVectorFamily:
 		return true
 	}
 	return false
@@ -13,6 +13,9 @@ package descpb
 import (
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	types "github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/errors"
@@ -44,10 +47,18 @@ func (desc *IndexDescriptor) ExplicitColumnStartIdx() int {
 func (desc *IndexDescriptor) FillColumns(elems tree.IndexElemList) error {
 	desc.KeyColumnNames = make([]string, 0, len(elems))
 	desc.KeyColumnDirections = make([]IndexDescriptor_Direction, 0, len(elems))
-	for _, c := range elems {
+	for i, c := range elems {
 		if c.Expr != nil {
 			return errors.AssertionFailedf("index elem expression should have been replaced with a column")
 		}
+		// Operator classes are only meaningful for the inverted column of an
+		// inverted index, which is always the last one.
+		if c.OpClass != "" && (desc.Type != IndexDescriptor_INVERTED || i != len(elems)-1) {
+			return pgerror.New(
+				pgcode.DatatypeMismatch,
+				"operator classes are only allowed for the last column of an inverted index",
+			)
+		}
 		desc.KeyColumnNames = append(desc.KeyColumnNames, string(c.Column))
 		switch c.Direction {
 		case tree.Ascending, tree.DefaultDirection:
@@ -125,3 +136,17 @@ func (desc *IndexDescriptor) InvertedColumnKeyType() *types.T {
 	}
 	return types.EncodedKey
 }
+
+// InvertedColumnKind returns the kind of the inverted column of the inverted
+// index. Indexes created before the kind was stored are always DEFAULT.
+//
+// Panics if the index is not inverted.
+func (desc *IndexDescriptor) InvertedColumnKind() catpb.InvertedIndexColumnKind {
+	if desc.Type != IndexDescriptor_INVERTED {
+		panic(errors.AssertionFailedf("index is not inverted"))
+	}
+	if len(desc.InvertedColumnKinds) == 0 {
+		return catpb.InvertedIndexColumnKind_DEFAULT
+	}
+	return desc.InvertedColumnKinds[0]
+}
@@ -45,6 +45,9 @@ const (
 
 	// Schema is for schema descriptors.
 	Schema = "schema"
+
+	// Function is for function descriptors.
+	Function = "function"
 )
 
 // MutationPublicationFilter is used by MakeFirstMutationPublic to filter the
@@ -659,6 +662,22 @@ type TableDescriptor interface {
 	// referenced by the returned checks are writable, but not necessarily public.
 	ActiveChecks() []descpb.TableDescriptor_CheckConstraint
 
+	// GetTriggers returns the triggers defined on this table, in creation
+	// order.
+	GetTriggers() []descpb.TriggerDescriptor
+
+	// GetRowLevelSecurity returns true if row-level security is enabled on this
+	// table.
+	GetRowLevelSecurity() bool
+
+	// GetForceRowLevelSecurity returns true if the row-level security policies
+	// of this table also apply to its owner.
+	GetForceRowLevelSecurity() bool
+
+	// GetPolicies returns the row-level security policies defined on this
+	// table, in creation order.
+	GetPolicies() []descpb.PolicyDescriptor
+
 	// GetLocalityConfig returns the locality config for this table, which
 	// describes the table's multi-region locality policy if one is set (e.g.
 	// GLOBAL or REGIONAL BY ROW).
@@ -87,6 +87,11 @@ func (u uncommittedDescriptor) GetID() descpb.ID {
 	return u.immutable.GetID()
 }
 
+// DescriptorType returns the type of the underlying descriptor.
+func (u uncommittedDescriptor) DescriptorType() catalog.DescriptorType {
+	return u.immutable.DescriptorType()
+}
+
 // checkOut is how the mutable descriptor should be accessed.
 func (u *uncommittedDescriptor) checkOut() catalog.MutableDescriptor {
 	if u.mutable == nil {
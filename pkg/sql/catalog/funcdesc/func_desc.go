@@ -0,0 +1,520 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package funcdesc contains the concrete implementations of
+// catalog.FunctionDescriptor.
+package funcdesc
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/oidext"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/redact"
+	"github.com/lib/pq/oid"
+)
+
+var _ catalog.FunctionDescriptor = (*immutable)(nil)
+var _ catalog.FunctionDescriptor = (*Mutable)(nil)
+var _ catalog.MutableDescriptor = (*Mutable)(nil)
+
+// immutable wraps a Function descriptor and provides methods on it.
+type immutable struct {
+	descpb.FunctionDescriptor
+
+	// isUncommittedVersion is set to true if this descriptor was created from
+	// a copy of a Mutable with an uncommitted version.
+	isUncommittedVersion bool
+
+	// changes represents how the descriptor was changed after
+	// RunPostDeserializationChanges.
+	changes catalog.PostDeserializationChanges
+}
+
+// Mutable is a mutable reference to a FunctionDescriptor.
+type Mutable struct {
+	immutable
+
+	ClusterVersion *immutable
+}
+
+var _ redact.SafeMessager = (*immutable)(nil)
+
+// SafeMessage makes immutable a SafeMessager.
+func (desc *immutable) SafeMessage() string {
+	return formatSafeMessage("funcdesc.immutable", desc)
+}
+
+// SafeMessage makes Mutable a SafeMessager.
+func (desc *Mutable) SafeMessage() string {
+	return formatSafeMessage("funcdesc.Mutable", desc)
+}
+
+func formatSafeMessage(typeName string, desc catalog.FunctionDescriptor) string {
+	var buf redact.StringBuilder
+	buf.Printf(typeName + ": {")
+	catalog.FormatSafeDescriptorProperties(&buf, desc)
+	buf.Printf("}")
+	return buf.String()
+}
+
+// FuncDesc implements the catalog.FunctionDescriptor interface.
+func (desc *immutable) FuncDesc() *descpb.FunctionDescriptor {
+	return &desc.FunctionDescriptor
+}
+
+// GetParamTypes implements the catalog.FunctionDescriptor interface.
+func (desc *immutable) GetParamTypes() []*types.T {
+	ret := make([]*types.T, len(desc.Params))
+	for i := range desc.Params {
+		ret[i] = desc.Params[i].Type
+	}
+	return ret
+}
+
+// IsUncommittedVersion implements the Descriptor interface.
+func (desc *immutable) IsUncommittedVersion() bool {
+	return desc.isUncommittedVersion
+}
+
+// GetDrainingNames implements the Descriptor interface. Functions are not
+// stored in the namespace table, so they never have draining names.
+//
+// Deprecated: Do not use.
+func (desc *immutable) GetDrainingNames() []descpb.NameInfo {
+	return nil
+}
+
+// GetAuditMode implements the DescriptorProto interface.
+func (desc *immutable) GetAuditMode() descpb.TableDescriptor_AuditMode {
+	return descpb.TableDescriptor_DISABLED
+}
+
+// DescriptorType implements the DescriptorProto interface.
+func (desc *immutable) DescriptorType() catalog.DescriptorType {
+	return catalog.Function
+}
+
+// Public implements the Descriptor interface.
+func (desc *immutable) Public() bool {
+	return desc.State == descpb.DescriptorState_PUBLIC
+}
+
+// Adding implements the Descriptor interface.
+func (desc *immutable) Adding() bool {
+	return false
+}
+
+// Offline implements the Descriptor interface.
+func (desc *immutable) Offline() bool {
+	return desc.State == descpb.DescriptorState_OFFLINE
+}
+
+// Dropped implements the Descriptor interface.
+func (desc *immutable) Dropped() bool {
+	return desc.State == descpb.DescriptorState_DROP
+}
+
+// DescriptorProto wraps a FunctionDescriptor in a Descriptor.
+func (desc *immutable) DescriptorProto() *descpb.Descriptor {
+	return &descpb.Descriptor{
+		Union: &descpb.Descriptor_Function{
+			Function: &desc.FunctionDescriptor,
+		},
+	}
+}
+
+// ByteSize implements the Descriptor interface.
+func (desc *immutable) ByteSize() int64 {
+	return int64(desc.Size())
+}
+
+// NewBuilder implements the catalog.Descriptor interface.
+//
+// It overrides the wrapper's implementation to deal with the fact that
+// mutable has overridden the definition of IsUncommittedVersion.
+func (desc *Mutable) NewBuilder() catalog.DescriptorBuilder {
+	return newBuilder(desc.FuncDesc(), desc.IsUncommittedVersion(), desc.changes)
+}
+
+// NewBuilder implements the catalog.Descriptor interface.
+func (desc *immutable) NewBuilder() catalog.DescriptorBuilder {
+	return newBuilder(desc.FuncDesc(), desc.IsUncommittedVersion(), desc.changes)
+}
+
+// ValidateSelf implements the catalog.Descriptor interface.
+func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
+	vea.Report(catalog.ValidateName(desc.GetName(), "function"))
+	if desc.GetID() == descpb.InvalidID {
+		vea.Report(errors.AssertionFailedf("invalid function ID %d", desc.GetID()))
+	}
+	if desc.GetParentID() == descpb.InvalidID {
+		vea.Report(errors.AssertionFailedf("invalid parent ID %d", desc.GetParentID()))
+	}
+	if desc.GetParentSchemaID() == descpb.InvalidID {
+		vea.Report(errors.AssertionFailedf("invalid parent schema ID %d", desc.GetParentSchemaID()))
+	}
+
+	// Validate the privilege descriptor.
+	if desc.Privileges == nil {
+		vea.Report(errors.AssertionFailedf("privileges not set"))
+	} else {
+		vea.Report(catprivilege.Validate(*desc.Privileges, desc, privilege.Function))
+	}
+
+	for i := range desc.Params {
+		if desc.Params[i].Type == nil {
+			vea.Report(errors.AssertionFailedf("missing type for parameter %d", i+1))
+		}
+	}
+	if desc.ReturnType == nil {
+		vea.Report(errors.AssertionFailedf("missing return type"))
+	}
+	if desc.FunctionBody == "" {
+		vea.Report(errors.AssertionFailedf("missing function body"))
+	}
+	if desc.LeakProof && desc.Volatility != descpb.FunctionDescriptor_IMMUTABLE {
+		vea.Report(errors.AssertionFailedf("leakproof is set for non-immutable function"))
+	}
+}
+
+// GetReferencedDescIDs returns the IDs of all descriptors referenced by
+// this descriptor, including itself.
+func (desc *immutable) GetReferencedDescIDs() (catalog.DescriptorIDSet, error) {
+	ids := catalog.MakeDescriptorIDSet(desc.GetID(), desc.GetParentID(), desc.GetParentSchemaID())
+	for _, id := range desc.DependsOn {
+		ids.Add(id)
+	}
+	for _, id := range desc.DependsOnTypes {
+		ids.Add(id)
+	}
+	for _, id := range desc.DependsOnFunctions {
+		ids.Add(id)
+	}
+	for _, ref := range desc.DependedOnBy {
+		ids.Add(ref.ID)
+	}
+	return ids, nil
+}
+
+// ValidateCrossReferences implements the catalog.Descriptor interface.
+func (desc *immutable) ValidateCrossReferences(
+	vea catalog.ValidationErrorAccumulator, vdg catalog.ValidationDescGetter,
+) {
+	// Check the parent database.
+	dbDesc, err := vdg.GetDatabaseDescriptor(desc.GetParentID())
+	if err != nil {
+		vea.Report(err)
+	} else if dbDesc.Dropped() && !desc.Dropped() {
+		vea.Report(errors.AssertionFailedf("parent database %q (%d) is dropped",
+			dbDesc.GetName(), dbDesc.GetID()))
+	}
+
+	// Check the parent schema, which must reference this function unless it is
+	// being dropped.
+	scDesc, err := vdg.GetSchemaDescriptor(desc.GetParentSchemaID())
+	if err != nil {
+		vea.Report(err)
+		return
+	}
+	if scDesc.GetParentID() != desc.GetParentID() {
+		vea.Report(errors.AssertionFailedf("parent schema %d is in different database %d",
+			scDesc.GetID(), scDesc.GetParentID()))
+	}
+	if desc.Dropped() {
+		return
+	}
+	if !desc.isInParentSchema(scDesc) {
+		vea.Report(errors.AssertionFailedf("not present in parent schema [%d] functions mapping",
+			desc.GetParentSchemaID()))
+	}
+
+	// Check the dependencies of the body of the function, which must all
+	// reference it back.
+	for _, id := range desc.DependsOn {
+		vea.Report(desc.validateOutboundTableRef(id, vdg))
+	}
+	for _, id := range desc.DependsOnTypes {
+		vea.Report(desc.validateOutboundTypeRef(id, vdg))
+	}
+	for _, id := range desc.DependsOnFunctions {
+		vea.Report(desc.validateOutboundFunctionRef(id, vdg))
+	}
+	for i := range desc.DependedOnBy {
+		vea.Report(desc.validateInboundRef(&desc.DependedOnBy[i], vdg))
+	}
+}
+
+func (desc *immutable) isInParentSchema(scDesc catalog.SchemaDescriptor) bool {
+	fn, _ := scDesc.GetFunction(desc.GetName())
+	for _, ol := range fn.Overloads {
+		if ol.ID == desc.GetID() {
+			return true
+		}
+	}
+	return false
+}
+
+func (desc *immutable) validateOutboundTableRef(
+	id descpb.ID, vdg catalog.ValidationDescGetter,
+) error {
+	referencedTable, err := vdg.GetTableDescriptor(id)
+	if err != nil {
+		return errors.NewAssertionErrorWithWrappedErrf(err, "invalid depends-on relation reference")
+	}
+	if referencedTable.Dropped() {
+		return errors.AssertionFailedf("depends-on relation %q (%d) is dropped",
+			referencedTable.GetName(), referencedTable.GetID())
+	}
+	for _, by := range referencedTable.TableDesc().DependedOnByFunctions {
+		if by.FunctionID == desc.GetID() {
+			return nil
+		}
+	}
+	return errors.AssertionFailedf("depends-on relation %q (%d) has no corresponding "+
+		"depended-on-by-function back reference", referencedTable.GetName(), id)
+}
+
+func (desc *immutable) validateOutboundTypeRef(id descpb.ID, vdg catalog.ValidationDescGetter) error {
+	typ, err := vdg.GetTypeDescriptor(id)
+	if err != nil {
+		return errors.NewAssertionErrorWithWrappedErrf(err, "invalid depends-on type reference")
+	}
+	if typ.Dropped() {
+		return errors.AssertionFailedf("depends-on type %q (%d) is dropped",
+			typ.GetName(), typ.GetID())
+	}
+	for _, fnID := range typ.TypeDesc().ReferencingFunctionIDs {
+		if fnID == desc.GetID() {
+			return nil
+		}
+	}
+	return errors.AssertionFailedf("depends-on type %q (%d) has no corresponding "+
+		"referencing function back reference", typ.GetName(), id)
+}
+
+func (desc *immutable) validateOutboundFunctionRef(
+	id descpb.ID, vdg catalog.ValidationDescGetter,
+) error {
+	fn, err := vdg.GetFunctionDescriptor(id)
+	if err != nil {
+		return errors.NewAssertionErrorWithWrappedErrf(err, "invalid depends-on function reference")
+	}
+	if fn.Dropped() {
+		return errors.AssertionFailedf("depends-on function %q (%d) is dropped",
+			fn.GetName(), fn.GetID())
+	}
+	for _, by := range fn.GetDependedOnBy() {
+		if by.ID == desc.GetID() && by.TriggerName == "" {
+			return nil
+		}
+	}
+	return errors.AssertionFailedf("depends-on function %q (%d) has no corresponding "+
+		"depended-on-by back reference", fn.GetName(), id)
+}
+
+func (desc *immutable) validateInboundRef(
+	by *descpb.FunctionDescriptor_Reference, vdg catalog.ValidationDescGetter,
+) error {
+	var dependsOn []descpb.ID
+	if by.TriggerName == "" {
+		fn, err := vdg.GetFunctionDescriptor(by.ID)
+		if err != nil {
+			return errors.NewAssertionErrorWithWrappedErrf(err,
+				"invalid depended-on-by function back reference")
+		}
+		if fn.Dropped() {
+			return errors.AssertionFailedf("depended-on-by function %q (%d) is dropped",
+				fn.GetName(), fn.GetID())
+		}
+		dependsOn = fn.GetDependsOnFunctions()
+	} else {
+		tbl, err := vdg.GetTableDescriptor(by.ID)
+		if err != nil {
+			return errors.NewAssertionErrorWithWrappedErrf(err,
+				"invalid depended-on-by trigger back reference")
+		}
+		if tbl.Dropped() {
+			return errors.AssertionFailedf("depended-on-by trigger %q on relation %q (%d) is dropped",
+				by.TriggerName, tbl.GetName(), tbl.GetID())
+		}
+		for _, trig := range tbl.GetTriggers() {
+			if trig.Name == by.TriggerName {
+				dependsOn = trig.DependsOnFunctions
+			}
+		}
+	}
+	for _, id := range dependsOn {
+		if id == desc.GetID() {
+			return nil
+		}
+	}
+	return errors.AssertionFailedf("depended-on-by reference to %d has no corresponding "+
+		"depends-on forward reference", by.ID)
+}
+
+// ValidateTxnCommit implements the catalog.Descriptor interface.
+func (desc *immutable) ValidateTxnCommit(
+	_ catalog.ValidationErrorAccumulator, _ catalog.ValidationDescGetter,
+) {
+	// No-op.
+}
+
+// GetPostDeserializationChanges implements the Descriptor interface.
+func (desc *immutable) GetPostDeserializationChanges() catalog.PostDeserializationChanges {
+	return desc.changes
+}
+
+// HasConcurrentSchemaChanges implements catalog.Descriptor.
+func (desc *immutable) HasConcurrentSchemaChanges() bool {
+	return desc.DeclarativeSchemaChangerState != nil &&
+		desc.DeclarativeSchemaChangerState.JobID != catpb.InvalidJobID
+}
+
+// GetDeclarativeSchemaChangeState is part of the catalog.MutableDescriptor
+// interface.
+func (desc *immutable) GetDeclarativeSchemaChangeState() *scpb.DescriptorState {
+	return desc.DeclarativeSchemaChangerState.Clone()
+}
+
+// FuncIDToOID converts a function descriptor ID into the OID of the
+// function.
+func FuncIDToOID(id descpb.ID) oid.Oid {
+	return oid.Oid(id) + oidext.CockroachPredefinedOIDMax
+}
+
+// UserDefinedFunctionOIDToID converts the OID of a user-defined function into
+// a descriptor ID. The function returns an error if the OID is not the OID of
+// a user-defined function.
+func UserDefinedFunctionOIDToID(oid oid.Oid) (descpb.ID, error) {
+	if descpb.ID(oid) <= oidext.CockroachPredefinedOIDMax {
+		return 0, errors.Newf("user-defined function OID %d should be greater "+
+			"than predefined max: %d", oid, oidext.CockroachPredefinedOIDMax)
+	}
+	return descpb.ID(oid) - oidext.CockroachPredefinedOIDMax, nil
+}
+
+// ToTreeVolatility converts the volatility and leakproof options of a function
+// to a tree.Volatility. Only immutable functions can be leakproof.
+func ToTreeVolatility(v descpb.FunctionDescriptor_Volatility, leakProof bool) tree.Volatility {
+	switch v {
+	case descpb.FunctionDescriptor_IMMUTABLE:
+		if leakProof {
+			return tree.VolatilityLeakProof
+		}
+		return tree.VolatilityImmutable
+	case descpb.FunctionDescriptor_STABLE:
+		return tree.VolatilityStable
+	default:
+		return tree.VolatilityVolatile
+	}
+}
+
+// MaybeIncrementVersion implements the MutableDescriptor interface.
+func (desc *Mutable) MaybeIncrementVersion() {
+	// Already incremented, no-op.
+	if desc.ClusterVersion == nil || desc.Version == desc.ClusterVersion.Version+1 {
+		return
+	}
+	desc.Version++
+	desc.ModificationTime = hlc.Timestamp{}
+}
+
+// SetDrainingNames implements the MutableDescriptor interface. It is a no-op
+// for functions, which are not stored in the namespace table.
+//
+// Deprecated: Do not use.
+func (desc *Mutable) SetDrainingNames(_ []descpb.NameInfo) {}
+
+// AddDrainingName implements the MutableDescriptor interface. It is a no-op
+// for functions, which are not stored in the namespace table.
+//
+// Deprecated: Do not use.
+func (desc *Mutable) AddDrainingName(_ descpb.NameInfo) {}
+
+// OriginalName implements the MutableDescriptor interface.
+func (desc *Mutable) OriginalName() string {
+	if desc.ClusterVersion == nil {
+		return ""
+	}
+	return desc.ClusterVersion.Name
+}
+
+// OriginalID implements the MutableDescriptor interface.
+func (desc *Mutable) OriginalID() descpb.ID {
+	if desc.ClusterVersion == nil {
+		return descpb.InvalidID
+	}
+	return desc.ClusterVersion.ID
+}
+
+// OriginalVersion implements the MutableDescriptor interface.
+func (desc *Mutable) OriginalVersion() descpb.DescriptorVersion {
+	if desc.ClusterVersion == nil {
+		return 0
+	}
+	return desc.ClusterVersion.Version
+}
+
+// ImmutableCopy implements the MutableDescriptor interface.
+func (desc *Mutable) ImmutableCopy() catalog.Descriptor {
+	return desc.NewBuilder().BuildImmutable()
+}
+
+// IsNew implements the MutableDescriptor interface.
+func (desc *Mutable) IsNew() bool {
+	return desc.ClusterVersion == nil
+}
+
+// SetPublic implements the MutableDescriptor interface.
+func (desc *Mutable) SetPublic() {
+	desc.State = descpb.DescriptorState_PUBLIC
+	desc.OfflineReason = ""
+}
+
+// SetDropped implements the MutableDescriptor interface.
+func (desc *Mutable) SetDropped() {
+	desc.State = descpb.DescriptorState_DROP
+	desc.OfflineReason = ""
+}
+
+// SetOffline implements the MutableDescriptor interface.
+func (desc *Mutable) SetOffline(reason string) {
+	desc.State = descpb.DescriptorState_OFFLINE
+	desc.OfflineReason = reason
+}
+
+// SetName sets the name of the function.
+func (desc *Mutable) SetName(name string) {
+	desc.Name = name
+}
+
+// SetParentSchemaID sets the parent schema of the function.
+func (desc *Mutable) SetParentSchemaID(id descpb.ID) {
+	desc.ParentSchemaID = id
+}
+
+// IsUncommittedVersion implements the Descriptor interface.
+func (desc *Mutable) IsUncommittedVersion() bool {
+	return desc.IsNew() || desc.GetVersion() != desc.ClusterVersion.GetVersion()
+}
+
+// SetDeclarativeSchemaChangerState is part of the catalog.MutableDescriptor
+// interface.
+func (desc *Mutable) SetDeclarativeSchemaChangerState(state *scpb.DescriptorState) {
+	desc.DeclarativeSchemaChangerState = state
+}
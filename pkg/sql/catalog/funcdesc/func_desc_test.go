@@ -0,0 +1,177 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package funcdesc_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/nstree"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/redact"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSafeMessage(t *testing.T) {
+	for _, tc := range []struct {
+		desc catalog.Descriptor
+		exp  string
+	}{
+		{
+			desc: funcdesc.NewBuilder(&descpb.FunctionDescriptor{
+				ID:             53,
+				Version:        1,
+				ParentID:       51,
+				ParentSchemaID: 52,
+				State:          descpb.DescriptorState_OFFLINE,
+				OfflineReason:  "foo",
+			}).BuildImmutable(),
+			exp: "funcdesc.immutable: {ID: 53, Version: 1, ModificationTime: \"0,0\", ParentID: 51, ParentSchemaID: 52, State: OFFLINE, OfflineReason: \"foo\"}",
+		},
+		{
+			desc: funcdesc.NewBuilder(&descpb.FunctionDescriptor{
+				ID:             53,
+				Version:        1,
+				ParentID:       51,
+				ParentSchemaID: 52,
+			}).BuildCreatedMutable(),
+			exp: "funcdesc.Mutable: {ID: 53, Version: 1, IsUncommitted: true, ModificationTime: \"0,0\", ParentID: 51, ParentSchemaID: 52, State: PUBLIC}",
+		},
+	} {
+		t.Run("", func(t *testing.T) {
+			redacted := string(redact.Sprint(tc.desc).Redact())
+			require.Equal(t, tc.exp, redacted)
+		})
+	}
+}
+
+func TestValidateFunctionDescriptor(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+
+	validDesc := func() descpb.FunctionDescriptor {
+		return descpb.FunctionDescriptor{
+			Name:           "f",
+			ID:             53,
+			ParentID:       51,
+			ParentSchemaID: 52,
+			Params: []descpb.FunctionDescriptor_Parameter{
+				{Name: "a", Type: types.Int},
+			},
+			ReturnType:   types.Int,
+			FunctionBody: "SELECT a + 1",
+		}
+	}
+	functions := map[string]descpb.SchemaDescriptor_Function{
+		"f": {Overloads: []descpb.SchemaDescriptor_Function_Overload{{ID: 53}}},
+	}
+
+	tests := []struct {
+		err      string
+		desc     descpb.FunctionDescriptor
+		schemaFn map[string]descpb.SchemaDescriptor_Function
+	}{
+		{ // 0
+			desc:     validDesc(),
+			schemaFn: functions,
+		},
+		{ // 1
+			err: `missing function body`,
+			desc: func() descpb.FunctionDescriptor {
+				d := validDesc()
+				d.FunctionBody = ""
+				return d
+			}(),
+			schemaFn: functions,
+		},
+		{ // 2
+			err: `missing type for parameter 1`,
+			desc: func() descpb.FunctionDescriptor {
+				d := validDesc()
+				d.Params[0].Type = nil
+				return d
+			}(),
+			schemaFn: functions,
+		},
+		{ // 3
+			err: `leakproof is set for non-immutable function`,
+			desc: func() descpb.FunctionDescriptor {
+				d := validDesc()
+				d.Volatility = descpb.FunctionDescriptor_STABLE
+				d.LeakProof = true
+				return d
+			}(),
+			schemaFn: functions,
+		},
+		{ // 4
+			err: `referenced schema ID 500: referenced descriptor not found`,
+			desc: func() descpb.FunctionDescriptor {
+				d := validDesc()
+				d.ParentSchemaID = 500
+				return d
+			}(),
+			schemaFn: functions,
+		},
+		{ // 5
+			err:  `not present in parent schema [52] functions mapping`,
+			desc: validDesc(),
+		},
+		{ // 6
+			err:  `not present in parent schema [52] functions mapping`,
+			desc: validDesc(),
+			schemaFn: map[string]descpb.SchemaDescriptor_Function{
+				"f": {Overloads: []descpb.SchemaDescriptor_Function_Overload{{ID: 54}}},
+			},
+		},
+	}
+
+	for i, test := range tests {
+		privileges := catpb.NewBasePrivilegeDescriptor(security.AdminRoleName())
+		var cb nstree.MutableCatalog
+		test.desc.Privileges = catpb.NewBaseFunctionPrivilegeDescriptor(security.AdminRoleName())
+		desc := funcdesc.NewBuilder(&test.desc).BuildImmutable()
+		cb.UpsertDescriptorEntry(desc)
+		cb.UpsertDescriptorEntry(dbdesc.NewBuilder(&descpb.DatabaseDescriptor{
+			Name:       "db",
+			ID:         51,
+			Privileges: privileges,
+			Schemas: map[string]descpb.DatabaseDescriptor_SchemaInfo{
+				"sc": {ID: 52},
+			},
+		}).BuildImmutable())
+		cb.UpsertDescriptorEntry(schemadesc.NewBuilder(&descpb.SchemaDescriptor{
+			Name:       "sc",
+			ID:         52,
+			ParentID:   51,
+			Privileges: privileges,
+			Functions:  test.schemaFn,
+		}).BuildImmutable())
+		expectedErr := fmt.Sprintf("%s %q (%d): %s", desc.DescriptorType(), desc.GetName(), desc.GetID(), test.err)
+		results := cb.Validate(ctx, clusterversion.TestingClusterVersion, catalog.NoValidationTelemetry, catalog.ValidationLevelCrossReferences, desc)
+		if err := results.CombinedError(); err == nil {
+			if test.err != "" {
+				t.Errorf("%d: expected \"%s\", but found success: %+v", i, expectedErr, test.desc)
+			}
+		} else if expectedErr != err.Error() {
+			t.Errorf("%d: expected \"%s\", but found \"%s\"", i, expectedErr, err.Error())
+		}
+	}
+}
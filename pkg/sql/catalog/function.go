@@ -0,0 +1,65 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package catalog
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// FunctionDescriptor is an interface around the function descriptor types.
+// Each overload of a user-defined function is represented by its own
+// descriptor.
+type FunctionDescriptor interface {
+	Descriptor
+
+	// FuncDesc returns the backing protobuf for this function.
+	FuncDesc() *descpb.FunctionDescriptor
+
+	// GetParams returns the parameters of the function.
+	GetParams() []descpb.FunctionDescriptor_Parameter
+
+	// GetParamTypes returns the types of the parameters of the function.
+	GetParamTypes() []*types.T
+
+	// GetReturnType returns the return type of the function.
+	GetReturnType() *types.T
+
+	// GetVolatility returns the declared volatility of the function.
+	GetVolatility() descpb.FunctionDescriptor_Volatility
+
+	// GetLeakProof returns true if the function is declared LEAKPROOF.
+	GetLeakProof() bool
+
+	// GetNullInputBehavior returns the behavior of the function when it is
+	// called with NULL arguments.
+	GetNullInputBehavior() descpb.FunctionDescriptor_NullInputBehavior
+
+	// GetFunctionBody returns the SQL statement that is the body of the
+	// function.
+	GetFunctionBody() string
+
+	// GetDependsOn returns the IDs of the relations referenced by the body of
+	// the function.
+	GetDependsOn() []descpb.ID
+
+	// GetDependsOnTypes returns the IDs of the user-defined types referenced
+	// by the body of the function.
+	GetDependsOnTypes() []descpb.ID
+
+	// GetDependsOnFunctions returns the IDs of the user-defined functions
+	// called by the body of the function.
+	GetDependsOnFunctions() []descpb.ID
+
+	// GetDependedOnBy returns the references to the function from the bodies
+	// of other functions and triggers.
+	GetDependedOnBy() []descpb.FunctionDescriptor_Reference
+}
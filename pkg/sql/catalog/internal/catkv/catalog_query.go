@@ -177,6 +177,8 @@ func wrapError(expectedType catalog.DescriptorType, id descpb.ID, err error) err
 		return catalog.WrapSchemaDescRefErr(id, err)
 	case catalog.Type:
 		return catalog.WrapTypeDescRefErr(id, err)
+	case catalog.Function:
+		return catalog.WrapFunctionDescRefErr(id, err)
 	}
 	return errors.Wrapf(err, "referenced descriptor ID %d", id)
 }
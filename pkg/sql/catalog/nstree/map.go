@@ -33,13 +33,16 @@ type Map struct {
 type EntryIterator func(entry catalog.NameEntry) error
 
 // Upsert adds the descriptor to the tree. If any descriptor exists in the
-// tree with the same name or id, it will be removed.
+// tree with the same name or id, it will be removed. Function descriptors are
+// only indexed by id, see hasNamespaceEntry.
 func (dt *Map) Upsert(d catalog.NameEntry) {
 	dt.maybeInitialize()
-	if replaced := dt.byName.upsert(d); replaced != nil {
-		dt.byID.delete(replaced.GetID())
+	if hasNamespaceEntry(d) {
+		if replaced := dt.byName.upsert(d); replaced != nil {
+			dt.byID.delete(replaced.GetID())
+		}
 	}
-	if replaced := dt.byID.upsert(d); replaced != nil {
+	if replaced := dt.byID.upsert(d); replaced != nil && hasNamespaceEntry(replaced) {
 		dt.byName.delete(replaced)
 	}
 }
@@ -49,12 +52,22 @@ func (dt *Map) Upsert(d catalog.NameEntry) {
 func (dt *Map) Remove(id descpb.ID) catalog.NameEntry {
 	dt.maybeInitialize()
 	if d := dt.byID.delete(id); d != nil {
-		dt.byName.delete(d)
+		if hasNamespaceEntry(d) {
+			dt.byName.delete(d)
+		}
 		return d
 	}
 	return nil
 }
 
+// hasNamespaceEntry returns false if the entry is for a function descriptor.
+// Functions are not stored in the namespace table and the overloads of a
+// function all share the same name, so they must not be indexed by name.
+func hasNamespaceEntry(e catalog.NameEntry) bool {
+	d, ok := e.(interface{ DescriptorType() catalog.DescriptorType })
+	return !ok || d.DescriptorType() != catalog.Function
+}
+
 // GetByID gets a descriptor from the tree by id.
 func (dt *Map) GetByID(id descpb.ID) catalog.NameEntry {
 	if !dt.initialized() {
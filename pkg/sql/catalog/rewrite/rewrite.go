@@ -373,7 +373,8 @@ func rewriteIDsInTypesT(typ *types.T, descriptorRewrites jobspb.DescRewriteMap)
 	if rw, ok := descriptorRewrites[tid]; ok {
 		newOID = typedesc.TypeIDToOID(rw.ID)
 	}
-	if typ.Family() != types.ArrayFamily {
+	// Domains do not have array types.
+	if typ.Family() != types.ArrayFamily && !typ.IsDomain() {
 		tid, err = typedesc.GetUserDefinedArrayTypeDescID(typ)
 		if err != nil {
 			return err
@@ -418,16 +419,34 @@ func TypeDescs(types []*typedesc.Mutable, descriptorRewrites jobspb.DescRewriteM
 				typ.ReferencingDescriptorIDs[i] = rw.ID
 			}
 		}
+		// Composite types which are not restored no longer depend on the type.
+		referencingTypeIDs := typ.ReferencingTypeIDs[:0]
+		for _, id := range typ.ReferencingTypeIDs {
+			if rw, ok := descriptorRewrites[id]; ok {
+				referencingTypeIDs = append(referencingTypeIDs, rw.ID)
+			}
+		}
+		typ.ReferencingTypeIDs = referencingTypeIDs
 		switch t := typ.Kind; t {
-		case descpb.TypeDescriptor_ENUM, descpb.TypeDescriptor_MULTIREGION_ENUM:
+		case descpb.TypeDescriptor_ENUM, descpb.TypeDescriptor_MULTIREGION_ENUM,
+			descpb.TypeDescriptor_COMPOSITE:
 			if rw, ok := descriptorRewrites[typ.ArrayTypeID]; ok {
 				typ.ArrayTypeID = rw.ID
 			}
+			// The fields of composite types may be of user defined types.
+			for i := range typ.CompositeElements {
+				if err := rewriteIDsInTypesT(typ.CompositeElements[i].Type, descriptorRewrites); err != nil {
+					return err
+				}
+			}
 		case descpb.TypeDescriptor_ALIAS:
 			// We need to rewrite any ID's present in the aliased types.T.
 			if err := rewriteIDsInTypesT(typ.Alias, descriptorRewrites); err != nil {
 				return err
 			}
+		case descpb.TypeDescriptor_DOMAIN:
+			// Domains have no array type, and their base types are never user
+			// defined, so there are no other IDs to rewrite.
 		default:
 			return errors.AssertionFailedf("unknown type kind %s", t.String())
 		}
@@ -450,6 +469,10 @@ func SchemaDescs(schemas []*schemadesc.Mutable, descriptorRewrites jobspb.DescRe
 		sc.ID = rewrite.ID
 		sc.ParentID = rewrite.ParentID
 
+		// User-defined functions are not backed up, so the restored schema
+		// must not reference them.
+		sc.Functions = nil
+
 		if err := rewriteSchemaChangerState(sc, descriptorRewrites); err != nil {
 			return err
 		}
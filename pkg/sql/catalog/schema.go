@@ -26,6 +26,16 @@ type SchemaDescriptor interface {
 	// GetDefaultPrivilegeDescriptor returns the default privileges for this
 	// database.
 	GetDefaultPrivilegeDescriptor() DefaultPrivilegeDescriptor
+
+	// GetFunction returns the overloads of the user-defined function with the
+	// given name in this schema, if any.
+	GetFunction(name string) (descpb.SchemaDescriptor_Function, bool)
+
+	// ForEachFunctionOverload iterates over the overloads of all user-defined
+	// functions in this schema, in order of function name.
+	ForEachFunctionOverload(
+		fn func(name string, overload descpb.SchemaDescriptor_Function_Overload) error,
+	) error
 }
 
 // ResolvedSchemaKind is an enum that represents what kind of schema
@@ -12,6 +12,7 @@ package schemadesc
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
@@ -25,6 +26,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
 )
@@ -259,6 +261,34 @@ func (desc *immutable) GetDefaultPrivilegeDescriptor() catalog.DefaultPrivilegeD
 	return catprivilege.MakeDefaultPrivileges(defaultPrivilegeDescriptor)
 }
 
+// GetFunction implements the SchemaDescriptor interface.
+func (desc *immutable) GetFunction(name string) (descpb.SchemaDescriptor_Function, bool) {
+	fn, ok := desc.Functions[name]
+	return fn, ok
+}
+
+// ForEachFunctionOverload implements the SchemaDescriptor interface.
+func (desc *immutable) ForEachFunctionOverload(
+	fn func(name string, overload descpb.SchemaDescriptor_Function_Overload) error,
+) error {
+	names := make([]string, 0, len(desc.Functions))
+	for name := range desc.Functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, ol := range desc.Functions[name].Overloads {
+			if err := fn(name, ol); err != nil {
+				if iterutil.Done(err) {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // GetPostDeserializationChanges implements the Descriptor interface.
 func (desc *immutable) GetPostDeserializationChanges() catalog.PostDeserializationChanges {
 	return desc.changes
@@ -337,6 +367,39 @@ func (desc *Mutable) SetName(name string) {
 	desc.Name = name
 }
 
+// AddFunction adds the overload with the given ID to the function with the
+// given name.
+func (desc *Mutable) AddFunction(name string, id descpb.ID) {
+	if desc.Functions == nil {
+		desc.Functions = make(map[string]descpb.SchemaDescriptor_Function)
+	}
+	fn := desc.Functions[name]
+	fn.Overloads = append(fn.Overloads, descpb.SchemaDescriptor_Function_Overload{ID: id})
+	desc.Functions[name] = fn
+}
+
+// RemoveFunction removes the overload with the given ID from the function
+// with the given name. The function is removed entirely if it has no
+// remaining overloads.
+func (desc *Mutable) RemoveFunction(name string, id descpb.ID) {
+	fn, ok := desc.Functions[name]
+	if !ok {
+		return
+	}
+	var overloads []descpb.SchemaDescriptor_Function_Overload
+	for _, ol := range fn.Overloads {
+		if ol.ID != id {
+			overloads = append(overloads, ol)
+		}
+	}
+	if len(overloads) == 0 {
+		delete(desc.Functions, name)
+		return
+	}
+	fn.Overloads = overloads
+	desc.Functions[name] = fn
+}
+
 // IsUncommittedVersion implements the Descriptor interface.
 func (desc *Mutable) IsUncommittedVersion() bool {
 	return desc.IsNew() || desc.GetVersion() != desc.ClusterVersion.GetVersion()
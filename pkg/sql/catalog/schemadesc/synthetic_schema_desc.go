@@ -110,6 +110,14 @@ func (p synthetic) SchemaDesc() *descpb.SchemaDescriptor {
 		"synthetic %s cannot be encoded", p.kindName())
 	return nil // unreachable
 }
+func (p synthetic) GetFunction(name string) (descpb.SchemaDescriptor_Function, bool) {
+	return descpb.SchemaDescriptor_Function{}, false
+}
+func (p synthetic) ForEachFunctionOverload(
+	fn func(name string, overload descpb.SchemaDescriptor_Function_Overload) error,
+) error {
+	return nil
+}
 func (p synthetic) GetDeclarativeSchemaChangerState() *scpb.DescriptorState {
 	return nil
 }
@@ -0,0 +1,91 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schemaexpr
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// DomainValueName is the name by which the CHECK constraint expressions of a
+// domain refer to the value being checked.
+const DomainValueName = tree.Name("value")
+
+// ValidateDomainCheckExpr validates that the CHECK constraint expression of a
+// domain with the given base type is a boolean expression that only refers to
+// VALUE and contains no functions with a volatility greater than immutable.
+// The serialized, type-checked expression is returned.
+func ValidateDomainCheckExpr(
+	ctx context.Context, expr tree.Expr, baseType *types.T, semaCtx *tree.SemaContext,
+) (string, error) {
+	replacedExpr, _, err := ReplaceColumnVars(
+		expr,
+		func(columnName tree.Name) (exists bool, accessible bool, id catid.ColumnID, typ *types.T) {
+			if columnName != DomainValueName {
+				return false, false, 0, nil
+			}
+			return true, true, 0, baseType
+		},
+	)
+	if err != nil {
+		if pgerror.GetPGCode(err) == pgcode.UndefinedColumn {
+			return "", pgerror.New(pgcode.FeatureNotSupported,
+				"cannot use table references in domain check constraint")
+		}
+		return "", err
+	}
+	typedExpr, err := SanitizeVarFreeExpr(
+		ctx, replacedExpr, types.Bool, "domain CHECK", semaCtx, tree.VolatilityImmutable,
+	)
+	if err != nil {
+		return "", err
+	}
+	return tree.Serialize(typedExpr), nil
+}
+
+// ValidateDomainDefaultExpr validates that the default expression of a domain
+// with the given base type is variable-free and of the base type. The
+// serialized, type-checked expression is returned.
+func ValidateDomainDefaultExpr(
+	ctx context.Context, expr tree.Expr, baseType *types.T, semaCtx *tree.SemaContext,
+) (string, error) {
+	typedExpr, err := SanitizeVarFreeExpr(
+		ctx, expr, baseType, "DEFAULT", semaCtx, tree.VolatilityVolatile,
+	)
+	if err != nil {
+		return "", err
+	}
+	return tree.Serialize(typedExpr), nil
+}
+
+// ReplaceDomainValue replaces the references to VALUE in the given domain
+// CHECK constraint expression with the given expression.
+func ReplaceDomainValue(rootExpr tree.Expr, value tree.Expr) (tree.Expr, error) {
+	return tree.SimpleVisit(rootExpr, func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		vBase, ok := expr.(tree.VarName)
+		if !ok {
+			return true, expr, nil
+		}
+		v, err := vBase.NormalizeVarName()
+		if err != nil {
+			return false, nil, err
+		}
+		if c, ok := v.(*tree.ColumnItem); ok && c.ColumnName == DomainValueName {
+			return false, value, nil
+		}
+		return true, expr, nil
+	})
+}
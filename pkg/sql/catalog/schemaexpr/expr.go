@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
 )
 
@@ -430,6 +431,9 @@ func SanitizeVarFreeExpr(
 	if err != nil {
 		return nil, err
 	}
+	if err := rejectUserDefinedFunctions(typedExpr, context); err != nil {
+		return nil, err
+	}
 
 	actualType := typedExpr.ResolvedType()
 	if !expectedType.Equivalent(actualType) && typedExpr != tree.DNull {
@@ -440,3 +444,20 @@ func SanitizeVarFreeExpr(
 	}
 	return typedExpr, nil
 }
+
+// rejectUserDefinedFunctions returns an error if the type-checked expression
+// calls a user-defined function. Expressions stored in descriptors are
+// evaluated without access to the catalog, so they may only reference
+// builtins.
+func rejectUserDefinedFunctions(expr tree.TypedExpr, context string) error {
+	_, err := tree.SimpleVisit(expr, func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		if f, ok := expr.(*tree.FuncExpr); ok {
+			if ov := f.ResolvedOverload(); ov != nil && ov.IsUDF {
+				return false, nil, unimplemented.NewWithIssueDetailf(17511, "udf",
+					"user-defined functions are not allowed in %s", context)
+			}
+		}
+		return true, expr, nil
+	})
+	return err
+}
@@ -16,6 +16,7 @@
 package seqexpr
 
 import (
+	"context"
 	"go/constant"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -48,8 +49,13 @@ func GetSequenceFromFunc(funcExpr *tree.FuncExpr) (*SeqIdentifier, error) {
 
 	// Resolve doesn't use the searchPath for resolving FunctionDefinitions
 	// so we can pass in an empty SearchPath.
-	def, err := funcExpr.Func.Resolve(searchPath)
+	def, err := funcExpr.Func.Resolve(context.Background(), searchPath, nil /* resolver */)
 	if err != nil {
+		// Only builtin functions can take sequence arguments, so a function that
+		// cannot be resolved as a builtin is not a sequence function.
+		if pgerror.GetPGCode(err) == pgcode.UndefinedFunction {
+			return nil, nil
+		}
 		return nil, err
 	}
 
@@ -52,6 +52,8 @@ func ShouldSplitAtDesc(rawDesc *roachpb.Value) bool {
 		return false
 	case *descpb.Descriptor_Schema:
 		return false
+	case *descpb.Descriptor_Function:
+		return false
 	default:
 		panic(errors.AssertionFailedf("unexpected descriptor type %#v", &desc))
 	}
@@ -676,6 +678,17 @@ CREATE TABLE system.span_count (
 	CONSTRAINT single_row CHECK (singleton),
 	FAMILY "primary" (singleton, span_count)
 );`
+
+	NotificationsTableSchema = `
+CREATE TABLE system.notifications (
+	id      INT8 NOT NULL DEFAULT unique_rowid(),
+	channel STRING NOT NULL,
+	payload STRING NOT NULL,
+	pid     INT4 NOT NULL,
+	created TIMESTAMP NOT NULL DEFAULT now(),
+	CONSTRAINT "primary" PRIMARY KEY (id),
+	FAMILY "primary" (id, channel, payload, pid, created)
+);`
 )
 
 func pk(name string) descpb.IndexDescriptor {
@@ -2396,6 +2409,32 @@ var (
 			}}
 		},
 	)
+
+	// NotificationsTable is the descriptor for the notifications table. It
+	// contains the notifications sent with NOTIFY, which are delivered to the
+	// listening sessions by watching the table with a rangefeed.
+	NotificationsTable = registerSystemTable(
+		NotificationsTableSchema,
+		systemTable(
+			catconstants.NotificationsTableName,
+			descpb.InvalidID, // dynamically assigned
+			[]descpb.ColumnDescriptor{
+				{Name: "id", ID: 1, Type: types.Int, DefaultExpr: &uniqueRowIDString},
+				{Name: "channel", ID: 2, Type: types.String},
+				{Name: "payload", ID: 3, Type: types.String},
+				{Name: "pid", ID: 4, Type: types.Int4},
+				{Name: "created", ID: 5, Type: types.Timestamp, DefaultExpr: &nowString},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"id", "channel", "payload", "pid", "created"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5},
+				},
+			},
+			pk("id"),
+		))
 )
 
 type descRefByName struct {
@@ -185,6 +185,12 @@ type Index interface {
 	// Panics if the index is not inverted.
 	InvertedColumnKeyType() *types.T
 
+	// InvertedColumnKind returns the kind of the inverted column of the
+	// inverted index, which determines how the column is encoded in the index.
+	//
+	// Panics if the index is not inverted.
+	InvertedColumnKind() catpb.InvertedIndexColumnKind
+
 	NumPrimaryStoredColumns() int
 	NumSecondaryStoredColumns() int
 	GetStoredColumnID(storedColumnOrdinal int) descpb.ColumnID
@@ -477,10 +477,15 @@ func (desc *wrapper) GetAllReferencedTypeIDs(
 	}
 
 	// Add any other type dependencies that are not
-	// used in a column (specifically for views).
+	// used in a column (specifically for views and triggers).
 	for _, id := range desc.DependsOnTypes {
 		ids[id] = struct{}{}
 	}
+	for i := range desc.Triggers {
+		for _, id := range desc.Triggers[i].DependsOnTypes {
+			ids[id] = struct{}{}
+		}
+	}
 
 	// Construct the output.
 	result := make(descpb.IDs, 0, len(ids))
@@ -1077,14 +1082,19 @@ func checkColumnsValidForIndex(tableDesc *Mutable, indexColNames []string) error
 	return nil
 }
 
-func checkColumnsValidForInvertedIndex(tableDesc *Mutable, indexColNames []string) error {
+func checkColumnsValidForInvertedIndex(
+	tableDesc *Mutable, indexColNames []string, kind catpb.InvertedIndexColumnKind,
+) error {
 	lastCol := len(indexColNames) - 1
 	for i, indexCol := range indexColNames {
 		for _, col := range tableDesc.NonDropColumns() {
 			if col.GetName() == indexCol {
 				// The last column indexed by an inverted index must be
-				// inverted indexable.
-				if i == lastCol && !colinfo.ColumnTypeIsInvertedIndexable(col.GetType()) {
+				// inverted indexable, or trigram indexable if the index is a
+				// trigram index.
+				isTrigram := kind == catpb.InvertedIndexColumnKind_TRIGRAM
+				if i == lastCol && !colinfo.ColumnTypeIsInvertedIndexable(col.GetType()) &&
+					!(isTrigram && colinfo.ColumnTypeIsTrigramIndexable(col.GetType())) {
 					return errors.WithHint(
 						pgerror.Newf(
 							pgcode.FeatureNotSupported,
@@ -1172,7 +1182,7 @@ func (desc *Mutable) AddSecondaryIndex(idx descpb.IndexDescriptor) error {
 			return err
 		}
 	} else {
-		if err := checkColumnsValidForInvertedIndex(desc, idx.KeyColumnNames); err != nil {
+		if err := checkColumnsValidForInvertedIndex(desc, idx.KeyColumnNames, idx.InvertedColumnKind()); err != nil {
 			return err
 		}
 	}
@@ -2105,7 +2115,7 @@ func (desc *Mutable) checkValidIndex(idx *descpb.IndexDescriptor) error {
 			return err
 		}
 	case descpb.IndexDescriptor_INVERTED:
-		if err := checkColumnsValidForInvertedIndex(desc, idx.KeyColumnNames); err != nil {
+		if err := checkColumnsValidForInvertedIndex(desc, idx.KeyColumnNames, idx.InvertedColumnKind()); err != nil {
 			return err
 		}
 	}
@@ -666,6 +666,19 @@ func RenameColumnInTable(
 		}
 	}
 
+	// Rename the column in row-level security policies.
+	for i := range tableDesc.Policies {
+		policy := &tableDesc.Policies[i]
+		for _, expr := range []*string{&policy.UsingExpr, &policy.WithCheckExpr} {
+			if *expr == "" {
+				continue
+			}
+			if err := renameInExpr(expr); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Rename the column in partial idx predicates.
 	for _, idx := range tableDesc.PublicNonPrimaryIndexes() {
 		if idx.IsPartial() {
@@ -243,6 +243,29 @@ func UpdateIndexPartitioning(
 	return true
 }
 
+// WithoutPartition returns the partitioning without its top-level partition
+// with the given name. If it was the last partition, the returned
+// partitioning is empty. The slices of part are reused.
+func WithoutPartition(part catpb.PartitioningDescriptor, name string) catpb.PartitioningDescriptor {
+	lists := part.List[:0]
+	for _, l := range part.List {
+		if l.Name != name {
+			lists = append(lists, l)
+		}
+	}
+	ranges := part.Range[:0]
+	for _, r := range part.Range {
+		if r.Name != name {
+			ranges = append(ranges, r)
+		}
+	}
+	if len(lists) == 0 && len(ranges) == 0 {
+		return catpb.PartitioningDescriptor{}
+	}
+	part.List, part.Range = lists, ranges
+	return part
+}
+
 // GetPrimaryIndex implements the TableDescriptor interface.
 func (desc *wrapper) GetPrimaryIndex() catalog.Index {
 	return desc.getExistingOrNewIndexCache().primary
@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
@@ -119,6 +120,24 @@ func (desc *wrapper) GetReferencedDescIDs() (catalog.DescriptorIDSet, error) {
 	for _, ref := range desc.GetDependedOnBy() {
 		ids.Add(ref.ID)
 	}
+	// Add trigger dependencies.
+	for i := range desc.Triggers {
+		for _, id := range desc.Triggers[i].DependsOn {
+			ids.Add(id)
+		}
+		for _, id := range desc.Triggers[i].DependsOnTypes {
+			ids.Add(id)
+		}
+		for _, id := range desc.Triggers[i].DependsOnFunctions {
+			ids.Add(id)
+		}
+	}
+	for _, ref := range desc.DependedOnByTriggers {
+		ids.Add(ref.TableID)
+	}
+	for _, ref := range desc.DependedOnByFunctions {
+		ids.Add(ref.FunctionID)
+	}
 	// Add sequence dependencies
 	return ids, nil
 }
@@ -187,6 +206,26 @@ func (desc *wrapper) ValidateCrossReferences(
 		vea.Report(desc.validateInboundTableRef(by, vdg))
 	}
 
+	// Check the relations and types referenced by triggers.
+	for i := range desc.Triggers {
+		trig := &desc.Triggers[i]
+		for _, id := range trig.DependsOn {
+			vea.Report(desc.validateOutboundTriggerRef(trig, id, vdg))
+		}
+		for _, id := range trig.DependsOnTypes {
+			vea.Report(desc.validateOutboundTypeRef(id, vdg))
+		}
+		for _, id := range trig.DependsOnFunctions {
+			vea.Report(desc.validateOutboundTriggerFunctionRef(trig, id, vdg))
+		}
+	}
+	for i := range desc.DependedOnByTriggers {
+		vea.Report(desc.validateInboundTriggerRef(&desc.DependedOnByTriggers[i], vdg))
+	}
+	for i := range desc.DependedOnByFunctions {
+		vea.Report(desc.validateInboundFunctionRef(&desc.DependedOnByFunctions[i], vdg))
+	}
+
 	// For row-level TTL, only ascending PKs are permitted.
 	if desc.HasRowLevelTTL() {
 		pk := desc.GetPrimaryIndex()
@@ -276,6 +315,95 @@ func (desc *wrapper) validateOutboundTableRef(
 		referencedTable.GetName(), id)
 }
 
+func (desc *wrapper) validateOutboundTriggerRef(
+	trig *descpb.TriggerDescriptor, id descpb.ID, vdg catalog.ValidationDescGetter,
+) error {
+	referencedTable, err := vdg.GetTableDescriptor(id)
+	if err != nil {
+		return errors.NewAssertionErrorWithWrappedErrf(err,
+			"invalid depends-on relation reference in trigger %q", trig.Name)
+	}
+	if referencedTable.Dropped() {
+		return errors.AssertionFailedf("depends-on relation %q (%d) of trigger %q is dropped",
+			referencedTable.GetName(), referencedTable.GetID(), trig.Name)
+	}
+	for _, by := range referencedTable.TableDesc().DependedOnByTriggers {
+		if by.TableID == desc.GetID() && by.TriggerName == trig.Name {
+			return nil
+		}
+	}
+	return errors.AssertionFailedf("depends-on relation %q (%d) of trigger %q has no corresponding "+
+		"depended-on-by-trigger back reference", referencedTable.GetName(), id, trig.Name)
+}
+
+func (desc *wrapper) validateInboundTriggerRef(
+	by *descpb.TableDescriptor_TriggerReference, vdg catalog.ValidationDescGetter,
+) error {
+	backReferencedTable, err := vdg.GetTableDescriptor(by.TableID)
+	if err != nil {
+		return errors.NewAssertionErrorWithWrappedErrf(err,
+			"invalid depended-on-by trigger back reference")
+	}
+	if backReferencedTable.Dropped() {
+		return errors.AssertionFailedf("depended-on-by trigger %q on relation %q (%d) is dropped",
+			by.TriggerName, backReferencedTable.GetName(), backReferencedTable.GetID())
+	}
+	for _, trig := range backReferencedTable.GetTriggers() {
+		if trig.Name != by.TriggerName {
+			continue
+		}
+		for _, id := range trig.DependsOn {
+			if id == desc.GetID() {
+				return nil
+			}
+		}
+	}
+	return errors.AssertionFailedf("depended-on-by trigger %q on relation %q (%d) has no corresponding "+
+		"depends-on forward reference", by.TriggerName, backReferencedTable.GetName(), by.TableID)
+}
+
+func (desc *wrapper) validateOutboundTriggerFunctionRef(
+	trig *descpb.TriggerDescriptor, id descpb.ID, vdg catalog.ValidationDescGetter,
+) error {
+	fn, err := vdg.GetFunctionDescriptor(id)
+	if err != nil {
+		return errors.NewAssertionErrorWithWrappedErrf(err,
+			"invalid depends-on function reference in trigger %q", trig.Name)
+	}
+	if fn.Dropped() {
+		return errors.AssertionFailedf("depends-on function %q (%d) of trigger %q is dropped",
+			fn.GetName(), fn.GetID(), trig.Name)
+	}
+	for _, by := range fn.GetDependedOnBy() {
+		if by.ID == desc.GetID() && by.TriggerName == trig.Name {
+			return nil
+		}
+	}
+	return errors.AssertionFailedf("depends-on function %q (%d) of trigger %q has no corresponding "+
+		"depended-on-by back reference", fn.GetName(), id, trig.Name)
+}
+
+func (desc *wrapper) validateInboundFunctionRef(
+	by *descpb.TableDescriptor_FunctionReference, vdg catalog.ValidationDescGetter,
+) error {
+	fn, err := vdg.GetFunctionDescriptor(by.FunctionID)
+	if err != nil {
+		return errors.NewAssertionErrorWithWrappedErrf(err,
+			"invalid depended-on-by function back reference")
+	}
+	if fn.Dropped() {
+		return errors.AssertionFailedf("depended-on-by function %q (%d) is dropped",
+			fn.GetName(), fn.GetID())
+	}
+	for _, id := range fn.GetDependsOn() {
+		if id == desc.GetID() {
+			return nil
+		}
+	}
+	return errors.AssertionFailedf("depended-on-by function %q (%d) has no corresponding "+
+		"depends-on forward reference", fn.GetName(), by.FunctionID)
+}
+
 func (desc *wrapper) validateOutboundTypeRef(id descpb.ID, vdg catalog.ValidationDescGetter) error {
 	typ, err := vdg.GetTypeDescriptor(id)
 	if err != nil {
@@ -605,6 +733,8 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			desc.validateColumnFamilies(columnIDs),
 			desc.validateCheckConstraints(columnIDs),
 			desc.validateUniqueWithoutIndexConstraints(columnIDs),
+			desc.validateTriggers(),
+			desc.validatePolicies(),
 			desc.validateTableIndexes(columnNames, vea),
 			desc.validatePartitioning(),
 		}
@@ -981,6 +1111,58 @@ func (desc *wrapper) validateCheckConstraints(
 	return nil
 }
 
+// validateTriggers validates that triggers are well formed. Checks include
+// validating the trigger names and verifying that trigger bodies can be parsed.
+func (desc *wrapper) validateTriggers() error {
+	names := make(map[string]struct{}, len(desc.Triggers))
+	for i := range desc.Triggers {
+		trig := &desc.Triggers[i]
+		if trig.Name == "" {
+			return errors.AssertionFailedf("empty trigger name")
+		}
+		if _, ok := names[trig.Name]; ok {
+			return errors.AssertionFailedf("duplicate trigger name: %q", trig.Name)
+		}
+		names[trig.Name] = struct{}{}
+		if len(trig.Events) == 0 {
+			return errors.AssertionFailedf("trigger %q has no events", trig.Name)
+		}
+		if _, err := parser.ParseOne(trig.Body); err != nil {
+			return errors.Wrapf(err, "trigger %q has an invalid body", trig.Name)
+		}
+	}
+	return nil
+}
+
+// validatePolicies validates that row-level security policies are well
+// formed. Checks include validating the policy names and roles, and verifying
+// that policy expressions can be parsed.
+func (desc *wrapper) validatePolicies() error {
+	names := make(map[string]struct{}, len(desc.Policies))
+	for i := range desc.Policies {
+		policy := &desc.Policies[i]
+		if policy.Name == "" {
+			return errors.AssertionFailedf("empty policy name")
+		}
+		if _, ok := names[policy.Name]; ok {
+			return errors.AssertionFailedf("duplicate policy name: %q", policy.Name)
+		}
+		names[policy.Name] = struct{}{}
+		if len(policy.Roles) == 0 {
+			return errors.AssertionFailedf("policy %q has no roles", policy.Name)
+		}
+		for _, expr := range []string{policy.UsingExpr, policy.WithCheckExpr} {
+			if expr == "" {
+				continue
+			}
+			if _, err := parser.ParseExpr(expr); err != nil {
+				return errors.Wrapf(err, "policy %q has an invalid expression", policy.Name)
+			}
+		}
+	}
+	return nil
+}
+
 // validateUniqueWithoutIndexConstraints validates that unique without index
 // constraints are well formed. Checks include validating the column IDs and
 // column names.
@@ -1017,6 +1199,20 @@ func (desc *wrapper) validateUniqueWithoutIndexConstraints(
 			seen.Add(int(colID))
 		}
 
+		if c.IsExclusion() {
+			if len(c.ExclusionOperators) != len(c.ColumnIDs) {
+				return errors.Newf(
+					"exclusion constraint %q has %d operators for %d columns",
+					c.Name, len(c.ExclusionOperators), len(c.ColumnIDs),
+				)
+			}
+			for _, op := range c.ExclusionOperators {
+				if op != treecmp.EQ.String() && op != treecmp.Overlaps.String() {
+					return errors.Newf("exclusion constraint %q has unsupported operator %q", c.Name, op)
+				}
+			}
+		}
+
 		if c.IsPartial() {
 			expr, err := parser.ParseExpr(c.Predicate)
 			if err != nil {
@@ -134,7 +134,13 @@ var validationMap = []struct {
 			"AutoStatsSettings": {
 				status: todoIAmKnowinglyAddingTechDebt,
 				reason: "initial import: TODO(msirek): add validation"},
-			"ForecastStats": {status: thisFieldReferencesNoObjects},
+			"ForecastStats":         {status: thisFieldReferencesNoObjects},
+			"Triggers":              {status: iSolemnlySwearThisFieldIsValidated},
+			"RowLevelSecurity":      {status: thisFieldReferencesNoObjects},
+			"ForceRowLevelSecurity": {status: thisFieldReferencesNoObjects},
+			"Policies":              {status: iSolemnlySwearThisFieldIsValidated},
+			"DependedOnByTriggers":  {status: iSolemnlySwearThisFieldIsValidated},
+			"DependedOnByFunctions": {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 	{
@@ -258,6 +264,11 @@ var validationMap = []struct {
 			"OfflineReason":                 {status: thisFieldReferencesNoObjects},
 			"RegionConfig":                  {status: iSolemnlySwearThisFieldIsValidated},
 			"DeclarativeSchemaChangerState": {status: thisFieldReferencesNoObjects},
+			"DomainBaseType":                {status: iSolemnlySwearThisFieldIsValidated},
+			"DomainDefaultExpr":             {status: thisFieldReferencesNoObjects},
+			"DomainConstraints":             {status: iSolemnlySwearThisFieldIsValidated},
+			"CompositeElements":             {status: iSolemnlySwearThisFieldIsValidated},
+			"ReferencingFunctionIDs":        {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 	{
@@ -43,6 +43,15 @@ func formatSafeTypeProperties(w *redact.StringBuilder, desc catalog.TypeDescript
 	if td.Alias != nil {
 		w.Printf(", Alias: %d", td.Alias.Oid())
 	}
+	if td.DomainBaseType != nil {
+		w.Printf(", DomainBaseType: %d", td.DomainBaseType.Oid())
+	}
+	if len(td.DomainConstraints) > 0 {
+		w.Printf(", NumDomainConstraints: %d", len(td.DomainConstraints))
+	}
+	if len(td.CompositeElements) > 0 {
+		w.Printf(", NumCompositeElements: %d", len(td.CompositeElements))
+	}
 	if td.ArrayTypeID != 0 {
 		w.Printf(", ArrayTypeID: %d", td.ArrayTypeID)
 	}
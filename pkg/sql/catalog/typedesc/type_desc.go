@@ -132,7 +132,7 @@ func UserDefinedTypeOIDToID(oid oid.Oid) (descpb.ID, error) {
 
 // GetUserDefinedTypeDescID gets the type descriptor ID from a user defined type.
 func GetUserDefinedTypeDescID(t *types.T) (descpb.ID, error) {
-	return UserDefinedTypeOIDToID(t.Oid())
+	return UserDefinedTypeOIDToID(t.UserDefinedOID())
 }
 
 // GetUserDefinedArrayTypeDescID gets the ID of the array type descriptor from a user
@@ -451,6 +451,36 @@ func (desc *Mutable) AddEnumValue(node *tree.AlterTypeAddValue) error {
 	return nil
 }
 
+// AddDomainConstraint adds a constraint to the domain. The constraint is
+// enforced on writes right away, but existing values of the domain are only
+// checked by the type schema changer, which promotes it to VALIDATED.
+// AddDomainConstraint assumes that the type is a domain.
+func (desc *Mutable) AddDomainConstraint(c descpb.TypeDescriptor_DomainConstraint) {
+	desc.DomainConstraints = append(desc.DomainConstraints, c)
+}
+
+// FindDomainConstraint returns the constraint of the domain with the given
+// name, or nil if there is no such constraint.
+func (desc *Mutable) FindDomainConstraint(name string) *descpb.TypeDescriptor_DomainConstraint {
+	for i := range desc.DomainConstraints {
+		if desc.DomainConstraints[i].Name == name {
+			return &desc.DomainConstraints[i]
+		}
+	}
+	return nil
+}
+
+// RemoveDomainConstraint removes the constraint with the given name from the
+// domain. It has no effect if there is no such constraint.
+func (desc *Mutable) RemoveDomainConstraint(name string) {
+	for i := range desc.DomainConstraints {
+		if desc.DomainConstraints[i].Name == name {
+			desc.DomainConstraints = append(desc.DomainConstraints[:i], desc.DomainConstraints[i+1:]...)
+			return
+		}
+	}
+}
+
 // AddReferencingDescriptorID adds a new referencing descriptor ID to the
 // TypeDescriptor. It ensures that duplicates are not added.
 func (desc *Mutable) AddReferencingDescriptorID(new descpb.ID) {
@@ -473,6 +503,50 @@ func (desc *Mutable) RemoveReferencingDescriptorID(remove descpb.ID) {
 	}
 }
 
+// AddReferencingFunctionID adds a new referencing function ID to the
+// TypeDescriptor. It ensures that duplicates are not added.
+func (desc *Mutable) AddReferencingFunctionID(new descpb.ID) {
+	for _, id := range desc.ReferencingFunctionIDs {
+		if new == id {
+			return
+		}
+	}
+	desc.ReferencingFunctionIDs = append(desc.ReferencingFunctionIDs, new)
+}
+
+// RemoveReferencingFunctionID removes the desired referencing function ID
+// from the TypeDescriptor. It has no effect if the requested ID is not present.
+func (desc *Mutable) RemoveReferencingFunctionID(remove descpb.ID) {
+	for i, id := range desc.ReferencingFunctionIDs {
+		if id == remove {
+			desc.ReferencingFunctionIDs = append(desc.ReferencingFunctionIDs[:i], desc.ReferencingFunctionIDs[i+1:]...)
+			return
+		}
+	}
+}
+
+// AddReferencingTypeID adds a new referencing composite type ID to the
+// TypeDescriptor. It ensures that duplicates are not added.
+func (desc *Mutable) AddReferencingTypeID(new descpb.ID) {
+	for _, id := range desc.ReferencingTypeIDs {
+		if new == id {
+			return
+		}
+	}
+	desc.ReferencingTypeIDs = append(desc.ReferencingTypeIDs, new)
+}
+
+// RemoveReferencingTypeID removes the desired referencing composite type ID
+// from the TypeDescriptor. It has no effect if the requested ID is not present.
+func (desc *Mutable) RemoveReferencingTypeID(remove descpb.ID) {
+	for i, id := range desc.ReferencingTypeIDs {
+		if id == remove {
+			desc.ReferencingTypeIDs = append(desc.ReferencingTypeIDs[:i], desc.ReferencingTypeIDs[i+1:]...)
+			return
+		}
+	}
+}
+
 // SetParentSchemaID sets the SchemaID of the type.
 func (desc *Mutable) SetParentSchemaID(schemaID descpb.ID) {
 	desc.ParentSchemaID = schemaID
@@ -555,6 +629,25 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 		if desc.GetArrayTypeID() != descpb.InvalidID {
 			vea.Report(errors.AssertionFailedf("ALIAS type desc has array type ID %d", desc.GetArrayTypeID()))
 		}
+	case descpb.TypeDescriptor_DOMAIN:
+		if desc.RegionConfig != nil {
+			vea.Report(errors.AssertionFailedf("found region config on %s type desc", desc.Kind.String()))
+		}
+		if desc.DomainBaseType == nil {
+			vea.Report(errors.AssertionFailedf("DOMAIN type desc has nil base type"))
+		} else if desc.DomainBaseType.UserDefined() {
+			vea.Report(errors.AssertionFailedf("DOMAIN type desc has user defined base type %s",
+				desc.DomainBaseType.SQLString()))
+		}
+		if desc.GetArrayTypeID() != descpb.InvalidID {
+			vea.Report(errors.AssertionFailedf("DOMAIN type desc has array type ID %d", desc.GetArrayTypeID()))
+		}
+		desc.validateDomainConstraints(vea)
+	case descpb.TypeDescriptor_COMPOSITE:
+		if desc.RegionConfig != nil {
+			vea.Report(errors.AssertionFailedf("found region config on %s type desc", desc.Kind.String()))
+		}
+		desc.validateCompositeElements(vea)
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
 		vea.Report(errors.AssertionFailedf("invalid type descriptor: kind %s should never be serialized or validated", desc.Kind.String()))
 	default:
@@ -604,10 +697,58 @@ func (desc *immutable) validateEnumMembers(vea catalog.ValidationErrorAccumulato
 	return isSorted
 }
 
+// validateDomainConstraints performs domain constraint checks.
+func (desc *immutable) validateDomainConstraints(vea catalog.ValidationErrorAccumulator) {
+	names := make(map[string]struct{}, len(desc.DomainConstraints))
+	for _, c := range desc.DomainConstraints {
+		if c.Name == "" {
+			vea.Report(errors.AssertionFailedf("empty domain constraint name"))
+		}
+		if _, ok := names[c.Name]; ok {
+			vea.Report(errors.AssertionFailedf("duplicate domain constraint %q", c.Name))
+		}
+		names[c.Name] = struct{}{}
+		if c.Expr == "" {
+			vea.Report(errors.AssertionFailedf("domain constraint %q has empty expression", c.Name))
+		}
+		if c.Validity == descpb.ConstraintValidity_Dropping {
+			vea.Report(errors.AssertionFailedf("domain constraint %q has invalid validity %s",
+				c.Name, c.Validity))
+		}
+	}
+}
+
+// validateCompositeElements performs composite type element checks.
+func (desc *immutable) validateCompositeElements(vea catalog.ValidationErrorAccumulator) {
+	labels := make(map[string]struct{}, len(desc.CompositeElements))
+	for _, e := range desc.CompositeElements {
+		if e.Label == "" {
+			vea.Report(errors.AssertionFailedf("empty composite type element label"))
+		}
+		if _, ok := labels[e.Label]; ok {
+			vea.Report(errors.AssertionFailedf("duplicate composite type element %q", e.Label))
+		}
+		labels[e.Label] = struct{}{}
+		if e.Type == nil {
+			vea.Report(errors.AssertionFailedf("composite type element %q has nil type", e.Label))
+		} else if e.Type.UserDefined() && e.Type.Family() != types.EnumFamily {
+			// Of the user defined types, only enums are allowed as fields.
+			vea.Report(errors.AssertionFailedf("composite type element %q has user defined type %s",
+				e.Label, e.Type.SQLString()))
+		}
+	}
+}
+
 // GetReferencedDescIDs returns the IDs of all descriptors referenced by
 // this descriptor, including itself.
 func (desc *immutable) GetReferencedDescIDs() (catalog.DescriptorIDSet, error) {
 	ids := catalog.MakeDescriptorIDSet(desc.GetReferencingDescriptorIDs()...)
+	for _, id := range desc.ReferencingFunctionIDs {
+		ids.Add(id)
+	}
+	for _, id := range desc.ReferencingTypeIDs {
+		ids.Add(id)
+	}
 	ids.Add(desc.GetParentID())
 	// TODO(richardjcai): Remove logic for keys.PublicSchemaID in 22.2.
 	if desc.GetParentSchemaID() != keys.PublicSchemaID {
@@ -657,13 +798,31 @@ func (desc *immutable) ValidateCrossReferences(
 
 	// Validate that the referenced types exist.
 	switch desc.GetKind() {
-	case descpb.TypeDescriptor_ENUM, descpb.TypeDescriptor_MULTIREGION_ENUM:
+	case descpb.TypeDescriptor_ENUM, descpb.TypeDescriptor_MULTIREGION_ENUM, descpb.TypeDescriptor_COMPOSITE:
 		// Ensure that the referenced array type exists.
 		if typ, err := vdg.GetTypeDescriptor(desc.GetArrayTypeID()); err != nil {
 			vea.Report(errors.Wrapf(err, "arrayTypeID %d does not exist for %q", desc.GetArrayTypeID(), desc.GetKind()))
 		} else if typ.Dropped() {
 			vea.Report(errors.AssertionFailedf("array type %q (%d) is dropped", typ.GetName(), typ.GetID()))
 		}
+	}
+	for _, e := range desc.CompositeElements {
+		if e.Type == nil || !e.Type.UserDefined() {
+			continue
+		}
+		elemID, err := GetUserDefinedTypeDescID(e.Type)
+		if err != nil {
+			vea.Report(err)
+			continue
+		}
+		if typ, err := vdg.GetTypeDescriptor(elemID); err != nil {
+			vea.Report(errors.Wrapf(err, "type %d of composite type element %q does not exist", elemID, e.Label))
+		} else if typ.Dropped() {
+			vea.Report(errors.AssertionFailedf("type %q (%d) of composite type element %q is dropped",
+				typ.GetName(), typ.GetID(), e.Label))
+		}
+	}
+	switch desc.GetKind() {
 	case descpb.TypeDescriptor_ALIAS:
 		if desc.GetAlias().UserDefined() {
 			aliasedID, err := UserDefinedTypeOIDToID(desc.GetAlias().Oid())
@@ -690,6 +849,28 @@ func (desc *immutable) ValidateCrossReferences(
 				"referencing table %d was dropped without dependency unlinking", id))
 		}
 	}
+	for _, id := range desc.ReferencingFunctionIDs {
+		fnDesc, err := vdg.GetFunctionDescriptor(id)
+		if err != nil {
+			vea.Report(err)
+			continue
+		}
+		if fnDesc.Dropped() {
+			vea.Report(errors.AssertionFailedf(
+				"referencing function %d was dropped without dependency unlinking", id))
+		}
+	}
+	for _, id := range desc.ReferencingTypeIDs {
+		typDesc, err := vdg.GetTypeDescriptor(id)
+		if err != nil {
+			vea.Report(err)
+			continue
+		}
+		if typDesc.Dropped() {
+			vea.Report(errors.AssertionFailedf(
+				"referencing type %d was dropped without dependency unlinking", id))
+		}
+	}
 }
 
 func (desc *immutable) validateMultiRegion(
@@ -798,6 +979,26 @@ func (desc *immutable) MakeTypesT(
 			return nil, err
 		}
 		return desc.Alias, nil
+	case descpb.TypeDescriptor_DOMAIN:
+		typ := types.MakeDomain(desc.DomainBaseType, TypeIDToOID(desc.GetID()))
+		if err := desc.HydrateTypeInfoWithName(ctx, typ, name, res); err != nil {
+			return nil, err
+		}
+		return typ, nil
+	case descpb.TypeDescriptor_COMPOSITE:
+		contents := make([]*types.T, len(desc.CompositeElements))
+		labels := make([]string, len(desc.CompositeElements))
+		for i, e := range desc.CompositeElements {
+			contents[i] = e.Type
+			labels[i] = e.Label
+		}
+		typ := types.MakeComposite(
+			TypeIDToOID(desc.GetID()), TypeIDToOID(desc.ArrayTypeID), contents, labels,
+		)
+		if err := desc.HydrateTypeInfoWithName(ctx, typ, name, res); err != nil {
+			return nil, err
+		}
+		return typ, nil
 	default:
 		return nil, errors.AssertionFailedf("unknown type kind %s", t.String())
 	}
@@ -813,7 +1014,10 @@ func EnsureTypeIsHydrated(
 				return err
 			}
 		}
-		return nil
+		// Composite types are hydrated like other user defined types below.
+		if !t.IsComposite() {
+			return nil
+		}
 	}
 	if !t.UserDefined() || t.IsHydrated() {
 		return nil
@@ -890,6 +1094,39 @@ func (desc *immutable) HydrateTypeInfoWithName(
 			}
 		}
 		return nil
+	case descpb.TypeDescriptor_DOMAIN:
+		if !typ.IsDomain() {
+			return errors.New("cannot hydrate a non-domain type with a domain type descriptor")
+		}
+		domainData := &types.DomainMetadata{}
+		if desc.DomainDefaultExpr != nil {
+			domainData.DefaultExpr = *desc.DomainDefaultExpr
+		}
+		for _, c := range desc.DomainConstraints {
+			// Constraints that are being validated are already enforced on new
+			// values, like CHECK constraints of tables.
+			if c.Validity == descpb.ConstraintValidity_Dropping {
+				continue
+			}
+			domainData.Constraints = append(domainData.Constraints, types.DomainConstraint{
+				Name:    c.Name,
+				Expr:    c.Expr,
+				NotNull: c.NotNull,
+			})
+		}
+		typ.TypeMeta.DomainData = domainData
+		return nil
+	case descpb.TypeDescriptor_COMPOSITE:
+		if !typ.IsComposite() {
+			return errors.New("cannot hydrate a non-composite type with a composite type descriptor")
+		}
+		// Hydrate the user defined types of the fields.
+		for _, elemType := range typ.TupleContents() {
+			if err := EnsureTypeIsHydrated(ctx, elemType, res); err != nil {
+				return err
+			}
+		}
+		return nil
 	default:
 		return errors.AssertionFailedf("unknown type descriptor kind %s", desc.Kind)
 	}
@@ -958,6 +1195,35 @@ func (desc *immutable) IsCompatibleWith(other catalog.TypeDescriptor) error {
 			}
 		}
 		return nil
+	case descpb.TypeDescriptor_DOMAIN:
+		if other.GetKind() != desc.Kind {
+			return errors.Newf("%q of type %q is not compatible with type %q",
+				other.GetName(), other.GetKind(), desc.Kind)
+		}
+		// The values of both domains must be encoded the same way.
+		if otherBase := other.TypeDesc().DomainBaseType; !desc.DomainBaseType.Identical(otherBase) {
+			return errors.Newf("%q has differing base type %s",
+				other.GetName(), otherBase.SQLString())
+		}
+		return nil
+	case descpb.TypeDescriptor_COMPOSITE:
+		if other.GetKind() != desc.Kind {
+			return errors.Newf("%q of type %q is not compatible with type %q",
+				other.GetName(), other.GetKind(), desc.Kind)
+		}
+		// The values of both composite types must be encoded the same way.
+		otherElements := other.TypeDesc().CompositeElements
+		if len(otherElements) != len(desc.CompositeElements) {
+			return errors.Newf("%q has %d fields, expected %d",
+				other.GetName(), len(otherElements), len(desc.CompositeElements))
+		}
+		for i, e := range desc.CompositeElements {
+			if e.Label != otherElements[i].Label || !e.Type.Identical(otherElements[i].Type) {
+				return errors.Newf("%q has differing field %q %s",
+					other.GetName(), otherElements[i].Label, otherElements[i].Type.SQLString())
+			}
+		}
+		return nil
 	default:
 		return errors.Newf("compatibility comparison unsupported for type kind %s", desc.Kind.String())
 	}
@@ -975,6 +1241,15 @@ func (desc *immutable) HasPendingSchemaChanges() bool {
 			}
 		}
 		return false
+	case descpb.TypeDescriptor_DOMAIN:
+		// If there are any constraints being validated, then a type schema change
+		// is needed to validate the existing values of the domain.
+		for i := range desc.DomainConstraints {
+			if desc.DomainConstraints[i].Validity == descpb.ConstraintValidity_Validating {
+				return true
+			}
+		}
+		return false
 	default:
 		return false
 	}
@@ -1012,10 +1287,20 @@ func (desc *immutable) GetIDClosure() (map[descpb.ID]struct{}, error) {
 		for id := range children {
 			ret[id] = struct{}{}
 		}
-	} else {
-		// Otherwise, take the array type ID.
+	} else if desc.Kind != descpb.TypeDescriptor_DOMAIN {
+		// Otherwise, take the array type ID. Domains do not have array types.
 		ret[desc.ArrayTypeID] = struct{}{}
 	}
+	// Composite types also depend on the types of their fields.
+	for _, e := range desc.CompositeElements {
+		children, err := GetTypeDescriptorClosure(e.Type)
+		if err != nil {
+			return nil, err
+		}
+		for id := range children {
+			ret[id] = struct{}{}
+		}
+	}
 	return ret, nil
 }
 
@@ -1033,6 +1318,11 @@ func GetTypeDescriptorClosure(typ *types.T) (map[descpb.ID]struct{}, error) {
 	ret := map[descpb.ID]struct{}{
 		id: {},
 	}
+	if typ.IsDomain() {
+		// Domains do not have array types, and their base types are not user
+		// defined.
+		return ret, nil
+	}
 	switch typ.Family() {
 	case types.ArrayFamily:
 		// If we have an array type, then collect all types in the contents.
@@ -446,15 +446,14 @@ func (p *planner) RevalidateUniqueConstraint(
 		}
 	}
 
-	// Check UNIQUE WITHOUT INDEX constraints.
-	for _, uc := range tableDesc.GetUniqueWithoutIndexConstraints() {
-		if uc.Name == constraintName {
-			return validateUniqueConstraint(
+	// Check UNIQUE WITHOUT INDEX and exclusion constraints.
+	ucs := tableDesc.GetUniqueWithoutIndexConstraints()
+	for i := range ucs {
+		if ucs[i].Name == constraintName {
+			return validateUniqueWithoutIndexConstraint(
 				ctx,
 				tableDesc,
-				uc.Name,
-				uc.ColumnIDs,
-				uc.Predicate,
+				&ucs[i],
 				p.ExecCfg().InternalExecutor,
 				p.Txn(),
 				true, /* preExisting */
@@ -511,15 +510,14 @@ func RevalidateUniqueConstraintsInTable(
 		}
 	}
 
-	// Check UNIQUE WITHOUT INDEX constraints.
-	for _, uc := range tableDesc.GetUniqueWithoutIndexConstraints() {
-		if uc.Validity == descpb.ConstraintValidity_Validated {
-			if err := validateUniqueConstraint(
+	// Check UNIQUE WITHOUT INDEX and exclusion constraints.
+	ucs := tableDesc.GetUniqueWithoutIndexConstraints()
+	for i := range ucs {
+		if ucs[i].Validity == descpb.ConstraintValidity_Validated {
+			if err := validateUniqueWithoutIndexConstraint(
 				ctx,
 				tableDesc,
-				uc.Name,
-				uc.ColumnIDs,
-				uc.Predicate,
+				&ucs[i],
 				ie,
 				txn,
 				true, /* preExisting */
@@ -534,6 +532,162 @@ func RevalidateUniqueConstraintsInTable(
 	return nil
 }
 
+// validateUniqueWithoutIndexConstraint verifies that all the rows in the
+// srcTable satisfy the given UNIQUE WITHOUT INDEX or exclusion constraint.
+//
+// It operates entirely on the current goroutine and is thus able to
+// reuse an existing kv.Txn safely.
+func validateUniqueWithoutIndexConstraint(
+	ctx context.Context,
+	srcTable catalog.TableDescriptor,
+	uc *descpb.UniqueWithoutIndexConstraint,
+	ie sqlutil.InternalExecutor,
+	txn *kv.Txn,
+	preExisting bool,
+) error {
+	if uc.IsExclusion() {
+		return validateExclusionConstraint(ctx, srcTable, uc, ie, txn, preExisting)
+	}
+	return validateUniqueConstraint(
+		ctx, srcTable, uc.Name, uc.ColumnIDs, uc.Predicate, ie, txn, preExisting,
+	)
+}
+
+// conflictingRowQuery generates and returns a query for a pair of distinct
+// rows which conflict according to the given exclusion constraint. Rows with
+// null values in the constraint columns never conflict, since the comparisons
+// evaluate to null.
+//
+// For example, an exclusion constraint EXCLUDE (a WITH =, b WITH &&) on the
+// table "tbl" with primary key k would require the following query:
+//
+// SELECT l.a, l.b, r.a, r.b
+// FROM (SELECT a, b, k FROM tbl) AS l, (SELECT a, b, k FROM tbl) AS r
+// WHERE l.a = r.a AND l.b && r.b AND (l.k) != (r.k)
+// LIMIT 1
+//
+// If the constraint is partial, its predicate filters both sides of the join.
+func conflictingRowQuery(
+	srcTbl catalog.TableDescriptor, uc *descpb.UniqueWithoutIndexConstraint,
+) (sql string, colNames []string, _ error) {
+	colNames, err := srcTbl.NamesForColumnIDs(uc.ColumnIDs)
+	if err != nil {
+		return "", nil, err
+	}
+	pkColNames, err := srcTbl.NamesForColumnIDs(srcTbl.GetPrimaryIndex().IndexDesc().KeyColumnIDs)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Project the constraint and primary key columns on both sides of the join,
+	// without duplicates.
+	var projected []string
+	seen := make(map[string]struct{})
+	for _, n := range append(append([]string(nil), colNames...), pkColNames...) {
+		if _, ok := seen[n]; !ok {
+			seen[n] = struct{}{}
+			projected = append(projected, tree.NameString(n))
+		}
+	}
+	srcWhere := ""
+	if uc.Predicate != "" {
+		srcWhere = fmt.Sprintf(" WHERE %s", uc.Predicate)
+	}
+	src := fmt.Sprintf(
+		"(SELECT %s FROM [%d AS tbl]%s)", strings.Join(projected, ", "), srcTbl.GetID(), srcWhere,
+	)
+
+	selectCols := make([]string, 0, 2*len(colNames))
+	filters := make([]string, 0, len(colNames)+1)
+	for _, side := range []string{"l", "r"} {
+		for _, n := range colNames {
+			selectCols = append(selectCols, fmt.Sprintf("%s.%s", side, tree.NameString(n)))
+		}
+	}
+	for i, n := range colNames {
+		col := tree.NameString(n)
+		filters = append(filters, fmt.Sprintf("l.%[1]s %[2]s r.%[1]s", col, uc.ExclusionOperators[i]))
+	}
+	leftPK := make([]string, len(pkColNames))
+	rightPK := make([]string, len(pkColNames))
+	for i, n := range pkColNames {
+		leftPK[i] = fmt.Sprintf("l.%s", tree.NameString(n))
+		rightPK[i] = fmt.Sprintf("r.%s", tree.NameString(n))
+	}
+	filters = append(filters, fmt.Sprintf(
+		"(%s) != (%s)", strings.Join(leftPK, ", "), strings.Join(rightPK, ", "),
+	))
+
+	return fmt.Sprintf(
+		`SELECT %[1]s FROM %[2]s AS l, %[2]s AS r WHERE %[3]s LIMIT 1`,
+		strings.Join(selectCols, ", "), // 1
+		src,                            // 2
+		strings.Join(filters, " AND "), // 3
+	), colNames, nil
+}
+
+// validateExclusionConstraint verifies that no two rows in the srcTable
+// conflict according to the given exclusion constraint.
+//
+// It operates entirely on the current goroutine and is thus able to
+// reuse an existing kv.Txn safely.
+//
+// preExisting indicates whether this constraint already exists, and therefore
+// informs the error message that gets produced.
+func validateExclusionConstraint(
+	ctx context.Context,
+	srcTable catalog.TableDescriptor,
+	uc *descpb.UniqueWithoutIndexConstraint,
+	ie sqlutil.InternalExecutor,
+	txn *kv.Txn,
+	preExisting bool,
+) error {
+	query, colNames, err := conflictingRowQuery(srcTable, uc)
+	if err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "validating exclusion constraint %q (%q [%v]) with query %q",
+		uc.Name,
+		srcTable.GetName(),
+		colNames,
+		query,
+	)
+
+	values, err := ie.QueryRowEx(ctx, "validate exclusion constraint", txn,
+		sessiondata.NodeUserSessionDataOverride, query)
+	if err != nil {
+		return err
+	}
+	if values.Len() > 0 {
+		valuesStr := make([]string, len(values))
+		for i := range values {
+			valuesStr[i] = values[i].String()
+		}
+		n := len(colNames)
+		// Note: this error message mirrors the message produced by Postgres
+		// when it fails to add an exclusion constraint due to conflicting rows.
+		errMsg := "could not create exclusion constraint"
+		if preExisting {
+			errMsg = "failed to validate exclusion constraint"
+		}
+		cols := strings.Join(colNames, ", ")
+		return errors.WithDetail(
+			pgerror.WithConstraintName(
+				pgerror.Newf(
+					pgcode.ExclusionViolation, "%s %q", errMsg, uc.Name,
+				),
+				uc.Name,
+			),
+			fmt.Sprintf(
+				"Key (%s)=(%s) conflicts with key (%s)=(%s).",
+				cols, strings.Join(valuesStr[:n], ", "), cols, strings.Join(valuesStr[n:], ", "),
+			),
+		)
+	}
+	return nil
+}
+
 // validateUniqueConstraint verifies that all the rows in the srcTable
 // have unique values for the given columns.
 //
@@ -790,5 +944,16 @@ func checkMutationInput(
 		}
 		colIdx++
 	}
+
+	// The row-level security check, if any, follows all the check constraints
+	// planned by the optimizer. See optbuilder.addRowLevelSecurityCheckCol.
+	if tabDesc.GetRowLevelSecurity() && checkOrds.Contains(rowLevelSecurityCheckOrdinal(tabDesc)) {
+		if res, err := tree.GetBool(checkVals[checkOrds.Len()-1]); err != nil {
+			return err
+		} else if !res {
+			return pgerror.Newf(pgcode.InsufficientPrivilege,
+				"new row violates row-level security policy for table %q", tabDesc.GetName())
+		}
+	}
 	return nil
 }
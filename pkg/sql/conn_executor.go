@@ -922,6 +922,17 @@ func (s *Server) newConnExecutor(
 	ex.extraTxnState.txnRewindPos = -1
 	ex.extraTxnState.schemaChangeJobRecords = make(map[descpb.ID]*jobs.Record)
 	ex.queryCancelKey = pgwirecancel.MakeBackendKeyData(ex.rng, ex.server.cfg.NodeID.SQLInstanceID())
+	ex.notifications = notificationState{
+		watcher: s.cfg.NotificationWatcher,
+		// The client sees the upper half of the query cancel key as the
+		// backend's process ID.
+		pid: int32(uint64(ex.queryCancelKey) >> 32),
+		onNotify: func() {
+			// The notifications are delivered by the session's goroutine. An
+			// error means that the session is closing.
+			_ = ex.stmtBuf.Push(ctx, DeliverNotifications{})
+		},
+	}
 	ex.mu.ActiveQueries = make(map[ClusterWideID]*queryMeta)
 	ex.machine = fsm.MakeMachine(TxnStateTransitions, stateNoTxn{}, &ex.state)
 
@@ -1124,9 +1135,11 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 			ctx, &ex.extraTxnState.prepStmtsNamespaceMemAcc,
 		)
 		ex.extraTxnState.prepStmtsNamespaceMemAcc.Close(ctx)
-		ex.extraTxnState.sqlCursors.closeAll()
+		ex.extraTxnState.sqlCursors.closeAll(ctx)
 	}
 
+	ex.notifications.close()
+
 	if ex.sessionTracing.Enabled() {
 		if err := ex.sessionTracing.StopTracing(); err != nil {
 			log.Warningf(ctx, "error stopping tracing: %s", err)
@@ -1306,10 +1319,17 @@ type connExecutor struct {
 		// connExecutor's closure.
 		prepStmtsNamespaceMemAcc mon.BoundAccount
 
+		// deferredConstraints tracks the modes set with SET CONSTRAINTS and the
+		// deferred constraints that need to be validated before the
+		// transaction commits.
+		deferredConstraints deferredConstraints
+
 		// sqlCursors contains the list of SQL CURSORs the session currently has
 		// access to.
 		// Cursors are bound to an explicit transaction and they're all destroyed
-		// once the transaction finishes.
+		// once the transaction finishes, except for WITH HOLD cursors, which are
+		// materialized when their transaction commits and remain open until they
+		// are closed.
 		sqlCursors cursorMap
 
 		// shouldExecuteOnTxnFinish indicates that ex.onTxnFinish will be called
@@ -1477,6 +1497,10 @@ type connExecutor struct {
 	// going to find a suitable time to close the connection.
 	draining bool
 
+	// notifications tracks the channels the session listens on with LISTEN,
+	// and the notifications received on them.
+	notifications notificationState
+
 	// executorType is set to whether this executor is an ordinary executor which
 	// responds to user queries or an internal one.
 	executorType executorType
@@ -1636,6 +1660,7 @@ func (ns *prepStmtNamespace) resetTo(
 // (e.g. onTxnFinish() and onTxnRestart()).
 func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent) error {
 	ex.extraTxnState.jobs = nil
+	ex.extraTxnState.deferredConstraints.reset()
 	ex.extraTxnState.firstStmtExecuted = false
 	ex.extraTxnState.hasAdminRoleCache = HasAdminRoleCache{}
 	ex.extraTxnState.schemaChangerState = SchemaChangerState{
@@ -1654,11 +1679,18 @@ func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent) err
 		delete(ex.extraTxnState.prepStmtsNamespace.portals, name)
 	}
 
-	// Close all cursors.
-	ex.extraTxnState.sqlCursors.closeAll()
+	// Close all cursors, except for the WITH HOLD cursors which outlive the
+	// transaction.
+	ex.extraTxnState.sqlCursors.finishTxn(ctx, ev.eventType == txnCommit)
 
 	ex.extraTxnState.createdSequences = make(map[descpb.ID]struct{})
 
+	if ev.eventType == txnCommit {
+		ex.notifications.commit()
+	} else {
+		ex.notifications.rollback()
+	}
+
 	switch ev.eventType {
 	case txnCommit, txnRollback:
 		for name, p := range ex.extraTxnState.prepStmtsNamespaceAtTxnRewindPos.portals {
@@ -2042,6 +2074,10 @@ func (ex *connExecutor) execCmd() error {
 	case Flush:
 		// Closing the res will flush the connection's buffer.
 		res = ex.clientComm.CreateFlushResult(pos)
+	case DeliverNotifications:
+		// The notifications are sent below if the connection is idle. Otherwise,
+		// they are sent with the next Sync after the transaction finished.
+		res = ex.clientComm.CreateFlushResult(pos)
 	default:
 		panic(errors.AssertionFailedf("unsupported command type: %T", cmd))
 	}
@@ -2101,6 +2137,16 @@ func (ex *connExecutor) execCmd() error {
 				res.SetError(pe.errorCause())
 			}
 		}
+		// Asynchronous notifications are only sent outside of transactions,
+		// ahead of the ReadyForQuery message for a Sync.
+		if ns, ok := res.(NotificationSender); ok && ex.idleConn() {
+			switch cmd.(type) {
+			case Sync, DeliverNotifications:
+				for _, n := range ex.notifications.take() {
+					ns.BufferNotification(n)
+				}
+			}
+		}
 		res.Close(ctx, stateToTxnStatusIndicator(ex.machine.CurState()))
 	} else {
 		res.Discard()
@@ -2256,6 +2302,8 @@ func (ex *connExecutor) updateTxnRewindPosMaybe(
 				canAdvance = true
 			case Flush:
 				canAdvance = true
+			case DeliverNotifications:
+				canAdvance = true
 			default:
 				panic(errors.AssertionFailedf("unsupported cmd: %T", cmd))
 			}
@@ -2681,10 +2729,15 @@ func (ex *connExecutor) initEvalCtx(ctx context.Context, evalCtx *extendedEvalCo
 		TxnModesSetter:         ex,
 		Jobs:                   &ex.extraTxnState.jobs,
 		SchemaChangeJobRecords: ex.extraTxnState.schemaChangeJobRecords,
+		DeferredConstraints:    &ex.extraTxnState.deferredConstraints,
+		SessionMon:             ex.sessionMon,
 		statsProvider:          ex.server.sqlStats,
 		indexUsageStats:        ex.indexUsageStats,
 		statementPreparer:      ex,
 	}
+	if ex.executorType != executorTypeInternal {
+		evalCtx.Notifications = &ex.notifications
+	}
 	evalCtx.copyFromExecCfg(ex.server.cfg)
 }
 
@@ -2792,6 +2845,7 @@ func (ex *connExecutor) resetPlanner(
 	p.semaCtx.SearchPath = ex.sessionData().SearchPath
 	p.semaCtx.Annotations = nil
 	p.semaCtx.TypeResolver = p
+	p.semaCtx.FunctionResolver = p
 	p.semaCtx.TableNameResolver = p
 	p.semaCtx.DateStyle = ex.sessionData().GetDateStyle()
 	p.semaCtx.IntervalStyle = ex.sessionData().GetIntervalStyle()
@@ -3359,7 +3413,7 @@ func (sc *StatementCounters) incrementCount(ex *connExecutor, stmt tree.Statemen
 		} else {
 			sc.RollbackToSavepointCount.Inc()
 		}
-	case *tree.CopyFrom:
+	case *tree.CopyFrom, *tree.CopyTo:
 		sc.CopyCount.Inc()
 	default:
 		if tree.CanModifySchema(stmt) {
@@ -937,6 +937,21 @@ func (ex *connExecutor) commitSQLTransactionInternal(ctx context.Context) error
 	ctx, sp := tracing.EnsureChildSpan(ctx, ex.server.cfg.AmbientCtx.Tracer, "commit sql txn")
 	defer sp.Finish()
 
+	if err := ex.extraTxnState.deferredConstraints.validatePending(
+		ctx,
+		ex.server.cfg.InternalExecutorFactory,
+		ex.sessionData(),
+		ex.state.mu.txn,
+		&ex.extraTxnState.descCollection,
+		false, /* onlyImmediate */
+	); err != nil {
+		return err
+	}
+
+	if err := ex.extraTxnState.sqlCursors.materializeHeld(ctx); err != nil {
+		return err
+	}
+
 	if err := ex.createJobs(ctx); err != nil {
 		return err
 	}
@@ -1376,6 +1391,10 @@ func (ex *connExecutor) makeExecPlan(ctx context.Context, planner *planner) erro
 		ex.extraTxnState.numDDL++
 	}
 
+	// Generate index recommendations so they can be saved in the statement
+	// statistics along with the sampled plan.
+	planner.setWorkloadIndexRecommendations(ctx)
+
 	return nil
 }
 
@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -319,6 +320,20 @@ func (Flush) String() string {
 
 var _ Command = Flush{}
 
+// DeliverNotifications is a Command pushed by the session's notification
+// listener when notifications arrive on a channel the session listens on. It
+// asks for them to be sent to the client, if the connection is idle.
+type DeliverNotifications struct{}
+
+// command implements the Command interface.
+func (DeliverNotifications) command() string { return "deliver notifications" }
+
+func (DeliverNotifications) String() string {
+	return "DeliverNotifications"
+}
+
+var _ Command = DeliverNotifications{}
+
 // CopyIn is the command for execution of the Copy-in pgwire subprotocol.
 type CopyIn struct {
 	Stmt *tree.CopyFrom
@@ -797,12 +812,22 @@ type DeleteResult interface {
 // flushed.
 type SyncResult interface {
 	ResultBase
+	NotificationSender
 }
 
 // FlushResult represents the result of a Flush command. When this result is
 // closed, all previously accumulated results are flushed to the client.
 type FlushResult interface {
 	ResultBase
+	NotificationSender
+}
+
+// NotificationSender is implemented by the results which can carry
+// asynchronous notifications received with LISTEN.
+type NotificationSender interface {
+	// BufferNotification buffers a notification to be sent to the client
+	// before the result's completion message.
+	BufferNotification(pgnotify.Notification)
 }
 
 // DrainResult represents the result of a Drain command. Closing this result
@@ -920,6 +945,11 @@ func (r *streamingCommandResult) BufferNotice(notice pgnotice.Notice) {
 	panic("unimplemented")
 }
 
+// BufferNotification is part of the NotificationSender interface.
+func (r *streamingCommandResult) BufferNotification(pgnotify.Notification) {
+	panic("unimplemented")
+}
+
 // ResetStmtType is part of the RestrictedCommandResult interface.
 func (r *streamingCommandResult) ResetStmtType(stmt tree.Statement) {
 	panic("unimplemented")
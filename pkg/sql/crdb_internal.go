@@ -2385,6 +2385,8 @@ CREATE TABLE crdb_internal.builtin_functions (
 }
 
 func writeCreateTypeDescRow(
+	ctx context.Context,
+	p *planner,
 	db catalog.DatabaseDescriptor,
 	sc string,
 	typeDesc catalog.TypeDescriptor,
@@ -2419,6 +2421,54 @@ func writeCreateTypeDescRow(
 			tree.NewDString(tree.AsString(node)),      // create_statement
 			enumLabelsDatum,
 		)
+	case descpb.TypeDescriptor_DOMAIN:
+		name, err := tree.NewUnresolvedObjectName(2, [3]string{typeDesc.GetName(), sc}, 0)
+		if err != nil {
+			return false, err
+		}
+		node, err := makeCreateDomainNode(name, typeDesc.TypeDesc())
+		if err != nil {
+			return false, err
+		}
+		return true, addRow(
+			tree.NewDInt(tree.DInt(db.GetID())),       // database_id
+			tree.NewDString(db.GetName()),             // database_name
+			tree.NewDString(sc),                       // schema_name
+			tree.NewDInt(tree.DInt(typeDesc.GetID())), // descriptor_id
+			tree.NewDString(typeDesc.GetName()),       // descriptor_name
+			tree.NewDString(tree.AsString(node)),      // create_statement
+			tree.DNull,
+		)
+	case descpb.TypeDescriptor_COMPOSITE:
+		name, err := tree.NewUnresolvedObjectName(2, [3]string{typeDesc.GetName(), sc}, 0)
+		if err != nil {
+			return false, err
+		}
+		elements := typeDesc.TypeDesc().CompositeElements
+		node := &tree.CreateType{
+			Variety:           tree.Composite,
+			TypeName:          name,
+			CompositeTypeList: make([]tree.CompositeTypeElem, len(elements)),
+		}
+		for i, e := range elements {
+			typ := e.Type
+			// User defined field types must be hydrated to be formatted by name.
+			if typ.UserDefined() {
+				if typ, err = p.ResolveTypeByOID(ctx, typ.Oid()); err != nil {
+					return false, err
+				}
+			}
+			node.CompositeTypeList[i] = tree.CompositeTypeElem{Label: tree.Name(e.Label), Type: typ}
+		}
+		return true, addRow(
+			tree.NewDInt(tree.DInt(db.GetID())),       // database_id
+			tree.NewDString(db.GetName()),             // database_name
+			tree.NewDString(sc),                       // schema_name
+			tree.NewDInt(tree.DInt(typeDesc.GetID())), // descriptor_id
+			tree.NewDString(typeDesc.GetName()),       // descriptor_name
+			tree.NewDString(tree.AsString(node)),      // create_statement
+			tree.DNull,
+		)
 	case descpb.TypeDescriptor_MULTIREGION_ENUM:
 		// Multi-region enums are created implicitly, so we don't have create
 		// statements for them.
@@ -2448,7 +2498,7 @@ CREATE TABLE crdb_internal.create_type_statements (
 `,
 	populate: func(ctx context.Context, p *planner, db catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
 		return forEachTypeDesc(ctx, p, db, func(db catalog.DatabaseDescriptor, sc string, typeDesc catalog.TypeDescriptor) error {
-			_, err := writeCreateTypeDescRow(db, sc, typeDesc, addRow)
+			_, err := writeCreateTypeDescRow(ctx, p, db, sc, typeDesc, addRow)
 			return err
 		})
 	},
@@ -2466,7 +2516,7 @@ CREATE TABLE crdb_internal.create_type_statements (
 				if err != nil || typDesc == nil {
 					return false, err
 				}
-				return writeCreateTypeDescRow(db, scName, typDesc, addRow)
+				return writeCreateTypeDescRow(ctx, p, db, scName, typDesc, addRow)
 			},
 		},
 	},
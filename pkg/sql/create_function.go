@@ -0,0 +1,347 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descidgen"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/optbuilder"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+type createFunctionNode struct {
+	n      *tree.CreateFunction
+	scDesc *schemadesc.Mutable
+	// desc is the descriptor of the new function. Its ID is allocated when
+	// the statement is executed.
+	desc descpb.FunctionDescriptor
+	// existing is the function replaced by CREATE OR REPLACE FUNCTION, if any.
+	existing *funcdesc.Mutable
+	// deps tracks which relations, types and functions the body of the
+	// function depends on. It is computed when the statement is executed.
+	deps bodyDependencies
+}
+
+// CreateFunction creates a user-defined function.
+// Privileges: CREATE on the schema.
+func (p *planner) CreateFunction(ctx context.Context, n *tree.CreateFunction) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE FUNCTION",
+	); err != nil {
+		return nil, err
+	}
+
+	db, sc, _, err := p.ResolveTargetObject(ctx, n.Name)
+	if err != nil {
+		return nil, err
+	}
+	if db.GetID() == keys.SystemDatabaseID {
+		return nil, errors.New("cannot create a function in the system database")
+	}
+	if sc.SchemaKind() == catalog.SchemaTemporary {
+		return nil, unimplemented.NewWithIssueDetail(17511, "udf temp",
+			"cannot create user-defined functions in temporary schemas")
+	}
+	mutSc, err := p.getNonTemporarySchemaForCreate(ctx, db, sc.GetName())
+	if err != nil {
+		return nil, err
+	}
+	scDesc, ok := mutSc.(*schemadesc.Mutable)
+	if !ok {
+		return nil, errFunctionInSyntheticSchema(sc.GetName())
+	}
+	if err := p.canCreateOnSchema(
+		ctx, scDesc.GetID(), db.GetID(), p.User(), checkPublicSchema,
+	); err != nil {
+		return nil, err
+	}
+
+	name := n.Name.Object()
+	desc := descpb.FunctionDescriptor{
+		Name:           name,
+		ParentID:       db.GetID(),
+		ParentSchemaID: scDesc.GetID(),
+		Params:         make([]descpb.FunctionDescriptor_Parameter, len(n.Params)),
+	}
+	seenParams := make(map[tree.Name]struct{}, len(n.Params))
+	for i := range n.Params {
+		param := &n.Params[i]
+		if param.Name != "" {
+			if _, ok := seenParams[param.Name]; ok {
+				return nil, pgerror.Newf(pgcode.InvalidFunctionDefinition,
+					"parameter name %q used more than once", param.Name)
+			}
+			seenParams[param.Name] = struct{}{}
+		}
+		typ, err := p.resolveFuncType(ctx, param.Type)
+		if err != nil {
+			return nil, err
+		}
+		desc.Params[i] = descpb.FunctionDescriptor_Parameter{Name: string(param.Name), Type: typ}
+	}
+	if desc.ReturnType, err = p.resolveFuncType(ctx, n.ReturnType); err != nil {
+		return nil, err
+	}
+	lang, body, err := setFunctionOptions(&desc, n.Options)
+	if err != nil {
+		return nil, err
+	}
+	switch lang {
+	case tree.FunctionLangSQL:
+	case "":
+		return nil, pgerror.New(pgcode.InvalidFunctionDefinition, "no language specified")
+	case "plpgsql":
+		return nil, unimplemented.NewWithIssueDetail(17511, "plpgsql",
+			"PL/pgSQL functions are not supported")
+	default:
+		return nil, pgerror.Newf(pgcode.UndefinedObject, "language %q does not exist", lang)
+	}
+	if body == "" {
+		return nil, pgerror.New(pgcode.InvalidFunctionDefinition, "no function body specified")
+	}
+	desc.FunctionBody = string(body)
+
+	paramTypes := make([]*types.T, len(desc.Params))
+	for i := range desc.Params {
+		paramTypes[i] = desc.Params[i].Type
+	}
+	if err := checkBuiltinFunctionConflict(name, paramTypes); err != nil {
+		return nil, err
+	}
+
+	// Look for an existing overload with the same parameter types.
+	var existing *funcdesc.Mutable
+	if fn, ok := scDesc.GetFunction(name); ok {
+		for _, ol := range fn.Overloads {
+			fnDesc, err := p.Descriptors().GetMutableFunctionByID(ctx, p.txn, ol.ID,
+				tree.ObjectLookupFlags{CommonLookupFlags: tree.CommonLookupFlags{Required: true}})
+			if err != nil {
+				return nil, err
+			}
+			if funcParamTypesIdentical(fnDesc.GetParamTypes(), paramTypes) {
+				existing = fnDesc
+				break
+			}
+		}
+	}
+	if existing != nil {
+		if !n.Replace {
+			return nil, pgerror.Newf(pgcode.DuplicateFunction,
+				"function %q already exists with same argument types", name)
+		}
+		if err := p.canModifyFunction(ctx, existing); err != nil {
+			return nil, err
+		}
+		if !existing.GetReturnType().Identical(desc.ReturnType) {
+			return nil, errors.WithHint(
+				pgerror.New(pgcode.InvalidFunctionDefinition,
+					"cannot change return type of existing function"),
+				"Use DROP FUNCTION first.",
+			)
+		}
+		for i, param := range existing.GetParams() {
+			if param.Name != desc.Params[i].Name {
+				return nil, errors.WithHint(
+					pgerror.Newf(pgcode.InvalidFunctionDefinition,
+						"cannot change name of input parameter %q", param.Name),
+					"Use DROP FUNCTION first.",
+				)
+			}
+		}
+	}
+
+	return &createFunctionNode{n: n, scDesc: scDesc, desc: desc, existing: existing}, nil
+}
+
+// checkBuiltinFunctionConflict returns an error if a user-defined function
+// with the given name and parameter types would conflict with a builtin
+// function. Aggregate, window and generator functions cannot be overloaded at
+// all.
+func checkBuiltinFunctionConflict(name string, paramTypes []*types.T) error {
+	def, ok := tree.FunDefs[name]
+	if !ok {
+		return nil
+	}
+	if def.Class != tree.NormalClass {
+		return pgerror.Newf(pgcode.DuplicateFunction,
+			"cannot create function %q: a builtin function with the same name exists", name)
+	}
+	for _, o := range def.Definition {
+		if argTypes, ok := o.(*tree.Overload).Types.(tree.ArgTypes); ok &&
+			funcParamTypesIdentical(argTypes.Types(), paramTypes) {
+			return pgerror.Newf(pgcode.DuplicateFunction,
+				"function %q already exists as a builtin function with same argument types", name)
+		}
+	}
+	return nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because CREATE FUNCTION validates the body of the function and
+// writes both the function and its schema.
+func (n *createFunctionNode) ReadingOwnWrites() {}
+
+func (n *createFunctionNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("function"))
+
+	var err error
+	if n.deps, err = params.p.validateFunctionBody(params.ctx, &n.desc); err != nil {
+		return err
+	}
+	jobDesc := tree.AsStringWithFQNames(n.n, params.Ann())
+
+	var fnDesc *funcdesc.Mutable
+	if n.existing != nil {
+		// The replaced function keeps its ID, owner and privileges.
+		fnDesc = n.existing
+		if err := params.p.checkFunctionDependencyCycle(params.ctx, fnDesc, n.deps.functions); err != nil {
+			return err
+		}
+		if err := params.p.removeFunctionBackReferences(params.ctx, fnDesc); err != nil {
+			return err
+		}
+		fnDesc.Params = n.desc.Params
+		fnDesc.Volatility = n.desc.Volatility
+		fnDesc.LeakProof = n.desc.LeakProof
+		fnDesc.NullInputBehavior = n.desc.NullInputBehavior
+		fnDesc.FunctionBody = n.desc.FunctionBody
+	} else {
+		id, err := descidgen.GenerateUniqueDescID(params.ctx, params.ExecCfg().DB, params.ExecCfg().Codec)
+		if err != nil {
+			return err
+		}
+		n.desc.ID = id
+		n.desc.Privileges = catpb.NewBaseFunctionPrivilegeDescriptor(params.SessionData().User())
+		fnDesc = funcdesc.NewBuilder(&n.desc).BuildCreatedMutableFunction()
+
+		n.scDesc.AddFunction(fnDesc.GetName(), fnDesc.GetID())
+		if err := params.p.writeSchemaDescChange(params.ctx, n.scDesc, jobDesc); err != nil {
+			return err
+		}
+	}
+	fnDesc.DependsOn = n.deps.relationIDs(descpb.InvalidID)
+	fnDesc.DependsOnTypes = n.deps.types.Ordered()
+	fnDesc.DependsOnFunctions = n.deps.functions.Ordered()
+	if n.existing != nil {
+		if err := params.p.writeFunctionDescChange(params.ctx, fnDesc, jobDesc); err != nil {
+			return err
+		}
+	} else if err := params.p.writeFunctionDesc(params.ctx, fnDesc); err != nil {
+		return err
+	}
+	return params.p.addFunctionBackReferences(params.ctx, fnDesc, n.deps)
+}
+
+func (n *createFunctionNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createFunctionNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createFunctionNode) Close(context.Context)        {}
+
+// validateFunctionBody checks that the body of a function is a single SELECT
+// statement, which only refers to existing objects and parameters of the
+// function, and whose result can be cast to the return type of the function.
+// It returns the objects which the body depends on.
+func (p *planner) validateFunctionBody(
+	ctx context.Context, desc *descpb.FunctionDescriptor,
+) (bodyDependencies, error) {
+	stmt, err := parser.ParseOne(desc.FunctionBody)
+	if err != nil {
+		return bodyDependencies{}, pgerror.Wrap(err, pgcode.InvalidFunctionDefinition, "invalid function body")
+	}
+	if _, ok := stmt.AST.(*tree.Select); !ok {
+		return bodyDependencies{}, pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"function body must be a SELECT statement, found %s", stmt.AST.StatementTag())
+	}
+	body, err := replaceUDFParams(stmt.AST, desc.Params)
+	if err != nil {
+		return bodyDependencies{}, err
+	}
+	execSQL, err := makeUDFExecSQL(body, desc.Params, desc.ReturnType)
+	if err != nil {
+		return bodyDependencies{}, err
+	}
+	execStmt, err := parser.ParseOne(execSQL)
+	if err != nil {
+		return bodyDependencies{}, errors.NewAssertionErrorWithWrappedErrf(err,
+			"failed to parse body of function %q", desc.Name)
+	}
+
+	// Build the body in the same form as it is executed, which type checks it
+	// without evaluating it.
+	paramTypes := make([]*types.T, len(desc.Params))
+	for i := range desc.Params {
+		paramTypes[i] = desc.Params[i].Type
+	}
+	deps, err := p.buildBodyDependencies(ctx, func(_ cat.Catalog, b *optbuilder.Builder) (
+		opt.ViewDeps, opt.ViewTypeDeps, opt.ViewFuncDeps, error,
+	) {
+		return b.BuildFunctionDependencies(execStmt.AST, paramTypes)
+	})
+	if err != nil {
+		return bodyDependencies{}, pgerror.Wrap(err, pgcode.InvalidFunctionDefinition, "invalid function body")
+	}
+	return deps, nil
+}
+
+// checkFunctionDependencyCycle returns an error if the given function would
+// call itself, directly or through other user-defined functions, if its body
+// depended on the given functions.
+func (p *planner) checkFunctionDependencyCycle(
+	ctx context.Context, fn catalog.FunctionDescriptor, deps catalog.DescriptorIDSet,
+) error {
+	var visited catalog.DescriptorIDSet
+	var visit func(id descpb.ID) error
+	visit = func(id descpb.ID) error {
+		if id == fn.GetID() {
+			return pgerror.Newf(pgcode.InvalidFunctionDefinition,
+				"cannot create function %q: recursive calls are not supported", fn.GetName())
+		}
+		if visited.Contains(id) {
+			return nil
+		}
+		visited.Add(id)
+		dep, err := p.Descriptors().GetImmutableFunctionByID(ctx, p.txn, id,
+			tree.ObjectLookupFlags{CommonLookupFlags: tree.CommonLookupFlags{Required: true}})
+		if err != nil {
+			return err
+		}
+		for _, depID := range dep.GetDependsOnFunctions() {
+			if err := visit(depID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, id := range deps.Ordered() {
+		if err := visit(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
 	"github.com/cockroachdb/errors"
 )
@@ -210,19 +211,13 @@ func makeIndexDescriptor(
 		}
 
 		indexDesc.Type = descpb.IndexDescriptor_INVERTED
-		column, err := tableDesc.FindColumnWithName(columns[len(columns)-1].Column)
+		invCol := columns[len(columns)-1]
+		column, err := tableDesc.FindColumnWithName(invCol.Column)
 		if err != nil {
 			return nil, err
 		}
-		switch column.GetType().Family() {
-		case types.GeometryFamily:
-			config, err := geoindex.GeometryIndexConfigForSRID(column.GetType().GeoSRIDOrZero())
-			if err != nil {
-				return nil, err
-			}
-			indexDesc.GeoConfig = *config
-		case types.GeographyFamily:
-			indexDesc.GeoConfig = *geoindex.DefaultGeographyIndexConfig()
+		if err := populateInvertedIndexDescriptor(column, &indexDesc, invCol); err != nil {
+			return nil, err
 		}
 	}
 
@@ -285,6 +280,9 @@ func makeIndexDescriptor(
 		if indexDesc.GeoConfig.IsGeography() {
 			telemetry.Inc(sqltelemetry.GeographyInvertedIndexCounter)
 		}
+		if indexDesc.InvertedColumnKind() == catpb.InvertedIndexColumnKind_TRIGRAM {
+			telemetry.Inc(sqltelemetry.TrigramInvertedIndexCounter)
+		}
 		if indexDesc.IsPartial() {
 			telemetry.Inc(sqltelemetry.PartialInvertedIndexCounter)
 		}
@@ -302,6 +300,77 @@ func makeIndexDescriptor(
 	return &indexDesc, nil
 }
 
+// populateInvertedIndexDescriptor adds information about the inverted column
+// of an inverted index to the index descriptor: the geospatial index config
+// of geospatial columns, and the kind of the inverted column, which is
+// determined by the operator class given for it in invCol.
+func populateInvertedIndexDescriptor(
+	column catalog.Column, indexDesc *descpb.IndexDescriptor, invCol tree.IndexElem,
+) error {
+	typ := column.GetType()
+	switch typ.Family() {
+	case types.StringFamily:
+		switch invCol.OpClass {
+		case "gin_trgm_ops":
+			indexDesc.InvertedColumnKinds = []catpb.InvertedIndexColumnKind{
+				catpb.InvertedIndexColumnKind_TRIGRAM,
+			}
+			return nil
+		case "gist_trgm_ops":
+			// Only GIN trigram indexes are supported, which do not behave like
+			// GiST ones, so don't silently build one in place of the other.
+			return errors.WithHint(
+				unimplemented.NewWithIssueDetail(41285, "gist_trgm_ops",
+					"GiST trigram indexes are not supported"),
+				"use the gin_trgm_ops operator class to create a trigram inverted index instead",
+			)
+		case "":
+			return errors.WithHint(
+				pgerror.Newf(
+					pgcode.UndefinedObject,
+					"data type %s has no default operator class for access method \"gin\"",
+					typ.Name(),
+				),
+				"You must specify an operator class for the index (did you mean gin_trgm_ops?)",
+			)
+		}
+		return newUndefinedOpClassError(invCol.OpClass)
+	case types.GeometryFamily:
+		config, err := geoindex.GeometryIndexConfigForSRID(typ.GeoSRIDOrZero())
+		if err != nil {
+			return err
+		}
+		indexDesc.GeoConfig = *config
+	case types.GeographyFamily:
+		indexDesc.GeoConfig = *geoindex.DefaultGeographyIndexConfig()
+	}
+	// Other types only support their default operator class, which can also be
+	// given explicitly.
+	if invCol.OpClass != "" && string(invCol.OpClass) != defaultInvertedOpClass(typ) {
+		return newUndefinedOpClassError(invCol.OpClass)
+	}
+	return nil
+}
+
+// defaultInvertedOpClass returns the name of the operator class that is used
+// by default for inverted indexes on the given type, or the empty string if
+// there is none.
+func defaultInvertedOpClass(typ *types.T) string {
+	switch typ.Family() {
+	case types.ArrayFamily:
+		return "array_ops"
+	case types.JsonFamily:
+		return "jsonb_ops"
+	case types.TSVectorFamily:
+		return "tsvector_ops"
+	}
+	return ""
+}
+
+func newUndefinedOpClassError(opClass tree.Name) error {
+	return pgerror.Newf(pgcode.UndefinedObject, "operator class %q does not exist", opClass)
+}
+
 // validateColumnsAreAccessible validates that the columns for an index are
 // accessible. This check must be performed before creating inaccessible columns
 // for expression indexes with replaceExpressionElemsWithVirtualCols.
@@ -448,7 +517,11 @@ func replaceExpressionElemsWithVirtualCols(
 						"see the documentation for more information about inverted indexes: "+docs.URL("inverted-indexes.html"),
 					)
 				}
-				if i == lastColumnIdx && !colinfo.ColumnTypeIsInvertedIndexable(typ) {
+				// Trigram indexable types are allowed as well, as long as the
+				// index element uses a trigram operator class, which is
+				// checked when the index descriptor is populated.
+				if i == lastColumnIdx && !colinfo.ColumnTypeIsInvertedIndexable(typ) &&
+					!colinfo.ColumnTypeIsTrigramIndexable(typ) {
 					return errors.WithHint(
 						pgerror.Newf(
 							pgcode.InvalidTableDefinition,
@@ -0,0 +1,226 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+type createPolicyNode struct {
+	n         *tree.CreatePolicy
+	tableDesc *tabledesc.Mutable
+	policy    descpb.PolicyDescriptor
+}
+
+// CreatePolicy creates a row-level security policy on a table.
+// Privileges: CREATE on table.
+func (p *planner) CreatePolicy(ctx context.Context, n *tree.CreatePolicy) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE POLICY",
+	); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case n.Command == tree.PolicyCommandInsert && n.Using != nil:
+		return nil, pgerror.New(pgcode.Syntax, "only WITH CHECK expression allowed for INSERT")
+	case (n.Command == tree.PolicyCommandSelect || n.Command == tree.PolicyCommandDelete) &&
+		n.WithCheck != nil:
+		return nil, pgerror.New(pgcode.Syntax, "WITH CHECK cannot be applied to SELECT or DELETE")
+	}
+
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &n.Table, true /* required */, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	for i := range tableDesc.Policies {
+		if tableDesc.Policies[i].Name == string(n.Name) {
+			return nil, pgerror.Newf(pgcode.DuplicateObject,
+				"policy %q for table %q already exists", n.Name, tableDesc.GetName())
+		}
+	}
+
+	roles, err := n.Roles.ToSQLUsernames(p.SessionData(), security.UsernameValidation)
+	if err != nil {
+		return nil, err
+	}
+	if len(roles) == 0 {
+		roles = append(roles, security.PublicRoleName())
+	}
+	if err := p.validateRoles(ctx, roles, true /* isPublicValid */); err != nil {
+		return nil, err
+	}
+
+	policy := descpb.PolicyDescriptor{
+		Name:    string(n.Name),
+		Type:    makePolicyDescriptorType(n.Type),
+		Command: makePolicyDescriptorCommand(n.Command),
+		Roles:   make([]string, len(roles)),
+	}
+	for i := range roles {
+		policy.Roles[i] = roles[i].Normalized()
+	}
+	if n.Using != nil {
+		policy.UsingExpr, _, _, err = schemaexpr.DequalifyAndValidateExpr(
+			ctx, tableDesc, n.Using, types.Bool, "POLICY USING",
+			&p.semaCtx, tree.VolatilityVolatile, &n.Table,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if n.WithCheck != nil {
+		policy.WithCheckExpr, _, _, err = schemaexpr.DequalifyAndValidateExpr(
+			ctx, tableDesc, n.WithCheck, types.Bool, "POLICY WITH CHECK",
+			&p.semaCtx, tree.VolatilityVolatile, &n.Table,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &createPolicyNode{n: n, tableDesc: tableDesc, policy: policy}, nil
+}
+
+func (n *createPolicyNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("policy"))
+
+	n.tableDesc.Policies = append(n.tableDesc.Policies, n.policy)
+	return params.p.writeSchemaChange(
+		params.ctx, n.tableDesc, descpb.InvalidMutationID, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *createPolicyNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createPolicyNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createPolicyNode) Close(context.Context)        {}
+
+func makePolicyDescriptorType(t tree.PolicyType) descpb.PolicyDescriptor_Type {
+	switch t {
+	case tree.PolicyTypePermissive:
+		return descpb.PolicyDescriptor_PERMISSIVE
+	case tree.PolicyTypeRestrictive:
+		return descpb.PolicyDescriptor_RESTRICTIVE
+	}
+	panic(errors.AssertionFailedf("unknown policy type %d", t))
+}
+
+func makePolicyDescriptorCommand(c tree.PolicyCommand) descpb.PolicyDescriptor_Command {
+	switch c {
+	case tree.PolicyCommandAll:
+		return descpb.PolicyDescriptor_ALL
+	case tree.PolicyCommandSelect:
+		return descpb.PolicyDescriptor_SELECT
+	case tree.PolicyCommandInsert:
+		return descpb.PolicyDescriptor_INSERT
+	case tree.PolicyCommandUpdate:
+		return descpb.PolicyDescriptor_UPDATE
+	case tree.PolicyCommandDelete:
+		return descpb.PolicyDescriptor_DELETE
+	}
+	panic(errors.AssertionFailedf("unknown policy command %d", c))
+}
+
+func makeTreePolicyType(t descpb.PolicyDescriptor_Type) tree.PolicyType {
+	switch t {
+	case descpb.PolicyDescriptor_PERMISSIVE:
+		return tree.PolicyTypePermissive
+	case descpb.PolicyDescriptor_RESTRICTIVE:
+		return tree.PolicyTypeRestrictive
+	}
+	panic(errors.AssertionFailedf("unknown policy type %d", t))
+}
+
+func makeTreePolicyCommand(c descpb.PolicyDescriptor_Command) tree.PolicyCommand {
+	switch c {
+	case descpb.PolicyDescriptor_ALL:
+		return tree.PolicyCommandAll
+	case descpb.PolicyDescriptor_SELECT:
+		return tree.PolicyCommandSelect
+	case descpb.PolicyDescriptor_INSERT:
+		return tree.PolicyCommandInsert
+	case descpb.PolicyDescriptor_UPDATE:
+		return tree.PolicyCommandUpdate
+	case descpb.PolicyDescriptor_DELETE:
+		return tree.PolicyCommandDelete
+	}
+	panic(errors.AssertionFailedf("unknown policy command %d", c))
+}
+
+// makeCreatePolicy converts a policy descriptor back into a CREATE POLICY
+// statement equivalent to the one which created it.
+func makeCreatePolicy(
+	policy *descpb.PolicyDescriptor, tn tree.TableName,
+) (*tree.CreatePolicy, error) {
+	n := &tree.CreatePolicy{
+		Name:    tree.Name(policy.Name),
+		Table:   tn,
+		Type:    makeTreePolicyType(policy.Type),
+		Command: makeTreePolicyCommand(policy.Command),
+		Roles:   make(tree.RoleSpecList, len(policy.Roles)),
+	}
+	for i, role := range policy.Roles {
+		n.Roles[i] = tree.MakeRoleSpecWithRoleName(role)
+	}
+	var err error
+	if policy.UsingExpr != "" {
+		if n.Using, err = parser.ParseExpr(policy.UsingExpr); err != nil {
+			return nil, err
+		}
+	}
+	if policy.WithCheckExpr != "" {
+		if n.WithCheck, err = parser.ParseExpr(policy.WithCheckExpr); err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+// makeCatPolicy converts a policy descriptor into the optimizer catalog
+// representation of the policy.
+func makeCatPolicy(policy *descpb.PolicyDescriptor) cat.Policy {
+	p := cat.Policy{
+		Name:          tree.Name(policy.Name),
+		Type:          makeTreePolicyType(policy.Type),
+		Command:       makeTreePolicyCommand(policy.Command),
+		Roles:         make([]security.SQLUsername, len(policy.Roles)),
+		UsingExpr:     policy.UsingExpr,
+		WithCheckExpr: policy.WithCheckExpr,
+	}
+	for i, role := range policy.Roles {
+		p.Roles[i] = security.MakeSQLUsernameFromPreNormalizedString(role)
+	}
+	return p
+}
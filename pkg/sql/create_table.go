@@ -21,7 +21,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/build"
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/docs"
-	"github.com/cockroachdb/cockroach/pkg/geo/geoindex"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/kv"
@@ -647,7 +646,9 @@ func addUniqueWithoutIndexColumnTableDef(
 		desc,
 		string(d.Unique.ConstraintName),
 		[]string{string(d.Name)},
-		"", /* predicate */
+		"",  /* predicate */
+		nil, /* exclusionOperators */
+		tree.ConstraintDeferrability{},
 		ts,
 		validationBehavior,
 	); err != nil {
@@ -704,16 +705,105 @@ func addUniqueWithoutIndexTableDef(
 		colNames[i] = string(d.Columns[i].Column)
 	}
 	if err := ResolveUniqueWithoutIndexConstraint(
-		ctx, desc, string(d.Name), colNames, predicate, ts, validationBehavior,
+		ctx, desc, string(d.Name), colNames, predicate, nil /* exclusionOperators */, d.Deferrability,
+		ts, validationBehavior,
 	); err != nil {
 		return err
 	}
 	return nil
 }
 
+// addExclusionConstraintTableDef runs various checks on the given
+// ExclusionConstraintTableDef before adding it to the given table descriptor.
+// Exclusion constraints are stored like UNIQUE WITHOUT INDEX constraints,
+// along with the operators used to compare the values of their columns, and
+// are enforced by the same checks.
+func addExclusionConstraintTableDef(
+	ctx context.Context,
+	d *tree.ExclusionConstraintTableDef,
+	desc *tabledesc.Mutable,
+	tn tree.TableName,
+	ts TableState,
+	validationBehavior tree.ValidationBehavior,
+	semaCtx *tree.SemaContext,
+) error {
+	// If there is a predicate, validate it.
+	var predicate string
+	if d.Predicate != nil {
+		var err error
+		predicate, err = schemaexpr.ValidateUniqueWithoutIndexPredicate(
+			ctx, tn, desc, d.Predicate, semaCtx,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	colNames := make([]string, len(d.Elems))
+	operators := make([]string, len(d.Elems))
+	for i := range d.Elems {
+		elem := &d.Elems[i]
+		col, err := desc.FindActiveOrNewColumnByName(elem.Column)
+		if err != nil {
+			return err
+		}
+		if col.IsVirtual() {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"exclusion constraints on virtual column %q are not supported", col.GetName())
+		}
+		typ := col.GetType()
+		if _, ok := tree.CmpOps[elem.Operator.Symbol].LookupImpl(typ, typ); !ok {
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"operator %s is not supported for column %q of type %s in exclusion constraints",
+				elem.Operator.Symbol, col.GetName(), typ.SQLString())
+		}
+		colNames[i] = col.GetName()
+		operators[i] = elem.Operator.Symbol.String()
+	}
+	return ResolveUniqueWithoutIndexConstraint(
+		ctx, desc, string(d.Name), colNames, predicate, operators, d.Deferrability,
+		ts, validationBehavior,
+	)
+}
+
+// makeExclusionConstraintTableDef returns the ExclusionConstraintTableDef
+// corresponding to the given exclusion constraint on td.
+func makeExclusionConstraintTableDef(
+	td catalog.TableDescriptor, c *descpb.UniqueWithoutIndexConstraint,
+) (*tree.ExclusionConstraintTableDef, error) {
+	colNames, err := td.NamesForColumnIDs(c.ColumnIDs)
+	if err != nil {
+		return nil, err
+	}
+	def := &tree.ExclusionConstraintTableDef{
+		Name:  tree.Name(c.Name),
+		Elems: make(tree.ExclusionElemList, len(colNames)),
+		Deferrability: tree.ConstraintDeferrability{
+			Deferrable:        c.Deferrable,
+			InitiallyDeferred: c.InitiallyDeferred,
+		},
+	}
+	for i := range colNames {
+		op := treecmp.MakeComparisonOperator(treecmp.EQ)
+		if c.ExclusionOperators[i] == treecmp.Overlaps.String() {
+			op = treecmp.MakeComparisonOperator(treecmp.Overlaps)
+		}
+		def.Elems[i] = tree.ExclusionElem{Column: tree.Name(colNames[i]), Operator: op}
+	}
+	if c.IsPartial() {
+		def.Predicate, err = parser.ParseExpr(c.Predicate)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return def, nil
+}
+
 // ResolveUniqueWithoutIndexConstraint looks up the columns mentioned in a
 // UNIQUE WITHOUT INDEX constraint and adds metadata representing that
-// constraint to the descriptor.
+// constraint to the descriptor. If exclusionOperators is not empty, the
+// constraint is an exclusion constraint which compares the columns with these
+// operators.
 //
 // The passed validationBehavior is used to determine whether or not preexisting
 // entries in the table need to be validated against the unique constraint being
@@ -724,6 +814,8 @@ func ResolveUniqueWithoutIndexConstraint(
 	constraintName string,
 	colNames []string,
 	predicate string,
+	exclusionOperators []string,
+	deferrability tree.ConstraintDeferrability,
 	ts TableState,
 	validationBehavior tree.ValidationBehavior,
 ) error {
@@ -736,8 +828,12 @@ func ResolveUniqueWithoutIndexConstraint(
 		}
 		// Ensure that the columns don't have duplicates.
 		if colSet.Contains(col.GetID()) {
+			constraintKind := "unique"
+			if len(exclusionOperators) > 0 {
+				constraintKind = "exclusion"
+			}
 			return pgerror.Newf(pgcode.DuplicateColumn,
-				"column %q appears twice in unique constraint", col.GetName())
+				"column %q appears twice in %s constraint", col.GetName(), constraintKind)
 		}
 		colSet.Add(col.GetID())
 		cols[i] = col
@@ -749,8 +845,13 @@ func ResolveUniqueWithoutIndexConstraint(
 		return err
 	}
 	if constraintName == "" {
+		// Like Postgres, name exclusion constraints <table>_<columns>_excl.
+		baseName := fmt.Sprintf("unique_%s", strings.Join(colNames, "_"))
+		if len(exclusionOperators) > 0 {
+			baseName = fmt.Sprintf("%s_%s_excl", tbl.Name, strings.Join(colNames, "_"))
+		}
 		constraintName = tabledesc.GenerateUniqueName(
-			fmt.Sprintf("unique_%s", strings.Join(colNames, "_")),
+			baseName,
 			func(p string) bool {
 				_, ok := constraintInfo[p]
 				return ok
@@ -777,12 +878,15 @@ func ResolveUniqueWithoutIndexConstraint(
 	}
 
 	uc := descpb.UniqueWithoutIndexConstraint{
-		Name:         constraintName,
-		TableID:      tbl.ID,
-		ColumnIDs:    columnIDs,
-		Predicate:    predicate,
-		Validity:     validity,
-		ConstraintID: tbl.NextConstraintID,
+		Name:               constraintName,
+		TableID:            tbl.ID,
+		ColumnIDs:          columnIDs,
+		Predicate:          predicate,
+		Validity:           validity,
+		ConstraintID:       tbl.NextConstraintID,
+		Deferrable:         deferrability.Deferrable,
+		InitiallyDeferred:  deferrability.InitiallyDeferred,
+		ExclusionOperators: exclusionOperators,
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -1038,6 +1142,8 @@ func ResolveFK(
 		OnUpdate:            descpb.ForeignKeyReferenceActionValue[d.Actions.Update],
 		Match:               descpb.CompositeKeyMatchMethodValue[d.Match],
 		ConstraintID:        tbl.NextConstraintID,
+		Deferrable:          d.Deferrability.Deferrable,
+		InitiallyDeferred:   d.Deferrability.InitiallyDeferred,
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -1786,15 +1892,8 @@ func NewTableDesc(
 				if err != nil {
 					return nil, err
 				}
-				switch column.GetType().Family() {
-				case types.GeometryFamily:
-					config, err := geoindex.GeometryIndexConfigForSRID(column.GetType().GeoSRIDOrZero())
-					if err != nil {
-						return nil, err
-					}
-					idx.GeoConfig = *config
-				case types.GeographyFamily:
-					idx.GeoConfig = *geoindex.DefaultGeographyIndexConfig()
+				if err := populateInvertedIndexDescriptor(column, &idx, columns[len(columns)-1]); err != nil {
+					return nil, err
 				}
 			}
 
@@ -1963,7 +2062,8 @@ func NewTableDesc(
 					return nil, err
 				}
 			}
-		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef:
+		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef,
+			*tree.ExclusionConstraintTableDef:
 			// pass, handled below.
 
 		default:
@@ -2145,6 +2245,13 @@ func NewTableDesc(
 				}
 			}
 
+		case *tree.ExclusionConstraintTableDef:
+			if err := addExclusionConstraintTableDef(
+				ctx, d, &desc, n.Table, NewTable, tree.ValidationDefault, semaCtx,
+			); err != nil {
+				return nil, err
+			}
+
 		case *tree.IndexTableDef, *tree.FamilyTableDef, *tree.LikeTableDef:
 			// Pass, handled above.
 
@@ -2566,6 +2673,14 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 				defs = append(defs, &def)
 			}
 			for _, c := range td.UniqueWithoutIndexConstraints {
+				if c.IsExclusion() {
+					def, err := makeExclusionConstraintTableDef(td, &c)
+					if err != nil {
+						return nil, err
+					}
+					defs = append(defs, def)
+					continue
+				}
 				def := tree.UniqueConstraintTableDef{
 					IndexTableDef: tree.IndexTableDef{
 						Name:    tree.Name(c.Name),
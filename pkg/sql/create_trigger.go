@@ -0,0 +1,267 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/optbuilder"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/errors"
+)
+
+type createTriggerNode struct {
+	n         *tree.CreateTrigger
+	tableDesc *tabledesc.Mutable
+	trig      descpb.TriggerDescriptor
+
+	// deps tracks which relations, types and functions the body of the
+	// trigger depends on.
+	deps bodyDependencies
+}
+
+// CreateTrigger creates a trigger on a table.
+// Privileges: CREATE on table.
+func (p *planner) CreateTrigger(ctx context.Context, n *tree.CreateTrigger) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE TRIGGER",
+	); err != nil {
+		return nil, err
+	}
+
+	if err := validateTriggerBody(n.Body); err != nil {
+		return nil, err
+	}
+
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &n.Table, true /* required */, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	for i := range tableDesc.Triggers {
+		if tableDesc.Triggers[i].Name == string(n.Name) {
+			return nil, pgerror.Newf(pgcode.DuplicateObject,
+				"trigger %q for relation %q already exists", n.Name, tableDesc.GetName())
+		}
+	}
+
+	trig := makeTriggerDescriptor(n)
+	deps, err := p.buildTriggerDependencies(ctx, tableDesc, &trig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &createTriggerNode{
+		n:         n,
+		tableDesc: tableDesc,
+		trig:      trig,
+		deps:      deps,
+	}, nil
+}
+
+// buildTriggerDependencies builds the body of the given trigger on the given
+// table, which checks it semantically, and returns the objects which the body
+// depends on.
+func (p *planner) buildTriggerDependencies(
+	ctx context.Context, tableDesc catalog.TableDescriptor, trig *descpb.TriggerDescriptor,
+) (bodyDependencies, error) {
+	return p.buildBodyDependencies(ctx, func(oc cat.Catalog, b *optbuilder.Builder) (
+		opt.ViewDeps, opt.ViewTypeDeps, opt.ViewFuncDeps, error,
+	) {
+		ds, err := oc.ResolveDataSourceByID(
+			ctx, cat.Flags{AvoidDescriptorCaches: true}, cat.StableID(tableDesc.GetID()),
+		)
+		if err != nil {
+			return nil, nil, opt.ViewFuncDeps{}, err
+		}
+		return b.BuildTriggerDependencies(ds.(cat.Table), makeCatTrigger(trig))
+	})
+}
+
+// validateTriggerBody checks that the body of a trigger is a single data
+// statement.
+func validateTriggerBody(body string) error {
+	stmt, err := parser.ParseOne(body)
+	if err != nil {
+		return pgerror.Wrap(err, pgcode.InvalidFunctionDefinition, "invalid trigger body")
+	}
+	switch stmt.AST.(type) {
+	case *tree.Insert, *tree.Update, *tree.Delete, *tree.Select:
+		return nil
+	}
+	return pgerror.Newf(pgcode.InvalidFunctionDefinition,
+		"trigger body must be a SELECT, INSERT, UPDATE, UPSERT or DELETE statement, found %s",
+		stmt.AST.StatementTag())
+}
+
+func (n *createTriggerNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("trigger"))
+
+	n.trig.DependsOn = n.deps.relationIDs(n.tableDesc.ID)
+	n.trig.DependsOnTypes = n.deps.types.Ordered()
+	n.trig.DependsOnFunctions = n.deps.functions.Ordered()
+	n.tableDesc.Triggers = append(n.tableDesc.Triggers, n.trig)
+	if err := params.p.writeSchemaChange(
+		params.ctx, n.tableDesc, descpb.InvalidMutationID, tree.AsStringWithFQNames(n.n, params.Ann()),
+	); err != nil {
+		return err
+	}
+
+	// Persist the back-references in all referenced relations.
+	for _, id := range n.trig.DependsOn {
+		backRefMutable, err := params.p.Descriptors().GetMutableTableVersionByID(params.ctx, id, params.p.txn)
+		if err != nil {
+			return err
+		}
+		ref := descpb.TableDescriptor_TriggerReference{
+			TableID:     n.tableDesc.ID,
+			TriggerName: n.trig.Name,
+		}
+		ref.ColumnIDs = n.deps.relations[id].Ordered()
+		backRefMutable.DependedOnByTriggers = append(backRefMutable.DependedOnByTriggers, ref)
+		if err := params.p.writeSchemaChange(
+			params.ctx, backRefMutable, descpb.InvalidMutationID,
+			fmt.Sprintf("updating trigger reference %q on table %s(%d) in relation %s(%d)",
+				n.trig.Name, n.tableDesc.Name, n.tableDesc.ID, backRefMutable.Name, backRefMutable.ID),
+		); err != nil {
+			return err
+		}
+	}
+
+	// Add back references for the type dependencies.
+	for _, id := range n.trig.DependsOnTypes {
+		jobDesc := fmt.Sprintf("updating type back reference %d for table %d", id, n.tableDesc.ID)
+		if err := params.p.addTypeBackReference(params.ctx, id, n.tableDesc.ID, jobDesc); err != nil {
+			return err
+		}
+	}
+
+	// Add back references for the function dependencies.
+	for _, id := range n.trig.DependsOnFunctions {
+		fnDesc, err := params.p.getMutableFunctionVersionByID(params.ctx, id)
+		if err != nil {
+			return err
+		}
+		fnDesc.DependedOnBy = append(fnDesc.DependedOnBy, descpb.FunctionDescriptor_Reference{
+			ID:          n.tableDesc.ID,
+			TriggerName: n.trig.Name,
+		})
+		if err := params.p.writeFunctionDescChange(params.ctx, fnDesc,
+			fmt.Sprintf("updating trigger reference %q on table %s(%d) in function %s(%d)",
+				n.trig.Name, n.tableDesc.Name, n.tableDesc.ID, fnDesc.Name, fnDesc.ID),
+		); err != nil {
+			return err
+		}
+	}
+	return validateDescriptor(params.ctx, params.p, n.tableDesc)
+}
+
+func (n *createTriggerNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createTriggerNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createTriggerNode) Close(context.Context)        {}
+
+// makeTriggerDescriptor converts a CREATE TRIGGER statement into a trigger
+// descriptor.
+func makeTriggerDescriptor(n *tree.CreateTrigger) descpb.TriggerDescriptor {
+	trig := descpb.TriggerDescriptor{
+		Name:       string(n.Name),
+		ForEachRow: n.ForEachRow,
+		Body:       n.Body,
+	}
+	switch n.ActionTime {
+	case tree.TriggerActionTimeBefore:
+		trig.ActionTime = descpb.TriggerDescriptor_BEFORE
+	case tree.TriggerActionTimeAfter:
+		trig.ActionTime = descpb.TriggerDescriptor_AFTER
+	default:
+		panic(errors.AssertionFailedf("unknown trigger action time %d", n.ActionTime))
+	}
+	trig.Events = make([]descpb.TriggerDescriptor_Event, len(n.Events))
+	for i, ev := range n.Events {
+		switch ev {
+		case tree.TriggerEventInsert:
+			trig.Events[i] = descpb.TriggerDescriptor_INSERT
+		case tree.TriggerEventUpdate:
+			trig.Events[i] = descpb.TriggerDescriptor_UPDATE
+		case tree.TriggerEventDelete:
+			trig.Events[i] = descpb.TriggerDescriptor_DELETE
+		default:
+			panic(errors.AssertionFailedf("unknown trigger event %d", ev))
+		}
+	}
+	return trig
+}
+
+// makeCreateTrigger converts a trigger descriptor back into the CREATE TRIGGER
+// statement which created it.
+func makeCreateTrigger(trig *descpb.TriggerDescriptor, tn tree.TableName) *tree.CreateTrigger {
+	n := &tree.CreateTrigger{
+		Name:       tree.Name(trig.Name),
+		Table:      tn,
+		ForEachRow: trig.ForEachRow,
+		Body:       trig.Body,
+	}
+	switch trig.ActionTime {
+	case descpb.TriggerDescriptor_BEFORE:
+		n.ActionTime = tree.TriggerActionTimeBefore
+	case descpb.TriggerDescriptor_AFTER:
+		n.ActionTime = tree.TriggerActionTimeAfter
+	default:
+		panic(errors.AssertionFailedf("unknown trigger action time %d", trig.ActionTime))
+	}
+	n.Events = make(tree.TriggerEvents, len(trig.Events))
+	for i, ev := range trig.Events {
+		switch ev {
+		case descpb.TriggerDescriptor_INSERT:
+			n.Events[i] = tree.TriggerEventInsert
+		case descpb.TriggerDescriptor_UPDATE:
+			n.Events[i] = tree.TriggerEventUpdate
+		case descpb.TriggerDescriptor_DELETE:
+			n.Events[i] = tree.TriggerEventDelete
+		default:
+			panic(errors.AssertionFailedf("unknown trigger event %d", ev))
+		}
+	}
+	return n
+}
+
+// makeCatTrigger converts a trigger descriptor into the optimizer catalog
+// representation of the trigger.
+func makeCatTrigger(trig *descpb.TriggerDescriptor) cat.Trigger {
+	n := makeCreateTrigger(trig, tree.TableName{})
+	return cat.Trigger{
+		Name:       n.Name,
+		ActionTime: n.ActionTime,
+		Events:     n.Events,
+		ForEachRow: n.ForEachRow,
+		Body:       n.Body,
+	}
+}
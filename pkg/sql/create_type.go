@@ -19,11 +19,14 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descidgen"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/enum"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
@@ -107,6 +110,10 @@ func (n *createTypeNode) startExec(params runParams) error {
 	switch n.n.Variety {
 	case tree.Enum:
 		return params.p.createUserDefinedEnum(params, n)
+	case tree.Composite:
+		return params.p.createUserDefinedComposite(params, n)
+	case tree.Domain:
+		return params.p.createUserDefinedDomain(params, n)
 	default:
 		return unimplemented.NewWithIssue(25123, "CREATE TYPE")
 	}
@@ -208,7 +215,7 @@ func findFreeArrayTypeName(
 }
 
 // CreateEnumArrayTypeDesc creates a type descriptor for the array of the
-// given enum.
+// given enum or composite type.
 func CreateEnumArrayTypeDesc(
 	params runParams,
 	typDesc *typedesc.Mutable,
@@ -223,6 +230,16 @@ func CreateEnumArrayTypeDesc(
 	switch t := typDesc.Kind; t {
 	case descpb.TypeDescriptor_ENUM, descpb.TypeDescriptor_MULTIREGION_ENUM:
 		elemTyp = types.MakeEnum(typedesc.TypeIDToOID(typDesc.GetID()), typedesc.TypeIDToOID(id))
+	case descpb.TypeDescriptor_COMPOSITE:
+		contents := make([]*types.T, len(typDesc.CompositeElements))
+		labels := make([]string, len(typDesc.CompositeElements))
+		for i, e := range typDesc.CompositeElements {
+			contents[i] = e.Type
+			labels[i] = e.Label
+		}
+		elemTyp = types.MakeComposite(
+			typedesc.TypeIDToOID(typDesc.GetID()), typedesc.TypeIDToOID(id), contents, labels,
+		)
 	default:
 		return nil, errors.AssertionFailedf("cannot make array type for kind %s", t.String())
 	}
@@ -430,6 +447,323 @@ func (p *planner) createEnumWithID(
 		})
 }
 
+func (p *planner) createUserDefinedComposite(params runParams, n *createTypeNode) error {
+	sqltelemetry.IncrementCompositeTypeCounter(sqltelemetry.CompositeTypeCreate)
+
+	elements := make([]descpb.TypeDescriptor_CompositeElement, len(n.n.CompositeTypeList))
+	seenLabels := make(map[tree.Name]struct{}, len(n.n.CompositeTypeList))
+	for i := range n.n.CompositeTypeList {
+		elem := &n.n.CompositeTypeList[i]
+		if _, ok := seenLabels[elem.Label]; ok {
+			return pgerror.Newf(pgcode.DuplicateColumn,
+				"column %q specified more than once", elem.Label)
+		}
+		seenLabels[elem.Label] = struct{}{}
+		typ, err := tree.ResolveType(params.ctx, elem.Type, p.semaCtx.GetTypeResolver())
+		if err != nil {
+			return err
+		}
+		// Enums are the only user defined types allowed as fields, which the
+		// composite type depends on.
+		if typ.UserDefined() && typ.Family() != types.EnumFamily {
+			return unimplemented.NewWithIssuef(27792,
+				"composite types with fields of user defined type %s are not supported", typ.SQLString())
+		}
+		if err := colinfo.ValidateColumnDefType(typ); err != nil {
+			return err
+		}
+		elements[i] = descpb.TypeDescriptor_CompositeElement{
+			Label: string(elem.Label),
+			Type:  typ,
+		}
+	}
+
+	schema, err := getCreateTypeParams(params, n.typeName, n.dbDesc)
+	if err != nil {
+		return err
+	}
+
+	id, err := descidgen.GenerateUniqueDescID(params.ctx, params.ExecCfg().DB, params.ExecCfg().Codec)
+	if err != nil {
+		return err
+	}
+	privs := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		n.dbDesc.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		n.dbDesc.GetID(),
+		params.SessionData().User(),
+		tree.Types,
+		n.dbDesc.GetPrivileges(),
+	)
+	typeDesc := typedesc.NewBuilder(&descpb.TypeDescriptor{
+		Name:              n.typeName.Type(),
+		ID:                id,
+		ParentID:          n.dbDesc.GetID(),
+		ParentSchemaID:    schema.GetID(),
+		Kind:              descpb.TypeDescriptor_COMPOSITE,
+		Version:           1,
+		Privileges:        privs,
+		CompositeElements: elements,
+	}).BuildCreatedMutableType()
+
+	// Create the implicit array type for this type before finishing the type.
+	arrayTypeID, err := p.createArrayType(params, n.typeName, typeDesc, n.dbDesc, schema.GetID())
+	if err != nil {
+		return err
+	}
+	typeDesc.ArrayTypeID = arrayTypeID
+
+	if err := p.createDescriptorWithID(
+		params.ctx,
+		catalogkeys.MakeObjectNameKey(params.ExecCfg().Codec, n.dbDesc.GetID(), schema.GetID(), n.typeName.Type()),
+		id,
+		typeDesc,
+		n.typeName.String(),
+	); err != nil {
+		return err
+	}
+	if err := p.addCompositeTypeBackReferences(params.ctx, typeDesc); err != nil {
+		return err
+	}
+
+	// Log the event.
+	return p.logEvent(params.ctx,
+		typeDesc.GetID(),
+		&eventpb.CreateType{
+			TypeName: n.typeName.FQString(),
+		})
+}
+
+// compositeElementTypeIDs returns the IDs of the user defined types of the
+// fields of the given composite type.
+func compositeElementTypeIDs(typeDesc *typedesc.Mutable) ([]descpb.ID, error) {
+	var ids catalog.DescriptorIDSet
+	for _, e := range typeDesc.CompositeElements {
+		closure, err := typedesc.GetTypeDescriptorClosure(e.Type)
+		if err != nil {
+			return nil, err
+		}
+		for id := range closure {
+			ids.Add(id)
+		}
+	}
+	return ids.Ordered(), nil
+}
+
+// addCompositeTypeBackReferences records the given composite type as a
+// dependent of the types of its fields, which the user must have the USAGE
+// privilege on.
+func (p *planner) addCompositeTypeBackReferences(
+	ctx context.Context, typeDesc *typedesc.Mutable,
+) error {
+	ids, err := compositeElementTypeIDs(typeDesc)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		mutDesc, err := p.Descriptors().GetMutableTypeVersionByID(ctx, p.txn, id)
+		if err != nil {
+			return err
+		}
+		if err := p.CheckPrivilege(ctx, mutDesc, privilege.USAGE); err != nil {
+			return err
+		}
+		mutDesc.AddReferencingTypeID(typeDesc.ID)
+		jobDesc := fmt.Sprintf("updating type back reference %d for composite type %d", id, typeDesc.ID)
+		if err := p.writeTypeSchemaChange(ctx, mutDesc, jobDesc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *planner) createUserDefinedDomain(params runParams, n *createTypeNode) error {
+	sqltelemetry.IncrementDomainCounter(sqltelemetry.DomainCreate)
+
+	baseType, err := tree.ResolveType(params.ctx, n.n.DomainBaseType, p.semaCtx.GetTypeResolver())
+	if err != nil {
+		return err
+	}
+	if baseType.UserDefined() {
+		return unimplemented.NewWithIssuef(27796,
+			"domains over user defined type %s are not supported", baseType.SQLString())
+	}
+	switch baseType.Family() {
+	case types.ArrayFamily, types.TupleFamily, types.AnyFamily, types.UnknownFamily:
+		return pgerror.Newf(pgcode.DatatypeMismatch,
+			"%s is not a valid base type for a domain", baseType.SQLString())
+	}
+
+	schema, err := getCreateTypeParams(params, n.typeName, n.dbDesc)
+	if err != nil {
+		return err
+	}
+
+	var defaultExpr *string
+	if n.n.DomainDefault != nil {
+		expr, err := schemaexpr.ValidateDomainDefaultExpr(
+			params.ctx, n.n.DomainDefault, baseType, p.SemaCtx(),
+		)
+		if err != nil {
+			return err
+		}
+		defaultExpr = &expr
+	}
+
+	var constraints []descpb.TypeDescriptor_DomainConstraint
+	var notNull, nullable bool
+	for i := range n.n.DomainConstraints {
+		d := &n.n.DomainConstraints[i]
+		switch {
+		case d.Nullable:
+			nullable = true
+			continue
+		case d.NotNull:
+			if notNull {
+				continue
+			}
+			notNull = true
+		}
+		c, err := p.makeDomainConstraint(params.ctx, n.typeName.Type(), baseType, d, constraints)
+		if err != nil {
+			return err
+		}
+		constraints = append(constraints, c)
+	}
+	if notNull && nullable {
+		return pgerror.New(pgcode.Syntax, "conflicting NULL/NOT NULL constraints")
+	}
+
+	id, err := descidgen.GenerateUniqueDescID(params.ctx, params.ExecCfg().DB, params.ExecCfg().Codec)
+	if err != nil {
+		return err
+	}
+	privs := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		n.dbDesc.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		n.dbDesc.GetID(),
+		params.SessionData().User(),
+		tree.Types,
+		n.dbDesc.GetPrivileges(),
+	)
+	typeDesc := typedesc.NewBuilder(&descpb.TypeDescriptor{
+		Name:              n.typeName.Type(),
+		ID:                id,
+		ParentID:          n.dbDesc.GetID(),
+		ParentSchemaID:    schema.GetID(),
+		Kind:              descpb.TypeDescriptor_DOMAIN,
+		Version:           1,
+		Privileges:        privs,
+		DomainBaseType:    baseType,
+		DomainDefaultExpr: defaultExpr,
+		DomainConstraints: constraints,
+	}).BuildCreatedMutableType()
+
+	// Unlike enums, domains do not have implicit array types.
+	if err := p.createDescriptorWithID(
+		params.ctx,
+		catalogkeys.MakeObjectNameKey(params.ExecCfg().Codec, n.dbDesc.GetID(), schema.GetID(), n.typeName.Type()),
+		id,
+		typeDesc,
+		n.typeName.String(),
+	); err != nil {
+		return err
+	}
+
+	// Log the event.
+	return p.logEvent(params.ctx,
+		typeDesc.GetID(),
+		&eventpb.CreateType{
+			TypeName: n.typeName.FQString(),
+		})
+}
+
+// makeCreateDomainNode returns a CREATE DOMAIN statement that creates a domain
+// with the given name like the given domain descriptor.
+func makeCreateDomainNode(
+	name *tree.UnresolvedObjectName, desc *descpb.TypeDescriptor,
+) (*tree.CreateType, error) {
+	node := &tree.CreateType{
+		Variety:        tree.Domain,
+		TypeName:       name,
+		DomainBaseType: desc.DomainBaseType,
+	}
+	if desc.DomainDefaultExpr != nil {
+		expr, err := parser.ParseExpr(*desc.DomainDefaultExpr)
+		if err != nil {
+			return nil, err
+		}
+		node.DomainDefault = expr
+	}
+	for _, c := range desc.DomainConstraints {
+		d := tree.DomainConstraint{Name: tree.Name(c.Name), NotNull: c.NotNull}
+		if !c.NotNull {
+			expr, err := parser.ParseExpr(c.Expr)
+			if err != nil {
+				return nil, err
+			}
+			d.Check = expr
+		}
+		node.DomainConstraints = append(node.DomainConstraints, d)
+	}
+	return node, nil
+}
+
+// makeDomainConstraint validates the given constraint of a domain with the
+// given name and base type and returns its descriptor representation. Unnamed
+// constraints are given a name that does not conflict with the existing
+// constraints of the domain. New constraints are VALIDATED unless they are
+// added to an existing domain, in which case the caller adjusts the validity.
+func (p *planner) makeDomainConstraint(
+	ctx context.Context,
+	domainName string,
+	baseType *types.T,
+	d *tree.DomainConstraint,
+	existing []descpb.TypeDescriptor_DomainConstraint,
+) (descpb.TypeDescriptor_DomainConstraint, error) {
+	c := descpb.TypeDescriptor_DomainConstraint{
+		Name:     string(d.Name),
+		Validity: descpb.ConstraintValidity_Validated,
+		NotNull:  d.NotNull,
+	}
+	if d.NotNull {
+		c.Expr = tree.Serialize(&tree.IsNotNullExpr{
+			Expr: &tree.UnresolvedName{NumParts: 1, Parts: tree.NameParts{string(schemaexpr.DomainValueName)}},
+		})
+	} else {
+		expr, err := schemaexpr.ValidateDomainCheckExpr(ctx, d.Check, baseType, p.SemaCtx())
+		if err != nil {
+			return c, err
+		}
+		c.Expr = expr
+	}
+
+	inUse := func(name string) bool {
+		for i := range existing {
+			if existing[i].Name == name {
+				return true
+			}
+		}
+		return false
+	}
+	if c.Name != "" {
+		if inUse(c.Name) {
+			return c, pgerror.Newf(pgcode.DuplicateObject,
+				"constraint %q for domain %q already exists", c.Name, domainName)
+		}
+		return c, nil
+	}
+	suffix := "check"
+	if d.NotNull {
+		suffix = "not_null"
+	}
+	c.Name = fmt.Sprintf("%s_%s", domainName, suffix)
+	for i := 1; inUse(c.Name); i++ {
+		c.Name = fmt.Sprintf("%s_%s%d", domainName, suffix, i)
+	}
+	return c, nil
+}
+
 func (n *createTypeNode) Next(params runParams) (bool, error) { return false, nil }
 func (n *createTypeNode) Values() tree.Datums                 { return tree.Datums{} }
 func (n *createTypeNode) Close(ctx context.Context)           {}
@@ -0,0 +1,200 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+// deferredConstraint identifies a deferrable FOREIGN KEY or UNIQUE WITHOUT
+// INDEX constraint. Foreign keys are identified by their origin table.
+type deferredConstraint struct {
+	tableID descpb.ID
+	name    string
+}
+
+// deferredConstraints is the per-transaction state of deferrable constraints.
+// It records the modes set with SET CONSTRAINTS, and the deferred constraints
+// that might have been violated by the statements executed so far. The checks
+// of a deferrable constraint are planned like those of any other constraint;
+// when a check query finds a violation while the constraint is deferred, the
+// constraint is added to the pending set instead of failing the statement.
+// Pending constraints are validated in full when they become immediate or
+// before the transaction commits.
+//
+// It is stored in the extraTxnState of the connExecutor and is reset when the
+// transaction finishes.
+type deferredConstraints struct {
+	// allMode is set by SET CONSTRAINTS ALL, and overrides the initial mode of
+	// every constraint.
+	allMode *bool
+	// modes contains the modes set by SET CONSTRAINTS <name>, which take
+	// precedence over allMode.
+	modes map[string]bool
+
+	// pending contains the constraints that must be validated before the
+	// transaction commits, along with whether they are initially deferred.
+	pending    []pendingConstraint
+	pendingSet map[deferredConstraint]struct{}
+}
+
+type pendingConstraint struct {
+	deferredConstraint
+	initiallyDeferred bool
+}
+
+// constraintDeferrability returns the deferrability of the given constraint.
+func constraintDeferrability(c descpb.ConstraintDetail) tree.ConstraintDeferrability {
+	switch {
+	case c.FK != nil:
+		return tree.ConstraintDeferrability{
+			Deferrable:        c.FK.Deferrable,
+			InitiallyDeferred: c.FK.InitiallyDeferred,
+		}
+	case c.UniqueWithoutIndexConstraint != nil:
+		return tree.ConstraintDeferrability{
+			Deferrable:        c.UniqueWithoutIndexConstraint.Deferrable,
+			InitiallyDeferred: c.UniqueWithoutIndexConstraint.InitiallyDeferred,
+		}
+	}
+	return tree.ConstraintDeferrability{}
+}
+
+// reset clears the state at the end of a transaction.
+func (dc *deferredConstraints) reset() {
+	*dc = deferredConstraints{}
+}
+
+// isDeferred returns true if the checks of the named constraint are currently
+// deferred.
+func (dc *deferredConstraints) isDeferred(name string, initiallyDeferred bool) bool {
+	if deferred, ok := dc.modes[name]; ok {
+		return deferred
+	}
+	if dc.allMode != nil {
+		return *dc.allMode
+	}
+	return initiallyDeferred
+}
+
+// maybeDefer adds the constraint violated by v to the pending set if the
+// constraint is currently deferred. It returns false if the violation must be
+// reported right away.
+func (dc *deferredConstraints) maybeDefer(v *exec.DeferrableConstraintViolation) bool {
+	if !dc.isDeferred(v.Constraint, v.InitiallyDeferred) {
+		return false
+	}
+	c := deferredConstraint{tableID: descpb.ID(v.TableID), name: v.Constraint}
+	if _, ok := dc.pendingSet[c]; ok {
+		return true
+	}
+	if dc.pendingSet == nil {
+		dc.pendingSet = make(map[deferredConstraint]struct{})
+	}
+	dc.pendingSet[c] = struct{}{}
+	dc.pending = append(dc.pending, pendingConstraint{
+		deferredConstraint: c,
+		initiallyDeferred:  v.InitiallyDeferred,
+	})
+	return true
+}
+
+// setMode implements SET CONSTRAINTS. If names is empty, the mode applies to
+// all constraints.
+func (dc *deferredConstraints) setMode(names tree.NameList, deferred bool) {
+	if len(names) == 0 {
+		dc.allMode = &deferred
+		dc.modes = nil
+		return
+	}
+	if dc.modes == nil {
+		dc.modes = make(map[string]bool, len(names))
+	}
+	for _, name := range names {
+		dc.modes[string(name)] = deferred
+	}
+}
+
+// validatePending validates the pending constraints and removes them from the
+// pending set. If onlyImmediate is set, the constraints that are still
+// deferred are left untouched.
+func (dc *deferredConstraints) validatePending(
+	ctx context.Context,
+	ief sqlutil.SessionBoundInternalExecutorFactory,
+	sd *sessiondata.SessionData,
+	txn *kv.Txn,
+	descsCol *descs.Collection,
+	onlyImmediate bool,
+) error {
+	remaining := dc.pending[:0]
+	for i, c := range dc.pending {
+		if onlyImmediate && dc.isDeferred(c.name, c.initiallyDeferred) {
+			remaining = append(remaining, c)
+			continue
+		}
+		if err := validateDeferredConstraint(
+			ctx, ief, sd, txn, descsCol, c.deferredConstraint,
+		); err != nil {
+			// Keep the constraints that weren't validated yet.
+			dc.pending = append(remaining, dc.pending[i:]...)
+			return err
+		}
+		delete(dc.pendingSet, c.deferredConstraint)
+	}
+	dc.pending = remaining
+	return nil
+}
+
+// validateDeferredConstraint checks that all the rows of the table satisfy the
+// given constraint. Constraints that were dropped in the meantime are ignored.
+func validateDeferredConstraint(
+	ctx context.Context,
+	ief sqlutil.SessionBoundInternalExecutorFactory,
+	sd *sessiondata.SessionData,
+	txn *kv.Txn,
+	descsCol *descs.Collection,
+	c deferredConstraint,
+) error {
+	flags := tree.ObjectLookupFlagsWithRequired()
+	flags.IncludeDropped = true
+	tbl, err := descsCol.GetImmutableTableByID(ctx, txn, c.tableID, flags)
+	if err != nil {
+		return err
+	}
+	if tbl.Dropped() {
+		return nil
+	}
+	tableDesc := tabledesc.NewBuilder(tbl.TableDesc()).BuildExistingMutableTable()
+	log.VEventf(ctx, 2, "validating deferred constraint %q on table %q", c.name, tbl.GetName())
+	for i := range tableDesc.OutboundFKs {
+		if tableDesc.OutboundFKs[i].Name == c.name {
+			return validateFkInTxn(ctx, ief, sd, tableDesc, txn, descsCol, c.name)
+		}
+	}
+	for i := range tableDesc.UniqueWithoutIndexConstraints {
+		if tableDesc.UniqueWithoutIndexConstraints[i].Name == c.name {
+			return validateUniqueWithoutIndexConstraintInTxn(
+				ctx, ief(ctx, sd), tableDesc, txn, c.name, true, /* preExisting */
+			)
+		}
+	}
+	return nil
+}
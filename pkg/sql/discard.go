@@ -40,6 +40,14 @@ func (p *planner) Discard(ctx context.Context, s *tree.Discard) (planNode, error
 
 		// DEALLOCATE ALL
 		p.preparedStatements.DeleteAll(ctx)
+
+		// CLOSE ALL
+		p.sqlCursors.closeAll(ctx)
+
+		// UNLISTEN *
+		if ns := p.extendedEvalCtx.Notifications; ns != nil {
+			ns.unlistenAll()
+		}
 	default:
 		return nil, errors.AssertionFailedf("unknown mode for DISCARD: %d", s.Mode)
 	}
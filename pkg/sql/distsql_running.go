@@ -1216,11 +1216,66 @@ func (dsp *DistSQLPlanner) PlanAndRunSubqueries(
 			}
 			return false
 		}
+		if !dsp.planAndRunBeforeCascades(ctx, planner, evalCtxFactory, subqueryPlan, recv) {
+			return false
+		}
 	}
 
 	return true
 }
 
+// planAndRunBeforeCascades runs the BEFORE cascades (see exec.Cascade.Before)
+// that read the mutation input buffered by the given subquery. They run once
+// the subquery has been executed and before the mutation that scans the buffer.
+//
+// Returns false if an error was encountered and sets that error in the provided
+// receiver.
+func (dsp *DistSQLPlanner) planAndRunBeforeCascades(
+	ctx context.Context,
+	planner *planner,
+	evalCtxFactory func() *extendedEvalContext,
+	subqueryPlan subquery,
+	recv *DistSQLReceiver,
+) bool {
+	buf, ok := subqueryPlan.plan.planNode.(*bufferNode)
+	if !ok {
+		return true
+	}
+	// Any cascades of the plans run so far (including those of nested cascade
+	// plans) are queued on the current plan.
+	plan := &planner.curPlan.planComponents
+	ran := false
+	for i := 0; i < len(plan.cascades); i++ {
+		if !plan.cascades[i].Before || plan.cascades[i].Buffer != buf {
+			continue
+		}
+		if buf.rows.rows.Len() == 0 {
+			// No rows are going to be modified.
+			return true
+		}
+		if !ran {
+			// The cascades step the transaction between rows, so stepping must be
+			// enabled until the mutation has run.
+			prevSteppingMode := planner.Txn().ConfigureStepping(ctx, kv.SteppingEnabled)
+			defer func() { _ = planner.Txn().ConfigureStepping(ctx, prevSteppingMode) }()
+		}
+		log.VEventf(ctx, 2, "executing cascade for constraint %s", plan.cascades[i].FKName)
+		if !dsp.planAndRunCascadeForEachRow(ctx, planner, evalCtxFactory, plan, i, recv) {
+			return false
+		}
+		ran = true
+	}
+	if ran {
+		// We place a sequence point so that the mutation observes the writes of
+		// the cascades.
+		if err := planner.Txn().Step(ctx); err != nil {
+			recv.SetError(err)
+			return false
+		}
+	}
+	return true
+}
+
 // subqueryResultMemAcc must be a non-nil memory account that the result of the
 // subquery's evaluation will be registered with. It is the caller's
 // responsibility to shrink it (or close it) accordingly, once the references to
@@ -1463,6 +1518,11 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 		// TODO(radu): this requires keeping all previous plans "alive" until the
 		// very end. We may want to make copies of the buffer nodes and clean up
 		// everything else.
+		if plan.cascades[i].Before {
+			// BEFORE cascades are run before the mutation, right after the
+			// subquery that buffers its input (see planAndRunBeforeCascades).
+			continue
+		}
 		buf := plan.cascades[i].Buffer
 		var numBufferedRows int
 		if buf != nil {
@@ -1487,7 +1547,15 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 			return false
 		}
 
+		if plan.cascades[i].ForEachRow {
+			if !dsp.planAndRunCascadeForEachRow(ctx, planner, evalCtxFactory, plan, i, recv) {
+				return false
+			}
+			continue
+		}
+
 		evalCtx := evalCtxFactory()
+
 		execFactory := newExecFactory(planner)
 		// The cascading query is allowed to autocommit only if it is the last
 		// cascade and there are no check queries to run.
@@ -1505,39 +1573,9 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 		}
 		cp := cascadePlan.(*planComponents)
 		plan.cascades[i].plan = cp.main
-		if len(cp.subqueryPlans) > 0 {
-			recv.SetError(errors.AssertionFailedf("cascades should not have subqueries"))
-			return false
-		}
-
-		// Queue any new cascades.
-		if len(cp.cascades) > 0 {
-			plan.cascades = append(plan.cascades, cp.cascades...)
-		}
-
-		// Collect any new checks.
-		if len(cp.checkPlans) > 0 {
-			plan.checkPlans = append(plan.checkPlans, cp.checkPlans...)
-		}
+		plan.cascades[i].subqueryPlans = cp.subqueryPlans
 
-		// In cyclical reference situations, the number of cascading operations can
-		// be arbitrarily large. To avoid OOM, we enforce a limit. This is also a
-		// safeguard in case we have a bug that results in an infinite cascade loop.
-		if limit := int(evalCtx.SessionData().OptimizerFKCascadesLimit); len(plan.cascades) > limit {
-			telemetry.Inc(sqltelemetry.CascadesLimitReached)
-			err := pgerror.Newf(pgcode.TriggeredActionException, "cascades limit (%d) reached", limit)
-			recv.SetError(err)
-			return false
-		}
-
-		if err := dsp.planAndRunPostquery(
-			ctx,
-			cp.main,
-			planner,
-			evalCtx,
-			recv,
-		); err != nil {
-			recv.SetError(err)
+		if !dsp.runCascadePlan(ctx, planner, evalCtx, plan, cp, recv) {
 			return false
 		}
 	}
@@ -1574,6 +1612,210 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 	return true
 }
 
+// runCascadePlan runs the given plan for a cascade, after queueing any new
+// cascades and checks it contains. The plan can have subqueries (for example,
+// for the bodies of triggers); these are run before the main plan.
+//
+// Returns false if an error was encountered and sets that error in the provided
+// receiver.
+func (dsp *DistSQLPlanner) runCascadePlan(
+	ctx context.Context,
+	planner *planner,
+	evalCtx *extendedEvalContext,
+	plan *planComponents,
+	cp *planComponents,
+	recv *DistSQLReceiver,
+) bool {
+	// Queue any new cascades.
+	if len(cp.cascades) > 0 {
+		plan.cascades = append(plan.cascades, cp.cascades...)
+	}
+
+	// Collect any new checks.
+	if len(cp.checkPlans) > 0 {
+		plan.checkPlans = append(plan.checkPlans, cp.checkPlans...)
+	}
+
+	// In cyclical reference situations, the number of cascading operations can
+	// be arbitrarily large. To avoid OOM, we enforce a limit. This is also a
+	// safeguard in case we have a bug that results in an infinite cascade loop.
+	if limit := int(evalCtx.SessionData().OptimizerFKCascadesLimit); plan.numOuterCascades+len(plan.cascades) > limit {
+		telemetry.Inc(sqltelemetry.CascadesLimitReached)
+		err := pgerror.Newf(pgcode.TriggeredActionException, "cascades limit (%d) reached", limit)
+		recv.SetError(err)
+		return false
+	}
+
+	if len(cp.subqueryPlans) > 0 {
+		// The subqueries referenced by the cascade plan are its own, so we
+		// temporarily replace the subqueries on the planner's curPlan (similar
+		// to what the apply join does).
+		oldSubqueries := planner.curPlan.subqueryPlans
+		planner.curPlan.subqueryPlans = cp.subqueryPlans
+		defer func() {
+			planner.curPlan.subqueryPlans = oldSubqueries
+		}()
+		// Create a separate memory account for the results of the subqueries.
+		// Note that we intentionally defer the closure of the account until we
+		// return from this method (after the cascade query is executed).
+		subqueryResultMemAcc := planner.EvalContext().Mon.MakeBoundAccount()
+		defer subqueryResultMemAcc.Close(ctx)
+		if !dsp.PlanAndRunSubqueries(
+			ctx,
+			planner,
+			func() *extendedEvalContext { return evalCtx },
+			cp.subqueryPlans,
+			recv,
+			&subqueryResultMemAcc,
+		) {
+			return false
+		}
+	}
+
+	if err := dsp.planAndRunPostquery(
+		ctx,
+		cp.main,
+		planner,
+		evalCtx,
+		recv,
+	); err != nil {
+		recv.SetError(err)
+		return false
+	}
+	return true
+}
+
+// planAndRunCascadeForEachRow plans and runs the cascade with the given index
+// once for each buffered row (see exec.Cascade.ForEachRow). The values of the
+// row are bound to the placeholders of the cascade query. This is used for
+// row-level triggers.
+//
+// The cascades and checks queued by the plan of a row are run right after it,
+// and all of these plans are closed before moving on to the next row. plan
+// must be the planComponents of planner.curPlan, whose cascades and checks are
+// replaced while each row is run.
+//
+// Returns false if an error was encountered and sets that error in the provided
+// receiver.
+func (dsp *DistSQLPlanner) planAndRunCascadeForEachRow(
+	ctx context.Context,
+	planner *planner,
+	evalCtxFactory func() *extendedEvalContext,
+	plan *planComponents,
+	cascadeIdx int,
+	recv *DistSQLReceiver,
+) bool {
+	// Note that plan.cascades is replaced while each row is run, so we don't
+	// hold on to a pointer into it.
+	buf := plan.cascades[cascadeIdx].Buffer.(*bufferNode)
+	ordinals := plan.cascades[cascadeIdx].PlaceholderOrdinals
+	rowFilterOrdinal := plan.cascades[cascadeIdx].RowFilterOrdinal
+	planFn := plan.cascades[cascadeIdx].PlanFn
+
+	evalCtx := evalCtxFactory()
+	oldPlaceholders := evalCtx.Placeholders
+	defer func() { evalCtx.Placeholders = oldPlaceholders }()
+	placeholders := &tree.PlaceholderInfo{Values: make(tree.QueryArguments, len(ordinals))}
+	placeholders.Types = make(tree.PlaceholderTypes, len(ordinals))
+	for i, ord := range ordinals {
+		if ord < 0 {
+			placeholders.Types[i] = types.Unknown
+		} else {
+			placeholders.Types[i] = buf.typs[ord]
+		}
+	}
+
+	// The cascades and checks queued so far are restored once all rows have
+	// run. The cascades queued by the enclosing plans still count toward the
+	// cascades limit, which bounds the recursion of triggers.
+	outerCascades, outerChecks, outerNumCascades := plan.cascades, plan.checkPlans, plan.numOuterCascades
+	defer func() {
+		plan.cascades, plan.checkPlans, plan.numOuterCascades = outerCascades, outerChecks, outerNumCascades
+	}()
+
+	iterator := newRowContainerIterator(ctx, buf.rows, buf.typs)
+	defer iterator.Close()
+	for {
+		row, err := iterator.Next()
+		if err != nil {
+			recv.SetError(err)
+			return false
+		}
+		if row == nil {
+			return true
+		}
+		if rowFilterOrdinal >= 0 && row[rowFilterOrdinal] != tree.DBoolTrue {
+			continue
+		}
+		for i, ord := range ordinals {
+			if ord < 0 {
+				placeholders.Values[i] = tree.DNull
+			} else {
+				placeholders.Values[i] = row[ord]
+			}
+		}
+		// The placeholders are set for every row, since the cascades of the
+		// previous row may have run with the same evalCtx.
+		evalCtx.Placeholders = placeholders
+
+		// We place a sequence point before every row, so that it can observe
+		// the writes performed for the previous rows.
+		// TODO(radu): the cascades themselves can have more cascades; if any of
+		// those fall back to legacy cascades code, it will disable stepping. So we
+		// have to reenable stepping each time.
+		_ = planner.Txn().ConfigureStepping(ctx, kv.SteppingEnabled)
+		if err := planner.Txn().Step(ctx); err != nil {
+			recv.SetError(err)
+			return false
+		}
+
+		cascadePlan, err := planFn(
+			ctx, &planner.semaCtx, &evalCtx.EvalContext, newExecFactory(planner),
+			nil /* bufferRef */, 0 /* numBufferedRows */, false, /* allowAutoCommit */
+		)
+		if err != nil {
+			recv.SetError(err)
+			return false
+		}
+		cp := cascadePlan.(*planComponents)
+		plan.cascades, plan.checkPlans = nil, nil
+		plan.numOuterCascades = outerNumCascades + len(outerCascades)
+		if !dsp.runCascadeRowPlan(ctx, planner, evalCtx, plan, cp, recv) {
+			return false
+		}
+	}
+}
+
+// runCascadeRowPlan runs the plan for one row of a cascade (see
+// planAndRunCascadeForEachRow), followed by the cascades and checks that it
+// queues on plan, and closes all of these plans.
+//
+// Returns false if an error was encountered and sets that error in the provided
+// receiver.
+func (dsp *DistSQLPlanner) runCascadeRowPlan(
+	ctx context.Context,
+	planner *planner,
+	evalCtx *extendedEvalContext,
+	plan *planComponents,
+	cp *planComponents,
+	recv *DistSQLReceiver,
+) bool {
+	rowPlan := planComponents{main: cp.main, subqueryPlans: cp.subqueryPlans}
+	defer func() {
+		rowPlan.cascades, rowPlan.checkPlans = plan.cascades, plan.checkPlans
+		rowPlan.close(ctx)
+	}()
+	if !dsp.runCascadePlan(ctx, planner, evalCtx, plan, cp, recv) {
+		return false
+	}
+	if len(plan.cascades) == 0 && len(plan.checkPlans) == 0 {
+		return true
+	}
+	return dsp.PlanAndRunCascadesAndChecks(
+		ctx, planner, func() *extendedEvalContext { return evalCtx }, plan, recv,
+	)
+}
+
 // planAndRunPostquery runs a cascade or check query.
 func (dsp *DistSQLPlanner) planAndRunPostquery(
 	ctx context.Context,
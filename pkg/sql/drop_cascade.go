@@ -12,11 +12,13 @@ package sql
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
@@ -36,6 +38,7 @@ type dropCascadeState struct {
 	toDeleteByID            map[descpb.ID]*toDelete
 	allTableObjectsToDelete []*tabledesc.Mutable
 	typesToDelete           []*typedesc.Mutable
+	functionsToDelete       []*funcdesc.Mutable
 
 	droppedNames []string
 }
@@ -66,6 +69,24 @@ func (d *dropCascadeState) collectObjectsInSchema(
 	for i := range names {
 		d.objectNamesToDelete = append(d.objectNamesToDelete, &names[i])
 	}
+	// User-defined functions are not in the namespace table; they are
+	// referenced by their schema instead.
+	if err := schema.ForEachFunctionOverload(func(
+		_ string, overload descpb.SchemaDescriptor_Function_Overload,
+	) error {
+		fn, err := p.Descriptors().GetMutableFunctionByID(ctx, p.txn, overload.ID,
+			tree.ObjectLookupFlags{CommonLookupFlags: tree.CommonLookupFlags{Required: true}})
+		if err != nil {
+			return err
+		}
+		if err := p.canModifyFunction(ctx, fn); err != nil {
+			return err
+		}
+		d.functionsToDelete = append(d.functionsToDelete, fn)
+		return nil
+	}); err != nil {
+		return err
+	}
 	d.schemasToDelete = append(d.schemasToDelete, schemaWithDbDesc{schema: schema, dbDesc: db})
 	return nil
 }
@@ -207,7 +228,18 @@ func (d *dropCascadeState) dropAllCollectedObjects(ctx context.Context, p *plann
 		d.droppedNames = append(d.droppedNames, toDel.tn.FQString())
 	}
 
-	// Now delete all of the types.
+	// Then delete all of the functions, which may depend on the types. Their
+	// schemas are being dropped, so the schemas do not need to be updated.
+	for _, fn := range d.functionsToDelete {
+		if err := p.dropFunctionImpl(
+			ctx, fn, false /* removeFromSchema */, fmt.Sprintf("dropping function %d with its schema", fn.GetID()),
+		); err != nil {
+			return err
+		}
+		d.droppedNames = append(d.droppedNames, fn.GetName())
+	}
+
+	// Finally, delete all of the types.
 	for _, typ := range d.typesToDelete {
 		if err := d.canDropType(ctx, p, typ); err != nil {
 			return err
@@ -225,6 +257,20 @@ func (d *dropCascadeState) dropAllCollectedObjects(ctx context.Context, p *plann
 func (d *dropCascadeState) canDropType(
 	ctx context.Context, p *planner, typ *typedesc.Mutable,
 ) error {
+	// The functions which were dropped above have already removed their
+	// references to the type.
+	if len(typ.ReferencingFunctionIDs) > 0 {
+		return p.dependentFunctionError(
+			ctx, "type", typ.Name, typ.ReferencingFunctionIDs[0], "drop",
+		)
+	}
+	// Composite types which depend on the type may only be in the schemas or
+	// database being dropped.
+	for _, id := range typ.ReferencingTypeIDs {
+		if !d.dropsType(id) {
+			return p.dependentTypeError(ctx, typ.Name, id, "drop")
+		}
+	}
 	var referencedButNotDropping []descpb.ID
 	for _, id := range typ.ReferencingDescriptorIDs {
 		if _, exists := d.toDeleteByID[id]; exists {
@@ -253,6 +299,16 @@ func (d *dropCascadeState) canDropType(
 	)
 }
 
+// dropsType returns true if the type with the given ID is being dropped.
+func (d *dropCascadeState) dropsType(id descpb.ID) bool {
+	for _, typ := range d.typesToDelete {
+		if typ.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
 func (d *dropCascadeState) getDroppedTableDetails() []jobspb.DroppedTableDetails {
 	res := make([]jobspb.DroppedTableDetails, len(d.allTableObjectsToDelete))
 	for i := range d.allTableObjectsToDelete {
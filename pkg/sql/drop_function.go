@@ -0,0 +1,317 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/errors"
+)
+
+type dropFunctionNode struct {
+	n         *tree.DropFunction
+	toDrop    []*funcdesc.Mutable
+	fnObjects []*tree.FuncObj
+}
+
+// DropFunction drops user-defined functions.
+// Privileges: ownership of the functions.
+func (p *planner) DropFunction(ctx context.Context, n *tree.DropFunction) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP FUNCTION",
+	); err != nil {
+		return nil, err
+	}
+
+	node := &dropFunctionNode{n: n}
+	for i := range n.Functions {
+		fo := &n.Functions[i]
+		desc, err := p.getMutableFunctionDescriptor(ctx, fo, !n.IfExists)
+		if err != nil {
+			return nil, err
+		}
+		if desc == nil {
+			continue
+		}
+		// Functions can be listed more than once.
+		dup := false
+		for _, d := range node.toDrop {
+			if d.GetID() == desc.GetID() {
+				dup = true
+				break
+			}
+		}
+		if dup {
+			continue
+		}
+		if err := p.canModifyFunction(ctx, desc); err != nil {
+			return nil, err
+		}
+		node.toDrop = append(node.toDrop, desc)
+		node.fnObjects = append(node.fnObjects, fo)
+	}
+	if len(node.toDrop) == 0 {
+		return newZeroNode(nil /* columns */), nil
+	}
+	// Functions which depend on each other can be dropped together.
+	var toDropIDs catalog.DescriptorIDSet
+	for _, desc := range node.toDrop {
+		toDropIDs.Add(desc.GetID())
+	}
+	for _, desc := range node.toDrop {
+		if err := p.canRemoveFunctionDependents(
+			ctx, desc, n.DropBehavior, toDropIDs.Contains,
+		); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+func (n *dropFunctionNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("function"))
+
+	for i, desc := range n.toDrop {
+		jobDesc := "DROP FUNCTION " + tree.AsStringWithFQNames(n.fnObjects[i], params.Ann())
+		if err := params.p.dropFunctionImpl(
+			params.ctx, desc, true /* removeFromSchema */, jobDesc,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *dropFunctionNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropFunctionNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropFunctionNode) Close(context.Context)        {}
+
+// dropFunctionImpl drops the given function, as well as the functions and
+// triggers which depend on it, assuming that canRemoveFunctionDependents has
+// already been called. The function is removed from its schema unless the
+// schema is also being dropped.
+func (p *planner) dropFunctionImpl(
+	ctx context.Context, desc *funcdesc.Mutable, removeFromSchema bool, jobDesc string,
+) error {
+	// The function may already have been dropped as a dependent of another
+	// object.
+	if desc.Dropped() {
+		return nil
+	}
+	if removeFromSchema {
+		scDesc, err := p.getMutableFunctionSchema(ctx, desc)
+		if err != nil {
+			return err
+		}
+		scDesc.RemoveFunction(desc.GetName(), desc.GetID())
+		if err := p.writeSchemaDescChange(ctx, scDesc, jobDesc); err != nil {
+			return err
+		}
+	}
+	desc.SetDropped()
+	if err := p.removeFunctionBackReferences(ctx, desc); err != nil {
+		return err
+	}
+
+	// Drop the dependent functions and triggers.
+	for _, ref := range desc.DependedOnBy {
+		if ref.TriggerName != "" {
+			tableDesc, err := p.Descriptors().GetMutableTableVersionByID(ctx, ref.ID, p.txn)
+			if err != nil {
+				return err
+			}
+			// The table of the trigger is also being dropped.
+			if tableDesc.Dropped() {
+				continue
+			}
+			for idx := range tableDesc.Triggers {
+				if tableDesc.Triggers[idx].Name != ref.TriggerName {
+					continue
+				}
+				if err := p.removeTrigger(ctx, tableDesc, idx, fmt.Sprintf(
+					"dropping trigger %q dependent on function %q which is being dropped",
+					ref.TriggerName, desc.Name),
+				); err != nil {
+					return err
+				}
+				break
+			}
+			continue
+		}
+		dependent, err := p.getMutableFunctionVersionByID(ctx, ref.ID)
+		if err != nil {
+			return err
+		}
+		if err := p.dropFunctionImpl(ctx, dependent, true /* removeFromSchema */, fmt.Sprintf(
+			"dropping function %q dependent on function %q which is being dropped",
+			dependent.Name, desc.Name),
+		); err != nil {
+			return err
+		}
+	}
+	desc.DependedOnBy = nil
+	return p.writeFunctionDescChange(ctx, desc, jobDesc)
+}
+
+// canRemoveFunctionDependents returns an error if the body of another function
+// or of a trigger depends on the given function, unless CASCADE was specified,
+// in which case it checks that the dependents can be dropped. The functions for
+// which skip returns true are ignored, since they are also being dropped.
+func (p *planner) canRemoveFunctionDependents(
+	ctx context.Context,
+	desc catalog.FunctionDescriptor,
+	behavior tree.DropBehavior,
+	skip func(descpb.ID) bool,
+) error {
+	for _, ref := range desc.GetDependedOnBy() {
+		if ref.TriggerName == "" && skip != nil && skip(ref.ID) {
+			continue
+		}
+		if behavior != tree.DropCascade {
+			return p.functionReferenceError(ctx, "function", desc.GetName(), &ref, "drop")
+		}
+		if ref.TriggerName != "" {
+			tableDesc, err := p.Descriptors().GetMutableTableVersionByID(ctx, ref.ID, p.txn)
+			if err != nil {
+				return err
+			}
+			if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+				return err
+			}
+			continue
+		}
+		dependent, err := p.getMutableFunctionVersionByID(ctx, ref.ID)
+		if err != nil {
+			return err
+		}
+		if err := p.canModifyFunction(ctx, dependent); err != nil {
+			return err
+		}
+		if err := p.canRemoveFunctionDependents(ctx, dependent, behavior, skip); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// canRemoveDependentFunctions returns an error if the body of a function
+// depends on the given relation or, if colID is non-zero, on the given column
+// of the relation, unless CASCADE was specified.
+func (p *planner) canRemoveDependentFunctions(
+	ctx context.Context,
+	typeName, objName string,
+	desc *tabledesc.Mutable,
+	colID descpb.ColumnID,
+	behavior tree.DropBehavior,
+) error {
+	for i := range desc.DependedOnByFunctions {
+		ref := &desc.DependedOnByFunctions[i]
+		if colID != 0 && !descpb.ColumnIDs(ref.ColumnIDs).Contains(colID) {
+			continue
+		}
+		if behavior != tree.DropCascade {
+			return p.dependentFunctionError(ctx, typeName, objName, ref.FunctionID, "drop")
+		}
+		fnDesc, err := p.getMutableFunctionVersionByID(ctx, ref.FunctionID)
+		if err != nil {
+			return err
+		}
+		if err := p.canModifyFunction(ctx, fnDesc); err != nil {
+			return err
+		}
+		if err := p.canRemoveFunctionDependents(ctx, fnDesc, behavior, nil /* skip */); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropDependentFunctions drops the functions whose bodies depend on the given
+// relation or, if colID is non-zero, on the given column of the relation. It
+// assumes that canRemoveDependentFunctions has already been called.
+func (p *planner) dropDependentFunctions(
+	ctx context.Context, desc *tabledesc.Mutable, colID descpb.ColumnID,
+) error {
+	refs := append([]descpb.TableDescriptor_FunctionReference(nil), desc.DependedOnByFunctions...)
+	for i := range refs {
+		ref := &refs[i]
+		if colID != 0 && !descpb.ColumnIDs(ref.ColumnIDs).Contains(colID) {
+			continue
+		}
+		fnDesc, err := p.getMutableFunctionVersionByID(ctx, ref.FunctionID)
+		if err != nil {
+			return err
+		}
+		jobDesc := fmt.Sprintf("dropping function %q dependent on relation %q which is being dropped",
+			fnDesc.Name, desc.Name)
+		if err := p.dropFunctionImpl(ctx, fnDesc, true /* removeFromSchema */, jobDesc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeFunctionReferences drops the functions whose bodies depend on the
+// given relation, which is being dropped, assuming that
+// canRemoveDependentFunctions has already been called.
+func (p *planner) removeFunctionReferences(ctx context.Context, desc *tabledesc.Mutable) error {
+	if err := p.dropDependentFunctions(ctx, desc, 0 /* colID */); err != nil {
+		return err
+	}
+	desc.DependedOnByFunctions = nil
+	return nil
+}
+
+// dependentFunctionError returns an error stating that the given object cannot
+// be modified by the given operation because the given function depends on it.
+func (p *planner) dependentFunctionError(
+	ctx context.Context, typeName, objName string, fnID descpb.ID, op string,
+) error {
+	fnDesc, err := p.Descriptors().GetImmutableFunctionByID(ctx, p.txn, fnID,
+		tree.ObjectLookupFlags{CommonLookupFlags: tree.CommonLookupFlags{Required: true}})
+	if err != nil {
+		return err
+	}
+	return errors.WithHintf(
+		sqlerrors.NewDependentObjectErrorf("cannot %s %s %q because function %q depends on it",
+			op, typeName, objName, fnDesc.GetName()),
+		"you can drop function %q instead.", fnDesc.GetName())
+}
+
+// functionReferenceError returns an error stating that the given object cannot
+// be modified by the given operation because the function or trigger which
+// holds the given reference depends on it.
+func (p *planner) functionReferenceError(
+	ctx context.Context,
+	typeName, objName string,
+	ref *descpb.FunctionDescriptor_Reference,
+	op string,
+) error {
+	if ref.TriggerName != "" {
+		return p.dependentTriggerError(ctx, typeName, objName, &descpb.TableDescriptor_TriggerReference{
+			TableID:     ref.ID,
+			TriggerName: ref.TriggerName,
+		}, op)
+	}
+	return p.dependentFunctionError(ctx, typeName, objName, ref.ID, op)
+}
@@ -57,6 +57,18 @@ func (p *planner) DropSequence(ctx context.Context, n *tree.DropSequence) (planN
 		if depErr := p.sequenceDependencyError(ctx, droppedDesc, n.DropBehavior); depErr != nil {
 			return nil, depErr
 		}
+		if err := p.canRemoveDependentTriggers(
+			ctx, string(droppedDesc.DescriptorType()), droppedDesc.Name, droppedDesc, 0, /* colID */
+			n.DropBehavior, nil, /* skip */
+		); err != nil {
+			return nil, err
+		}
+		if err := p.canRemoveDependentFunctions(
+			ctx, string(droppedDesc.DescriptorType()), droppedDesc.Name, droppedDesc, 0, /* colID */
+			n.DropBehavior,
+		); err != nil {
+			return nil, err
+		}
 
 		td = append(td, toDelete{tn, droppedDesc})
 	}
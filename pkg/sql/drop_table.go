@@ -90,6 +90,18 @@ func (p *planner) DropTable(ctx context.Context, n *tree.DropTable) (planNode, e
 				}
 			}
 		}
+		if err := p.canRemoveDependentTriggers(
+			ctx, string(droppedDesc.DescriptorType()), droppedDesc.Name, droppedDesc, 0, /* colID */
+			n.DropBehavior, func(id descpb.ID) bool { _, ok := td[id]; return ok },
+		); err != nil {
+			return nil, err
+		}
+		if err := p.canRemoveDependentFunctions(
+			ctx, string(droppedDesc.DescriptorType()), droppedDesc.Name, droppedDesc, 0, /* colID */
+			n.DropBehavior,
+		); err != nil {
+			return nil, err
+		}
 		if err := p.canRemoveAllTableOwnedSequences(ctx, droppedDesc, n.DropBehavior); err != nil {
 			return nil, err
 		}
@@ -384,6 +396,14 @@ func (p *planner) initiateDropTable(
 		return scerrors.ConcurrentSchemaChangeError(tableDesc)
 	}
 
+	// Remove the references from and to the bodies of triggers and functions.
+	if err := p.removeTriggerReferences(ctx, tableDesc); err != nil {
+		return err
+	}
+	if err := p.removeFunctionReferences(ctx, tableDesc); err != nil {
+		return err
+	}
+
 	// Use the delayed GC mechanism to schedule usage of the more efficient
 	// ClearRange pathway.
 	if tableDesc.IsTable() {
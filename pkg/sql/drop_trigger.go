@@ -0,0 +1,314 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+type dropTriggerNode struct {
+	n         *tree.DropTrigger
+	tableDesc *tabledesc.Mutable
+	// idx is the position of the trigger in tableDesc.Triggers.
+	idx int
+}
+
+// DropTrigger drops a trigger from a table.
+// Privileges: CREATE on table.
+//   Notes: no objects can depend on a trigger, so CASCADE and RESTRICT behave
+//          the same, as in postgres.
+func (p *planner) DropTrigger(ctx context.Context, n *tree.DropTrigger) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP TRIGGER",
+	); err != nil {
+		return nil, err
+	}
+
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &n.Table, !n.IfExists, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if tableDesc == nil {
+		// Noop.
+		return newZeroNode(nil /* columns */), nil
+	}
+
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	for i := range tableDesc.Triggers {
+		if tableDesc.Triggers[i].Name == string(n.Name) {
+			return &dropTriggerNode{n: n, tableDesc: tableDesc, idx: i}, nil
+		}
+	}
+	if n.IfExists {
+		return newZeroNode(nil /* columns */), nil
+	}
+	return nil, pgerror.Newf(pgcode.UndefinedObject,
+		"trigger %q for table %q does not exist", n.Name, tableDesc.GetName())
+}
+
+func (n *dropTriggerNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("trigger"))
+
+	if err := params.p.removeTrigger(
+		params.ctx, n.tableDesc, n.idx, tree.AsStringWithFQNames(n.n, params.Ann()),
+	); err != nil {
+		return err
+	}
+	return validateDescriptor(params.ctx, params.p, n.tableDesc)
+}
+
+func (n *dropTriggerNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropTriggerNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropTriggerNode) Close(context.Context)        {}
+
+// removeTrigger removes the trigger at the given position from the given
+// table, along with the back-references from the relations and types its body
+// depends on.
+func (p *planner) removeTrigger(
+	ctx context.Context, tableDesc *tabledesc.Mutable, idx int, jobDesc string,
+) error {
+	trig := tableDesc.Triggers[idx]
+	if err := p.removeTriggerBackReferences(ctx, tableDesc, &trig); err != nil {
+		return err
+	}
+	triggers := tableDesc.Triggers
+	tableDesc.Triggers = append(triggers[:idx:idx], triggers[idx+1:]...)
+
+	// Remove the back-references from the types which the table no longer
+	// references.
+	_, dbDesc, err := p.Descriptors().GetImmutableDatabaseByID(
+		ctx, p.txn, tableDesc.GetParentID(), tree.DatabaseLookupFlags{
+			Required:    true,
+			AvoidLeased: true,
+		})
+	if err != nil {
+		return err
+	}
+	typeIDs, _, err := tableDesc.GetAllReferencedTypeIDs(dbDesc, func(id descpb.ID) (catalog.TypeDescriptor, error) {
+		mutDesc, err := p.Descriptors().GetMutableTypeVersionByID(ctx, p.txn, id)
+		if err != nil {
+			return nil, err
+		}
+		return mutDesc, nil
+	})
+	if err != nil {
+		return err
+	}
+	referenced := catalog.MakeDescriptorIDSet(typeIDs...)
+	var unreferenced []descpb.ID
+	for _, id := range trig.DependsOnTypes {
+		if !referenced.Contains(id) {
+			unreferenced = append(unreferenced, id)
+		}
+	}
+	backRefJobDesc := fmt.Sprintf("updating type back references %v for table %d", unreferenced, tableDesc.ID)
+	if err := p.removeTypeBackReferences(ctx, unreferenced, tableDesc.ID, backRefJobDesc); err != nil {
+		return err
+	}
+	return p.writeSchemaChange(ctx, tableDesc, descpb.InvalidMutationID, jobDesc)
+}
+
+// removeTriggerBackReferences removes the back-references to the given
+// trigger on the given table from the relations and functions its body depends
+// on.
+func (p *planner) removeTriggerBackReferences(
+	ctx context.Context, tableDesc *tabledesc.Mutable, trig *descpb.TriggerDescriptor,
+) error {
+	for _, depID := range trig.DependsOn {
+		dependencyDesc, err := p.Descriptors().GetMutableTableVersionByID(ctx, depID, p.txn)
+		if err != nil {
+			return errors.Wrapf(err, "error resolving dependency relation ID %d", depID)
+		}
+		// The dependency is also being deleted, so we don't have to remove the
+		// references.
+		if dependencyDesc.Dropped() {
+			continue
+		}
+		dependencyDesc.DependedOnByTriggers = removeMatchingTriggerReferences(
+			dependencyDesc.DependedOnByTriggers, tableDesc.ID, trig.Name,
+		)
+		if err := p.writeSchemaChange(
+			ctx, dependencyDesc, descpb.InvalidMutationID,
+			fmt.Sprintf("removing references for trigger %s on table %s(%d) from relation %s(%d)",
+				trig.Name, tableDesc.Name, tableDesc.ID, dependencyDesc.Name, dependencyDesc.ID),
+		); err != nil {
+			return err
+		}
+	}
+	for _, depID := range trig.DependsOnFunctions {
+		fnDesc, err := p.getMutableFunctionVersionByID(ctx, depID)
+		if err != nil {
+			return errors.Wrapf(err, "error resolving dependency function ID %d", depID)
+		}
+		if fnDesc.Dropped() {
+			continue
+		}
+		fnDesc.DependedOnBy = removeMatchingFunctionReferences(
+			fnDesc.DependedOnBy, tableDesc.ID, trig.Name,
+		)
+		if err := p.writeFunctionDescChange(ctx, fnDesc,
+			fmt.Sprintf("removing references for trigger %s on table %s(%d) from function %s(%d)",
+				trig.Name, tableDesc.Name, tableDesc.ID, fnDesc.Name, fnDesc.ID),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeMatchingTriggerReferences removes the references to the given trigger
+// on the given table from a slice of trigger references.
+func removeMatchingTriggerReferences(
+	refs []descpb.TableDescriptor_TriggerReference, tableID descpb.ID, triggerName string,
+) []descpb.TableDescriptor_TriggerReference {
+	updatedRefs := refs[:0]
+	for _, ref := range refs {
+		if ref.TableID != tableID || ref.TriggerName != triggerName {
+			updatedRefs = append(updatedRefs, ref)
+		}
+	}
+	return updatedRefs
+}
+
+// canRemoveDependentTriggers returns an error if the body of a trigger depends
+// on the given relation or, if colID is non-zero, on the given column of the
+// relation, unless CASCADE was specified. Triggers on the tables for which
+// skip returns true are ignored, since these tables are also being dropped.
+func (p *planner) canRemoveDependentTriggers(
+	ctx context.Context,
+	typeName, objName string,
+	desc *tabledesc.Mutable,
+	colID descpb.ColumnID,
+	behavior tree.DropBehavior,
+	skip func(descpb.ID) bool,
+) error {
+	for i := range desc.DependedOnByTriggers {
+		ref := &desc.DependedOnByTriggers[i]
+		if skip != nil && skip(ref.TableID) {
+			continue
+		}
+		if colID != 0 && !descpb.ColumnIDs(ref.ColumnIDs).Contains(colID) {
+			continue
+		}
+		if behavior != tree.DropCascade {
+			return p.dependentTriggerError(ctx, typeName, objName, ref, "drop")
+		}
+		tableDesc, err := p.Descriptors().GetMutableTableVersionByID(ctx, ref.TableID, p.txn)
+		if err != nil {
+			return err
+		}
+		if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropDependentTriggers removes the triggers whose bodies depend on the given
+// relation or, if colID is non-zero, on the given column of the relation. It
+// assumes that canRemoveDependentTriggers has already been called.
+func (p *planner) dropDependentTriggers(
+	ctx context.Context, desc *tabledesc.Mutable, colID descpb.ColumnID,
+) error {
+	refs := append([]descpb.TableDescriptor_TriggerReference(nil), desc.DependedOnByTriggers...)
+	for i := range refs {
+		ref := &refs[i]
+		if colID != 0 && !descpb.ColumnIDs(ref.ColumnIDs).Contains(colID) {
+			continue
+		}
+		tableDesc, err := p.Descriptors().GetMutableTableVersionByID(ctx, ref.TableID, p.txn)
+		if err != nil {
+			return err
+		}
+		// The table of the trigger is also being dropped.
+		if tableDesc.Dropped() {
+			continue
+		}
+		for idx := range tableDesc.Triggers {
+			if tableDesc.Triggers[idx].Name != ref.TriggerName {
+				continue
+			}
+			jobDesc := fmt.Sprintf("dropping trigger %q dependent on relation %q which is being dropped",
+				ref.TriggerName, desc.Name)
+			if err := p.removeTrigger(ctx, tableDesc, idx, jobDesc); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// dependentTriggerError returns an error stating that the given object cannot
+// be modified by the given operation because the given trigger depends on it.
+func (p *planner) dependentTriggerError(
+	ctx context.Context,
+	typeName, objName string,
+	ref *descpb.TableDescriptor_TriggerReference,
+	op string,
+) error {
+	tableDesc, err := p.Descriptors().Direct().MustGetTableDescByID(ctx, p.txn, ref.TableID)
+	if err != nil {
+		return err
+	}
+	tableName, err := p.getQualifiedTableName(ctx, tableDesc)
+	if err != nil {
+		log.Warningf(ctx, "unable to retrieve name of table %d: %v", ref.TableID, err)
+		return sqlerrors.NewDependentObjectErrorf(
+			"cannot %s %s %q because a trigger depends on it",
+			op, typeName, objName)
+	}
+	return errors.WithHintf(
+		sqlerrors.NewDependentObjectErrorf("cannot %s %s %q because trigger %q on table %q depends on it",
+			op, typeName, objName, ref.TriggerName, tableName.FQString()),
+		"you can drop trigger %q on table %q instead.", ref.TriggerName, tableName.FQString())
+}
+
+// removeTriggerReferences removes the references from the triggers on the
+// given relation, which is being dropped, to the relations their bodies depend
+// on. It also drops the triggers on other tables whose bodies depend on the
+// relation, assuming that canRemoveDependentTriggers has already been called.
+func (p *planner) removeTriggerReferences(ctx context.Context, desc *tabledesc.Mutable) error {
+	for i := range desc.Triggers {
+		trig := &desc.Triggers[i]
+		if err := p.removeTriggerBackReferences(ctx, desc, trig); err != nil {
+			return err
+		}
+		trig.DependsOn = nil
+		trig.DependsOnFunctions = nil
+	}
+	if err := p.dropDependentTriggers(ctx, desc, 0 /* colID */); err != nil {
+		return err
+	}
+	desc.DependedOnByTriggers = nil
+	return nil
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
@@ -65,6 +66,14 @@ func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, err
 		if _, ok := node.toDrop[typeDesc.ID]; ok {
 			continue
 		}
+		if isDomain := typeDesc.Kind == descpb.TypeDescriptor_DOMAIN; isDomain != n.Domain {
+			if isDomain {
+				return nil, errors.WithHint(
+					pgerror.Newf(pgcode.WrongObjectType, "%q is a domain", name),
+					"use DROP DOMAIN to remove a domain")
+			}
+			return nil, pgerror.Newf(pgcode.WrongObjectType, "%q is not a domain", name)
+		}
 		switch typeDesc.Kind {
 		case descpb.TypeDescriptor_ALIAS:
 			// The implicit array types are not directly droppable.
@@ -84,6 +93,10 @@ func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, err
 				"try ALTER DATABASE DROP REGION %s", name)
 		case descpb.TypeDescriptor_ENUM:
 			sqltelemetry.IncrementEnumCounter(sqltelemetry.EnumDrop)
+		case descpb.TypeDescriptor_DOMAIN:
+			sqltelemetry.IncrementDomainCounter(sqltelemetry.DomainDrop)
+		case descpb.TypeDescriptor_COMPOSITE:
+			sqltelemetry.IncrementCompositeTypeCounter(sqltelemetry.CompositeTypeDrop)
 		case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
 			return nil, pgerror.Newf(
 				pgcode.DependentObjectsStillExist,
@@ -96,6 +109,12 @@ func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, err
 		if err := p.canDropTypeDesc(ctx, typeDesc, n.DropBehavior); err != nil {
 			return nil, err
 		}
+		node.toDrop[typeDesc.ID] = typeDesc
+
+		// Domains do not have implicit array types.
+		if typeDesc.Kind == descpb.TypeDescriptor_DOMAIN {
+			continue
+		}
 
 		// Get the array type that needs to be dropped as well.
 		mutArrayDesc, err := p.Descriptors().GetMutableTypeVersionByID(ctx, p.txn, typeDesc.ArrayTypeID)
@@ -106,8 +125,7 @@ func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, err
 		if err := p.canDropTypeDesc(ctx, mutArrayDesc, n.DropBehavior); err != nil {
 			return nil, err
 		}
-		// Record these descriptors for deletion.
-		node.toDrop[typeDesc.ID] = typeDesc
+		// Record the array type for deletion as well.
 		node.toDrop[mutArrayDesc.ID] = mutArrayDesc
 	}
 	return node, nil
@@ -131,9 +149,32 @@ func (p *planner) canDropTypeDesc(
 			dependentNames,
 		)
 	}
+	if len(desc.ReferencingFunctionIDs) > 0 {
+		return p.dependentFunctionError(ctx, "type", desc.Name, desc.ReferencingFunctionIDs[0], "drop")
+	}
+	if len(desc.ReferencingTypeIDs) > 0 {
+		return p.dependentTypeError(ctx, desc.Name, desc.ReferencingTypeIDs[0], "drop")
+	}
 	return nil
 }
 
+// dependentTypeError returns an error stating that the given type cannot be
+// modified by the given operation because the composite type with the given
+// ID has fields of that type.
+func (p *planner) dependentTypeError(
+	ctx context.Context, typeName string, compositeID descpb.ID, op string,
+) error {
+	compositeDesc, err := p.Descriptors().GetImmutableTypeByID(ctx, p.txn, compositeID,
+		tree.ObjectLookupFlags{CommonLookupFlags: tree.CommonLookupFlags{Required: true}})
+	if err != nil {
+		return err
+	}
+	return errors.WithHintf(
+		sqlerrors.NewDependentObjectErrorf("cannot %s type %q because type %q depends on it",
+			op, typeName, compositeDesc.GetName()),
+		"you can drop type %q instead.", compositeDesc.GetName())
+}
+
 func (n *dropTypeNode) startExec(params runParams) error {
 	for _, typeDesc := range n.toDrop {
 		typeFQName, err := getTypeNameFromTypeDescriptor(
@@ -259,6 +300,34 @@ func (p *planner) dropTypeImpl(
 	// Actually mark the type as dropped.
 	typeDesc.SetDropped()
 
+	// Composite types are no longer dependents of the types of their fields.
+	if typeDesc.Kind == descpb.TypeDescriptor_COMPOSITE {
+		ids, err := compositeElementTypeIDs(typeDesc)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			mutDesc, err := p.Descriptors().GetMutableTypeVersionByID(ctx, p.txn, id)
+			if err != nil {
+				return err
+			}
+			// The types of the fields may be dropped along with the composite
+			// type, by DROP SCHEMA or DROP DATABASE CASCADE.
+			if mutDesc.Dropped() {
+				continue
+			}
+			mutDesc.RemoveReferencingTypeID(typeDesc.ID)
+			if queueJob {
+				err = p.writeTypeSchemaChange(ctx, mutDesc, jobDesc)
+			} else {
+				err = p.writeTypeDesc(ctx, mutDesc)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	// Delete namespace entry for type.
 	b := p.txn.NewBatch()
 	p.dropNamespaceEntry(ctx, b, typeDesc)
@@ -76,6 +76,18 @@ func (p *planner) DropView(ctx context.Context, n *tree.DropView) (planNode, err
 				return nil, err
 			}
 		}
+		if err := p.canRemoveDependentTriggers(
+			ctx, string(droppedDesc.DescriptorType()), droppedDesc.Name, droppedDesc, 0, /* colID */
+			n.DropBehavior, nil, /* skip */
+		); err != nil {
+			return nil, err
+		}
+		if err := p.canRemoveDependentFunctions(
+			ctx, string(droppedDesc.DescriptorType()), droppedDesc.Name, droppedDesc, 0, /* colID */
+			n.DropBehavior,
+		); err != nil {
+			return nil, err
+		}
 	}
 
 	if len(td) == 0 {
@@ -15,6 +15,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
 )
 
 // errorIfRowsNode wraps another planNode and returns an error if the wrapped
@@ -44,7 +45,18 @@ func (n *errorIfRowsNode) Next(params runParams) (bool, error) {
 		return false, err
 	}
 	if ok {
-		return false, n.mkErr(n.plan.Values())
+		err := n.mkErr(n.plan.Values())
+		var v *exec.DeferrableConstraintViolation
+		if errors.As(err, &v) {
+			// The constraint is deferrable. If it is deferred, it will be
+			// validated in full before the transaction commits. Internal planners
+			// don't track deferred constraints.
+			if dc := params.p.extendedEvalCtx.DeferredConstraints; dc != nil && dc.maybeDefer(v) {
+				return false, nil
+			}
+			return false, v.Err
+		}
+		return false, err
 	}
 	return false, nil
 }
@@ -71,6 +71,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/optionalnodeliveness"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
@@ -1287,6 +1288,10 @@ type ExecutorConfig struct {
 	// SystemTableIDResolver is used to obtain dynamic IDs for system tables.
 	SystemTableIDResolver catalog.SystemTableIDResolver
 
+	// NotificationWatcher delivers the notifications sent with NOTIFY to the
+	// sessions which run LISTEN.
+	NotificationWatcher *pgnotify.Watcher
+
 	// SpanConfigReconciler is used to drive the span config reconciliation job
 	// and related migrations.
 	SpanConfigReconciler spanconfig.Reconciler
@@ -1744,6 +1749,9 @@ func checkResultType(typ *types.T) error {
 	case types.OidFamily:
 	case types.TupleFamily:
 	case types.EnumFamily:
+	case types.RangeFamily:
+	case types.TSQueryFamily:
+	case types.TSVectorFamily:
 	case types.VoidFamily:
 	case types.ArrayFamily:
 		if typ.ArrayContents().Family() == types.ArrayFamily {
@@ -1813,6 +1821,11 @@ func (p *planner) isAsOf(ctx context.Context, stmt tree.Statement) (*tree.AsOfSy
 		asOf = s.AsOf
 	case *tree.Export:
 		return p.isAsOf(ctx, s.Query)
+	case *tree.CopyTo:
+		if s.Statement == nil {
+			return nil, nil
+		}
+		return p.isAsOf(ctx, s.Statement)
 	case *tree.CreateStats:
 		if s.Options.AsOf.Expr == nil {
 			return nil, nil
@@ -3185,6 +3198,10 @@ func (m *sessionDataMutator) SetLargeFullScanRows(val float64) {
 	m.data.LargeFullScanRows = val
 }
 
+func (m *sessionDataMutator) SetTrigramSimilarityThreshold(val float64) {
+	m.data.TrigramSimilarityThreshold = val
+}
+
 func (m *sessionDataMutator) SetInjectRetryErrorsEnabled(val bool) {
 	m.data.InjectRetryErrorsEnabled = val
 }
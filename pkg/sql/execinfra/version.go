@@ -64,11 +64,11 @@ import "github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 //
 // ATTENTION: When updating these fields, add a brief description of what
 // changed to the version history below.
-const Version execinfrapb.DistSQLVersion = 68
+const Version execinfrapb.DistSQLVersion = 69
 
 // MinAcceptedVersion is the oldest version that the server is compatible with.
 // A server will not accept flows with older versions.
-const MinAcceptedVersion execinfrapb.DistSQLVersion = 68
+const MinAcceptedVersion execinfrapb.DistSQLVersion = 69
 
 /*
 
@@ -76,6 +76,13 @@ const MinAcceptedVersion execinfrapb.DistSQLVersion = 68
 
 Please add new entries at the top.
 
+- Version: 69 (MinAcceptedVersion: 69)
+  - The key encoding of tuples now wraps the encoded fields of the tuple in a
+    single bytes value, so that tuples can be decoded and composite types can
+    be used in indexes. Processors that exchange key-encoded datums, such as
+    hash routers and distinct processors, would not agree on the encoding of
+    tuples with older servers, hence the MinAcceptedVersion bump.
+
 - Version: 68 (MinAcceptedVersion: 68)
   - ZigzagJoinerSpec now uses descpb.IndexFetchSpec instead of table and
     index descriptors.
@@ -193,21 +193,22 @@ func (ex *connExecutor) recordStatementSummary(
 	}
 
 	recordedStmtStats := sqlstats.RecordedStmtStats{
-		AutoRetryCount:  automaticRetryCount,
-		RowsAffected:    rowsAffected,
-		ParseLatency:    parseLat,
-		PlanLatency:     planLat,
-		RunLatency:      runLat,
-		ServiceLatency:  svcLat,
-		OverheadLatency: execOverhead,
-		BytesRead:       stats.bytesRead,
-		RowsRead:        stats.rowsRead,
-		RowsWritten:     stats.rowsWritten,
-		Nodes:           getNodesFromPlanner(planner),
-		StatementType:   stmt.AST.StatementType(),
-		Plan:            planner.instrumentation.PlanForStats(ctx),
-		PlanGist:        planner.instrumentation.planGist.String(),
-		StatementError:  stmtErr,
+		AutoRetryCount:       automaticRetryCount,
+		RowsAffected:         rowsAffected,
+		ParseLatency:         parseLat,
+		PlanLatency:          planLat,
+		RunLatency:           runLat,
+		ServiceLatency:       svcLat,
+		OverheadLatency:      execOverhead,
+		BytesRead:            stats.bytesRead,
+		RowsRead:             stats.rowsRead,
+		RowsWritten:          stats.rowsWritten,
+		Nodes:                getNodesFromPlanner(planner),
+		StatementType:        stmt.AST.StatementType(),
+		Plan:                 planner.instrumentation.PlanForStats(ctx),
+		PlanGist:             planner.instrumentation.planGist.String(),
+		IndexRecommendations: planner.instrumentation.workloadIndexRecommendations,
+		StatementError:       stmtErr,
 	}
 
 	stmtFingerprintID, err :=
@@ -0,0 +1,533 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/optbuilder"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+func (p *planner) writeFunctionDesc(ctx context.Context, desc *funcdesc.Mutable) error {
+	b := p.txn.NewBatch()
+	if err := p.Descriptors().WriteDescToBatch(
+		ctx, p.extendedEvalCtx.Tracing.KVTracingEnabled(), desc, b,
+	); err != nil {
+		return err
+	}
+	return p.txn.Run(ctx, b)
+}
+
+// writeFunctionDescChange writes a modified function descriptor and queues a
+// schema change job which waits for the leases on the old versions of the
+// descriptor to be released, and deletes the descriptor if it was dropped.
+func (p *planner) writeFunctionDescChange(
+	ctx context.Context, desc *funcdesc.Mutable, jobDesc string,
+) error {
+	record, recordExists := p.extendedEvalCtx.SchemaChangeJobRecords[desc.ID]
+	if recordExists {
+		// Update it.
+		record.AppendDescription(jobDesc)
+		log.Infof(ctx, "job %d: updated job's specification for change on function %d", record.JobID, desc.ID)
+	} else {
+		// Or, create a new job.
+		jobRecord := jobs.Record{
+			JobID:         p.extendedEvalCtx.ExecCfg.JobRegistry.MakeJobID(),
+			Description:   jobDesc,
+			Username:      p.User(),
+			DescriptorIDs: descpb.IDs{desc.ID},
+			Details: jobspb.SchemaChangeDetails{
+				DescID: desc.ID,
+				// The version distinction for database jobs doesn't matter for
+				// function jobs.
+				FormatVersion: jobspb.DatabaseJobFormatVersion,
+			},
+			Progress:      jobspb.SchemaChangeProgress{},
+			NonCancelable: true,
+		}
+		p.extendedEvalCtx.SchemaChangeJobRecords[desc.ID] = &jobRecord
+		log.Infof(ctx, "queued new schema change job %d for function %d", jobRecord.JobID, desc.ID)
+	}
+
+	return p.writeFunctionDesc(ctx, desc)
+}
+
+// getMutableFunctionDescriptor returns the function identified by the given
+// FuncObj. If the FuncObj does not specify parameter types, the name must
+// identify a single overload. If required is false, nil is returned if the
+// function does not exist.
+func (p *planner) getMutableFunctionDescriptor(
+	ctx context.Context, fo *tree.FuncObj, required bool,
+) (*funcdesc.Mutable, error) {
+	var dbName, scName string
+	if fo.Name.NumParts > 1 {
+		scName = fo.Name.Parts[1]
+	}
+	if fo.Name.NumParts > 2 {
+		dbName = fo.Name.Parts[2]
+	}
+	_, fns, err := p.getFunctionOverloadsByName(
+		ctx, dbName, scName, fo.Name.Object(), p.CurrentSearchPath(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var found catalog.FunctionDescriptor
+	if fo.HasParams {
+		paramTypes, err := p.resolveFuncParamTypes(ctx, fo.Params)
+		if err != nil {
+			return nil, err
+		}
+		for _, fn := range fns {
+			if funcParamTypesIdentical(fn.GetParamTypes(), paramTypes) {
+				found = fn
+				break
+			}
+		}
+	} else if len(fns) > 1 {
+		return nil, errors.WithHint(
+			pgerror.Newf(pgcode.AmbiguousFunction,
+				"function name %q is not unique", fo.Name.Object()),
+			"Specify the argument list to select the function unambiguously.",
+		)
+	} else if len(fns) == 1 {
+		found = fns[0]
+	}
+	if found == nil {
+		if !required {
+			return nil, nil
+		}
+		return nil, pgerror.Newf(pgcode.UndefinedFunction,
+			"function %s does not exist", tree.AsString(fo))
+	}
+	return p.Descriptors().GetMutableFunctionByID(ctx, p.txn, found.GetID(),
+		tree.ObjectLookupFlags{CommonLookupFlags: tree.CommonLookupFlags{Required: true}})
+}
+
+// getMutableFunctionVersionByID returns the mutable descriptor of the function
+// with the given ID, even if it is offline or being dropped.
+func (p *planner) getMutableFunctionVersionByID(
+	ctx context.Context, id descpb.ID,
+) (*funcdesc.Mutable, error) {
+	return p.Descriptors().GetMutableFunctionByID(ctx, p.txn, id, tree.ObjectLookupFlags{
+		CommonLookupFlags: tree.CommonLookupFlags{
+			Required:       true,
+			IncludeOffline: true,
+			IncludeDropped: true,
+		},
+	})
+}
+
+// canModifyFunction checks that the current user can modify or drop the
+// given function: only admins and the owner of the function can.
+func (p *planner) canModifyFunction(ctx context.Context, desc *funcdesc.Mutable) error {
+	hasAdmin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return err
+	}
+	if hasAdmin {
+		return nil
+	}
+
+	hasOwnership, err := p.HasOwnership(ctx, desc)
+	if err != nil {
+		return err
+	}
+	if !hasOwnership {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of function %s", tree.Name(desc.GetName()))
+	}
+	return nil
+}
+
+// getMutableFunctionSchema returns the mutable descriptor of the schema which
+// contains the given function.
+func (p *planner) getMutableFunctionSchema(
+	ctx context.Context, desc catalog.FunctionDescriptor,
+) (*schemadesc.Mutable, error) {
+	sc, err := p.Descriptors().GetMutableDescriptorByID(ctx, p.txn, desc.GetParentSchemaID())
+	if err != nil {
+		return nil, err
+	}
+	scDesc, ok := sc.(*schemadesc.Mutable)
+	if !ok {
+		return nil, errors.AssertionFailedf(
+			"parent schema %d of function %d is a %T", desc.GetParentSchemaID(), desc.GetID(), sc)
+	}
+	return scDesc, nil
+}
+
+// errFunctionInSyntheticSchema is returned when a function would be added to
+// a schema without a descriptor, such as the public schema of a database
+// created before public schemas had descriptors.
+func errFunctionInSyntheticSchema(scName string) error {
+	return unimplemented.NewWithIssueDetailf(17511, "udf synthetic schema",
+		"cannot create user-defined functions in schema %q", scName)
+}
+
+// resolveFuncParamTypes resolves the types of the given function parameters.
+func (p *planner) resolveFuncParamTypes(
+	ctx context.Context, params tree.FuncParams,
+) ([]*types.T, error) {
+	ret := make([]*types.T, len(params))
+	for i := range params {
+		typ, err := p.resolveFuncType(ctx, params[i].Type)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = typ
+	}
+	return ret, nil
+}
+
+// resolveFuncType resolves a type in the signature of a user-defined
+// function.
+func (p *planner) resolveFuncType(
+	ctx context.Context, ref tree.ResolvableTypeReference,
+) (*types.T, error) {
+	typ, err := tree.ResolveType(ctx, ref, p.semaCtx.GetTypeResolver())
+	if err != nil {
+		return nil, err
+	}
+	if typ.UserDefined() {
+		return nil, unimplemented.NewWithIssueDetail(17511, "udf user-defined type",
+			"user-defined types are not supported in the signatures of user-defined functions")
+	}
+	return typ, nil
+}
+
+// funcParamTypesIdentical returns true if the two lists of parameter types are
+// identical.
+func funcParamTypesIdentical(a, b []*types.T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Identical(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// setFunctionOptions applies the volatility, leakproof and null input behavior
+// options of a CREATE FUNCTION or ALTER FUNCTION statement to a function
+// descriptor. The LANGUAGE and AS options, which can only be specified by
+// CREATE FUNCTION, are returned to the caller.
+func setFunctionOptions(
+	desc *descpb.FunctionDescriptor, opts tree.FunctionOptions,
+) (lang tree.FunctionLanguage, body tree.FunctionBodyStr, _ error) {
+	var seenVolatility, seenLeakproof, seenNullInput, seenLang, seenBody bool
+	checkSeen := func(seen *bool) error {
+		if *seen {
+			return pgerror.New(pgcode.Syntax, "conflicting or redundant options")
+		}
+		*seen = true
+		return nil
+	}
+	for _, opt := range opts {
+		var err error
+		switch t := opt.(type) {
+		case tree.FunctionVolatility:
+			if err = checkSeen(&seenVolatility); err == nil {
+				switch t {
+				case tree.FunctionImmutable:
+					desc.Volatility = descpb.FunctionDescriptor_IMMUTABLE
+				case tree.FunctionStable:
+					desc.Volatility = descpb.FunctionDescriptor_STABLE
+				default:
+					desc.Volatility = descpb.FunctionDescriptor_VOLATILE
+				}
+			}
+		case tree.FunctionLeakproof:
+			if err = checkSeen(&seenLeakproof); err == nil {
+				desc.LeakProof = bool(t)
+			}
+		case tree.FunctionNullInputBehavior:
+			if err = checkSeen(&seenNullInput); err == nil {
+				if t == tree.FunctionCalledOnNullInput {
+					desc.NullInputBehavior = descpb.FunctionDescriptor_CALLED_ON_NULL_INPUT
+				} else {
+					desc.NullInputBehavior = descpb.FunctionDescriptor_RETURNS_NULL_ON_NULL_INPUT
+				}
+			}
+		case tree.FunctionLanguage:
+			if err = checkSeen(&seenLang); err == nil {
+				lang = t
+			}
+		case tree.FunctionBodyStr:
+			if err = checkSeen(&seenBody); err == nil {
+				body = t
+			}
+		default:
+			err = errors.AssertionFailedf("unknown function option %T", opt)
+		}
+		if err != nil {
+			return "", "", err
+		}
+	}
+	if desc.LeakProof && desc.Volatility != descpb.FunctionDescriptor_IMMUTABLE {
+		return "", "", pgerror.New(pgcode.InvalidFunctionDefinition,
+			"only immutable functions can be leakproof")
+	}
+	return lang, body, nil
+}
+
+// makeCreateFunction converts a function descriptor back into a CREATE
+// FUNCTION statement.
+func makeCreateFunction(
+	fn catalog.FunctionDescriptor, name *tree.UnresolvedObjectName,
+) *tree.CreateFunction {
+	params := fn.GetParams()
+	n := &tree.CreateFunction{
+		Name:       name,
+		Params:     make(tree.FuncParams, len(params)),
+		ReturnType: fn.GetReturnType(),
+	}
+	for i := range params {
+		n.Params[i] = tree.FuncParam{Name: tree.Name(params[i].Name), Type: params[i].Type}
+	}
+	switch fn.GetVolatility() {
+	case descpb.FunctionDescriptor_IMMUTABLE:
+		n.Options = append(n.Options, tree.FunctionImmutable)
+	case descpb.FunctionDescriptor_STABLE:
+		n.Options = append(n.Options, tree.FunctionStable)
+	default:
+		n.Options = append(n.Options, tree.FunctionVolatile)
+	}
+	n.Options = append(n.Options, tree.FunctionLeakproof(fn.GetLeakProof()))
+	if fn.GetNullInputBehavior() == descpb.FunctionDescriptor_CALLED_ON_NULL_INPUT {
+		n.Options = append(n.Options, tree.FunctionCalledOnNullInput)
+	} else {
+		n.Options = append(n.Options, tree.FunctionReturnsNullOnNullInput)
+	}
+	n.Options = append(n.Options,
+		tree.FunctionLangSQL,
+		tree.FunctionBodyStr(fn.GetFunctionBody()),
+	)
+	return n
+}
+
+// bodyDependencies are the objects which the body of a user-defined function
+// or of a trigger depends on.
+type bodyDependencies struct {
+	// relations maps the IDs of the referenced relations to the IDs of their
+	// referenced columns.
+	relations map[descpb.ID]catalog.TableColSet
+	types     catalog.DescriptorIDSet
+	functions catalog.DescriptorIDSet
+}
+
+// buildBodyDependencies builds the body of a user-defined function or of a
+// trigger with the given function, which checks the body semantically, and
+// returns the objects which the body depends on.
+func (p *planner) buildBodyDependencies(
+	ctx context.Context,
+	build func(oc cat.Catalog, b *optbuilder.Builder) (
+		opt.ViewDeps, opt.ViewTypeDeps, opt.ViewFuncDeps, error,
+	),
+) (bodyDependencies, error) {
+	var oc optCatalog
+	oc.init(p)
+	oc.reset()
+	var f norm.Factory
+	f.Init(p.EvalContext(), &oc)
+	b := optbuilder.New(ctx, &p.semaCtx, p.EvalContext(), &oc, &f, nil /* stmt */)
+	deps, typeDeps, funcDeps, err := build(&oc, b)
+	if err != nil {
+		return bodyDependencies{}, err
+	}
+
+	res := bodyDependencies{relations: make(map[descpb.ID]catalog.TableColSet, len(deps))}
+	for _, d := range deps {
+		desc, err := getDescForDataSource(d.DataSource)
+		if err != nil {
+			return bodyDependencies{}, err
+		}
+		cols := res.relations[desc.GetID()]
+		d.ColumnOrdinals.ForEach(func(ord int) {
+			cols.Add(desc.AllColumns()[ord].GetID())
+		})
+		res.relations[desc.GetID()] = cols
+	}
+	typeDeps.ForEach(func(id int) {
+		res.types.Add(descpb.ID(id))
+	})
+	var idErr error
+	funcDeps.ForEach(func(o int) {
+		id, err := funcdesc.UserDefinedFunctionOIDToID(oid.Oid(o))
+		if err != nil {
+			idErr = err
+		}
+		res.functions.Add(id)
+	})
+	return res, idErr
+}
+
+// relationIDs returns the sorted IDs of the relations in deps, except for
+// the given one.
+func (deps *bodyDependencies) relationIDs(except descpb.ID) []descpb.ID {
+	var ids catalog.DescriptorIDSet
+	for id := range deps.relations {
+		if id != except {
+			ids.Add(id)
+		}
+	}
+	return ids.Ordered()
+}
+
+// addFunctionBackReferences adds the back-references from the relations,
+// types and functions which the body of the given function depends on.
+func (p *planner) addFunctionBackReferences(
+	ctx context.Context, desc *funcdesc.Mutable, deps bodyDependencies,
+) error {
+	for _, id := range desc.DependsOn {
+		backRefMutable, err := p.Descriptors().GetMutableTableVersionByID(ctx, id, p.txn)
+		if err != nil {
+			return err
+		}
+		backRefMutable.DependedOnByFunctions = append(backRefMutable.DependedOnByFunctions,
+			descpb.TableDescriptor_FunctionReference{
+				FunctionID: desc.ID,
+				ColumnIDs:  deps.relations[id].Ordered(),
+			})
+		if err := p.writeSchemaChange(
+			ctx, backRefMutable, descpb.InvalidMutationID,
+			fmt.Sprintf("updating function reference %s(%d) in relation %s(%d)",
+				desc.Name, desc.ID, backRefMutable.Name, backRefMutable.ID),
+		); err != nil {
+			return err
+		}
+	}
+	for _, id := range desc.DependsOnTypes {
+		typDesc, err := p.Descriptors().GetMutableTypeVersionByID(ctx, p.txn, id)
+		if err != nil {
+			return err
+		}
+		if err := p.CheckPrivilege(ctx, typDesc, privilege.USAGE); err != nil {
+			return err
+		}
+		typDesc.AddReferencingFunctionID(desc.ID)
+		if err := p.writeTypeSchemaChange(ctx, typDesc, fmt.Sprintf(
+			"updating function reference %s(%d) in type %s(%d)",
+			desc.Name, desc.ID, typDesc.Name, typDesc.ID),
+		); err != nil {
+			return err
+		}
+	}
+	for _, id := range desc.DependsOnFunctions {
+		fnDesc, err := p.getMutableFunctionVersionByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		fnDesc.DependedOnBy = append(fnDesc.DependedOnBy, descpb.FunctionDescriptor_Reference{ID: desc.ID})
+		if err := p.writeFunctionDescChange(ctx, fnDesc, fmt.Sprintf(
+			"updating function reference %s(%d) in function %s(%d)",
+			desc.Name, desc.ID, fnDesc.Name, fnDesc.ID),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeFunctionBackReferences removes the back-references to the given
+// function from the relations, types and functions which its body depends on.
+// Dependencies which are also being dropped are left untouched.
+func (p *planner) removeFunctionBackReferences(ctx context.Context, desc *funcdesc.Mutable) error {
+	jobDesc := fmt.Sprintf("removing references for function %s(%d)", desc.Name, desc.ID)
+	for _, id := range desc.DependsOn {
+		dependencyDesc, err := p.Descriptors().GetMutableTableVersionByID(ctx, id, p.txn)
+		if err != nil {
+			return errors.Wrapf(err, "error resolving dependency relation ID %d", id)
+		}
+		if dependencyDesc.Dropped() {
+			continue
+		}
+		refs := dependencyDesc.DependedOnByFunctions[:0]
+		for _, ref := range dependencyDesc.DependedOnByFunctions {
+			if ref.FunctionID != desc.ID {
+				refs = append(refs, ref)
+			}
+		}
+		if len(refs) == len(dependencyDesc.DependedOnByFunctions) {
+			continue
+		}
+		dependencyDesc.DependedOnByFunctions = refs
+		if err := p.writeSchemaChange(
+			ctx, dependencyDesc, descpb.InvalidMutationID, jobDesc,
+		); err != nil {
+			return err
+		}
+	}
+	for _, id := range desc.DependsOnTypes {
+		typDesc, err := p.Descriptors().GetMutableTypeVersionByID(ctx, p.txn, id)
+		if err != nil {
+			return err
+		}
+		if typDesc.Dropped() {
+			continue
+		}
+		typDesc.RemoveReferencingFunctionID(desc.ID)
+		if err := p.writeTypeSchemaChange(ctx, typDesc, jobDesc); err != nil {
+			return err
+		}
+	}
+	for _, id := range desc.DependsOnFunctions {
+		fnDesc, err := p.getMutableFunctionVersionByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if fnDesc.Dropped() {
+			continue
+		}
+		fnDesc.DependedOnBy = removeMatchingFunctionReferences(fnDesc.DependedOnBy, desc.ID, "")
+		if err := p.writeFunctionDescChange(ctx, fnDesc, jobDesc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeMatchingFunctionReferences removes the references from the given
+// function, or from the given trigger on the given table, from a slice of
+// function references.
+func removeMatchingFunctionReferences(
+	refs []descpb.FunctionDescriptor_Reference, id descpb.ID, triggerName string,
+) []descpb.FunctionDescriptor_Reference {
+	updatedRefs := refs[:0]
+	for _, ref := range refs {
+		if ref.ID != id || ref.TriggerName != triggerName {
+			updatedRefs = append(updatedRefs, ref)
+		}
+	}
+	return updatedRefs
+}
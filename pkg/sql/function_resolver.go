@@ -0,0 +1,296 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+var _ tree.FunctionReferenceResolver = &planner{}
+
+// ResolveFunction implements the tree.FunctionReferenceResolver interface.
+// The builtin function with the given name, if any, is merged with the
+// overloads of the user-defined function with that name. An unqualified name
+// refers to the user-defined function in the first schema of the search path
+// which contains a function with that name.
+func (p *planner) ResolveFunction(
+	ctx context.Context, name *tree.UnresolvedName, path sessiondata.SearchPath,
+) (*tree.FunctionDefinition, error) {
+	builtin, builtinErr := name.ResolveFunction(path)
+	if builtinErr != nil && pgerror.GetPGCode(builtinErr) != pgcode.UndefinedFunction {
+		return nil, builtinErr
+	}
+	if builtin != nil && builtin.Class != tree.NormalClass {
+		// Aggregate, window and generator functions cannot be overloaded by
+		// user-defined functions.
+		return builtin, nil
+	}
+
+	dbName, scName := "", ""
+	if name.NumParts > 1 {
+		scName = name.Parts[1]
+	}
+	if name.NumParts > 2 {
+		dbName = name.Parts[2]
+	}
+	_, fns, err := p.getFunctionOverloadsByName(ctx, dbName, scName, name.Parts[0], path)
+	if err != nil {
+		return nil, err
+	}
+	if len(fns) == 0 {
+		return builtin, builtinErr
+	}
+	overloads := make([]*tree.Overload, len(fns))
+	for i, fn := range fns {
+		if overloads[i], err = makeUDFOverload(fn); err != nil {
+			return nil, err
+		}
+	}
+	return tree.MergeUDFOverloads(name.String(), builtin, overloads), nil
+}
+
+// ResolveFunctionByOID looks up the overload of a user-defined function by its
+// OID and checks that the current user may execute it.
+func (p *planner) ResolveFunctionByOID(ctx context.Context, oid oid.Oid) (*tree.Overload, error) {
+	id, err := funcdesc.UserDefinedFunctionOIDToID(oid)
+	if err != nil {
+		return nil, pgerror.Wrapf(err, pgcode.UndefinedFunction, "function %d does not exist", oid)
+	}
+	fn, err := p.Descriptors().GetImmutableFunctionByID(ctx, p.txn, id, tree.ObjectLookupFlags{
+		CommonLookupFlags: tree.CommonLookupFlags{
+			Required:       true,
+			AvoidLeased:    p.avoidLeasedDescriptors,
+			IncludeDropped: true,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fn.Dropped() {
+		return nil, pgerror.Newf(pgcode.UndefinedFunction, "function %q does not exist", fn.GetName())
+	}
+	if err := p.CheckPrivilege(ctx, fn, privilege.EXECUTE); err != nil {
+		return nil, err
+	}
+	return makeUDFOverload(fn)
+}
+
+// getFunctionOverloadsByName returns the descriptors of the overloads of the
+// user-defined function with the given name, along with the schema that
+// contains them. If dbName is empty, the current database is used. If scName
+// is empty, the schemas of the search path are searched in order and the
+// overloads in the first schema which contains a function with the given name
+// are returned. No overloads are returned if the function does not exist.
+func (p *planner) getFunctionOverloadsByName(
+	ctx context.Context, dbName, scName, fnName string, path sessiondata.SearchPath,
+) (catalog.SchemaDescriptor, []catalog.FunctionDescriptor, error) {
+	if dbName == "" {
+		dbName = p.CurrentDatabase()
+		if dbName == "" {
+			return nil, nil, nil
+		}
+	}
+	db, err := p.Descriptors().GetImmutableDatabaseByName(ctx, p.txn, dbName,
+		tree.DatabaseLookupFlags{AvoidLeased: p.avoidLeasedDescriptors})
+	if err != nil || db == nil {
+		return nil, nil, err
+	}
+
+	lookupSchema := func(scName string) (catalog.SchemaDescriptor, []catalog.FunctionDescriptor, error) {
+		sc, err := p.Descriptors().GetImmutableSchemaByName(
+			ctx, p.txn, db, scName, p.CommonLookupFlags(false /* required */),
+		)
+		if err != nil || sc == nil {
+			return nil, nil, err
+		}
+		fn, ok := sc.GetFunction(fnName)
+		if !ok {
+			return nil, nil, nil
+		}
+		if err := p.canResolveDescUnderSchema(ctx, sc, nil /* desc */); err != nil {
+			return nil, nil, err
+		}
+		fns := make([]catalog.FunctionDescriptor, 0, len(fn.Overloads))
+		for _, ol := range fn.Overloads {
+			desc, err := p.Descriptors().GetImmutableFunctionByID(ctx, p.txn, ol.ID,
+				tree.ObjectLookupFlags{CommonLookupFlags: p.CommonLookupFlags(true /* required */)})
+			if err != nil {
+				return nil, nil, err
+			}
+			fns = append(fns, desc)
+		}
+		return sc, fns, nil
+	}
+
+	if scName != "" {
+		return lookupSchema(scName)
+	}
+	iter := path.Iter()
+	for scName, ok := iter.Next(); ok; scName, ok = iter.Next() {
+		sc, fns, err := lookupSchema(scName)
+		if err != nil || len(fns) > 0 {
+			return sc, fns, err
+		}
+	}
+	return nil, nil, nil
+}
+
+// maxUDFNestingDepth is the maximum number of calls to user-defined functions
+// which can be evaluated within each other. Recursive functions are rejected
+// when they are created, but a deep chain of distinct functions could still
+// exhaust the resources of the node.
+const maxUDFNestingDepth = 32
+
+// udfNestingDepthKey is the context key of the number of calls to user-defined
+// functions in which the current statement is evaluated.
+type udfNestingDepthKey struct{}
+
+// makeUDFOverload converts the descriptor of a user-defined function into an
+// overload which can be type checked along with the overloads of builtins.
+// Calls to the function which are not inlined by the optimizer are evaluated
+// by running the body of the function with the internal executor.
+func makeUDFOverload(fn catalog.FunctionDescriptor) (*tree.Overload, error) {
+	body, err := parseUDFBody(fn)
+	if err != nil {
+		return nil, err
+	}
+	params := fn.GetParams()
+	argTypes := make(tree.ArgTypes, len(params))
+	for i := range params {
+		argTypes[i].Name = params[i].Name
+		argTypes[i].Typ = params[i].Type
+	}
+
+	retType := fn.GetReturnType()
+	execSQL, err := makeUDFExecSQL(body, params, retType)
+	if err != nil {
+		return nil, err
+	}
+	calledOnNullInput :=
+		fn.GetNullInputBehavior() == descpb.FunctionDescriptor_CALLED_ON_NULL_INPUT
+
+	return &tree.Overload{
+		Types:      argTypes,
+		ReturnType: tree.FixedReturnType(retType),
+		Volatility: funcdesc.ToTreeVolatility(fn.GetVolatility(), fn.GetLeakProof()),
+		Fn: func(evalCtx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+			qargs := make([]interface{}, len(args))
+			for i, arg := range args {
+				if arg == tree.DNull && !calledOnNullInput {
+					return tree.DNull, nil
+				}
+				qargs[i] = arg
+			}
+			ctx := evalCtx.Ctx()
+			depth, _ := ctx.Value(udfNestingDepthKey{}).(int)
+			if depth >= maxUDFNestingDepth {
+				return nil, pgerror.Newf(pgcode.ProgramLimitExceeded,
+					"user-defined function nesting limit (%d) reached", maxUDFNestingDepth)
+			}
+			row, err := evalCtx.Planner.QueryRowEx(
+				context.WithValue(ctx, udfNestingDepthKey{}, depth+1), "udf", evalCtx.Txn,
+				sessiondata.NoSessionDataOverride, execSQL, qargs...,
+			)
+			if err != nil {
+				return nil, err
+			}
+			if row == nil {
+				return tree.DNull, nil
+			}
+			return row[0], nil
+		},
+		// The body of the function is evaluated with the internal executor of
+		// the gateway, so the function cannot be distributed.
+		DistsqlBlocklist:  true,
+		Oid:               funcdesc.FuncIDToOID(fn.GetID()),
+		IsUDF:             true,
+		Body:              tree.AsStringWithFlags(body, tree.FmtParsable),
+		CalledOnNullInput: calledOnNullInput,
+		UDFVersion:        uint64(fn.GetVersion()),
+	}, nil
+}
+
+// makeUDFExecSQL returns the query which is run by the internal executor to
+// evaluate the body of a user-defined function. The arguments of the call are
+// passed as placeholders.
+func makeUDFExecSQL(
+	body tree.Statement, params []descpb.FunctionDescriptor_Parameter, retType *types.T,
+) (string, error) {
+	// The placeholders in the body are typed explicitly, since the type of a
+	// NULL argument cannot be inferred from the argument itself.
+	execBody, err := tree.SimpleStmtVisit(body, func(expr tree.Expr) (bool, tree.Expr, error) {
+		if ph, ok := expr.(*tree.Placeholder); ok {
+			return false, &tree.CastExpr{
+				Expr:       ph,
+				Type:       params[ph.Idx].Type,
+				SyntaxMode: tree.CastShort,
+			}, nil
+		}
+		return true, expr, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("SELECT (%s)::%s",
+		tree.AsStringWithFlags(execBody, tree.FmtParsable), retType.SQLString()), nil
+}
+
+// parseUDFBody parses the body of a user-defined function and replaces the
+// references to its named parameters with placeholders.
+func parseUDFBody(fn catalog.FunctionDescriptor) (tree.Statement, error) {
+	stmt, err := parser.ParseOne(fn.GetFunctionBody())
+	if err != nil {
+		return nil, errors.NewAssertionErrorWithWrappedErrf(err,
+			"failed to parse body of function %q (%d)", fn.GetName(), fn.GetID())
+	}
+	return replaceUDFParams(stmt.AST, fn.GetParams())
+}
+
+// replaceUDFParams replaces the references to the named parameters of a
+// user-defined function in the given statement with placeholders, and checks
+// that all placeholders in the statement refer to parameters.
+func replaceUDFParams(
+	stmt tree.Statement, params []descpb.FunctionDescriptor_Parameter,
+) (tree.Statement, error) {
+	return tree.SimpleStmtVisit(stmt, func(expr tree.Expr) (bool, tree.Expr, error) {
+		switch t := expr.(type) {
+		case *tree.UnresolvedName:
+			if t.NumParts != 1 || t.Star {
+				return true, expr, nil
+			}
+			for i := range params {
+				if params[i].Name != "" && params[i].Name == t.Parts[0] {
+					return false, &tree.Placeholder{Idx: tree.PlaceholderIdx(i)}, nil
+				}
+			}
+		case *tree.Placeholder:
+			if int(t.Idx) >= len(params) {
+				return false, nil, pgerror.Newf(pgcode.UndefinedParameter,
+					"there is no parameter %s", t)
+			}
+		}
+		return true, expr, nil
+	})
+}
@@ -141,6 +141,66 @@ func newParquetExporter(sp execinfrapb.ExportSpec, typs []*types.T) (*parquetExp
 	return exporter, nil
 }
 
+// ParquetWriter writes rows of datums to a parquet file buffered in memory. It
+// allows other users of parquet files, like changefeeds, to share the column
+// encodings of EXPORT PARQUET.
+type ParquetWriter struct {
+	exporter *parquetExporter
+	row      map[string]interface{}
+}
+
+// NewParquetWriter returns a ParquetWriter for rows with the given column names
+// and types. All the columns of the file are nullable.
+func NewParquetWriter(
+	colNames []string, typs []*types.T, compression roachpb.IOFileFormat_Compression,
+) (*ParquetWriter, error) {
+	sp := execinfrapb.ExportSpec{ColNames: colNames}
+	sp.Format.Compression = compression
+	sp.Format.Parquet.ColNullability = make([]bool, len(typs))
+	for i := range sp.Format.Parquet.ColNullability {
+		sp.Format.Parquet.ColNullability[i] = true
+	}
+	exporter, err := newParquetExporter(sp, typs)
+	if err != nil {
+		return nil, err
+	}
+	exporter.ResetBuffer()
+	return &ParquetWriter{
+		exporter: exporter,
+		row:      make(map[string]interface{}, len(typs)),
+	}, nil
+}
+
+// AddRow appends a row to the parquet file. The datums must match the types
+// the ParquetWriter was created with.
+func (w *ParquetWriter) AddRow(datums tree.Datums) error {
+	for i, d := range datums {
+		col := &w.exporter.parquetColumns[i]
+		if d == tree.DNull {
+			w.row[col.name] = nil
+			continue
+		}
+		// If we're encoding a DOidWrapper, then we want to cast the wrapped datum.
+		v, err := col.encodeFn(tree.UnwrapDatum(nil, d))
+		if err != nil {
+			return err
+		}
+		w.row[col.name] = v
+	}
+	return w.exporter.Write(w.row)
+}
+
+// Close flushes all the rows of the file, after which Bytes returns its
+// contents.
+func (w *ParquetWriter) Close() error {
+	return w.exporter.Close()
+}
+
+// Bytes returns the contents of the parquet file.
+func (w *ParquetWriter) Bytes() []byte {
+	return w.exporter.Bytes()
+}
+
 // ParquetColumn contains the relevant data to map a crdb table column to a parquet table column.
 type ParquetColumn struct {
 	name     string
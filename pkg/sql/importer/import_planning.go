@@ -168,6 +168,8 @@ var avroAllowedOptions = makeStringSet(
 	avroRecordsSeparatedBy, avroSchema, avroSchemaURI, optMaxRowSize, csvRowLimit,
 )
 
+var parquetAllowedOptions = makeStringSet(avroStrict, csvRowLimit)
+
 var csvAllowedOptions = makeStringSet(
 	csvDelimiter, csvComment, csvNullIf, csvSkip, csvStrictQuotes, csvRowLimit,
 )
@@ -192,6 +194,7 @@ var importIntoRequiredPrivileges = []privilege.Kind{privilege.INSERT, privilege.
 var allowedIntoFormats = map[string]struct{}{
 	"CSV":       {},
 	"AVRO":      {},
+	"PARQUET":   {},
 	"DELIMITED": {},
 	"PGCOPY":    {},
 }
@@ -730,6 +733,13 @@ func importPlanHook(
 			if err != nil {
 				return err
 			}
+		case "PARQUET":
+			if err = validateFormatOptions(importStmt.FileFormat, opts, parquetAllowedOptions); err != nil {
+				return err
+			}
+			if err := parseParquetOptions(opts, &format); err != nil {
+				return err
+			}
 		default:
 			return unimplemented.Newf("import.format", "unsupported import format: %q", importStmt.FileFormat)
 		}
@@ -1088,6 +1098,23 @@ func parseAvroOptions(
 	return nil
 }
 
+func parseParquetOptions(opts map[string]string, format *roachpb.IOFileFormat) error {
+	format.Format = roachpb.IOFileFormat_Parquet
+	_, format.Parquet.StrictMode = opts[avroStrict]
+
+	if override, ok := opts[csvRowLimit]; ok {
+		rowLimit, err := strconv.Atoi(override)
+		if err != nil {
+			return pgerror.Wrapf(err, pgcode.Syntax, "invalid numeric %s value", csvRowLimit)
+		}
+		if rowLimit <= 0 {
+			return pgerror.Newf(pgcode.Syntax, "%s must be > 0", csvRowLimit)
+		}
+		format.Parquet.RowLimit = int64(rowLimit)
+	}
+	return nil
+}
+
 type loggerKind int
 
 const (
@@ -125,6 +125,7 @@ func MakeSimpleTableDescriptor(
 	for i := range create.Defs {
 		switch def := create.Defs[i].(type) {
 		case *tree.CheckConstraintTableDef,
+			*tree.ExclusionConstraintTableDef,
 			*tree.FamilyTableDef,
 			*tree.UniqueConstraintTableDef:
 			// ignore
@@ -0,0 +1,211 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	goparquet "github.com/fraugster/parquet-go"
+)
+
+// parquetConsumer implements importRowConsumer interface. It converts the rows
+// read from a parquet file, which map column names to the native go values
+// created by the parquet library, using the decoders of the parquet columns
+// written by EXPORT PARQUET.
+type parquetConsumer struct {
+	fieldNameToIdx map[string]int
+	decoders       []func(interface{}) (tree.Datum, error)
+	strict         bool
+}
+
+var _ importRowConsumer = &parquetConsumer{}
+
+// FillDatums implements importRowConsumer interface.
+func (p *parquetConsumer) FillDatums(
+	native interface{}, rowIndex int64, conv *row.DatumRowConverter,
+) error {
+	record, ok := native.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("unexpected native type; expected map[string]interface{} found %T instead", native)
+	}
+
+	for f, v := range record {
+		field := lexbase.NormalizeName(f)
+		idx, ok := p.fieldNameToIdx[field]
+		if !ok {
+			if p.strict {
+				return fmt.Errorf("could not find column for parquet column %s", field)
+			}
+			continue
+		}
+		datum, err := p.decoders[idx](v)
+		if err != nil {
+			return err
+		}
+		conv.Datums[idx] = datum
+	}
+
+	// Parquet files do not contain the values of NULL columns, so set any nil
+	// datums to DNull.
+	for i := range conv.Datums {
+		if conv.TargetColOrds.Contains(i) && conv.Datums[i] == nil {
+			conv.Datums[i] = tree.DNull
+		}
+	}
+	return nil
+}
+
+// parquetStream implements importRowProducer interface over the rows of a
+// parquet file.
+type parquetStream struct {
+	reader *goparquet.FileReader
+	read   int64
+	row    map[string]interface{}
+	err    error
+}
+
+var _ importRowProducer = &parquetStream{}
+
+// Progress implements importRowProducer interface.
+func (p *parquetStream) Progress() float32 {
+	if n := p.reader.NumRows(); n > 0 {
+		return float32(p.read) / float32(n)
+	}
+	return 0
+}
+
+// Scan implements importRowProducer interface.
+func (p *parquetStream) Scan() bool {
+	if p.row != nil {
+		panic("must call Row() or Skip() before calling Scan()")
+	}
+	p.row, p.err = p.reader.NextRow()
+	if p.err == io.EOF {
+		p.row, p.err = nil, nil
+		return false
+	}
+	p.read++
+	return p.err == nil
+}
+
+// Err implements importRowProducer interface.
+func (p *parquetStream) Err() error {
+	return p.err
+}
+
+// Skip implements importRowProducer interface.
+func (p *parquetStream) Skip() error {
+	p.row = nil
+	return nil
+}
+
+// Row implements importRowProducer interface.
+func (p *parquetStream) Row() (interface{}, error) {
+	res := p.row
+	p.row = nil
+	return res, nil
+}
+
+type parquetInputReader struct {
+	importContext *parallelImportContext
+	opts          roachpb.ParquetOptions
+}
+
+var _ inputConverter = &parquetInputReader{}
+
+func newParquetInputReader(
+	semaCtx *tree.SemaContext,
+	kvCh chan row.KVBatch,
+	tableDesc catalog.TableDescriptor,
+	parquetOpts roachpb.ParquetOptions,
+	walltime int64,
+	parallelism int,
+	evalCtx *tree.EvalContext,
+) *parquetInputReader {
+	return &parquetInputReader{
+		importContext: &parallelImportContext{
+			semaCtx:    semaCtx,
+			walltime:   walltime,
+			numWorkers: parallelism,
+			evalCtx:    evalCtx,
+			tableDesc:  tableDesc,
+			kvCh:       kvCh,
+		},
+		opts: parquetOpts,
+	}
+}
+
+func (p *parquetInputReader) start(group ctxgroup.Group) {}
+
+func (p *parquetInputReader) readFiles(
+	ctx context.Context,
+	dataFiles map[int32]string,
+	resumePos map[int32]int64,
+	format roachpb.IOFileFormat,
+	makeExternalStorage cloud.ExternalStorageFactory,
+	user security.SQLUsername,
+) error {
+	return readInputFiles(ctx, dataFiles, resumePos, format, p.readFile, makeExternalStorage, user)
+}
+
+func (p *parquetInputReader) readFile(
+	ctx context.Context, input *fileReader, inputIdx int32, resumePos int64, rejected chan string,
+) error {
+	// The metadata of a parquet file is stored at its end, so the file cannot
+	// be read as a stream.
+	data, err := ioutil.ReadAll(input)
+	if err != nil {
+		return err
+	}
+	reader, err := goparquet.NewFileReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	visibleCols := p.importContext.tableDesc.VisibleColumns()
+	consumer := &parquetConsumer{
+		fieldNameToIdx: make(map[string]int, len(visibleCols)),
+		decoders:       make([]func(interface{}) (tree.Datum, error), len(visibleCols)),
+		strict:         p.opts.StrictMode,
+	}
+	for idx, col := range visibleCols {
+		consumer.fieldNameToIdx[col.GetName()] = idx
+		parquetCol, err := NewParquetColumn(col.GetType(), col.GetName(), true /* nullable */)
+		if err != nil {
+			// The column cannot be imported, which is only an error if the file
+			// has a value for it.
+			colErr := err
+			consumer.decoders[idx] = func(interface{}) (tree.Datum, error) { return nil, colErr }
+			continue
+		}
+		consumer.decoders[idx] = parquetCol.DecodeFn
+	}
+
+	fileCtx := &importFileContext{
+		source:   inputIdx,
+		skip:     resumePos,
+		rejected: rejected,
+		rowLimit: p.opts.RowLimit,
+	}
+	return runParallelImport(ctx, p.importContext, fileCtx, &parquetStream{reader: reader}, consumer)
+}
@@ -184,6 +184,12 @@ func yesOrNoDatum(b bool) tree.Datum {
 	return noString
 }
 
+// isExclusionConstraint returns true if the constraint is an exclusion
+// constraint, which information_schema does not report.
+func isExclusionConstraint(c descpb.ConstraintDetail) bool {
+	return c.UniqueWithoutIndexConstraint != nil && c.UniqueWithoutIndexConstraint.IsExclusion()
+}
+
 func dNameOrNull(s string) tree.Datum {
 	if s == "" {
 		return tree.DNull
@@ -796,6 +802,10 @@ https://www.postgresql.org/docs/9.5/infoschema-key-column-usage.html`,
 				case descpb.ConstraintTypePK:
 				case descpb.ConstraintTypeFK:
 				case descpb.ConstraintTypeUnique:
+					// Like Postgres, exclusion constraints are not included.
+					if isExclusionConstraint(con) {
+						continue
+					}
 				default:
 					continue
 				}
@@ -1266,6 +1276,13 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 				tbNameStr := tree.NewDString(table.GetName())
 
 				for conName, c := range conInfo {
+					// Like Postgres, exclusion constraints are not included.
+					if isExclusionConstraint(c) {
+						continue
+					}
+					deferrability := constraintDeferrability(c)
+					isDeferrable := yesOrNoDatum(deferrability.Deferrable)
+					initiallyDeferred := yesOrNoDatum(deferrability.InitiallyDeferred)
 					if err := addRow(
 						dbNameStr,                       // constraint_catalog
 						scNameStr,                       // constraint_schema
@@ -1274,8 +1291,8 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 						scNameStr,                       // table_schema
 						tbNameStr,                       // table_name
 						tree.NewDString(string(c.Kind)), // constraint_type
-						yesOrNoDatum(false),             // is_deferrable
-						yesOrNoDatum(false),             // initially_deferred
+						isDeferrable,                    // is_deferrable
+						initiallyDeferred,               // initially_deferred
 					); err != nil {
 						return err
 					}
@@ -2580,6 +2597,11 @@ func (r roleOptions) createRole() (tree.DBool, error) {
 	return tree.DBool(createRole), err
 }
 
+func (r roleOptions) bypassRLS() (tree.DBool, error) {
+	bypassRLS, err := r.Exists("BYPASSRLS")
+	return tree.DBool(bypassRLS), err
+}
+
 func forEachRoleQuery(ctx context.Context, p *planner) string {
 	return `
 SELECT
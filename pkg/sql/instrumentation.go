@@ -128,6 +128,11 @@ type instrumentationHelper struct {
 	// indexRecommendations is a string slice containing index recommendations for
 	// the planned statement. This is only set for EXPLAIN statements.
 	indexRecommendations []string
+
+	// workloadIndexRecommendations is a string slice containing the index
+	// recommendations recorded in the statement statistics. It is only set
+	// when the plan is saved for stats.
+	workloadIndexRecommendations []string
 }
 
 // outputMode indicates how the statement output needs to be populated (for
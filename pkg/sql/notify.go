@@ -0,0 +1,212 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+)
+
+// notificationState is the per-session state of LISTEN and UNLISTEN.
+type notificationState struct {
+	watcher *pgnotify.Watcher
+	// pid identifies the session in the notifications it sends. It is the
+	// process ID reported to the client in the BackendKeyData message.
+	pid int32
+	// onNotify is called by the listener when new notifications are queued.
+	onNotify func()
+	// listener is created by the first LISTEN which commits.
+	listener *pgnotify.Listener
+	// pending contains the LISTEN and UNLISTEN actions of the current
+	// transaction. They take effect when the transaction commits.
+	pending []listenAction
+}
+
+// listenAction is a LISTEN or UNLISTEN executed in the current transaction.
+type listenAction struct {
+	// channel is empty for UNLISTEN *.
+	channel  string
+	unlisten bool
+}
+
+// commit applies the pending actions of the transaction.
+func (ns *notificationState) commit() {
+	for _, a := range ns.pending {
+		switch {
+		case !a.unlisten:
+			if ns.listener == nil {
+				ns.listener = ns.watcher.NewListener(ns.onNotify)
+			}
+			ns.listener.Listen(a.channel)
+		case ns.listener == nil:
+		case a.channel == "":
+			ns.listener.UnlistenAll()
+		default:
+			ns.listener.Unlisten(a.channel)
+		}
+	}
+	ns.pending = nil
+}
+
+// rollback discards the pending actions of the transaction.
+func (ns *notificationState) rollback() {
+	ns.pending = nil
+}
+
+// unlistenAll stops listening on all channels right away.
+func (ns *notificationState) unlistenAll() {
+	ns.pending = nil
+	if ns.listener != nil {
+		ns.listener.UnlistenAll()
+	}
+}
+
+// take returns the notifications which were received and not yet delivered
+// to the client.
+func (ns *notificationState) take() []pgnotify.Notification {
+	if ns.listener == nil {
+		return nil
+	}
+	return ns.listener.Take()
+}
+
+// close releases the listener.
+func (ns *notificationState) close() {
+	if ns.listener != nil {
+		ns.listener.Close()
+		ns.listener = nil
+	}
+}
+
+// checkNotifications returns an error if LISTEN, UNLISTEN and NOTIFY cannot
+// be used.
+func (p *planner) checkNotifications(ctx context.Context, stmt string) error {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.NotificationsTable) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s is not supported until upgrade to version %s is finalized",
+			stmt, clusterversion.NotificationsTable.String())
+	}
+	if p.extendedEvalCtx.Notifications == nil || p.ExecCfg().NotificationWatcher == nil {
+		return pgerror.Newf(pgcode.FeatureNotSupported, "%s is not supported in this context", stmt)
+	}
+	return nil
+}
+
+type listenNode struct {
+	n *tree.Listen
+}
+
+// Listen registers the session as a listener on a notification channel once
+// the transaction commits.
+// Privileges: None.
+func (p *planner) Listen(ctx context.Context, n *tree.Listen) (planNode, error) {
+	if err := p.checkNotifications(ctx, "LISTEN"); err != nil {
+		return nil, err
+	}
+	if err := pgnotify.ValidateChannel(string(n.Channel)); err != nil {
+		return nil, err
+	}
+	return &listenNode{n: n}, nil
+}
+
+func (n *listenNode) startExec(params runParams) error {
+	execCfg := params.ExecCfg()
+	if err := execCfg.NotificationWatcher.Start(params.ctx, execCfg.SystemTableIDResolver); err != nil {
+		return err
+	}
+	ns := params.p.extendedEvalCtx.Notifications
+	ns.pending = append(ns.pending, listenAction{channel: string(n.n.Channel)})
+	return nil
+}
+
+func (*listenNode) Next(runParams) (bool, error) { return false, nil }
+func (*listenNode) Values() tree.Datums          { return nil }
+func (*listenNode) Close(context.Context)        {}
+
+type unlistenNode struct {
+	n *tree.Unlisten
+}
+
+// Unlisten stops listening on one or all notification channels once the
+// transaction commits.
+// Privileges: None.
+func (p *planner) Unlisten(ctx context.Context, n *tree.Unlisten) (planNode, error) {
+	if err := p.checkNotifications(ctx, "UNLISTEN"); err != nil {
+		return nil, err
+	}
+	return &unlistenNode{n: n}, nil
+}
+
+func (n *unlistenNode) startExec(params runParams) error {
+	ns := params.p.extendedEvalCtx.Notifications
+	a := listenAction{unlisten: true}
+	if !n.n.All {
+		a.channel = string(n.n.Channel)
+	}
+	ns.pending = append(ns.pending, a)
+	return nil
+}
+
+func (*unlistenNode) Next(runParams) (bool, error) { return false, nil }
+func (*unlistenNode) Values() tree.Datums          { return nil }
+func (*unlistenNode) Close(context.Context)        {}
+
+type notifyNode struct {
+	n *tree.Notify
+}
+
+// Notify sends a notification on a channel once the transaction commits.
+// Privileges: None.
+func (p *planner) Notify(ctx context.Context, n *tree.Notify) (planNode, error) {
+	if err := p.checkNotifications(ctx, "NOTIFY"); err != nil {
+		return nil, err
+	}
+	return &notifyNode{n: n}, nil
+}
+
+func (n *notifyNode) startExec(params runParams) error {
+	return params.p.SendNotification(params.ctx, string(n.n.Channel), n.n.Payload)
+}
+
+func (*notifyNode) Next(runParams) (bool, error) { return false, nil }
+func (*notifyNode) Values() tree.Datums          { return nil }
+func (*notifyNode) Close(context.Context)        {}
+
+// SendNotification is part of the EvalPlanner interface.
+func (p *planner) SendNotification(ctx context.Context, channel, payload string) error {
+	if err := p.checkNotifications(ctx, "NOTIFY"); err != nil {
+		return err
+	}
+	if err := pgnotify.ValidateChannel(channel); err != nil {
+		return err
+	}
+	if err := pgnotify.ValidatePayload(payload); err != nil {
+		return err
+	}
+	if p.EvalContext().TxnReadOnly {
+		return readOnlyError("NOTIFY")
+	}
+	// The notification is written in the current transaction, so it is only
+	// delivered if the transaction commits.
+	_, err := p.ExecCfg().InternalExecutor.ExecEx(
+		ctx, "notify", p.Txn(),
+		sessiondata.NodeUserSessionDataOverride,
+		`INSERT INTO system.notifications (channel, payload, pid) VALUES ($1, $2, $3)`,
+		channel, payload, p.extendedEvalCtx.Notifications.pid,
+	)
+	return err
+}
@@ -126,6 +126,11 @@ type Catalog interface {
 		ctx context.Context, name *tree.UnresolvedObjectName,
 	) (*types.T, error)
 
+	// ResolveFunctionByOID looks up the overload of a user-defined function by
+	// its OID. It returns an error if the current user does not have the
+	// EXECUTE privilege on the function.
+	ResolveFunctionByOID(ctx context.Context, oid oid.Oid) (*tree.Overload, error)
+
 	// CheckPrivilege verifies that the current user has the given privilege on
 	// the given catalog object. If not, then CheckPrivilege returns an error.
 	CheckPrivilege(ctx context.Context, o Object, priv privilege.Kind) error
@@ -161,4 +166,12 @@ type Catalog interface {
 
 	// RoleExists returns true if the role exists.
 	RoleExists(ctx context.Context, role security.SQLUsername) (bool, error)
+
+	// IsOwner returns true if the current user or any role it is a member of
+	// owns the given catalog object.
+	IsOwner(ctx context.Context, o Object) (bool, error)
+
+	// IsMemberOfRole returns true if the current user is the given role or is
+	// a direct or indirect member of it.
+	IsMemberOfRole(ctx context.Context, role security.SQLUsername) (bool, error)
 }
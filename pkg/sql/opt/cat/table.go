@@ -13,7 +13,9 @@ package cat
 import (
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 )
 
 // Table is an interface to a database table, exposing only the information
@@ -134,6 +136,30 @@ type Table interface {
 	// i < UniqueCount.
 	Unique(i UniqueOrdinal) UniqueConstraint
 
+	// TriggerCount returns the number of triggers defined on this table.
+	TriggerCount() int
+
+	// Trigger returns the ith trigger defined on this table, where
+	// i < TriggerCount. Triggers are ordered by creation time.
+	Trigger(i int) Trigger
+
+	// IsRowLevelSecurityEnabled returns true if row-level security is enabled
+	// on this table, in which case only the rows allowed by its policies are
+	// visible to and modifiable by users other than the owner.
+	IsRowLevelSecurityEnabled() bool
+
+	// IsRowLevelSecurityForced returns true if the policies of this table also
+	// apply to its owner.
+	IsRowLevelSecurityForced() bool
+
+	// PolicyCount returns the number of row-level security policies defined on
+	// this table.
+	PolicyCount() int
+
+	// Policy returns the ith row-level security policy defined on this table,
+	// where i < PolicyCount.
+	Policy(i int) Policy
+
 	// Zone returns a table's zone.
 	Zone() Zone
 
@@ -154,6 +180,50 @@ type CheckConstraint struct {
 	Validated  bool
 }
 
+// Trigger contains the definition of a trigger on a table. A trigger runs its
+// SQL body whenever the table is modified by one of its events, either once
+// per statement or once per modified row. For example:
+//
+//   CREATE TRIGGER audit AFTER INSERT ON t FOR EACH ROW
+//     AS $$INSERT INTO t_audit VALUES (NEW.k, now())$$
+//
+type Trigger struct {
+	Name       tree.Name
+	ActionTime tree.TriggerActionTime
+	Events     tree.TriggerEvents
+	ForEachRow bool
+	// Body is the SQL statement run by the trigger. The body of a row-level
+	// trigger refers to the values of the modified row as NEW.<column> and
+	// OLD.<column>.
+	Body string
+}
+
+// Policy contains the definition of a row-level security policy on a table.
+// The policy applies to the given roles when they run statements of its
+// command. For example:
+//
+//   CREATE POLICY own_rows ON t FOR SELECT TO public USING (owner = current_user)
+//
+type Policy struct {
+	Name    tree.Name
+	Type    tree.PolicyType
+	Command tree.PolicyCommand
+	Roles   []security.SQLUsername
+	// UsingExpr is the SQL text of the expression that existing rows must
+	// satisfy to be visible to the statement, or the empty string if there is
+	// none.
+	UsingExpr string
+	// WithCheckExpr is the SQL text of the expression that new rows must
+	// satisfy, or the empty string if there is none.
+	WithCheckExpr string
+}
+
+// AppliesToCommand returns true if the policy applies to statements of the
+// given command.
+func (p *Policy) AppliesToCommand(cmd tree.PolicyCommand) bool {
+	return p.Command == tree.PolicyCommandAll || p.Command == cmd
+}
+
 // TableStatistic is an interface to a table statistic. Each statistic is
 // associated with a set of columns.
 type TableStatistic interface {
@@ -257,6 +327,10 @@ type ForeignKeyConstraint interface {
 	// UpdateReferenceAction returns the action to be performed if the foreign key
 	// constraint would be violated by an update.
 	UpdateReferenceAction() tree.ReferenceAction
+	// Deferrability returns whether the checks of the constraint may be
+	// postponed to the end of the transaction, and whether they are postponed
+	// by default.
+	Deferrability() tree.ConstraintDeferrability
 }
 
 // UniqueConstraint represents a uniqueness constraint. UniqueConstraints may
@@ -302,6 +376,21 @@ type UniqueConstraint interface {
 	// satisfied when building functional dependencies for the table. This enables
 	// additional optimizations, such as omission of uniqueness checks.
 	UniquenessGuaranteedByAnotherIndex() bool
+	// Deferrability returns whether the checks of the constraint may be
+	// postponed to the end of the transaction, and whether they are postponed
+	// by default. Only constraints without an index can be deferrable.
+	Deferrability() tree.ConstraintDeferrability
+
+	// IsExclusion is true if this is an exclusion constraint, which prevents
+	// any two rows from having values that all compare true with the
+	// constraint's operators. A unique constraint is the special case where
+	// all the operators are equality.
+	IsExclusion() bool
+
+	// ColumnOperator returns the operator used to compare the values of the
+	// ith column in this constraint. It is always treecmp.EQ unless the
+	// constraint is an exclusion constraint.
+	ColumnOperator(i int) treecmp.ComparisonOperatorSymbol
 }
 
 // UniqueOrdinal identifies a unique constraint (in the context of a Table).
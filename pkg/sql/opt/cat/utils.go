@@ -15,10 +15,12 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/treeprinter"
 	"github.com/cockroachdb/errors"
@@ -50,6 +52,48 @@ func ExpandDataSourceGlob(
 	}
 }
 
+// ApplicablePolicies returns the ordinals of the row-level security policies
+// of the given table that apply to the current user. If row-level security is
+// not enforced for the current user, either because it is disabled on the
+// table or because the user bypasses it, enforced is false. When enforced is
+// true and no policies apply, all rows are hidden from the user.
+//
+// The owner of the table bypasses its policies unless row-level security is
+// forced, and users with the BYPASSRLS role option always bypass them.
+func ApplicablePolicies(
+	ctx context.Context, catalog Catalog, tab Table,
+) (policies []int, enforced bool, err error) {
+	if !tab.IsRowLevelSecurityEnabled() {
+		return nil, false, nil
+	}
+	bypass, err := catalog.HasRoleOption(ctx, roleoption.BYPASSRLS)
+	if err != nil || bypass {
+		return nil, false, err
+	}
+	if !tab.IsRowLevelSecurityForced() {
+		isOwner, err := catalog.IsOwner(ctx, tab)
+		if err != nil || isOwner {
+			return nil, false, err
+		}
+	}
+	for i, n := 0, tab.PolicyCount(); i < n; i++ {
+		p := tab.Policy(i)
+		for _, role := range p.Roles {
+			applies := role.IsPublicRole()
+			if !applies {
+				if applies, err = catalog.IsMemberOfRole(ctx, role); err != nil {
+					return nil, false, err
+				}
+			}
+			if applies {
+				policies = append(policies, i)
+				break
+			}
+		}
+	}
+	return policies, true, nil
+}
+
 // ResolveTableIndex resolves a TableIndexName.
 func ResolveTableIndex(
 	ctx context.Context, catalog Catalog, flags Flags, name *tree.TableIndexName,
@@ -172,16 +216,52 @@ func FormatTable(cat Catalog, tab Table, tp treeprinter.Node) {
 		if uniq.WithoutIndex() {
 			withoutIndexStr = "WITHOUT INDEX "
 		}
-		c := child.Childf(
-			"UNIQUE %s%s",
-			withoutIndexStr,
-			formatCols(tab, tab.Unique(i).ColumnCount(), tab.Unique(i).ColumnOrdinal),
-		)
+		var c treeprinter.Node
+		if uniq.IsExclusion() {
+			c = child.Childf("EXCLUDE %s", formatExclusionElems(tab, uniq))
+		} else {
+			c = child.Childf(
+				"UNIQUE %s%s",
+				withoutIndexStr,
+				formatCols(tab, tab.Unique(i).ColumnCount(), tab.Unique(i).ColumnOrdinal),
+			)
+		}
 		if pred, isPartial := uniq.Predicate(); isPartial {
 			c.Childf("WHERE %s", pred)
 		}
 	}
 
+	for i := 0; i < tab.TriggerCount(); i++ {
+		trig := tab.Trigger(i)
+		forEach := "STATEMENT"
+		if trig.ForEachRow {
+			forEach = "ROW"
+		}
+		c := child.Childf(
+			"TRIGGER %s %s %s FOR EACH %s",
+			trig.Name, trig.ActionTime, tree.AsString(&trig.Events), forEach,
+		)
+		c.Child(trig.Body)
+	}
+
+	if tab.IsRowLevelSecurityEnabled() {
+		if tab.IsRowLevelSecurityForced() {
+			child.Child("ROW LEVEL SECURITY FORCED")
+		} else {
+			child.Child("ROW LEVEL SECURITY")
+		}
+	}
+	for i := 0; i < tab.PolicyCount(); i++ {
+		p := tab.Policy(i)
+		c := child.Childf("POLICY %s AS %s FOR %s TO %s", p.Name, p.Type, p.Command, formatRoles(p.Roles))
+		if p.UsingExpr != "" {
+			c.Childf("USING (%s)", p.UsingExpr)
+		}
+		if p.WithCheckExpr != "" {
+			c.Childf("WITH CHECK (%s)", p.WithCheckExpr)
+		}
+	}
+
 	// TODO(radu): show stats.
 }
 
@@ -266,6 +346,35 @@ func formatCols(tab Table, numCols int, colOrdinal func(tab Table, i int) int) s
 	return buf.String()
 }
 
+// formatExclusionElems formats the columns of an exclusion constraint along
+// with their operators, e.g. "(a WITH =, b WITH &&)".
+func formatExclusionElems(tab Table, uniq UniqueConstraint) string {
+	var buf bytes.Buffer
+	buf.WriteByte('(')
+	for i, n := 0, uniq.ColumnCount(); i < n; i++ {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		colName := tab.Column(uniq.ColumnOrdinal(tab, i)).ColName()
+		fmt.Fprintf(&buf, "%s WITH %s", colName.String(), uniq.ColumnOperator(i))
+	}
+	buf.WriteByte(')')
+
+	return buf.String()
+}
+
+// formatRoles formats the roles of a policy, e.g. "alice, public".
+func formatRoles(roles []security.SQLUsername) string {
+	var buf bytes.Buffer
+	for i, role := range roles {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(role.SQLIdentifier())
+	}
+	return buf.String()
+}
+
 // formatCatalogFKRef nicely formats a catalog foreign key reference using a
 // treeprinter for debugging and testing.
 func formatCatalogFKRef(
@@ -153,9 +153,47 @@ func makeCascadeBuilder(b *Builder, mutationWithID opt.WithID) (*cascadeBuilder,
 
 // setupCascade fills in an exec.Cascade struct for the given cascade.
 func (cb *cascadeBuilder) setupCascade(cascade *memo.FKCascade) exec.Cascade {
+	var placeholderOrdinals []int
+	rowFilterOrdinal := -1
+	if cascade.ForEachRow {
+		if cascade.RowFilterCol != 0 {
+			ord, ok := cb.mutationBufferCols.Get(int(cascade.RowFilterCol))
+			if !ok {
+				panic(errors.AssertionFailedf(
+					"row filter column %d not in mutation buffer", cascade.RowFilterCol,
+				))
+			}
+			rowFilterOrdinal = ord
+		}
+		// The new values are bound to the first placeholders, followed by the old
+		// values.
+		placeholderOrdinals = make([]int, 0, len(cascade.NewValues)+len(cascade.OldValues))
+		for _, cols := range []opt.ColList{cascade.NewValues, cascade.OldValues} {
+			for _, col := range cols {
+				ord, ok := cb.mutationBufferCols.Get(int(col))
+				if col == 0 || !ok {
+					ord = -1
+				}
+				placeholderOrdinals = append(placeholderOrdinals, ord)
+			}
+		}
+	}
+	buffer := cb.mutationBuffer
+	if cascade.WithID == 0 {
+		// The cascade does not require the buffered input (e.g. statement-level
+		// triggers, which run even if no rows were modified).
+		buffer = nil
+	}
+	// preparedMemo caches the memo of a cascade that runs once for each row; it
+	// is built on the first row and reused for the rest.
+	var preparedMemo *memo.Memo
 	return exec.Cascade{
-		FKName: cascade.FKName,
-		Buffer: cb.mutationBuffer,
+		FKName:              cascade.FKName,
+		Buffer:              buffer,
+		ForEachRow:          cascade.ForEachRow,
+		PlaceholderOrdinals: placeholderOrdinals,
+		RowFilterOrdinal:    rowFilterOrdinal,
+		Before:              cascade.Before,
 		PlanFn: func(
 			ctx context.Context,
 			semaCtx *tree.SemaContext,
@@ -165,6 +203,11 @@ func (cb *cascadeBuilder) setupCascade(cascade *memo.FKCascade) exec.Cascade {
 			numBufferedRows int,
 			allowAutoCommit bool,
 		) (exec.Plan, error) {
+			if cascade.ForEachRow {
+				return cb.planCascadeForRow(
+					ctx, semaCtx, evalCtx, execFactory, cascade, &preparedMemo, allowAutoCommit,
+				)
+			}
 			return cb.planCascade(
 				ctx, semaCtx, evalCtx, execFactory, cascade, bufferRef, numBufferedRows, allowAutoCommit,
 			)
@@ -305,6 +348,58 @@ func (cb *cascadeBuilder) planCascade(
 	return plan, nil
 }
 
+// planCascadeForRow is used to plan a cascade query that runs once for each
+// buffered row (see memo.FKCascade.ForEachRow). The values of the current row
+// are bound to the placeholders in evalCtx.
+//
+// The cascade is built and optimized only once, with the placeholders left in
+// place, and the resulting memo is cached in preparedMemo. For each row, only
+// the exec plan is built from the cached memo; the placeholders are evaluated
+// with the values of the row when the plan runs.
+func (cb *cascadeBuilder) planCascadeForRow(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *tree.EvalContext,
+	execFactory exec.Factory,
+	cascade *memo.FKCascade,
+	preparedMemo **memo.Memo,
+	allowAutoCommit bool,
+) (exec.Plan, error) {
+	var o xform.Optimizer
+	o.Init(evalCtx, cb.b.catalog)
+
+	if *preparedMemo == nil {
+		factory := o.Factory()
+		relExpr, err := cascade.Builder.Build(
+			ctx,
+			semaCtx,
+			evalCtx,
+			cb.b.catalog,
+			factory,
+			0,   /* binding */
+			nil, /* bindingProps */
+			nil, /* oldValues */
+			nil, /* newValues */
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "while building cascade expression")
+		}
+		o.Memo().SetRoot(relExpr, &physical.Required{})
+		if _, err := o.Optimize(); err != nil {
+			return nil, errors.Wrap(err, "while optimizing cascade expression")
+		}
+		*preparedMemo = o.DetachMemo()
+	}
+
+	mem := *preparedMemo
+	eb := New(execFactory, &o, mem, cb.b.catalog, mem.RootExpr(), evalCtx, allowAutoCommit)
+	plan, err := eb.Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "while building cascade plan")
+	}
+	return plan, nil
+}
+
 // Remap columns according to a ColMap.
 func remapColumns(cols opt.ColList, m opt.ColMap) (opt.ColList, error) {
 	res := make(opt.ColList, len(cols))
@@ -148,6 +148,12 @@ func (b *Builder) buildRelational(e memo.RelExpr) (execPlan, error) {
 	var ep execPlan
 	var err error
 
+	// A Barrier only affects the optimization of the plan, and is not present
+	// at execution time.
+	if barrier, ok := e.(*memo.BarrierExpr); ok {
+		return b.buildRelational(barrier.Input)
+	}
+
 	if opt.IsDDLOp(e) {
 		// Mark the statement as containing DDL for use
 		// in the SQL executor.
@@ -218,6 +218,29 @@ type Cascade struct {
 	// the mutation. It is nil if the cascade does not require a buffer.
 	Buffer Node
 
+	// ForEachRow is set if PlanFn must be called once for each buffered row
+	// (e.g. for row-level triggers). In that case, PlanFn is called with a nil
+	// bufferRef and the values of the current row must be bound to placeholders
+	// according to PlaceholderOrdinals.
+	ForEachRow bool
+
+	// PlaceholderOrdinals is set if ForEachRow is set. For each placeholder
+	// index, it contains the ordinal of the buffer column whose value is bound
+	// to that placeholder, or -1 if the placeholder is bound to NULL.
+	PlaceholderOrdinals []int
+
+	// RowFilterOrdinal is the ordinal of a boolean buffer column if PlanFn must
+	// only be called for the rows in which that column is true, or -1 otherwise.
+	// It is only used if ForEachRow is set.
+	RowFilterOrdinal int
+
+	// Before is set if the cascade must run before the mutation rather than
+	// after the main query (e.g. for BEFORE row-level triggers). In that case,
+	// the Buffer is also the root of a subquery; the cascade must run right
+	// after that subquery, once the mutation input is fully buffered. It can
+	// only be set together with ForEachRow.
+	Before bool
+
 	// PlanFn builds the cascade query and creates the plan for it.
 	// Note that the generated Plan can in turn contain more cascades (as well as
 	// checks, which should run after all cascades are executed).
@@ -231,9 +254,10 @@ type Cascade struct {
 	// If the cascade does not require input buffering (Buffer is nil), then
 	// bufferRef should be nil and numBufferedRows should be 0.
 	//
-	// This method does not mutate any captured state; it is ok to call PlanFn
-	// methods concurrently (provided that they don't use a single non-thread-safe
-	// execFactory).
+	// This method does not mutate any captured state, except for caching the
+	// built query of a ForEachRow cascade between rows; it is ok to call PlanFn
+	// methods of different cascades concurrently (provided that they don't use a
+	// single non-thread-safe execFactory).
 	PlanFn func(
 		ctx context.Context,
 		semaCtx *tree.SemaContext,
@@ -269,6 +293,34 @@ type InsertFastPathFKCheck struct {
 // relevant row.
 type MkErrFn func(tree.Datums) error
 
+// DeferrableConstraintViolation is the error generated by the MkErrFn of a
+// check query that enforces a deferrable constraint. If the constraint is
+// deferred in the current transaction, the execution engine ignores the error
+// and validates the whole constraint again before the transaction commits;
+// otherwise, Err is returned to the client.
+type DeferrableConstraintViolation struct {
+	// TableID identifies the table on which the constraint is defined (the
+	// origin table for foreign keys).
+	TableID cat.StableID
+	// Constraint is the name of the constraint.
+	Constraint string
+	// InitiallyDeferred is true if the constraint is deferred unless SET
+	// CONSTRAINTS requested otherwise.
+	InitiallyDeferred bool
+	// Err is the violation error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *DeferrableConstraintViolation) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap implements the errors.Wrapper interface.
+func (e *DeferrableConstraintViolation) Unwrap() error {
+	return e.Err
+}
+
 // ExplainFactory is an extension of Factory used when constructing a plan that
 // can be explained. It allows annotation of nodes with extra information.
 type ExplainFactory interface {
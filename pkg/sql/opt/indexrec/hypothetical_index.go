@@ -15,6 +15,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/errors"
@@ -46,6 +47,18 @@ type hypotheticalIndex struct {
 
 	// inverted indicates if an index is inverted.
 	inverted bool
+
+	// predicate is the SQL expression of the predicate of a partial index. It is
+	// empty if the index is not a partial index.
+	predicate string
+
+	// predicateExpr stores the predicate of a partial index, used to output the
+	// index recommendation.
+	predicateExpr tree.Expr
+
+	// predicateFilters stores the predicate of a partial index as it appears in
+	// the query the index was recommended for.
+	predicateFilters memo.FiltersExpr
 }
 
 var _ cat.Index = &hypotheticalIndex{}
@@ -75,11 +88,14 @@ func (hi *hypotheticalIndex) init(
 	suffixKeyColsSet := hi.tab.primaryKeyColsOrdSet.Difference(colsOrdSet)
 	hi.suffixKeyColsOrdList = suffixKeyColsSet.Ordered()
 
-	// Build the stored cols set.
+	// Build the stored cols set. The virtual columns of hypothetical expression
+	// indexes cannot be stored.
 	keyColsOrds := colsOrdSet.Union(suffixKeyColsSet)
 	var tableOrdinalSet util.FastIntSet
 	for i := 0; i < tab.ColumnCount(); i++ {
-		tableOrdinalSet.Add(i)
+		if tab.indexExpr(i) == nil {
+			tableOrdinalSet.Add(i)
+		}
 	}
 
 	// Only add stored columns for non-inverted indexes.
@@ -171,7 +187,7 @@ func (hi *hypotheticalIndex) InvertedColumn() cat.IndexColumn {
 
 // Predicate is part of the cat.Index interface.
 func (hi *hypotheticalIndex) Predicate() (string, bool) {
-	return "", false
+	return hi.predicate, hi.predicate != ""
 }
 
 // Zone is part of the cat.Index interface.
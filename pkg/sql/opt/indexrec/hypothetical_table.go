@@ -14,7 +14,9 @@ import (
 	"fmt"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util"
@@ -22,21 +24,28 @@ import (
 
 // BuildOptAndHypTableMaps builds a HypotheticalTable for each table in
 // indexCandidates. This HypotheticalTable stores a hypothetical index for each
-// of the table's index candidates. The function returns a map from each table's
-// cat.StableID to its original sql.optTable, as well as a map from each table's
-// cat.StableID to its constructed HypotheticalTable. These tables will be used
-// to update the table query metadata when making index recommendations.
+// of the table's index candidates, including its partial index candidates. The
+// function returns a map from each table's cat.StableID to its original
+// sql.optTable, as well as a map from each table's cat.StableID to its
+// constructed HypotheticalTable. These tables will be used to update the table
+// query metadata when making index recommendations.
 func BuildOptAndHypTableMaps(
-	indexCandidates map[cat.Table][][]cat.IndexColumn,
+	indexCandidates IndexCandidates,
 ) (optTables, hypTables map[cat.StableID]cat.Table) {
-	numTables := len(indexCandidates)
+	numTables := len(indexCandidates.Indexes) + len(indexCandidates.Partial)
 	hypTables = make(map[cat.StableID]cat.Table, numTables)
 	optTables = make(map[cat.StableID]cat.Table, numTables)
 
-	for t, indexes := range indexCandidates {
-		hypIndexes := make([]hypotheticalIndex, 0, len(indexes))
+	buildHypTable := func(t cat.Table) {
+		if _, ok := hypTables[t.ID()]; ok {
+			return
+		}
+		indexes := indexCandidates.Indexes[t]
+		partialIndexes := indexCandidates.Partial[t]
+		hypIndexes := make([]hypotheticalIndex, 0, len(indexes)+len(partialIndexes))
 		var hypTable HypotheticalTable
 		hypTable.init(t)
+		hypTable.exprCols = indexCandidates.exprCols[t]
 
 		for _, indexCols := range indexes {
 			indexOrd := hypTable.Table.IndexCount() + len(hypIndexes)
@@ -65,19 +74,78 @@ func BuildOptAndHypTableMaps(
 			}
 		}
 
+		for i := range partialIndexes {
+			indexOrd := hypTable.Table.IndexCount() + len(hypIndexes)
+			var hypIndex hypotheticalIndex
+			hypIndex.init(
+				&hypTable,
+				tree.Name(fmt.Sprintf("_hyp_%d", indexOrd)),
+				partialIndexes[i].Columns,
+				indexOrd,
+				false, /* inverted */
+				t.Zone(),
+			)
+			hypIndex.predicate = partialIndexes[i].Predicate
+			hypIndex.predicateExpr = partialIndexes[i].predExpr
+			hypIndex.predicateFilters = partialIndexes[i].filters
+			hypIndexes = append(hypIndexes, hypIndex)
+		}
+
 		hypTable.hypotheticalIndexes = hypIndexes
 		optTables[t.ID()] = t
 		hypTables[t.ID()] = &hypTable
 	}
 
+	for t := range indexCandidates.Indexes {
+		buildHypTable(t)
+	}
+	for t := range indexCandidates.Partial {
+		buildHypTable(t)
+	}
+
 	return optTables, hypTables
 }
 
+// AddHypotheticalTableExprs adds the expressions of the virtual computed
+// columns and the predicates of the partial indexes of each HypotheticalTable
+// in the factory's metadata to the corresponding table metadata. The optimizer
+// needs these expressions to plan scans over hypothetical expression and
+// partial indexes. It must be called after the hypothetical tables have been
+// added to the metadata with UpdateTableMeta.
+func AddHypotheticalTableExprs(f *norm.Factory) {
+	md := f.Metadata()
+	for _, table := range md.AllTables() {
+		hypTable, ok := table.Table.(*HypotheticalTable)
+		if !ok {
+			continue
+		}
+		tabMeta := md.TableMeta(table.MetaID)
+		for i := range hypTable.exprCols {
+			exprCol := &hypTable.exprCols[i]
+			expr := f.CopyWithoutAssigningPlaceholders(exprCol.scalar).(opt.ScalarExpr)
+			tabMeta.AddComputedCol(tabMeta.MetaID.ColumnID(exprCol.col.Ordinal()), expr)
+		}
+		for i := range hypTable.hypotheticalIndexes {
+			hypIndex := &hypTable.hypotheticalIndexes[i]
+			if hypIndex.predicate == "" {
+				continue
+			}
+			pred := f.CopyWithoutAssigningPlaceholders(&hypIndex.predicateFilters).(opt.ScalarExpr)
+			tabMeta.AddPartialIndexPredicate(hypIndex.indexOrdinal, pred)
+		}
+	}
+}
+
 // HypotheticalTable is a wrapper around cat.Table, used for creating index
 // recommendations. The hypotheticalIndexes slice stores fake indexes that could
 // potentially speed up queries to this table.
 type HypotheticalTable struct {
 	cat.Table
+	// exprCols stores the virtual computed columns of hypothetical expression
+	// indexes. They directly follow the embedded table's columns.
+	exprCols []exprColumn
+	// invertedCols stores the inverted columns of hypothetical inverted indexes.
+	// They directly follow the virtual computed columns in exprCols.
 	invertedCols         []*cat.Column
 	primaryKeyColsOrdSet util.FastIntSet
 	hypotheticalIndexes  []hypotheticalIndex
@@ -98,7 +166,7 @@ func (ht *HypotheticalTable) init(table cat.Table) {
 
 // ColumnCount is part of the cat.Table interface.
 func (ht *HypotheticalTable) ColumnCount() int {
-	return ht.Table.ColumnCount() + len(ht.invertedCols)
+	return ht.Table.ColumnCount() + len(ht.exprCols) + len(ht.invertedCols)
 }
 
 // Column is part of the cat.Table interface.
@@ -107,7 +175,22 @@ func (ht *HypotheticalTable) Column(i int) *cat.Column {
 	if i < originalColCount {
 		return ht.Table.Column(i)
 	}
-	return ht.invertedCols[i-originalColCount]
+	i -= originalColCount
+	if i < len(ht.exprCols) {
+		return ht.exprCols[i].col
+	}
+	return ht.invertedCols[i-len(ht.exprCols)]
+}
+
+// indexExpr returns the index expression of the given virtual computed column
+// of a hypothetical expression index, or nil if the column ordinal does not
+// belong to such a column.
+func (ht *HypotheticalTable) indexExpr(ord int) tree.Expr {
+	i := ord - ht.Table.ColumnCount()
+	if i < 0 || i >= len(ht.exprCols) {
+		return nil
+	}
+	return ht.exprCols[i].expr
 }
 
 // IndexCount is part of the cat.Table interface.
@@ -140,8 +223,12 @@ func (ht *HypotheticalTable) Index(i cat.IndexOrdinal) cat.Index {
 // as the index argument is present in the HypotheticalTable's embedded table.
 // If so, it returns the first instance of such an existing index (that is not a
 // partial index). Existing partial indexes and hypothetical standard indexes
-// are not considered redundant. Otherwise, the function returns nil.
+// are not considered redundant, and hypothetical partial indexes never have a
+// redundant index. Otherwise, the function returns nil.
 func (ht *HypotheticalTable) existingRedundantIndex(index *hypotheticalIndex) cat.Index {
+	if _, isPartialIndex := index.Predicate(); isPartialIndex {
+		return nil
+	}
 	for i, n := 0, ht.Table.IndexCount(); i < n; i++ {
 		indexCols := index.cols
 		existingIndex := ht.Table.Index(i)
@@ -158,6 +245,14 @@ func (ht *HypotheticalTable) existingRedundantIndex(index *hypotheticalIndex) ca
 					indexExists = false
 					break
 				}
+			} else if indexCol.IsVirtualComputed() && indexCols[j].IsVirtualComputed() {
+				// Compare the expressions of virtual computed columns, since the
+				// columns of hypothetical expression indexes are not table columns.
+				if indexCol.ComputedExprStr() != indexCols[j].ComputedExprStr() ||
+					indexCol.Descending != indexCols[j].Descending {
+					indexExists = false
+					break
+				}
 			} else if indexCol != indexCols[j] {
 				indexExists = false
 				break
@@ -11,10 +11,13 @@
 package indexrec
 
 import (
+	"fmt"
+
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util"
 )
@@ -45,7 +48,17 @@ import (
 //     because indexes do not benefit here.
 //  7. For JSON and array columns, we create single column inverted indexes. We
 //     also create the following multi-column combination candidates for each
-//     inverted column: eq + 'inverted column', EQ + 'inverted column'.
+//     inverted column: eq + 'inverted column', EQ + 'inverted column'. This
+//     includes JSON columns used with the ?, ?| and ?& operators.
+//  8. For expressions on the columns of a single table that are used in place
+//     of a column in rules 2 and 5, such as j->>'k' = 'v' or lower(s) = 'v',
+//     we create expression indexes on the expression. The expression must be
+//     immutable.
+//  9. For filters on a table that compare a column to a constant (=, IS NULL,
+//     IS NOT NULL, or a boolean column), we create partial indexes with those
+//     filters as the predicate. The key columns are the columns referenced by
+//     the other filters on the table: one single-column index is created for
+//     each of them, as well as one index on all of them.
 // TODO(nehageorge): Add a rule for columns that are referenced in the statement
 // but do not fall into one of these categories. In order to account for this,
 // *memo.VariableExpr would be the final case in the switch statement, hit only
@@ -53,12 +66,60 @@ import (
 // RFC for inspiration: https://github.com/cockroachdb/cockroach/pull/71784. We
 // may also consider matching more types of SQL expressions, including LIKE
 // expressions.
-func FindIndexCandidateSet(rootExpr opt.Expr, md *opt.Metadata) map[cat.Table][][]cat.IndexColumn {
+func FindIndexCandidateSet(rootExpr opt.Expr, md *opt.Metadata) IndexCandidates {
 	var candidateSet indexCandidateSet
 	candidateSet.init(md)
 	candidateSet.categorizeIndexCandidates(rootExpr)
 	candidateSet.combineIndexCandidates()
-	return candidateSet.overallCandidates
+	return IndexCandidates{
+		Indexes:  candidateSet.overallCandidates,
+		Partial:  candidateSet.partialCandidates,
+		exprCols: candidateSet.exprCols,
+	}
+}
+
+// IndexCandidates stores the index candidates for each table referenced in a
+// query.
+type IndexCandidates struct {
+	// Indexes stores the key columns of each standard, inverted and expression
+	// index candidate.
+	Indexes map[cat.Table][][]cat.IndexColumn
+
+	// Partial stores the partial index candidates.
+	Partial map[cat.Table][]PartialIndexCandidate
+
+	// exprCols stores the virtual computed columns that are the keys of
+	// expression index candidates, in ordinal order. Their ordinals directly
+	// follow the ordinals of the table's columns.
+	exprCols map[cat.Table][]exprColumn
+}
+
+// PartialIndexCandidate is an index candidate with a predicate.
+type PartialIndexCandidate struct {
+	// Columns stores the key columns of the index.
+	Columns []cat.IndexColumn
+
+	// Predicate is the SQL expression of the index predicate.
+	Predicate string
+
+	// predExpr stores the index predicate, used to output the index
+	// recommendation.
+	predExpr tree.Expr
+
+	// filters stores the index predicate as it appears in the query.
+	filters memo.FiltersExpr
+}
+
+// exprColumn is a virtual computed column that is the key of an expression
+// index candidate.
+type exprColumn struct {
+	col *cat.Column
+
+	// scalar is the column's expression, as it appears in the query.
+	scalar opt.ScalarExpr
+
+	// expr is the column's expression, used to output the index recommendation.
+	expr tree.Expr
 }
 
 // indexCandidateSet stores potential indexes that could be recommended for a
@@ -70,6 +131,8 @@ type indexCandidateSet struct {
 	joinCandidates     map[cat.Table][][]cat.IndexColumn
 	invertedCandidates map[cat.Table][][]cat.IndexColumn
 	overallCandidates  map[cat.Table][][]cat.IndexColumn
+	partialCandidates  map[cat.Table][]PartialIndexCandidate
+	exprCols           map[cat.Table][]exprColumn
 }
 
 // init allocates memory for the maps in the set.
@@ -81,6 +144,8 @@ func (ics *indexCandidateSet) init(md *opt.Metadata) {
 	ics.joinCandidates = make(map[cat.Table][][]cat.IndexColumn, numTables)
 	ics.invertedCandidates = make(map[cat.Table][][]cat.IndexColumn, numTables)
 	ics.overallCandidates = make(map[cat.Table][][]cat.IndexColumn, numTables)
+	ics.partialCandidates = make(map[cat.Table][]PartialIndexCandidate, numTables)
+	ics.exprCols = make(map[cat.Table][]exprColumn, numTables)
 }
 
 // combineIndexCandidates adds index candidates that are combinations of
@@ -165,6 +230,16 @@ func (ics *indexCandidateSet) categorizeIndexCandidates(expr opt.Expr) {
 	case *memo.ContainedByExpr:
 		ics.addVariableExprIndex(expr.Left, ics.overallCandidates)
 		ics.addVariableExprIndex(expr.Right, ics.overallCandidates)
+	case *memo.JsonExistsExpr:
+		ics.addVariableExprIndex(expr.Left, ics.overallCandidates)
+	case *memo.JsonSomeExistsExpr:
+		ics.addVariableExprIndex(expr.Left, ics.overallCandidates)
+	case *memo.JsonAllExistsExpr:
+		ics.addVariableExprIndex(expr.Left, ics.overallCandidates)
+	case *memo.SelectExpr:
+		if scan, ok := expr.Input.(*memo.ScanExpr); ok {
+			ics.addPartialIndexes(scan.Table, expr.Filters)
+		}
 	}
 	for i, n := 0, expr.ChildCount(); i < n; i++ {
 		ics.categorizeIndexCandidates(expr.Child(i))
@@ -273,15 +348,17 @@ func constructLeftIndexCombination(
 	outputIndexes map[cat.Table][][]cat.IndexColumn,
 ) {
 	var leftIndexColSet util.FastIntSet
-	// Store left columns in a set for fast access.
+	// Store left column ordinals in a set for fast access. Ordinals are used
+	// rather than column IDs, because the virtual columns of expression index
+	// candidates do not have a stable ID.
 	for _, leftCol := range leftIndex {
-		leftIndexColSet.Add(int(leftCol.ColID()))
+		leftIndexColSet.Add(leftCol.Ordinal())
 	}
 	for _, rightIndex := range rightIndexes {
 		// Remove columns in the right index that exist in the left index.
 		updatedRightIndex := make([]cat.IndexColumn, 0, len(rightIndex))
 		for _, rightCol := range rightIndex {
-			if !leftIndexColSet.Contains(int(rightCol.ColID())) {
+			if !leftIndexColSet.Contains(rightCol.Ordinal()) {
 				updatedRightIndex = append(updatedRightIndex, rightCol)
 			}
 		}
@@ -293,7 +370,7 @@ func constructLeftIndexCombination(
 
 // addVariableExprIndex adds an index candidate to indexCandidates if the expr
 // argument can be cast to a *memo.VariableExpr and the index does not already
-// exist.
+// exist. Otherwise, it tries to add an expression index candidate on expr.
 func (ics *indexCandidateSet) addVariableExprIndex(
 	expr opt.Expr, indexCandidates map[cat.Table][][]cat.IndexColumn,
 ) {
@@ -305,9 +382,166 @@ func (ics *indexCandidateSet) addVariableExprIndex(
 		} else {
 			ics.addSingleColumnIndex(col, false /* desc */, ics.invertedCandidates)
 		}
+	case opt.ScalarExpr:
+		ics.addExprIndex(expr, indexCandidates)
+	}
+}
+
+// addExprIndex adds an expression index candidate to indexCandidates if the
+// expression only references columns of a single table, can be converted to an
+// index expression, and has an indexable type.
+func (ics *indexCandidateSet) addExprIndex(
+	expr opt.ScalarExpr, indexCandidates map[cat.Table][][]cat.IndexColumn,
+) {
+	if memo.CanExtractConstDatum(expr) || !colinfo.ColumnTypeIsIndexable(expr.DataType()) {
+		return
+	}
+	tabID := findColumnTable(ics.md, expr)
+	if tabID == 0 {
+		return
+	}
+	currTable := ics.md.Table(tabID)
+
+	// The virtual columns of expression index candidates are referenced by the
+	// column IDs that follow the IDs of the table's columns. Only add expression
+	// index candidates if those IDs are not already used by other columns.
+	if int(tabID.ColumnID(currTable.ColumnCount()-1)) != ics.md.NumColumns() {
+		return
+	}
+
+	col, ok := ics.addExprColumn(tabID, currTable, expr)
+	if !ok {
+		return
+	}
+	addIndexToCandidates([]cat.IndexColumn{{Column: col}}, currTable, indexCandidates)
+}
+
+// addExprColumn returns the virtual column for the given expression, adding
+// it to exprCols if it does not already exist. It returns ok=false if the
+// expression cannot be converted to an index expression.
+func (ics *indexCandidateSet) addExprColumn(
+	tabID opt.TableID, tab cat.Table, expr opt.ScalarExpr,
+) (_ *cat.Column, ok bool) {
+	// Scalar expressions are interned in the memo, so identical expressions
+	// have the same pointer.
+	for _, exprCol := range ics.exprCols[tab] {
+		if exprCol.scalar == expr {
+			return exprCol.col, true
+		}
+	}
+	indexExpr, ok := buildIndexExpr(ics.md, tabID, expr)
+	if !ok {
+		return nil, false
+	}
+
+	exprColOrd := len(ics.exprCols[tab])
+	col := &cat.Column{}
+	col.InitVirtualComputed(
+		tab.ColumnCount()+exprColOrd,
+		0, /* stableID */
+		tree.Name(fmt.Sprintf("_hyp_expr_%d", exprColOrd+1)),
+		expr.DataType(),
+		true, /* nullable */
+		cat.Inaccessible,
+		tree.Serialize(indexExpr),
+	)
+	ics.exprCols[tab] = append(ics.exprCols[tab], exprColumn{col: col, scalar: expr, expr: indexExpr})
+	return col, true
+}
+
+// findColumnTable returns the table of the first column referenced in the
+// given expression, or 0 if the expression does not reference any column of a
+// base table.
+func findColumnTable(md *opt.Metadata, expr opt.Expr) opt.TableID {
+	if v, ok := expr.(*memo.VariableExpr); ok {
+		return md.ColumnMeta(v.Col).Table
+	}
+	for i, n := 0, expr.ChildCount(); i < n; i++ {
+		if tabID := findColumnTable(md, expr.Child(i)); tabID != 0 {
+			return tabID
+		}
+	}
+	return 0
+}
+
+// addPartialIndexes adds partial index candidates for the filters of a Select
+// on top of a Scan of the given table. The filters that compare a single column
+// to a constant form the predicate of the candidates, and the remaining columns
+// of the table referenced in the other filters form their key columns. One
+// single-column candidate is added for each key column, as well as a candidate
+// on all key columns.
+func (ics *indexCandidateSet) addPartialIndexes(tabID opt.TableID, filters memo.FiltersExpr) {
+	currTable := ics.md.Table(tabID)
+	if currTable.IsVirtualTable() || currTable.IsPartitionAllBy() {
+		return
+	}
+
+	var predFilters memo.FiltersExpr
+	var predExpr tree.Expr
+	var predCols, otherCols opt.ColSet
+	for i := range filters {
+		pred, ok := buildPredicateExpr(ics.md, tabID, filters[i].Condition)
+		if !ok {
+			otherCols.UnionWith(filters[i].ScalarProps().OuterCols)
+			continue
+		}
+		predFilters = append(predFilters, filters[i])
+		predCols.UnionWith(filters[i].ScalarProps().OuterCols)
+		if predExpr == nil {
+			predExpr = pred
+		} else {
+			predExpr = &tree.AndExpr{Left: predExpr, Right: pred}
+		}
+	}
+	if len(predFilters) == 0 {
+		return
+	}
+
+	var keyCols []cat.IndexColumn
+	otherCols.Difference(predCols).ForEach(func(colID opt.ColumnID) {
+		colMeta := ics.md.ColumnMeta(colID)
+		if colMeta.Table != tabID || !colinfo.ColumnTypeIsIndexable(colMeta.Type) {
+			return
+		}
+		colFamily := colMeta.Type.Family()
+		if colFamily == types.GeometryFamily || colFamily == types.GeographyFamily {
+			return
+		}
+		keyCols = append(keyCols, cat.IndexColumn{Column: currTable.Column(tabID.ColumnOrdinal(colID))})
+	})
+	if len(keyCols) == 0 {
+		return
+	}
+
+	candidate := PartialIndexCandidate{
+		Predicate: tree.Serialize(predExpr),
+		predExpr:  predExpr,
+		filters:   predFilters,
+	}
+	for i := range keyCols {
+		candidate.Columns = keyCols[i : i+1]
+		ics.addPartialIndexToCandidates(candidate, currTable)
+	}
+	if len(keyCols) > 1 {
+		candidate.Columns = keyCols
+		ics.addPartialIndexToCandidates(candidate, currTable)
 	}
 }
 
+// addPartialIndexToCandidates adds a partial index to partialCandidates if it
+// does not already exist.
+func (ics *indexCandidateSet) addPartialIndexToCandidates(
+	newIndex PartialIndexCandidate, currTable cat.Table,
+) {
+	for _, existingIndex := range ics.partialCandidates[currTable] {
+		if existingIndex.Predicate == newIndex.Predicate &&
+			indexColumnsEqual(existingIndex.Columns, newIndex.Columns) {
+			return
+		}
+	}
+	ics.partialCandidates[currTable] = append(ics.partialCandidates[currTable], newIndex)
+}
+
 // addMultiColumnIndex adds indexes to indexCandidates for groups of columns
 // in a column set that are from the same table, without duplicates.
 func (ics *indexCandidateSet) addMultiColumnIndex(
@@ -390,17 +624,7 @@ func addIndexToCandidates(
 
 	// Do not add duplicate indexes.
 	for _, existingIndex := range indexCandidates[currTable] {
-		if len(existingIndex) != len(newIndex) {
-			continue
-		}
-		potentialDuplicate := true
-		for i := range existingIndex {
-			if newIndex[i] != existingIndex[i] {
-				potentialDuplicate = false
-				break
-			}
-		}
-		if potentialDuplicate {
+		if indexColumnsEqual(existingIndex, newIndex) {
 			// Duplicate index found, return.
 			return
 		}
@@ -408,3 +632,17 @@ func addIndexToCandidates(
 	// Index does not exist already, so add it.
 	indexCandidates[currTable] = append(indexCandidates[currTable], newIndex)
 }
+
+// indexColumnsEqual returns true if the two lists of index columns are
+// identical.
+func indexColumnsEqual(left, right []cat.IndexColumn) bool {
+	if len(left) != len(right) {
+		return false
+	}
+	for i := range left {
+		if left[i] != right[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,153 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package indexrec
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treebin"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+)
+
+// buildIndexExpr converts a scalar expression into an equivalent tree.Expr
+// that can be used as the key of an expression index on the given table. It
+// returns ok=false if the expression cannot be indexed, either because it
+// references columns from other tables, because it contains an operator that
+// is not supported, or because it is not immutable.
+//
+// Only column references, constants, binary operators (including the JSON
+// fetch operators) and function calls are supported.
+func buildIndexExpr(
+	md *opt.Metadata, tabID opt.TableID, e opt.ScalarExpr,
+) (_ tree.Expr, ok bool) {
+	switch t := e.(type) {
+	case *memo.VariableExpr:
+		return buildColumnItem(md, tabID, t.Col)
+
+	case *memo.FunctionExpr:
+		if t.Overload.Volatility > tree.VolatilityImmutable {
+			return nil, false
+		}
+		args := make(tree.Exprs, len(t.Args))
+		for i := range t.Args {
+			if args[i], ok = buildIndexExpr(md, tabID, t.Args[i]); !ok {
+				return nil, false
+			}
+		}
+		return &tree.FuncExpr{Func: tree.WrapFunction(t.Name), Exprs: args}, true
+	}
+
+	if memo.CanExtractConstDatum(e) {
+		return memo.ExtractConstDatum(e), true
+	}
+
+	if sym, isBinary := opt.BinaryOpReverseMap[e.Op()]; isBinary {
+		left, right := e.Child(0).(opt.ScalarExpr), e.Child(1).(opt.ScalarExpr)
+		overload, found := memo.FindBinaryOverload(e.Op(), left.DataType(), right.DataType())
+		if !found || overload.Volatility > tree.VolatilityImmutable {
+			return nil, false
+		}
+		leftExpr, ok := buildIndexExpr(md, tabID, left)
+		if !ok {
+			return nil, false
+		}
+		rightExpr, ok := buildIndexExpr(md, tabID, right)
+		if !ok {
+			return nil, false
+		}
+		return &tree.BinaryExpr{
+			Operator: treebin.MakeBinaryOperator(sym),
+			Left:     leftExpr,
+			Right:    rightExpr,
+		}, true
+	}
+
+	return nil, false
+}
+
+// buildPredicateExpr converts a filter condition into an equivalent tree.Expr
+// that can be used in the predicate of a partial index on the given table. It
+// returns ok=false if the condition is not a constant filter on a single
+// column of the table. The supported conditions are:
+//
+//   col = <constant>
+//   col IS NULL
+//   col IS NOT NULL
+//   col
+//   NOT col
+//
+func buildPredicateExpr(
+	md *opt.Metadata, tabID opt.TableID, e opt.ScalarExpr,
+) (_ tree.Expr, ok bool) {
+	switch t := e.(type) {
+	case *memo.VariableExpr:
+		return buildColumnItem(md, tabID, t.Col)
+
+	case *memo.NotExpr:
+		if v, isVar := t.Input.(*memo.VariableExpr); isVar {
+			col, ok := buildColumnItem(md, tabID, v.Col)
+			if !ok {
+				return nil, false
+			}
+			return &tree.NotExpr{Expr: col}, true
+		}
+
+	case *memo.EqExpr:
+		if v, isVar := t.Left.(*memo.VariableExpr); isVar && memo.CanExtractConstDatum(t.Right) {
+			col, ok := buildColumnItem(md, tabID, v.Col)
+			if !ok || t.Right.Op() == opt.NullOp {
+				return nil, false
+			}
+			return &tree.ComparisonExpr{
+				Operator: treecmp.MakeComparisonOperator(treecmp.EQ),
+				Left:     col,
+				Right:    memo.ExtractConstDatum(t.Right),
+			}, true
+		}
+
+	case *memo.IsExpr:
+		if v, isVar := t.Left.(*memo.VariableExpr); isVar && t.Right.Op() == opt.NullOp {
+			col, ok := buildColumnItem(md, tabID, v.Col)
+			if !ok {
+				return nil, false
+			}
+			return &tree.IsNullExpr{Expr: col}, true
+		}
+
+	case *memo.IsNotExpr:
+		if v, isVar := t.Left.(*memo.VariableExpr); isVar && t.Right.Op() == opt.NullOp {
+			col, ok := buildColumnItem(md, tabID, v.Col)
+			if !ok {
+				return nil, false
+			}
+			return &tree.IsNotNullExpr{Expr: col}, true
+		}
+	}
+	return nil, false
+}
+
+// buildColumnItem returns a reference to the given column by name. It returns
+// ok=false if the column is not an ordinary, accessible column of the given
+// table.
+func buildColumnItem(
+	md *opt.Metadata, tabID opt.TableID, colID opt.ColumnID,
+) (_ tree.Expr, ok bool) {
+	if md.ColumnMeta(colID).Table != tabID {
+		return nil, false
+	}
+	col := md.Table(tabID).Column(tabID.ColumnOrdinal(colID))
+	if col.Kind() != cat.Ordinary || col.Visibility() == cat.Inaccessible {
+		return nil, false
+	}
+	return &tree.ColumnItem{ColumnName: col.ColName()}, true
+}
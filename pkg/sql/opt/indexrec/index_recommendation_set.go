@@ -183,6 +183,27 @@ func (irs *IndexRecommendationSet) Output() []string {
 	return output
 }
 
+// StatsOutput returns a string slice of index recommendations that is stored
+// in the statement statistics. Each recommendation has the form
+// "<type> : <SQL commands>", where the type is either "creation" or
+// "replacement". Recommendations are sorted so that the output is
+// deterministic.
+func (irs *IndexRecommendationSet) StatsOutput() []string {
+	var output []string
+	for _, indexRecs := range irs.indexRecs {
+		for _, indexRec := range indexRecs {
+			recType := "creation"
+			if indexRec.existingIndex != nil {
+				recType = "replacement"
+			}
+			indexRecSQL := indexRec.indexRecommendationSQL(indexRec.indexCols(), indexRec.storingColumns())
+			output = append(output, recType+" : "+indexRecSQL)
+		}
+	}
+	sort.Strings(output)
+	return output
+}
+
 // indexRecommendation stores the information pertaining to a single index
 // recommendation.
 type indexRecommendation struct {
@@ -265,6 +286,12 @@ func (ir *indexRecommendation) indexCols() []tree.IndexElem {
 			direction = tree.Descending
 		}
 
+		// The key of a hypothetical expression index is an expression rather
+		// than a column.
+		if expr := ir.index.tab.indexExpr(indexCol.Ordinal()); expr != nil {
+			indexCols[i] = tree.IndexElem{Expr: expr, Direction: direction}
+			continue
+		}
 		indexCols[i] = tree.IndexElem{Column: colName, Direction: direction}
 	}
 
@@ -291,6 +318,17 @@ func (ir *indexRecommendation) storingColumns() []tree.Name {
 // recommendation.
 func (ir *indexRecommendation) indexRecommendationString(
 	indexCols []tree.IndexElem, storing []tree.Name,
+) string {
+	if ir.existingIndex != nil {
+		return "   SQL commands: " + ir.indexRecommendationSQL(indexCols, storing)
+	}
+	return "   SQL command: " + ir.indexRecommendationSQL(indexCols, storing)
+}
+
+// indexRecommendationSQL returns the SQL command(s) needed to follow an index
+// recommendation.
+func (ir *indexRecommendation) indexRecommendationSQL(
+	indexCols []tree.IndexElem, storing []tree.Name,
 ) string {
 	var sb strings.Builder
 	tableName := tree.NewUnqualifiedTableName(ir.index.tab.Name())
@@ -298,14 +336,11 @@ func (ir *indexRecommendation) indexRecommendationString(
 	var dropCmd tree.DropIndex
 	unique := false
 	if ir.existingIndex != nil {
-		sb.WriteString("   SQL commands: ")
 		indexName := tree.UnrestrictedName(ir.existingIndex.Name())
 		dropCmd.IndexList = []*tree.TableIndexName{{Table: *tableName, Index: indexName}}
 
 		// Maintain uniqueness if the existing index is unique.
 		unique = ir.existingIndex.IsUnique()
-	} else {
-		sb.WriteString("   SQL command: ")
 	}
 
 	createCmd := tree.CreateIndex{
@@ -315,6 +350,9 @@ func (ir *indexRecommendation) indexRecommendationString(
 		Unique:   unique,
 		Inverted: ir.index.IsInverted(),
 	}
+	if _, isPartialIndex := ir.index.Predicate(); isPartialIndex {
+		createCmd.Predicate = ir.index.predicateExpr
+	}
 	sb.WriteString(createCmd.String() + ";")
 	if len(dropCmd.IndexList) > 0 {
 		sb.WriteString(" " + dropCmd.String() + ";")
@@ -164,6 +164,20 @@ func getInvertedExprForArrayIndexForOverlaps(
 	return invertedExpr
 }
 
+// getInvertedExprForJSONIndexForExists gets an inverted.Expression that
+// constrains a JSON index according to the given constant string. This
+// results in a span expression representing the union of the paths through
+// the JSON that have the string as a top-level key or element.
+func getInvertedExprForJSONIndexForExists(
+	evalCtx *tree.EvalContext, d tree.Datum,
+) inverted.Expression {
+	invertedExpr, err := rowenc.EncodeExistsInvertedIndexSpans(evalCtx, d)
+	if err != nil {
+		panic(err)
+	}
+	return invertedExpr
+}
+
 type jsonOrArrayInvertedExpr struct {
 	tree.ComparisonExpr
 
@@ -365,6 +379,12 @@ func (j *jsonOrArrayFilterPlanner) extractInvertedFilterConditionFromLeaf(
 		}
 	case *memo.OverlapsExpr:
 		invertedExpr = j.extractArrayOverlapsCondition(evalCtx, t.Left, t.Right)
+	case *memo.JsonExistsExpr:
+		invertedExpr = j.extractJSONExistsCondition(evalCtx, t.Left, t.Right, false /* all */)
+	case *memo.JsonSomeExistsExpr:
+		invertedExpr = j.extractJSONExistsCondition(evalCtx, t.Left, t.Right, false /* all */)
+	case *memo.JsonAllExistsExpr:
+		invertedExpr = j.extractJSONExistsCondition(evalCtx, t.Left, t.Right, true /* all */)
 	}
 
 	if invertedExpr == nil {
@@ -408,6 +428,47 @@ func (j *jsonOrArrayFilterPlanner) extractArrayOverlapsCondition(
 	return getInvertedExprForArrayIndexForOverlaps(evalCtx, memo.ExtractConstDatum(constantVal))
 }
 
+// extractJSONExistsCondition extracts an InvertedExpression representing an
+// inverted filter over the planner's inverted index, based on the given left
+// and right arguments of a ?, ?| or ?& expression. The right argument must be
+// a constant string or string array. If all is true, the JSON must have all of
+// the keys in the array (?&). Otherwise, it must have at least one of them.
+// Returns an empty InvertedExpression if no inverted filter could be
+// extracted.
+func (j *jsonOrArrayFilterPlanner) extractJSONExistsCondition(
+	evalCtx *tree.EvalContext, left, right opt.ScalarExpr, all bool,
+) inverted.Expression {
+	if left.DataType().Family() != types.JsonFamily ||
+		!isIndexColumn(j.tabID, j.index, left, j.computedColumns) ||
+		!memo.CanExtractConstDatum(right) {
+		return inverted.NonInvertedColExpression{}
+	}
+	d := memo.ExtractConstDatum(right)
+	keys := tree.Datums{d}
+	if arr, ok := d.(*tree.DArray); ok {
+		// NULL elements are ignored by ?| and ?&, and ?& is true for every JSON
+		// value when the array is empty, so do not try to use the index in those
+		// cases.
+		if arr.HasNulls || arr.Len() == 0 {
+			return inverted.NonInvertedColExpression{}
+		}
+		keys = arr.Array
+	}
+	var invertedExpr inverted.Expression
+	for _, key := range keys {
+		keyExpr := getInvertedExprForJSONIndexForExists(evalCtx, key)
+		switch {
+		case invertedExpr == nil:
+			invertedExpr = keyExpr
+		case all:
+			invertedExpr = inverted.And(invertedExpr, keyExpr)
+		default:
+			invertedExpr = inverted.Or(invertedExpr, keyExpr)
+		}
+	}
+	return invertedExpr
+}
+
 // extractJSONOrArrayContainsCondition extracts an InvertedExpression
 // representing an inverted filter over the planner's inverted index, based
 // on the given left and right expression arguments. Returns an empty
@@ -794,6 +794,51 @@ func TestTryFilterJsonOrArrayIndex(t *testing.T) {
 			unique:           true,
 			remainingFilters: "str <@ '{hello}'",
 		},
+		{
+			// Exists is supported for json. The spans may have duplicate primary
+			// keys, since a key's value can be an object or array.
+			filters:  "j ? 'a'",
+			indexOrd: jsonOrd,
+			ok:       true,
+			tight:    true,
+			unique:   false,
+		},
+		{
+			filters:  "j ?| ARRAY['a', 'b']",
+			indexOrd: jsonOrd,
+			ok:       true,
+			tight:    true,
+			unique:   false,
+		},
+		{
+			filters:  "j ?& ARRAY['a', 'b']",
+			indexOrd: jsonOrd,
+			ok:       true,
+			tight:    true,
+			unique:   false,
+		},
+		{
+			// The empty key is a prefix of the encoding of every top-level scalar,
+			// so the spans are not tight.
+			filters:          "j ? ''",
+			indexOrd:         jsonOrd,
+			ok:               true,
+			tight:            false,
+			unique:           false,
+			remainingFilters: "j ? ''",
+		},
+		{
+			// ?& is true for every JSON value if the array is empty.
+			filters:  "j ?& ARRAY[]::STRING[]",
+			indexOrd: jsonOrd,
+			ok:       false,
+		},
+		{
+			// Exists is not supported for arrays.
+			filters:  "j ? 'a'",
+			indexOrd: arrayOrd,
+			ok:       false,
+		},
 		{
 			// If all the expressions in a conjunction produce unique results,
 			// then the AND-ed result is also unique.
@@ -0,0 +1,124 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package invertedidx
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/invertedexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/trigram"
+)
+
+type trigramFilterPlanner struct {
+	tabID           opt.TableID
+	index           cat.Index
+	computedColumns map[opt.ColumnID]opt.ScalarExpr
+}
+
+var _ invertedFilterPlanner = &trigramFilterPlanner{}
+
+// extractInvertedFilterConditionFromLeaf is part of the invertedFilterPlanner
+// interface.
+func (t *trigramFilterPlanner) extractInvertedFilterConditionFromLeaf(
+	evalCtx *tree.EvalContext, expr opt.ScalarExpr,
+) (
+	invertedExpr inverted.Expression,
+	remainingFilters opt.ScalarExpr,
+	_ *invertedexpr.PreFiltererStateForInvertedFilterer,
+) {
+	switch e := expr.(type) {
+	case *memo.LikeExpr:
+		invertedExpr = t.extractLikeCondition(e.Left, e.Right)
+	case *memo.ILikeExpr:
+		// Trigrams are lowercased, so LIKE and ILIKE patterns produce the same
+		// trigrams.
+		invertedExpr = t.extractLikeCondition(e.Left, e.Right)
+	case *memo.ModExpr:
+		// The string % string operator is the trigram similarity operator.
+		if e.Left.DataType().Family() == types.StringFamily &&
+			e.Right.DataType().Family() == types.StringFamily {
+			invertedExpr = t.extractSimilarityCondition(evalCtx, e.Left, e.Right)
+		}
+	}
+
+	if invertedExpr == nil {
+		// An inverted expression could not be extracted.
+		return inverted.NonInvertedColExpression{}, expr, nil
+	}
+
+	// Trigram spans are never tight, since two strings sharing trigrams does
+	// not imply that one matches the other, so the original filter must always
+	// be applied after the inverted index scan.
+	if !invertedExpr.IsTight() {
+		remainingFilters = expr
+	}
+
+	// We do not currently support pre-filtering for trigram indexes, so the
+	// returned pre-filter state is nil.
+	return invertedExpr, remainingFilters, nil
+}
+
+// extractLikeCondition extracts an InvertedExpression representing an
+// inverted filter over the planner's inverted index, based on the given left
+// and right arguments of a LIKE or ILIKE expression. Any string matching the
+// pattern must contain all of the pattern's trigrams. Returns an empty
+// InvertedExpression if no inverted filter could be extracted.
+func (t *trigramFilterPlanner) extractLikeCondition(
+	left, right opt.ScalarExpr,
+) inverted.Expression {
+	if !isIndexColumn(t.tabID, t.index, left, t.computedColumns) ||
+		!memo.CanExtractConstDatum(right) {
+		return inverted.NonInvertedColExpression{}
+	}
+	d, ok := memo.ExtractConstDatum(right).(*tree.DString)
+	if !ok {
+		return inverted.NonInvertedColExpression{}
+	}
+	trigrams := trigram.MakeTrigramsForLikePattern(string(*d))
+	return rowenc.EncodeTrigramSpans(trigrams, true /* allMustMatch */)
+}
+
+// extractSimilarityCondition extracts an InvertedExpression representing an
+// inverted filter over the planner's inverted index, based on the given left
+// and right arguments of a % expression. A string similar to the constant
+// argument must share at least one of its trigrams. Returns an empty
+// InvertedExpression if no inverted filter could be extracted.
+func (t *trigramFilterPlanner) extractSimilarityCondition(
+	evalCtx *tree.EvalContext, left, right opt.ScalarExpr,
+) inverted.Expression {
+	// With a threshold of 0, strings that share no trigrams at all are
+	// similar, so the index cannot be used.
+	if evalCtx.SessionData().TrigramSimilarityThreshold <= 0 {
+		return inverted.NonInvertedColExpression{}
+	}
+	var constantVal opt.ScalarExpr
+	if isIndexColumn(t.tabID, t.index, left, t.computedColumns) &&
+		memo.CanExtractConstDatum(right) {
+		constantVal = right
+	} else if isIndexColumn(t.tabID, t.index, right, t.computedColumns) &&
+		memo.CanExtractConstDatum(left) {
+		// The % operator is commutative, so the same applies when the index
+		// column is the second argument.
+		constantVal = left
+	} else {
+		return inverted.NonInvertedColExpression{}
+	}
+	d, ok := memo.ExtractConstDatum(constantVal).(*tree.DString)
+	if !ok {
+		return inverted.NonInvertedColExpression{}
+	}
+	return rowenc.EncodeTrigramSpans(trigram.MakeTrigrams(string(*d)), false /* allMustMatch */)
+}
@@ -1050,6 +1050,35 @@ type FKCascade struct {
 	// It is empty if the mutation is a deletion. Empty if the cascade does not
 	// require input.
 	NewValues opt.ColList
+
+	// ForEachRow is set if the Builder must be invoked once for each row of the
+	// mutation input (e.g. for row-level triggers). In that case, the values of
+	// NewValues and then OldValues for the current row are bound to placeholders
+	// (in that order) instead of being scanned with a WithScan; a zero column ID
+	// is bound to NULL.
+	ForEachRow bool
+
+	// RowFilterCol is an optional boolean column from the mutation input. If it
+	// is set, the Builder is only invoked for the rows in which it is true (e.g.
+	// row-level INSERT triggers of an upsert only fire for the inserted rows).
+	// It can only be set together with ForEachRow.
+	RowFilterCol opt.ColumnID
+
+	// Before is set if the cascade must run before the mutation, once the
+	// mutation input has been fully buffered (e.g. for BEFORE row-level
+	// triggers). It can only be set together with ForEachRow.
+	Before bool
+}
+
+// HasBefore returns true if any of the cascades must run before the mutation
+// (see FKCascade.Before).
+func (c FKCascades) HasBefore() bool {
+	for i := range c {
+		if c[i].Before {
+			return true
+		}
+	}
+	return false
 }
 
 // CascadeBuilder is an interface used to construct a cascading query for a
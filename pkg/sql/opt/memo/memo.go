@@ -152,6 +152,7 @@ type Memo struct {
 	largeFullScanRows           float64
 	nullOrderedLast             bool
 	costScansWithDefaultColSize bool
+	trigramSimilarityThreshold  float64
 
 	// curRank is the highest currently in-use scalar expression rank.
 	curRank opt.ScalarRank
@@ -199,6 +200,7 @@ func (m *Memo) Init(evalCtx *tree.EvalContext) {
 		largeFullScanRows:           evalCtx.SessionData().LargeFullScanRows,
 		nullOrderedLast:             evalCtx.SessionData().NullOrderedLast,
 		costScansWithDefaultColSize: evalCtx.SessionData().CostScansWithDefaultColSize,
+		trigramSimilarityThreshold:  evalCtx.SessionData().TrigramSimilarityThreshold,
 	}
 	m.metadata.Init()
 	m.logPropsBuilder.init(evalCtx, m)
@@ -323,7 +325,8 @@ func (m *Memo) IsStale(
 		m.disallowFullTableScans != evalCtx.SessionData().DisallowFullTableScans ||
 		m.largeFullScanRows != evalCtx.SessionData().LargeFullScanRows ||
 		m.nullOrderedLast != evalCtx.SessionData().NullOrderedLast ||
-		m.costScansWithDefaultColSize != evalCtx.SessionData().CostScansWithDefaultColSize {
+		m.costScansWithDefaultColSize != evalCtx.SessionData().CostScansWithDefaultColSize ||
+		m.trigramSimilarityThreshold != evalCtx.SessionData().TrigramSimilarityThreshold {
 		return true, nil
 	}
 
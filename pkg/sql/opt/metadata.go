@@ -98,6 +98,10 @@ type Metadata struct {
 	userDefinedTypes      map[oid.Oid]struct{}
 	userDefinedTypesSlice []*types.T
 
+	// userDefinedFunctions contains the overloads of all user-defined functions
+	// called by the query, keyed by their OID.
+	userDefinedFunctions map[oid.Oid]*tree.Overload
+
 	// deps stores information about all data source objects depended on by the
 	// query, as well as the privileges required to access them. The objects are
 	// deduplicated: any name/object pair shows up at most once.
@@ -107,6 +111,11 @@ type Metadata struct {
 	// we want to verify the resolution of both names.
 	deps []mdDep
 
+	// rlsDeps stores the row-level security policies that were applied to the
+	// tables referenced by the query. Since the applicable policies depend on
+	// the current user, they must be recomputed before reusing the query.
+	rlsDeps []mdRLSDep
+
 	// views stores the list of referenced views. This information is only
 	// needed for EXPLAIN (opt, env).
 	views []cat.View
@@ -131,6 +140,16 @@ type mdDep struct {
 	privileges privilegeBitmap
 }
 
+type mdRLSDep struct {
+	tab cat.Table
+
+	// enforced is true if row-level security was enforced on the table.
+	enforced bool
+
+	// policies are the ordinals of the policies applied to the table.
+	policies []int
+}
+
 // MDDepName stores either the unresolved DataSourceName or the StableID from
 // the query that was used to resolve a data source.
 type MDDepName struct {
@@ -202,8 +221,9 @@ func (md *Metadata) Init() {
 // expression.
 func (md *Metadata) CopyFrom(from *Metadata, copyScalarFn func(Expr) Expr) {
 	if len(md.schemas) != 0 || len(md.cols) != 0 || len(md.tables) != 0 ||
-		len(md.sequences) != 0 || len(md.deps) != 0 || len(md.views) != 0 ||
-		len(md.userDefinedTypes) != 0 || len(md.userDefinedTypesSlice) != 0 {
+		len(md.sequences) != 0 || len(md.deps) != 0 || len(md.rlsDeps) != 0 ||
+		len(md.views) != 0 || len(md.userDefinedTypes) != 0 || len(md.userDefinedTypesSlice) != 0 ||
+		len(md.userDefinedFunctions) != 0 {
 		panic(errors.AssertionFailedf("CopyFrom requires empty destination"))
 	}
 	md.schemas = append(md.schemas, from.schemas...)
@@ -215,11 +235,18 @@ func (md *Metadata) CopyFrom(from *Metadata, copyScalarFn func(Expr) Expr) {
 		}
 		for i := range from.userDefinedTypesSlice {
 			typ := from.userDefinedTypesSlice[i]
-			md.userDefinedTypes[typ.Oid()] = struct{}{}
+			md.userDefinedTypes[typ.UserDefinedOID()] = struct{}{}
 			md.userDefinedTypesSlice = append(md.userDefinedTypesSlice, typ)
 		}
 	}
 
+	if len(from.userDefinedFunctions) > 0 {
+		md.userDefinedFunctions = make(map[oid.Oid]*tree.Overload, len(from.userDefinedFunctions))
+		for o, ov := range from.userDefinedFunctions {
+			md.userDefinedFunctions[o] = ov
+		}
+	}
+
 	if cap(md.tables) >= len(from.tables) {
 		md.tables = md.tables[:len(from.tables)]
 	} else {
@@ -232,6 +259,7 @@ func (md *Metadata) CopyFrom(from *Metadata, copyScalarFn func(Expr) Expr) {
 
 	md.sequences = append(md.sequences, from.sequences...)
 	md.deps = append(md.deps, from.deps...)
+	md.rlsDeps = append(md.rlsDeps, from.rlsDeps...)
 	md.views = append(md.views, from.views...)
 	md.currUniqueID = from.currUniqueID
 
@@ -270,6 +298,18 @@ func (md *Metadata) AddDependency(name MDDepName, ds cat.DataSource, priv privil
 	})
 }
 
+// AddRowLevelSecurityDependency records the row-level security policies that
+// were applied to the given table, so that CheckDependencies can detect when
+// they no longer apply to the current user.
+func (md *Metadata) AddRowLevelSecurityDependency(tab cat.Table, enforced bool, policies []int) {
+	for i := range md.rlsDeps {
+		if md.rlsDeps[i].tab == tab {
+			return
+		}
+	}
+	md.rlsDeps = append(md.rlsDeps, mdRLSDep{tab: tab, enforced: enforced, policies: policies})
+}
+
 // CheckDependencies resolves (again) each data source on which this metadata
 // depends, in order to check that all data source names resolve to the same
 // objects, and that the user still has sufficient privileges to access the
@@ -318,9 +358,26 @@ func (md *Metadata) CheckDependencies(
 			privs &= ^(1 << priv)
 		}
 	}
+	// Check that the same row-level security policies apply to the current
+	// user. The tables themselves have already been checked above.
+	for i := range md.rlsDeps {
+		dep := &md.rlsDeps[i]
+		policies, enforced, err := cat.ApplicablePolicies(ctx, catalog, dep.tab)
+		if err != nil {
+			return false, err
+		}
+		if enforced != dep.enforced || len(policies) != len(dep.policies) {
+			return false, nil
+		}
+		for j := range policies {
+			if policies[j] != dep.policies[j] {
+				return false, nil
+			}
+		}
+	}
 	// Check that all of the user defined types present have not changed.
 	for _, typ := range md.AllUserDefinedTypes() {
-		toCheck, err := catalog.ResolveTypeByOID(ctx, typ.Oid())
+		toCheck, err := catalog.ResolveTypeByOID(ctx, typ.UserDefinedOID())
 		if err != nil {
 			// Handle when the type no longer exists.
 			if pgerror.GetPGCode(err) == pgcode.UndefinedObject {
@@ -332,6 +389,21 @@ func (md *Metadata) CheckDependencies(
 			return false, nil
 		}
 	}
+	// Check that all of the user-defined functions called by the query have not
+	// changed and are still executable by the current user.
+	for o, ov := range md.userDefinedFunctions {
+		toCheck, err := catalog.ResolveFunctionByOID(ctx, o)
+		if err != nil {
+			// Handle when the function no longer exists.
+			if pgerror.GetPGCode(err) == pgcode.UndefinedFunction {
+				return false, nil
+			}
+			return false, err
+		}
+		if ov.UDFVersion != toCheck.UDFVersion {
+			return false, nil
+		}
+	}
 	return true, nil
 }
 
@@ -355,8 +427,8 @@ func (md *Metadata) AddUserDefinedType(typ *types.T) {
 	if md.userDefinedTypes == nil {
 		md.userDefinedTypes = make(map[oid.Oid]struct{})
 	}
-	if _, ok := md.userDefinedTypes[typ.Oid()]; !ok {
-		md.userDefinedTypes[typ.Oid()] = struct{}{}
+	if _, ok := md.userDefinedTypes[typ.UserDefinedOID()]; !ok {
+		md.userDefinedTypes[typ.UserDefinedOID()] = struct{}{}
 		md.userDefinedTypesSlice = append(md.userDefinedTypesSlice, typ)
 	}
 }
@@ -366,6 +438,15 @@ func (md *Metadata) AllUserDefinedTypes() []*types.T {
 	return md.userDefinedTypesSlice
 }
 
+// AddUserDefinedFunction adds the overload of a user-defined function called
+// by the query to the metadata for this query.
+func (md *Metadata) AddUserDefinedFunction(ov *tree.Overload) {
+	if md.userDefinedFunctions == nil {
+		md.userDefinedFunctions = make(map[oid.Oid]*tree.Overload)
+	}
+	md.userDefinedFunctions[ov.Oid] = ov
+}
+
 // AddTable indexes a new reference to a table within the query. Separate
 // references to the same table are assigned different table ids (e.g.  in a
 // self-join query). All columns are added to the metadata. If mutation columns
@@ -609,13 +690,18 @@ func (md *Metadata) QualifiedAlias(colID ColumnID, fullyQualify bool, catalog ca
 // UpdateTableMeta allows the caller to replace the cat.Table struct that a
 // TableMeta instance stores.
 func (md *Metadata) UpdateTableMeta(tables map[cat.StableID]cat.Table) {
-	for i := range md.tables {
+	// Iterate over the tables in reverse so that any new columns of the last
+	// table added to the metadata are assigned the IDs that directly follow its
+	// existing columns, which are the IDs returned by TableID.ColumnID.
+	for i := len(md.tables) - 1; i >= 0; i-- {
 		if tab, ok := tables[md.tables[i].Table.ID()]; ok {
-			// If there are any inverted hypothetical indexes, the hypothetical table
-			// will have extra inverted columns added. Add any new inverted columns to
-			// the metadata.
+			// If there are any inverted or expression hypothetical indexes, the
+			// hypothetical table will have extra inverted or virtual computed columns
+			// added. Add any new columns to the metadata.
 			for j, n := md.tables[i].Table.ColumnCount(), tab.ColumnCount(); j < n; j++ {
-				md.AddColumn(string(tab.Column(i).ColName()), types.Bytes)
+				col := tab.Column(j)
+				colID := md.AddColumn(string(col.ColName()), col.DatumType())
+				md.ColumnMeta(colID).Table = md.tables[i].MetaID
 			}
 			md.tables[i].Table = tab
 		}
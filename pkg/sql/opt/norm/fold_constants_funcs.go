@@ -585,6 +585,12 @@ func (c *CustomFuncs) FoldFunction(
 		return nil, false
 	}
 
+	// User-defined functions which were not inlined are evaluated by running
+	// their body as a separate query, which is not done during optimization.
+	if private.Overload.IsUDF {
+		return nil, false
+	}
+
 	exprs := make(tree.TypedExprs, len(args))
 	for i := range exprs {
 		exprs[i] = memo.ExtractConstDatum(args[i])
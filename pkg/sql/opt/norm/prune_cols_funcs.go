@@ -78,6 +78,18 @@ func (c *CustomFuncs) neededMutationCols(
 		cols.Add(private.CanaryCol)
 	}
 
+	// Add the columns that are bound to the values of each row passed to
+	// row-level triggers.
+	for i := range private.FKCascades {
+		if private.FKCascades[i].ForEachRow {
+			addCols(opt.OptionalColList(private.FKCascades[i].OldValues))
+			addCols(opt.OptionalColList(private.FKCascades[i].NewValues))
+			if private.FKCascades[i].RowFilterCol != 0 {
+				cols.Add(private.FKCascades[i].RowFilterCol)
+			}
+		}
+	}
+
 	if private.WithID != 0 {
 		for i := range uniqueChecks {
 			withUses := memo.WithUses(uniqueChecks[i].Check)
@@ -145,6 +157,22 @@ func (c *CustomFuncs) NeededMutationFetchCols(
 		}
 	}
 
+	// Retain any FetchCols that are bound to the values of each row passed to
+	// row-level triggers.
+	for i := range private.FKCascades {
+		if !private.FKCascades[i].ForEachRow {
+			continue
+		}
+		var triggerCols opt.ColSet
+		triggerCols.UnionWith(opt.OptionalColList(private.FKCascades[i].OldValues).ToSet())
+		triggerCols.UnionWith(opt.OptionalColList(private.FKCascades[i].NewValues).ToSet())
+		for ord, col := range private.FetchCols {
+			if col != 0 && triggerCols.Contains(col) {
+				cols.Add(tabMeta.MetaID.ColumnID(ord))
+			}
+		}
+	}
+
 	switch op {
 	case opt.UpdateOp, opt.UpsertOp:
 		// Determine set of target table columns that need to be updated.
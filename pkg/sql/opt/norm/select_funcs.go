@@ -45,6 +45,13 @@ func (c *CustomFuncs) CanMapOnSetOp(filter *memo.FiltersItem) bool {
 	return true
 }
 
+// IsLeakproofFilter returns true if the given filter is leakproof, meaning
+// that it cannot raise an error or otherwise reveal anything about the rows it
+// is evaluated on, other than through its result.
+func (c *CustomFuncs) IsLeakproofFilter(filter *memo.FiltersItem) bool {
+	return filter.ScalarProps().VolatilitySet.IsLeakProof()
+}
+
 // MapSetOpFilterLeft maps the filter onto the left expression by replacing
 // the out columns of the filter with the appropriate corresponding columns in
 // the left side of the operator.
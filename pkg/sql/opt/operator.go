@@ -144,6 +144,8 @@ var ComparisonOpReverseMap = map[Operator]treecmp.ComparisonOperatorSymbol{
 	JsonSomeExistsOp: treecmp.JSONSomeExists,
 	JsonAllExistsOp:  treecmp.JSONAllExists,
 	OverlapsOp:       treecmp.Overlaps,
+	AdjacentOp:       treecmp.Adjacent,
+	TSMatchesOp:      treecmp.TSMatches,
 	BBoxCoversOp:     treecmp.RegMatch,
 	BBoxIntersectsOp: treecmp.Overlaps,
 }
@@ -0,0 +1,47 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// buildCopyTo builds a COPY ... TO STDOUT statement. The statement returns the
+// rows of its query, or of the given columns of the table; they are encoded in
+// the COPY format by the client connection.
+func (b *Builder) buildCopyTo(copyTo *tree.CopyTo, inScope *scope) (outScope *scope) {
+	// Validate the options during planning so that invalid options are
+	// reported before any row is sent to the client.
+	if _, err := tree.MakeCopyOutSettings(&copyTo.Options); err != nil {
+		panic(err)
+	}
+
+	stmt := copyTo.Statement
+	if stmt == nil {
+		exprs := tree.SelectExprs{tree.StarSelectExpr()}
+		if len(copyTo.Columns) > 0 {
+			exprs = make(tree.SelectExprs, len(copyTo.Columns))
+			for i := range copyTo.Columns {
+				exprs[i].Expr = &tree.ColumnItem{ColumnName: copyTo.Columns[i]}
+			}
+		}
+		stmt = &tree.Select{Select: &tree.SelectClause{
+			Exprs: exprs,
+			From:  tree.From{Tables: tree.TableExprs{&copyTo.Table}},
+		}}
+	} else if stmt.StatementReturnType() != tree.Rows {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"COPY query must have a RETURNING clause"))
+	}
+	return b.buildStmt(stmt, nil /* desiredTypes */, inScope)
+}
@@ -0,0 +1,119 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
+)
+
+// BuildDependencies builds the given statement (e.g. the body of a trigger or
+// of a user-defined function) in order to check it semantically and to collect
+// the objects it depends on. The result of the build is not otherwise used.
+//
+// The relations are collected in the same way as the dependencies of a view
+// (see buildCreateView); in addition, the table mutated by an INSERT, UPDATE,
+// UPSERT or DELETE statement is a dependency. The returned type dependencies
+// contain the IDs of all user-defined types referenced by the statement, and
+// the function dependencies contain the OIDs of the user-defined functions it
+// calls.
+func (b *Builder) BuildDependencies(
+	stmt tree.Statement,
+) (deps opt.ViewDeps, typeDeps opt.ViewTypeDeps, funcDeps opt.ViewFuncDeps, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			// See Builder.Build.
+			if ok, e := errorutil.ShouldCatch(r); ok {
+				err = e
+			} else {
+				panic(r)
+			}
+		}
+	}()
+
+	// Record all of the user-defined types that are resolved while building
+	// the statement (see Builder.Build).
+	existingResolver := b.semaCtx.TypeResolver
+	defer func() { b.semaCtx.TypeResolver = existingResolver }()
+	b.semaCtx.TypeResolver = &optTrackingTypeResolver{
+		res:      b.semaCtx.TypeResolver,
+		metadata: b.factory.Metadata(),
+	}
+
+	b.DisableMemoReuse = true
+	b.trackViewDeps = true
+	defer func() {
+		b.trackViewDeps = false
+		b.viewDeps = nil
+		b.viewTypeDeps = util.FastIntSet{}
+		b.viewFuncDeps = util.FastIntSet{}
+	}()
+
+	b.buildStmtAtRoot(stmt, nil /* desiredTypes */)
+
+	typeDeps = b.viewTypeDeps.Copy()
+	for _, typ := range b.factory.Metadata().AllUserDefinedTypes() {
+		children, err := typedesc.GetTypeDescriptorClosure(typ)
+		if err != nil {
+			panic(err)
+		}
+		for id := range children {
+			typeDeps.Add(int(id))
+		}
+	}
+	return b.viewDeps, typeDeps, b.viewFuncDeps.Copy(), nil
+}
+
+// BuildTriggerDependencies builds the body of the given trigger on the given
+// table; see BuildDependencies. References to NEW.<column> and OLD.<column> in
+// the body of a row-level trigger are built as placeholders, in the same way
+// as when the trigger fires (see triggerBuilder).
+func (b *Builder) BuildTriggerDependencies(
+	tab cat.Table, trig cat.Trigger,
+) (opt.ViewDeps, opt.ViewTypeDeps, opt.ViewFuncDeps, error) {
+	tb := newTriggerBuilder(tab, trig)
+	parsed, err := parser.ParseOne(trig.Body)
+	if err != nil {
+		return nil, opt.ViewTypeDeps{}, opt.ViewFuncDeps{}, err
+	}
+	stmt := parsed.AST
+	if trig.ForEachRow {
+		if stmt, err = tb.replaceRowRefs(stmt); err != nil {
+			return nil, opt.ViewTypeDeps{}, opt.ViewFuncDeps{}, err
+		}
+		semaCtxCopy := *b.semaCtx
+		semaCtxCopy.Placeholders = tree.PlaceholderInfo{PlaceholderTypesInfo: tb.placeholderTypes()}
+		b.semaCtx = &semaCtxCopy
+	}
+	b.KeepPlaceholders = true
+	return b.BuildDependencies(stmt)
+}
+
+// BuildFunctionDependencies builds the body of a user-defined function with
+// the given parameter types; see BuildDependencies. References to the
+// parameters in the body must have been replaced by placeholders.
+func (b *Builder) BuildFunctionDependencies(
+	stmt tree.Statement, paramTypes []*types.T,
+) (opt.ViewDeps, opt.ViewTypeDeps, opt.ViewFuncDeps, error) {
+	semaCtxCopy := *b.semaCtx
+	semaCtxCopy.Placeholders = tree.PlaceholderInfo{
+		PlaceholderTypesInfo: tree.PlaceholderTypesInfo{TypeHints: paramTypes, Types: paramTypes},
+	}
+	b.semaCtx = &semaCtxCopy
+	b.KeepPlaceholders = true
+	return b.BuildDependencies(stmt)
+}
@@ -0,0 +1,183 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// domainValue is a typed expression that refers to an already built scalar
+// expression of a domain type within the constraint expressions of the domain.
+// It is used to enforce the constraints of the domain on the result of casts.
+type domainValue struct {
+	scalar opt.ScalarExpr
+	typ    *types.T
+}
+
+var _ tree.TypedExpr = &domainValue{}
+
+// String implements the Stringer interface.
+func (v *domainValue) String() string {
+	return tree.AsString(v)
+}
+
+// Format implements the NodeFormatter interface.
+func (v *domainValue) Format(ctx *tree.FmtCtx) {
+	ctx.FormatNode(&schemaexpr.DomainValueName)
+}
+
+// Walk implements the Expr interface.
+func (v *domainValue) Walk(_ tree.Visitor) tree.Expr {
+	return v
+}
+
+// TypeCheck implements the Expr interface.
+func (v *domainValue) TypeCheck(
+	_ context.Context, _ *tree.SemaContext, desired *types.T,
+) (tree.TypedExpr, error) {
+	return v, nil
+}
+
+// Eval implements the TypedExpr interface.
+func (*domainValue) Eval(_ *tree.EvalContext) (tree.Datum, error) {
+	panic(errors.AssertionFailedf("domainValue must be replaced before evaluation"))
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (v *domainValue) ResolvedType() *types.T {
+	return v.typ
+}
+
+// buildDomainCheck wraps the given scalar expression, which is of the given
+// domain type, with checks that enforce the constraints of the domain (see
+// makeDomainCheckExpr).
+func (b *Builder) buildDomainCheck(
+	value opt.ScalarExpr, typ *types.T, inScope *scope, colRefs *opt.ColSet,
+) opt.ScalarExpr {
+	b.factory.Metadata().AddUserDefinedType(typ)
+	expr := makeDomainCheckExpr(typ, &domainValue{scalar: value, typ: typ})
+	texpr, err := tree.TypeCheck(b.ctx, expr, b.semaCtx, types.Any)
+	if err != nil {
+		panic(err)
+	}
+	return b.buildScalar(texpr, inScope, nil, nil, colRefs)
+}
+
+// hasDomainConstraints returns true if the given type is a domain with
+// constraints.
+func hasDomainConstraints(typ *types.T) bool {
+	return typ.IsDomain() && typ.TypeMeta.DomainData != nil &&
+		len(typ.TypeMeta.DomainData.Constraints) > 0
+}
+
+// makeDomainCheckExpr wraps the given expression, which is of the given domain
+// type, with calls to crdb_internal.check_domain_value that enforce the
+// constraints of the domain. For example, the value of a column c of a domain
+// d with a NOT NULL constraint and a CHECK constraint d_check is checked by:
+//
+//   crdb_internal.check_domain_value(
+//     crdb_internal.check_domain_value(c, c IS NOT NULL, 'd', ''),
+//     c > 0, 'd', 'd_check'
+//   )
+//
+func makeDomainCheckExpr(typ *types.T, value tree.Expr) tree.Expr {
+	domainName := tree.NewDString(typ.Name())
+	expr := value
+	for _, c := range typ.TypeMeta.DomainData.Constraints {
+		checkExpr, err := parser.ParseExpr(c.Expr)
+		if err != nil {
+			panic(err)
+		}
+		checkExpr, err = schemaexpr.ReplaceDomainValue(checkExpr, value)
+		if err != nil {
+			panic(err)
+		}
+		// NOT NULL constraints are identified by an empty name.
+		name := c.Name
+		if c.NotNull {
+			name = ""
+		}
+		expr = &tree.FuncExpr{
+			Func: tree.WrapFunction("crdb_internal.check_domain_value"),
+			Exprs: tree.Exprs{
+				expr, &tree.ParenExpr{Expr: checkExpr}, domainName, tree.NewDString(name),
+			},
+		}
+	}
+	return expr
+}
+
+// addDomainChecks builds a projection that wraps the columns in srcCols whose
+// target columns are of a domain type with checks that enforce the constraints
+// of the domain (see makeDomainCheckExpr). It must be called after the
+// assignment casts are added, so that the values are of the domain type.
+//
+// srcCols should be either insertColIDs or updateColIDs. The columns in
+// srcCols are updated with new column IDs of the projected checks.
+func (mb *mutationBuilder) addDomainChecks(srcCols opt.OptionalColList) {
+	var projectionScope *scope
+	for ord, colID := range srcCols {
+		if colID == 0 {
+			// Column not mutated, so nothing to do.
+			continue
+		}
+
+		targetCol := mb.tab.Column(ord)
+		targetType := targetCol.DatumType()
+		if !hasDomainConstraints(targetType) {
+			continue
+		}
+
+		// Record the domain in the metadata, so that cached plans are
+		// invalidated when its constraints change.
+		mb.md.AddUserDefinedType(targetType)
+
+		// Build the check expression.
+		check := func() opt.ScalarExpr {
+			scalarProps := &mb.b.semaCtx.Properties
+			defer scalarProps.Restore(*scalarProps)
+			mb.b.semaCtx.Properties.Require(exprKindDomainCheck.String(), tree.RejectSpecial)
+			defer func(context exprKind) { mb.outScope.context = context }(mb.outScope.context)
+			mb.outScope.context = exprKindDomainCheck
+
+			inCol := mb.outScope.getColumnWithIDAndReferenceName(colID, targetCol.ColName())
+			texpr := mb.outScope.resolveType(makeDomainCheckExpr(targetType, inCol), types.Any)
+			return mb.b.buildScalar(texpr, mb.outScope, nil, nil, nil)
+		}()
+
+		// Lazily create the new scope.
+		if projectionScope == nil {
+			projectionScope = mb.outScope.replace()
+			projectionScope.appendColumnsFromScope(mb.outScope)
+		}
+
+		// Update the scope column to be checked. See addAssignmentCasts.
+		scopeCol := projectionScope.getColumnWithIDAndReferenceName(colID, targetCol.ColName())
+		scopeCol.name = scopeCol.name.WithMetadataName(fmt.Sprintf("%s_domain_check", targetCol.ColName()))
+		mb.b.populateSynthesizedColumn(scopeCol, check)
+
+		// Replace old source column with the new one.
+		srcCols[ord] = scopeCol.id
+	}
+
+	if projectionScope != nil {
+		projectionScope.expr = mb.b.constructProject(mb.outScope.expr, projectionScope.cols)
+		mb.outScope = projectionScope
+	}
+}
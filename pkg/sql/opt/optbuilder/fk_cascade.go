@@ -86,6 +86,7 @@ func (cb *onDeleteCascadeBuilder) Build(
 	oldValues, newValues opt.ColList,
 ) (_ memo.RelExpr, err error) {
 	return buildCascadeHelper(ctx, semaCtx, evalCtx, catalog, factoryI, func(b *Builder) memo.RelExpr {
+		b.skipRowLevelSecurity = true
 		fk := cb.mutatedTable.InboundForeignKey(cb.fkInboundOrdinal)
 
 		dep := opt.DepByID(fk.OriginTableID())
@@ -270,6 +271,7 @@ func (cb *onDeleteFastCascadeBuilder) Build(
 	_, _ opt.ColList,
 ) (_ memo.RelExpr, err error) {
 	return buildCascadeHelper(ctx, semaCtx, evalCtx, catalog, factoryI, func(b *Builder) memo.RelExpr {
+		b.skipRowLevelSecurity = true
 		fk := cb.mutatedTable.InboundForeignKey(cb.fkInboundOrdinal)
 
 		dep := opt.DepByID(fk.OriginTableID())
@@ -419,6 +421,7 @@ func (cb *onDeleteSetBuilder) Build(
 	oldValues, newValues opt.ColList,
 ) (_ memo.RelExpr, err error) {
 	return buildCascadeHelper(ctx, semaCtx, evalCtx, catalog, factoryI, func(b *Builder) memo.RelExpr {
+		b.skipRowLevelSecurity = true
 		fk := cb.mutatedTable.InboundForeignKey(cb.fkInboundOrdinal)
 
 		dep := opt.DepByID(fk.OriginTableID())
@@ -633,6 +636,7 @@ func (cb *onUpdateCascadeBuilder) Build(
 	oldValues, newValues opt.ColList,
 ) (_ memo.RelExpr, err error) {
 	return buildCascadeHelper(ctx, semaCtx, evalCtx, catalog, factoryI, func(b *Builder) memo.RelExpr {
+		b.skipRowLevelSecurity = true
 		fk := cb.mutatedTable.InboundForeignKey(cb.fkInboundOrdinal)
 
 		dep := opt.DepByID(fk.OriginTableID())
@@ -898,5 +902,8 @@ func buildCascadeHelper(
 		}
 	}()
 
-	return fn(b), nil
+	// Build With operators for any CTEs added while building the cascade (e.g.
+	// for BEFORE triggers on the mutated table).
+	expr := fn(b)
+	return b.buildWiths(expr, b.ctes), nil
 }
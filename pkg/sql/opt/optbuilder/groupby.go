@@ -45,6 +45,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/errors"
 )
 
@@ -96,6 +97,22 @@ type groupby struct {
 	// projects that expression.
 	groupStrs groupByStrSet
 
+	// groupingSets contains the grouping sets of a GROUP BY clause with ROLLUP,
+	// CUBE or GROUPING SETS, as sets of ordinals of the grouping columns in
+	// groupingCols(). It is nil if the GROUP BY clause has a single grouping
+	// set. See grouping_sets.go for more details.
+	groupingSets []util.FastIntSet
+
+	// groupingSetCols contains the grouping columns produced by the expand
+	// operation of a GROUP BY clause with grouping sets, in the same order as
+	// groupingCols(). groupStrs maps to these columns if groupingSets is set.
+	groupingSetCols []scopeColumn
+
+	// groupingSetIDCol is the column that identifies the grouping set of each
+	// row produced by the expand operation. Its value is an index into
+	// groupingSets.
+	groupingSetIDCol scopeColumn
+
 	// buildingGroupingCols is true while the grouping columns are being built.
 	// It is used to ensure that the builder does not throw a grouping error
 	// prematurely.
@@ -335,7 +352,12 @@ func (b *Builder) buildGroupingColumns(sel *tree.SelectClause, projectionsScope,
 	b.buildGroupingList(sel.GroupBy, sel.Exprs, projectionsScope, fromScope)
 
 	// Copy the grouping columns to the aggOutScope.
-	g.aggOutScope.appendColumns(g.groupingCols())
+	if g.groupingSets != nil {
+		g.aggOutScope.appendColumns(g.groupingSetCols)
+		g.aggOutScope.appendColumn(&g.groupingSetIDCol)
+	} else {
+		g.aggOutScope.appendColumns(g.groupingCols())
+	}
 }
 
 // buildAggregation builds the aggregation operators and constructs the
@@ -354,6 +376,10 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 	// If there are any aggregates that are ordering sensitive, build the
 	// aggregations as window functions over each group.
 	if g.hasNonCommutativeAggregates() {
+		if g.groupingSets != nil {
+			panic(unimplementedWithIssueDetailf(46280, "ordered aggregate with grouping sets",
+				"ordered aggregates are not supported with ROLLUP, CUBE or GROUPING SETS"))
+		}
 		return b.buildAggregationAsWindow(groupingColSet, having, fromScope)
 	}
 
@@ -429,9 +455,28 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 	// Construct the pre-projection, which renders the grouping columns and the
 	// aggregate arguments, as well as any additional order by columns.
 	b.constructProjectForScope(fromScope, g.aggInScope)
+	input := g.aggInScope.expr
+
+	// Replicate the input rows for each grouping set, if there are several.
+	if g.groupingSets != nil {
+		var rowCol opt.ColumnID
+		input, groupingColSet, rowCol = b.constructGroupingSetsExpand(input, g)
+		if rowCol != 0 {
+			// Ignore the rows that the expand operation adds for the empty
+			// grouping sets. The filter columns of the aggregates with a FILTER
+			// clause are already NULL in these rows.
+			for i, agg := range aggInfos {
+				if agg.filter == nil {
+					aggCols[i].scalar = b.factory.ConstructAggFilter(
+						aggCols[i].scalar, b.factory.ConstructVariable(rowCol),
+					)
+				}
+			}
+		}
+	}
 
 	g.aggOutScope.expr = b.constructGroupBy(
-		g.aggInScope.expr,
+		input,
 		groupingColSet,
 		aggCols,
 		g.aggInScope.ordering,
@@ -507,15 +552,20 @@ func (b *Builder) buildGroupingList(
 	// used in an aggregate function`. The builder cannot know whether there is
 	// a grouping error until the grouping columns are fully built.
 	g.buildingGroupingCols = true
-	for _, e := range groupBy {
-		b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
+	if hasGroupingSets(groupBy) {
+		b.buildGroupingSets(groupBy, selects, projectionsScope, fromScope)
+	} else {
+		for _, e := range groupBy {
+			b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
+		}
 	}
 	g.buildingGroupingCols = false
 }
 
 // buildGrouping builds a set of memo groups that represent a GROUP BY
 // expression. The expression (or expressions, if we have a star) is added to
-// groupStrs and to the aggInScope.
+// groupStrs and to the aggInScope. Returns the set of grouping columns for
+// the expression.
 //
 //
 // groupBy          The given GROUP BY expression.
@@ -529,7 +579,7 @@ func (b *Builder) buildGroupingList(
 //                  as the aggregate function arguments.
 func (b *Builder) buildGrouping(
 	groupBy tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope, aggInScope *scope,
-) {
+) (cols opt.ColSet) {
 	// Unwrap parenthesized expressions like "((a))" to "a".
 	groupBy = tree.StripParens(groupBy)
 	alias := ""
@@ -627,7 +677,8 @@ func (b *Builder) buildGrouping(
 		// If a grouping column has already been added, don't add it again.
 		// GROUP BY a, a is semantically equivalent to GROUP BY a.
 		exprStr := symbolicExprStr(e)
-		if _, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+		if col, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+			cols.Add(col.id)
 			continue
 		}
 
@@ -637,7 +688,9 @@ func (b *Builder) buildGrouping(
 		col := aggInScope.addColumn(scopeColName(tree.Name(alias)), e)
 		b.buildScalar(e, fromScope, aggInScope, col, nil)
 		fromScope.groupby.groupStrs[exprStr] = col
+		cols.Add(col.id)
 	}
+	return cols
 }
 
 // buildAggArg builds a scalar expression which is used as an input in some form
@@ -903,6 +956,11 @@ func newGroupingError(name tree.Name) error {
 // table. In that case, we can allow col as an "implicit" grouping column, even
 // if it is not specified in the query.
 func (b *Builder) allowImplicitGroupingColumn(colID opt.ColumnID, g *groupby) bool {
+	if g.groupingSets != nil {
+		// The column would not be replaced with NULL for the grouping sets that
+		// do not contain the PK columns.
+		return false
+	}
 	md := b.factory.Metadata()
 	colMeta := md.ColumnMeta(colID)
 	if colMeta.Table == 0 {
@@ -0,0 +1,397 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+// This file has builder code specific to GROUP BY clauses with ROLLUP, CUBE
+// or GROUPING SETS, and to the GROUPING operation.
+//
+// A GROUP BY with grouping sets is built as a single GroupBy operator on top
+// of an "expand" operation, which replicates every input row once for each
+// grouping set. In the replica for a grouping set, the grouping columns that
+// are not part of the set are replaced with NULL. The GroupBy groups on these
+// columns and on a column identifying the grouping set, so it computes the
+// groups of all the grouping sets at once. For example:
+//
+//   SELECT a, b, count(*) FROM t GROUP BY ROLLUP (a, b)
+//
+// has the grouping sets (a, b), (a) and (), and is built as:
+//
+//   group-by (a', b', grouping_set_id)
+//    └── project
+//         ├── inner-join (cross)
+//         │    ├── scan t
+//         │    └── values (0), (1), (2)    -- grouping_set_id
+//         └── projections
+//              ├── CASE grouping_set_id WHEN 2 THEN NULL ELSE a END  -- a'
+//              └── CASE grouping_set_id WHEN 1 THEN NULL WHEN 2 THEN NULL ELSE b END  -- b'
+//
+// Since the expand operation is made of regular operators, grouping sets are
+// supported by all the execution engines.
+//
+// Like a scalar aggregation, the empty grouping set produces a row even if the
+// input is empty. If there is an empty grouping set, the expand operation is
+// built as a left join of the grouping set IDs with the input instead, so that
+// each grouping set ID is paired with a row of NULLs if the input is empty.
+// These rows are only kept for the empty grouping sets, and are ignored by the
+// aggregates, which are filtered on a column that is NULL only in these rows:
+//
+//   group-by (a', b', grouping_set_id)
+//    └── project
+//         ├── select
+//         │    ├── left-join (cross)
+//         │    │    ├── values (0), (1), (2)    -- grouping_set_id
+//         │    │    └── project
+//         │    │         ├── scan t
+//         │    │         └── projections
+//         │    │              └── true    -- grouping_set_row
+//         │    └── filters
+//         │         └── (grouping_set_row IS NOT NULL) OR (grouping_set_id = 2)
+//         └── projections
+//              └── ...
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// maxCubeElements is the maximum number of elements of a CUBE, which
+	// produces a grouping set for each subset of its elements. This is the same
+	// limit as Postgres.
+	maxCubeElements = 12
+
+	// maxGroupingSets is the maximum number of grouping sets of a GROUP BY
+	// clause. This is the same limit as Postgres.
+	maxGroupingSets = 4096
+
+	// maxGroupingArgs is the maximum number of arguments of GROUPING. This is
+	// the same limit as Postgres.
+	maxGroupingArgs = 31
+
+	// groupingFuncName is the name of the builtin function for the GROUPING
+	// operation.
+	groupingFuncName = "grouping"
+)
+
+var errGroupingArgs = pgerror.New(pgcode.Grouping,
+	"arguments to GROUPING must be grouping expressions of the associated query level")
+
+// hasGroupingSets returns true if the GROUP BY clause contains ROLLUP, CUBE or
+// GROUPING SETS.
+func hasGroupingSets(groupBy tree.GroupBy) bool {
+	for _, e := range groupBy {
+		if _, ok := e.(*tree.GroupingSet); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// buildGroupingSets builds the grouping columns of a GROUP BY clause that
+// contains ROLLUP, CUBE or GROUPING SETS and computes its grouping sets. The
+// grouping columns are added to groupStrs and to the aggInScope, like for a
+// regular GROUP BY.
+//
+// See buildGroupingList for a description of the arguments.
+func (b *Builder) buildGroupingSets(
+	groupBy tree.GroupBy, selects tree.SelectExprs, projectionsScope, fromScope *scope,
+) {
+	g := fromScope.groupby
+	build := func(e tree.Expr) opt.ColSet {
+		return b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
+	}
+
+	// The grouping sets of the GROUP BY clause are the cross product of the
+	// grouping sets of its items. For example, GROUP BY a, ROLLUP (b, c) has
+	// the grouping sets (a, b, c), (a, b) and (a).
+	sets := []opt.ColSet{{}}
+	for _, e := range groupBy {
+		itemSets := expandGroupingSet(e, build)
+		product := make([]opt.ColSet, 0, len(sets)*len(itemSets))
+		for _, s := range sets {
+			for _, t := range itemSets {
+				product = append(product, s.Union(t))
+			}
+		}
+		sets = product
+		checkGroupingSetsCount(len(sets))
+	}
+
+	if len(sets) == 1 {
+		// A single grouping set is equivalent to a regular GROUP BY.
+		return
+	}
+
+	groupingCols := g.groupingCols()
+	g.groupingSets = make([]util.FastIntSet, len(sets))
+	for i := range sets {
+		for ord := range groupingCols {
+			if sets[i].Contains(groupingCols[ord].id) {
+				g.groupingSets[i].Add(ord)
+			}
+		}
+	}
+
+	// Synthesize the grouping columns produced by the expand operation. A
+	// column that is part of every grouping set is never replaced with NULL, so
+	// it is passed through unchanged.
+	md := b.factory.Metadata()
+	g.groupingSetCols = make([]scopeColumn, len(groupingCols))
+	for ord := range groupingCols {
+		col := groupingCols[ord]
+		col.scalar = nil
+		for i := range g.groupingSets {
+			if !g.groupingSets[i].Contains(ord) {
+				col.id = md.AddColumn(col.name.MetadataName(), col.typ)
+				break
+			}
+		}
+		g.groupingSetCols[ord] = col
+	}
+	for exprStr, col := range g.groupStrs {
+		for ord := range groupingCols {
+			if groupingCols[ord].id == col.id {
+				g.groupStrs[exprStr] = &g.groupingSetCols[ord]
+				break
+			}
+		}
+	}
+
+	g.groupingSetIDCol = scopeColumn{
+		name: scopeColName("").WithMetadataName("grouping_set_id"),
+		typ:  types.Int,
+		id:   md.AddColumn("grouping_set_id", types.Int),
+	}
+}
+
+// expandGroupingSet returns the grouping sets of an item of a GROUP BY
+// clause, as sets of grouping columns. The given build function builds the
+// grouping columns of an expression.
+func expandGroupingSet(e tree.Expr, build func(tree.Expr) opt.ColSet) []opt.ColSet {
+	gs, ok := e.(*tree.GroupingSet)
+	if !ok {
+		// A regular GROUP BY expression, or a parenthesized list of expressions
+		// inside GROUPING SETS, is a single grouping set.
+		return []opt.ColSet{build(e)}
+	}
+
+	var sets []opt.ColSet
+	switch gs.Type {
+	case tree.RollupGroupingSet:
+		// ROLLUP (a, b, c) is equivalent to
+		// GROUPING SETS ((a, b, c), (a, b), (a), ()).
+		elems := make([]opt.ColSet, len(gs.Exprs))
+		for i := range gs.Exprs {
+			elems[i] = build(gs.Exprs[i])
+		}
+		n := len(elems)
+		sets = make([]opt.ColSet, n+1)
+		for i := range sets {
+			for j := 0; j < n-i; j++ {
+				sets[i].UnionWith(elems[j])
+			}
+		}
+
+	case tree.CubeGroupingSet:
+		// CUBE (a, b) is equivalent to GROUPING SETS ((a, b), (a), (b), ()).
+		if len(gs.Exprs) > maxCubeElements {
+			panic(pgerror.Newf(pgcode.ProgramLimitExceeded,
+				"CUBE is limited to %d elements", maxCubeElements))
+		}
+		elems := make([]opt.ColSet, len(gs.Exprs))
+		for i := range gs.Exprs {
+			elems[i] = build(gs.Exprs[i])
+		}
+		n := len(elems)
+		sets = make([]opt.ColSet, 0, 1<<n)
+		for mask := (1 << n) - 1; mask >= 0; mask-- {
+			var s opt.ColSet
+			for i := range elems {
+				if mask&(1<<(n-1-i)) != 0 {
+					s.UnionWith(elems[i])
+				}
+			}
+			sets = append(sets, s)
+		}
+
+	case tree.GroupingSetsGroupingSet:
+		for _, item := range gs.Exprs {
+			sets = append(sets, expandGroupingSet(item, build)...)
+			checkGroupingSetsCount(len(sets))
+		}
+
+	default:
+		panic(errors.AssertionFailedf("unknown grouping set type %v", gs.Type))
+	}
+	return sets
+}
+
+func checkGroupingSetsCount(n int) {
+	if n > maxGroupingSets {
+		panic(pgerror.Newf(pgcode.ProgramLimitExceeded,
+			"too many grouping sets present (maximum %d)", maxGroupingSets))
+	}
+}
+
+// constructGroupingSetsExpand constructs the expand operation of a GROUP BY
+// with grouping sets (see the comment at the top of this file) on top of the
+// pre-projection of the aggregation. It returns the expand operation and the
+// columns that the aggregation must group on. If there is an empty grouping
+// set, it also returns the column on which the aggregates must be filtered;
+// otherwise, the returned column is 0.
+func (b *Builder) constructGroupingSetsExpand(
+	input memo.RelExpr, g *groupby,
+) (_ memo.RelExpr, _ opt.ColSet, rowCol opt.ColumnID) {
+	gidCol := g.groupingSetIDCol.id
+	tupleTyp := types.MakeTuple([]*types.T{types.Int})
+	rows := make(memo.ScalarListExpr, len(g.groupingSets))
+	for i := range g.groupingSets {
+		rows[i] = b.factory.ConstructTuple(
+			memo.ScalarListExpr{b.constructGroupingSetID(i)}, tupleTyp,
+		)
+	}
+	values := b.factory.ConstructValues(rows, &memo.ValuesPrivate{
+		Cols: opt.ColList{gidCol},
+		ID:   b.factory.Metadata().NextUniqueID(),
+	})
+
+	passthrough := g.aggInScope.colSet()
+	passthrough.Add(gidCol)
+
+	var expand memo.RelExpr
+	var emptySets memo.ScalarExpr
+	for i := range g.groupingSets {
+		if g.groupingSets[i].Empty() {
+			isSet := b.factory.ConstructEq(
+				b.factory.ConstructVariable(gidCol), b.constructGroupingSetID(i),
+			)
+			if emptySets == nil {
+				emptySets = isSet
+			} else {
+				emptySets = b.factory.ConstructOr(emptySets, isSet)
+			}
+		}
+	}
+	if emptySets == nil {
+		expand = b.factory.ConstructInnerJoin(input, values, memo.TrueFilter, memo.EmptyJoinPrivate)
+	} else {
+		rowCol = b.factory.Metadata().AddColumn("grouping_set_row", types.Bool)
+		input = b.factory.ConstructProject(input, memo.ProjectionsExpr{
+			b.factory.ConstructProjectionsItem(memo.TrueSingleton, rowCol),
+		}, g.aggInScope.colSet())
+		expand = b.factory.ConstructLeftJoin(values, input, memo.TrueFilter, memo.EmptyJoinPrivate)
+		isInputRow := b.factory.ConstructIsNot(
+			b.factory.ConstructVariable(rowCol), memo.NullSingleton,
+		)
+		expand = b.factory.ConstructSelect(expand, memo.FiltersExpr{
+			b.factory.ConstructFiltersItem(b.factory.ConstructOr(isInputRow, emptySets)),
+		})
+		passthrough.Add(rowCol)
+	}
+
+	var groupingColSet opt.ColSet
+	groupingColSet.Add(gidCol)
+	var projections memo.ProjectionsExpr
+	groupingCols := g.groupingCols()
+	for ord := range groupingCols {
+		in, out := &groupingCols[ord], &g.groupingSetCols[ord]
+		groupingColSet.Add(out.id)
+		if in.id == out.id {
+			continue
+		}
+		var whens memo.ScalarListExpr
+		for i := range g.groupingSets {
+			if !g.groupingSets[i].Contains(ord) {
+				whens = append(whens, b.factory.ConstructWhen(
+					b.constructGroupingSetID(i), b.factory.ConstructNull(in.typ),
+				))
+			}
+		}
+		caseExpr := b.factory.ConstructCase(
+			b.factory.ConstructVariable(gidCol), whens, b.factory.ConstructVariable(in.id),
+		)
+		projections = append(projections, b.factory.ConstructProjectionsItem(caseExpr, out.id))
+	}
+
+	return b.factory.ConstructProject(expand, projections, passthrough), groupingColSet, rowCol
+}
+
+// constructGroupingSetID constructs the value of the grouping set ID column
+// for the grouping set with the given index.
+func (b *Builder) constructGroupingSetID(i int) opt.ScalarExpr {
+	return b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(i)), types.Int)
+}
+
+// buildGroupingFunc builds the GROUPING operation, which returns an integer
+// bit mask where a bit is set if the corresponding argument is not part of
+// the grouping set of the current row. The last argument corresponds to the
+// least significant bit. The arguments must match grouping expressions of
+// the enclosing GROUP BY clause. For example, with GROUP BY ROLLUP (a, b):
+//
+//   grouping set | GROUPING(a) | GROUPING(b) | GROUPING(a, b)
+//   -------------+-------------+-------------+---------------
+//   (a, b)       | 0           | 0           | 0
+//   (a)          | 0           | 1           | 1
+//   ()           | 1           | 1           | 3
+//
+func (b *Builder) buildGroupingFunc(
+	f *tree.FuncExpr, inScope *scope, colRefs *opt.ColSet,
+) opt.ScalarExpr {
+	if !inScope.inGroupingContext() || inScope.inAgg || inScope.groupby.buildingGroupingCols {
+		panic(errGroupingArgs)
+	}
+	if len(f.Exprs) > maxGroupingArgs {
+		panic(pgerror.Newf(pgcode.TooManyArguments,
+			"GROUPING must have fewer than %d arguments", maxGroupingArgs+1))
+	}
+	g := inScope.groupby
+	ords := make([]int, len(f.Exprs))
+	for i, e := range f.Exprs {
+		col, ok := g.groupStrs[symbolicExprStr(e.(tree.TypedExpr))]
+		if !ok {
+			panic(errGroupingArgs)
+		}
+		ords[i] = -1
+		for ord := range g.groupingSetCols {
+			if &g.groupingSetCols[ord] == col {
+				ords[i] = ord
+				break
+			}
+		}
+	}
+
+	if g.groupingSets == nil {
+		// All the arguments are part of the single grouping set.
+		return b.factory.ConstructConstVal(tree.NewDInt(0), types.Int)
+	}
+
+	whens := make(memo.ScalarListExpr, len(g.groupingSets))
+	for i := range g.groupingSets {
+		var mask tree.DInt
+		for _, ord := range ords {
+			mask <<= 1
+			if !g.groupingSets[i].Contains(ord) {
+				mask |= 1
+			}
+		}
+		whens[i] = b.factory.ConstructWhen(
+			b.constructGroupingSetID(i), b.factory.ConstructConstVal(tree.NewDInt(mask), types.Int),
+		)
+	}
+	gid := b.finishBuildScalarRef(&g.groupingSetIDCol, g.aggOutScope, nil, nil, colRefs)
+	return b.factory.ConstructCase(gid, whens, b.factory.ConstructNull(types.Int))
+}
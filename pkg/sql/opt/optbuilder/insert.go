@@ -398,6 +398,12 @@ func (mb *mutationBuilder) needExistingRows() bool {
 		}
 	}
 
+	// Row-level triggers need to know whether each row is inserted or updated,
+	// as well as the old values of the updated rows.
+	if mb.hasRowTriggers(tree.TriggerEventInsert, tree.TriggerEventUpdate) {
+		return true
+	}
+
 	// If there are inbound foreign key constraints that contain any non-key
 	// columns, we need the existing values.
 	for i, n := 0, mb.tab.InboundForeignKeyCount(); i < n; i++ {
@@ -674,8 +680,12 @@ func (mb *mutationBuilder) buildInsert(returning tree.ReturningExprs) {
 	// check constraint, refer to the correct columns.
 	mb.disambiguateColumns()
 
+	// Enforce the constraints of the domains of inserted columns.
+	mb.addDomainChecks(mb.insertColIDs)
+
 	// Add any check constraint boolean columns to the input.
 	mb.addCheckConstraintCols(false /* isUpdate */)
+	mb.addRowLevelSecurityCheckCol(tree.PolicyCommandInsert)
 
 	// Project partial index PUT boolean columns.
 	mb.projectPartialIndexPutCols()
@@ -684,6 +694,8 @@ func (mb *mutationBuilder) buildInsert(returning tree.ReturningExprs) {
 
 	mb.buildFKChecksForInsert()
 
+	mb.buildTriggers(tree.TriggerEventInsert, 0 /* rowFilterCol */)
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructInsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fkChecks, private,
@@ -875,6 +887,10 @@ func (mb *mutationBuilder) setUpsertCols(insertCols tree.NameList) {
 // buildUpsert constructs an Upsert operator, possibly wrapped by a Project
 // operator that corresponds to the given RETURNING clause.
 func (mb *mutationBuilder) buildUpsert(returning tree.ReturningExprs) {
+	// Enforce the constraints of the domains of inserted and updated columns.
+	mb.addDomainChecks(mb.insertColIDs)
+	mb.addDomainChecks(mb.updateColIDs)
+
 	// Merge input insert and update columns using CASE expressions.
 	mb.projectUpsertColumns()
 
@@ -884,6 +900,7 @@ func (mb *mutationBuilder) buildUpsert(returning tree.ReturningExprs) {
 
 	// Add any check constraint boolean columns to the input.
 	mb.addCheckConstraintCols(false /* isUpdate */)
+	mb.addRowLevelSecurityCheckColForUpsert()
 
 	// Add the partial index predicate expressions to the table metadata.
 	// These expressions are used to prune fetch columns during
@@ -907,6 +924,8 @@ func (mb *mutationBuilder) buildUpsert(returning tree.ReturningExprs) {
 
 	mb.buildFKChecksForUpsert()
 
+	mb.buildTriggersForUpsert()
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructUpsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fkChecks, private,
@@ -0,0 +1,844 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// duplicateMergeErrText is error text used when a target row is matched by
+// more than one source row in a MERGE statement and would be modified more
+// than once.
+const duplicateMergeErrText = "MERGE command cannot affect row a second time"
+
+// mergeBuilder holds the state shared by the mutations that are built for a
+// single MERGE statement.
+type mergeBuilder struct {
+	b          *Builder
+	merge      *tree.Merge
+	tab        cat.Table
+	alias      tree.TableName
+	indexFlags *tree.IndexFlags
+
+	// joinScope is the scope of the join of the USING data source with the
+	// target table. The join is built once as a With binding identified by
+	// joinID, and each mutation reads it through its own WithScan.
+	joinScope *scope
+	joinID    opt.WithID
+
+	// targetCols are the columns of joinScope that come from the target
+	// table, and sourceCols those that come from the USING data source.
+	targetCols opt.ColSet
+	sourceCols opt.ColSet
+
+	// armColID is the column of joinScope that contains the 1-based position
+	// in the statement of the WHEN clause that applies to each row.
+	armColID opt.ColumnID
+}
+
+// buildMerge builds a memo group for a MERGE statement. MERGE is planned on
+// top of the usual mutation builders: the UPDATE and INSERT clauses are
+// applied by an Update, an Insert, or a single Upsert operator if there are
+// both, and the DELETE clauses by a separate Delete operator. The mutations
+// all read a single join of the source with the target table, which
+// determines the WHEN clause that applies to each row. For example:
+//
+//   MERGE INTO t USING s ON t.k = s.k
+//   WHEN MATCHED THEN UPDATE SET v = s.v
+//   WHEN NOT MATCHED THEN INSERT VALUES (s.k, s.v)
+//
+// is built similar to this SQL:
+//
+//   WITH
+//     merge_join AS (
+//       SELECT * FROM (
+//         SELECT *, CASE WHEN t.k IS NOT NULL THEN <matched clause>
+//                   ELSE <not matched clause> END AS merge_when
+//         FROM s LEFT JOIN t ON t.k = s.k
+//       ) WHERE merge_when IN (1, 2)
+//     ),
+//     merge_upsert AS (
+//       INSERT INTO t SELECT s.k, s.v ... <rows of merge_join for clauses 1, 2>
+//       ON CONFLICT (k) DO UPDATE SET v = s.v
+//     )
+//   SELECT count(*) FROM merge_upsert
+//
+// where the Upsert updates the rows that have a match in the target table,
+// and inserts the others. Each target row that is matched is assigned the
+// first WHEN MATCHED clause whose condition holds, and each unmatched source
+// row is assigned the first WHEN NOT MATCHED clause whose condition holds. It
+// is an error for a target row to be modified by more than one source row.
+// The FK and unique checks are those planned by the individual mutation
+// operators.
+//
+// Since a Delete operator is a separate modification of the table, a MERGE
+// with both DELETE clauses and other actions is subject to the same
+// restrictions as statements that modify a table in several subqueries (see
+// checkMultipleMutations).
+func (b *Builder) buildMerge(merge *tree.Merge, inScope *scope) (outScope *scope) {
+	// Find which table we're working on. Existing rows must always be read to
+	// determine whether they match.
+	tab, depName, alias, refColumns := b.resolveTableForMutation(merge.Table, privilege.SELECT)
+
+	if refColumns != nil {
+		panic(pgerror.Newf(pgcode.Syntax,
+			"cannot specify a list of column IDs with MERGE"))
+	}
+
+	mb := mergeBuilder{b: b, merge: merge, tab: tab, alias: alias}
+	if source, ok := merge.Table.(*tree.AliasedTableExpr); ok && source.IndexFlags != nil {
+		mb.indexFlags = source.IndexFlags
+		telemetry.Inc(sqltelemetry.IndexHintUseCounter)
+	}
+
+	// Check that every WHEN clause is reachable, and check the permissions
+	// required by their actions.
+	var matched, notMatched tree.MergeWhens
+	var hasUpdate, hasDelete, hasInsert bool
+	for _, w := range merge.Whens {
+		if w.Matched {
+			matched = append(matched, w)
+		} else {
+			notMatched = append(notMatched, w)
+		}
+		switch w.Action {
+		case tree.MergeUpdate:
+			hasUpdate = true
+		case tree.MergeDelete:
+			hasDelete = true
+		case tree.MergeInsert:
+			hasInsert = true
+		}
+	}
+	checkMergeWhensReachable(matched)
+	checkMergeWhensReachable(notMatched)
+	if hasUpdate {
+		b.checkPrivilege(depName, tab, privilege.UPDATE)
+	}
+	if hasDelete {
+		b.checkPrivilege(depName, tab, privilege.DELETE)
+	}
+	if hasInsert {
+		b.checkPrivilege(depName, tab, privilege.INSERT)
+	}
+
+	// Build the join of the USING data source with the target table once, so
+	// that it is evaluated a single time no matter how many mutations read
+	// from it.
+	mb.buildJoin(inScope)
+
+	// Build each of the mutations as a With binding, and count the rows that
+	// they affect. Each of them is a separate modification of the table.
+	var input memo.RelExpr
+	addMutation := func(mutationScope *scope, name string) {
+		id := b.factory.Memo().NextWithID()
+		b.factory.Metadata().AddWithBinding(id, mutationScope.expr)
+		b.addCTE(&cteSource{
+			name:         tree.AliasClause{Alias: tree.Name(name)},
+			originalExpr: merge,
+			expr:         mutationScope.expr,
+			id:           id,
+		})
+		withScan := b.factory.ConstructWithScan(&memo.WithScanPrivate{
+			With:    id,
+			Name:    name,
+			InCols:  opt.ColList{},
+			OutCols: opt.ColList{},
+			ID:      b.factory.Metadata().NextUniqueID(),
+		})
+		if input == nil {
+			input = withScan
+		} else {
+			input = b.factory.ConstructUnionAll(input, withScan, &memo.SetPrivate{
+				LeftCols:  opt.ColList{},
+				RightCols: opt.ColList{},
+				OutCols:   opt.ColList{},
+			})
+		}
+	}
+	if hasDelete {
+		b.checkMultipleMutations(tab, false /* simpleInsert */)
+		addMutation(mb.buildMatched(tree.MergeDelete, inScope), "merge_delete")
+	}
+	switch {
+	case hasUpdate && hasInsert:
+		// The UPDATE and INSERT clauses are applied by a single Upsert, which
+		// chooses the action for each row.
+		b.checkMultipleMutations(tab, false /* simpleInsert */)
+		addMutation(mb.buildUpsert(inScope), "merge_upsert")
+	case hasUpdate:
+		b.checkMultipleMutations(tab, false /* simpleInsert */)
+		addMutation(mb.buildMatched(tree.MergeUpdate, inScope), "merge_update")
+	case hasInsert:
+		b.checkMultipleMutations(tab, true /* simpleInsert */)
+		addMutation(mb.buildNotMatched(inScope), "merge_insert")
+	}
+	if input == nil {
+		input = b.factory.ConstructValues(memo.ScalarListExpr{}, &memo.ValuesPrivate{
+			Cols: opt.ColList{},
+			ID:   b.factory.Metadata().NextUniqueID(),
+		})
+	}
+
+	outScope = inScope.push()
+	countCol := b.synthesizeColumn(outScope, scopeColName("count"), types.Int, nil /* expr */, nil /* scalar */)
+	outScope.expr = b.factory.ConstructScalarGroupBy(
+		input,
+		memo.AggregationsExpr{b.factory.ConstructAggregationsItem(
+			b.factory.ConstructCountRows(), countCol.id,
+		)},
+		&memo.GroupingPrivate{},
+	)
+	return outScope
+}
+
+// checkMergeWhensReachable raises an error if a WHEN clause without an AND
+// condition is followed by another clause of the same kind, since the latter
+// can never be chosen.
+func checkMergeWhensReachable(whens tree.MergeWhens) {
+	for i := 0; i < len(whens)-1; i++ {
+		if whens[i].Cond == nil {
+			panic(pgerror.Newf(pgcode.Syntax,
+				"unreachable WHEN clause specified after unconditional WHEN clause"))
+		}
+	}
+}
+
+// buildJoin builds the left join of the USING data source with the target
+// table on the ON condition, and registers it as a With binding. A column is
+// projected with the WHEN clause that applies to each row, and the rows which
+// are not modified by any clause are discarded. The join contains one row for
+// each target row that is modified, with the columns of the matching source
+// row, and one row for each unmatched source row that is inserted, in which
+// the target columns are NULL.
+func (mb *mergeBuilder) buildJoin(inScope *scope) {
+	b := mb.b
+	sourceScope := b.buildFromTables(tree.TableExprs{mb.merge.Source}, noRowLocking, inScope)
+
+	// NOTE: Include mutation columns, but be careful to never use them for any
+	// reason other than as "fetch columns". See buildScan comment.
+	targetScope := b.buildScan(
+		b.addTable(mb.tab, &mb.alias),
+		tableOrdinals(mb.tab, columnKinds{
+			includeMutations: true,
+			includeSystem:    true,
+			includeInverted:  false,
+		}),
+		mb.indexFlags,
+		noRowLocking,
+		inScope,
+	)
+	b.validateJoinTableNames(targetScope, sourceScope)
+	mb.targetCols = targetScope.colSet()
+	mb.sourceCols = sourceScope.colSet()
+
+	joinScope := inScope.push()
+	joinScope.appendColumnsFromScope(targetScope)
+	joinScope.appendColumnsFromScope(sourceScope)
+	filter := b.resolveAndBuildScalar(
+		mb.merge.On,
+		types.Bool,
+		exprKindOn,
+		tree.RejectGenerators|tree.RejectWindowApplications,
+		joinScope,
+	)
+	joinScope.expr = b.factory.ConstructLeftJoin(
+		sourceScope.expr,
+		targetScope.expr,
+		memo.FiltersExpr{b.factory.ConstructFiltersItem(filter)},
+		memo.EmptyJoinPrivate,
+	)
+
+	// A primary key column of the target table is NULL if and only if the
+	// source row has no match, since primary key columns are not nullable.
+	primaryIndex := mb.tab.Index(cat.PrimaryIndex)
+	canaryCol := targetScope.getColumnForTableOrdinal(primaryIndex.Column(0).Ordinal())
+
+	// Determine the WHEN clause for each row, and discard rows that are not
+	// modified.
+	joinScope = mb.projectWhenColumn(joinScope, sourceScope, canaryCol.id)
+	mb.buildWhenFilter(joinScope, mb.armColID, func(w *tree.MergeWhen) bool {
+		return w.Action != tree.MergeDoNothing
+	})
+
+	// Ensure that each target row is modified at most once, regardless of the
+	// action. All primary key columns are used, including hidden ones. The
+	// primary key columns of unmatched source rows are NULL, and these rows are
+	// not considered duplicates of each other.
+	var pkCols opt.ColSet
+	for i := 0; i < primaryIndex.KeyColumnCount(); i++ {
+		pkCols.Add(targetScope.getColumnForTableOrdinal(primaryIndex.Column(i).Ordinal()).id)
+	}
+	joinScope = b.buildDistinctOn(
+		pkCols, joinScope, true /* nullsAreDistinct */, duplicateMergeErrText,
+	)
+
+	mb.joinScope = joinScope
+	mb.joinID = b.factory.Memo().NextWithID()
+	b.factory.Metadata().AddWithBinding(mb.joinID, joinScope.expr)
+	b.addCTE(&cteSource{
+		name:         tree.AliasClause{Alias: "merge_join"},
+		cols:         joinScope.makePresentationWithHiddenCols(),
+		originalExpr: mb.merge,
+		expr:         joinScope.expr,
+		id:           mb.joinID,
+	})
+}
+
+// buildJoinScan constructs a WithScan over the join of the source with the
+// target table (see buildJoin), restricted to the rows of the WHEN clauses
+// accepted by include. The columns of the returned scope keep the names and
+// table aliases of the join, but have new column IDs. The target columns are
+// only included if withTarget is true, in which case they are also returned
+// separately. armCol is the column with the WHEN clause of each row.
+func (mb *mergeBuilder) buildJoinScan(
+	inScope *scope, withTarget bool, include func(*tree.MergeWhen) bool,
+) (outScope *scope, targetCols []scopeColumn, armCol scopeColumn) {
+	md := mb.b.factory.Metadata()
+	var inCols, outCols opt.ColList
+	outScope = inScope.push()
+	for i := range mb.joinScope.cols {
+		col := mb.joinScope.cols[i]
+		isTarget := mb.targetCols.Contains(col.id)
+		if isTarget && !withTarget {
+			continue
+		}
+		inCols = append(inCols, col.id)
+		col.scalar = nil
+		col.id = md.AddColumn(md.ColumnMeta(col.id).Alias, col.typ)
+		outCols = append(outCols, col.id)
+		outScope.cols = append(outScope.cols, col)
+		if isTarget {
+			targetCols = append(targetCols, col)
+		}
+		if inCols[len(inCols)-1] == mb.armColID {
+			armCol = col
+		}
+	}
+	outScope.expr = mb.b.factory.ConstructWithScan(&memo.WithScanPrivate{
+		With:    mb.joinID,
+		Name:    "merge_join",
+		InCols:  inCols,
+		OutCols: outCols,
+		ID:      md.NextUniqueID(),
+	})
+	mb.buildWhenFilter(outScope, armCol.id, include)
+	return outScope, targetCols, armCol
+}
+
+// buildMatched builds the Update or Delete operator that applies the WHEN
+// MATCHED clauses with the given action. The input is the set of rows of the
+// join of the target table with the source that are assigned to a clause with
+// that action.
+func (mb *mergeBuilder) buildMatched(action tree.MergeAction, inScope *scope) *scope {
+	b := mb.b
+	var m mutationBuilder
+	if action == tree.MergeUpdate {
+		m.init(b, "update", mb.tab, mb.alias)
+	} else {
+		m.init(b, "delete", mb.tab, mb.alias)
+	}
+
+	joinScope, targetCols, armCol := mb.buildJoinScan(inScope, true /* withTarget */, func(w *tree.MergeWhen) bool {
+		return w.Matched && w.Action == action
+	})
+
+	// The fetch scope only contains the columns of the target table, so that
+	// the row-level security policies and the partial index predicates refer
+	// to them unambiguously. It is not modified after this point.
+	m.fetchScope = b.allocScope()
+	m.fetchScope.appendColumns(targetCols)
+	m.fetchScope.expr = joinScope.expr
+	if action == tree.MergeUpdate {
+		b.addRowLevelSecurityFilter(mb.tab, tree.PolicyCommandUpdate, m.fetchScope)
+	} else {
+		b.addRowLevelSecurityFilter(mb.tab, tree.PolicyCommandDelete, m.fetchScope)
+	}
+	m.setFetchColIDs(m.fetchScope.cols)
+
+	m.outScope = m.fetchScope.replace()
+	m.outScope.appendColumnsFromScope(joinScope)
+	m.outScope.expr = m.fetchScope.expr
+
+	// A RETURNING list without any expressions makes the mutation return one
+	// empty row per affected row, so that the rows can be counted.
+	if action == tree.MergeUpdate {
+		mb.addUpdateCols(&m, armCol)
+		m.buildUpdate(tree.ReturningExprs{})
+	} else {
+		m.buildDelete(tree.ReturningExprs{})
+	}
+	return m.outScope
+}
+
+// buildNotMatched builds the Insert operator that applies the WHEN NOT
+// MATCHED clauses. The input is the set of source rows of the join of the
+// target table with the source that have no match and are assigned to an
+// INSERT clause.
+func (mb *mergeBuilder) buildNotMatched(inScope *scope) *scope {
+	b := mb.b
+	var m mutationBuilder
+	m.init(b, "insert", mb.tab, mb.alias)
+
+	// The target columns are all NULL in these rows, and are left out so that
+	// the values refer to the source columns unambiguously.
+	var armCol scopeColumn
+	m.outScope, _, armCol = mb.buildJoinScan(inScope, false /* withTarget */, func(w *tree.MergeWhen) bool {
+		return !w.Matched && w.Action == tree.MergeInsert
+	})
+
+	mb.addInsertCols(&m, armCol)
+
+	// Source columns may have the same names as the table columns, so make
+	// sure that default and computed expressions refer to the inserted values.
+	m.disambiguateColumns()
+	m.addSynthesizedColsForInsert()
+
+	// Set insertExpr. This expression is used when building uniqueness checks.
+	// See mutationBuilder.buildCheckInputScan.
+	m.insertExpr = m.outScope.expr
+	m.buildInsert(tree.ReturningExprs{})
+	return m.outScope
+}
+
+// buildUpsert builds the Upsert operator that applies both the WHEN MATCHED
+// THEN UPDATE and the WHEN NOT MATCHED THEN INSERT clauses. The input is the
+// set of rows of the join of the target table with the source that are
+// assigned to one of these clauses. The first primary key column of the
+// target table is the canary column of the Upsert: it is NULL if and only if
+// the source row has no match, in which case the row is inserted.
+func (mb *mergeBuilder) buildUpsert(inScope *scope) *scope {
+	b := mb.b
+	f := b.factory
+	var m mutationBuilder
+	m.init(b, "upsert", mb.tab, mb.alias)
+
+	joinScope, targetCols, armCol := mb.buildJoinScan(inScope, true /* withTarget */, func(w *tree.MergeWhen) bool {
+		return w.Action == tree.MergeUpdate || w.Action == tree.MergeInsert
+	})
+
+	// The fetch scope only contains the columns of the target table, so that
+	// the row-level security policies and the partial index predicates refer
+	// to them unambiguously. It is not modified after this point.
+	m.fetchScope = b.allocScope()
+	m.fetchScope.appendColumns(targetCols)
+	m.fetchScope.expr = joinScope.expr
+	primaryIndex := mb.tab.Index(cat.PrimaryIndex)
+	m.canaryColID = m.fetchScope.getColumnForTableOrdinal(primaryIndex.Column(0).Ordinal()).id
+
+	// As for a MERGE with only UPDATE clauses, the matched rows that are not
+	// visible to the UPDATE policies are left alone.
+	if policies, enforced := b.rowLevelSecurityPolicies(mb.tab, tree.PolicyCommandUpdate); enforced {
+		using := m.buildPolicyScalar(m.fetchScope, policies, policyUsingExpr)
+		isInsert := f.ConstructIs(f.ConstructVariable(m.canaryColID), memo.NullSingleton)
+		m.fetchScope.expr = f.ConstructBarrier(f.ConstructSelect(
+			m.fetchScope.expr,
+			memo.FiltersExpr{f.ConstructFiltersItem(f.ConstructOr(isInsert, using))},
+		))
+	}
+
+	m.outScope = m.fetchScope.replace()
+	m.outScope.appendColumnsFromScope(joinScope)
+	m.outScope.expr = m.fetchScope.expr
+
+	// The target columns are all NULL in the rows that are inserted, and are
+	// made inaccessible while the inserted values are built, so that the
+	// values refer to the source columns unambiguously. Source columns may
+	// also have the same names as the table columns, so make sure that
+	// default and computed expressions refer to the inserted values. The
+	// fetch columns are only set afterwards, since they would otherwise take
+	// precedence over the inserted values in disambiguateColumns.
+	fetchCols := m.fetchScope.colSet()
+	for i := range m.outScope.cols {
+		if fetchCols.Contains(m.outScope.cols[i].id) {
+			m.outScope.cols[i].visibility = inaccessible
+		}
+	}
+	mb.addInsertCols(&m, armCol)
+	m.disambiguateColumns()
+	m.addSynthesizedColsForInsert()
+
+	// The SET expressions refer to the target and source columns, so restore
+	// them, and hide the inserted values.
+	joinCols := make(map[opt.ColumnID]scopeColumn, len(joinScope.cols))
+	for _, col := range joinScope.cols {
+		joinCols[col.id] = col
+	}
+	for i := range m.outScope.cols {
+		if col, ok := joinCols[m.outScope.cols[i].id]; ok {
+			m.outScope.cols[i] = col
+		} else {
+			m.outScope.cols[i].clearName()
+		}
+	}
+	m.setFetchColIDs(m.fetchScope.cols)
+	m.targetColList = make(opt.ColList, 0, mb.tab.ColumnCount())
+	m.targetColSet = opt.ColSet{}
+
+	// A RETURNING list without any expressions makes the mutation return one
+	// empty row per affected row, so that the rows can be counted.
+	mb.addUpdateCols(&m, armCol)
+	m.buildUpsert(tree.ReturningExprs{})
+	return m.outScope
+}
+
+// projectWhenColumn projects a column on top of the given join scope that
+// contains the 1-based position in the statement of the first WHEN clause
+// whose condition holds for each row, or 0 if there is no such clause. The
+// WHEN MATCHED clauses apply to the rows where the canary column is not NULL,
+// and the WHEN NOT MATCHED clauses to the other rows. The conditions of the
+// WHEN NOT MATCHED clauses can only refer to the columns of the source scope.
+func (mb *mergeBuilder) projectWhenColumn(
+	joinScope, sourceScope *scope, canaryColID opt.ColumnID,
+) *scope {
+	b := mb.b
+	f := b.factory
+
+	// WHEN conditions should reject aggregates, generators, etc.
+	scalarProps := &b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+	b.semaCtx.Properties.Require("MERGE WHEN", tree.RejectSpecial)
+
+	buildCase := func(matched bool, condScope *scope) opt.ScalarExpr {
+		condScope.context = exprKindWhere
+		var whenExprs memo.ScalarListExpr
+		for i, w := range mb.merge.Whens {
+			if w.Matched != matched {
+				continue
+			}
+			cond := opt.ScalarExpr(memo.TrueSingleton)
+			if w.Cond != nil {
+				texpr := condScope.resolveAndRequireType(w.Cond, types.Bool)
+				cond = b.buildScalar(texpr, condScope, nil, nil, nil)
+			}
+			whenExprs = append(whenExprs, f.ConstructWhen(
+				cond, f.ConstructConstVal(tree.NewDInt(tree.DInt(i+1)), types.Int),
+			))
+		}
+		return f.ConstructCase(
+			memo.TrueSingleton, whenExprs, f.ConstructConstVal(tree.NewDInt(0), types.Int),
+		)
+	}
+	isMatched := f.ConstructIsNot(f.ConstructVariable(canaryColID), memo.NullSingleton)
+	caseExpr := f.ConstructCase(
+		memo.TrueSingleton,
+		memo.ScalarListExpr{f.ConstructWhen(isMatched, buildCase(true /* matched */, joinScope))},
+		buildCase(false /* matched */, sourceScope),
+	)
+
+	projectionsScope := joinScope.replace()
+	projectionsScope.appendColumnsFromScope(joinScope)
+	armCol := b.synthesizeColumn(
+		projectionsScope, scopeColName("").WithMetadataName("merge_when"), types.Int, nil /* expr */, caseExpr,
+	)
+	b.constructProjectForScope(joinScope, projectionsScope)
+	mb.armColID = armCol.id
+	return projectionsScope
+}
+
+// buildWhenFilter wraps the input of the given scope in a Select that only
+// keeps the rows assigned to one of the WHEN clauses accepted by include.
+// armColID is the column with the WHEN clause of each row.
+func (mb *mergeBuilder) buildWhenFilter(
+	s *scope, armColID opt.ColumnID, include func(*tree.MergeWhen) bool,
+) {
+	f := mb.b.factory
+	var filter opt.ScalarExpr
+	for i, w := range mb.merge.Whens {
+		if !include(w) {
+			continue
+		}
+		eq := f.ConstructEq(
+			f.ConstructVariable(armColID), f.ConstructConstVal(tree.NewDInt(tree.DInt(i+1)), types.Int),
+		)
+		if filter == nil {
+			filter = eq
+		} else {
+			filter = f.ConstructOr(filter, eq)
+		}
+	}
+	if filter == nil {
+		filter = memo.FalseSingleton
+	}
+	s.expr = f.ConstructSelect(s.expr, memo.FiltersExpr{f.ConstructFiltersItem(filter)})
+}
+
+// addUpdateCols adds the columns that provide the new values of the updated
+// rows. If there are several WHEN MATCHED THEN UPDATE clauses, each column
+// that is assigned by any of them is set to the value given by the clause
+// chosen for the row, or keeps its existing value if that clause does not
+// assign it.
+func (mb *mergeBuilder) addUpdateCols(m *mutationBuilder, armCol scopeColumn) {
+	var arms []int
+	for i, w := range mb.merge.Whens {
+		if w.Matched && w.Action == tree.MergeUpdate {
+			arms = append(arms, i)
+		}
+	}
+	if len(arms) == 1 {
+		exprs := mb.merge.Whens[arms[0]].Exprs
+		m.addTargetColsForUpdate(exprs)
+		m.addUpdateCols(exprs)
+		return
+	}
+
+	// Determine the values that each clause assigns to the table columns.
+	var ords []int
+	var targetOrds util.FastIntSet
+	armValues := make([]map[int]tree.Expr, len(mb.merge.Whens))
+	for _, arm := range arms {
+		armValues[arm] = make(map[int]tree.Expr)
+		addSet := func(name tree.Name, expr tree.Expr) {
+			ord := findPublicTableColumnByName(mb.tab, name)
+			if ord == -1 {
+				panic(colinfo.NewUndefinedColumnError(string(name)))
+			}
+			if _, ok := armValues[arm][ord]; ok {
+				panic(pgerror.Newf(pgcode.Syntax,
+					"multiple assignments to the same column %q", name))
+			}
+			armValues[arm][ord] = expr
+			if !targetOrds.Contains(ord) {
+				targetOrds.Add(ord)
+				ords = append(ords, ord)
+			}
+		}
+		for _, set := range mb.merge.Whens[arm].Exprs {
+			if !set.Tuple {
+				addSet(set.Names[0], set.Expr)
+				continue
+			}
+			t, ok := set.Expr.(*tree.Tuple)
+			if !ok {
+				panic(unimplemented.Newf("merge multiple update subquery",
+					"multiple-column subquery SET is not supported when MERGE has more than one UPDATE clause"))
+			}
+			if len(set.Names) != len(t.Exprs) {
+				panic(pgerror.Newf(pgcode.Syntax,
+					"number of columns (%d) does not match number of values (%d)",
+					len(set.Names), len(t.Exprs)))
+			}
+			for i, name := range set.Names {
+				addSet(name, t.Exprs[i])
+			}
+		}
+	}
+	for _, ord := range ords {
+		if col := mb.tab.Column(ord); col.Kind() == cat.System {
+			panic(pgerror.Newf(pgcode.InvalidColumnReference,
+				"cannot modify system column %q", col.ColName()))
+		}
+		m.addTargetCol(ord)
+	}
+
+	// SET expressions should reject aggregates, generators, etc.
+	scalarProps := &mb.b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+	mb.b.semaCtx.Properties.Require("UPDATE SET", tree.RejectSpecial)
+
+	mb.projectWhenValues(m, armCol.id, arms, ords, func(arm, ord int) tree.Expr {
+		colID := m.tabID.ColumnID(ord)
+		expr, ok := armValues[arm][ord]
+		if !ok {
+			// The column keeps its existing value.
+			for i := range m.fetchScope.cols {
+				if col := &m.fetchScope.cols[i]; col.id == m.fetchColIDs[ord] {
+					return col
+				}
+			}
+			panic(errors.AssertionFailedf("fetch column for %q not found", mb.tab.Column(ord).ColName()))
+		}
+		if _, ok := expr.(tree.DefaultVal); ok {
+			return m.parseDefaultExpr(colID)
+		}
+		// GENERATED ALWAYS AS IDENTITY columns are not allowed to be
+		// explicitly written to.
+		if col := mb.tab.Column(ord); col.IsGeneratedAlwaysAsIdentity() {
+			panic(sqlerrors.NewGeneratedAlwaysAsIdentityColumnUpdateError(string(col.ColName())))
+		}
+		return expr
+	}, m.updateColIDs)
+
+	// Add assignment casts for update columns.
+	m.addAssignmentCasts(m.updateColIDs)
+
+	// Add additional columns for computed expressions that may depend on the
+	// updated columns.
+	m.addSynthesizedColsForUpdate()
+}
+
+// addInsertCols adds the columns that provide the values of the inserted
+// rows. The target columns are all the columns that are given a value by any
+// of the WHEN NOT MATCHED THEN INSERT clauses; a clause that does not give a
+// value for one of them inserts its default value.
+func (mb *mergeBuilder) addInsertCols(m *mutationBuilder, armCol scopeColumn) {
+	// Determine the values that each clause gives to the table columns.
+	var arms []int
+	var targetOrds util.FastIntSet
+	armValues := make([]map[int]tree.Expr, len(mb.merge.Whens))
+	for i, w := range mb.merge.Whens {
+		if w.Matched || w.Action != tree.MergeInsert {
+			continue
+		}
+		arms = append(arms, i)
+
+		var ords []int
+		if len(w.Columns) != 0 {
+			for _, name := range w.Columns {
+				ord := findPublicTableColumnByName(mb.tab, name)
+				if ord == -1 {
+					panic(colinfo.NewUndefinedColumnError(string(name)))
+				}
+				ords = append(ords, ord)
+			}
+		} else {
+			// Values are mapped to the table's non-hidden columns by ordinal
+			// position.
+			for ord, n := 0, mb.tab.ColumnCount(); ord < n && len(ords) < len(w.Values); ord++ {
+				if col := mb.tab.Column(ord); col.Kind() == cat.Ordinary && col.Visibility() == cat.Visible {
+					ords = append(ords, ord)
+				}
+			}
+		}
+		m.checkNumCols(len(ords), len(w.Values))
+
+		armValues[i] = make(map[int]tree.Expr, len(ords))
+		for j, ord := range ords {
+			col := mb.tab.Column(ord)
+			if col.Kind() == cat.System {
+				panic(pgerror.Newf(pgcode.InvalidColumnReference,
+					"cannot modify system column %q", col.ColName()))
+			}
+			if _, ok := armValues[i][ord]; ok {
+				panic(pgerror.Newf(pgcode.Syntax,
+					"multiple assignments to the same column %q", col.ColName()))
+			}
+			armValues[i][ord] = w.Values[j]
+			targetOrds.Add(ord)
+		}
+	}
+	targetOrds.ForEach(func(ord int) {
+		m.addTargetCol(ord)
+	})
+
+	// VALUES expressions should reject aggregates, generators, etc.
+	scalarProps := &mb.b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+	mb.b.semaCtx.Properties.Require("VALUES", tree.RejectSpecial)
+
+	mb.projectWhenValues(m, armCol.id, arms, targetOrds.Ordered(), func(arm, ord int) tree.Expr {
+		colID := m.tabID.ColumnID(ord)
+		expr, ok := armValues[arm][ord]
+		if _, isDefault := expr.(tree.DefaultVal); !ok || isDefault {
+			return m.parseDefaultExpr(colID)
+		}
+		// GENERATED ALWAYS AS IDENTITY columns are not allowed to be
+		// explicitly written to.
+		if col := mb.tab.Column(ord); col.IsGeneratedAlwaysAsIdentity() {
+			panic(sqlerrors.NewGeneratedAlwaysAsIdentityColumnOverrideError(string(col.ColName())))
+		}
+		return expr
+	}, m.insertColIDs)
+
+	// Add assignment casts for insert columns.
+	m.addAssignmentCasts(m.insertColIDs)
+}
+
+// projectWhenValues projects one column for each of the given table columns
+// that holds the value given to it by the WHEN clause chosen for the row, and
+// records the new columns in colIDs. The value that each clause gives to a
+// column is built from the expression returned by value.
+func (mb *mergeBuilder) projectWhenValues(
+	m *mutationBuilder,
+	armColID opt.ColumnID,
+	arms []int,
+	ords []int,
+	value func(arm, ord int) tree.Expr,
+	colIDs opt.OptionalColList,
+) {
+	b := mb.b
+	f := b.factory
+
+	// Project the value of every column for every clause. The input columns
+	// are accessible to the value expressions.
+	inScope := m.outScope
+	valuesScope := inScope.replace()
+	valuesScope.appendColumnsFromScope(inScope)
+	valueCols := make([]opt.ColumnID, len(ords)*len(arms))
+	for i, ord := range ords {
+		targetCol := mb.tab.Column(ord)
+		for j, arm := range arms {
+			texpr := inScope.resolveType(value(arm, ord), targetCol.DatumType())
+			colName := scopeColName("").WithMetadataName(
+				fmt.Sprintf("%s_when%d", targetCol.ColName(), arm+1),
+			)
+			scopeCol := valuesScope.addColumn(colName, texpr)
+			b.buildScalar(texpr, inScope, valuesScope, scopeCol, nil)
+			valueCols[i*len(arms)+j] = scopeCol.id
+		}
+	}
+	b.constructProjectForScope(m.outScope, valuesScope)
+	m.outScope = valuesScope
+
+	if len(arms) == 1 {
+		for i, ord := range ords {
+			colIDs[ord] = valueCols[i]
+		}
+		return
+	}
+
+	// Select the value of the chosen clause. The values are cast to the column
+	// type first, so that all the branches of the CASE have the same type.
+	caseScope := m.outScope.replace()
+	caseScope.appendColumnsFromScope(m.outScope)
+	for i, ord := range ords {
+		targetCol := mb.tab.Column(ord)
+		targetType := targetCol.DatumType()
+		whens := make(memo.ScalarListExpr, len(arms))
+		for j, arm := range arms {
+			colID := valueCols[i*len(arms)+j]
+			val := opt.ScalarExpr(f.ConstructVariable(colID))
+			if srcType := m.md.ColumnMeta(colID).Type; !srcType.Identical(targetType) {
+				if !tree.ValidCast(srcType, targetType, tree.CastContextAssignment) {
+					panic(sqlerrors.NewInvalidAssignmentCastError(
+						srcType, targetType, string(targetCol.ColName()),
+					))
+				}
+				val = f.ConstructAssignmentCast(val, targetType)
+			}
+			whens[j] = f.ConstructWhen(f.ConstructConstVal(tree.NewDInt(tree.DInt(arm+1)), types.Int), val)
+		}
+		caseExpr := f.ConstructCase(f.ConstructVariable(armColID), whens, f.ConstructNull(targetType))
+		colName := scopeColName(targetCol.ColName()).WithMetadataName(
+			fmt.Sprintf("%s_new", targetCol.ColName()),
+		)
+		scopeCol := b.synthesizeColumn(caseScope, colName, targetType, nil /* expr */, caseExpr)
+		colIDs[ord] = scopeCol.id
+	}
+	b.constructProjectForScope(m.outScope, caseScope)
+	m.outScope = caseScope
+}
@@ -109,7 +109,9 @@ type mutationBuilder struct {
 	// checkColIDs lists the input column IDs storing the boolean results of
 	// evaluating check constraint expressions defined on the target table. Its
 	// length is always equal to the number of check constraints on the table
-	// (see opt.Table.CheckCount).
+	// (see opt.Table.CheckCount), plus one if row-level security is enabled on
+	// the table. The extra column stores the result of evaluating the
+	// row-level security policies (see addRowLevelSecurityCheckCol).
 	checkColIDs opt.OptionalColList
 
 	// partialIndexPutColIDs lists the input column IDs storing the boolean
@@ -213,15 +215,19 @@ func (mb *mutationBuilder) init(b *Builder, opName string, tab cat.Table, alias
 	mb.targetColList = make(opt.ColList, 0, n)
 
 	// Allocate segmented array of column IDs.
+	numChecks := tab.CheckCount()
+	if tab.IsRowLevelSecurityEnabled() {
+		numChecks++
+	}
 	numPartialIndexes := partialIndexCount(tab)
-	colIDs := make(opt.OptionalColList, n*4+tab.CheckCount()+2*numPartialIndexes)
+	colIDs := make(opt.OptionalColList, n*4+numChecks+2*numPartialIndexes)
 	mb.insertColIDs = colIDs[:n]
 	mb.fetchColIDs = colIDs[n : n*2]
 	mb.updateColIDs = colIDs[n*2 : n*3]
 	mb.upsertColIDs = colIDs[n*3 : n*4]
-	mb.checkColIDs = colIDs[n*4 : n*4+tab.CheckCount()]
-	mb.partialIndexPutColIDs = colIDs[n*4+tab.CheckCount() : n*4+tab.CheckCount()+numPartialIndexes]
-	mb.partialIndexDelColIDs = colIDs[n*4+tab.CheckCount()+numPartialIndexes:]
+	mb.checkColIDs = colIDs[n*4 : n*4+numChecks]
+	mb.partialIndexPutColIDs = colIDs[n*4+numChecks : n*4+numChecks+numPartialIndexes]
+	mb.partialIndexDelColIDs = colIDs[n*4+numChecks+numPartialIndexes:]
 
 	// Add the table and its columns (including mutation columns) to metadata.
 	mb.tabID = mb.md.AddTable(tab, &mb.alias)
@@ -295,6 +301,7 @@ func (mb *mutationBuilder) buildInputForUpdate(
 		noRowLocking,
 		inScope,
 	)
+	mb.b.addRowLevelSecurityFilter(mb.tab, tree.PolicyCommandUpdate, mb.fetchScope)
 
 	// Set list of columns that will be fetched by the input expression.
 	mb.setFetchColIDs(mb.fetchScope.cols)
@@ -330,6 +337,22 @@ func (mb *mutationBuilder) buildInputForUpdate(
 	// WHERE
 	mb.b.buildWhere(where, mb.outScope)
 
+	// Build a distinct on to ensure there is at most one row in the joined output
+	// for every row in the table. Unlike UPDATE ... FROM, hidden primary key
+	// columns are included, since deleting a row more than once would return
+	// it more than once and cascade the deletion more than once. The distinct on
+	// is built before ORDER BY and LIMIT so that duplicate matches do not count
+	// toward the limit.
+	if usingClausePresent {
+		var pkCols opt.ColSet
+		primaryIndex := mb.tab.Index(cat.PrimaryIndex)
+		for i := 0; i < primaryIndex.KeyColumnCount(); i++ {
+			pkCols.Add(mb.fetchColIDs[primaryIndex.Column(i).Ordinal()])
+		}
+		mb.outScope = mb.b.buildDistinctOn(
+			pkCols, mb.outScope, false /* nullsAreDistinct */, "" /* errorOnDup */)
+	}
+
 	// SELECT + ORDER BY (which may add projected expressions)
 	projectionsScope := mb.outScope.replace()
 	projectionsScope.appendColumnsFromScope(mb.outScope)
@@ -380,9 +403,22 @@ func (mb *mutationBuilder) buildInputForUpdate(
 //   LIMIT <limit>
 //
 // All columns from the table to update are added to fetchColList.
+// If a USING clause is defined, we build out each of the table expressions
+// required and JOIN them together with the target table, then apply the WHERE
+// conditions. A DISTINCT ON the primary key of the target table ensures that
+// each target row is deleted at most once, no matter how many rows of the
+// USING tables it matches, which gives the input the semantics of a semi-join.
+//
+// buildInputForDelete stores the columns of the USING tables in the
+// mutationBuilder so that they can be referenced by the RETURNING clause.
 // TODO(andyk): Do needed column analysis to project fewer columns if possible.
 func (mb *mutationBuilder) buildInputForDelete(
-	inScope *scope, texpr tree.TableExpr, where *tree.Where, limit *tree.Limit, orderBy tree.OrderBy,
+	inScope *scope,
+	texpr tree.TableExpr,
+	using tree.TableExprs,
+	where *tree.Where,
+	limit *tree.Limit,
+	orderBy tree.OrderBy,
 ) {
 	var indexFlags *tree.IndexFlags
 	if source, ok := texpr.(*tree.AliasedTableExpr); ok && source.IndexFlags != nil {
@@ -410,11 +446,56 @@ func (mb *mutationBuilder) buildInputForDelete(
 		noRowLocking,
 		inScope,
 	)
-	mb.outScope = mb.fetchScope
+	mb.b.addRowLevelSecurityFilter(mb.tab, tree.PolicyCommandDelete, mb.fetchScope)
+
+	// Set list of columns that will be fetched by the input expression.
+	mb.setFetchColIDs(mb.fetchScope.cols)
+
+	// If there is a USING clause present, we must join all the tables
+	// together with the table being deleted from.
+	usingClausePresent := len(using) > 0
+	if usingClausePresent {
+		usingScope := mb.b.buildFromTables(using, noRowLocking, inScope)
+
+		// Check that the same table name is not used multiple times.
+		mb.b.validateJoinTableNames(mb.fetchScope, usingScope)
+
+		// The USING table columns can be accessed by the RETURNING clause of the
+		// query and so we have to make them accessible.
+		mb.extraAccessibleCols = usingScope.cols
+
+		// Add the columns in the USING scope. As for UPDATE ... FROM, a new
+		// scope is created so that fetchScope is not modified.
+		mb.outScope = mb.fetchScope.replace()
+		mb.outScope.appendColumnsFromScope(mb.fetchScope)
+		mb.outScope.appendColumnsFromScope(usingScope)
+
+		left := mb.fetchScope.expr
+		right := usingScope.expr
+		mb.outScope.expr = mb.b.factory.ConstructInnerJoin(left, right, memo.TrueFilter, memo.EmptyJoinPrivate)
+	} else {
+		mb.outScope = mb.fetchScope
+	}
 
 	// WHERE
 	mb.b.buildWhere(where, mb.outScope)
 
+	// Build a distinct on to ensure there is at most one row in the joined output
+	// for every row in the table. Unlike UPDATE ... FROM, hidden primary key
+	// columns are included, since deleting a row more than once would return
+	// it more than once and cascade the deletion more than once. The distinct on
+	// is built before ORDER BY and LIMIT so that duplicate matches do not count
+	// toward the limit.
+	if usingClausePresent {
+		var pkCols opt.ColSet
+		primaryIndex := mb.tab.Index(cat.PrimaryIndex)
+		for i := 0; i < primaryIndex.KeyColumnCount(); i++ {
+			pkCols.Add(mb.fetchColIDs[primaryIndex.Column(i).Ordinal()])
+		}
+		mb.outScope = mb.b.buildDistinctOn(
+			pkCols, mb.outScope, false /* nullsAreDistinct */, "" /* errorOnDup */)
+	}
+
 	// SELECT + ORDER BY (which may add projected expressions)
 	projectionsScope := mb.outScope.replace()
 	projectionsScope.appendColumnsFromScope(mb.outScope)
@@ -428,9 +509,6 @@ func (mb *mutationBuilder) buildInputForDelete(
 	}
 
 	mb.outScope = projectionsScope
-
-	// Set list of columns that will be fetched by the input expression.
-	mb.setFetchColIDs(mb.outScope.cols)
 }
 
 // addTargetColsByName adds one target column for each of the names in the given
@@ -476,6 +554,17 @@ func (mb *mutationBuilder) addTargetCol(ord int) {
 	mb.targetColSet.Add(colID)
 
 	mb.targetColList = append(mb.targetColList, colID)
+
+	// The target column is a dependency of the statement; see
+	// resolveTableForMutation.
+	if mb.b.trackViewDeps {
+		for i := len(mb.b.viewDeps) - 1; i >= 0; i-- {
+			if mb.b.viewDeps[i].DataSource == mb.tab {
+				mb.b.viewDeps[i].ColumnOrdinals.Add(ord)
+				break
+			}
+		}
+	}
 }
 
 // extractValuesInput tests whether the given input is a VALUES clause with no
@@ -1051,6 +1140,12 @@ func (mb *mutationBuilder) parseDefaultExpr(colID opt.ColumnID) tree.Expr {
 	col := mb.tab.Column(ord)
 	exprStr := col.DefaultExprStr()
 
+	// Columns of a domain type without a default expression use the default
+	// expression of the domain, if any.
+	if typ := col.DatumType(); exprStr == "" && typ.IsDomain() && typ.TypeMeta.DomainData != nil {
+		exprStr = typ.TypeMeta.DomainData.DefaultExpr
+	}
+
 	// If no default expression, return NULL or a default value.
 	if exprStr == "" {
 		if col.IsMutation() && !col.IsNullable() {
@@ -78,6 +78,10 @@ func (mb *mutationBuilder) findArbiters(onConflict *tree.OnConflict) arbiterSet
 		for i, uc := 0, mb.tab.UniqueCount(); i < uc; i++ {
 			constraint := mb.tab.Unique(i)
 			if constraint.Name() == string(onConflict.Constraint) {
+				if constraint.IsExclusion() {
+					panic(pgerror.Newf(pgcode.FeatureNotSupported,
+						"ON CONFLICT is not supported with exclusion constraint %q", onConflict.Constraint))
+				}
 				if _, partial := constraint.Predicate(); partial {
 					panic(partialIndexArbiterError(onConflict, mb.tab.Name()))
 				}
@@ -166,7 +170,9 @@ func (mb *mutationBuilder) inferArbitersFromConflictOrds(
 			}
 		}
 		for uc, ucCount := 0, mb.tab.UniqueCount(); uc < ucCount; uc++ {
-			if mb.tab.Unique(uc).WithoutIndex() {
+			// Exclusion constraints are not used as arbiters. They are enforced by
+			// uniqueness checks instead.
+			if u := mb.tab.Unique(uc); u.WithoutIndex() && !u.IsExclusion() {
 				arbiters.AddUniqueConstraint(uc)
 			}
 		}
@@ -224,6 +230,10 @@ func (mb *mutationBuilder) inferArbitersFromConflictOrds(
 			// Unique constraints with an index were handled above.
 			continue
 		}
+		if uniqueConstraint.IsExclusion() {
+			// Exclusion constraints cannot be arbiters.
+			continue
+		}
 
 		// Determine whether the conflict columns match the columns in the
 		// unique constraint. If not, the constraint cannot be an arbiter. We
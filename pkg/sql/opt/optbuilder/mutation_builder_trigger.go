@@ -0,0 +1,309 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// maxTriggerDepth is the maximum nesting depth of BEFORE triggers that are
+// built as part of the same statement. It guards against triggers that
+// (directly or indirectly) fire themselves.
+const maxTriggerDepth = 16
+
+// buildTriggers plans the triggers on the target table that fire for the
+// given event.
+//
+// BEFORE statement-level triggers are built as materialized CTEs that are
+// hoisted to the root of the statement, so that they are run (as subqueries)
+// before the mutation.
+//
+// All other triggers are planned in the same way as FK cascades: they are built
+// lazily by a triggerBuilder. AFTER triggers run after the mutation (and any
+// previous cascades) have run. Row-level triggers require the mutation input to
+// be buffered; the trigger body is run once for each buffered row. Row-level
+// BEFORE triggers run once the input is fully buffered, before the mutation
+// (see memo.FKCascade.Before).
+//
+// If rowFilterCol is not zero, it is a boolean column of the mutation input;
+// row-level triggers only fire for the rows in which it is true.
+func (mb *mutationBuilder) buildTriggers(event tree.TriggerEvent, rowFilterCol opt.ColumnID) {
+	for i, n := 0, mb.tab.TriggerCount(); i < n; i++ {
+		trig := mb.tab.Trigger(i)
+		if !trig.Events.Contains(event) {
+			continue
+		}
+
+		if trig.ActionTime == tree.TriggerActionTimeBefore && !trig.ForEachRow {
+			mb.buildBeforeTrigger(&trig)
+			continue
+		}
+
+		builder := newTriggerBuilder(mb.tab, trig)
+		cascade := memo.FKCascade{
+			FKName:  string(trig.Name),
+			Builder: builder,
+		}
+		if trig.ForEachRow {
+			mb.ensureWithID()
+			cascade.WithID = mb.withID
+			cascade.ForEachRow = true
+			cascade.Before = trig.ActionTime == tree.TriggerActionTimeBefore
+			cascade.RowFilterCol = rowFilterCol
+			cascade.NewValues = make(opt.ColList, len(builder.colOrds))
+			cascade.OldValues = make(opt.ColList, len(builder.colOrds))
+			for j, ord := range builder.colOrds {
+				if event != tree.TriggerEventDelete {
+					cascade.NewValues[j] = mb.mapToReturnColID(ord)
+				}
+				if event != tree.TriggerEventInsert {
+					cascade.OldValues[j] = mb.fetchColIDs[ord]
+				}
+			}
+		}
+		mb.cascades = append(mb.cascades, cascade)
+	}
+}
+
+// buildTriggersForUpsert plans the triggers on the target table for an upsert.
+// Both INSERT and UPDATE triggers fire; row-level INSERT triggers only fire for
+// the inserted rows and row-level UPDATE triggers only fire for the updated
+// rows. The existing rows are always fetched when there are row-level triggers
+// (see needExistingRows), so the canary column tells the two apart.
+func (mb *mutationBuilder) buildTriggersForUpsert() {
+	if !mb.hasRowTriggers(tree.TriggerEventInsert, tree.TriggerEventUpdate) {
+		mb.buildTriggers(tree.TriggerEventInsert, 0 /* rowFilterCol */)
+		mb.buildTriggers(tree.TriggerEventUpdate, 0 /* rowFilterCol */)
+		return
+	}
+	if mb.canaryColID == 0 {
+		panic(errors.AssertionFailedf("upsert with row-level triggers requires a canary column"))
+	}
+
+	f := mb.b.factory
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	isNull := f.ConstructIs(f.ConstructVariable(mb.canaryColID), memo.NullSingleton)
+	insertCol := mb.b.synthesizeColumn(
+		projectionsScope, scopeColName("").WithMetadataName("trigger_insert"), types.Bool,
+		nil /* expr */, isNull,
+	)
+	updateCol := mb.b.synthesizeColumn(
+		projectionsScope, scopeColName("").WithMetadataName("trigger_update"), types.Bool,
+		nil /* expr */, f.ConstructNot(isNull),
+	)
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+
+	mb.buildTriggers(tree.TriggerEventInsert, insertCol.id)
+	mb.buildTriggers(tree.TriggerEventUpdate, updateCol.id)
+}
+
+// hasRowTriggers returns true if the target table has any row-level triggers
+// that fire for one of the given events.
+func (mb *mutationBuilder) hasRowTriggers(events ...tree.TriggerEvent) bool {
+	for i, n := 0, mb.tab.TriggerCount(); i < n; i++ {
+		trig := mb.tab.Trigger(i)
+		if !trig.ForEachRow {
+			continue
+		}
+		for _, ev := range events {
+			if trig.Events.Contains(ev) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// buildBeforeTrigger builds the body of a BEFORE STATEMENT trigger and adds it
+// as a materialized CTE, which will be built at the root level (see
+// buildStmtAtRoot).
+func (mb *mutationBuilder) buildBeforeTrigger(trig *cat.Trigger) {
+	b := mb.b
+	stmt := parseTriggerBody(trig)
+	expr := b.buildTriggerBody(stmt)
+
+	id := b.factory.Memo().NextWithID()
+	b.factory.Metadata().AddWithBinding(id, expr)
+	b.addCTE(&cteSource{
+		id:           id,
+		name:         tree.AliasClause{Alias: trig.Name},
+		originalExpr: stmt,
+		expr:         expr,
+		mtr:          tree.MaterializeClause{Set: true, Materialize: true},
+	})
+}
+
+// buildTriggerBody builds the given trigger body statement.
+func (b *Builder) buildTriggerBody(stmt tree.Statement) memo.RelExpr {
+	if b.triggerDepth >= maxTriggerDepth {
+		panic(pgerror.Newf(pgcode.TriggeredActionException,
+			"trigger nesting limit (%d) reached", maxTriggerDepth))
+	}
+	b.triggerDepth++
+	defer func() { b.triggerDepth-- }()
+
+	return b.buildStmtAtRoot(stmt, nil /* desiredTypes */).expr
+}
+
+// parseTriggerBody parses the body of the given trigger.
+func parseTriggerBody(trig *cat.Trigger) tree.Statement {
+	stmt, err := parser.ParseOne(trig.Body)
+	if err != nil {
+		panic(errors.NewAssertionErrorWithWrappedErrf(err,
+			"failed to parse body of trigger %q", trig.Name))
+	}
+	return stmt.AST
+}
+
+// triggerBuilder is a memo.CascadeBuilder implementation for AFTER triggers
+// and row-level BEFORE triggers.
+//
+// It builds the trigger body so that it is run in the same way as a cascading
+// query. The body is built as a materialized With whose
+// main expression produces no rows, so that the result of the body (if any) is
+// discarded:
+//
+//   with &1 (trig)
+//    ├── insert audit
+//    │    └── values
+//    │         └── (1, 'a')
+//    └── values
+//         └── columns: <none>
+//
+// For row-level triggers, each reference to NEW.<column> in the body is
+// replaced by the placeholder with the ordinal of the column in colOrds; each
+// reference to OLD.<column> is replaced by the placeholder with that ordinal
+// plus len(colOrds). The body is built once with the placeholders left in
+// place; the execution engine binds the values of each row modified by the
+// mutation to these placeholders (see memo.FKCascade.ForEachRow).
+type triggerBuilder struct {
+	tab  cat.Table
+	trig cat.Trigger
+
+	// colOrds contains the ordinals of the table columns that can be referenced
+	// through NEW and OLD.
+	colOrds []int
+}
+
+var _ memo.CascadeBuilder = &triggerBuilder{}
+
+func newTriggerBuilder(tab cat.Table, trig cat.Trigger) *triggerBuilder {
+	tb := &triggerBuilder{tab: tab, trig: trig}
+	if trig.ForEachRow {
+		for i, n := 0, tab.ColumnCount(); i < n; i++ {
+			if tab.Column(i).Kind() == cat.Ordinary {
+				tb.colOrds = append(tb.colOrds, i)
+			}
+		}
+	}
+	return tb
+}
+
+// Build is part of the memo.CascadeBuilder interface.
+func (tb *triggerBuilder) Build(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *tree.EvalContext,
+	catalog cat.Catalog,
+	factoryI interface{},
+	binding opt.WithID,
+	bindingProps *props.Relational,
+	oldValues, newValues opt.ColList,
+) (_ memo.RelExpr, err error) {
+	stmt := parseTriggerBody(&tb.trig)
+	if tb.trig.ForEachRow {
+		// Set up the placeholders for the values of the modified rows.
+		if stmt, err = tb.replaceRowRefs(stmt); err != nil {
+			return nil, err
+		}
+		semaCtxCopy := *semaCtx
+		semaCtxCopy.Placeholders = tree.PlaceholderInfo{PlaceholderTypesInfo: tb.placeholderTypes()}
+		semaCtx = &semaCtxCopy
+	}
+
+	return buildCascadeHelper(ctx, semaCtx, evalCtx, catalog, factoryI, func(b *Builder) memo.RelExpr {
+		// Keep the placeholders so that the body can be reused for each row.
+		b.KeepPlaceholders = tb.trig.ForEachRow
+		body := b.buildTriggerBody(stmt)
+
+		// Discard the result of the body.
+		id := b.factory.Memo().NextWithID()
+		b.factory.Metadata().AddWithBinding(id, body)
+		return b.factory.ConstructWith(
+			body,
+			b.factory.CustomFuncs().ConstructEmptyValues(opt.ColSet{}),
+			&memo.WithPrivate{
+				ID:           id,
+				Name:         string(tb.trig.Name),
+				Mtr:          tree.MaterializeClause{Set: true, Materialize: true},
+				OriginalExpr: stmt,
+			},
+		)
+	})
+}
+
+// placeholderTypes returns the types of the placeholders which replace the
+// references to NEW.<column> and OLD.<column> (see replaceRowRefs).
+func (tb *triggerBuilder) placeholderTypes() tree.PlaceholderTypesInfo {
+	typs := make(tree.PlaceholderTypes, 2*len(tb.colOrds))
+	for i, ord := range tb.colOrds {
+		typs[i] = tb.tab.Column(ord).DatumType()
+		typs[i+len(tb.colOrds)] = typs[i]
+	}
+	return tree.PlaceholderTypesInfo{TypeHints: typs, Types: typs}
+}
+
+// replaceRowRefs replaces references to NEW.<column> and OLD.<column> in the
+// given trigger body with placeholders.
+func (tb *triggerBuilder) replaceRowRefs(stmt tree.Statement) (tree.Statement, error) {
+	var err error
+	newStmt, _ := tree.SimpleStmtVisit(stmt, func(expr tree.Expr) (bool, tree.Expr, error) {
+		name, ok := expr.(*tree.UnresolvedName)
+		if !ok || name.NumParts != 2 || name.Star {
+			return true, expr, nil
+		}
+		var offset int
+		switch name.Parts[1] {
+		case "new":
+		case "old":
+			offset = len(tb.colOrds)
+		default:
+			return true, expr, nil
+		}
+		for i, ord := range tb.colOrds {
+			if string(tb.tab.Column(ord).ColName()) == name.Parts[0] {
+				return false, &tree.Placeholder{Idx: tree.PlaceholderIdx(i + offset)}, nil
+			}
+		}
+		if err == nil {
+			err = pgerror.Newf(pgcode.UndefinedColumn,
+				"record %q has no field %q", name.Parts[1], name.Parts[0])
+		}
+		return false, expr, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newStmt, nil
+}
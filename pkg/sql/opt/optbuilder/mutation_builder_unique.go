@@ -17,6 +17,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util"
@@ -33,7 +34,8 @@ var UniquenessChecksForGenRandomUUIDClusterMode = settings.RegisterBoolSetting(
 ).WithPublic()
 
 // buildUniqueChecksForInsert builds uniqueness check queries for an insert.
-// These check queries are used to enforce UNIQUE WITHOUT INDEX constraints.
+// These check queries are used to enforce UNIQUE WITHOUT INDEX constraints and
+// exclusion constraints.
 func (mb *mutationBuilder) buildUniqueChecksForInsert() {
 	// We only need to build unique checks if there is at least one unique
 	// constraint without an index.
@@ -197,8 +199,13 @@ type uniqueCheckHelper struct {
 	// UniqueConstraint.
 	uniqueOrdinals util.FastIntSet
 
+	// overlapsOrdinals is the subset of uniqueOrdinals which are compared with
+	// the && operator rather than equality. It is only non-empty for exclusion
+	// constraints.
+	overlapsOrdinals util.FastIntSet
+
 	// primaryKeyOrdinals includes the ordinals from any primary key columns
-	// that are not included in uniqueOrdinals.
+	// that are not compared with equality by the constraint.
 	primaryKeyOrdinals util.FastIntSet
 
 	// The scope and column ordinals of the scan that will serve as the right
@@ -220,20 +227,28 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 		uniqueOrdinal: uniqueOrdinal,
 	}
 
-	var uniqueOrds util.FastIntSet
+	var uniqueOrds, overlapsOrds util.FastIntSet
 	for i, n := 0, h.unique.ColumnCount(); i < n; i++ {
-		uniqueOrds.Add(h.unique.ColumnOrdinal(mb.tab, i))
+		ord := h.unique.ColumnOrdinal(mb.tab, i)
+		uniqueOrds.Add(ord)
+		if h.unique.ColumnOperator(i) == treecmp.Overlaps {
+			overlapsOrds.Add(ord)
+		}
 	}
+	// Only the columns compared with equality guarantee that two conflicting
+	// rows share the same values.
+	equalityOrds := uniqueOrds.Difference(overlapsOrds)
 
-	// Find the primary key columns that are not part of the unique constraint.
-	// If there aren't any, we don't need a check.
+	// Find the primary key columns that are not part of the unique constraint,
+	// or that are compared with && by an exclusion constraint. If there aren't
+	// any, we don't need a check.
 	// TODO(mgartner): We also don't need a check if there exists a unique index
 	// with columns that are a subset of the unique constraint columns.
 	// Similarly, we don't need a check for a partial unique constraint if there
 	// exists a non-partial unique constraint with columns that are a subset of
 	// the partial unique constraint columns.
 	primaryOrds := getIndexLaxKeyOrdinals(mb.tab.Index(cat.PrimaryIndex))
-	primaryOrds.DifferenceWith(uniqueOrds)
+	primaryOrds.DifferenceWith(equalityOrds)
 	if primaryOrds.Empty() {
 		// The primary key columns are a subset of the unique columns; unique check
 		// not needed.
@@ -241,6 +256,7 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	}
 
 	h.uniqueOrdinals = uniqueOrds
+	h.overlapsOrdinals = overlapsOrds
 	h.primaryKeyOrdinals = primaryOrds
 
 	for tabOrd, ok := h.uniqueOrdinals.Next(0); ok; tabOrd, ok = h.uniqueOrdinals.Next(tabOrd + 1) {
@@ -290,8 +306,11 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	// However, because the region column is computed and depends only on k, the
 	// presence of the unique index on (region, k) (i.e., the primary index) is
 	// sufficient to guarantee the uniqueness of k.
+	//
+	// For exclusion constraints, only the columns compared with equality are
+	// considered, since overlapping values can be distinct.
 	var uniqueCols opt.ColSet
-	h.uniqueOrdinals.ForEach(func(ord int) {
+	equalityOrds.ForEach(func(ord int) {
 		colID := h.scanScope.cols[ord].id
 		uniqueCols.Add(colID)
 	})
@@ -315,6 +334,9 @@ func (h *uniqueCheckHelper) buildInsertionCheck() memo.UniqueChecksItem {
 	// Build the join filters:
 	//   (new_a = existing_a) AND (new_b = existing_b) AND ...
 	//
+	// Columns of an exclusion constraint which use the && operator are instead
+	// compared with (new_c && existing_c).
+	//
 	// Set the capacity to h.uniqueOrdinals.Len()+1 since we'll have an equality
 	// condition for each column in the unique constraint, plus one additional
 	// condition to prevent rows from matching themselves (see below). If the
@@ -327,12 +349,15 @@ func (h *uniqueCheckHelper) buildInsertionCheck() memo.UniqueChecksItem {
 	}
 	semiJoinFilters := make(memo.FiltersExpr, 0, numFilters)
 	for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
-		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(
-			f.ConstructEq(
-				f.ConstructVariable(withScanScope.cols[i].id),
-				f.ConstructVariable(h.scanScope.cols[i].id),
-			),
-		))
+		left := f.ConstructVariable(withScanScope.cols[i].id)
+		right := f.ConstructVariable(h.scanScope.cols[i].id)
+		var cmp opt.ScalarExpr
+		if h.overlapsOrdinals.Contains(i) {
+			cmp = h.constructOverlaps(left, right, withScanScope.cols[i].typ)
+		} else {
+			cmp = f.ConstructEq(left, right)
+		}
+		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(cmp))
 	}
 
 	// If the unique constraint is partial, we need to filter out inserted rows
@@ -375,8 +400,17 @@ func (h *uniqueCheckHelper) buildInsertionCheck() memo.UniqueChecksItem {
 	// Collect the key columns that will be shown in the error message if there
 	// is a duplicate key violation resulting from this uniqueness check.
 	keyCols := make(opt.ColList, 0, h.uniqueOrdinals.Len())
-	for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
-		keyCols = append(keyCols, withScanScope.cols[i].id)
+	if h.unique.IsExclusion() {
+		// The columns of an exclusion constraint are not necessarily in table
+		// order, so collect them in the order in which they are declared.
+		for i, n := 0, h.unique.ColumnCount(); i < n; i++ {
+			ord := h.unique.ColumnOrdinal(h.mb.tab, i)
+			keyCols = append(keyCols, withScanScope.cols[ord].id)
+		}
+	} else {
+		for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
+			keyCols = append(keyCols, withScanScope.cols[i].id)
+		}
 	}
 
 	// Create a Project that passes-through only the key columns. This allows
@@ -393,6 +427,20 @@ func (h *uniqueCheckHelper) buildInsertionCheck() memo.UniqueChecksItem {
 	})
 }
 
+// constructOverlaps builds the && comparison of two values of the given type.
+// Like the optbuilder does for scalar expressions, geometry and box2d values
+// use BBoxIntersects so that the check can be planned with an inverted join on
+// a spatial index.
+func (h *uniqueCheckHelper) constructOverlaps(
+	left, right opt.ScalarExpr, typ *types.T,
+) opt.ScalarExpr {
+	f := h.mb.b.factory
+	if fam := typ.Family(); fam == types.GeometryFamily || fam == types.Box2DFamily {
+		return f.ConstructBBoxIntersects(left, right)
+	}
+	return f.ConstructOverlaps(left, right)
+}
+
 // buildTableScan builds a Scan of the table. The ordinals of the columns
 // scanned are also returned.
 func (h *uniqueCheckHelper) buildTableScan() (outScope *scope, ordinals []int) {
@@ -0,0 +1,221 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// rowLevelSecurityPolicies returns the row-level security policies of the
+// given table that apply to statements of the given command run by the
+// current user. If row-level security is not enforced for the current user,
+// enforced is false and the table can be read and modified without
+// restrictions.
+//
+// Since the result depends on the current user, it is recorded in the
+// metadata so that cached plans are not reused by users to which different
+// policies apply.
+//
+// Policies are not applied while building the definition of a view, since the
+// view stores its query rather than the built expression. Instead, the query
+// is rebuilt each time the view is used, and the policies that apply to the
+// user querying the view are applied, as for views with security_invoker in
+// PostgreSQL. Unlike privileges, policies are never checked against the owner
+// of the view, so that a view cannot be used to read the rows that policies
+// hide from its users.
+func (b *Builder) rowLevelSecurityPolicies(
+	tab cat.Table, cmd tree.PolicyCommand,
+) (policies []cat.Policy, enforced bool) {
+	if b.skipRowLevelSecurity || b.insideViewDef || !tab.IsRowLevelSecurityEnabled() {
+		return nil, false
+	}
+	ords, enforced, err := cat.ApplicablePolicies(b.ctx, b.catalog, tab)
+	if err != nil {
+		panic(err)
+	}
+	b.factory.Metadata().AddRowLevelSecurityDependency(tab, enforced, ords)
+	for _, i := range ords {
+		if p := tab.Policy(i); p.AppliesToCommand(cmd) {
+			policies = append(policies, p)
+		}
+	}
+	return policies, enforced
+}
+
+// policyUsingExpr returns the expression that existing rows must satisfy to
+// be visible to a statement.
+func policyUsingExpr(p *cat.Policy) string {
+	return p.UsingExpr
+}
+
+// policyCheckExpr returns the expression that new rows must satisfy. If the
+// policy has no WITH CHECK expression, its USING expression is used instead.
+func policyCheckExpr(p *cat.Policy) string {
+	if p.WithCheckExpr != "" {
+		return p.WithCheckExpr
+	}
+	return p.UsingExpr
+}
+
+// combinePolicyExprs combines the expressions of the given policies into a
+// single boolean expression, which holds if any of the permissive policies
+// and all of the restrictive policies allow the row. Policies that lack the
+// expression are ignored. In particular, no rows are allowed if none of the
+// permissive policies have an expression.
+func combinePolicyExprs(
+	policies []cat.Policy, policyExpr func(p *cat.Policy) string,
+) tree.Expr {
+	var permissive, restrictive tree.Expr
+	for i := range policies {
+		str := policyExpr(&policies[i])
+		if str == "" {
+			continue
+		}
+		expr, err := parser.ParseExpr(str)
+		if err != nil {
+			panic(err)
+		}
+		expr = &tree.ParenExpr{Expr: expr}
+		switch {
+		case policies[i].Type == tree.PolicyTypeRestrictive && restrictive == nil:
+			restrictive = expr
+		case policies[i].Type == tree.PolicyTypeRestrictive:
+			restrictive = &tree.AndExpr{Left: restrictive, Right: expr}
+		case permissive == nil:
+			permissive = expr
+		default:
+			permissive = &tree.OrExpr{Left: permissive, Right: expr}
+		}
+	}
+	if permissive == nil {
+		return tree.DBoolFalse
+	}
+	if restrictive == nil {
+		return permissive
+	}
+	return &tree.AndExpr{Left: &tree.ParenExpr{Expr: permissive}, Right: restrictive}
+}
+
+// addRowLevelSecurityFilter wraps the expression of the given scope, which
+// scans the given table, in a Select that filters out the rows that the
+// row-level security policies for the given command do not allow the current
+// user to see.
+//
+// The Select is placed under a Barrier, so that the other filters of the query
+// are only evaluated on the rows that the policies allow, unless they are
+// leakproof. Otherwise, an expression that raises an error, such as a division
+// by zero, could reveal the values of hidden rows.
+func (b *Builder) addRowLevelSecurityFilter(
+	tab cat.Table, cmd tree.PolicyCommand, inScope *scope,
+) {
+	policies, enforced := b.rowLevelSecurityPolicies(tab, cmd)
+	if !enforced {
+		return
+	}
+	filter := b.resolveAndBuildScalar(
+		combinePolicyExprs(policies, policyUsingExpr),
+		types.Bool,
+		exprKindPolicy,
+		tree.RejectSpecial,
+		inScope,
+	)
+	inScope.expr = b.factory.ConstructBarrier(b.factory.ConstructSelect(
+		inScope.expr,
+		memo.FiltersExpr{b.factory.ConstructFiltersItem(filter)},
+	))
+}
+
+// buildPolicyScalar builds a scalar expression that holds if the row in the
+// given scope is allowed by the given policies. See combinePolicyExprs.
+func (mb *mutationBuilder) buildPolicyScalar(
+	inScope *scope, policies []cat.Policy, policyExpr func(p *cat.Policy) string,
+) opt.ScalarExpr {
+	scalarProps := &mb.b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+	mb.b.semaCtx.Properties.Require(exprKindPolicy.String(), tree.RejectSpecial)
+	inScope.context = exprKindPolicy
+
+	texpr := inScope.resolveAndRequireType(combinePolicyExprs(policies, policyExpr), types.Bool)
+	return mb.b.buildScalar(texpr, inScope, nil, nil, nil)
+}
+
+// addRowLevelSecurityCheckCol synthesizes a boolean output column that holds
+// whether the new rows of an INSERT or UPDATE satisfy the row-level security
+// policies for the given command. The column is tracked in checkColIDs after
+// the check constraints of the table, and the mutation fails if it is not
+// true.
+func (mb *mutationBuilder) addRowLevelSecurityCheckCol(cmd tree.PolicyCommand) {
+	policies, enforced := mb.b.rowLevelSecurityPolicies(mb.tab, cmd)
+	if !enforced {
+		return
+	}
+	mb.projectRowLevelSecurityCheckCol(mb.buildPolicyScalar(mb.outScope, policies, policyCheckExpr))
+}
+
+// addRowLevelSecurityCheckColForUpsert is similar to
+// addRowLevelSecurityCheckCol, but checks the new rows of an UPSERT against
+// the INSERT or UPDATE policies, depending on whether a conflicting row
+// exists. A conflicting row must also be visible to the UPDATE policies
+// in order to be updated:
+//
+//   CASE WHEN canary IS NULL THEN <insert check>
+//   ELSE <update using on existing row> AND <update check> END
+//
+// If existing rows are not fetched, the new rows must satisfy both the INSERT
+// and the UPDATE policies.
+func (mb *mutationBuilder) addRowLevelSecurityCheckColForUpsert() {
+	insertPolicies, enforced := mb.b.rowLevelSecurityPolicies(mb.tab, tree.PolicyCommandInsert)
+	if !enforced {
+		return
+	}
+	updatePolicies, _ := mb.b.rowLevelSecurityPolicies(mb.tab, tree.PolicyCommandUpdate)
+
+	f := mb.b.factory
+	insertCheck := mb.buildPolicyScalar(mb.outScope, insertPolicies, policyCheckExpr)
+	updateCheck := mb.buildPolicyScalar(mb.outScope, updatePolicies, policyCheckExpr)
+	if mb.canaryColID == 0 {
+		mb.projectRowLevelSecurityCheckCol(f.ConstructAnd(insertCheck, updateCheck))
+		return
+	}
+	updateUsing := mb.buildPolicyScalar(mb.fetchScope, updatePolicies, policyUsingExpr)
+	mb.projectRowLevelSecurityCheckCol(f.ConstructCase(
+		memo.TrueSingleton,
+		memo.ScalarListExpr{
+			f.ConstructWhen(
+				f.ConstructIs(f.ConstructVariable(mb.canaryColID), memo.NullSingleton),
+				insertCheck,
+			),
+		},
+		f.ConstructAnd(updateUsing, updateCheck),
+	))
+}
+
+// projectRowLevelSecurityCheckCol projects the given row-level security check
+// and tracks it as the last of the check columns.
+func (mb *mutationBuilder) projectRowLevelSecurityCheckCol(check opt.ScalarExpr) {
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+
+	// Use an anonymous name because the column cannot be referenced in other
+	// expressions.
+	scopeCol := mb.b.synthesizeColumn(
+		projectionsScope, scopeColName("").WithMetadataName("rls"), types.Bool, nil /* expr */, check,
+	)
+	mb.checkColIDs[mb.tab.CheckCount()] = scopeCol.id
+
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+}
@@ -118,6 +118,7 @@ const (
 	exprKindNone exprKind = iota
 	exprKindAlterTableSplitAt
 	exprKindDistinctOn
+	exprKindDomainCheck
 	exprKindFrom
 	exprKindGroupBy
 	exprKindHaving
@@ -126,6 +127,7 @@ const (
 	exprKindOffset
 	exprKindOn
 	exprKindOrderBy
+	exprKindPolicy
 	exprKindReturning
 	exprKindSelect
 	exprKindStoreID
@@ -139,6 +141,7 @@ var exprKindName = [...]string{
 	exprKindNone:              "",
 	exprKindAlterTableSplitAt: "ALTER TABLE SPLIT AT",
 	exprKindDistinctOn:        "DISTINCT ON",
+	exprKindDomainCheck:       "DOMAIN CHECK",
 	exprKindFrom:              "FROM",
 	exprKindGroupBy:           "GROUP BY",
 	exprKindHaving:            "HAVING",
@@ -147,6 +150,7 @@ var exprKindName = [...]string{
 	exprKindOffset:            "OFFSET",
 	exprKindOn:                "ON",
 	exprKindOrderBy:           "ORDER BY",
+	exprKindPolicy:            "POLICY",
 	exprKindReturning:         "RETURNING",
 	exprKindSelect:            "SELECT",
 	exprKindStoreID:           "RELOCATE STORE ID",
@@ -1013,7 +1017,7 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 		return false, colI.(*scopeColumn)
 
 	case *tree.FuncExpr:
-		def, err := t.Func.Resolve(s.builder.semaCtx.SearchPath)
+		def, err := t.Func.Resolve(s.builder.ctx, s.builder.semaCtx.SearchPath, s.builder.semaCtx.FunctionResolver)
 		if err != nil {
 			panic(err)
 		}
@@ -1494,7 +1498,7 @@ func (s *scope) replaceCount(
 				e := &cpy
 				e.Exprs = tree.Exprs{tree.DBoolTrue}
 
-				newDef, err := e.Func.Resolve(s.builder.semaCtx.SearchPath)
+				newDef, err := e.Func.Resolve(s.builder.ctx, s.builder.semaCtx.SearchPath, nil /* resolver */)
 				if err != nil {
 					panic(err)
 				}
@@ -1520,7 +1524,7 @@ func (s *scope) replaceCount(
 			if _, err := e.TypeCheck(s.builder.ctx, &semaCtx, types.Any); err != nil {
 				panic(err)
 			}
-			newDef, err := e.Func.Resolve(s.builder.semaCtx.SearchPath)
+			newDef, err := e.Func.Resolve(s.builder.ctx, s.builder.semaCtx.SearchPath, nil /* resolver */)
 			if err != nil {
 				panic(err)
 			}
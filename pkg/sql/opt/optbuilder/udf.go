@@ -0,0 +1,209 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+// buildUDF builds a call to a user-defined function. Calls to simple scalar
+// functions are inlined into the query. Other calls are built as regular
+// function calls, which evaluate the body of the function for each call.
+func (b *Builder) buildUDF(
+	f *tree.FuncExpr,
+	def *tree.FunctionDefinition,
+	o *tree.Overload,
+	inScope, outScope *scope,
+	outCol *scopeColumn,
+	colRefs *opt.ColSet,
+) opt.ScalarExpr {
+	// Check that the current user can execute the function, and add a
+	// dependency on the function so that cached plans are invalidated when the
+	// function is altered or dropped.
+	if _, err := b.catalog.ResolveFunctionByOID(b.ctx, o.Oid); err != nil {
+		panic(err)
+	}
+	b.factory.Metadata().AddUserDefinedFunction(o)
+
+	if b.insideViewDef {
+		panic(unimplemented.NewWithIssueDetail(17511, "udf in view",
+			"user-defined functions are not supported in views"))
+	}
+	if b.trackViewDeps {
+		b.viewFuncDeps.Add(int(o.Oid))
+	}
+
+	if inlined := b.tryInlineUDF(f, o); inlined != nil {
+		return b.buildScalar(inlined, inScope, outScope, outCol, colRefs)
+	}
+
+	args := make(memo.ScalarListExpr, len(f.Exprs))
+	for i, pexpr := range f.Exprs {
+		args[i] = b.buildScalar(pexpr.(tree.TypedExpr), inScope, nil, nil, colRefs)
+	}
+
+	// The NULL input behavior of a user-defined function is a property of the
+	// overload rather than of the function definition, which may contain
+	// builtin overloads.
+	props := &tree.FunctionProperties{
+		NullableArgs:     o.CalledOnNullInput,
+		DistsqlBlocklist: true,
+	}
+	out := b.factory.ConstructFunction(args, &memo.FunctionPrivate{
+		Name:       def.Name,
+		Typ:        f.ResolvedType(),
+		Properties: props,
+		Overload:   o,
+	})
+	return b.finishBuildScalar(f, out, inScope, outScope, outCol)
+}
+
+// tryInlineUDF returns the body of the given call to a user-defined function
+// with the arguments of the call substituted for the parameters of the
+// function, type checked and ready to be built. It returns nil if the call
+// cannot be inlined.
+//
+// A call can be inlined if the function is not volatile and its body is a
+// SELECT statement with a single scalar expression and no other clauses. An
+// argument which is neither a constant nor a column reference must be
+// referenced exactly once by the body, so that it is evaluated exactly once.
+func (b *Builder) tryInlineUDF(f *tree.FuncExpr, o *tree.Overload) tree.TypedExpr {
+	if o.Volatility == tree.VolatilityVolatile {
+		return nil
+	}
+	body := parseInlinableUDFBody(o.Body)
+	if body == nil {
+		return nil
+	}
+
+	// Count the references to each parameter, and make sure the body does
+	// not reference columns or call functions which cannot be inlined.
+	refs := make([]int, len(f.Exprs))
+	inlinable := true
+	_, err := tree.SimpleVisit(body, func(expr tree.Expr) (bool, tree.Expr, error) {
+		switch t := expr.(type) {
+		case *tree.Placeholder:
+			refs[t.Idx]++
+		case tree.VarName, *tree.Subquery:
+			inlinable = false
+		case *tree.FuncExpr:
+			fd, err := t.Func.Resolve(b.ctx, b.semaCtx.SearchPath, b.semaCtx.FunctionResolver)
+			if err != nil || fd.Class != tree.NormalClass || t.WindowDef != nil {
+				inlinable = false
+			}
+		}
+		return inlinable, expr, nil
+	})
+	if err != nil || !inlinable {
+		return nil
+	}
+
+	paramTypes := o.Types.(tree.ArgTypes)
+	args := make([]tree.Expr, len(f.Exprs))
+	var nullCheck tree.Expr
+	for i := range f.Exprs {
+		arg := f.Exprs[i].(tree.TypedExpr)
+		isSimple := false
+		switch arg.(type) {
+		case tree.Datum, *scopeColumn:
+			isSimple = true
+		}
+		if !isSimple && (refs[i] != 1 || !o.CalledOnNullInput) {
+			return nil
+		}
+		if !o.CalledOnNullInput {
+			if arg == tree.DNull {
+				return b.typeCheckInlinedUDF(tree.DNull, f)
+			}
+			if _, ok := arg.(*scopeColumn); ok {
+				var isNull tree.Expr = &tree.IsNullExpr{Expr: arg}
+				if nullCheck != nil {
+					isNull = &tree.OrExpr{Left: nullCheck, Right: isNull}
+				}
+				nullCheck = isNull
+			}
+		}
+		if !arg.ResolvedType().Identical(paramTypes[i].Typ) {
+			args[i] = &tree.CastExpr{Expr: arg, Type: paramTypes[i].Typ, SyntaxMode: tree.CastShort}
+		} else {
+			args[i] = arg
+		}
+	}
+
+	inlined, err := tree.SimpleVisit(body, func(expr tree.Expr) (bool, tree.Expr, error) {
+		if ph, ok := expr.(*tree.Placeholder); ok {
+			return false, args[ph.Idx], nil
+		}
+		return true, expr, nil
+	})
+	if err != nil {
+		return nil
+	}
+	if nullCheck != nil {
+		// A strict function returns NULL if any of its arguments is NULL.
+		inlined = &tree.CaseExpr{
+			Whens: []*tree.When{{Cond: nullCheck, Val: tree.DNull}},
+			Else:  inlined,
+		}
+	}
+	return b.typeCheckInlinedUDF(inlined, f)
+}
+
+// typeCheckInlinedUDF type checks the inlined body of a call to a
+// user-defined function. It returns nil if the body cannot be type checked,
+// or if it calls another user-defined function; such calls are built as
+// regular function calls instead.
+func (b *Builder) typeCheckInlinedUDF(inlined tree.Expr, f *tree.FuncExpr) tree.TypedExpr {
+	typ := f.ResolvedType()
+	texpr, err := tree.TypeCheck(b.ctx,
+		&tree.CastExpr{Expr: inlined, Type: typ, SyntaxMode: tree.CastShort}, b.semaCtx, typ,
+	)
+	if err != nil {
+		return nil
+	}
+	callsUDF := false
+	_, _ = tree.SimpleVisit(texpr, func(expr tree.Expr) (bool, tree.Expr, error) {
+		if fn, ok := expr.(*tree.FuncExpr); ok && fn.ResolvedOverload() != nil &&
+			fn.ResolvedOverload().IsUDF {
+			callsUDF = true
+		}
+		return !callsUDF, expr, nil
+	})
+	if callsUDF {
+		return nil
+	}
+	return texpr
+}
+
+// parseInlinableUDFBody returns the single scalar expression selected by the
+// body of a user-defined function, or nil if the body has any other form.
+func parseInlinableUDFBody(body string) tree.Expr {
+	stmt, err := parser.ParseOne(body)
+	if err != nil {
+		return nil
+	}
+	sel, ok := stmt.AST.(*tree.Select)
+	if !ok || sel.With != nil || sel.OrderBy != nil || sel.Limit != nil || sel.Locking != nil {
+		return nil
+	}
+	clause, ok := sel.Select.(*tree.SelectClause)
+	if !ok || len(clause.Exprs) != 1 ||
+		len(clause.From.Tables) != 0 || clause.From.AsOf.Expr != nil ||
+		clause.Where != nil || clause.GroupBy != nil || clause.Having != nil ||
+		clause.Window != nil || clause.Distinct || clause.DistinctOn != nil {
+		return nil
+	}
+	return clause.Exprs[0].Expr
+}
@@ -553,6 +553,12 @@ func (b *Builder) resolveTableForMutation(
 			"%q does not resolve to a table", tree.ErrString(n)))
 	}
 
+	// The mutated table is a dependency of the statement (e.g. of the body of a
+	// trigger; views cannot contain mutations).
+	if b.trackViewDeps {
+		b.viewDeps = append(b.viewDeps, opt.ViewDep{DataSource: tab})
+	}
+
 	if outerAlias != nil {
 		alias = *outerAlias
 	}
@@ -216,6 +216,11 @@ func init() {
 		buildChildReqOrdering: sortBuildChildReqOrdering,
 		buildProvidedOrdering: sortBuildProvided,
 	}
+	funcMap[opt.BarrierOp] = funcs{
+		canProvideOrdering:    barrierCanProvideOrdering,
+		buildChildReqOrdering: barrierBuildChildReqOrdering,
+		buildProvidedOrdering: barrierBuildProvided,
+	}
 	funcMap[opt.DistributeOp] = funcs{
 		canProvideOrdering:    distributeCanProvideOrdering,
 		buildChildReqOrdering: distributeBuildChildReqOrdering,
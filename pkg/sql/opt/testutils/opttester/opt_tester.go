@@ -2120,29 +2120,26 @@ func (ot *OptTester) IndexCandidates() (string, error) {
 	indexCandidates := indexrec.FindIndexCandidateSet(expr, expr.(memo.RelExpr).Memo().Metadata())
 
 	// Build a formatted string to output from the map of indexCandidates.
-	tablesOutput := make([]string, 0, len(indexCandidates))
-	for t, indexes := range indexCandidates {
+	tableIndexes := make(map[cat.Table][]string, len(indexCandidates.Indexes))
+	for t, indexes := range indexCandidates.Indexes {
+		for _, index := range indexes {
+			tableIndexes[t] = append(tableIndexes[t], formatIndexCandidate(t, index, ""))
+		}
+	}
+	for t, indexes := range indexCandidates.Partial {
+		for _, index := range indexes {
+			tableIndexes[t] = append(
+				tableIndexes[t], formatIndexCandidate(t, index.Columns, index.Predicate),
+			)
+		}
+	}
+
+	tablesOutput := make([]string, 0, len(tableIndexes))
+	for t, indexesOutput := range tableIndexes {
 		var tableSb strings.Builder
 		tableName := t.Name()
 		tableSb.WriteString(tableName.String())
 		tableSb.WriteString(":\n")
-		indexesOutput := make([]string, len(indexes))
-		for i, index := range indexes {
-			var indexSb strings.Builder
-			indexSb.WriteString(" (")
-			for j, indexCol := range index {
-				if j > 0 {
-					indexSb.WriteString(", ")
-				}
-				colName := indexCol.Column.ColName()
-				indexSb.WriteString(colName.String())
-				if indexCol.Descending {
-					indexSb.WriteString(" DESC")
-				}
-			}
-			indexSb.WriteString(")\n")
-			indexesOutput[i] = indexSb.String()
-		}
 		sort.Strings(indexesOutput)
 		tableSb.WriteString(strings.Join(indexesOutput, ""))
 		tablesOutput = append(tablesOutput, tableSb.String())
@@ -2151,6 +2148,34 @@ func (ot *OptTester) IndexCandidates() (string, error) {
 	return strings.Join(tablesOutput, ""), nil
 }
 
+// formatIndexCandidate formats the key columns of an index candidate on the
+// given table, followed by its predicate if it is a partial index candidate.
+// Hypothetical expression index columns are formatted as their expression.
+func formatIndexCandidate(t cat.Table, index []cat.IndexColumn, predicate string) string {
+	var indexSb strings.Builder
+	indexSb.WriteString(" (")
+	for j, indexCol := range index {
+		if j > 0 {
+			indexSb.WriteString(", ")
+		}
+		if indexCol.Ordinal() >= t.ColumnCount() {
+			indexSb.WriteString(indexCol.ComputedExprStr())
+		} else {
+			colName := indexCol.Column.ColName()
+			indexSb.WriteString(colName.String())
+		}
+		if indexCol.Descending {
+			indexSb.WriteString(" DESC")
+		}
+	}
+	indexSb.WriteString(")")
+	if predicate != "" {
+		indexSb.WriteString(" WHERE " + predicate)
+	}
+	indexSb.WriteString("\n")
+	return indexSb.String()
+}
+
 // IndexRecommendations is used with the index-recommendations option. It
 // determines index recommendations for the SQL statement, if they exist, and
 // formats them as a human-readable string.
@@ -2217,6 +2242,7 @@ func (ot *OptTester) optimizeExpr(
 	}
 	if tables != nil {
 		o.Memo().Metadata().UpdateTableMeta(tables)
+		indexrec.AddHypotheticalTableExprs(o.Factory())
 	}
 	root, err := o.Optimize()
 	if err != nil {
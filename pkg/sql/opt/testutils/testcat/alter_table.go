@@ -27,6 +27,7 @@ import (
 // Supported commands:
 //  - INJECT STATISTICS: imports table statistics from a JSON object.
 //  - ADD CONSTRAINT FOREIGN KEY: add a foreign key reference.
+//  - {ENABLE | DISABLE | FORCE | NO FORCE} ROW LEVEL SECURITY.
 //
 func (tc *Catalog) AlterTable(stmt *tree.AlterTable) {
 	tn := stmt.Table.ToTableName()
@@ -48,6 +49,18 @@ func (tc *Catalog) AlterTable(stmt *tree.AlterTable) {
 				panic(errors.AssertionFailedf("unsupported constraint type %v", d))
 			}
 
+		case *tree.AlterTableRowLevelSecurity:
+			switch t.Action {
+			case tree.RowLevelSecurityEnable:
+				tab.rowLevelSecurity = true
+			case tree.RowLevelSecurityDisable:
+				tab.rowLevelSecurity = false
+			case tree.RowLevelSecurityForce:
+				tab.forceRowLevelSecurity = true
+			case tree.RowLevelSecurityNoForce:
+				tab.forceRowLevelSecurity = false
+			}
+
 		default:
 			panic(errors.AssertionFailedf("unsupported ALTER TABLE command %T", t))
 		}
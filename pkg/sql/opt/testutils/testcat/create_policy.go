@@ -0,0 +1,52 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package testcat
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+// CreatePolicy is a partial implementation of the CREATE POLICY statement.
+func (tc *Catalog) CreatePolicy(stmt *tree.CreatePolicy) {
+	// Update the table name to include catalog and schema if not provided.
+	tc.qualifyTableName(&stmt.Table)
+
+	// Ensure that table with that name exists.
+	tab := tc.Table(&stmt.Table)
+
+	for i := range tab.policies {
+		if tab.policies[i].Name == stmt.Name {
+			panic(errors.Newf("policy %q already exists on table %s", stmt.Name, tab.Name()))
+		}
+	}
+
+	policy := cat.Policy{
+		Name:    stmt.Name,
+		Type:    stmt.Type,
+		Command: stmt.Command,
+	}
+	for _, role := range stmt.Roles {
+		policy.Roles = append(policy.Roles, security.MakeSQLUsernameFromPreNormalizedString(role.Name))
+	}
+	if len(policy.Roles) == 0 {
+		policy.Roles = append(policy.Roles, security.PublicRoleName())
+	}
+	if stmt.Using != nil {
+		policy.UsingExpr = tree.Serialize(stmt.Using)
+	}
+	if stmt.WithCheck != nil {
+		policy.WithCheckExpr = tree.Serialize(stmt.WithCheck)
+	}
+	tab.policies = append(tab.policies, policy)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util"
@@ -226,6 +227,9 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 				tab.addIndex(&def.IndexTableDef, uniqueIndex)
 			}
 
+		case *tree.ExclusionConstraintTableDef:
+			tab.addExclusionConstraint(def)
+
 		case *tree.IndexTableDef:
 			tab.addIndex(def, nonUniqueIndex)
 
@@ -533,6 +537,7 @@ func (tc *Catalog) resolveFK(tab *Table, d *tree.ForeignKeyConstraintTableDef) {
 		matchMethod:              d.Match,
 		deleteAction:             d.Actions.Delete,
 		updateAction:             d.Actions.Update,
+		deferrability:            d.Deferrability,
 	}
 	tab.outboundFKs = append(tab.outboundFKs, fk)
 	targetTable.inboundFKs = append(targetTable.inboundFKs, fk)
@@ -578,6 +583,40 @@ func (tt *Table) addUniqueConstraint(
 	tt.uniqueConstraints = append(tt.uniqueConstraints, u)
 }
 
+func (tt *Table) addExclusionConstraint(def *tree.ExclusionConstraintTableDef) {
+	// Unlike unique constraints, the columns are not sorted, since each one is
+	// paired with an operator.
+	cols := make([]int, len(def.Elems))
+	ops := make([]treecmp.ComparisonOperatorSymbol, len(def.Elems))
+	var buf bytes.Buffer
+	buf.WriteString(tt.TabName.Table())
+	for i := range def.Elems {
+		cols[i] = tt.FindOrdinal(string(def.Elems[i].Column))
+		ops[i] = def.Elems[i].Operator.Symbol
+		buf.WriteRune('_')
+		buf.WriteString(string(def.Elems[i].Column))
+	}
+	buf.WriteString("_excl")
+	name := string(def.Name)
+	if name == "" {
+		name = buf.String()
+	}
+
+	u := UniqueConstraint{
+		name:               name,
+		tabID:              tt.TabID,
+		columnOrdinals:     cols,
+		withoutIndex:       true,
+		validated:          true,
+		deferrability:      def.Deferrability,
+		exclusionOperators: ops,
+	}
+	if def.Predicate != nil {
+		u.predicate = tree.Serialize(def.Predicate)
+	}
+	tt.uniqueConstraints = append(tt.uniqueConstraints, u)
+}
+
 func (tt *Table) addColumn(def *tree.ColumnTableDef) {
 	ordinal := len(tt.Columns)
 	nullable := !def.PrimaryKey.IsPrimaryKey && def.Nullable.Nullability != tree.NotNull
@@ -1102,7 +1141,7 @@ func (ti *Index) addColumnByOrdinal(
 	if colType == keyCol || colType == strictKeyCol {
 		typ := col.DatumType()
 		if col.Kind() == cat.Inverted {
-			if !colinfo.ColumnTypeIsInvertedIndexable(typ) {
+			if !colinfo.ColumnTypeIsInvertedIndexable(typ) && !colinfo.ColumnTypeIsTrigramIndexable(typ) {
 				panic(fmt.Errorf(
 					"column %s of type %s is not allowed as the last column of an inverted index",
 					col.ColName(), typ,
@@ -0,0 +1,40 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package testcat
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+// CreateTrigger is a partial implementation of the CREATE TRIGGER statement.
+func (tc *Catalog) CreateTrigger(stmt *tree.CreateTrigger) {
+	// Update the table name to include catalog and schema if not provided.
+	tc.qualifyTableName(&stmt.Table)
+
+	// Ensure that table with that name exists.
+	tab := tc.Table(&stmt.Table)
+
+	for i := range tab.triggers {
+		if tab.triggers[i].Name == stmt.Name {
+			panic(errors.Newf("trigger %q already exists on table %s", stmt.Name, tab.Name()))
+		}
+	}
+
+	tab.triggers = append(tab.triggers, cat.Trigger{
+		Name:       stmt.Name,
+		ActionTime: stmt.ActionTime,
+		Events:     stmt.Events,
+		ForEachRow: stmt.ForEachRow,
+		Body:       stmt.Body,
+	})
+}
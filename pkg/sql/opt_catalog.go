@@ -253,6 +253,13 @@ func (oc *optCatalog) ResolveTypeByOID(ctx context.Context, oid oid.Oid) (*types
 	return oc.planner.ResolveTypeByOID(ctx, oid)
 }
 
+// ResolveFunctionByOID is part of the cat.Catalog interface.
+func (oc *optCatalog) ResolveFunctionByOID(
+	ctx context.Context, oid oid.Oid,
+) (*tree.Overload, error) {
+	return oc.planner.ResolveFunctionByOID(ctx, oid)
+}
+
 // ResolveType is part of the cat.Catalog interface.
 func (oc *optCatalog) ResolveType(
 	ctx context.Context, name *tree.UnresolvedObjectName,
@@ -378,6 +385,29 @@ func (oc *optCatalog) RoleExists(ctx context.Context, role security.SQLUsername)
 	return RoleExists(ctx, oc.planner.ExecCfg(), oc.planner.Txn(), role)
 }
 
+// IsOwner is part of the cat.Catalog interface.
+func (oc *optCatalog) IsOwner(ctx context.Context, o cat.Object) (bool, error) {
+	desc, err := getDescFromCatalogObjectForPermissions(o)
+	if err != nil {
+		return false, err
+	}
+	return oc.planner.HasOwnership(ctx, desc)
+}
+
+// IsMemberOfRole is part of the cat.Catalog interface.
+func (oc *optCatalog) IsMemberOfRole(ctx context.Context, role security.SQLUsername) (bool, error) {
+	user := oc.planner.User()
+	if user == role {
+		return true, nil
+	}
+	memberOf, err := oc.planner.MemberOfWithAdminOption(ctx, user)
+	if err != nil {
+		return false, err
+	}
+	_, ok := memberOf[role]
+	return ok, nil
+}
+
 // dataSourceForDesc returns a data source wrapper for the given descriptor.
 // The wrapper might come from the cache, or it may be created now.
 func (oc *optCatalog) dataSourceForDesc(
@@ -648,6 +678,13 @@ type optTable struct {
 	// constraints for user defined types.
 	checkConstraints []cat.CheckConstraint
 
+	// triggers is the set of triggers defined on this table.
+	triggers []cat.Trigger
+
+	// policies is the set of row-level security policies defined on this
+	// table.
+	policies []cat.Policy
+
 	// colMap is a mapping from unique ColumnID to column ordinal within the
 	// table. This is a common lookup that needs to be fast.
 	colMap catalog.TableColMap
@@ -783,7 +820,26 @@ func newOptTable(
 			predicate:    u.Predicate,
 			withoutIndex: true,
 			validity:     u.Validity,
+			deferrability: tree.ConstraintDeferrability{
+				Deferrable:        u.Deferrable,
+				InitiallyDeferred: u.InitiallyDeferred,
+			},
 		})
+		if u.IsExclusion() {
+			ops := make([]treecmp.ComparisonOperatorSymbol, len(u.ExclusionOperators))
+			for j, op := range u.ExclusionOperators {
+				switch op {
+				case treecmp.EQ.String():
+					ops[j] = treecmp.EQ
+				case treecmp.Overlaps.String():
+					ops[j] = treecmp.Overlaps
+				default:
+					return nil, errors.AssertionFailedf(
+						"unsupported operator %q in exclusion constraint %q", op, u.Name)
+				}
+			}
+			ot.uniqueConstraints[len(ot.uniqueConstraints)-1].exclusionOperators = ops
+		}
 	}
 
 	// Build the indexes.
@@ -882,6 +938,10 @@ func newOptTable(
 			match:             fk.Match,
 			deleteAction:      fk.OnDelete,
 			updateAction:      fk.OnUpdate,
+			deferrability: tree.ConstraintDeferrability{
+				Deferrable:        fk.Deferrable,
+				InitiallyDeferred: fk.InitiallyDeferred,
+			},
 		})
 		return nil
 	})
@@ -896,6 +956,10 @@ func newOptTable(
 			match:             fk.Match,
 			deleteAction:      fk.OnDelete,
 			updateAction:      fk.OnUpdate,
+			deferrability: tree.ConstraintDeferrability{
+				Deferrable:        fk.Deferrable,
+				InitiallyDeferred: fk.InitiallyDeferred,
+			},
 		})
 		return nil
 	})
@@ -914,21 +978,17 @@ func newOptTable(
 			// We do not synthesize check constraints for mutation columns.
 			continue
 		}
-		colType := col.DatumType()
-		if colType.UserDefined() {
-			switch colType.Family() {
-			case types.EnumFamily:
-				// We synthesize an (x IN (v1, v2, v3...)) check for enum types.
-				expr := &tree.ComparisonExpr{
-					Operator: treecmp.MakeComparisonOperator(treecmp.In),
-					Left:     &tree.ColumnItem{ColumnName: col.ColName()},
-					Right:    tree.NewDTuple(colType, tree.MakeAllDEnumsInType(colType)...),
-				}
-				synthesizedChecks = append(synthesizedChecks, cat.CheckConstraint{
-					Constraint: tree.Serialize(expr),
-					Validated:  true,
-				})
+		if colType := col.DatumType(); hasSynthesizedCheck(colType) {
+			// We synthesize an (x IN (v1, v2, v3...)) check for enum types.
+			expr := &tree.ComparisonExpr{
+				Operator: treecmp.MakeComparisonOperator(treecmp.In),
+				Left:     &tree.ColumnItem{ColumnName: col.ColName()},
+				Right:    tree.NewDTuple(colType, tree.MakeAllDEnumsInType(colType)...),
 			}
+			synthesizedChecks = append(synthesizedChecks, cat.CheckConstraint{
+				Constraint: tree.Serialize(expr),
+				Validated:  true,
+			})
 		}
 	}
 	// Move all existing and synthesized checks into the opt table.
@@ -942,6 +1002,22 @@ func newOptTable(
 	}
 	ot.checkConstraints = append(ot.checkConstraints, synthesizedChecks...)
 
+	// Add the triggers.
+	if descTriggers := desc.GetTriggers(); len(descTriggers) > 0 {
+		ot.triggers = make([]cat.Trigger, len(descTriggers))
+		for i := range descTriggers {
+			ot.triggers[i] = makeCatTrigger(&descTriggers[i])
+		}
+	}
+
+	// Add the row-level security policies.
+	if descPolicies := desc.GetPolicies(); len(descPolicies) > 0 {
+		ot.policies = make([]cat.Policy, len(descPolicies))
+		for i := range descPolicies {
+			ot.policies[i] = makeCatPolicy(&descPolicies[i])
+		}
+	}
+
 	// Add stats last, now that other metadata is initialized.
 	if stats != nil {
 		ot.stats = make([]optTableStat, len(stats))
@@ -1109,6 +1185,27 @@ func (ot *optTable) Statistic(i int) cat.TableStatistic {
 	return &ot.stats[i]
 }
 
+// hasSynthesizedCheck returns true if a check constraint is synthesized for
+// the public columns of the given type, which is the case for enum types.
+func hasSynthesizedCheck(colType *types.T) bool {
+	return colType.UserDefined() && colType.Family() == types.EnumFamily
+}
+
+// rowLevelSecurityCheckOrdinal returns the ordinal of the row-level security
+// check planned by the optimizer for mutations of the given table. The check
+// follows all the check constraints of the optTable, which are the active
+// checks of the table followed by the checks synthesized for its public
+// columns (see newOptTable and optbuilder.addRowLevelSecurityCheckCol).
+func rowLevelSecurityCheckOrdinal(desc catalog.TableDescriptor) int {
+	ord := len(desc.ActiveChecks())
+	for _, col := range desc.PublicColumns() {
+		if hasSynthesizedCheck(col.GetType()) {
+			ord++
+		}
+	}
+	return ord
+}
+
 // CheckCount is part of the cat.Table interface.
 func (ot *optTable) CheckCount() int {
 	return len(ot.checkConstraints)
@@ -1162,6 +1259,36 @@ func (ot *optTable) Unique(i cat.UniqueOrdinal) cat.UniqueConstraint {
 	return &ot.uniqueConstraints[i]
 }
 
+// TriggerCount is part of the cat.Table interface.
+func (ot *optTable) TriggerCount() int {
+	return len(ot.triggers)
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optTable) Trigger(i int) cat.Trigger {
+	return ot.triggers[i]
+}
+
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (ot *optTable) IsRowLevelSecurityEnabled() bool {
+	return ot.desc.GetRowLevelSecurity()
+}
+
+// IsRowLevelSecurityForced is part of the cat.Table interface.
+func (ot *optTable) IsRowLevelSecurityForced() bool {
+	return ot.desc.GetForceRowLevelSecurity()
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (ot *optTable) PolicyCount() int {
+	return len(ot.policies)
+}
+
+// Policy is part of the cat.Table interface.
+func (ot *optTable) Policy(i int) cat.Policy {
+	return ot.policies[i]
+}
+
 // Zone is part of the cat.Table interface.
 func (ot *optTable) Zone() cat.Zone {
 	return ot.zone
@@ -1643,8 +1770,13 @@ type optUniqueConstraint struct {
 	columns   []descpb.ColumnID
 	predicate string
 
-	withoutIndex bool
-	validity     descpb.ConstraintValidity
+	withoutIndex  bool
+	validity      descpb.ConstraintValidity
+	deferrability tree.ConstraintDeferrability
+
+	// exclusionOperators is set for exclusion constraints, and contains the
+	// operator used to compare each column.
+	exclusionOperators []treecmp.ComparisonOperatorSymbol
 
 	uniquenessGuaranteedByAnotherIndex bool
 }
@@ -1702,6 +1834,24 @@ func (u *optUniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return u.uniquenessGuaranteedByAnotherIndex
 }
 
+// Deferrability is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) Deferrability() tree.ConstraintDeferrability {
+	return u.deferrability
+}
+
+// IsExclusion is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) IsExclusion() bool {
+	return u.exclusionOperators != nil
+}
+
+// ColumnOperator is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) ColumnOperator(i int) treecmp.ComparisonOperatorSymbol {
+	if u.exclusionOperators == nil {
+		return treecmp.EQ
+	}
+	return u.exclusionOperators[i]
+}
+
 // optForeignKeyConstraint implements cat.ForeignKeyConstraint and represents a
 // foreign key relationship. Both the origin and the referenced table store the
 // same optForeignKeyConstraint (as an outbound and inbound reference,
@@ -1715,10 +1865,11 @@ type optForeignKeyConstraint struct {
 	referencedTable   cat.StableID
 	referencedColumns []descpb.ColumnID
 
-	validity     descpb.ConstraintValidity
-	match        descpb.ForeignKeyReference_Match
-	deleteAction catpb.ForeignKeyAction
-	updateAction catpb.ForeignKeyAction
+	validity      descpb.ConstraintValidity
+	match         descpb.ForeignKeyReference_Match
+	deleteAction  catpb.ForeignKeyAction
+	updateAction  catpb.ForeignKeyAction
+	deferrability tree.ConstraintDeferrability
 }
 
 var _ cat.ForeignKeyConstraint = &optForeignKeyConstraint{}
@@ -1790,6 +1941,11 @@ func (fk *optForeignKeyConstraint) UpdateReferenceAction() tree.ReferenceAction
 	return descpb.ForeignKeyReferenceActionType[fk.updateAction]
 }
 
+// Deferrability is part of the cat.ForeignKeyConstraint interface.
+func (fk *optForeignKeyConstraint) Deferrability() tree.ConstraintDeferrability {
+	return fk.deferrability
+}
+
 // optVirtualTable is similar to optTable but is used with virtual tables.
 type optVirtualTable struct {
 	desc catalog.TableDescriptor
@@ -2082,6 +2238,36 @@ func (ot *optVirtualTable) Unique(i cat.UniqueOrdinal) cat.UniqueConstraint {
 	panic(errors.AssertionFailedf("no unique constraints"))
 }
 
+// TriggerCount is part of the cat.Table interface.
+func (ot *optVirtualTable) TriggerCount() int {
+	return 0
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optVirtualTable) Trigger(i int) cat.Trigger {
+	panic(errors.AssertionFailedf("no triggers"))
+}
+
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (ot *optVirtualTable) IsRowLevelSecurityEnabled() bool {
+	return false
+}
+
+// IsRowLevelSecurityForced is part of the cat.Table interface.
+func (ot *optVirtualTable) IsRowLevelSecurityForced() bool {
+	return false
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (ot *optVirtualTable) PolicyCount() int {
+	return 0
+}
+
+// Policy is part of the cat.Table interface.
+func (ot *optVirtualTable) Policy(i int) cat.Policy {
+	panic(errors.AssertionFailedf("no policies"))
+}
+
 // Zone is part of the cat.Table interface.
 func (ot *optVirtualTable) Zone() cat.Zone {
 	panic(errors.AssertionFailedf("no zone"))
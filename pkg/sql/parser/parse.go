@@ -241,6 +241,37 @@ func unaryNegation(e tree.Expr) tree.Expr {
 	}
 }
 
+// makeRecursiveViewSelect constructs the query of a recursive view, which is
+// defined in terms of itself. Like Postgres, we desugar
+//
+//   CREATE RECURSIVE VIEW name (cols) AS query
+//
+// into
+//
+//   CREATE VIEW name AS WITH RECURSIVE name (cols) AS (query) SELECT cols FROM name
+//
+// so that the stored view query is a regular recursive CTE.
+func makeRecursiveViewSelect(name tree.Name, cols tree.NameList, query *tree.Select) *tree.Select {
+	exprs := make(tree.SelectExprs, len(cols))
+	for i := range cols {
+		exprs[i] = tree.SelectExpr{Expr: tree.NewUnresolvedName(string(cols[i]))}
+	}
+	cteName := tree.MakeUnqualifiedTableName(name)
+	return &tree.Select{
+		With: &tree.With{
+			Recursive: true,
+			CTEList: []*tree.CTE{{
+				Name: tree.AliasClause{Alias: name, Cols: cols},
+				Stmt: query,
+			}},
+		},
+		Select: &tree.SelectClause{
+			Exprs: exprs,
+			From:  tree.From{Tables: tree.TableExprs{&tree.AliasedTableExpr{Expr: &cteName}}},
+		},
+	}
+}
+
 // Parse parses a sql statement string and returns a list of Statements.
 func Parse(sql string) (Statements, error) {
 	var p Parser
@@ -55,6 +55,9 @@ func TestScanner(t *testing.T) {
 		{`;`, []int{';'}},
 		{`+`, []int{'+'}},
 		{`-`, []int{'-'}},
+		{`-|-`, []int{RANGE_ADJACENT}},
+		{`-|`, []int{'-', '|'}},
+		{`@@`, []int{AT_AT}},
 		{`*`, []int{'*'}},
 		{`/`, []int{'/'}},
 		{`//`, []int{FLOORDIV}},
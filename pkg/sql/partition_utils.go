@@ -271,3 +271,61 @@ func indexCoveringsForPartitioning(
 	// them to the front.
 	return append(descendentCoverings, coverings...), nil
 }
+
+// partitionSpans returns the spans of the rows of the top-level partition
+// with the given name in every index of the table which has it.
+func partitionSpans(
+	codec keys.SQLCodec, tableDesc catalog.TableDescriptor, name string,
+) (roachpb.Spans, error) {
+	var spans roachpb.Spans
+	for _, idx := range tableDesc.ActiveIndexes() {
+		part := idx.GetPartitioning()
+		if !hasTopLevelPartition(part, name) {
+			continue
+		}
+		idxSpans, err := indexPartitionSpans(codec, tableDesc, idx, part, name)
+		if err != nil {
+			return nil, err
+		}
+		spans = append(spans, idxSpans...)
+	}
+	return spans, nil
+}
+
+// indexPartitionSpans returns the spans of the rows of the top-level partition
+// with the given name of the partitioning of the index. The spans exclude the
+// parts of the partition which belong to other partitions taking precedence
+// over it, such as (1, 2) over (1, DEFAULT).
+func indexPartitionSpans(
+	codec keys.SQLCodec,
+	tableDesc catalog.TableDescriptor,
+	idx catalog.Index,
+	part catalog.Partitioning,
+	name string,
+) (roachpb.Spans, error) {
+	// Only the top-level partitions are relevant, since subpartitions are
+	// contained in their parent partition.
+	relevantPartitions := make(map[string]int32)
+	desc := part.PartitioningDesc()
+	for i := range desc.List {
+		relevantPartitions[desc.List[i].Name] = int32(i)
+	}
+	for i := range desc.Range {
+		relevantPartitions[desc.Range[i].Name] = int32(len(desc.List) + i)
+	}
+	var emptyPrefix []tree.Datum
+	coverings, err := indexCoveringsForPartitioning(
+		&tree.DatumAlloc{}, codec, tableDesc, idx, part, relevantPartitions, emptyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	var spans roachpb.Spans
+	for _, r := range covering.OverlapCoveringMerge(coverings) {
+		payloads := r.Payload.([]interface{})
+		if len(payloads) == 0 || payloads[0].(zonepb.Subzone).PartitionName != name {
+			continue
+		}
+		spans = append(spans, roachpb.Span{Key: r.Start, EndKey: r.End})
+	}
+	return spans, nil
+}
@@ -524,6 +524,10 @@ https://www.postgresql.org/docs/9.5/catalog-pg-authid.html`,
 			if err != nil {
 				return err
 			}
+			bypassRLS, err := options.bypassRLS()
+			if err != nil {
+				return err
+			}
 
 			isSuper, err := userIsSuper(ctx, p, username)
 			if err != nil {
@@ -539,7 +543,7 @@ https://www.postgresql.org/docs/9.5/catalog-pg-authid.html`,
 				tree.MakeDBool(isRoot || createDB),   // rolcreatedb
 				tree.MakeDBool(roleCanLogin),         // rolcanlogin.
 				tree.DBoolFalse,                      // rolreplication
-				tree.DBoolFalse,                      // rolbypassrls
+				tree.MakeDBool(isRoot || bypassRLS),  // rolbypassrls
 				negOneVal,                            // rolconnlimit
 				passwdStarString,                     // rolpassword
 				rolValidUntil,                        // rolvaliduntil
@@ -664,12 +668,12 @@ https://www.postgresql.org/docs/9.5/catalog-pg-class.html`,
 			tree.DNull,      // relacl
 			relOptions,      // reloptions
 			// These columns were automatically created by pg_catalog_test's missing column generator.
-			tree.DNull, // relforcerowsecurity
+			tree.MakeDBool(tree.DBool(table.GetForceRowLevelSecurity())), // relforcerowsecurity
 			tree.DNull, // relispartition
 			tree.DNull, // relispopulated
 			tree.DNull, // relreplident
 			tree.DNull, // relrewrite
-			tree.DNull, // relrowsecurity
+			tree.MakeDBool(tree.DBool(table.GetRowLevelSecurity())), // relrowsecurity
 			tree.DNull, // relpartbound
 			// These columns were automatically created by pg_catalog_test's missing column generator.
 			tree.DNull, // relminmxid
@@ -782,7 +786,6 @@ var (
 
 	// Avoid unused warning for constants.
 	_ = conTypeTrigger
-	_ = conTypeExclusion
 
 	fkActionNone       = tree.NewDString("a")
 	fkActionRestrict   = tree.NewDString("r")
@@ -838,6 +841,9 @@ func populateTableConstraints(
 		consrc := tree.DNull
 		conbin := tree.DNull
 		condef := tree.DNull
+		deferrability := constraintDeferrability(con)
+		condeferrable := tree.MakeDBool(tree.DBool(deferrability.Deferrable))
+		condeferred := tree.MakeDBool(tree.DBool(deferrability.InitiallyDeferred))
 
 		// Determine constraint kind-specific fields.
 		var err error
@@ -926,12 +932,26 @@ func populateTableConstraints(
 				oid = h.UniqueWithoutIndexConstraintOid(
 					db.GetID(), scName, table.GetID(), con.UniqueWithoutIndexConstraint,
 				)
-				f.WriteString("UNIQUE WITHOUT INDEX (")
 				colNames, err := table.NamesForColumnIDs(con.UniqueWithoutIndexConstraint.ColumnIDs)
 				if err != nil {
 					return err
 				}
-				f.WriteString(strings.Join(colNames, ", "))
+				if ops := con.UniqueWithoutIndexConstraint.ExclusionOperators; len(ops) > 0 {
+					contype = conTypeExclusion
+					if conkey, err = colIDArrayToDatum(con.UniqueWithoutIndexConstraint.ColumnIDs); err != nil {
+						return err
+					}
+					f.WriteString("EXCLUDE (")
+					for i := range colNames {
+						if i > 0 {
+							f.WriteString(", ")
+						}
+						f.WriteString(fmt.Sprintf("%s WITH %s", colNames[i], ops[i]))
+					}
+				} else {
+					f.WriteString("UNIQUE WITHOUT INDEX (")
+					f.WriteString(strings.Join(colNames, ", "))
+				}
 				f.WriteByte(')')
 				if con.UniqueWithoutIndexConstraint.Validity != descpb.ConstraintValidity_Validated {
 					f.WriteString(" NOT VALID")
@@ -974,8 +994,8 @@ func populateTableConstraints(
 			dNameOrNull(conName), // conname
 			namespaceOid,         // connamespace
 			contype,              // contype
-			tree.DBoolFalse,      // condeferrable
-			tree.DBoolFalse,      // condeferred
+			condeferrable,        // condeferrable
+			condeferred,          // condeferred
 			tree.MakeDBool(tree.DBool(!con.Unvalidated)), // convalidated
 			tblOid,         // conrelid
 			oidZero,        // contypid
@@ -2349,16 +2369,24 @@ https://www.postgresql.org/docs/9.5/catalog-pg-proc.html`,
 }
 
 var pgCatalogRangeTable = virtualSchemaTable{
-	comment: `range types (empty - feature does not exist)
+	comment: `range types
 https://www.postgresql.org/docs/9.5/catalog-pg-range.html`,
 	schema: vtable.PGCatalogRange,
 	populate: func(_ context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// We currently do not support any range types, so this table is empty.
-		// This table should be populated when any range types are added to
-		// oidToDatum (and therefore pg_type).
+		for _, typ := range types.Ranges {
+			if err := addRow(
+				tree.NewDOid(tree.DInt(typ.Oid())),                 // rngtypid
+				tree.NewDOid(tree.DInt(typ.RangeContents().Oid())), // rngsubtype
+				oidZero, // rngcollation
+				oidZero, // rngsubopc
+				oidZero, // rngcanonical
+				oidZero, // rngsubdiff
+			); err != nil {
+				return err
+			}
+		}
 		return nil
 	},
-	unimplemented: true,
 }
 
 var pgCatalogRewriteTable = virtualSchemaTable{
@@ -2430,6 +2458,10 @@ https://www.postgresql.org/docs/9.5/view-pg-roles.html`,
 				if err != nil {
 					return err
 				}
+				bypassRLS, err := options.bypassRLS()
+				if err != nil {
+					return err
+				}
 				isSuper, err := userIsSuper(ctx, p, username)
 				if err != nil {
 					return err
@@ -2448,7 +2480,7 @@ https://www.postgresql.org/docs/9.5/view-pg-roles.html`,
 					negOneVal,                            // rolconnlimit
 					passwdStarString,                     // rolpassword
 					rolValidUntil,                        // rolvaliduntil
-					tree.DBoolFalse,                      // rolbypassrls
+					tree.MakeDBool(isRoot || bypassRLS),  // rolbypassrls
 					settings,                             // rolconfig
 				)
 			})
@@ -2701,7 +2733,7 @@ https://www.postgresql.org/docs/9.5/view-pg-tables.html`,
 					tree.MakeDBool(tree.DBool(table.IsPhysicalTable())), // hasindexes
 					tree.DBoolFalse, // hasrules
 					tree.DBoolFalse, // hastriggers
-					tree.DBoolFalse, // rowsecurity
+					tree.MakeDBool(tree.DBool(table.GetRowLevelSecurity())), // rowsecurity
 				)
 			})
 	},
@@ -2745,7 +2777,6 @@ var (
 	// Avoid unused warning for constants.
 	_ = typTypeDomain
 	_ = typTypePseudo
-	_ = typTypeRange
 
 	// See https://www.postgresql.org/docs/9.6/static/catalog-pg-type.html#CATALOG-TYPCATEGORY-TABLE.
 	typCategoryArray       = tree.NewDString("A")
@@ -2868,26 +2899,53 @@ func addPGTypeRow(
 		builtinPrefix = "enum_"
 		typType = typTypeEnum
 	}
+	if typ.Family() == types.RangeFamily {
+		typType = typTypeRange
+	}
+	if typ.IsComposite() {
+		builtinPrefix = "record_"
+		typType = typTypeComposite
+	}
 	if cat == typCategoryPseudo {
 		typType = typTypePseudo
 	}
 	typname := typ.PGName()
+	typOid := tree.NewDOid(tree.DInt(typ.Oid()))
+	typNotNull := tree.DBoolFalse
+	typBaseType := oidZero
+	typDefault := tree.DNull
+	if typ.IsDomain() {
+		typType = typTypeDomain
+		typBaseType = typOid
+		typOid = tree.NewDOid(tree.DInt(typ.DomainOID()))
+		typArray = oidZero
+		if domainData := typ.TypeMeta.DomainData; domainData != nil {
+			for _, c := range domainData.Constraints {
+				if c.NotNull {
+					typNotNull = tree.DBoolTrue
+				}
+			}
+			if domainData.DefaultExpr != "" {
+				typDefault = tree.NewDString(domainData.DefaultExpr)
+			}
+		}
+	}
 
 	return addRow(
-		tree.NewDOid(tree.DInt(typ.Oid())), // oid
-		tree.NewDName(typname),             // typname
-		nspOid,                             // typnamespace
-		owner,                              // typowner
-		typLen(typ),                        // typlen
-		typByVal(typ),                      // typbyval (is it fixedlen or not)
-		typType,                            // typtype
-		cat,                                // typcategory
-		tree.DBoolFalse,                    // typispreferred
-		tree.DBoolTrue,                     // typisdefined
-		typDelim,                           // typdelim
-		oidZero,                            // typrelid
-		typElem,                            // typelem
-		typArray,                           // typarray
+		typOid,                 // oid
+		tree.NewDName(typname), // typname
+		nspOid,                 // typnamespace
+		owner,                  // typowner
+		typLen(typ),            // typlen
+		typByVal(typ),          // typbyval (is it fixedlen or not)
+		typType,                // typtype
+		cat,                    // typcategory
+		tree.DBoolFalse,        // typispreferred
+		tree.DBoolTrue,         // typisdefined
+		typDelim,               // typdelim
+		oidZero,                // typrelid
+		typElem,                // typelem
+		typArray,               // typarray
 
 		// regproc references
 		h.RegProc(builtinPrefix+"in"),   // typinput
@@ -2900,13 +2958,13 @@ func addPGTypeRow(
 
 		tree.DNull,      // typalign
 		tree.DNull,      // typstorage
-		tree.DBoolFalse, // typnotnull
-		oidZero,         // typbasetype
+		typNotNull,      // typnotnull
+		typBaseType,     // typbasetype
 		negOneVal,       // typtypmod
 		zeroVal,         // typndims
 		typColl(typ, h), // typcollation
 		tree.DNull,      // typdefaultbin
-		tree.DNull,      // typdefault
+		typDefault,      // typdefault
 		tree.DNull,      // typacl
 	)
 }
@@ -3588,12 +3646,12 @@ https://www.postgresql.org/docs/14/view-pg-cursors.html`,
 				return err
 			}
 			if err := addRow(
-				tree.NewDString(name),        /* name */
-				tree.NewDString(c.statement), /* statement */
-				tree.DBoolFalse,              /* is_holdable */
-				tree.DBoolFalse,              /* is_binary */
-				tree.DBoolFalse,              /* is_scrollable */
-				tz,                           /* creation_date */
+				tree.NewDString(name),                /* name */
+				tree.NewDString(c.statement),         /* statement */
+				tree.MakeDBool(tree.DBool(c.hold)),   /* is_holdable */
+				tree.DBoolFalse,                      /* is_binary */
+				tree.MakeDBool(tree.DBool(c.scroll)), /* is_scrollable */
+				tz,                                   /* creation_date */
 			); err != nil {
 				return err
 			}
@@ -4053,6 +4111,9 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.OidFamily:         typCategoryNumeric,
 	types.UuidFamily:        typCategoryUserDefined,
 	types.INetFamily:        typCategoryNetworkAddr,
+	types.RangeFamily:       typCategoryRange,
+	types.TSQueryFamily:     typCategoryUserDefined,
+	types.TSVectorFamily:    typCategoryUserDefined,
 	types.UnknownFamily:     typCategoryUnknown,
 	types.VoidFamily:        typCategoryPseudo,
 }
@@ -4062,6 +4123,10 @@ func typCategory(typ *types.T) tree.Datum {
 	if typ.Family() == types.ArrayFamily && typ.ArrayContents().Family() == types.AnyFamily {
 		return typCategoryPseudo
 	}
+	// Composite types are tuples, but unlike RECORD they are not pseudo-types.
+	if typ.IsComposite() {
+		return typCategoryComposite
+	}
 	return datumToTypeCategory[typ.Family()]
 }
 
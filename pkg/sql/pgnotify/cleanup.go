@@ -0,0 +1,88 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgnotify
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// Retention is the duration for which sent notifications are kept in
+// system.notifications. Notifications are delivered as soon as the rangefeed
+// watching the table observes them, so they only need to be retained for long
+// enough to survive rangefeed restarts.
+var Retention = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	"sql.notifications.retention",
+	"the amount of time for which notifications sent with NOTIFY are kept in system.notifications",
+	time.Hour,
+	settings.PositiveDuration,
+)
+
+// cleanupInterval is the interval between two deletions of old notifications.
+const cleanupInterval = 10 * time.Minute
+
+// cleanupBatchSize is the maximum number of rows deleted per statement.
+const cleanupBatchSize = 1000
+
+// StartCleanup starts a task which periodically deletes the notifications
+// which are older than the retention period.
+func StartCleanup(
+	ctx context.Context, stopper *stop.Stopper, st *cluster.Settings, ie sqlutil.InternalExecutor,
+) {
+	_ = stopper.RunAsyncTask(ctx, "notifications-cleanup", func(ctx context.Context) {
+		ctx, cancel := stopper.WithCancelOnQuiesce(ctx)
+		defer cancel()
+		timer := timeutil.NewTimer()
+		defer timer.Stop()
+		for {
+			timer.Reset(cleanupInterval)
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+				timer.Read = true
+			}
+			if !st.Version.IsActive(ctx, clusterversion.NotificationsTable) {
+				continue
+			}
+			if err := deleteOldNotifications(ctx, st, ie); err != nil {
+				log.Warningf(ctx, "error deleting old notifications: %v", err)
+			}
+		}
+	})
+}
+
+func deleteOldNotifications(
+	ctx context.Context, st *cluster.Settings, ie sqlutil.InternalExecutor,
+) error {
+	cutoff := timeutil.Now().Add(-Retention.Get(&st.SV))
+	for {
+		n, err := ie.ExecEx(
+			ctx, "delete-old-notifications", nil, /* txn */
+			sessiondata.NodeUserSessionDataOverride,
+			`DELETE FROM system.notifications WHERE created < $1 ORDER BY id LIMIT $2`,
+			cutoff, cleanupBatchSize,
+		)
+		if err != nil || n < cleanupBatchSize {
+			return err
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgnotify
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// MaxChannelLength is the maximum length of a channel name, matching the
+// maximum identifier length in Postgres.
+const MaxChannelLength = 63
+
+// MaxPayloadLength is the maximum length of a notification payload, matching
+// the limit in Postgres.
+const MaxPayloadLength = 8000
+
+// Notification is a notification sent on a channel with NOTIFY or
+// pg_notify.
+type Notification struct {
+	// Channel is the name of the channel the notification was sent on.
+	Channel string
+	// Payload is the (possibly empty) payload of the notification.
+	Payload string
+	// PID is the process ID of the session that sent the notification, as
+	// reported to its client in the BackendKeyData message.
+	PID int32
+}
+
+// ValidateChannel returns an error if the channel name cannot be used with
+// LISTEN or NOTIFY.
+func ValidateChannel(channel string) error {
+	if channel == "" {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name cannot be empty")
+	}
+	if len(channel) > MaxChannelLength {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name too long")
+	}
+	return nil
+}
+
+// ValidatePayload returns an error if the payload is too long to be sent with
+// NOTIFY.
+func ValidatePayload(payload string) error {
+	if len(payload) >= MaxPayloadLength {
+		return pgerror.New(pgcode.InvalidParameterValue, "payload string too long")
+	}
+	return nil
+}
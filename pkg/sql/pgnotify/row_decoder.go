@@ -0,0 +1,82 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgnotify
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// RowDecoder decodes rows from the notifications table.
+type RowDecoder struct {
+	codec   keys.SQLCodec
+	alloc   tree.DatumAlloc
+	columns []catalog.Column
+	decoder valueside.Decoder
+}
+
+// MakeRowDecoder makes a new RowDecoder for the notifications table.
+func MakeRowDecoder(codec keys.SQLCodec) RowDecoder {
+	columns := systemschema.NotificationsTable.PublicColumns()
+	return RowDecoder{
+		codec:   codec,
+		columns: columns,
+		decoder: valueside.MakeDecoder(columns),
+	}
+}
+
+// DecodeRow decodes a row of the system.notifications table. If the value is
+// not present, only the returned id is populated and the tombstone bool will
+// be set.
+func (d *RowDecoder) DecodeRow(
+	kv roachpb.KeyValue,
+) (id int64, _ Notification, tombstone bool, _ error) {
+	keyTypes := []*types.T{d.columns[0].GetType()}
+	keyVals := make([]rowenc.EncDatum, 1)
+	if _, _, err := rowenc.DecodeIndexKey(d.codec, keyTypes, keyVals, nil, kv.Key); err != nil {
+		return 0, Notification{}, false, errors.Wrap(err, "failed to decode key")
+	}
+	if err := keyVals[0].EnsureDecoded(keyTypes[0], &d.alloc); err != nil {
+		return 0, Notification{}, false, err
+	}
+	id = int64(tree.MustBeDInt(keyVals[0].Datum))
+	if !kv.Value.IsPresent() {
+		return id, Notification{}, true, nil
+	}
+
+	// The rest of the columns are stored as a single family.
+	bytes, err := kv.Value.GetTuple()
+	if err != nil {
+		return 0, Notification{}, false, err
+	}
+	datums, err := d.decoder.Decode(&d.alloc, bytes)
+	if err != nil {
+		return 0, Notification{}, false, err
+	}
+	var n Notification
+	if channel := datums[1]; channel != tree.DNull {
+		n.Channel = string(tree.MustBeDString(channel))
+	}
+	if payload := datums[2]; payload != tree.DNull {
+		n.Payload = string(tree.MustBeDString(payload))
+	}
+	if pid := datums[3]; pid != tree.DNull {
+		n.PID = int32(tree.MustBeDInt(pid))
+	}
+	return id, n, false, nil
+}
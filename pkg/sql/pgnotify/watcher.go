@@ -0,0 +1,307 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package pgnotify implements the cluster-wide notification channels used by
+// LISTEN, NOTIFY and pg_notify.
+//
+// NOTIFY inserts a row into system.notifications in the notifying
+// transaction, so notifications only become visible once that transaction
+// commits. Each SQL server watches the table with a rangefeed and hands new
+// rows to the Listeners registered for the row's channel. Old rows are removed
+// by a periodic cleanup task (see StartCleanup).
+package pgnotify
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed/rangefeedbuffer"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed/rangefeedcache"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/logtags"
+)
+
+// maxQueuedNotifications bounds the number of notifications that are queued
+// for a single Listener which has not yet consumed them. Notifications beyond
+// this limit are dropped.
+const maxQueuedNotifications = 10000
+
+// bufferSize bounds the number of notifications buffered by the rangefeed
+// between two frontier advances. If the limit is exceeded, the rangefeed is
+// restarted and the rows which were not yet delivered are picked up by the
+// initial scan.
+const bufferSize = 1 << 16
+
+// Watcher watches the system.notifications table and delivers new
+// notifications to the Listeners registered on this server.
+//
+// The rangefeed is only started once the first Listener starts listening,
+// so servers which never run LISTEN do not pay for it.
+type Watcher struct {
+	clock   *hlc.Clock
+	codec   keys.SQLCodec
+	f       *rangefeed.Factory
+	stopper *stop.Stopper
+
+	// startMu serializes calls to Start.
+	startMu struct {
+		syncutil.Mutex
+		started bool
+	}
+
+	mu struct {
+		syncutil.Mutex
+		listeners map[*Listener]struct{}
+		// frontier is the timestamp up to which notifications have been
+		// delivered. Rows at or below the frontier are ignored, which prevents
+		// duplicate deliveries when the rangefeed is restarted and rescans the
+		// table. It is read by translateEvent, which runs on the rangefeed's
+		// goroutine, and advanced by onUpdate, which runs on the goroutine of
+		// the rangefeedcache.Watcher.
+		frontier hlc.Timestamp
+	}
+
+	// dec is only accessed from translateEvent.
+	dec RowDecoder
+}
+
+// NewWatcher constructs a new Watcher.
+func NewWatcher(
+	clock *hlc.Clock, codec keys.SQLCodec, f *rangefeed.Factory, stopper *stop.Stopper,
+) *Watcher {
+	w := &Watcher{
+		clock:   clock,
+		codec:   codec,
+		f:       f,
+		stopper: stopper,
+		dec:     MakeRowDecoder(codec),
+	}
+	w.mu.listeners = make(map[*Listener]struct{})
+	return w
+}
+
+// Start starts the rangefeed over the notifications table, unless it was
+// already started. Only notifications committed after the first call to
+// Start are delivered.
+func (w *Watcher) Start(ctx context.Context, sysTableResolver catalog.SystemTableIDResolver) error {
+	w.startMu.Lock()
+	defer w.startMu.Unlock()
+	if w.startMu.started {
+		return nil
+	}
+	tableID, err := sysTableResolver.LookupSystemTableID(ctx, systemschema.NotificationsTable.GetName())
+	if err != nil {
+		return err
+	}
+	tablePrefix := w.codec.TablePrefix(uint32(tableID))
+	tableSpan := roachpb.Span{
+		Key:    tablePrefix,
+		EndKey: tablePrefix.PrefixEnd(),
+	}
+	w.mu.Lock()
+	w.mu.frontier = w.clock.Now()
+	w.mu.Unlock()
+
+	c := rangefeedcache.NewWatcher(
+		"notifications-watcher",
+		w.clock, w.f,
+		bufferSize,
+		[]roachpb.Span{tableSpan},
+		false, /* withPrevValue */
+		w.translateEvent,
+		w.onUpdate,
+		nil, /* knobs */
+	)
+	// Start the rangefeedcache, which will retry until the stopper stops. The
+	// rangefeed outlives the session that started it, so it must not use the
+	// session's context.
+	bgCtx := logtags.WithTags(context.Background(), logtags.FromContext(ctx))
+	if err := rangefeedcache.Start(bgCtx, w.stopper, c, nil /* onError */); err != nil {
+		return err // we're shutting down
+	}
+	w.startMu.started = true
+	return nil
+}
+
+// event is the rangefeedbuffer.Event buffered for each new notification.
+type event struct {
+	ts hlc.Timestamp
+	id int64
+	n  Notification
+}
+
+// Timestamp implements the rangefeedbuffer.Event interface.
+func (e *event) Timestamp() hlc.Timestamp {
+	return e.ts
+}
+
+func (w *Watcher) translateEvent(
+	ctx context.Context, kv *roachpb.RangeFeedValue,
+) rangefeedbuffer.Event {
+	// Rows are only ever inserted by NOTIFY; deletions come from the cleanup
+	// task and are of no interest.
+	if !kv.Value.IsPresent() || kv.Value.Timestamp.LessEq(w.getFrontier()) {
+		return nil
+	}
+	id, n, _, err := w.dec.DecodeRow(roachpb.KeyValue{
+		Key:   kv.Key,
+		Value: kv.Value,
+	})
+	if err != nil {
+		log.Warningf(ctx, "failed to decode notifications row %v: %v", kv.Key, err)
+		return nil
+	}
+	return &event{ts: kv.Value.Timestamp, id: id, n: n}
+}
+
+// getFrontier returns the timestamp up to which notifications have been
+// delivered.
+func (w *Watcher) getFrontier() hlc.Timestamp {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.mu.frontier
+}
+
+func (w *Watcher) onUpdate(ctx context.Context, update rangefeedcache.Update) {
+	events := update.Events
+	// The events are sorted by timestamp; order the notifications sent by the
+	// same transaction by their (increasing) row IDs.
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i].(*event), events[j].(*event)
+		if a.ts != b.ts {
+			return a.ts.Less(b.ts)
+		}
+		return a.id < b.id
+	})
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.mu.frontier.Forward(update.Timestamp)
+	for _, ev := range events {
+		n := ev.(*event).n
+		for l := range w.mu.listeners {
+			l.deliver(ctx, n)
+		}
+	}
+}
+
+// NewListener creates a new Listener which is not yet listening on any
+// channel. onNotify is called whenever notifications become available to
+// Take after all queued notifications were taken. It must not block.
+//
+// The Listener must be closed once it is no longer used.
+func (w *Watcher) NewListener(onNotify func()) *Listener {
+	l := &Listener{w: w, onNotify: onNotify}
+	l.mu.channels = make(map[string]struct{})
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.mu.listeners[l] = struct{}{}
+	return l
+}
+
+// Listener receives the notifications sent on the channels it listens on.
+type Listener struct {
+	w        *Watcher
+	onNotify func()
+
+	mu struct {
+		syncutil.Mutex
+		channels map[string]struct{}
+		queue    []Notification
+		// signaled is set once onNotify was called, and reset by Take.
+		signaled bool
+	}
+}
+
+var droppedNotificationsLogEvery = log.Every(time.Minute)
+
+// deliver queues the notification if the Listener is listening on its
+// channel.
+func (l *Listener) deliver(ctx context.Context, n Notification) {
+	l.mu.Lock()
+	if _, ok := l.mu.channels[n.Channel]; !ok {
+		l.mu.Unlock()
+		return
+	}
+	if len(l.mu.queue) >= maxQueuedNotifications {
+		l.mu.Unlock()
+		if droppedNotificationsLogEvery.ShouldLog() {
+			log.Warningf(ctx, "notification queue full, dropping notification on channel %q", n.Channel)
+		}
+		return
+	}
+	l.mu.queue = append(l.mu.queue, n)
+	signal := !l.mu.signaled
+	l.mu.signaled = true
+	l.mu.Unlock()
+	if signal {
+		l.onNotify()
+	}
+}
+
+// Listen starts listening on the given channel.
+func (l *Listener) Listen(channel string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.mu.channels[channel] = struct{}{}
+}
+
+// Unlisten stops listening on the given channel.
+func (l *Listener) Unlisten(channel string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.mu.channels, channel)
+}
+
+// UnlistenAll stops listening on all channels.
+func (l *Listener) UnlistenAll() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.mu.channels = make(map[string]struct{})
+}
+
+// Channels returns the sorted names of the channels the Listener listens on.
+func (l *Listener) Channels() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	channels := make([]string, 0, len(l.mu.channels))
+	for c := range l.mu.channels {
+		channels = append(channels, c)
+	}
+	sort.Strings(channels)
+	return channels
+}
+
+// Take returns and removes the queued notifications, in the order in which
+// they were committed.
+func (l *Listener) Take() []Notification {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	queue := l.mu.queue
+	l.mu.queue = nil
+	l.mu.signaled = false
+	return queue
+}
+
+// Close unregisters the Listener from its Watcher.
+func (l *Listener) Close() {
+	l.w.mu.Lock()
+	defer l.w.mu.Unlock()
+	delete(l.w.mu.listeners, l)
+}
@@ -0,0 +1,137 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgnotify
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed/rangefeedbuffer"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed/rangefeedcache"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListener(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+
+	w := NewWatcher(nil /* clock */, keys.SystemSQLCodec, nil /* f */, nil /* stopper */)
+	w.mu.frontier = hlc.Timestamp{WallTime: 10}
+
+	var signals int
+	l := w.NewListener(func() { signals++ })
+	defer l.Close()
+	l.Listen("b")
+	l.Listen("a")
+	require.Equal(t, []string{"a", "b"}, l.Channels())
+
+	update := func(wallTime int64, evs ...*event) {
+		var events []rangefeedbuffer.Event
+		for _, ev := range evs {
+			events = append(events, ev)
+		}
+		w.onUpdate(ctx, rangefeedcache.Update{
+			Type:      rangefeedcache.IncrementalUpdate,
+			Timestamp: hlc.Timestamp{WallTime: wallTime},
+			Events:    events,
+		})
+	}
+	ts := func(wallTime int64) hlc.Timestamp { return hlc.Timestamp{WallTime: wallTime} }
+
+	// Notifications are ordered by timestamp, then by row ID, and only
+	// delivered for the channels the listener listens on.
+	update(20,
+		&event{ts: ts(15), id: 2, n: Notification{Channel: "a", Payload: "2", PID: 1}},
+		&event{ts: ts(15), id: 1, n: Notification{Channel: "b", Payload: "1", PID: 1}},
+		&event{ts: ts(12), id: 5, n: Notification{Channel: "a", Payload: "0", PID: 2}},
+		&event{ts: ts(16), id: 6, n: Notification{Channel: "c", Payload: "3", PID: 2}},
+	)
+	require.Equal(t, 1, signals)
+	require.Equal(t, []Notification{
+		{Channel: "a", Payload: "0", PID: 2},
+		{Channel: "b", Payload: "1", PID: 1},
+		{Channel: "a", Payload: "2", PID: 1},
+	}, l.Take())
+	require.Equal(t, ts(20), w.getFrontier())
+
+	// The listener is signaled again once the queue was drained.
+	l.Unlisten("a")
+	update(30,
+		&event{ts: ts(25), id: 7, n: Notification{Channel: "a", Payload: "4"}},
+		&event{ts: ts(25), id: 8, n: Notification{Channel: "b", Payload: "5"}},
+	)
+	update(40, &event{ts: ts(35), id: 9, n: Notification{Channel: "b", Payload: "6"}})
+	require.Equal(t, 2, signals)
+	require.Equal(t, []Notification{
+		{Channel: "b", Payload: "5"},
+		{Channel: "b", Payload: "6"},
+	}, l.Take())
+	require.Nil(t, l.Take())
+
+	l.UnlistenAll()
+	require.Empty(t, l.Channels())
+	update(50, &event{ts: ts(45), id: 10, n: Notification{Channel: "b", Payload: "7"}})
+	require.Equal(t, 2, signals)
+	require.Nil(t, l.Take())
+}
+
+// TestWatcherConcurrentFrontier checks that the frontier can be advanced by
+// onUpdate while translateEvent reads it. It is meant to be run with -race.
+func TestWatcherConcurrentFrontier(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+
+	w := NewWatcher(nil /* clock */, keys.SystemSQLCodec, nil /* f */, nil /* stopper */)
+	w.mu.frontier = hlc.Timestamp{WallTime: 10}
+
+	const numUpdates = 1000
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= numUpdates; i++ {
+			w.onUpdate(ctx, rangefeedcache.Update{
+				Type:      rangefeedcache.IncrementalUpdate,
+				Timestamp: hlc.Timestamp{WallTime: int64(10 + i)},
+			})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		// Rows below the frontier are ignored without being decoded.
+		kv := &roachpb.RangeFeedValue{Value: roachpb.MakeValueFromString("foo")}
+		kv.Value.Timestamp = hlc.Timestamp{WallTime: 5}
+		for i := 0; i < numUpdates; i++ {
+			if ev := w.translateEvent(ctx, kv); ev != nil {
+				t.Errorf("unexpected event %v", ev)
+				return
+			}
+		}
+	}()
+	wg.Wait()
+	require.Equal(t, hlc.Timestamp{WallTime: 10 + numUpdates}, w.getFrontier())
+}
+
+func TestValidate(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	require.NoError(t, ValidateChannel("foo"))
+	require.EqualError(t, ValidateChannel(""), "channel name cannot be empty")
+	require.EqualError(t, ValidateChannel(string(make([]byte, MaxChannelLength+1))), "channel name too long")
+	require.NoError(t, ValidatePayload(""))
+	require.NoError(t, ValidatePayload(string(make([]byte, MaxPayloadLength-1))))
+	require.EqualError(t, ValidatePayload(string(make([]byte, MaxPayloadLength))), "payload string too long")
+}
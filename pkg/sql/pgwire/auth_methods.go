@@ -213,6 +213,26 @@ func passwordAuthenticator(
 	return err
 }
 
+// GetCleartextPassword requests a cleartext password from the client and
+// waits for its response. It is used by authentication methods that verify
+// the password with an external service, such as LDAP.
+func GetCleartextPassword(ctx context.Context, c AuthConn) (string, error) {
+	if err := c.SendAuthRequest(authCleartextPassword, nil /* data */); err != nil {
+		return "", err
+	}
+	pwdData, err := c.GetPwdData()
+	if err != nil {
+		c.LogAuthFailed(ctx, eventpb.AuthFailReason_PRE_HOOK_ERROR, err)
+		return "", err
+	}
+	password, err := passwordString(pwdData)
+	if err != nil {
+		c.LogAuthFailed(ctx, eventpb.AuthFailReason_PRE_HOOK_ERROR, err)
+		return "", err
+	}
+	return password, nil
+}
+
 func passwordString(pwdData []byte) (string, error) {
 	// Make a string out of the byte array.
 	if bytes.IndexByte(pwdData, 0) != len(pwdData)-1 {
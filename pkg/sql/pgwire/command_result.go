@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -59,6 +60,7 @@ type commandResult struct {
 	buffer struct {
 		notices            []pgnotice.Notice
 		paramStatusUpdates []paramStatusUpdate
+		notifications      []pgnotify.Notification
 	}
 
 	err error
@@ -91,6 +93,10 @@ type commandResult struct {
 	// statements.
 	bufferingDisabled bool
 
+	// copyOut is set for COPY ... TO STDOUT statements, whose results are sent
+	// using the Copy-out subprotocol.
+	copyOut *copyOutEncoder
+
 	// released is set when the command result has been released so that its
 	// memory can be reused. It is also used to assert against use-after-free
 	// errors.
@@ -133,6 +139,12 @@ func (r *commandResult) Close(ctx context.Context, t sql.TransactionStatusIndica
 		}
 	}
 
+	for _, n := range r.buffer.notifications {
+		if err := r.conn.bufferNotification(n); err != nil {
+			panic(errors.NewAssertionErrorWithWrappedErrf(err, "unexpected err when sending notification"))
+		}
+	}
+
 	for _, paramStatusUpdate := range r.buffer.paramStatusUpdates {
 		if err := r.conn.bufferParamStatus(
 			paramStatusUpdate.param,
@@ -147,6 +159,9 @@ func (r *commandResult) Close(ctx context.Context, t sql.TransactionStatusIndica
 	// Send a completion message, specific to the type of result.
 	switch r.typ {
 	case commandComplete:
+		if r.copyOut != nil && r.copyOut.started {
+			r.conn.bufferCopyDone(r.copyOut)
+		}
 		tag := cookTag(
 			r.cmdCompleteTag, r.conn.writerState.tagBuf[:0], r.stmtType, r.rowsAffected,
 		)
@@ -224,6 +239,10 @@ func (r *commandResult) addInternal(bufferData func()) error {
 func (r *commandResult) AddRow(ctx context.Context, row tree.Datums) error {
 	return r.addInternal(func() {
 		r.rowsAffected++
+		if r.copyOut != nil {
+			r.conn.bufferCopyData(ctx, r.copyOut, row, r.conv, r.location, r.types)
+			return
+		}
 		r.conn.bufferRow(ctx, row, r.formatCodes, r.conv, r.location, r.types)
 	})
 }
@@ -238,7 +257,7 @@ func (r *commandResult) AddBatch(ctx context.Context, batch coldata.Batch) error
 
 // SupportsAddBatch is part of the sql.RestrictedCommandResult interface.
 func (r *commandResult) SupportsAddBatch() bool {
-	return true
+	return r.copyOut == nil
 }
 
 // DisableBuffering is part of the sql.RestrictedCommandResult interface.
@@ -260,11 +279,18 @@ func (r *commandResult) BufferNotice(notice pgnotice.Notice) {
 	r.buffer.notices = append(r.buffer.notices, notice)
 }
 
+// BufferNotification is part of the sql.NotificationSender interface.
+func (r *commandResult) BufferNotification(n pgnotify.Notification) {
+	r.buffer.notifications = append(r.buffer.notifications, n)
+}
+
 // SetColumns is part of the sql.RestrictedCommandResult interface.
 func (r *commandResult) SetColumns(ctx context.Context, cols colinfo.ResultColumns) {
 	r.assertNotReleased()
 	r.conn.writerState.fi.registerCmd(r.pos)
-	if r.descOpt == sql.NeedRowDesc {
+	if r.copyOut != nil {
+		r.conn.bufferCopyOutResponse(r.copyOut, cols)
+	} else if r.descOpt == sql.NeedRowDesc {
 		_ /* err */ = r.conn.writeRowDescription(ctx, cols, r.formatCodes, &r.conn.writerState.buf)
 	}
 	r.types = make([]*types.T, len(cols))
@@ -373,6 +399,9 @@ func (c *conn) newCommandResult(
 		descOpt:        descOpt,
 		formatCodes:    formatCodes,
 	}
+	if copyTo, ok := stmt.(*tree.CopyTo); ok {
+		r.copyOut = newCopyOutEncoder(copyTo)
+	}
 	if limit == 0 {
 		return r
 	}
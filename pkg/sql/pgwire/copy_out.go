@@ -0,0 +1,226 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// copyOutSignature is the header of the binary COPY format, followed by the
+// flags field and the length of the header extension area.
+var copyOutSignature = []byte("PGCOPY\n\377\r\n\000\000\000\000\000\000\000\000\000")
+
+// copyOutEncoder encodes the results of a COPY ... TO STDOUT statement for the
+// Copy-out subprotocol. Instead of a RowDescription and DataRow messages, the
+// client receives a CopyOutResponse message, followed by a CopyData message
+// for every row and a CopyDone message.
+//
+// The rows are produced by the execution of the statement like those of any
+// other query, so the messages are subject to the same flushing policy: the
+// execution blocks while the client doesn't consume the data fast enough, and
+// statement timeouts and cancellation apply as usual.
+type copyOutEncoder struct {
+	settings tree.CopyOutSettings
+	// started is set once the CopyOutResponse message has been buffered.
+	started bool
+	// scratch is used to produce the text representation of values.
+	scratch writeBuffer
+}
+
+// newCopyOutEncoder returns an encoder for the results of the given COPY TO
+// statement, or nil if its options are invalid. Invalid options are reported
+// when the statement is planned, so no row is ever sent in that case.
+func newCopyOutEncoder(stmt *tree.CopyTo) *copyOutEncoder {
+	settings, err := tree.MakeCopyOutSettings(&stmt.Options)
+	if err != nil {
+		return nil
+	}
+	e := &copyOutEncoder{settings: settings}
+	e.scratch.init(nil /* bytecount */)
+	return e
+}
+
+// formatCode returns the format code of the columns.
+func (e *copyOutEncoder) formatCode() pgwirebase.FormatCode {
+	if e.settings.Format == tree.CopyFormatBinary {
+		return pgwirebase.FormatBinary
+	}
+	return pgwirebase.FormatText
+}
+
+// bufferCopyOutResponse buffers the CopyOutResponse message starting the
+// Copy-out subprotocol, followed by the header of the data, if any.
+func (c *conn) bufferCopyOutResponse(e *copyOutEncoder, cols colinfo.ResultColumns) {
+	e.started = true
+	format := e.formatCode()
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyOutResponse)
+	c.msgBuilder.writeByte(byte(format))
+	c.msgBuilder.putInt16(int16(len(cols)))
+	for range cols {
+		c.msgBuilder.putInt16(int16(format))
+	}
+	if err := c.msgBuilder.finishMsg(&c.writerState.buf); err != nil {
+		panic(errors.NewAssertionErrorWithWrappedErrf(err, "unexpected err from buffer"))
+	}
+
+	switch {
+	case e.settings.Format == tree.CopyFormatBinary:
+		c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyData)
+		c.msgBuilder.write(copyOutSignature)
+	case e.settings.Header:
+		c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyData)
+		for i := range cols {
+			if i > 0 {
+				c.msgBuilder.writeByte(e.settings.Delimiter)
+			}
+			e.writeCSVValue(&c.msgBuilder, []byte(cols[i].Name))
+		}
+		c.msgBuilder.writeByte('\n')
+	default:
+		return
+	}
+	if err := c.msgBuilder.finishMsg(&c.writerState.buf); err != nil {
+		panic(errors.NewAssertionErrorWithWrappedErrf(err, "unexpected err from buffer"))
+	}
+}
+
+// bufferCopyData buffers a CopyData message containing the encoding of row.
+func (c *conn) bufferCopyData(
+	ctx context.Context,
+	e *copyOutEncoder,
+	row tree.Datums,
+	conv sessiondatapb.DataConversionConfig,
+	sessionLoc *time.Location,
+	types []*types.T,
+) {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyData)
+	if e.settings.Format == tree.CopyFormatBinary {
+		// The binary format of a value is the same as in a DataRow message.
+		c.msgBuilder.putInt16(int16(len(row)))
+		for i, d := range row {
+			c.msgBuilder.writeBinaryDatum(ctx, d, sessionLoc, types[i])
+		}
+	} else {
+		for i, d := range row {
+			if i > 0 {
+				c.msgBuilder.writeByte(e.settings.Delimiter)
+			}
+			if d == tree.DNull {
+				c.msgBuilder.writeString(e.settings.Null)
+				continue
+			}
+			// Produce the text representation of the value, and strip the length
+			// prefix written by writeTextDatumNotNull.
+			e.scratch.reset()
+			writeTextDatumNotNull(&e.scratch, d, conv, sessionLoc, types[i])
+			if e.scratch.err != nil {
+				c.msgBuilder.setError(e.scratch.err)
+				break
+			}
+			v := e.scratch.wrapped.Bytes()[4:]
+			if e.settings.Format == tree.CopyFormatCSV {
+				e.writeCSVValue(&c.msgBuilder, v)
+			} else {
+				e.writeTextValue(&c.msgBuilder, v)
+			}
+		}
+		c.msgBuilder.writeByte('\n')
+	}
+	if err := c.msgBuilder.finishMsg(&c.writerState.buf); err != nil {
+		panic(errors.NewAssertionErrorWithWrappedErrf(err, "unexpected err from buffer"))
+	}
+}
+
+// bufferCopyDone buffers the trailer of the data, if any, followed by the
+// CopyDone message ending the Copy-out subprotocol.
+func (c *conn) bufferCopyDone(e *copyOutEncoder) {
+	if e.settings.Format == tree.CopyFormatBinary {
+		c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyData)
+		c.msgBuilder.putInt16(-1)
+		if err := c.msgBuilder.finishMsg(&c.writerState.buf); err != nil {
+			panic(errors.NewAssertionErrorWithWrappedErrf(err, "unexpected err from buffer"))
+		}
+	}
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyDone)
+	if err := c.msgBuilder.finishMsg(&c.writerState.buf); err != nil {
+		panic(errors.NewAssertionErrorWithWrappedErrf(err, "unexpected err from buffer"))
+	}
+}
+
+// writeTextValue writes a value in the text format, escaping the backslashes,
+// the delimiter and the control characters that would otherwise be confused
+// with the separators of the format.
+func (e *copyOutEncoder) writeTextValue(b *writeBuffer, v []byte) {
+	start := 0
+	for i, c := range v {
+		var escaped byte
+		switch c {
+		case '\b':
+			escaped = 'b'
+		case '\f':
+			escaped = 'f'
+		case '\n':
+			escaped = 'n'
+		case '\r':
+			escaped = 'r'
+		case '\t':
+			escaped = 't'
+		case '\v':
+			escaped = 'v'
+		case '\\':
+			escaped = '\\'
+		default:
+			if c != e.settings.Delimiter {
+				continue
+			}
+			escaped = c
+		}
+		b.write(v[start:i])
+		b.writeByte('\\')
+		b.writeByte(escaped)
+		start = i + 1
+	}
+	b.write(v[start:])
+}
+
+// writeCSVValue writes a value in the CSV format. The value is quoted if it
+// contains the delimiter, the quote character or a line break, or if it could
+// be confused with NULL.
+func (e *copyOutEncoder) writeCSVValue(b *writeBuffer, v []byte) {
+	quote := string(v) == e.settings.Null ||
+		bytes.IndexByte(v, e.settings.Delimiter) >= 0 ||
+		bytes.IndexByte(v, e.settings.Quote) >= 0 ||
+		bytes.IndexAny(v, "\r\n") >= 0
+	if !quote {
+		b.write(v)
+		return
+	}
+	b.writeByte(e.settings.Quote)
+	start := 0
+	for i, c := range v {
+		if c == e.settings.Quote || c == e.settings.Escape {
+			b.write(v[start:i])
+			b.writeByte(e.settings.Escape)
+			start = i
+		}
+	}
+	b.write(v[start:])
+	b.writeByte(e.settings.Quote)
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uint128"
 	"github.com/cockroachdb/errors"
 	"github.com/dustin/go-humanize"
@@ -511,6 +512,28 @@ func DecodeDatum(
 			}
 			return tree.NewDString(string(b)), nil
 		}
+		if t.Family() == types.RangeFamily {
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			d, _, err := tree.ParseDRangeFromString(evalCtx, string(b), t)
+			if err != nil {
+				return nil, err
+			}
+			return d, nil
+		}
+		switch t.Family() {
+		case types.TSVectorFamily:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDTSVector(string(b))
+		case types.TSQueryFamily:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDTSQuery(string(b))
+		}
 	case FormatBinary:
 		switch id {
 		case oid.T_record:
@@ -788,6 +811,23 @@ func DecodeDatum(
 			if t.Family() == types.ArrayFamily {
 				return decodeBinaryArray(evalCtx, t.ArrayContents(), b, code)
 			}
+			if t.Family() == types.RangeFamily {
+				return decodeBinaryRange(evalCtx, t, b)
+			}
+			switch t.Family() {
+			case types.TSVectorFamily:
+				v, err := tsearch.DecodeTSVector(b)
+				if err != nil {
+					return nil, NewInvalidBinaryRepresentationErrorf("%v", err)
+				}
+				return tree.NewDTSVector(v), nil
+			case types.TSQueryFamily:
+				q, err := tsearch.DecodeTSQuery(b)
+				if err != nil {
+					return nil, NewInvalidBinaryRepresentationErrorf("%v", err)
+				}
+				return tree.NewDTSQuery(q), nil
+			}
 		}
 	default:
 		return nil, errors.AssertionFailedf(
@@ -992,6 +1032,50 @@ func decodeBinaryArray(
 
 const tupleHeaderSize, oidSize, elementSize = 4, 4, 4
 
+// decodeBinaryRange decodes a range of the given type from its pgwire binary
+// format, which is a flags byte followed by the length-prefixed binary
+// encodings of the bounds that are present.
+func decodeBinaryRange(evalCtx *tree.EvalContext, t *types.T, b []byte) (tree.Datum, error) {
+	if len(b) < 1 {
+		return nil, NewProtocolViolationErrorf("no data to decode")
+	}
+	flags := b[0]
+	b = b[1:]
+	if flags&PGBinaryRangeEmpty != 0 {
+		return tree.NewDEmptyRange(t), nil
+	}
+	decodeBound := func(inf bool) (tree.Datum, error) {
+		if inf {
+			return tree.DNull, nil
+		}
+		if len(b) < 4 {
+			return nil, NewProtocolViolationErrorf("insufficient data: %d", len(b))
+		}
+		n := int32(binary.BigEndian.Uint32(b))
+		b = b[4:]
+		if n < 0 || len(b) < int(n) {
+			return nil, NewInvalidBinaryRepresentationErrorf("invalid range bound length %d", n)
+		}
+		d, err := DecodeDatum(evalCtx, t.RangeContents(), FormatBinary, b[:n])
+		b = b[n:]
+		return d, err
+	}
+	lower, err := decodeBound(flags&PGBinaryRangeLowerInf != 0)
+	if err != nil {
+		return nil, err
+	}
+	upper, err := decodeBound(flags&PGBinaryRangeUpperInf != 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != 0 {
+		return nil, NewInvalidBinaryRepresentationErrorf("unexpected %d trailing bytes", len(b))
+	}
+	return tree.NewDRange(
+		t, lower, upper, flags&PGBinaryRangeLowerInc != 0, flags&PGBinaryRangeUpperInc != 0,
+	)
+}
+
 func decodeBinaryTuple(evalCtx *tree.EvalContext, b []byte) (tree.Datum, error) {
 
 	bufferLength := len(b)
@@ -1098,3 +1182,17 @@ const (
 	// AF_NET + 1.
 	PGBinaryIPv6family byte = 3
 )
+
+// Flags of the pgwire binary format of ranges.
+const (
+	// PGBinaryRangeEmpty is set if the range is empty.
+	PGBinaryRangeEmpty byte = 0x01
+	// PGBinaryRangeLowerInc is set if the lower bound is inclusive.
+	PGBinaryRangeLowerInc byte = 0x02
+	// PGBinaryRangeUpperInc is set if the upper bound is inclusive.
+	PGBinaryRangeUpperInc byte = 0x04
+	// PGBinaryRangeLowerInf is set if the range has no lower bound.
+	PGBinaryRangeLowerInf byte = 0x08
+	// PGBinaryRangeUpperInf is set if the range has no upper bound.
+	PGBinaryRangeUpperInf byte = 0x10
+)
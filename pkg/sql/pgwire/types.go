@@ -36,6 +36,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
@@ -242,10 +243,21 @@ func writeTextDatumNotNull(
 	case *tree.DJSON:
 		b.writeLengthPrefixedString(v.JSON.String())
 
+	case *tree.DTSQuery:
+		b.writeLengthPrefixedString(v.TSQuery.String())
+
+	case *tree.DTSVector:
+		b.writeLengthPrefixedString(v.TSVector.String())
+
 	case *tree.DTuple:
 		b.textFormatter.FormatNode(v)
 		b.writeFromFmtCtx(b.textFormatter)
 
+	case *tree.DRange:
+		// TIMESTAMPTZ bounds are formatted in the session's time zone.
+		b.textFormatter.FormatNode(v.InLocation(sessionLoc))
+		b.writeFromFmtCtx(b.textFormatter)
+
 	case *tree.DArray:
 		// Arrays have custom formatting depending on their OID.
 		b.textFormatter.FormatNode(d)
@@ -687,6 +699,39 @@ func writeBinaryDatumNotNull(
 		lengthToWrite := b.Len() - (initialLen + 4)
 		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
 
+	case *tree.DRange:
+		initialLen := b.Len()
+
+		// Reserve bytes for writing length later.
+		b.putInt32(int32(0))
+
+		var flags byte
+		if v.Empty {
+			flags |= pgwirebase.PGBinaryRangeEmpty
+		} else {
+			if v.LowerInc {
+				flags |= pgwirebase.PGBinaryRangeLowerInc
+			}
+			if v.UpperInc {
+				flags |= pgwirebase.PGBinaryRangeUpperInc
+			}
+			if v.Lower == tree.DNull {
+				flags |= pgwirebase.PGBinaryRangeLowerInf
+			}
+			if v.Upper == tree.DNull {
+				flags |= pgwirebase.PGBinaryRangeUpperInf
+			}
+		}
+		b.writeByte(flags)
+		for _, bound := range []tree.Datum{v.Lower, v.Upper} {
+			if bound != tree.DNull {
+				b.writeBinaryDatum(ctx, bound, sessionLoc, v.Typ.RangeContents())
+			}
+		}
+
+		lengthToWrite := b.Len() - (initialLen + 4)
+		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
+
 	case *tree.DVoid:
 		b.putInt32(0)
 
@@ -745,6 +790,16 @@ func writeBinaryDatumNotNull(
 	case *tree.DJSON:
 		writeBinaryJSON(b, v.JSON)
 
+	case *tree.DTSQuery:
+		encoded := tsearch.EncodeTSQuery(nil, v.TSQuery)
+		b.putInt32(int32(len(encoded)))
+		b.write(encoded)
+
+	case *tree.DTSVector:
+		encoded := tsearch.EncodeTSVector(nil, v.TSVector)
+		b.putInt32(int32(len(encoded)))
+		b.write(encoded)
+
 	case *tree.DOid:
 		b.putInt32(4)
 		b.putInt32(int32(v.DInt))
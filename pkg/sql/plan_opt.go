@@ -32,6 +32,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/querycache"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlstats"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
@@ -62,9 +63,9 @@ func (p *planner) prepareUsingOptimizer(ctx context.Context) (planFlags, error)
 		*tree.CommitTransaction,
 		*tree.CopyFrom, *tree.CreateDatabase, *tree.CreateIndex, *tree.CreateView,
 		*tree.CreateSequence,
-		*tree.CreateStats,
+		*tree.CreateStats, *tree.CreateTrigger,
 		*tree.Deallocate, *tree.Discard, *tree.DropDatabase, *tree.DropIndex,
-		*tree.DropTable, *tree.DropView, *tree.DropSequence, *tree.DropType,
+		*tree.DropTable, *tree.DropView, *tree.DropSequence, *tree.DropType, *tree.DropTrigger,
 		*tree.Grant, *tree.GrantRole,
 		*tree.Prepare,
 		*tree.ReleaseSavepoint, *tree.RenameColumn, *tree.RenameDatabase,
@@ -545,9 +546,11 @@ func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ e
 	// find potential index candidates in the memo.
 	_, isExplain := opc.p.stmt.AST.(*tree.Explain)
 	if isExplain && p.SessionData().IndexRecommendationsEnabled {
-		if err := opc.makeQueryIndexRecommendation(); err != nil {
+		indexRecommendations, err := opc.makeQueryIndexRecommendation()
+		if err != nil {
 			return nil, err
 		}
+		opc.p.instrumentation.indexRecommendations = indexRecommendations.Output()
 	}
 
 	if _, isCanned := opc.p.stmt.AST.(*tree.CannedOptPlan); !isCanned {
@@ -680,9 +683,9 @@ func (p *planner) DecodeGist(gist string) ([]string, error) {
 // makeQueryIndexRecommendation builds a statement and walks through it to find
 // potential index candidates. It then optimizes the statement with those
 // indexes hypothetically added to the table. An index recommendation for the
-// query is outputted based on which hypothetical indexes are helpful in the
+// query is returned based on which hypothetical indexes are helpful in the
 // optimal plan.
-func (opc *optPlanningCtx) makeQueryIndexRecommendation() error {
+func (opc *optPlanningCtx) makeQueryIndexRecommendation() (indexrec.IndexRecommendationSet, error) {
 	// Save the normalized memo created by the optbuilder.
 	savedMemo := opc.optimizer.DetachMemo()
 
@@ -701,7 +704,7 @@ func (opc *optPlanningCtx) makeQueryIndexRecommendation() error {
 		return ruleName.IsNormalize()
 	})
 	if _, err := opc.optimizer.Optimize(); err != nil {
-		return err
+		return indexrec.IndexRecommendationSet{}, err
 	}
 
 	// Walk through the fully normalized memo to determine index candidates and
@@ -718,12 +721,12 @@ func (opc *optPlanningCtx) makeQueryIndexRecommendation() error {
 		f.CopyWithoutAssigningPlaceholders,
 	)
 	opc.optimizer.Memo().Metadata().UpdateTableMeta(hypTables)
+	indexrec.AddHypotheticalTableExprs(f)
 	if _, err := opc.optimizer.Optimize(); err != nil {
-		return err
+		return indexrec.IndexRecommendationSet{}, err
 	}
 
 	indexRecommendations := indexrec.FindIndexRecommendationSet(f.Memo().RootExpr(), f.Metadata())
-	opc.p.instrumentation.indexRecommendations = indexRecommendations.Output()
 
 	// Re-initialize the optimizer (which also re-initializes the factory) and
 	// update the saved memo's metadata with the original table information.
@@ -736,5 +739,41 @@ func (opc *optPlanningCtx) makeQueryIndexRecommendation() error {
 		f.CopyWithoutAssigningPlaceholders,
 	)
 
-	return nil
+	return indexRecommendations, nil
+}
+
+// setWorkloadIndexRecommendations generates index recommendations for the
+// current statement if its logical plan is sampled for the statement
+// statistics. The recommendations are generated with a separate optimizer, so
+// that the memo used to build the statement's plan, which may be cached, is not
+// modified. Errors are logged and otherwise ignored, since they should not
+// cause the statement to fail.
+func (p *planner) setWorkloadIndexRecommendations(ctx context.Context) {
+	if !p.instrumentation.savePlanForStats ||
+		!sqlstats.SampleIndexRecommendations.Get(&p.execCfg.Settings.SV) {
+		return
+	}
+	switch p.stmt.AST.(type) {
+	case *tree.ParenSelect, *tree.Select, *tree.SelectClause, *tree.UnionClause,
+		*tree.Insert, *tree.Update, *tree.Delete:
+	default:
+		return
+	}
+
+	var opc optPlanningCtx
+	opc.init(p)
+	opc.reset()
+	f := opc.optimizer.Factory()
+	f.FoldingControl().AllowStableFolds()
+	bld := optbuilder.New(ctx, &p.semaCtx, p.EvalContext(), &opc.catalog, f, p.stmt.AST)
+	if err := bld.Build(); err != nil {
+		log.VEventf(ctx, 1, "could not build statement for index recommendations: %v", err)
+		return
+	}
+	indexRecommendations, err := opc.makeQueryIndexRecommendation()
+	if err != nil {
+		log.VEventf(ctx, 1, "could not generate index recommendations: %v", err)
+		return
+	}
+	p.instrumentation.workloadIndexRecommendations = indexRecommendations.StatsOutput()
 }
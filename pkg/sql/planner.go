@@ -96,6 +96,20 @@ type extendedEvalContext struct {
 	// records when transaction is committed.
 	SchemaChangeJobRecords map[descpb.ID]*jobs.Record
 
+	// DeferredConstraints refers to the deferredConstraints in extraTxnState of
+	// sql.connExecutor. Violations of deferred constraints are recorded there,
+	// and validated when the transaction is committed.
+	DeferredConstraints *deferredConstraints
+
+	// Notifications refers to the notificationState of sql.connExecutor. It is
+	// nil for internal executors.
+	Notifications *notificationState
+
+	// SessionMon is the memory monitor of the session. Unlike Mon, it can be
+	// used for objects which outlive the transaction, like the buffers of
+	// WITH HOLD cursors.
+	SessionMon *mon.BytesMonitor
+
 	statsProvider *persistedsqlstats.PersistedSQLStats
 
 	indexUsageStats *idxusage.LocalIndexUsageStats
@@ -366,6 +380,7 @@ func newInternalPlanner(
 	}
 	p.semaCtx.SearchPath = sd.SearchPath
 	p.semaCtx.TypeResolver = p
+	p.semaCtx.FunctionResolver = p
 	p.semaCtx.DateStyle = sd.GetDateStyle()
 	p.semaCtx.IntervalStyle = sd.GetIntervalStyle()
 
@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uint128"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
@@ -242,6 +243,54 @@ func RandDatumWithNullChance(rng *rand.Rand, typ *types.T, nullChance int) tree.
 			panic(err)
 		}
 		return d
+	case types.RangeFamily:
+		if rng.Intn(10) == 0 {
+			return tree.NewDEmptyRange(typ)
+		}
+		// A NULL bound makes the range unbounded on that side.
+		lower := RandDatumWithNullChance(rng, typ.RangeContents(), 5)
+		upper := RandDatumWithNullChance(rng, typ.RangeContents(), 5)
+		lowerInc, upperInc := rng.Intn(2) == 1, rng.Intn(2) == 1
+		d, err := tree.NewDRange(typ, lower, upper, lowerInc, upperInc)
+		if err != nil {
+			// The lower bound was greater than the upper bound, so swap them.
+			d, err = tree.NewDRange(typ, upper, lower, lowerInc, upperInc)
+			if err != nil {
+				panic(err)
+			}
+		}
+		return d
+	case types.TSVectorFamily:
+		terms := make([]tsearch.Term, rng.Intn(5))
+		for i := range terms {
+			terms[i].Lexeme = randStringSimple(rng)
+			for j := rng.Intn(3); j > 0; j-- {
+				terms[i].Positions = append(terms[i].Positions, tsearch.Position{
+					Pos:    uint16(1 + rng.Intn(tsearch.MaxPosition)),
+					Weight: tsearch.Weight(rng.Intn(4)),
+				})
+			}
+		}
+		return tree.NewDTSVector(tsearch.NewTSVector(terms))
+	case types.TSQueryFamily:
+		var q tsearch.TSQuery
+		for i := rng.Intn(4); i > 0; i-- {
+			operand := tsearch.TSQuery{Root: &tsearch.Node{
+				Lexeme: randStringSimple(rng),
+				Prefix: rng.Intn(5) == 0,
+			}}
+			if rng.Intn(5) == 0 {
+				operand = operand.Not()
+			}
+			if q.Root == nil {
+				q = operand
+			} else if rng.Intn(2) == 0 {
+				q = q.And(operand)
+			} else {
+				q = q.Or(operand)
+			}
+		}
+		return tree.NewDTSQuery(q)
 	case types.VoidFamily:
 		return tree.DVoidDatum
 	default:
@@ -113,6 +113,18 @@ func (p *planner) findColumnToRename(
 			)
 		}
 	}
+	for i := range tableDesc.DependedOnByTriggers {
+		ref := &tableDesc.DependedOnByTriggers[i]
+		if descpb.ColumnIDs(ref.ColumnIDs).Contains(col.GetID()) {
+			return nil, p.dependentTriggerError(ctx, "column", oldName.String(), ref, "rename")
+		}
+	}
+	for i := range tableDesc.DependedOnByFunctions {
+		ref := &tableDesc.DependedOnByFunctions[i]
+		if descpb.ColumnIDs(ref.ColumnIDs).Contains(col.GetID()) {
+			return nil, p.dependentFunctionError(ctx, "column", oldName.String(), ref.FunctionID, "rename")
+		}
+	}
 	if oldName == newName {
 		// Noop.
 		return nil, nil
@@ -88,6 +88,20 @@ func (p *planner) RenameTable(ctx context.Context, n *tree.RenameTable) (planNod
 			)
 		}
 	}
+	// Trigger bodies always reference relations by name.
+	if len(tableDesc.DependedOnByTriggers) > 0 {
+		return nil, p.dependentTriggerError(
+			ctx, string(tableDesc.DescriptorType()), oldTn.String(),
+			&tableDesc.DependedOnByTriggers[0], "rename",
+		)
+	}
+	// So do function bodies.
+	if len(tableDesc.DependedOnByFunctions) > 0 {
+		return nil, p.dependentFunctionError(
+			ctx, string(tableDesc.DescriptorType()), oldTn.String(),
+			tableDesc.DependedOnByFunctions[0].FunctionID, "rename",
+		)
+	}
 
 	return &renameTableNode{n: n, oldTn: &oldTn, newTn: &newTn, tableDesc: tableDesc}, nil
 }
@@ -743,6 +743,13 @@ func (f *DiskBackedIndexedRowContainer) resetIterator() {
 	}
 }
 
+// ResetIterator closes the disk iterator used by GetRow, if any, so that rows
+// added after it was created are visible to the next GetRow. The cache remains
+// valid since rows are only ever appended.
+func (f *DiskBackedIndexedRowContainer) ResetIterator() {
+	f.resetIterator()
+}
+
 // UnsafeReset resets the underlying container (if it is using disk, it will be
 // reset to using memory).
 func (f *DiskBackedIndexedRowContainer) UnsafeReset(ctx context.Context) error {
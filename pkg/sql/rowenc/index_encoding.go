@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/keyside"
@@ -33,6 +34,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/cockroach/pkg/util/trigram"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/unique"
 	"github.com/cockroachdb/errors"
 )
@@ -519,6 +522,9 @@ func EncodeInvertedIndexKeys(
 	if !indexGeoConfig.IsEmpty() {
 		return EncodeGeoInvertedIndexTableKeys(val, keyPrefix, indexGeoConfig)
 	}
+	if index.InvertedColumnKind() == catpb.InvertedIndexColumnKind_TRIGRAM {
+		return EncodeTrigramInvertedIndexTableKeys(val, keyPrefix)
+	}
 	return EncodeInvertedIndexTableKeys(val, keyPrefix, index.GetVersion())
 }
 
@@ -577,6 +583,8 @@ func EncodeInvertedIndexTableKeys(
 		return json.EncodeInvertedIndexKeys(inKey, val.(*tree.DJSON).JSON)
 	case types.ArrayFamily:
 		return encodeArrayInvertedIndexTableKeys(val.(*tree.DArray), inKey, version, false /* excludeNulls */)
+	case types.TSVectorFamily:
+		return encodeTSVectorInvertedIndexTableKeys(val.(*tree.DTSVector), inKey), nil
 	}
 	return nil, errors.AssertionFailedf("trying to apply inverted index to unsupported type %s", datum.ResolvedType())
 }
@@ -670,6 +678,172 @@ func EncodeOverlapsInvertedIndexSpans(
 	}
 }
 
+// EncodeExistsInvertedIndexSpans returns the spans that must be scanned in the
+// inverted index to evaluate an exists (?) predicate with the given datum,
+// which should be a string. These spans should be used to find the JSON values
+// in the index that have the given string as a top-level key or element. In
+// other words, if we have a predicate x ? y, this function should use the
+// value of y to find the spans to scan in an inverted index on x.
+//
+// The spans are returned in an inverted.SpanExpression, which represents the
+// set operations that must be applied on the spans read during execution. See
+// comments in the SpanExpression definition for details.
+func EncodeExistsInvertedIndexSpans(
+	evalCtx *tree.EvalContext, val tree.Datum,
+) (invertedExpr inverted.Expression, err error) {
+	if val == tree.DNull {
+		return nil, nil
+	}
+	datum := tree.UnwrapDatum(evalCtx, val)
+	s, ok := datum.(*tree.DString)
+	if !ok {
+		return nil, errors.AssertionFailedf(
+			"trying to apply inverted index to unsupported type %s", datum.ResolvedType(),
+		)
+	}
+	return json.EncodeExistsInvertedIndexSpans(nil /* inKey */, string(*s))
+}
+
+// EncodeTSMatchesInvertedIndexSpans returns the spans that must be scanned in
+// the inverted index to evaluate a text search match (@@) predicate with the
+// given datum, which should be a tsquery. These spans should be used to find
+// the tsvectors in the index that could match the given tsquery. In other
+// words, if we have a predicate x @@ y, this function should use the value of
+// y to find the spans to scan in an inverted index on x.
+//
+// The spans are returned in an inverted.Expression. If the query can match
+// tsvectors that do not contain any of its lexemes, for example !'cat', the
+// expression is an inverted.NonInvertedColExpression, and the index cannot be
+// used. The expression is not tight if the query has weights, prefixes or
+// phrase operators, since those are not stored in the index.
+func EncodeTSMatchesInvertedIndexSpans(
+	evalCtx *tree.EvalContext, val tree.Datum,
+) (invertedExpr inverted.Expression, err error) {
+	if val == tree.DNull {
+		return nil, nil
+	}
+	datum := tree.UnwrapDatum(evalCtx, val)
+	q, ok := datum.(*tree.DTSQuery)
+	if !ok {
+		return nil, errors.AssertionFailedf(
+			"trying to apply inverted index to unsupported type %s", datum.ResolvedType(),
+		)
+	}
+	if q.Root == nil {
+		// The empty query does not match anything.
+		return &inverted.SpanExpression{Tight: true, Unique: true}, nil
+	}
+	return encodeTSQueryInvertedIndexSpans(q.Root, nil /* inKey */), nil
+}
+
+// encodeTSVectorInvertedIndexTableKeys returns a list of inverted index keys
+// for the given tsvector, one per lexeme. The positions and weights of the
+// lexemes are not stored in the index. The input inKey is prefixed to all
+// returned keys.
+func encodeTSVectorInvertedIndexTableKeys(val *tree.DTSVector, inKey []byte) [][]byte {
+	outKeys := make([][]byte, 0, len(val.TSVector))
+	for _, t := range val.TSVector {
+		outKey := make([]byte, len(inKey), len(inKey)+len(t.Lexeme)+3)
+		copy(outKey, inKey)
+		outKeys = append(outKeys, encoding.EncodeStringAscending(outKey, t.Lexeme))
+	}
+	return outKeys
+}
+
+// encodeTSQueryInvertedIndexSpans returns the inverted expression for the
+// given node of a tsquery. The input inKey is prefixed to all returned keys.
+func encodeTSQueryInvertedIndexSpans(n *tsearch.Node, inKey []byte) inverted.Expression {
+	switch n.Op {
+	case tsearch.Invalid:
+		key := encoding.EncodeStringAscending(append([]byte(nil), inKey...), n.Lexeme)
+		if n.Prefix {
+			// The keys of all the lexemes that start with the prefix start with
+			// the encoding of the prefix, without its terminator.
+			start := key[:len(key)-2]
+			span := inverted.Span{Start: start, End: inverted.EncVal(roachpb.Key(start).PrefixEnd())}
+			return inverted.ExprForSpan(span, false /* tight */)
+		}
+		// Weights are not stored in the index, so a lexeme with weights is
+		// not tight.
+		spanExpr := inverted.ExprForSpan(inverted.MakeSingleValSpan(key), n.Weights == 0 /* tight */)
+		spanExpr.Unique = true
+		return spanExpr
+	case tsearch.And:
+		return inverted.And(
+			encodeTSQueryInvertedIndexSpans(n.Left, inKey), encodeTSQueryInvertedIndexSpans(n.Right, inKey),
+		)
+	case tsearch.FollowedBy:
+		// A phrase requires the same lexemes as the conjunction of its operands,
+		// but their positions must be checked as well.
+		expr := inverted.And(
+			encodeTSQueryInvertedIndexSpans(n.Left, inKey), encodeTSQueryInvertedIndexSpans(n.Right, inKey),
+		)
+		expr.SetNotTight()
+		return expr
+	case tsearch.Or:
+		return inverted.Or(
+			encodeTSQueryInvertedIndexSpans(n.Left, inKey), encodeTSQueryInvertedIndexSpans(n.Right, inKey),
+		)
+	default:
+		// A negation matches the tsvectors that do not contain its operand, which
+		// cannot be found using the index.
+		return inverted.NonInvertedColExpression{}
+	}
+}
+
+// EncodeTrigramInvertedIndexTableKeys produces one inverted index key per
+// trigram in the input datum, which should be a string. Each output key is
+// prefixed by inKey. If the input Datum is (SQL) NULL, no inverted index keys
+// will be produced.
+func EncodeTrigramInvertedIndexTableKeys(val tree.Datum, inKey []byte) (key [][]byte, err error) {
+	if val == tree.DNull {
+		return nil, nil
+	}
+	datum := tree.UnwrapDatum(nil, val)
+	s, ok := datum.(*tree.DString)
+	if !ok {
+		return nil, errors.AssertionFailedf(
+			"trying to apply trigram inverted index to unsupported type %s", datum.ResolvedType(),
+		)
+	}
+	trigrams := trigram.MakeTrigrams(string(*s))
+	outKeys := make([][]byte, 0, len(trigrams))
+	for _, t := range trigrams {
+		outKey := make([]byte, len(inKey), len(inKey)+len(t)+3)
+		copy(outKey, inKey)
+		outKeys = append(outKeys, encoding.EncodeStringAscending(outKey, t))
+	}
+	return outKeys, nil
+}
+
+// EncodeTrigramSpans returns the spans that must be scanned in a trigram
+// inverted index to find the strings that contain the given trigrams. If
+// allMustMatch is true, the strings must contain all of the trigrams,
+// otherwise they must contain at least one of them. The returned expression is
+// never tight, since the strings found must still be checked against the
+// original predicate. If there are no trigrams, the strings cannot be found
+// using the index, and an inverted.NonInvertedColExpression is returned.
+func EncodeTrigramSpans(trigrams []string, allMustMatch bool) inverted.Expression {
+	var ret inverted.Expression
+	for _, t := range trigrams {
+		key := encoding.EncodeStringAscending(nil, t)
+		spanExpr := inverted.ExprForSpan(inverted.MakeSingleValSpan(key), false /* tight */)
+		spanExpr.Unique = true
+		switch {
+		case ret == nil:
+			ret = spanExpr
+		case allMustMatch:
+			ret = inverted.And(ret, spanExpr)
+		default:
+			ret = inverted.Or(ret, spanExpr)
+		}
+	}
+	if ret == nil {
+		return inverted.NonInvertedColExpression{}
+	}
+	return ret
+}
+
 // encodeArrayInvertedIndexTableKeys returns a list of inverted index keys for
 // the given input array, one per entry in the array. The input inKey is
 // prefixed to all returned keys.
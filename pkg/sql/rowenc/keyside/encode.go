@@ -140,16 +140,11 @@ func Encode(b []byte, val tree.Datum, dir encoding.Direction) ([]byte, error) {
 		}
 		return encoding.EncodeBytesDescending(b, data), nil
 	case *tree.DTuple:
-		for _, datum := range t.D {
-			var err error
-			b, err = Encode(b, datum, dir)
-			if err != nil {
-				return nil, err
-			}
-		}
-		return b, nil
+		return encodeTupleKey(b, t, dir)
 	case *tree.DArray:
 		return encodeArrayKey(b, t, dir)
+	case *tree.DRange:
+		return encodeRangeKey(b, t, dir)
 	case *tree.DCollatedString:
 		if dir == encoding.Ascending {
 			return encoding.EncodeBytesAscending(b, t.Key), nil
@@ -102,6 +102,16 @@ func TestEncodeDecode(t *testing.T) {
 		return ""
 	}
 
+	// Also run the property on tuples, which are not column types by
+	// themselves.
+	properties.Property("roundtrip-tuples", prop.ForAll(
+		roundtripDatum,
+		genRandomTupleType().
+			SuchThat(hasKeyEncoding).
+			FlatMap(genDatumWithType, reflect.TypeOf((*tree.Datum)(nil)).Elem()),
+		genEncodingDirection(),
+	))
+
 	properties.Property("order-preserving", prop.ForAll(
 		generateAndCompareDatums,
 		// For each column type, generate two datums of that type.
@@ -148,6 +158,28 @@ func TestEncodeDecode(t *testing.T) {
 		genEncodingDirection(),
 	))
 
+	properties.Property("order-preserving-tuples", prop.ForAll(
+		generateAndCompareDatums,
+		// For each tuple type, generate two datums of that type.
+		genRandomTupleType().
+			SuchThat(hasKeyEncoding).
+			FlatMap(
+				func(t interface{}) gopter.Gen {
+					colTyp := t.(*types.T)
+					return gopter.CombineGens(
+						genDatumWithType(colTyp),
+						genDatumWithType(colTyp))
+				}, reflect.TypeOf([]interface{}{})).
+			Map(func(datums []interface{}) []tree.Datum {
+				ret := make([]tree.Datum, len(datums))
+				for i, d := range datums {
+					ret[i] = d.(tree.Datum)
+				}
+				return ret
+			}),
+		genEncodingDirection(),
+	))
+
 	properties.TestingRun(t)
 }
 
@@ -212,6 +244,13 @@ func genRandomArrayType() gopter.Gen {
 	}
 }
 
+func genRandomTupleType() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		contents := randgen.RandColumnTypes(genParams.Rng, genParams.Rng.Intn(4)+1)
+		return gopter.NewGenResult(types.MakeTuple(contents), gopter.NoShrinker)
+	}
+}
+
 func genDatumWithType(columnType interface{}) gopter.Gen {
 	return func(genParams *gopter.GenParameters) *gopter.GenResult {
 		datum := randgen.RandDatum(genParams.Rng, columnType.(*types.T), false)
@@ -238,11 +277,17 @@ func genEncodingDirection() gopter.Gen {
 func hasKeyEncoding(typ *types.T) bool {
 	// Only some types are round-trip key encodable.
 	switch typ.Family() {
-	case types.JsonFamily, types.CollatedStringFamily, types.TupleFamily, types.DecimalFamily,
+	case types.JsonFamily, types.CollatedStringFamily, types.DecimalFamily,
 		types.GeographyFamily, types.GeometryFamily:
 		return false
 	case types.ArrayFamily:
 		return hasKeyEncoding(typ.ArrayContents())
+	case types.TupleFamily:
+		for _, t := range typ.TupleContents() {
+			if !hasKeyEncoding(t) {
+				return false
+			}
+		}
 	}
 	return true
 }
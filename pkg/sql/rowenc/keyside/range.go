@@ -0,0 +1,146 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package keyside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// Markers used in the key encoding of ranges. They are chosen so that the
+// encoded ranges sort like tree.DRange.Compare: the empty range sorts first,
+// followed by the other ranges ordered by their lower and then their upper
+// bounds. An unbounded lower bound sorts before all values, and an unbounded
+// upper bound sorts after all values.
+const (
+	rangeKeyEmpty    = 0
+	rangeKeyNonEmpty = 1
+
+	rangeKeyLowerUnbounded = 0
+	rangeKeyBounded        = 1
+	rangeKeyUpperUnbounded = 2
+
+	// At equal values, an inclusive lower bound sorts before an exclusive
+	// one, and an exclusive upper bound sorts before an inclusive one.
+	rangeKeyLowerInclusive = 0
+	rangeKeyLowerExclusive = 1
+	rangeKeyUpperExclusive = 0
+	rangeKeyUpperInclusive = 1
+)
+
+// encodeRangeKey generates an ordered key encoding of a range.
+// The encoding format for a non-empty range [a, b) is as follows:
+// [nonEmptyMarker, boundedMarker, enc(a), inclusiveMarker, boundedMarker,
+// enc(b), exclusiveMarker], where the bounds are always encoded in ascending
+// order. The result is then encoded as bytes in the requested direction, so
+// that the range is a single value of the key.
+func encodeRangeKey(b []byte, r *tree.DRange, dir encoding.Direction) ([]byte, error) {
+	var inner []byte
+	if r.Empty {
+		inner = encoding.EncodeVarintAscending(inner, rangeKeyEmpty)
+	} else {
+		inner = encoding.EncodeVarintAscending(inner, rangeKeyNonEmpty)
+		var err error
+		inner, err = encodeRangeKeyBound(
+			inner, r.Lower, r.LowerInc, rangeKeyLowerUnbounded, rangeKeyLowerInclusive, rangeKeyLowerExclusive,
+		)
+		if err != nil {
+			return nil, err
+		}
+		inner, err = encodeRangeKeyBound(
+			inner, r.Upper, r.UpperInc, rangeKeyUpperUnbounded, rangeKeyUpperInclusive, rangeKeyUpperExclusive,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if dir == encoding.Ascending {
+		return encoding.EncodeBytesAscending(b, inner), nil
+	}
+	return encoding.EncodeBytesDescending(b, inner), nil
+}
+
+func encodeRangeKeyBound(
+	b []byte, bound tree.Datum, inclusive bool, unbounded, incMarker, excMarker int64,
+) ([]byte, error) {
+	if bound == tree.DNull {
+		return encoding.EncodeVarintAscending(b, unbounded), nil
+	}
+	b = encoding.EncodeVarintAscending(b, rangeKeyBounded)
+	b, err := Encode(b, bound, encoding.Ascending)
+	if err != nil {
+		return nil, err
+	}
+	if inclusive {
+		return encoding.EncodeVarintAscending(b, incMarker), nil
+	}
+	return encoding.EncodeVarintAscending(b, excMarker), nil
+}
+
+// decodeRangeKey decodes a range key generated by encodeRangeKey.
+func decodeRangeKey(
+	a *tree.DatumAlloc, t *types.T, buf []byte, dir encoding.Direction,
+) (tree.Datum, []byte, error) {
+	var inner []byte
+	var err error
+	if dir == encoding.Ascending {
+		buf, inner, err = encoding.DecodeBytesAscending(buf, nil)
+	} else {
+		buf, inner, err = encoding.DecodeBytesDescending(buf, nil)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	inner, marker, err := encoding.DecodeVarintAscending(inner)
+	if err != nil {
+		return nil, nil, err
+	}
+	if marker == rangeKeyEmpty {
+		return tree.NewDEmptyRange(t), buf, nil
+	}
+	lower, lowerInc, inner, err := decodeRangeKeyBound(a, t, inner, rangeKeyLowerInclusive)
+	if err != nil {
+		return nil, nil, err
+	}
+	upper, upperInc, inner, err := decodeRangeKeyBound(a, t, inner, rangeKeyUpperInclusive)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(inner) != 0 {
+		return nil, nil, errors.AssertionFailedf("invalid range encoding (%d trailing bytes)", len(inner))
+	}
+	return &tree.DRange{
+		Typ: t, Lower: lower, Upper: upper, LowerInc: lowerInc, UpperInc: upperInc,
+	}, buf, nil
+}
+
+func decodeRangeKeyBound(
+	a *tree.DatumAlloc, t *types.T, buf []byte, incMarker int64,
+) (bound tree.Datum, inclusive bool, _ []byte, _ error) {
+	buf, marker, err := encoding.DecodeVarintAscending(buf)
+	if err != nil {
+		return nil, false, nil, err
+	}
+	if marker != rangeKeyBounded {
+		return tree.DNull, false, buf, nil
+	}
+	bound, buf, err = Decode(a, t.RangeContents(), buf, encoding.Ascending)
+	if err != nil {
+		return nil, false, nil, err
+	}
+	buf, marker, err = encoding.DecodeVarintAscending(buf)
+	if err != nil {
+		return nil, false, nil, err
+	}
+	return bound, marker == incMarker, buf, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package keyside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// encodeTupleKey generates an ordered key encoding of a tuple. The fields of
+// the tuple are encoded in ascending order one after the other, and the result
+// is then encoded as bytes in the requested direction, so that the tuple is a
+// single value of the key. This allows tuples to be skipped, and distinguishes
+// a NULL tuple from a tuple whose first field is NULL.
+func encodeTupleKey(b []byte, t *tree.DTuple, dir encoding.Direction) ([]byte, error) {
+	var inner []byte
+	for _, datum := range t.D {
+		var err error
+		inner, err = Encode(inner, datum, encoding.Ascending)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if dir == encoding.Ascending {
+		return encoding.EncodeBytesAscending(b, inner), nil
+	}
+	return encoding.EncodeBytesDescending(b, inner), nil
+}
+
+// decodeTupleKey decodes a tuple key generated by encodeTupleKey.
+func decodeTupleKey(
+	a *tree.DatumAlloc, t *types.T, buf []byte, dir encoding.Direction,
+) (tree.Datum, []byte, error) {
+	var inner []byte
+	var err error
+	if dir == encoding.Ascending {
+		buf, inner, err = encoding.DecodeBytesAscending(buf, nil)
+	} else {
+		buf, inner, err = encoding.DecodeBytesDescending(buf, nil)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	result := tree.NewDTupleWithLen(t, len(t.TupleContents()))
+	for i, typ := range t.TupleContents() {
+		result.D[i], inner, err = Decode(a, typ, inner, encoding.Ascending)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(inner) != 0 {
+		return nil, nil, errors.AssertionFailedf("invalid tuple encoding (%d trailing bytes)", len(inner))
+	}
+	return result, buf, nil
+}
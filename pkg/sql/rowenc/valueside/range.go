@@ -0,0 +1,107 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package valueside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// Flags stored in the first byte of the value encoding of a range. They match
+// the flags used by the Postgres binary format of ranges.
+const (
+	rangeFlagEmpty     = 0x01
+	rangeFlagLowerInc  = 0x02
+	rangeFlagUpperInc  = 0x04
+	rangeFlagLowerInf  = 0x08
+	rangeFlagUpperInf  = 0x10
+	rangeFlagsKnownAll = rangeFlagEmpty | rangeFlagLowerInc | rangeFlagUpperInc |
+		rangeFlagLowerInf | rangeFlagUpperInf
+)
+
+// encodeRange produces the value encoding of a range, which is stored as
+// bytes. The encoding is a flags byte followed by the value encodings of the
+// bounds that are present.
+func encodeRange(r *tree.DRange, scratch []byte) ([]byte, error) {
+	var flags byte
+	if r.Empty {
+		flags |= rangeFlagEmpty
+	} else {
+		if r.LowerInc {
+			flags |= rangeFlagLowerInc
+		}
+		if r.UpperInc {
+			flags |= rangeFlagUpperInc
+		}
+		if r.Lower == tree.DNull {
+			flags |= rangeFlagLowerInf
+		}
+		if r.Upper == tree.DNull {
+			flags |= rangeFlagUpperInf
+		}
+	}
+	b := append(scratch[:0], flags)
+	var err error
+	for _, bound := range []tree.Datum{r.Lower, r.Upper} {
+		if bound == tree.DNull {
+			continue
+		}
+		b, err = Encode(b, NoColumnID, bound, nil /* scratch */)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// decodeRange decodes a range from the bytes produced by encodeRange. It is
+// the counterpart of encodeRange().
+func decodeRange(a *tree.DatumAlloc, t *types.T, b []byte) (tree.Datum, error) {
+	if len(b) == 0 {
+		return nil, errors.AssertionFailedf("invalid range encoding (empty)")
+	}
+	flags := b[0]
+	b = b[1:]
+	if flags&^rangeFlagsKnownAll != 0 {
+		return nil, errors.AssertionFailedf("invalid range encoding (flags %x)", flags)
+	}
+	if flags&rangeFlagEmpty != 0 {
+		return tree.NewDEmptyRange(t), nil
+	}
+	decodeBound := func(inf bool) (tree.Datum, error) {
+		if inf {
+			return tree.DNull, nil
+		}
+		var d tree.Datum
+		var err error
+		d, b, err = Decode(a, t.RangeContents(), b)
+		return d, err
+	}
+	lower, err := decodeBound(flags&rangeFlagLowerInf != 0)
+	if err != nil {
+		return nil, err
+	}
+	upper, err := decodeBound(flags&rangeFlagUpperInf != 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != 0 {
+		return nil, errors.AssertionFailedf("invalid range encoding (%d trailing bytes)", len(b))
+	}
+	return &tree.DRange{
+		Typ:      t,
+		Lower:    lower,
+		Upper:    upper,
+		LowerInc: flags&rangeFlagLowerInc != 0,
+		UpperInc: flags&rangeFlagUpperInc != 0,
+	}, nil
+}
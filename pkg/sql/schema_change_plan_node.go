@@ -216,6 +216,7 @@ func newSchemaChangerTxnRunDependencies(
 		scdeps.NewConstantClock(evalContext.GetTxnTimestamp(time.Microsecond).Time),
 		execCfg.DescMetadaUpdaterFactory,
 		NewSchemaChangerEventLogger(txn, execCfg, 1),
+		NewSchemaChangerPartitionRemover(txn, execCfg, kvTrace),
 		kvTrace,
 		schemaChangerJobID,
 		stmts,
@@ -527,6 +527,8 @@ func (b *builderState) ResolveEnumType(
 		// Implicit record types are not directly modifiable.
 		panic(pgerror.Newf(pgcode.DependentObjectsStillExist,
 			"cannot modify table record type %q", typ.GetName()))
+	case descpb.TypeDescriptor_DOMAIN, descpb.TypeDescriptor_COMPOSITE:
+		panic(scerrors.NotImplementedErrorf(nil /* n */, "%s types", typ.GetKind()))
 	default:
 		panic(errors.AssertionFailedf("unknown type kind %s", typ.GetKind()))
 	}
@@ -26,7 +26,8 @@ import (
 // declarative schema  changer. Operations marked as non-fully supported can
 // only be with the use_declarative_schema_changer session variable.
 var supportedAlterTableStatements = map[reflect.Type]supportedStatement{
-	reflect.TypeOf((*tree.AlterTableAddColumn)(nil)): {alterTableAddColumn, false},
+	reflect.TypeOf((*tree.AlterTableAddColumn)(nil)):     {alterTableAddColumn, false},
+	reflect.TypeOf((*tree.AlterTableDropPartition)(nil)): {alterTableDropPartition, true},
 }
 
 func init() {
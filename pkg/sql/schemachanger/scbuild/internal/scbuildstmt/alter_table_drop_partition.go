@@ -0,0 +1,191 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scbuildstmt
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/errors"
+)
+
+// alterTableDropPartition implements ALTER TABLE ... DROP PARTITION. The
+// partition is removed from every index of the table which has a top-level
+// partition of that name, along with its zone configs. With WITH DATA, the
+// rows of the partition are deleted in the same transaction, which keeps the
+// table online.
+func alterTableDropPartition(
+	b BuildCtx, tn *tree.TableName, tbl *scpb.Table, t *tree.AlterTableDropPartition,
+) {
+	b.IncrementSchemaChangeAlterCounter("table", "drop_partition")
+	name := string(t.Partition)
+	elts := b.QueryByID(tbl.TableID)
+	checkCanDropPartitions(elts, tn)
+	var partitions []*scpb.IndexPartition
+	scpb.ForEachIndexPartition(elts, func(
+		current scpb.Status, target scpb.TargetStatus, p *scpb.IndexPartition,
+	) {
+		if current == scpb.Status_PUBLIC && target == scpb.ToPublic && p.Name == name {
+			partitions = append(partitions, p)
+		}
+	})
+	if len(partitions) == 0 {
+		err := partitionNotFoundError(elts, tn, name)
+		if t.IfExists && pgerror.GetPGCode(err) == pgcode.UndefinedObject {
+			return
+		}
+		panic(err)
+	}
+	if t.WithData {
+		b.CheckPrivilege(tbl, privilege.DELETE)
+		checkCanDropPartitionRows(b, elts, tbl, tn, name)
+	}
+	for _, p := range partitions {
+		dropped := protoutil.Clone(p).(*scpb.IndexPartition)
+		dropped.DropRows = t.WithData
+		b.Drop(dropped)
+	}
+}
+
+// checkCanDropPartitions panics if the partitions of the table cannot be
+// dropped.
+func checkCanDropPartitions(elts ElementResultSet, tn *tree.TableName) {
+	elts.ForEachElementStatus(func(
+		current scpb.Status, target scpb.TargetStatus, e scpb.Element,
+	) {
+		if target != scpb.ToPublic {
+			return
+		}
+		switch e.(type) {
+		case *scpb.TableLocalityGlobal, *scpb.TableLocalityPrimaryRegion,
+			*scpb.TableLocalitySecondaryRegion, *scpb.TableLocalityRegionalByRow:
+			panic(pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot DROP PARTITION on a table in a multi-region enabled database"))
+		}
+	})
+	elts.ForEachElementStatus(func(
+		current scpb.Status, target scpb.TargetStatus, e scpb.Element,
+	) {
+		// Partitions dropped by previous commands of the statement don't
+		// prevent dropping more of them.
+		if _, ok := e.(*scpb.IndexPartition); ok {
+			return
+		}
+		if current != target.Status() {
+			panic(pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"cannot DROP PARTITION on table %q while it is undergoing a schema change",
+				tn.Object()))
+		}
+	})
+	scpb.ForEachIndexPartitioning(elts, func(
+		_ scpb.Status, _ scpb.TargetStatus, p *scpb.IndexPartitioning,
+	) {
+		if p.NumImplicitColumns > 0 {
+			panic(pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot DROP PARTITION on table %q which has implicit column partitioning",
+				tn.Object()))
+		}
+	})
+}
+
+// checkCanDropPartitionRows panics if the rows of the partition cannot be
+// deleted by deleting the spans of the partition. This requires all indexes
+// of the table to be partitioned identically, so that the spans of the
+// partition in every index contain exactly the entries of the rows of the
+// partition, and no foreign keys to reference the rows.
+func checkCanDropPartitionRows(
+	b BuildCtx, elts ElementResultSet, tbl *scpb.Table, tn *tree.TableName, name string,
+) {
+	checkInboundFK := func(_ scpb.Status, target scpb.TargetStatus, fk *scpb.ForeignKeyConstraint) {
+		if target == scpb.ToPublic && fk.ReferencedTableID == tbl.TableID {
+			panic(pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot drop the rows of partition %q of table %q which is referenced by foreign keys",
+				name, tn.Object()))
+		}
+	}
+	scpb.ForEachForeignKeyConstraint(elts, checkInboundFK)
+	scpb.ForEachForeignKeyConstraint(b.BackReferences(tbl.TableID), checkInboundFK)
+
+	partitionings := make(map[catid.IndexID]catpb.PartitioningDescriptor)
+	scpb.ForEachIndexPartitioning(elts, func(
+		_ scpb.Status, _ scpb.TargetStatus, p *scpb.IndexPartitioning,
+	) {
+		partitionings[p.IndexID] = p.PartitioningDescriptor
+	})
+	indexNames := make(map[catid.IndexID]string)
+	scpb.ForEachIndexName(elts, func(_ scpb.Status, _ scpb.TargetStatus, n *scpb.IndexName) {
+		indexNames[n.IndexID] = n.Name
+	})
+	_, _, primary := scpb.FindPrimaryIndex(elts)
+	if primary == nil {
+		panic(errors.AssertionFailedf("missing primary index in table %q", tn.Object()))
+	}
+	primaryPartitioning := partitionings[primary.IndexID]
+	scpb.ForEachSecondaryIndex(elts, func(_ scpb.Status, _ scpb.TargetStatus, idx *scpb.SecondaryIndex) {
+		p := partitionings[idx.IndexID]
+		same := p.Equal(primaryPartitioning)
+		for i := 0; same && i < int(p.NumColumns); i++ {
+			same = i < len(idx.KeyColumnIDs) && idx.KeyColumnIDs[i] == primary.KeyColumnIDs[i]
+		}
+		if !same {
+			panic(errors.WithHint(
+				pgerror.Newf(pgcode.FeatureNotSupported,
+					"cannot drop the rows of partition %q of table %q: index %q is not partitioned like the primary index",
+					name, tn.Object(), indexNames[idx.IndexID]),
+				"Partition all indexes of the table with PARTITION ALL BY.",
+			))
+		}
+	})
+}
+
+// partitionNotFoundError returns the error for a partition which is not a
+// top-level partition of any index of the table.
+func partitionNotFoundError(elts ElementResultSet, tn *tree.TableName, name string) error {
+	var isSubpartition bool
+	scpb.ForEachIndexPartitioning(elts, func(
+		_ scpb.Status, _ scpb.TargetStatus, p *scpb.IndexPartitioning,
+	) {
+		isSubpartition = isSubpartition || hasSubpartition(p.PartitioningDescriptor, name)
+	})
+	if isSubpartition {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"partition %q of table %q is a subpartition", name, tn.Object())
+	}
+	return pgerror.Newf(pgcode.UndefinedObject,
+		"partition %q does not exist on table %q", name, tn.Object())
+}
+
+// hasSubpartition returns whether any of the partitions of the partitioning
+// has a subpartition with the given name, recursively.
+func hasSubpartition(p catpb.PartitioningDescriptor, name string) bool {
+	for i := range p.List {
+		sub := p.List[i].Subpartitioning
+		for j := range sub.List {
+			if sub.List[j].Name == name {
+				return true
+			}
+		}
+		for j := range sub.Range {
+			if sub.Range[j].Name == name {
+				return true
+			}
+		}
+		if hasSubpartition(sub, name) {
+			return true
+		}
+	}
+	return false
+}
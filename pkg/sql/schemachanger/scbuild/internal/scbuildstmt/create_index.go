@@ -130,6 +130,10 @@ func CreateIndex(b BuildCtx, n *tree.CreateIndex) {
 	keyColNames := make([]string, len(n.Columns))
 	for i, columnNode := range n.Columns {
 		colName := columnNode.Column.String()
+		if columnNode.OpClass != "" {
+			panic(scerrors.NotImplementedErrorf(n,
+				"operator classes are not supported"))
+		}
 		if columnNode.Expr != nil {
 			tbl, ok := relation.(*scpb.Table)
 			if !ok {
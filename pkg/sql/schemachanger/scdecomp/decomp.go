@@ -15,6 +15,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
@@ -143,6 +144,18 @@ func (w *walkCtx) walkSchema(sc catalog.SchemaDescriptor) {
 }
 
 func (w *walkCtx) walkType(typ catalog.TypeDescriptor) {
+	if len(typ.TypeDesc().ReferencingFunctionIDs) > 0 {
+		// There are no elements for user-defined functions yet, so schema
+		// changes involving types they depend on are handled by the legacy
+		// schema changer.
+		panic(scerrors.NotImplementedErrorf(nil /* n */, "type %q (%d) referenced by functions",
+			typ.GetName(), typ.GetID()))
+	}
+	if len(typ.TypeDesc().ReferencingTypeIDs) > 0 {
+		// Likewise for the types of the fields of composite types.
+		panic(scerrors.NotImplementedErrorf(nil /* n */, "type %q (%d) referenced by composite types",
+			typ.GetName(), typ.GetID()))
+	}
 	switch typ.GetKind() {
 	case descpb.TypeDescriptor_ALIAS:
 		typeT, err := newTypeT(typ.TypeDesc().Alias)
@@ -160,6 +173,11 @@ func (w *walkCtx) walkType(typ catalog.TypeDescriptor) {
 			ArrayTypeID:   typ.GetArrayTypeID(),
 			IsMultiRegion: typ.GetKind() == descpb.TypeDescriptor_MULTIREGION_ENUM,
 		})
+	case descpb.TypeDescriptor_DOMAIN, descpb.TypeDescriptor_COMPOSITE:
+		// There are no elements for domains or composite types yet, so schema
+		// changes involving them are handled by the legacy schema changer.
+		panic(scerrors.NotImplementedErrorf(nil /* n */, "%s type %q (%d)",
+			typ.GetKind(), typ.GetName(), typ.GetID()))
 	default:
 		panic(errors.AssertionFailedf("unsupported type kind %q", typ.GetKind()))
 	}
@@ -173,6 +191,17 @@ func (w *walkCtx) walkType(typ catalog.TypeDescriptor) {
 }
 
 func (w *walkCtx) walkRelation(tbl catalog.TableDescriptor) {
+	if len(tbl.GetTriggers()) > 0 || len(tbl.TableDesc().DependedOnByTriggers) > 0 {
+		// There are no elements for triggers and their dependencies yet, so
+		// schema changes involving them are handled by the legacy schema changer.
+		panic(scerrors.NotImplementedErrorf(nil /* n */, "relation %q (%d) with triggers",
+			tbl.GetName(), tbl.GetID()))
+	}
+	if len(tbl.TableDesc().DependedOnByFunctions) > 0 {
+		// Likewise for the dependencies of user-defined functions.
+		panic(scerrors.NotImplementedErrorf(nil /* n */, "relation %q (%d) referenced by functions",
+			tbl.GetName(), tbl.GetID()))
+	}
 	switch {
 	case tbl.IsSequence():
 		w.ev(descriptorStatus(tbl), &scpb.Sequence{
@@ -432,6 +461,20 @@ func (w *walkCtx) walkIndex(tbl catalog.TableDescriptor, idx catalog.Index) {
 				IndexID:                idx.GetID(),
 				PartitioningDescriptor: cpy.Partitioning,
 			})
+			for i := range cpy.Partitioning.List {
+				w.ev(scpb.Status_PUBLIC, &scpb.IndexPartition{
+					TableID: tbl.GetID(),
+					IndexID: idx.GetID(),
+					Name:    cpy.Partitioning.List[i].Name,
+				})
+			}
+			for i := range cpy.Partitioning.Range {
+				w.ev(scpb.Status_PUBLIC, &scpb.IndexPartition{
+					TableID: tbl.GetID(),
+					IndexID: idx.GetID(),
+					Name:    cpy.Partitioning.Range[i].Name,
+				})
+			}
 		}
 	}
 	w.ev(scpb.Status_PUBLIC, &scpb.IndexName{
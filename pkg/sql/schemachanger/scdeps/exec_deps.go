@@ -61,6 +61,7 @@ func NewExecutorDependencies(
 	clock scmutationexec.Clock,
 	commentUpdaterFactory scexec.DescriptorMetadataUpdaterFactory,
 	eventLogger scexec.EventLogger,
+	partitionRemover scexec.IndexPartitionRemover,
 	kvTrace bool,
 	schemaChangerJobID jobspb.JobID,
 	statements []string,
@@ -73,6 +74,7 @@ func NewExecutorDependencies(
 			jobRegistry:        jobRegistry,
 			indexValidator:     indexValidator,
 			eventLogger:        eventLogger,
+			partitionRemover:   partitionRemover,
 			schemaChangerJobID: schemaChangerJobID,
 			kvTrace:            kvTrace,
 		},
@@ -95,6 +97,7 @@ type txnDeps struct {
 	createdJobs        []jobspb.JobID
 	indexValidator     scexec.IndexValidator
 	eventLogger        scexec.EventLogger
+	partitionRemover   scexec.IndexPartitionRemover
 	deletedDescriptors catalog.DescriptorIDSet
 	schemaChangerJobID jobspb.JobID
 	kvTrace            bool
@@ -424,6 +427,15 @@ func (d *execDeps) EventLogger() scexec.EventLogger {
 	return d.eventLogger
 }
 
+// IndexPartitionRemoverFactory constructs a new index partition remover with a
+// txn.
+type IndexPartitionRemoverFactory = func(*kv.Txn) scexec.IndexPartitionRemover
+
+// IndexPartitionRemover implements scexec.Dependencies
+func (d *execDeps) IndexPartitionRemover() scexec.IndexPartitionRemover {
+	return d.partitionRemover
+}
+
 // NewNoOpBackfillTracker constructs a backfill tracker which does not do
 // anything. It will always return progress for a given backfill which
 // contains a full set of CompletedSpans corresponding to the source index
@@ -35,6 +35,7 @@ func NewJobRunDependencies(
 	backfiller scexec.Backfiller,
 	rangeCounter RangeCounter,
 	eventLoggerFactory EventLoggerFactory,
+	partitionRemoverFactory IndexPartitionRemoverFactory,
 	jobRegistry *jobs.Registry,
 	job *jobs.Job,
 	codec keys.SQLCodec,
@@ -47,36 +48,38 @@ func NewJobRunDependencies(
 	kvTrace bool,
 ) scrun.JobRunDependencies {
 	return &jobExecutionDeps{
-		collectionFactory:     collectionFactory,
-		db:                    db,
-		internalExecutor:      internalExecutor,
-		backfiller:            backfiller,
-		rangeCounter:          rangeCounter,
-		eventLoggerFactory:    eventLoggerFactory,
-		jobRegistry:           jobRegistry,
-		job:                   job,
-		codec:                 codec,
-		settings:              settings,
-		testingKnobs:          testingKnobs,
-		statements:            statements,
-		indexValidator:        indexValidator,
-		commentUpdaterFactory: commentUpdaterFactory,
-		sessionData:           sessionData,
-		kvTrace:               kvTrace,
+		collectionFactory:       collectionFactory,
+		db:                      db,
+		internalExecutor:        internalExecutor,
+		backfiller:              backfiller,
+		rangeCounter:            rangeCounter,
+		eventLoggerFactory:      eventLoggerFactory,
+		partitionRemoverFactory: partitionRemoverFactory,
+		jobRegistry:             jobRegistry,
+		job:                     job,
+		codec:                   codec,
+		settings:                settings,
+		testingKnobs:            testingKnobs,
+		statements:              statements,
+		indexValidator:          indexValidator,
+		commentUpdaterFactory:   commentUpdaterFactory,
+		sessionData:             sessionData,
+		kvTrace:                 kvTrace,
 	}
 }
 
 type jobExecutionDeps struct {
-	collectionFactory     *descs.CollectionFactory
-	db                    *kv.DB
-	internalExecutor      sqlutil.InternalExecutor
-	eventLoggerFactory    func(txn *kv.Txn) scexec.EventLogger
-	backfiller            scexec.Backfiller
-	commentUpdaterFactory scexec.DescriptorMetadataUpdaterFactory
-	rangeCounter          RangeCounter
-	jobRegistry           *jobs.Registry
-	job                   *jobs.Job
-	kvTrace               bool
+	collectionFactory       *descs.CollectionFactory
+	db                      *kv.DB
+	internalExecutor        sqlutil.InternalExecutor
+	eventLoggerFactory      func(txn *kv.Txn) scexec.EventLogger
+	partitionRemoverFactory func(txn *kv.Txn) scexec.IndexPartitionRemover
+	backfiller              scexec.Backfiller
+	commentUpdaterFactory   scexec.DescriptorMetadataUpdaterFactory
+	rangeCounter            RangeCounter
+	jobRegistry             *jobs.Registry
+	job                     *jobs.Job
+	kvTrace                 bool
 
 	indexValidator scexec.IndexValidator
 
@@ -109,6 +112,7 @@ func (d *jobExecutionDeps) WithTxnInJob(ctx context.Context, fn scrun.JobTxnFunc
 				jobRegistry:        d.jobRegistry,
 				indexValidator:     d.indexValidator,
 				eventLogger:        d.eventLoggerFactory(txn),
+				partitionRemover:   d.partitionRemoverFactory(txn),
 				schemaChangerJobID: d.job.ID(),
 				kvTrace:            d.kvTrace,
 			},
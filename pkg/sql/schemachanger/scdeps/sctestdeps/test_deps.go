@@ -911,6 +911,28 @@ func (s *TestState) EventLogger() scexec.EventLogger {
 	return s
 }
 
+// RemoveIndexPartition implements scexec.IndexPartitionRemover.
+func (s *TestState) RemoveIndexPartition(
+	_ context.Context,
+	_, table catalog.TableDescriptor,
+	indexID descpb.IndexID,
+	name string,
+	dropRows bool,
+) error {
+	if dropRows {
+		s.LogSideEffectf("delete rows of partition %s of index #%d in table #%d",
+			name, indexID, table.GetID())
+	}
+	s.LogSideEffectf("delete zone configs of partition %s of index #%d in table #%d",
+		name, indexID, table.GetID())
+	return nil
+}
+
+// IndexPartitionRemover implements scexec.Dependencies.
+func (s *TestState) IndexPartitionRemover() scexec.IndexPartitionRemover {
+	return s
+}
+
 // UpsertDescriptorComment implements scexec.DescriptorMetadataUpdater.
 func (s *TestState) UpsertDescriptorComment(
 	id int64, subID int64, commentType keys.CommentType, comment string,
@@ -38,6 +38,7 @@ type Dependencies interface {
 	IndexValidator() IndexValidator
 	IndexSpanSplitter() IndexSpanSplitter
 	EventLogger() EventLogger
+	IndexPartitionRemover() IndexPartitionRemover
 	DescriptorMetadataUpdater(ctx context.Context) DescriptorMetadataUpdater
 
 	// Statements returns the statements behind this schema change.
@@ -75,6 +76,22 @@ type EventLogger interface {
 	) error
 }
 
+// IndexPartitionRemover encapsulates the side effects of removing a partition
+// from an index which don't live in the table descriptor.
+type IndexPartitionRemover interface {
+	// RemoveIndexPartition deletes the zone configs of the partition named name,
+	// and of its subpartitions, which was removed from the index in table. The
+	// rows of the partition are deleted in the current transaction if dropRows
+	// is set. oldTable is the table as it was before the partition was removed.
+	RemoveIndexPartition(
+		ctx context.Context,
+		oldTable, table catalog.TableDescriptor,
+		indexID descpb.IndexID,
+		name string,
+		dropRows bool,
+	) error
+}
+
 // CatalogChangeBatcher encapsulates batched updates to the catalog: descriptor
 // updates, namespace operations, etc.
 type CatalogChangeBatcher interface {
@@ -65,6 +65,9 @@ func executeDescriptorMutationOps(ctx context.Context, deps Dependencies, ops []
 	); err != nil {
 		return err
 	}
+	if err := removeIndexPartitions(ctx, mvs, deps.IndexPartitionRemover()); err != nil {
+		return err
+	}
 	if err := logEvents(ctx, mvs, deps.EventLogger()); err != nil {
 		return err
 	}
@@ -231,6 +234,26 @@ func eventLogEntriesForStatement(statementEvents []eventPayload) (logEntries []e
 	return logEntries
 }
 
+// removeIndexPartitions performs the side effects of the removal of index
+// partitions which don't live in the table descriptors.
+func removeIndexPartitions(
+	ctx context.Context, mvs *mutationVisitorState, r IndexPartitionRemover,
+) error {
+	for _, p := range mvs.partitionsToRemove {
+		entry := mvs.modifiedDescriptors.GetByID(p.tableID)
+		if entry == nil {
+			// The table is being dropped.
+			continue
+		}
+		if err := r.RemoveIndexPartition(
+			ctx, p.oldTable, entry.(catalog.TableDescriptor), p.indexID, p.name, p.dropRows,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // updateDescriptorMetadata performs the portions of the side effects of the
 // operations delegated to the DescriptorMetadataUpdater.
 func updateDescriptorMetadata(
@@ -331,6 +354,7 @@ type mutationVisitorState struct {
 	schemaChangerJobUpdates      map[jobspb.JobID]schemaChangerJobUpdate
 	eventsByStatement            map[uint32][]eventPayload
 	scheduleIDsToDelete          []int64
+	partitionsToRemove           []partitionToRemove
 
 	gcJobs
 }
@@ -352,6 +376,14 @@ type databaseRoleSettingToDelete struct {
 	dbID catid.DescID
 }
 
+type partitionToRemove struct {
+	oldTable catalog.TableDescriptor
+	tableID  catid.DescID
+	indexID  descpb.IndexID
+	name     string
+	dropRows bool
+}
+
 type eventPayload struct {
 	id descpb.ID
 	scpb.TargetMetadata
@@ -471,6 +503,23 @@ func (mvs *mutationVisitorState) DeleteSchedule(scheduleID int64) {
 	mvs.scheduleIDsToDelete = append(mvs.scheduleIDsToDelete, scheduleID)
 }
 
+func (mvs *mutationVisitorState) RemoveIndexPartition(
+	oldTable catalog.TableDescriptor,
+	tableID descpb.ID,
+	indexID descpb.IndexID,
+	name string,
+	dropRows bool,
+) {
+	mvs.partitionsToRemove = append(mvs.partitionsToRemove,
+		partitionToRemove{
+			oldTable: oldTable,
+			tableID:  tableID,
+			indexID:  indexID,
+			name:     name,
+			dropRows: dropRows,
+		})
+}
+
 func (mvs *mutationVisitorState) AddDrainedName(id descpb.ID, nameInfo descpb.NameInfo) {
 	mvs.drainedNames[id] = append(mvs.drainedNames[id], nameInfo)
 }
@@ -76,6 +76,7 @@ func (ti testInfra) newExecDeps(
 		scdeps.NewConstantClock(timeutil.Now()),
 		noopMetadataUpdaterFactory{},
 		noopEventLogger{},
+		noopPartitionRemover{},
 		kvTrace,
 		schemaChangerJobID,
 		nil, /* statements */
@@ -512,6 +513,14 @@ func (noopEventLogger) LogEvent(
 	return nil
 }
 
+type noopPartitionRemover struct{}
+
+func (noopPartitionRemover) RemoveIndexPartition(
+	_ context.Context, _, _ catalog.TableDescriptor, _ descpb.IndexID, _ string, _ bool,
+) error {
+	return nil
+}
+
 type noopMetadataUpdaterFactory struct {
 }
 
@@ -234,6 +234,20 @@ func (mr *MockDependenciesMockRecorder) IndexBackfiller() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexBackfiller", reflect.TypeOf((*MockDependencies)(nil).IndexBackfiller))
 }
 
+// IndexPartitionRemover mocks base method.
+func (m *MockDependencies) IndexPartitionRemover() scexec.IndexPartitionRemover {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexPartitionRemover")
+	ret0, _ := ret[0].(scexec.IndexPartitionRemover)
+	return ret0
+}
+
+// IndexPartitionRemover indicates an expected call of IndexPartitionRemover.
+func (mr *MockDependenciesMockRecorder) IndexPartitionRemover() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexPartitionRemover", reflect.TypeOf((*MockDependencies)(nil).IndexPartitionRemover))
+}
+
 // IndexSpanSplitter mocks base method.
 func (m *MockDependencies) IndexSpanSplitter() scexec.IndexSpanSplitter {
 	m.ctrl.T.Helper()
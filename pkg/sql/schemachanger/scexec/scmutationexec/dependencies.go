@@ -122,4 +122,15 @@ type MutationVisitorStateUpdater interface {
 
 	// DeleteSchedule deletes a scheduled job.
 	DeleteSchedule(scheduleID int64)
+
+	// RemoveIndexPartition enqueues the removal of the zone configs, and the
+	// rows if dropRows is set, of a partition removed from an index. oldTable
+	// is the table before the partition was removed.
+	RemoveIndexPartition(
+		oldTable catalog.TableDescriptor,
+		tableID descpb.ID,
+		indexID descpb.IndexID,
+		name string,
+		dropRows bool,
+	)
 }
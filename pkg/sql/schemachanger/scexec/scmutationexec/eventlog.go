@@ -73,6 +73,8 @@ func asEventPayload(
 			TableName:  fullName,
 			MutationID: uint32(mutation.MutationID()),
 		}, nil
+	case *scpb.IndexPartition:
+		return &eventpb.AlterTable{TableName: fullName}, nil
 	case *scpb.SecondaryIndex:
 		tbl, err := m.checkOutTable(ctx, e.TableID)
 		if err != nil {
@@ -13,6 +13,7 @@ package scmutationexec
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
@@ -254,6 +255,25 @@ func (m *visitor) AddIndexPartitionInfo(ctx context.Context, op scop.AddIndexPar
 	return nil
 }
 
+func (m *visitor) RemoveIndexPartition(ctx context.Context, op scop.RemoveIndexPartition) error {
+	tbl, err := m.checkOutTable(ctx, op.TableID)
+	if err != nil {
+		return err
+	}
+	index, err := tbl.FindIndexWithID(op.IndexID)
+	if err != nil || !index.Public() {
+		// The partition is being removed along with the index, there's nothing
+		// left to do here.
+		return nil
+	}
+	oldTable := tbl.ImmutableCopy().(catalog.TableDescriptor)
+	index.IndexDesc().Partitioning = tabledesc.WithoutPartition(
+		index.IndexDesc().Partitioning, op.Name,
+	)
+	m.s.RemoveIndexPartition(oldTable, op.TableID, op.IndexID, op.Name, op.DropRows)
+	return nil
+}
+
 func (m *visitor) SetIndexName(ctx context.Context, op scop.SetIndexName) error {
 	tbl, err := m.checkOutTable(ctx, op.TableID)
 	if err != nil {
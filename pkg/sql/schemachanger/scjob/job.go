@@ -72,6 +72,11 @@ func (n *newSchemaChangeResumer) run(ctx context.Context, execCtxI interface{})
 		func(txn *kv.Txn) scexec.EventLogger {
 			return sql.NewSchemaChangerEventLogger(txn, execCfg, 0)
 		},
+		func(txn *kv.Txn) scexec.IndexPartitionRemover {
+			return sql.NewSchemaChangerPartitionRemover(
+				txn, execCfg, execCtx.ExtendedEvalContext().Tracing.KVTracingEnabled(),
+			)
+		},
 		execCfg.JobRegistry,
 		n.job,
 		execCfg.Codec,
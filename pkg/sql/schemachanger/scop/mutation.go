@@ -266,6 +266,16 @@ type AddIndexPartitionInfo struct {
 	Partitioning scpb.IndexPartitioning
 }
 
+// RemoveIndexPartition removes a top-level partition from the partitioning
+// of an index, deleting the rows of the partition if DropRows is set.
+type RemoveIndexPartition struct {
+	mutationOp
+	TableID  descpb.ID
+	IndexID  descpb.IndexID
+	Name     string
+	DropRows bool
+}
+
 // LogEvent logs an event for a given descriptor.
 type LogEvent struct {
 	mutationOp
@@ -54,6 +54,7 @@ type MutationVisitor interface {
 	RemoveForeignKeyBackReference(context.Context, RemoveForeignKeyBackReference) error
 	RemoveSchemaParent(context.Context, RemoveSchemaParent) error
 	AddIndexPartitionInfo(context.Context, AddIndexPartitionInfo) error
+	RemoveIndexPartition(context.Context, RemoveIndexPartition) error
 	LogEvent(context.Context, LogEvent) error
 	AddColumnFamily(context.Context, AddColumnFamily) error
 	AddColumnDefaultExpression(context.Context, AddColumnDefaultExpression) error
@@ -242,6 +243,11 @@ func (op AddIndexPartitionInfo) Visit(ctx context.Context, v MutationVisitor) er
 	return v.AddIndexPartitionInfo(ctx, op)
 }
 
+// Visit is part of the MutationOp interface.
+func (op RemoveIndexPartition) Visit(ctx context.Context, v MutationVisitor) error {
+	return v.RemoveIndexPartition(ctx, op)
+}
+
 // Visit is part of the MutationOp interface.
 func (op LogEvent) Visit(ctx context.Context, v MutationVisitor) error {
 	return v.LogEvent(ctx, op)
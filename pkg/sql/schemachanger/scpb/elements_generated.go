@@ -606,6 +606,37 @@ func FindIndexName(b ElementStatusIterator) (current Status, target TargetStatus
 	return current, target, element
 }
 
+func (e IndexPartition) element() {}
+
+// ForEachIndexPartition iterates over elements of type IndexPartition.
+func ForEachIndexPartition(
+	b ElementStatusIterator, fn func(current Status, target TargetStatus, e *IndexPartition),
+) {
+  if b == nil {
+    return
+  }
+	b.ForEachElementStatus(func(current Status, target TargetStatus, e Element) {
+		if elt, ok := e.(*IndexPartition); ok {
+			fn(current, target, elt)
+		}
+	})
+}
+
+// FindIndexPartition finds the first element of type IndexPartition.
+func FindIndexPartition(b ElementStatusIterator) (current Status, target TargetStatus, element *IndexPartition) {
+  if b == nil {
+    return current, target, element
+  }
+	b.ForEachElementStatus(func(c Status, t TargetStatus, e Element) {
+		if elt, ok := e.(*IndexPartition); ok {
+			element = elt
+			current = c
+			target = t
+		}
+	})
+	return current, target, element
+}
+
 func (e IndexPartitioning) element() {}
 
 // ForEachIndexPartitioning iterates over elements of type IndexPartitioning.
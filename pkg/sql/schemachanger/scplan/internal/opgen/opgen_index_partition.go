@@ -0,0 +1,48 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package opgen
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+)
+
+func init() {
+	opRegistry.register((*scpb.IndexPartition)(nil),
+		toPublic(
+			scpb.Status_ABSENT,
+			to(scpb.Status_PUBLIC,
+				emit(func(this *scpb.IndexPartition) scop.Op {
+					return notImplemented(this)
+				}),
+			),
+		),
+		toAbsent(
+			scpb.Status_PUBLIC,
+			to(scpb.Status_ABSENT,
+				minPhase(scop.PreCommitPhase),
+				// Deleting the rows of the partition can't be undone.
+				revertible(false),
+				emit(func(this *scpb.IndexPartition) scop.Op {
+					return &scop.RemoveIndexPartition{
+						TableID:  this.TableID,
+						IndexID:  this.IndexID,
+						Name:     this.Name,
+						DropRows: this.DropRows,
+					}
+				}),
+				emit(func(this *scpb.IndexPartition, md *targetsWithElementMap) scop.Op {
+					return newLogEventOp(this, md)
+				}),
+			),
+		),
+	)
+}
@@ -275,6 +275,7 @@ func init() {
 			(*scpb.IndexName)(nil),
 			(*scpb.IndexPartitioning)(nil),
 			(*scpb.IndexComment)(nil),
+			(*scpb.IndexPartition)(nil),
 			// Constraint elements.
 			(*scpb.ConstraintName)(nil),
 			(*scpb.ConstraintComment)(nil),
@@ -67,6 +67,7 @@ func init() {
 		element(scpb.Status_PUBLIC,
 			(*scpb.IndexName)(nil),
 			(*scpb.IndexPartitioning)(nil),
+			(*scpb.IndexPartition)(nil),
 			(*scpb.IndexComment)(nil),
 		),
 		screl.DescID,
@@ -93,6 +94,7 @@ func init() {
 		scpb.ToPublic,
 		element(scpb.Status_PUBLIC,
 			(*scpb.IndexPartitioning)(nil),
+			(*scpb.IndexPartition)(nil),
 			(*scpb.IndexComment)(nil),
 		),
 		element(scpb.Status_WRITE_ONLY,
@@ -129,6 +131,7 @@ func init() {
 		element(scpb.Status_ABSENT,
 			(*scpb.IndexName)(nil),
 			(*scpb.IndexPartitioning)(nil),
+			(*scpb.IndexPartition)(nil),
 			(*scpb.SecondaryIndexPartial)(nil),
 			(*scpb.IndexComment)(nil),
 		),
@@ -143,6 +146,7 @@ func init() {
 		element(scpb.Status_ABSENT,
 			(*scpb.IndexName)(nil),
 			(*scpb.IndexPartitioning)(nil),
+			(*scpb.IndexPartition)(nil),
 			(*scpb.SecondaryIndexPartial)(nil),
 			(*scpb.IndexComment)(nil),
 		),
@@ -135,6 +135,7 @@ func init() {
 			dep.Type(
 				(*scpb.IndexName)(nil),
 				(*scpb.IndexPartitioning)(nil),
+				(*scpb.IndexPartition)(nil),
 			),
 
 			relationID.Entities(screl.DescID, relation, index, dep),
@@ -207,6 +207,11 @@ var elementSchemaOptions = []rel.SchemaOption{
 		rel.EntityAttr(DescID, "TableID"),
 		rel.EntityAttr(IndexID, "IndexID"),
 	),
+	rel.EntityMapping(t((*scpb.IndexPartition)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(IndexID, "IndexID"),
+		rel.EntityAttr(Name, "Name"),
+	),
 	// Constraint elements.
 	rel.EntityMapping(t((*scpb.ConstraintName)(nil)),
 		rel.EntityAttr(DescID, "TableID"),
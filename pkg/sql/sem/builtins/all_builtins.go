@@ -39,6 +39,8 @@ func init() {
 	initPGBuiltins()
 	initMathBuiltins()
 	initOverlapsBuiltins()
+	initRangeBuiltins()
+	initTSearchBuiltins()
 	initReplicationBuiltins()
 	initPgcryptoBuiltins()
 
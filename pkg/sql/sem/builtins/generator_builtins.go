@@ -398,6 +398,32 @@ var generators = map[string]builtinDefinition{
 			tree.VolatilityVolatile,
 		),
 	),
+	"crdb_internal.workload_index_recs": makeBuiltin(
+		tree.FunctionProperties{
+			Class:    tree.GeneratorClass,
+			Category: categorySystemInfo,
+		},
+		makeGeneratorOverload(
+			tree.ArgTypes{},
+			workloadIndexRecsGeneratorType,
+			makeWorkloadIndexRecsGenerator,
+			"Returns the index recommendations collected for the statements of the "+
+				"workload, ordered by the number of statement executions that they "+
+				"would benefit.",
+			tree.VolatilityVolatile,
+		),
+		makeGeneratorOverload(
+			tree.ArgTypes{
+				{Name: "since", Typ: types.TimestampTZ},
+			},
+			workloadIndexRecsGeneratorType,
+			makeWorkloadIndexRecsGenerator,
+			"Returns the index recommendations collected for the statements of the "+
+				"workload since the given timestamp, ordered by the number of statement "+
+				"executions that they would benefit.",
+			tree.VolatilityVolatile,
+		),
+	),
 	"crdb_internal.show_create_all_schemas": makeBuiltin(
 		tree.FunctionProperties{
 			Class: tree.GeneratorClass,
@@ -2024,6 +2050,89 @@ func (p *payloadsForTraceGenerator) Close(_ context.Context) {
 	}
 }
 
+var workloadIndexRecsGeneratorType = types.MakeLabeledTuple(
+	[]*types.T{types.String, types.Int, types.Int},
+	[]string{"index_rec", "statement_count", "execution_count"},
+)
+
+// workloadIndexRecsGenerator is a value generator that iterates over the index
+// recommendations of the statements in the workload. Each recommendation is
+// returned once, along with the number of distinct statements and the number
+// of statement executions it was recommended for.
+type workloadIndexRecsGenerator struct {
+	// Iterator over all internal rows of a query that aggregates the index
+	// recommendations stored in the statement statistics.
+	it tree.InternalRows
+}
+
+func makeWorkloadIndexRecsGenerator(
+	ctx *tree.EvalContext, args tree.Datums,
+) (tree.ValueGenerator, error) {
+	// The user must be an admin to use this builtin.
+	isAdmin, err := ctx.SessionAccessor.HasAdminRole(ctx.Context)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, pgerror.Newf(
+			pgcode.InsufficientPrivilege,
+			"only users with the admin role are allowed to use crdb_internal.workload_index_recs",
+		)
+	}
+
+	const query = `SELECT index_rec,
+       count(DISTINCT fingerprint_id),
+       sum((statistics->'statistics'->>'cnt')::INT8)::INT8
+  FROM crdb_internal.statement_statistics,
+       jsonb_array_elements_text(statistics->'statistics'->'indexRecommendations') AS index_rec
+ WHERE $1::TIMESTAMPTZ IS NULL OR aggregated_ts >= $1::TIMESTAMPTZ
+ GROUP BY index_rec
+ ORDER BY 3 DESC, 1`
+
+	var since tree.Datum = tree.DNull
+	if len(args) > 0 {
+		since = args[0]
+	}
+	it, err := ctx.Planner.QueryIteratorEx(
+		ctx.Ctx(),
+		"crdb_internal.workload_index_recs",
+		ctx.Txn,
+		sessiondata.NoSessionDataOverride,
+		query,
+		since,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &workloadIndexRecsGenerator{it: it}, nil
+}
+
+// ResolvedType implements the tree.ValueGenerator interface.
+func (w *workloadIndexRecsGenerator) ResolvedType() *types.T {
+	return workloadIndexRecsGeneratorType
+}
+
+// Start implements the tree.ValueGenerator interface.
+func (w *workloadIndexRecsGenerator) Start(_ context.Context, _ *kv.Txn) error {
+	return nil
+}
+
+// Next implements the tree.ValueGenerator interface.
+func (w *workloadIndexRecsGenerator) Next(ctx context.Context) (bool, error) {
+	return w.it.Next(ctx)
+}
+
+// Values implements the tree.ValueGenerator interface.
+func (w *workloadIndexRecsGenerator) Values() (tree.Datums, error) {
+	return w.it.Cur(), nil
+}
+
+// Close implements the tree.ValueGenerator interface.
+func (w *workloadIndexRecsGenerator) Close(_ context.Context) {
+	_ = w.it.Close()
+}
+
 var showCreateAllSchemasGeneratorType = types.String
 var showCreateAllTypesGeneratorType = types.String
 var showCreateAllTablesGeneratorType = types.String
@@ -1330,6 +1330,33 @@ SELECT description
 		},
 	),
 
+	// https://www.postgresql.org/docs/current/sql-notify.html
+	"pg_notify": makeBuiltin(
+		tree.FunctionProperties{NullableArgs: true, DistsqlBlocklist: true},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"channel", types.String}, {"payload", types.String}},
+			ReturnType: tree.FixedReturnType(types.Void),
+			Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				// As in Postgres, NULL arguments are treated as empty strings.
+				var channel, payload string
+				if args[0] != tree.DNull {
+					channel = string(tree.MustBeDString(args[0]))
+				}
+				if args[1] != tree.DNull {
+					payload = string(tree.MustBeDString(args[1]))
+				}
+				if err := ctx.Planner.SendNotification(ctx.Ctx(), channel, payload); err != nil {
+					return nil, err
+				}
+				return tree.DVoidDatum, nil
+			},
+			Info: "Sends a notification with the given payload on the given channel. " +
+				"The notification is delivered to the sessions listening on the channel " +
+				"when the current transaction commits.",
+			Volatility: tree.VolatilityVolatile,
+		},
+	),
+
 	// pg_is_in_recovery returns true if the Postgres database is currently in
 	// recovery.  This is not applicable so this can always return false.
 	// https://www.postgresql.org/docs/current/static/functions-admin.html#FUNCTIONS-RECOVERY-INFO-TABLE
@@ -0,0 +1,185 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+func initRangeBuiltins() {
+	// Add all rangeBuiltins to the builtins map after a sanity check.
+	for k, v := range rangeBuiltins {
+		if _, exists := builtins[k]; exists {
+			panic("duplicate builtin: " + k)
+		}
+		builtins[k] = v
+	}
+
+	// Each range type has a constructor function with the name of the type.
+	for _, t := range types.Ranges {
+		name := t.Name()
+		if _, exists := builtins[name]; exists {
+			panic("duplicate builtin: " + name)
+		}
+		builtins[name] = makeRangeConstructorBuiltin(t)
+	}
+
+	// The lower and upper builtins are also defined on strings. The string
+	// overloads are preferred so that calls with NULL or placeholder arguments
+	// keep resolving to them.
+	for name, fn := range map[string]func(r *tree.DRange) tree.Datum{
+		"lower": func(r *tree.DRange) tree.Datum { return r.Lower },
+		"upper": func(r *tree.DRange) tree.Datum { return r.Upper },
+	} {
+		def, exists := builtins[name]
+		if !exists {
+			panic("missing builtin: " + name)
+		}
+		for i := range def.overloads {
+			def.overloads[i].PreferredOverload = true
+		}
+		def.overloads = append(def.overloads, makeRangeOverloads(
+			nil, /* returnType */
+			fmt.Sprintf("Returns the %s bound of `val`, or NULL if it is empty or unbounded on that side.", name),
+			fn,
+		)...)
+		builtins[name] = def
+	}
+}
+
+var rangeBuiltins = map[string]builtinDefinition{
+	"isempty": makeBuiltin(
+		tree.FunctionProperties{Category: categoryRange},
+		makeRangeOverloads(types.Bool, "Returns whether `val` is empty.",
+			func(r *tree.DRange) tree.Datum {
+				return tree.MakeDBool(tree.DBool(r.Empty))
+			},
+		)...,
+	),
+	"lower_inc": makeBuiltin(
+		tree.FunctionProperties{Category: categoryRange},
+		makeRangeOverloads(types.Bool, "Returns whether the lower bound of `val` is inclusive.",
+			func(r *tree.DRange) tree.Datum {
+				return tree.MakeDBool(tree.DBool(r.LowerInc))
+			},
+		)...,
+	),
+	"upper_inc": makeBuiltin(
+		tree.FunctionProperties{Category: categoryRange},
+		makeRangeOverloads(types.Bool, "Returns whether the upper bound of `val` is inclusive.",
+			func(r *tree.DRange) tree.Datum {
+				return tree.MakeDBool(tree.DBool(r.UpperInc))
+			},
+		)...,
+	),
+	"lower_inf": makeBuiltin(
+		tree.FunctionProperties{Category: categoryRange},
+		makeRangeOverloads(types.Bool, "Returns whether `val` has no lower bound.",
+			func(r *tree.DRange) tree.Datum {
+				return tree.MakeDBool(tree.DBool(!r.Empty && r.Lower == tree.DNull))
+			},
+		)...,
+	),
+	"upper_inf": makeBuiltin(
+		tree.FunctionProperties{Category: categoryRange},
+		makeRangeOverloads(types.Bool, "Returns whether `val` has no upper bound.",
+			func(r *tree.DRange) tree.Datum {
+				return tree.MakeDBool(tree.DBool(!r.Empty && r.Upper == tree.DNull))
+			},
+		)...,
+	),
+}
+
+// makeRangeOverloads returns an overload of a builtin taking a single range
+// argument for each range type. If returnType is nil, the overloads return the
+// element type of the range.
+func makeRangeOverloads(
+	returnType *types.T, info string, fn func(r *tree.DRange) tree.Datum,
+) []tree.Overload {
+	overloads := make([]tree.Overload, 0, len(types.Ranges))
+	for _, t := range types.Ranges {
+		retType := returnType
+		if retType == nil {
+			retType = t.RangeContents()
+		}
+		overloads = append(overloads, tree.Overload{
+			Types:      tree.ArgTypes{{"val", t}},
+			ReturnType: tree.FixedReturnType(retType),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return fn(tree.MustBeDRange(args[0])), nil
+			},
+			Info:       info,
+			Volatility: tree.VolatilityImmutable,
+		})
+	}
+	return overloads
+}
+
+// makeRangeConstructorBuiltin returns the constructor function of the given
+// range type, which builds a range out of its bounds. A NULL bound makes the
+// range unbounded on that side.
+func makeRangeConstructorBuiltin(t *types.T) builtinDefinition {
+	elemType := t.RangeContents()
+	return makeBuiltin(
+		tree.FunctionProperties{
+			Category:     categoryRange,
+			NullableArgs: true,
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"lower", elemType}, {"upper", elemType}},
+			ReturnType: tree.FixedReturnType(t),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return tree.NewDRange(t, args[0], args[1], true /* lowerInc */, false /* upperInc */)
+			},
+			Info: fmt.Sprintf("Returns a range of type %s with the bounds `lower` and `upper`, "+
+				"including `lower` and excluding `upper`.", t.SQLString()),
+			Volatility: tree.VolatilityImmutable,
+		},
+		tree.Overload{
+			Types: tree.ArgTypes{
+				{"lower", elemType}, {"upper", elemType}, {"bounds", types.String},
+			},
+			ReturnType: tree.FixedReturnType(t),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				if args[2] == tree.DNull {
+					return nil, pgerror.New(pgcode.NullValueNotAllowed,
+						"range constructor flags argument must not be null")
+				}
+				lowerInc, upperInc, err := parseRangeBoundFlags(string(tree.MustBeDString(args[2])))
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDRange(t, args[0], args[1], lowerInc, upperInc)
+			},
+			Info: fmt.Sprintf("Returns a range of type %s with the bounds `lower` and `upper`. "+
+				"`bounds` is one of `[]`, `[)`, `(]` or `()`, and determines whether "+
+				"each bound is included in the range.", t.SQLString()),
+			Volatility: tree.VolatilityImmutable,
+		},
+	)
+}
+
+// parseRangeBoundFlags parses the bounds argument of a range constructor.
+func parseRangeBoundFlags(s string) (lowerInc, upperInc bool, _ error) {
+	if len(s) != 2 || (s[0] != '[' && s[0] != '(') || (s[1] != ']' && s[1] != ')') {
+		return false, false, errors.WithHint(
+			pgerror.New(pgcode.Syntax, "invalid range bound flags"),
+			`Valid values are "[]", "[)", "(]", and "()".`,
+		)
+	}
+	return s[0] == '[', s[1] == ']', nil
+}
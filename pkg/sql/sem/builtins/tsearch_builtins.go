@@ -0,0 +1,310 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+)
+
+func initTSearchBuiltins() {
+	// Add all tsearchBuiltins to the builtins map after a sanity check.
+	for k, v := range tsearchBuiltins {
+		if _, exists := builtins[k]; exists {
+			panic("duplicate builtin: " + k)
+		}
+		builtins[k] = v
+	}
+}
+
+var tsearchBuiltins = map[string]builtinDefinition{
+	"to_tsvector": makeBuiltin(
+		tree.FunctionProperties{Category: categoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"document", types.String}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return toTSVector(tsearch.DefaultConfigName, args[0])
+			},
+			Info: "Converts `document` to a tsvector using the default text search " +
+				"configuration.",
+			// The default configuration is a session setting in Postgres, so this
+			// overload is not immutable.
+			Volatility: tree.VolatilityStable,
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"config", types.String}, {"document", types.String}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return toTSVector(string(tree.MustBeDString(args[0])), args[1])
+			},
+			Info: "Converts `document` to a tsvector using the text search " +
+				"configuration `config`, which is either `english` or `simple`.",
+			Volatility: tree.VolatilityImmutable,
+		},
+	),
+
+	"to_tsquery": makeTSQueryBuiltin(
+		"Converts `query` to a tsquery. The operands of the query are normalized "+
+			"using the text search configuration, and stop words are removed.",
+		func(c *tsearch.Config, query string) (tsearch.TSQuery, error) {
+			return c.ToTSQuery(query)
+		},
+	),
+
+	"plainto_tsquery": makeTSQueryBuiltin(
+		"Converts the unformatted text `query` to a tsquery that matches "+
+			"documents containing all of its words.",
+		func(c *tsearch.Config, query string) (tsearch.TSQuery, error) {
+			return c.PlainToTSQuery(query), nil
+		},
+	),
+
+	"phraseto_tsquery": makeTSQueryBuiltin(
+		"Converts the unformatted text `query` to a tsquery that matches "+
+			"documents containing its words in the same order.",
+		func(c *tsearch.Config, query string) (tsearch.TSQuery, error) {
+			return c.PhraseToTSQuery(query), nil
+		},
+	),
+
+	"ts_rank": makeBuiltin(
+		tree.FunctionProperties{Category: categoryFullTextSearch},
+		makeTSRankOverload(false /* hasWeights */, false /* hasNormalization */),
+		makeTSRankOverload(false /* hasWeights */, true /* hasNormalization */),
+		makeTSRankOverload(true /* hasWeights */, false /* hasNormalization */),
+		makeTSRankOverload(true /* hasWeights */, true /* hasNormalization */),
+	),
+
+	"ts_headline": makeBuiltin(
+		tree.FunctionProperties{Category: categoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"document", types.String}, {"query", types.TSQuery}},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return tsHeadline(tsearch.DefaultConfigName, args[0], args[1], "")
+			},
+			Info:       "Returns an excerpt of `document` in which the words matching `query` are highlighted.",
+			Volatility: tree.VolatilityStable,
+		},
+		tree.Overload{
+			Types: tree.ArgTypes{
+				{"config", types.String}, {"document", types.String}, {"query", types.TSQuery},
+			},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return tsHeadline(string(tree.MustBeDString(args[0])), args[1], args[2], "")
+			},
+			Info: "Returns an excerpt of `document` in which the words matching `query` are highlighted, " +
+				"using the text search configuration `config`.",
+			Volatility: tree.VolatilityImmutable,
+			// Postgres has a (document, query, options) overload with the same
+			// argument families, which is stable.
+			IgnoreVolatilityCheck: true,
+		},
+		tree.Overload{
+			Types: tree.ArgTypes{
+				{"document", types.String}, {"query", types.TSQuery}, {"options", types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return tsHeadline(tsearch.DefaultConfigName, args[0], args[1], string(tree.MustBeDString(args[2])))
+			},
+			Info: "Returns an excerpt of `document` in which the words matching `query` are highlighted. " +
+				"`options` is a comma-separated list of `name=value` pairs, where the names are " +
+				"StartSel, StopSel, MaxWords, MinWords, ShortWord and HighlightAll.",
+			Volatility: tree.VolatilityStable,
+		},
+		tree.Overload{
+			Types: tree.ArgTypes{
+				{"config", types.String},
+				{"document", types.String},
+				{"query", types.TSQuery},
+				{"options", types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return tsHeadline(
+					string(tree.MustBeDString(args[0])), args[1], args[2], string(tree.MustBeDString(args[3])),
+				)
+			},
+			Info: "Returns an excerpt of `document` in which the words matching `query` are highlighted, " +
+				"using the text search configuration `config`. `options` is a comma-separated list " +
+				"of `name=value` pairs, where the names are StartSel, StopSel, MaxWords, MinWords, " +
+				"ShortWord and HighlightAll.",
+			Volatility: tree.VolatilityImmutable,
+		},
+	),
+
+	"setweight": makeBuiltin(
+		tree.FunctionProperties{Category: categoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"vector", types.TSVector}, {"weight", types.QChar}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				w, err := tsearch.ParseWeight(string(tree.MustBeDString(args[1])))
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDTSVector(tree.MustBeDTSVector(args[0]).SetWeight(w)), nil
+			},
+			Info:       "Returns `vector` with the weight of all its positions set to `weight`.",
+			Volatility: tree.VolatilityImmutable,
+		},
+	),
+
+	"strip": makeBuiltin(
+		tree.FunctionProperties{Category: categoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"vector", types.TSVector}},
+			ReturnType: tree.FixedReturnType(types.TSVector),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return tree.NewDTSVector(tree.MustBeDTSVector(args[0]).Strip()), nil
+			},
+			Info:       "Returns `vector` without the positions and weights of its lexemes.",
+			Volatility: tree.VolatilityImmutable,
+		},
+	),
+}
+
+func toTSVector(configName string, document tree.Datum) (tree.Datum, error) {
+	c, err := tsearch.GetConfig(configName)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDTSVector(c.ToTSVector(string(tree.MustBeDString(document)))), nil
+}
+
+// makeTSQueryBuiltin returns a builtin converting a string to a tsquery,
+// with an optional text search configuration argument.
+func makeTSQueryBuiltin(
+	info string, fn func(c *tsearch.Config, query string) (tsearch.TSQuery, error),
+) builtinDefinition {
+	eval := func(configName string, query tree.Datum) (tree.Datum, error) {
+		c, err := tsearch.GetConfig(configName)
+		if err != nil {
+			return nil, err
+		}
+		q, err := fn(c, string(tree.MustBeDString(query)))
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDTSQuery(q), nil
+	}
+	return makeBuiltin(
+		tree.FunctionProperties{Category: categoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"query", types.String}},
+			ReturnType: tree.FixedReturnType(types.TSQuery),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return eval(tsearch.DefaultConfigName, args[0])
+			},
+			Info:       info + " The default text search configuration is used.",
+			Volatility: tree.VolatilityStable,
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"config", types.String}, {"query", types.String}},
+			ReturnType: tree.FixedReturnType(types.TSQuery),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return eval(string(tree.MustBeDString(args[0])), args[1])
+			},
+			Info:       info + " The text search configuration is `config`.",
+			Volatility: tree.VolatilityImmutable,
+		},
+	)
+}
+
+// makeTSRankOverload returns an overload of ts_rank, which optionally takes
+// an array of weights as its first argument and a normalization method as its
+// last argument.
+func makeTSRankOverload(hasWeights, hasNormalization bool) tree.Overload {
+	argTypes := tree.ArgTypes{{"vector", types.TSVector}, {"query", types.TSQuery}}
+	if hasWeights {
+		argTypes = append(tree.ArgTypes{{"weights", types.MakeArray(types.Float4)}}, argTypes...)
+	}
+	if hasNormalization {
+		argTypes = append(argTypes, tree.ArgTypes{{"normalization", types.Int4}}...)
+	}
+	info := "Returns a number that indicates how well `vector` matches `query`, " +
+		"based on the frequency of the matching lexemes."
+	if hasWeights {
+		info += " `weights` are the weights of the D, C, B and A lexemes, which default " +
+			"to {0.1, 0.2, 0.4, 1.0}."
+	}
+	if hasNormalization {
+		info += " `normalization` is a bit mask of the ways in which the length of the " +
+			"document is taken into account: 1 divides the rank by 1 + the logarithm of the " +
+			"length, 2 by the length, 8 by the number of unique words, 16 by 1 + the " +
+			"logarithm of the number of unique words, and 32 by itself + 1."
+	}
+	return tree.Overload{
+		Types:      argTypes,
+		ReturnType: tree.FixedReturnType(types.Float4),
+		Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+			weights := tsearch.DefaultRankWeights
+			if hasWeights {
+				var err error
+				if weights, err = getTSRankWeights(tree.MustBeDArray(args[0])); err != nil {
+					return nil, err
+				}
+				args = args[1:]
+			}
+			var method int
+			if hasNormalization {
+				method = int(tree.MustBeDInt(args[2]))
+			}
+			rank := tsearch.Rank(weights, tree.MustBeDTSVector(args[0]).TSVector, tree.MustBeDTSQuery(args[1]).TSQuery, method)
+			return tree.NewDFloat(tree.DFloat(rank)), nil
+		},
+		Info:       info,
+		Volatility: tree.VolatilityImmutable,
+	}
+}
+
+// getTSRankWeights returns the weights of ts_rank given as an array. Negative
+// weights are replaced by the default ones, like in Postgres.
+func getTSRankWeights(arr *tree.DArray) ([4]float32, error) {
+	weights := tsearch.DefaultRankWeights
+	if arr.Len() < len(weights) {
+		return weights, pgerror.New(pgcode.ArraySubscript, "array of weight is too short")
+	}
+	if arr.HasNulls {
+		return weights, pgerror.New(pgcode.NullValueNotAllowed, "array of weight must not contain nulls")
+	}
+	for i := range weights {
+		w := float32(tree.MustBeDFloat(arr.Array[i]))
+		if w > 1 {
+			return weights, pgerror.New(pgcode.InvalidParameterValue, "weight out of range")
+		}
+		if w >= 0 {
+			weights[i] = w
+		}
+	}
+	return weights, nil
+}
+
+func tsHeadline(
+	configName string, document tree.Datum, query tree.Datum, options string,
+) (tree.Datum, error) {
+	c, err := tsearch.GetConfig(configName)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := tsearch.ParseHeadlineOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	headline := c.Headline(string(tree.MustBeDString(document)), tree.MustBeDTSQuery(query).TSQuery, opts)
+	return tree.NewDString(headline), nil
+}
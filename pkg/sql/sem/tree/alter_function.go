@@ -0,0 +1,85 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// AlterFunction represents an ALTER FUNCTION statement.
+type AlterFunction struct {
+	Function FuncObj
+	Cmd      AlterFunctionCmd
+}
+
+var _ Statement = &AlterFunction{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterFunction) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER FUNCTION ")
+	ctx.FormatNode(&node.Function)
+	ctx.FormatNode(node.Cmd)
+}
+
+// AlterFunctionCmd represents a function modification operation.
+type AlterFunctionCmd interface {
+	NodeFormatter
+	alterFunctionCmd()
+}
+
+func (*AlterFunctionOptions) alterFunctionCmd() {}
+
+// AlterFunctionOptions represents an ALTER FUNCTION command that changes the
+// volatility, leakproof or null input behavior options of a function.
+type AlterFunctionOptions struct {
+	Options FunctionOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterFunctionOptions) Format(ctx *FmtCtx) {
+	ctx.WriteByte(' ')
+	ctx.FormatNode(&node.Options)
+}
+
+func (*AlterFunctionRename) alterFunctionCmd() {}
+
+// AlterFunctionRename represents an ALTER FUNCTION RENAME command.
+type AlterFunctionRename struct {
+	NewName Name
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterFunctionRename) Format(ctx *FmtCtx) {
+	ctx.WriteString(" RENAME TO ")
+	ctx.FormatNode(&node.NewName)
+}
+
+func (*AlterFunctionOwner) alterFunctionCmd() {}
+
+// AlterFunctionOwner represents an ALTER FUNCTION OWNER TO command.
+type AlterFunctionOwner struct {
+	Owner RoleSpec
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterFunctionOwner) Format(ctx *FmtCtx) {
+	ctx.WriteString(" OWNER TO ")
+	ctx.FormatNode(&node.Owner)
+}
+
+func (*AlterFunctionSetSchema) alterFunctionCmd() {}
+
+// AlterFunctionSetSchema represents an ALTER FUNCTION SET SCHEMA command.
+type AlterFunctionSetSchema struct {
+	Schema Name
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterFunctionSetSchema) Format(ctx *FmtCtx) {
+	ctx.WriteString(" SET SCHEMA ")
+	ctx.FormatNode(&node.Schema)
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 )
 
@@ -76,6 +77,9 @@ func (*AlterTableSetOnUpdate) alterTableCmd()        {}
 func (*AlterTableSetVisible) alterTableCmd()         {}
 func (*AlterTableValidateConstraint) alterTableCmd() {}
 func (*AlterTablePartitionByTable) alterTableCmd()   {}
+func (*AlterTableDropPartition) alterTableCmd()      {}
+func (*AlterTableDetachPartition) alterTableCmd()    {}
+func (*AlterTableRowLevelSecurity) alterTableCmd()   {}
 func (*AlterTableInjectStats) alterTableCmd()        {}
 func (*AlterTableSetStorageParams) alterTableCmd()   {}
 func (*AlterTableResetStorageParams) alterTableCmd() {}
@@ -96,6 +100,9 @@ var _ AlterTableCmd = &AlterTableSetOnUpdate{}
 var _ AlterTableCmd = &AlterTableSetVisible{}
 var _ AlterTableCmd = &AlterTableValidateConstraint{}
 var _ AlterTableCmd = &AlterTablePartitionByTable{}
+var _ AlterTableCmd = &AlterTableDropPartition{}
+var _ AlterTableCmd = &AlterTableDetachPartition{}
+var _ AlterTableCmd = &AlterTableRowLevelSecurity{}
 var _ AlterTableCmd = &AlterTableInjectStats{}
 var _ AlterTableCmd = &AlterTableSetStorageParams{}
 var _ AlterTableCmd = &AlterTableResetStorageParams{}
@@ -552,6 +559,99 @@ func (node *AlterTablePartitionByTable) Format(ctx *FmtCtx) {
 	ctx.FormatNode(node.PartitionByTable)
 }
 
+// AlterTableDropPartition represents an ALTER TABLE DROP PARTITION command.
+type AlterTableDropPartition struct {
+	IfExists  bool
+	Partition Name
+	// WithData is set if the rows of the partition are deleted along with
+	// it. Otherwise, only the partition is removed and its rows are kept.
+	WithData bool
+}
+
+// TelemetryCounter implements the AlterTableCmd interface.
+func (node *AlterTableDropPartition) TelemetryCounter() telemetry.Counter {
+	return sqltelemetry.SchemaChangeAlterCounterWithExtra("table", "drop_partition")
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTableDropPartition) Format(ctx *FmtCtx) {
+	ctx.WriteString(" DROP PARTITION ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	// Without quotes, CASCADE and RESTRICT would be parsed as the drop
+	// behavior of a column named "partition".
+	if !ctx.HasFlags(FmtAnonymize) && (node.Partition == "cascade" || node.Partition == "restrict") {
+		lexbase.EncodeEscapedSQLIdent(&ctx.Buffer, string(node.Partition))
+	} else {
+		ctx.FormatNode(&node.Partition)
+	}
+	if node.WithData {
+		ctx.WriteString(" WITH DATA")
+	}
+}
+
+// AlterTableDetachPartition represents an ALTER TABLE DETACH PARTITION ...
+// INTO command.
+type AlterTableDetachPartition struct {
+	Partition Name
+	Into      *UnresolvedObjectName
+}
+
+// TelemetryCounter implements the AlterTableCmd interface.
+func (node *AlterTableDetachPartition) TelemetryCounter() telemetry.Counter {
+	return sqltelemetry.SchemaChangeAlterCounterWithExtra("table", "detach_partition")
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTableDetachPartition) Format(ctx *FmtCtx) {
+	ctx.WriteString(" DETACH PARTITION ")
+	ctx.FormatNode(&node.Partition)
+	ctx.WriteString(" INTO ")
+	ctx.FormatNode(node.Into)
+}
+
+// RowLevelSecurityAction represents the change made to the row-level security
+// of a table by an ALTER TABLE command.
+type RowLevelSecurityAction int
+
+// RowLevelSecurityAction values.
+const (
+	RowLevelSecurityEnable RowLevelSecurityAction = iota
+	RowLevelSecurityDisable
+	RowLevelSecurityForce
+	RowLevelSecurityNoForce
+)
+
+var rowLevelSecurityActionName = [...]string{
+	RowLevelSecurityEnable:  "ENABLE",
+	RowLevelSecurityDisable: "DISABLE",
+	RowLevelSecurityForce:   "FORCE",
+	RowLevelSecurityNoForce: "NO FORCE",
+}
+
+func (a RowLevelSecurityAction) String() string {
+	return rowLevelSecurityActionName[a]
+}
+
+// AlterTableRowLevelSecurity represents an ALTER TABLE {ENABLE | DISABLE |
+// FORCE | NO FORCE} ROW LEVEL SECURITY command.
+type AlterTableRowLevelSecurity struct {
+	Action RowLevelSecurityAction
+}
+
+// TelemetryCounter implements the AlterTableCmd interface.
+func (node *AlterTableRowLevelSecurity) TelemetryCounter() telemetry.Counter {
+	return sqltelemetry.SchemaChangeAlterCounterWithExtra("table", "row_level_security")
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTableRowLevelSecurity) Format(ctx *FmtCtx) {
+	ctx.WriteByte(' ')
+	ctx.WriteString(node.Action.String())
+	ctx.WriteString(" ROW LEVEL SECURITY")
+}
+
 // AuditMode represents a table audit mode
 type AuditMode int
 
@@ -62,7 +62,7 @@ func resolveAsOfFuncType(asOf AsOfClause, searchPath sessiondata.SearchPath) asO
 	if !ok {
 		return asOfFuncTypeInvalid
 	}
-	def, err := fe.Func.Resolve(searchPath)
+	def, err := fe.Func.Resolve(context.Background(), searchPath, nil /* resolver */)
 	if err != nil {
 		return asOfFuncTypeInvalid
 	}
@@ -15,21 +15,62 @@ type CreateChangefeed struct {
 	Targets ChangefeedTargets
 	SinkURI Expr
 	Options KVOptions
+
+	// Select is set for changefeeds created with CREATE CHANGEFEED AS SELECT.
+	// It selects from a single target table, and its projection and WHERE
+	// clause are evaluated for each changed row. Targets is empty in this
+	// case.
+	Select *SelectClause
+
+	// Database is set for changefeeds created with CREATE CHANGEFEED FOR
+	// DATABASE, which target every table of the database, including the tables
+	// created after the changefeed. Targets is empty in this case.
+	Database Name
+
+	// Schema is set for changefeeds created with CREATE CHANGEFEED FOR SCHEMA,
+	// which target every table of the schema in the same way.
+	Schema *ObjectNamePrefix
 }
 
 var _ Statement = &CreateChangefeed{}
 
 // Format implements the NodeFormatter interface.
 func (node *CreateChangefeed) Format(ctx *FmtCtx) {
-	if node.SinkURI != nil {
+	if node.Select != nil {
+		node.formatWithSelect(ctx)
+		return
+	}
+	if node.SinkURI != nil || node.Database != "" || node.Schema != nil {
 		ctx.WriteString("CREATE ")
 	} else {
 		// Sinkless feeds don't really CREATE anything, so the syntax omits the
 		// prefix. They're also still EXPERIMENTAL, so they get marked as such.
+		// There is no such syntax for feeds on a database or schema.
 		ctx.WriteString("EXPERIMENTAL ")
 	}
 	ctx.WriteString("CHANGEFEED FOR ")
-	ctx.FormatNode(&node.Targets)
+	switch {
+	case node.Database != "":
+		ctx.WriteString("DATABASE ")
+		ctx.FormatNode(&node.Database)
+	case node.Schema != nil:
+		ctx.WriteString("SCHEMA ")
+		ctx.FormatNode(node.Schema)
+	default:
+		ctx.FormatNode(&node.Targets)
+	}
+	if node.SinkURI != nil {
+		ctx.WriteString(" INTO ")
+		ctx.FormatNode(node.SinkURI)
+	}
+	if node.Options != nil {
+		ctx.WriteString(" WITH ")
+		ctx.FormatNode(&node.Options)
+	}
+}
+
+func (node *CreateChangefeed) formatWithSelect(ctx *FmtCtx) {
+	ctx.WriteString("CREATE CHANGEFEED")
 	if node.SinkURI != nil {
 		ctx.WriteString(" INTO ")
 		ctx.FormatNode(node.SinkURI)
@@ -38,6 +79,8 @@ func (node *CreateChangefeed) Format(ctx *FmtCtx) {
 		ctx.WriteString(" WITH ")
 		ctx.FormatNode(&node.Options)
 	}
+	ctx.WriteString(" AS ")
+	ctx.FormatNode(node.Select)
 }
 
 // ChangefeedTarget represents a database object to be watched by a changefeed.
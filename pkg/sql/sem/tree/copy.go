@@ -11,6 +11,8 @@
 package tree
 
 import (
+	"strings"
+
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 )
@@ -23,6 +25,15 @@ type CopyFrom struct {
 	Options CopyOptions
 }
 
+// CopyTo represents a COPY TO statement. Either Table (and optionally
+// Columns) or Statement is set.
+type CopyTo struct {
+	Table     TableName
+	Columns   NameList
+	Statement Statement
+	Options   CopyOptions
+}
+
 // CopyOptions describes options for COPY execution.
 type CopyOptions struct {
 	Destination Expr
@@ -30,6 +41,8 @@ type CopyOptions struct {
 	Delimiter   Expr
 	Null        Expr
 	Escape      *StrVal
+	Header      bool
+	Quote       *StrVal
 }
 
 var _ NodeFormatter = &CopyOptions{}
@@ -53,6 +66,28 @@ func (node *CopyFrom) Format(ctx *FmtCtx) {
 	}
 }
 
+// Format implements the NodeFormatter interface.
+func (node *CopyTo) Format(ctx *FmtCtx) {
+	ctx.WriteString("COPY ")
+	if node.Statement != nil {
+		ctx.WriteString("(")
+		ctx.FormatNode(node.Statement)
+		ctx.WriteString(")")
+	} else {
+		ctx.FormatNode(&node.Table)
+		if len(node.Columns) > 0 {
+			ctx.WriteString(" (")
+			ctx.FormatNode(&node.Columns)
+			ctx.WriteString(")")
+		}
+	}
+	ctx.WriteString(" TO STDOUT")
+	if !node.Options.IsDefault() {
+		ctx.WriteString(" WITH ")
+		ctx.FormatNode(&node.Options)
+	}
+}
+
 // Format implements the NodeFormatter interface
 func (o *CopyOptions) Format(ctx *FmtCtx) {
 	var addSep bool
@@ -96,6 +131,16 @@ func (o *CopyOptions) Format(ctx *FmtCtx) {
 		maybeAddSep()
 		ctx.WriteString("ESCAPE ")
 		ctx.FormatNode(o.Escape)
+		addSep = true
+	}
+	if o.Header {
+		maybeAddSep()
+		ctx.WriteString("HEADER")
+	}
+	if o.Quote != nil {
+		maybeAddSep()
+		ctx.WriteString("QUOTE ")
+		ctx.FormatNode(o.Quote)
 	}
 }
 
@@ -137,6 +182,18 @@ func (o *CopyOptions) CombineWith(other *CopyOptions) error {
 		}
 		o.Escape = other.Escape
 	}
+	if other.Header {
+		if o.Header {
+			return pgerror.Newf(pgcode.Syntax, "header option specified multiple times")
+		}
+		o.Header = true
+	}
+	if other.Quote != nil {
+		if o.Quote != nil {
+			return pgerror.Newf(pgcode.Syntax, "quote option specified multiple times")
+		}
+		o.Quote = other.Quote
+	}
 	return nil
 }
 
@@ -149,3 +206,111 @@ const (
 	CopyFormatBinary
 	CopyFormatCSV
 )
+
+// CopyOutSettings are the formatting settings of a COPY TO statement,
+// resolved from its options and the defaults of its format.
+type CopyOutSettings struct {
+	Format    CopyFormat
+	Delimiter byte
+	Null      string
+	Header    bool
+	// Quote and Escape are only used by the CSV format.
+	Quote  byte
+	Escape byte
+}
+
+// MakeCopyOutSettings validates the options of a COPY TO statement and
+// resolves them into CopyOutSettings.
+func MakeCopyOutSettings(o *CopyOptions) (CopyOutSettings, error) {
+	s := CopyOutSettings{Format: o.CopyFormat, Header: o.Header}
+	switch o.CopyFormat {
+	case CopyFormatText:
+		s.Delimiter = '\t'
+		s.Null = `\N`
+	case CopyFormatCSV:
+		s.Delimiter = ','
+		s.Quote = '"'
+	}
+	if o.Destination != nil {
+		return s, pgerror.New(pgcode.Syntax, "COPY TO does not support the destination option")
+	}
+	if o.Delimiter != nil {
+		if o.CopyFormat == CopyFormatBinary {
+			return s, pgerror.New(pgcode.Syntax, "cannot specify DELIMITER in BINARY mode")
+		}
+		c, err := copyOptionByte(o.Delimiter, "delimiter")
+		if err != nil {
+			return s, err
+		}
+		if c == '\n' || c == '\r' {
+			return s, pgerror.New(pgcode.InvalidParameterValue,
+				"COPY delimiter cannot be newline or carriage return")
+		}
+		s.Delimiter = c
+	}
+	if o.Null != nil {
+		if o.CopyFormat == CopyFormatBinary {
+			return s, pgerror.New(pgcode.Syntax, "cannot specify NULL in BINARY mode")
+		}
+		null, err := copyOptionString(o.Null, "null")
+		if err != nil {
+			return s, err
+		}
+		if strings.ContainsAny(null, "\r\n") {
+			return s, pgerror.New(pgcode.InvalidParameterValue,
+				"COPY null representation cannot use newline or carriage return")
+		}
+		s.Null = null
+	}
+	if o.Header && o.CopyFormat != CopyFormatCSV {
+		return s, pgerror.New(pgcode.FeatureNotSupported, "COPY HEADER available only in CSV mode")
+	}
+	if o.Quote != nil {
+		if o.CopyFormat != CopyFormatCSV {
+			return s, pgerror.New(pgcode.FeatureNotSupported, "COPY quote available only in CSV mode")
+		}
+		c, err := copyOptionByte(o.Quote, "quote")
+		if err != nil {
+			return s, err
+		}
+		s.Quote = c
+	}
+	s.Escape = s.Quote
+	if o.Escape != nil {
+		if o.CopyFormat != CopyFormatCSV {
+			return s, pgerror.New(pgcode.FeatureNotSupported, "COPY escape available only in CSV mode")
+		}
+		c, err := copyOptionByte(o.Escape, "escape")
+		if err != nil {
+			return s, err
+		}
+		s.Escape = c
+	}
+	if o.CopyFormat == CopyFormatCSV && s.Delimiter == s.Quote {
+		return s, pgerror.New(pgcode.InvalidParameterValue, "COPY delimiter and quote must be different")
+	}
+	return s, nil
+}
+
+// copyOptionString returns the value of a string COPY option. Only literals
+// are supported, since COPY cannot be prepared.
+func copyOptionString(e Expr, name string) (string, error) {
+	if s, ok := e.(*StrVal); ok {
+		return s.RawString(), nil
+	}
+	return "", pgerror.Newf(pgcode.FeatureNotSupported, "COPY %s must be a string literal", name)
+}
+
+// copyOptionByte returns the value of a COPY option that must be a single
+// one-byte character.
+func copyOptionByte(e Expr, name string) (byte, error) {
+	s, err := copyOptionString(e, name)
+	if err != nil {
+		return 0, err
+	}
+	if len(s) != 1 {
+		return 0, pgerror.Newf(pgcode.InvalidParameterValue,
+			"COPY %s must be a single one-byte character", name)
+	}
+	return s[0], nil
+}
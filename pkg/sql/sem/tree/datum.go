@@ -41,6 +41,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timetz"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uint128"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
@@ -3548,6 +3549,637 @@ func (d *DBox2D) Size() uintptr {
 	return unsafe.Sizeof(*d) + unsafe.Sizeof(d.CartesianBoundingBox)
 }
 
+// DRange is the Datum representation of the range types. A range is either
+// empty, or contains the values between its lower and upper bounds. A DNull
+// bound means that the range is unbounded on that side.
+//
+// Ranges of discrete types (INT4RANGE, INT8RANGE and DATERANGE) are always
+// stored in their canonical [lower,upper) form, so that equal ranges have
+// identical representations.
+type DRange struct {
+	Typ *types.T
+	// Lower and Upper are the bounds of the range. They are DNull for
+	// unbounded sides and for the empty range.
+	Lower, Upper Datum
+	// LowerInc and UpperInc are set if the corresponding bound is included in
+	// the range. They are always unset for unbounded sides.
+	LowerInc, UpperInc bool
+	// Empty is set if the range contains no values.
+	Empty bool
+}
+
+// rangeEvalCtx is used to compare the bounds of ranges. Both bounds of a range
+// always have the same type, so their comparison does not depend on session
+// settings.
+var rangeEvalCtx = &EvalContext{}
+
+// NewDRange returns a new range of the given type with the given bounds. A
+// DNull bound is unbounded. The range is empty if it contains no values, and
+// ranges of discrete types are converted to their canonical form.
+func NewDRange(typ *types.T, lower, upper Datum, lowerInc, upperInc bool) (*DRange, error) {
+	if lower == DNull {
+		lowerInc = false
+	}
+	if upper == DNull {
+		upperInc = false
+	}
+	if lower != DNull && upper != DNull {
+		if c := lower.Compare(rangeEvalCtx, upper); c > 0 {
+			return nil, pgerror.New(pgcode.DataException,
+				"range lower bound must be less than or equal to range upper bound")
+		} else if c == 0 && !(lowerInc && upperInc) {
+			return NewDEmptyRange(typ), nil
+		}
+	}
+	d := &DRange{Typ: typ, Lower: lower, Upper: upper, LowerInc: lowerInc, UpperInc: upperInc}
+	if err := d.canonicalize(); err != nil {
+		return nil, err
+	}
+	if d.Lower != DNull && d.Upper != DNull && d.Lower.Compare(rangeEvalCtx, d.Upper) == 0 &&
+		!(d.LowerInc && d.UpperInc) {
+		return NewDEmptyRange(typ), nil
+	}
+	return d, nil
+}
+
+// NewDEmptyRange returns the empty range of the given type.
+func NewDEmptyRange(typ *types.T) *DRange {
+	return &DRange{Typ: typ, Lower: DNull, Upper: DNull, Empty: true}
+}
+
+// canonicalize converts a range of a discrete type to the [lower,upper) form.
+func (d *DRange) canonicalize() error {
+	switch d.Typ.Oid() {
+	case oid.T_int4range, oid.T_int8range:
+		if d.Lower != DNull && !d.LowerInc {
+			next, err := nextRangeInt(d.Typ, d.Lower)
+			if err != nil {
+				return err
+			}
+			d.Lower, d.LowerInc = next, true
+		}
+		if d.Upper != DNull && d.UpperInc {
+			next, err := nextRangeInt(d.Typ, d.Upper)
+			if err != nil {
+				return err
+			}
+			d.Upper, d.UpperInc = next, false
+		}
+	case oid.T_daterange:
+		// Infinite dates are left unchanged, like in Postgres.
+		if d.Lower != DNull && !d.LowerInc && !isInfiniteDate(d.Lower) {
+			if next, ok := d.Lower.Next(rangeEvalCtx); ok {
+				d.Lower, d.LowerInc = next, true
+			}
+		}
+		if d.Upper != DNull && d.UpperInc && !isInfiniteDate(d.Upper) {
+			if next, ok := d.Upper.Next(rangeEvalCtx); ok {
+				d.Upper, d.UpperInc = next, false
+			}
+		}
+	}
+	return nil
+}
+
+// nextRangeInt returns the integer that follows the given bound of an integer
+// range, or an error if it is out of range for the bounds of the range.
+func nextRangeInt(typ *types.T, d Datum) (Datum, error) {
+	i := MustBeDInt(d)
+	max := DInt(math.MaxInt64)
+	if typ.RangeContents().Width() == 32 {
+		max = math.MaxInt32
+	}
+	if i >= max {
+		return nil, pgerror.Newf(pgcode.NumericValueOutOfRange,
+			"%s out of range", typ.RangeContents().SQLString())
+	}
+	return NewDInt(i + 1), nil
+}
+
+func isInfiniteDate(d Datum) bool {
+	date := MustBeDDate(d)
+	return !date.IsFinite()
+}
+
+// InLocation returns a copy of the range in which TIMESTAMPTZ bounds are
+// converted to the given location, so that they are formatted in that
+// location. Other ranges are returned unchanged.
+func (d *DRange) InLocation(loc *time.Location) *DRange {
+	if d.Typ.Oid() != oid.T_tstzrange || d.Empty {
+		return d
+	}
+	ret := *d
+	if d.Lower != DNull {
+		ret.Lower = &DTimestampTZ{Time: MustBeDTimestampTZ(d.Lower).Time.In(loc)}
+	}
+	if d.Upper != DNull {
+		ret.Upper = &DTimestampTZ{Time: MustBeDTimestampTZ(d.Upper).Time.In(loc)}
+	}
+	return &ret
+}
+
+// AsDRange attempts to retrieve a *DRange from an Expr, returning a *DRange and
+// a flag signifying whether the assertion was successful. The function should
+// be used instead of direct type assertions wherever a *DRange wrapped by a
+// *DOidWrapper is possible.
+func AsDRange(e Expr) (*DRange, bool) {
+	switch t := e.(type) {
+	case *DRange:
+		return t, true
+	case *DOidWrapper:
+		return AsDRange(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDRange attempts to retrieve a *DRange from an Expr, panicking if the
+// assertion fails.
+func MustBeDRange(e Expr) *DRange {
+	r, ok := AsDRange(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DRange, found %T", e))
+	}
+	return r
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DRange) ResolvedType() *types.T {
+	return d.Typ
+}
+
+// Compare implements the Datum interface.
+func (d *DRange) Compare(ctx *EvalContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface. Like in Postgres, the empty
+// range sorts before all other ranges, which are ordered by their lower bound
+// and then by their upper bound.
+func (d *DRange) CompareError(ctx *EvalContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := UnwrapDatum(ctx, other).(*DRange)
+	if !ok || !d.Typ.Equivalent(v.Typ) {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	switch {
+	case d.Empty && v.Empty:
+		return 0, nil
+	case d.Empty:
+		return -1, nil
+	case v.Empty:
+		return 1, nil
+	}
+	if c := d.lowerBound().compare(v.lowerBound()); c != 0 {
+		return c, nil
+	}
+	return d.upperBound().compare(v.upperBound()), nil
+}
+
+// rangeBound is one of the bounds of a non-empty range.
+type rangeBound struct {
+	val       Datum
+	inclusive bool
+	lower     bool
+}
+
+func (d *DRange) lowerBound() rangeBound {
+	return rangeBound{val: d.Lower, inclusive: d.LowerInc, lower: true}
+}
+
+func (d *DRange) upperBound() rangeBound {
+	return rangeBound{val: d.Upper, inclusive: d.UpperInc}
+}
+
+// compare compares two range bounds, taking into account whether they are
+// unbounded, inclusive, and lower or upper bounds. For example, the exclusive
+// lower bound 1 sorts after the inclusive lower bound 1, which sorts after the
+// exclusive upper bound 1.
+func (b rangeBound) compare(o rangeBound) int {
+	if b.val == DNull && o.val == DNull {
+		if b.lower == o.lower {
+			return 0
+		}
+		if b.lower {
+			return -1
+		}
+		return 1
+	}
+	if b.val == DNull {
+		if b.lower {
+			return -1
+		}
+		return 1
+	}
+	if o.val == DNull {
+		if o.lower {
+			return 1
+		}
+		return -1
+	}
+	if c := b.val.Compare(rangeEvalCtx, o.val); c != 0 {
+		return c
+	}
+	switch {
+	case !b.inclusive && !o.inclusive:
+		if b.lower == o.lower {
+			return 0
+		}
+		if b.lower {
+			return 1
+		}
+		return -1
+	case !b.inclusive:
+		if b.lower {
+			return 1
+		}
+		return -1
+	case !o.inclusive:
+		if o.lower {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// Contains returns whether the range contains the other range. Every range
+// contains the empty range.
+func (d *DRange) Contains(other *DRange) bool {
+	if other.Empty {
+		return true
+	}
+	if d.Empty {
+		return false
+	}
+	return d.lowerBound().compare(other.lowerBound()) <= 0 &&
+		d.upperBound().compare(other.upperBound()) >= 0
+}
+
+// ContainsElem returns whether the range contains the given value, which must
+// have the type of the bounds of the range.
+func (d *DRange) ContainsElem(elem Datum) bool {
+	if d.Empty {
+		return false
+	}
+	if d.Lower != DNull {
+		c := d.Lower.Compare(rangeEvalCtx, elem)
+		if c > 0 || (c == 0 && !d.LowerInc) {
+			return false
+		}
+	}
+	if d.Upper != DNull {
+		c := d.Upper.Compare(rangeEvalCtx, elem)
+		if c < 0 || (c == 0 && !d.UpperInc) {
+			return false
+		}
+	}
+	return true
+}
+
+// Overlaps returns whether the two ranges have any values in common.
+func (d *DRange) Overlaps(other *DRange) bool {
+	if d.Empty || other.Empty {
+		return false
+	}
+	return d.lowerBound().compare(other.upperBound()) <= 0 &&
+		other.lowerBound().compare(d.upperBound()) <= 0
+}
+
+// Adjacent returns whether the two ranges are adjacent, that is, they do not
+// overlap but there are no values between them.
+func (d *DRange) Adjacent(other *DRange) bool {
+	if d.Empty || other.Empty {
+		return false
+	}
+	return boundsAdjacent(d.upperBound(), other.lowerBound()) ||
+		boundsAdjacent(other.upperBound(), d.lowerBound())
+}
+
+// boundsAdjacent returns whether the upper bound of one range is adjacent to
+// the lower bound of another. Ranges of discrete types are always in their
+// canonical form, so it is enough to check if the bounds have the same value
+// and exactly one of them is inclusive.
+func boundsAdjacent(upper, lower rangeBound) bool {
+	if upper.val == DNull || lower.val == DNull {
+		return false
+	}
+	return upper.val.Compare(rangeEvalCtx, lower.val) == 0 && upper.inclusive != lower.inclusive
+}
+
+// Prev implements the Datum interface.
+func (d *DRange) Prev(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DRange) Next(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DRange) IsMax(_ *EvalContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DRange) IsMin(_ *EvalContext) bool {
+	return d.Empty
+}
+
+// Max implements the Datum interface.
+func (d *DRange) Max(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DRange) Min(_ *EvalContext) (Datum, bool) {
+	return NewDEmptyRange(d.Typ), true
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DRange) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface. Ranges are formatted like in
+// Postgres, for example '[1,10)' or 'empty'.
+func (d *DRange) Format(ctx *FmtCtx) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	d.pgwireFormat(ctx)
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// IsComposite implements the CompositeDatum interface.
+func (d *DRange) IsComposite() bool {
+	for _, bound := range []Datum{d.Lower, d.Upper} {
+		if cdatum, ok := bound.(CompositeDatum); ok && cdatum.IsComposite() {
+			return true
+		}
+	}
+	return false
+}
+
+// Size implements the Datum interface.
+func (d *DRange) Size() uintptr {
+	return unsafe.Sizeof(*d) + d.Lower.Size() + d.Upper.Size()
+}
+
+// DTSVector is the Datum representation of the TSVector type.
+type DTSVector struct {
+	tsearch.TSVector
+}
+
+// NewDTSVector returns a new TSVector Datum.
+func NewDTSVector(v tsearch.TSVector) *DTSVector {
+	return &DTSVector{TSVector: v}
+}
+
+// ParseDTSVector attempts to parse `str` as a TSVector type.
+func ParseDTSVector(str string) (*DTSVector, error) {
+	v, err := tsearch.ParseTSVector(str)
+	if err != nil {
+		return nil, err
+	}
+	return NewDTSVector(v), nil
+}
+
+// AsDTSVector attempts to retrieve a *DTSVector from an Expr, returning a
+// *DTSVector and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DTSVector wrapped by a *DOidWrapper is possible.
+func AsDTSVector(e Expr) (*DTSVector, bool) {
+	switch t := e.(type) {
+	case *DTSVector:
+		return t, true
+	case *DOidWrapper:
+		return AsDTSVector(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDTSVector attempts to retrieve a *DTSVector from an Expr, panicking
+// if the assertion fails.
+func MustBeDTSVector(e Expr) *DTSVector {
+	v, ok := AsDTSVector(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DTSVector, found %T", e))
+	}
+	return v
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (*DTSVector) ResolvedType() *types.T {
+	return types.TSVector
+}
+
+// Compare implements the Datum interface.
+func (d *DTSVector) Compare(ctx *EvalContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DTSVector) CompareError(ctx *EvalContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := UnwrapDatum(ctx, other).(*DTSVector)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return d.TSVector.Compare(v.TSVector), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DTSVector) Prev(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DTSVector) Next(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DTSVector) IsMax(_ *EvalContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DTSVector) IsMin(_ *EvalContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DTSVector) Max(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DTSVector) Min(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DTSVector) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DTSVector) Format(ctx *FmtCtx) {
+	formatStringLike(ctx, d.TSVector.String())
+}
+
+// Size implements the Datum interface.
+func (d *DTSVector) Size() uintptr {
+	ret := unsafe.Sizeof(*d)
+	for _, t := range d.TSVector {
+		ret += unsafe.Sizeof(t) + uintptr(len(t.Lexeme)) +
+			uintptr(len(t.Positions))*unsafe.Sizeof(tsearch.Position{})
+	}
+	return ret
+}
+
+// DTSQuery is the Datum representation of the TSQuery type.
+type DTSQuery struct {
+	tsearch.TSQuery
+}
+
+// NewDTSQuery returns a new TSQuery Datum.
+func NewDTSQuery(q tsearch.TSQuery) *DTSQuery {
+	return &DTSQuery{TSQuery: q}
+}
+
+// ParseDTSQuery attempts to parse `str` as a TSQuery type.
+func ParseDTSQuery(str string) (*DTSQuery, error) {
+	q, err := tsearch.ParseTSQuery(str)
+	if err != nil {
+		return nil, err
+	}
+	return NewDTSQuery(q), nil
+}
+
+// AsDTSQuery attempts to retrieve a *DTSQuery from an Expr, returning a
+// *DTSQuery and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DTSQuery wrapped by a *DOidWrapper is possible.
+func AsDTSQuery(e Expr) (*DTSQuery, bool) {
+	switch t := e.(type) {
+	case *DTSQuery:
+		return t, true
+	case *DOidWrapper:
+		return AsDTSQuery(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDTSQuery attempts to retrieve a *DTSQuery from an Expr, panicking
+// if the assertion fails.
+func MustBeDTSQuery(e Expr) *DTSQuery {
+	q, ok := AsDTSQuery(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DTSQuery, found %T", e))
+	}
+	return q
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (*DTSQuery) ResolvedType() *types.T {
+	return types.TSQuery
+}
+
+// Compare implements the Datum interface.
+func (d *DTSQuery) Compare(ctx *EvalContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DTSQuery) CompareError(ctx *EvalContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := UnwrapDatum(ctx, other).(*DTSQuery)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return d.TSQuery.Compare(v.TSQuery), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DTSQuery) Prev(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DTSQuery) Next(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DTSQuery) IsMax(_ *EvalContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DTSQuery) IsMin(_ *EvalContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DTSQuery) Max(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DTSQuery) Min(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DTSQuery) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DTSQuery) Format(ctx *FmtCtx) {
+	formatStringLike(ctx, d.TSQuery.String())
+}
+
+// Size implements the Datum interface.
+func (d *DTSQuery) Size() uintptr {
+	ret := unsafe.Sizeof(*d)
+	for _, n := range d.TSQuery.Operands() {
+		ret += unsafe.Sizeof(*n) + uintptr(len(n.Lexeme))
+	}
+	return ret
+}
+
+// formatStringLike formats the text representation of a datum whose type has
+// no literal syntax of its own as a string literal, unless bare strings are
+// requested.
+func formatStringLike(ctx *FmtCtx, s string) {
+	if ctx.HasFlags(FmtFlags(lexbase.EncBareStrings)) {
+		ctx.WriteString(s)
+		return
+	}
+	lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, s, ctx.flags.EncodeFlags())
+}
+
 // DJSON is the JSON Datum.
 type DJSON struct{ json.JSON }
 
@@ -3671,6 +4303,12 @@ func AsJSON(
 		return json.FromString(t.UTC().Format("2006-01-02T15:04:05.999999999")), nil
 	case *DDate, *DUuid, *DOid, *DInterval, *DBytes, *DIPAddr, *DTime, *DTimeTZ, *DBitArray, *DBox2D:
 		return json.FromString(AsStringWithFlags(t, FmtBareStrings, FmtDataConversionConfig(dcc))), nil
+	case *DRange:
+		return json.FromString(AsStringWithFlags(t.InLocation(loc), FmtPgwireText, FmtDataConversionConfig(dcc))), nil
+	case *DTSVector:
+		return json.FromString(t.TSVector.String()), nil
+	case *DTSQuery:
+		return json.FromString(t.TSQuery.String()), nil
 	case *DGeometry:
 		return json.FromSpatialObject(t.Geometry.SpatialObject(), geo.DefaultGeoJSONDecimalDigits)
 	case *DGeography:
@@ -4129,6 +4767,16 @@ func (d *DTuple) makeUnique(ctx *EvalContext) {
 	d.D = d.D[:n]
 }
 
+// IsComposite implements the CompositeDatum interface.
+func (d *DTuple) IsComposite() bool {
+	for _, elem := range d.D {
+		if cdatum, ok := elem.(CompositeDatum); ok && cdatum.IsComposite() {
+			return true
+		}
+	}
+	return false
+}
+
 // Size implements the Datum interface.
 func (d *DTuple) Size() uintptr {
 	sz := unsafe.Sizeof(*d)
@@ -5555,6 +6203,12 @@ func NewDefaultDatum(evalCtx *EvalContext, t *types.T) (d Datum, err error) {
 			"%s must be set or be NULL",
 			t.Name(),
 		)
+	case types.RangeFamily:
+		return NewDEmptyRange(t), nil
+	case types.TSVectorFamily:
+		return NewDTSVector(tsearch.TSVector{}), nil
+	case types.TSQueryFamily:
+		return NewDTSQuery(tsearch.TSQuery{}), nil
 	case types.TupleFamily:
 		contents := t.TupleContents()
 		datums := make([]Datum, len(contents))
@@ -5663,6 +6317,9 @@ var baseDatumTypeSizes = map[types.Family]struct {
 	types.INetFamily:           {unsafe.Sizeof(DIPAddr{}), fixedSize},
 	types.OidFamily:            {unsafe.Sizeof(DInt(0)), fixedSize},
 	types.EnumFamily:           {unsafe.Sizeof(DEnum{}), variableSize},
+	types.RangeFamily:          {unsafe.Sizeof(DRange{}), variableSize},
+	types.TSQueryFamily:        {unsafe.Sizeof(DTSQuery{}), variableSize},
+	types.TSVectorFamily:       {unsafe.Sizeof(DTSVector{}), variableSize},
 
 	types.VoidFamily: {sz: unsafe.Sizeof(DVoid{}), variable: fixedSize},
 	// TODO(jordan,justin): This seems suspicious.
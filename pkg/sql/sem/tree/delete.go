@@ -23,6 +23,7 @@ package tree
 type Delete struct {
 	With      *With
 	Table     TableExpr
+	Using     TableExprs
 	Where     *Where
 	OrderBy   OrderBy
 	Limit     *Limit
@@ -34,6 +35,10 @@ func (node *Delete) Format(ctx *FmtCtx) {
 	ctx.FormatNode(node.With)
 	ctx.WriteString("DELETE FROM ")
 	ctx.FormatNode(node.Table)
+	if len(node.Using) > 0 {
+		ctx.WriteString(" USING ")
+		ctx.FormatNode(&node.Using)
+	}
 	if node.Where != nil {
 		ctx.WriteByte(' ')
 		ctx.FormatNode(node.Where)
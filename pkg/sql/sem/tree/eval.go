@@ -52,6 +52,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/cockroach/pkg/util/trigram"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
@@ -1710,6 +1712,20 @@ var BinOps = map[treebin.BinaryOperatorSymbol]binOpOverload{
 			},
 			Volatility: VolatilityImmutable,
 		},
+		&BinOp{
+			LeftType:   types.String,
+			RightType:  types.String,
+			ReturnType: types.Bool,
+			Fn: func(ctx *EvalContext, left Datum, right Datum) (Datum, error) {
+				// The string % string operator is the similarity operator of
+				// pg_trgm: it returns whether the trigram similarity of its
+				// arguments is at least pg_trgm.similarity_threshold.
+				threshold := ctx.SessionData().TrigramSimilarityThreshold
+				sim := trigram.Similarity(string(MustBeDString(left)), string(MustBeDString(right)))
+				return MakeDBool(DBool(sim >= threshold)), nil
+			},
+			Volatility: VolatilityStable,
+		},
 	},
 
 	treebin.Concat: {
@@ -1757,6 +1773,24 @@ var BinOps = map[treebin.BinaryOperatorSymbol]binOpOverload{
 			},
 			Volatility: VolatilityImmutable,
 		},
+		&BinOp{
+			LeftType:   types.TSVector,
+			RightType:  types.TSVector,
+			ReturnType: types.TSVector,
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				return NewDTSVector(MustBeDTSVector(left).TSVector.Concat(MustBeDTSVector(right).TSVector)), nil
+			},
+			Volatility: VolatilityImmutable,
+		},
+		&BinOp{
+			LeftType:   types.TSQuery,
+			RightType:  types.TSQuery,
+			ReturnType: types.TSQuery,
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				return NewDTSQuery(MustBeDTSQuery(left).TSQuery.Or(MustBeDTSQuery(right).TSQuery)), nil
+			},
+			Volatility: VolatilityImmutable,
+		},
 	},
 
 	// TODO(pmattis): Check that the shift is valid.
@@ -2091,8 +2125,17 @@ func cmpOpFixups(
 		panic(errors.AssertionFailedf("could not find cmp op %s(%s,%s)", op, t, t))
 	}
 
+	// Range comparisons.
+	for _, t := range types.Ranges {
+		cmpOps[treecmp.EQ] = append(cmpOps[treecmp.EQ], makeEqFn(t, t, VolatilityImmutable))
+		cmpOps[treecmp.LE] = append(cmpOps[treecmp.LE], makeLeFn(t, t, VolatilityImmutable))
+		cmpOps[treecmp.LT] = append(cmpOps[treecmp.LT], makeLtFn(t, t, VolatilityImmutable))
+		cmpOps[treecmp.IsNotDistinctFrom] = append(cmpOps[treecmp.IsNotDistinctFrom], makeIsFn(t, t, VolatilityImmutable))
+		cmpOps[treecmp.In] = append(cmpOps[treecmp.In], makeEvalTupleIn(t, VolatilityImmutable))
+	}
+
 	// Array equality comparisons.
-	for _, t := range append(types.Scalar, types.AnyEnum) {
+	for _, t := range append(append(types.Scalar, types.AnyEnum), types.Ranges...) {
 		cmpOps[treecmp.EQ] = append(cmpOps[treecmp.EQ], &CmpOp{
 			LeftType:   types.MakeArray(t),
 			RightType:  types.MakeArray(t),
@@ -2200,6 +2243,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]cmpOpOverload{
 		makeEqFn(types.TimeTZ, types.TimeTZ, VolatilityLeakProof),
 		makeEqFn(types.Timestamp, types.Timestamp, VolatilityLeakProof),
 		makeEqFn(types.TimestampTZ, types.TimestampTZ, VolatilityLeakProof),
+		makeEqFn(types.TSQuery, types.TSQuery, VolatilityImmutable),
+		makeEqFn(types.TSVector, types.TSVector, VolatilityImmutable),
 		makeEqFn(types.Uuid, types.Uuid, VolatilityLeakProof),
 		makeEqFn(types.VarBit, types.VarBit, VolatilityLeakProof),
 
@@ -2256,6 +2301,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]cmpOpOverload{
 		makeLtFn(types.TimeTZ, types.TimeTZ, VolatilityLeakProof),
 		makeLtFn(types.Timestamp, types.Timestamp, VolatilityLeakProof),
 		makeLtFn(types.TimestampTZ, types.TimestampTZ, VolatilityLeakProof),
+		makeLtFn(types.TSQuery, types.TSQuery, VolatilityImmutable),
+		makeLtFn(types.TSVector, types.TSVector, VolatilityImmutable),
 		makeLtFn(types.Uuid, types.Uuid, VolatilityLeakProof),
 		makeLtFn(types.VarBit, types.VarBit, VolatilityLeakProof),
 
@@ -2312,6 +2359,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]cmpOpOverload{
 		makeLeFn(types.TimeTZ, types.TimeTZ, VolatilityLeakProof),
 		makeLeFn(types.Timestamp, types.Timestamp, VolatilityLeakProof),
 		makeLeFn(types.TimestampTZ, types.TimestampTZ, VolatilityLeakProof),
+		makeLeFn(types.TSQuery, types.TSQuery, VolatilityImmutable),
+		makeLeFn(types.TSVector, types.TSVector, VolatilityImmutable),
 		makeLeFn(types.Uuid, types.Uuid, VolatilityLeakProof),
 		makeLeFn(types.VarBit, types.VarBit, VolatilityLeakProof),
 
@@ -2385,6 +2434,8 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]cmpOpOverload{
 		makeIsFn(types.TimeTZ, types.TimeTZ, VolatilityLeakProof),
 		makeIsFn(types.Timestamp, types.Timestamp, VolatilityLeakProof),
 		makeIsFn(types.TimestampTZ, types.TimestampTZ, VolatilityLeakProof),
+		makeIsFn(types.TSQuery, types.TSQuery, VolatilityImmutable),
+		makeIsFn(types.TSVector, types.TSVector, VolatilityImmutable),
 		makeIsFn(types.Uuid, types.Uuid, VolatilityLeakProof),
 		makeIsFn(types.VarBit, types.VarBit, VolatilityLeakProof),
 
@@ -2565,55 +2616,71 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]cmpOpOverload{
 		},
 	},
 
-	treecmp.Contains: {
-		&CmpOp{
-			LeftType:  types.AnyArray,
-			RightType: types.AnyArray,
-			Fn: func(ctx *EvalContext, left Datum, right Datum) (Datum, error) {
-				haystack := MustBeDArray(left)
-				needles := MustBeDArray(right)
-				return ArrayContains(ctx, haystack, needles)
+	treecmp.Contains: append(
+		cmpOpOverload{
+			&CmpOp{
+				LeftType:  types.AnyArray,
+				RightType: types.AnyArray,
+				Fn: func(ctx *EvalContext, left Datum, right Datum) (Datum, error) {
+					haystack := MustBeDArray(left)
+					needles := MustBeDArray(right)
+					return ArrayContains(ctx, haystack, needles)
+				},
+				Volatility: VolatilityImmutable,
 			},
-			Volatility: VolatilityImmutable,
-		},
-		&CmpOp{
-			LeftType:  types.Jsonb,
-			RightType: types.Jsonb,
-			Fn: func(ctx *EvalContext, left Datum, right Datum) (Datum, error) {
-				c, err := json.Contains(left.(*DJSON).JSON, right.(*DJSON).JSON)
-				if err != nil {
-					return nil, err
-				}
-				return MakeDBool(DBool(c)), nil
+			&CmpOp{
+				LeftType:  types.Jsonb,
+				RightType: types.Jsonb,
+				Fn: func(ctx *EvalContext, left Datum, right Datum) (Datum, error) {
+					c, err := json.Contains(left.(*DJSON).JSON, right.(*DJSON).JSON)
+					if err != nil {
+						return nil, err
+					}
+					return MakeDBool(DBool(c)), nil
+				},
+				Volatility: VolatilityImmutable,
 			},
-			Volatility: VolatilityImmutable,
 		},
-	},
+		append(
+			makeRangeComparisonOperators(func(left, right *DRange) bool {
+				return left.Contains(right)
+			}),
+			makeRangeElemContainsOperators(true /* rangeOnLeft */)...,
+		)...,
+	),
 
-	treecmp.ContainedBy: {
-		&CmpOp{
-			LeftType:  types.AnyArray,
-			RightType: types.AnyArray,
-			Fn: func(ctx *EvalContext, left Datum, right Datum) (Datum, error) {
-				needles := MustBeDArray(left)
-				haystack := MustBeDArray(right)
-				return ArrayContains(ctx, haystack, needles)
+	treecmp.ContainedBy: append(
+		cmpOpOverload{
+			&CmpOp{
+				LeftType:  types.AnyArray,
+				RightType: types.AnyArray,
+				Fn: func(ctx *EvalContext, left Datum, right Datum) (Datum, error) {
+					needles := MustBeDArray(left)
+					haystack := MustBeDArray(right)
+					return ArrayContains(ctx, haystack, needles)
+				},
+				Volatility: VolatilityImmutable,
 			},
-			Volatility: VolatilityImmutable,
-		},
-		&CmpOp{
-			LeftType:  types.Jsonb,
-			RightType: types.Jsonb,
-			Fn: func(ctx *EvalContext, left Datum, right Datum) (Datum, error) {
-				c, err := json.Contains(right.(*DJSON).JSON, left.(*DJSON).JSON)
-				if err != nil {
-					return nil, err
-				}
-				return MakeDBool(DBool(c)), nil
+			&CmpOp{
+				LeftType:  types.Jsonb,
+				RightType: types.Jsonb,
+				Fn: func(ctx *EvalContext, left Datum, right Datum) (Datum, error) {
+					c, err := json.Contains(right.(*DJSON).JSON, left.(*DJSON).JSON)
+					if err != nil {
+						return nil, err
+					}
+					return MakeDBool(DBool(c)), nil
+				},
+				Volatility: VolatilityImmutable,
 			},
-			Volatility: VolatilityImmutable,
 		},
-	},
+		append(
+			makeRangeComparisonOperators(func(left, right *DRange) bool {
+				return right.Contains(left)
+			}),
+			makeRangeElemContainsOperators(false /* rangeOnLeft */)...,
+		)...,
+	),
 	treecmp.Overlaps: append(
 		cmpOpOverload{
 			&CmpOp{
@@ -2637,12 +2704,38 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]cmpOpOverload{
 				Volatility: VolatilityImmutable,
 			},
 		},
-		makeBox2DComparisonOperators(
-			func(lhs, rhs *geo.CartesianBoundingBox) bool {
-				return lhs.Intersects(rhs)
-			},
+		append(
+			makeBox2DComparisonOperators(
+				func(lhs, rhs *geo.CartesianBoundingBox) bool {
+					return lhs.Intersects(rhs)
+				},
+			),
+			makeRangeComparisonOperators(func(left, right *DRange) bool {
+				return left.Overlaps(right)
+			})...,
 		)...,
 	),
+	treecmp.Adjacent: makeRangeComparisonOperators(func(left, right *DRange) bool {
+		return left.Adjacent(right)
+	}),
+	treecmp.TSMatches: {
+		&CmpOp{
+			LeftType:  types.TSVector,
+			RightType: types.TSQuery,
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				return MakeDBool(DBool(tsearch.Match(MustBeDTSVector(left).TSVector, MustBeDTSQuery(right).TSQuery))), nil
+			},
+			Volatility: VolatilityImmutable,
+		},
+		&CmpOp{
+			LeftType:  types.TSQuery,
+			RightType: types.TSVector,
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				return MakeDBool(DBool(tsearch.Match(MustBeDTSVector(right).TSVector, MustBeDTSQuery(left).TSQuery))), nil
+			},
+			Volatility: VolatilityImmutable,
+		},
+	},
 })
 
 const experimentalBox2DClusterSettingName = "sql.spatial.experimental_box2d_comparison_operators.enabled"
@@ -2733,6 +2826,48 @@ func makeBox2DComparisonOperators(op func(lhs, rhs *geo.CartesianBoundingBox) bo
 	}
 }
 
+// makeRangeComparisonOperators returns an overload of a comparison operator
+// between two ranges for each range type.
+func makeRangeComparisonOperators(op func(left, right *DRange) bool) cmpOpOverload {
+	ops := make(cmpOpOverload, 0, len(types.Ranges))
+	for _, t := range types.Ranges {
+		ops = append(ops, &CmpOp{
+			LeftType:  t,
+			RightType: t,
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				return MakeDBool(DBool(op(MustBeDRange(left), MustBeDRange(right)))), nil
+			},
+			Volatility: VolatilityImmutable,
+		})
+	}
+	return ops
+}
+
+// makeRangeElemContainsOperators returns the overloads of the @> (if
+// rangeOnLeft is true) or <@ (otherwise) operators between a range and a value
+// of the range's element type.
+func makeRangeElemContainsOperators(rangeOnLeft bool) cmpOpOverload {
+	ops := make(cmpOpOverload, 0, len(types.Ranges))
+	for _, t := range types.Ranges {
+		op := &CmpOp{
+			LeftType:  t,
+			RightType: t.RangeContents(),
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				return MakeDBool(DBool(MustBeDRange(left).ContainsElem(right))), nil
+			},
+			Volatility: VolatilityImmutable,
+		}
+		if !rangeOnLeft {
+			op.LeftType, op.RightType = op.RightType, op.LeftType
+			op.Fn = func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				return MakeDBool(DBool(MustBeDRange(right).ContainsElem(left))), nil
+			}
+		}
+		ops = append(ops, op)
+	}
+	return ops
+}
+
 // This map contains the inverses for operators in the CmpOps map that have
 // inverses.
 var cmpOpsInverse map[treecmp.ComparisonOperatorSymbol]treecmp.ComparisonOperatorSymbol
@@ -3318,6 +3453,11 @@ type EvalPlanner interface {
 	// it is invalid.
 	RepairTTLScheduledJobForTable(ctx context.Context, tableID int64) error
 
+	// SendNotification sends a notification on the given channel, which is
+	// delivered to the listening sessions once the current transaction
+	// commits.
+	SendNotification(ctx context.Context, channel, payload string) error
+
 	// QueryRowEx executes the supplied SQL statement and returns a single row, or
 	// nil if no row is found, or an error if more that one row is returned.
 	//
@@ -4769,6 +4909,21 @@ func (t *DBox2D) Eval(_ *EvalContext) (Datum, error) {
 	return t, nil
 }
 
+// Eval implements the TypedExpr interface.
+func (t *DRange) Eval(_ *EvalContext) (Datum, error) {
+	return t, nil
+}
+
+// Eval implements the TypedExpr interface.
+func (t *DTSVector) Eval(_ *EvalContext) (Datum, error) {
+	return t, nil
+}
+
+// Eval implements the TypedExpr interface.
+func (t *DTSQuery) Eval(_ *EvalContext) (Datum, error) {
+	return t, nil
+}
+
 // Eval implements the TypedExpr interface.
 func (t *DGeography) Eval(_ *EvalContext) (Datum, error) {
 	return t, nil
@@ -174,6 +174,35 @@ func NewFunctionDefinition(
 	}
 }
 
+// MergeUDFOverloads returns a function definition that contains the overloads
+// of the given builtin function definition, which may be nil, followed by the
+// given overloads of user-defined functions.
+func MergeUDFOverloads(
+	name string, builtin *FunctionDefinition, udfs []*Overload,
+) *FunctionDefinition {
+	fd := &FunctionDefinition{Name: name}
+	if builtin != nil {
+		fd.FunctionProperties = builtin.FunctionProperties
+		fd.Definition = make([]overloadImpl, 0, len(builtin.Definition)+len(udfs))
+		fd.Definition = append(fd.Definition, builtin.Definition...)
+	}
+	for _, o := range udfs {
+		fd.Definition = append(fd.Definition, o)
+	}
+	return fd
+}
+
+// hasUDFs returns true if the function definition contains overloads of
+// user-defined functions.
+func (fd *FunctionDefinition) hasUDFs() bool {
+	for _, o := range fd.Definition {
+		if o.(*Overload).IsUDF {
+			return true
+		}
+	}
+	return false
+}
+
 // FunDefs holds pre-allocated FunctionDefinition instances
 // for every builtin function. Initialized by builtins.init().
 var FunDefs map[string]*FunctionDefinition
@@ -11,6 +11,7 @@
 package tree
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
@@ -40,16 +41,35 @@ func (fn *ResolvableFunctionReference) Format(ctx *FmtCtx) {
 }
 func (fn *ResolvableFunctionReference) String() string { return AsString(fn) }
 
+// FunctionReferenceResolver is the interface that provides the ability to
+// resolve function names to definitions that include user-defined functions.
+type FunctionReferenceResolver interface {
+	// ResolveFunction resolves the given function name. The returned definition
+	// contains the builtin overloads of the function, if any, and the overloads
+	// of the user-defined functions with that name that are visible on the
+	// search path.
+	ResolveFunction(
+		ctx context.Context, name *UnresolvedName, path sessiondata.SearchPath,
+	) (*FunctionDefinition, error)
+}
+
 // Resolve checks if the function name is already resolved and
-// resolves it as necessary.
+// resolves it as necessary. If resolver is nil, only builtin functions
+// can be resolved.
 func (fn *ResolvableFunctionReference) Resolve(
-	searchPath sessiondata.SearchPath,
+	ctx context.Context, searchPath sessiondata.SearchPath, resolver FunctionReferenceResolver,
 ) (*FunctionDefinition, error) {
 	switch t := fn.FunctionReference.(type) {
 	case *FunctionDefinition:
 		return t, nil
 	case *UnresolvedName:
-		fd, err := t.ResolveFunction(searchPath)
+		var fd *FunctionDefinition
+		var err error
+		if resolver != nil {
+			fd, err = resolver.ResolveFunction(ctx, t, searchPath)
+		} else {
+			fd, err = t.ResolveFunction(searchPath)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -72,6 +92,23 @@ func WrapFunction(n string) ResolvableFunctionReference {
 	return ResolvableFunctionReference{fd}
 }
 
+// WrapFunctionOverload returns a ResolvableFunctionReference for the function
+// with the given name and properties which the given overload belongs to.
+// User-defined functions are not present in FunDefs, so a definition which
+// only contains the given overload is created for them.
+func WrapFunctionOverload(
+	n string, props *FunctionProperties, o *Overload,
+) ResolvableFunctionReference {
+	if !o.IsUDF {
+		return WrapFunction(n)
+	}
+	return ResolvableFunctionReference{&FunctionDefinition{
+		Name:               n,
+		Definition:         []overloadImpl{o},
+		FunctionProperties: *props,
+	}}
+}
+
 // FunctionReference is the common interface to UnresolvedName and QualifiedFunctionName.
 type FunctionReference interface {
 	fmt.Stringer
@@ -37,6 +37,7 @@ type TargetList struct {
 	Tables    TablePatterns
 	TenantID  TenantID
 	Types     []*UnresolvedObjectName
+	Functions FuncObjs
 	// If the target is for all tables in a set of schemas.
 	AllTablesInSchema bool
 	// Whether the target is only system users and roles_members table
@@ -71,6 +72,9 @@ func (tl *TargetList) Format(ctx *FmtCtx) {
 			}
 			ctx.FormatNode(typ)
 		}
+	} else if tl.Functions != nil {
+		ctx.WriteString("FUNCTION ")
+		ctx.FormatNode(&tl.Functions)
 	} else {
 		ctx.WriteString("TABLE ")
 		ctx.FormatNode(&tl.Tables)
@@ -128,19 +128,14 @@ type QualifiedNameResolver interface {
 	CurrentDatabase() string
 }
 
-// ResolveFunction transforms an UnresolvedName to a FunctionDefinition.
+// ResolveFunction transforms an UnresolvedName to the FunctionDefinition of
+// a builtin function.
 //
-// Function resolution currently takes a "short path" using the
-// assumption that there are no stored functions in the database. That
-// is, only functions in the (virtual) global namespace and virtual
-// schemas can be used. This in turn implies that the current
-// database does not matter and no resolver is needed.
-//
-// TODO(whoever): this needs to be revisited when there can be stored functions.
-// When that is the case, function names must be first normalized to e.g.
-// TableName (or whatever an object name will be called by then)
-// and then undergo regular name resolution via ResolveExisting(). When
-// that happens, the following function can be removed.
+// Only functions in the (virtual) global namespace and virtual schemas are
+// considered, so the current database does not matter and no resolver is
+// needed. User-defined functions are stored in the database and are resolved
+// by a FunctionReferenceResolver, which uses this function to find the builtin
+// overloads with the same name.
 func (n *UnresolvedName) ResolveFunction(
 	searchPath sessiondata.SearchPath,
 ) (*FunctionDefinition, error) {
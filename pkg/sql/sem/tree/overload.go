@@ -42,7 +42,7 @@ const (
 	SubstringStringIntInt
 )
 
-// Overload is one of the overloads of a built-in function.
+// Overload is one of the overloads of a built-in or user-defined function.
 // Each FunctionDefinition may contain one or more overloads.
 type Overload struct {
 	Types      TypeList
@@ -111,6 +111,27 @@ type Overload struct {
 	// DistSQL. One example is when the type information for function arguments
 	// cannot be recovered.
 	DistsqlBlocklist bool
+
+	// IsUDF is set to true when this is an overload of a user-defined function.
+	// The parameter names of the function are the names in Types, which is an
+	// ArgTypes, and Oid is the OID of the function.
+	IsUDF bool
+
+	// Body is the SQL statement that is the body of a user-defined function,
+	// with references to the parameters of the function replaced by the
+	// placeholders $1, $2, etc.
+	Body string
+
+	// CalledOnNullInput is set to true if a user-defined function is evaluated
+	// when any of its arguments is NULL. Otherwise, the function returns NULL
+	// without being evaluated. The NULL input behavior of builtin functions is
+	// determined by FunctionProperties.NullableArgs instead.
+	CalledOnNullInput bool
+
+	// UDFVersion is the version of the descriptor of a user-defined function.
+	// It is used to detect plans that depend on a stale version of the
+	// function.
+	UDFVersion uint64
 }
 
 // params implements the overloadImpl interface.
@@ -0,0 +1,138 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+func makeMalformedRangeError(s, detail string) error {
+	return errors.WithDetail(
+		pgerror.Newf(pgcode.InvalidTextRepresentation, "malformed range literal: %q", s),
+		detail,
+	)
+}
+
+// rangeParseState holds the state of the parser of a range literal.
+type rangeParseState struct {
+	s   string
+	pos int
+}
+
+// parseBound parses the next bound of the range literal, stopping at the first
+// unquoted comma or closing bracket. Double quotes can be used to quote special
+// characters, and a double quote inside a quoted section is written as two
+// double quotes. A backslash quotes the character that follows it. The
+// returned bool is false for an empty unquoted bound, which is unbounded.
+func (p *rangeParseState) parseBound() (string, bool, error) {
+	var sb strings.Builder
+	inQuote := false
+	hasValue := false
+	for p.pos < len(p.s) {
+		ch := p.s[p.pos]
+		switch {
+		case ch == '\\':
+			p.pos++
+			if p.pos >= len(p.s) {
+				return "", false, makeMalformedRangeError(p.s, "Unexpected end of input.")
+			}
+			sb.WriteByte(p.s[p.pos])
+		case ch == '"':
+			if inQuote && p.pos+1 < len(p.s) && p.s[p.pos+1] == '"' {
+				sb.WriteByte('"')
+				p.pos++
+			} else {
+				inQuote = !inQuote
+			}
+		case !inQuote && (ch == ',' || ch == ')' || ch == ']'):
+			return sb.String(), hasValue, nil
+		case !inQuote && (ch == '(' || ch == '['):
+			return "", false, makeMalformedRangeError(p.s, "Unexpected bracket.")
+		default:
+			sb.WriteByte(ch)
+		}
+		hasValue = true
+		p.pos++
+	}
+	if inQuote {
+		return "", false, makeMalformedRangeError(p.s, "Unexpected end of input.")
+	}
+	return sb.String(), hasValue, nil
+}
+
+// ParseDRangeFromString parses the string-form of a range into a range of the
+// given type, such as '[1,10)', '(,"2020-01-01 10:00:00"]' or 'empty'.
+//
+// The dependsOnContext return value indicates if we had to consult the
+// ParseTimeContext (either for the time or the local timezone).
+func ParseDRangeFromString(
+	ctx ParseTimeContext, s string, t *types.T,
+) (_ *DRange, dependsOnContext bool, _ error) {
+	in := strings.TrimSpace(s)
+	if strings.EqualFold(in, "empty") {
+		return NewDEmptyRange(t), false, nil
+	}
+	p := rangeParseState{s: in}
+	if len(in) == 0 || (in[0] != '[' && in[0] != '(') {
+		return nil, false, makeMalformedRangeError(s, "Missing left parenthesis or bracket.")
+	}
+	lowerInc := in[0] == '['
+	p.pos++
+
+	lowerStr, hasLower, err := p.parseBound()
+	if err != nil {
+		return nil, false, err
+	}
+	if p.pos >= len(in) || in[p.pos] != ',' {
+		return nil, false, makeMalformedRangeError(s, "Missing comma after lower bound.")
+	}
+	p.pos++
+
+	upperStr, hasUpper, err := p.parseBound()
+	if err != nil {
+		return nil, false, err
+	}
+	if p.pos >= len(in) {
+		return nil, false, makeMalformedRangeError(s, "Missing right parenthesis or bracket.")
+	}
+	if in[p.pos] == ',' {
+		return nil, false, makeMalformedRangeError(s, "Too many commas.")
+	}
+	upperInc := in[p.pos] == ']'
+	p.pos++
+	if p.pos != len(in) {
+		return nil, false, makeMalformedRangeError(s, "Junk after right parenthesis or bracket.")
+	}
+
+	parseBound := func(str string, ok bool) (Datum, error) {
+		if !ok {
+			return DNull, nil
+		}
+		d, boundDependsOnContext, err := ParseAndRequireString(t.RangeContents(), str, ctx)
+		dependsOnContext = dependsOnContext || boundDependsOnContext
+		return d, err
+	}
+	lower, err := parseBound(lowerStr, hasLower)
+	if err != nil {
+		return nil, false, err
+	}
+	upper, err := parseBound(upperStr, hasUpper)
+	if err != nil {
+		return nil, false, err
+	}
+	r, err := NewDRange(t, lower, upper, lowerInc, upperInc)
+	return r, dependsOnContext, err
+}
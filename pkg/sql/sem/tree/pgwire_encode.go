@@ -148,7 +148,52 @@ func (d *DArray) pgwireFormat(ctx *FmtCtx) {
 	}
 }
 
-var tupleQuoteSet, arrayQuoteSet asciiSet
+func (d *DRange) pgwireFormat(ctx *FmtCtx) {
+	// Ranges are printed like in Postgres: the bounds are printed in "postgres
+	// mode", and quoted if they contain any characters that are special to the
+	// range syntax. In quoted bounds, the double quote and backslash characters
+	// are *doubled*, like in tuples.
+	if d.Empty {
+		ctx.WriteString("empty")
+		return
+	}
+	if d.LowerInc {
+		ctx.WriteByte('[')
+	} else {
+		ctx.WriteByte('(')
+	}
+	d.pgwireFormatBound(ctx, d.Lower)
+	ctx.WriteByte(',')
+	d.pgwireFormatBound(ctx, d.Upper)
+	if d.UpperInc {
+		ctx.WriteByte(']')
+	} else {
+		ctx.WriteByte(')')
+	}
+}
+
+func (d *DRange) pgwireFormatBound(ctx *FmtCtx, bound Datum) {
+	if bound == DNull {
+		// Unbounded sides are printed as the empty string.
+		return
+	}
+	s := AsStringWithFlags(bound, FmtPgwireText, FmtDataConversionConfig(ctx.dataConversionConfig))
+	quote := s == "" || rangeQuoteSet.in(s)
+	if quote {
+		ctx.WriteByte('"')
+	}
+	for _, r := range s {
+		if r == '"' || r == '\\' {
+			ctx.WriteByte(byte(r))
+		}
+		ctx.WriteRune(r)
+	}
+	if quote {
+		ctx.WriteByte('"')
+	}
+}
+
+var tupleQuoteSet, arrayQuoteSet, rangeQuoteSet asciiSet
 
 func init() {
 	var ok bool
@@ -160,6 +205,10 @@ func init() {
 	if !ok {
 		panic("array asciiset")
 	}
+	rangeQuoteSet, ok = makeASCIISet(" \t\v\f\r\n()[],\"\\")
+	if !ok {
+		panic("range asciiset")
+	}
 }
 
 func pgwireQuoteStringInTuple(in string) bool {
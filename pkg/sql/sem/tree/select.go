@@ -692,11 +692,64 @@ func (node *GroupBy) Format(ctx *FmtCtx) {
 	prefix := "GROUP BY "
 	for _, n := range *node {
 		ctx.WriteString(prefix)
-		ctx.FormatNode(n)
+		formatGroupByItem(ctx, n)
 		prefix = ", "
 	}
 }
 
+// formatGroupByItem formats an item of a GROUP BY clause or of a GroupingSet.
+func formatGroupByItem(ctx *FmtCtx, e Expr) {
+	if gs, ok := e.(*GroupingSet); ok {
+		// A GroupingSet is not a scalar expression, so it must not be enclosed
+		// in parentheses.
+		gs.Format(ctx)
+		return
+	}
+	ctx.FormatNode(e)
+}
+
+// GroupingSetType is the type of a GroupingSet.
+type GroupingSetType int
+
+// GroupingSetType values.
+const (
+	RollupGroupingSet GroupingSetType = iota
+	CubeGroupingSet
+	GroupingSetsGroupingSet
+)
+
+var groupingSetTypeName = [...]string{
+	RollupGroupingSet:       "ROLLUP",
+	CubeGroupingSet:         "CUBE",
+	GroupingSetsGroupingSet: "GROUPING SETS",
+}
+
+func (t GroupingSetType) String() string {
+	return groupingSetTypeName[t]
+}
+
+// GroupingSet represents a ROLLUP, CUBE or GROUPING SETS item in a GROUP BY
+// clause. Each element of Exprs is either an expression, a tuple of
+// expressions that is treated as a single unit (the empty tuple denotes the
+// empty grouping set), or, for GROUPING SETS, a nested GroupingSet.
+type GroupingSet struct {
+	Type  GroupingSetType
+	Exprs Exprs
+}
+
+// Format implements the NodeFormatter interface.
+func (node *GroupingSet) Format(ctx *FmtCtx) {
+	ctx.WriteString(node.Type.String())
+	ctx.WriteString(" (")
+	for i, e := range node.Exprs {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		formatGroupByItem(ctx, e)
+	}
+	ctx.WriteByte(')')
+}
+
 // DistinctOn represents a DISTINCT ON clause.
 type DistinctOn []Expr
 
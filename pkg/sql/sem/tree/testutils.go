@@ -84,6 +84,18 @@ func SampleDatum(t *types.T) Datum {
 	case types.Box2DFamily:
 		b := geo.NewCartesianBoundingBox().AddPoint(1, 2).AddPoint(3, 4)
 		return NewDBox2D(*b)
+	case types.RangeFamily:
+		r, err := NewDRange(t, SampleDatum(t.RangeContents()), DNull, true /* lowerInc */, false /* upperInc */)
+		if err != nil {
+			panic(err)
+		}
+		return r
+	case types.TSVectorFamily:
+		v, _ := ParseDTSVector("'fat':2 'cat':3")
+		return v
+	case types.TSQueryFamily:
+		q, _ := ParseDTSQuery("fat & cat")
+		return q
 	case types.GeographyFamily:
 		return NewDGeography(geo.MustParseGeographyFromEWKB([]byte("\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\xf0\x3f\x00\x00\x00\x00\x00\x00\xf0\x3f")))
 	case types.GeometryFamily:
@@ -181,7 +181,7 @@ func (ctx *FmtCtx) FormatTypeReference(ref ResolvableTypeReference) {
 				ctx.WriteByte('_')
 				return
 			} else if ctx.HasFlags(fmtStaticallyFormatUserDefinedTypes) {
-				idRef := OIDTypeReference{OID: t.Oid()}
+				idRef := OIDTypeReference{OID: t.UserDefinedOID()}
 				ctx.WriteString(idRef.SQLString())
 				return
 			}
@@ -0,0 +1,102 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+type setConstraintsNode struct {
+	n *tree.SetConstraints
+}
+
+// SetConstraints sets the mode of deferrable constraints for the current
+// transaction.
+// Privileges: None.
+func (p *planner) SetConstraints(ctx context.Context, n *tree.SetConstraints) (planNode, error) {
+	if !n.All {
+		if err := p.checkDeferrableConstraints(ctx, n.Names); err != nil {
+			return nil, err
+		}
+	}
+	return &setConstraintsNode{n: n}, nil
+}
+
+// checkDeferrableConstraints verifies that every name refers to a deferrable
+// constraint of a table in the current database.
+func (p *planner) checkDeferrableConstraints(ctx context.Context, names tree.NameList) error {
+	db, err := p.Descriptors().GetImmutableDatabaseByName(
+		ctx, p.Txn(), p.CurrentDatabase(), tree.DatabaseLookupFlags{Required: true},
+	)
+	if err != nil {
+		return err
+	}
+	tableDescs, err := p.Descriptors().GetAllTableDescriptorsInDatabase(ctx, p.Txn(), db.GetID())
+	if err != nil {
+		return err
+	}
+	// deferrable maps the names of the constraints in the database to whether
+	// they are deferrable. Constraint names are only unique per table, so a
+	// name is deferrable if any constraint with that name is.
+	deferrable := make(map[string]bool)
+	for _, tbl := range tableDescs {
+		if tbl.Dropped() {
+			continue
+		}
+		info, err := tbl.GetConstraintInfo()
+		if err != nil {
+			return err
+		}
+		for name, detail := range info {
+			isDeferrable := (detail.FK != nil && detail.FK.Deferrable) ||
+				(detail.UniqueWithoutIndexConstraint != nil && detail.UniqueWithoutIndexConstraint.Deferrable)
+			deferrable[name] = deferrable[name] || isDeferrable
+		}
+	}
+	for _, name := range names {
+		isDeferrable, ok := deferrable[string(name)]
+		if !ok {
+			return pgerror.Newf(pgcode.UndefinedObject, "constraint %q does not exist", name)
+		}
+		if !isDeferrable {
+			return pgerror.Newf(pgcode.WrongObjectType, "constraint %q is not deferrable", name)
+		}
+	}
+	return nil
+}
+
+func (n *setConstraintsNode) startExec(params runParams) error {
+	dc := params.p.extendedEvalCtx.DeferredConstraints
+	if dc == nil {
+		return nil
+	}
+	dc.setMode(n.n.Names, n.n.Deferred)
+	if n.n.Deferred {
+		return nil
+	}
+	// Constraints that become immediate are checked right away.
+	return dc.validatePending(
+		params.ctx,
+		params.ExecCfg().InternalExecutorFactory,
+		params.SessionData(),
+		params.p.Txn(),
+		params.p.Descriptors(),
+		true, /* onlyImmediate */
+	)
+}
+
+func (*setConstraintsNode) Next(runParams) (bool, error) { return false, nil }
+func (*setConstraintsNode) Values() tree.Datums          { return nil }
+func (*setConstraintsNode) Close(context.Context)        {}
@@ -186,6 +186,12 @@ func ShowCreateTable(
 		return "", err
 	}
 
+	showTriggers(tn, desc, &f.Buffer)
+
+	if err := showRowLevelSecurity(tn, desc, &f.Buffer); err != nil {
+		return "", err
+	}
+
 	if !displayOptions.IgnoreComments {
 		if err := showComments(tn, desc, selectComment(ctx, p, desc.GetID()), &f.Buffer); err != nil {
 			return "", err
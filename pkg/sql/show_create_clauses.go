@@ -249,6 +249,52 @@ func formatViewQueryTypesForDisplay(
 	return newStmt.String(), nil
 }
 
+// showTriggers prints out the CREATE TRIGGER statements sufficient to recreate
+// a table's triggers.
+func showTriggers(tn *tree.TableName, table catalog.TableDescriptor, buf *bytes.Buffer) {
+	triggers := table.GetTriggers()
+	if len(triggers) == 0 {
+		return
+	}
+	f := tree.NewFmtCtx(tree.FmtSimple)
+	for i := range triggers {
+		f.WriteString(";\n")
+		f.FormatNode(makeCreateTrigger(&triggers[i], *tn))
+	}
+	buf.WriteString(f.CloseAndGetString())
+}
+
+// showRowLevelSecurity prints out the ALTER TABLE and CREATE POLICY
+// statements sufficient to recreate the row-level security configuration of
+// the given table.
+func showRowLevelSecurity(
+	tn *tree.TableName, table catalog.TableDescriptor, buf *bytes.Buffer,
+) error {
+	f := tree.NewFmtCtx(tree.FmtSimple)
+	var cmds tree.AlterTableCmds
+	if table.GetRowLevelSecurity() {
+		cmds = append(cmds, &tree.AlterTableRowLevelSecurity{Action: tree.RowLevelSecurityEnable})
+	}
+	if table.GetForceRowLevelSecurity() {
+		cmds = append(cmds, &tree.AlterTableRowLevelSecurity{Action: tree.RowLevelSecurityForce})
+	}
+	if len(cmds) > 0 {
+		f.WriteString(";\n")
+		f.FormatNode(&tree.AlterTable{Table: tn.ToUnresolvedObjectName(), Cmds: cmds})
+	}
+	policies := table.GetPolicies()
+	for i := range policies {
+		n, err := makeCreatePolicy(&policies[i], *tn)
+		if err != nil {
+			return err
+		}
+		f.WriteString(";\n")
+		f.FormatNode(n)
+	}
+	buf.WriteString(f.CloseAndGetString())
+	return nil
+}
+
 // showComments prints out the COMMENT statements sufficient to populate a
 // table's comments, including its index and column comments.
 func showComments(
@@ -382,6 +428,12 @@ func showForeignKeyConstraint(
 		buf.WriteString(" ON UPDATE ")
 		buf.WriteString(fk.OnUpdate.String())
 	}
+	if fk.Deferrable {
+		buf.WriteString(" DEFERRABLE")
+		if fk.InitiallyDeferred {
+			buf.WriteString(" INITIALLY DEFERRED")
+		}
+	}
 	if fk.Validity != descpb.ConstraintValidity_Validated {
 		buf.WriteString(" NOT VALID")
 	}
@@ -621,13 +673,25 @@ func showConstraintClause(
 			formatQuoteNames(&f.Buffer, c.Name)
 			f.WriteString(" ")
 		}
-		f.WriteString("UNIQUE WITHOUT INDEX (")
 		colNames, err := desc.NamesForColumnIDs(c.ColumnIDs)
 		if err != nil {
 			return err
 		}
+		if c.IsExclusion() {
+			if err := formatExclusionConstraint(ctx, f, desc, c, colNames, semaCtx, sessionData); err != nil {
+				return err
+			}
+			continue
+		}
+		f.WriteString("UNIQUE WITHOUT INDEX (")
 		f.WriteString(strings.Join(colNames, ", "))
 		f.WriteString(")")
+		if c.Deferrable {
+			f.WriteString(" DEFERRABLE")
+			if c.InitiallyDeferred {
+				f.WriteString(" INITIALLY DEFERRED")
+			}
+		}
 		if c.IsPartial() {
 			f.WriteString(" WHERE ")
 			pred, err := schemaexpr.FormatExprForDisplay(ctx, desc, c.Predicate, semaCtx, sessionData, tree.FmtParsable)
@@ -643,3 +707,45 @@ func showConstraintClause(
 	f.WriteString("\n)")
 	return nil
 }
+
+// formatExclusionConstraint formats the body of an exclusion constraint, e.g.
+// EXCLUDE (a WITH =, b WITH &&) WHERE (a > 0).
+func formatExclusionConstraint(
+	ctx context.Context,
+	f *tree.FmtCtx,
+	desc catalog.TableDescriptor,
+	c *descpb.UniqueWithoutIndexConstraint,
+	colNames []string,
+	semaCtx *tree.SemaContext,
+	sessionData *sessiondata.SessionData,
+) error {
+	f.WriteString("EXCLUDE (")
+	for i := range colNames {
+		if i > 0 {
+			f.WriteString(", ")
+		}
+		f.WriteString(colNames[i])
+		f.WriteString(" WITH ")
+		f.WriteString(c.ExclusionOperators[i])
+	}
+	f.WriteString(")")
+	if c.IsPartial() {
+		pred, err := schemaexpr.FormatExprForDisplay(ctx, desc, c.Predicate, semaCtx, sessionData, tree.FmtParsable)
+		if err != nil {
+			return err
+		}
+		f.WriteString(" WHERE (")
+		f.WriteString(pred)
+		f.WriteString(")")
+	}
+	if c.Deferrable {
+		f.WriteString(" DEFERRABLE")
+		if c.InitiallyDeferred {
+			f.WriteString(" INITIALLY DEFERRED")
+		}
+	}
+	if c.Validity != descpb.ConstraintValidity_Validated {
+		f.WriteString(" NOT VALID")
+	}
+	return nil
+}
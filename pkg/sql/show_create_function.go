@@ -0,0 +1,77 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+var showCreateFunctionColumns = colinfo.ResultColumns{
+	{Name: "function_name", Typ: types.String},
+	{Name: "create_statement", Typ: types.String},
+}
+
+// ShowCreateFunction returns the CREATE FUNCTION statements of all overloads
+// of a user-defined function.
+// Privileges: None.
+func (p *planner) ShowCreateFunction(
+	ctx context.Context, n *tree.ShowCreateFunction,
+) (planNode, error) {
+	return &delayedNode{
+		name:    tree.AsString(n),
+		columns: showCreateFunctionColumns,
+
+		constructor: func(ctx context.Context, p *planner) (planNode, error) {
+			var dbName, scName string
+			if n.Name.NumParts > 1 {
+				scName = n.Name.Parts[1]
+			}
+			if n.Name.NumParts > 2 {
+				dbName = n.Name.Parts[2]
+			}
+			sc, fns, err := p.getFunctionOverloadsByName(
+				ctx, dbName, scName, n.Name.Object(), p.CurrentSearchPath(),
+			)
+			if err != nil {
+				return nil, err
+			}
+			if len(fns) == 0 {
+				return nil, pgerror.Newf(pgcode.UndefinedFunction,
+					"function %s does not exist", tree.AsString(n.Name))
+			}
+
+			name, err := tree.NewUnresolvedObjectName(
+				2 /* numParts */, [3]string{n.Name.Object(), sc.GetName()}, 0, /* annotationIdx */
+			)
+			if err != nil {
+				return nil, err
+			}
+			v := p.newContainerValuesNode(showCreateFunctionColumns, len(fns))
+			for _, fn := range fns {
+				stmt := tree.AsString(makeCreateFunction(fn, name))
+				if _, err := v.rows.AddRow(ctx, tree.Datums{
+					tree.NewDString(fn.GetName()),
+					tree.NewDString(stmt),
+				}); err != nil {
+					v.Close(ctx)
+					return nil, err
+				}
+			}
+			return v, nil
+		},
+	}, nil
+}
@@ -12,17 +12,24 @@ package sql
 
 import (
 	"context"
+	"math"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowcontainer"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 )
@@ -30,20 +37,16 @@ import (
 // DeclareCursor implements the DECLARE statement.
 // See https://www.postgresql.org/docs/current/sql-declare.html for details.
 func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (planNode, error) {
-	if s.Hold {
-		return nil, unimplemented.NewWithIssue(77101, "DECLARE CURSOR WITH HOLD")
-	}
 	if s.Binary {
 		return nil, unimplemented.NewWithIssue(77099, "DECLARE BINARY CURSOR")
 	}
-	if s.Scroll == tree.Scroll {
-		return nil, unimplemented.NewWithIssue(77102, "DECLARE SCROLL CURSOR")
-	}
 
 	return &delayedNode{
 		name: s.String(),
 		constructor: func(ctx context.Context, p *planner) (_ planNode, _ error) {
-			if p.extendedEvalCtx.TxnImplicit {
+			// WITH HOLD cursors outlive their transaction, so they can be declared
+			// in an implicit transaction too, like in Postgres.
+			if p.extendedEvalCtx.TxnImplicit && !s.Hold {
 				return nil, pgerror.Newf(pgcode.NoActiveSQLTransaction, "DECLARE CURSOR can only be used in transaction blocks")
 			}
 
@@ -93,17 +96,27 @@ func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (pla
 			}
 			inputState := p.txn.GetLeafTxnInputState(ctx)
 			cursor := &sqlCursor{
-				InternalRows: rows,
-				readSeqNum:   inputState.ReadSeqNum,
-				txn:          p.txn,
-				statement:    statement,
-				created:      timeutil.Now(),
+				rows:       rows,
+				cols:       rows.Types(),
+				readSeqNum: inputState.ReadSeqNum,
+				txn:        p.txn,
+				statement:  statement,
+				created:    timeutil.Now(),
+				hold:       s.Hold,
+				scroll:     s.Scroll == tree.Scroll,
+			}
+			if cursor.hold || cursor.scroll {
+				// SCROLL cursors need to revisit the rows they already returned, and
+				// WITH HOLD cursors are materialized when their transaction commits,
+				// so both keep the rows they read in a buffer.
+				cursor.buf = &cursorBuffer{}
+				cursor.buf.init(ctx, cursor.cols, p.ExtendedEvalContext())
 			}
 			if err := p.sqlCursors.addCursor(cursorName, cursor); err != nil {
 				// This case shouldn't happen because cursor names are scoped to a session,
 				// and sessions can't have more than one statement running at once. But
 				// let's be diligent and clean up if it somehow does happen anyway.
-				_ = cursor.Close()
+				_ = cursor.Close(ctx)
 				return nil, err
 			}
 			return newZeroNode(nil /* columns */), nil
@@ -122,17 +135,31 @@ func (p *planner) FetchCursor(
 	if err != nil {
 		return nil, err
 	}
-	if s.Count < 0 || s.FetchType == tree.FetchBackwardAll {
+	if !cursor.scroll && (s.Count < 0 || s.FetchType == tree.FetchBackwardAll ||
+		s.FetchType == tree.FetchLast) {
 		return nil, errBackwardScan
 	}
 	node := &fetchNode{
-		n:         s.Count,
 		fetchType: s.FetchType,
 		cursor:    cursor,
 		isMove:    isMove,
 	}
-	if s.FetchType != tree.FetchNormal {
-		node.n = 0
+	switch s.FetchType {
+	case tree.FetchNormal:
+		node.n = s.Count
+		if s.Count < 0 {
+			node.n = -s.Count
+			node.backward = true
+		} else if s.Count == 0 {
+			// FETCH 0 re-fetches the current row.
+			node.fetchType = tree.FetchRelative
+		}
+	case tree.FetchAll:
+		node.n = math.MaxInt64
+	case tree.FetchBackwardAll:
+		node.n = math.MaxInt64
+		node.backward = true
+	default:
 		node.offset = s.Count
 	}
 	return node, nil
@@ -140,10 +167,12 @@ func (p *planner) FetchCursor(
 
 type fetchNode struct {
 	cursor *sqlCursor
-	// n is the number of rows requested.
+	// n is the number of rows requested, when in normal or ALL mode.
 	n int64
-	// offset is the number of rows to read first, when in relative or absolute
+	// backward is set if the rows are fetched backward, when in normal or ALL
 	// mode.
+	backward bool
+	// offset is the position to move to, when in relative or absolute mode.
 	offset    int64
 	fetchType tree.FetchType
 	// isMove is true if this is a MOVE statement, which is identical to a FETCH
@@ -159,6 +188,10 @@ type fetchNode struct {
 }
 
 func (f *fetchNode) startExec(params runParams) error {
+	if f.cursor.txn == nil {
+		// The cursor was materialized; it no longer reads from its transaction.
+		return nil
+	}
 	state := f.cursor.txn.GetLeafTxnInputState(params.ctx)
 	// We need to make sure that we're reading at the same read sequence number
 	// that we had when we created the cursor, to preserve the "sensitivity"
@@ -169,62 +202,51 @@ func (f *fetchNode) startExec(params runParams) error {
 }
 
 func (f *fetchNode) Next(params runParams) (bool, error) {
-	if f.fetchType == tree.FetchAll {
-		return f.cursor.Next(params.ctx)
-	}
-
-	if !f.seeked {
-		// FIRST, LAST, ABSOLUTE, and RELATIVE require seeking before returning
-		// values. Do that first.
-		f.seeked = true
-		switch f.fetchType {
-		case tree.FetchFirst:
-			switch f.cursor.curRow {
-			case 0:
-				_, err := f.cursor.Next(params.ctx)
-				return true, err
-			case 1:
-				return true, nil
-			}
-			return false, errBackwardScan
-		case tree.FetchLast:
-			return false, errBackwardScan
-		case tree.FetchAbsolute:
-			if f.cursor.curRow > f.offset {
-				return false, errBackwardScan
-			}
-			for f.cursor.curRow < f.offset {
-				more, err := f.cursor.Next(params.ctx)
-				if !more || err != nil {
-					return more, err
-				}
-			}
-			return true, nil
-		case tree.FetchRelative:
-			for i := int64(0); i < f.offset; i++ {
-				more, err := f.cursor.Next(params.ctx)
-				if !more || err != nil {
-					return more, err
-				}
-			}
-			return true, nil
+	switch f.fetchType {
+	case tree.FetchNormal, tree.FetchAll, tree.FetchBackwardAll:
+		if f.n <= 0 {
+			return false, nil
 		}
+		f.n--
+		if f.backward {
+			return f.cursor.seek(params.ctx, f.cursor.curRow-1)
+		}
+		return f.cursor.seek(params.ctx, f.cursor.curRow+1)
 	}
-	if f.n <= 0 {
+
+	// FIRST, LAST, ABSOLUTE, and RELATIVE move the cursor and return the row
+	// it ends up on, if any.
+	if f.seeked {
 		return false, nil
 	}
-	f.n--
-	return f.cursor.Next(params.ctx)
+	f.seeked = true
+	switch f.fetchType {
+	case tree.FetchFirst:
+		return f.cursor.seek(params.ctx, 1)
+	case tree.FetchLast:
+		return f.cursor.seekFromEnd(params.ctx, -1)
+	case tree.FetchAbsolute:
+		if f.offset < 0 {
+			return f.cursor.seekFromEnd(params.ctx, f.offset)
+		}
+		return f.cursor.seek(params.ctx, f.offset)
+	case tree.FetchRelative:
+		return f.cursor.seek(params.ctx, f.cursor.curRow+f.offset)
+	}
+	return false, errors.AssertionFailedf("unknown fetch type %d", f.fetchType)
 }
 
 func (f fetchNode) Values() tree.Datums {
-	return f.cursor.Cur()
+	return f.cursor.cur
 }
 
 func (f fetchNode) Close(ctx context.Context) {
 	// We explicitly do not pass through the Close to our InternalRows, because
 	// running FETCH on a CURSOR does not close it.
 
+	if f.cursor.txn == nil {
+		return
+	}
 	// Reset the transaction's read sequence number to what it was before the
 	// fetch began, so that subsequent reads in the transaction can still see
 	// writes from that transaction.
@@ -233,46 +255,245 @@ func (f fetchNode) Close(ctx context.Context) {
 	}
 }
 
-// CloseCursor implements the FETCH statement.
+// CloseCursor implements the CLOSE statement.
 // See https://www.postgresql.org/docs/current/sql-close.html for details.
 func (p *planner) CloseCursor(ctx context.Context, n *tree.CloseCursor) (planNode, error) {
 	return &delayedNode{
 		name: n.String(),
 		constructor: func(ctx context.Context, p *planner) (planNode, error) {
-			return newZeroNode(nil /* columns */), p.sqlCursors.closeCursor(n.Name.String())
+			if n.All {
+				p.sqlCursors.closeAll(ctx)
+				return newZeroNode(nil /* columns */), nil
+			}
+			return newZeroNode(nil /* columns */), p.sqlCursors.closeCursor(ctx, n.Name.String())
 		},
 	}, nil
 }
 
 type sqlCursor struct {
-	sqlutil.InternalRows
+	// rows returns the rows of the cursor's query which were not read yet. It
+	// is nil once a WITH HOLD cursor was materialized.
+	rows sqlutil.InternalRows
+	cols colinfo.ResultColumns
 	// txn is the transaction object that the internal executor for this cursor
-	// is running with.
+	// is running with. It is nil once a WITH HOLD cursor was materialized.
 	txn *kv.Txn
 	// readSeqNum is the sequence number of the transaction that the cursor was
 	// initialized with.
 	readSeqNum enginepb.TxnSeq
 	statement  string
 	created    time.Time
-	curRow     int64
+
+	// hold is set for cursors declared WITH HOLD, which remain open after the
+	// transaction that declared them commits.
+	hold bool
+	// committed is set once the transaction that declared a WITH HOLD cursor
+	// committed.
+	committed bool
+	// scroll is set for cursors declared SCROLL, which can move backward.
+	scroll bool
+	// buf contains all the rows read so far for SCROLL and WITH HOLD cursors,
+	// and is nil for other cursors.
+	buf *cursorBuffer
+
+	// numRead is the number of rows read from rows so far.
+	numRead int64
+	// curRow is the position of the cursor: 0 before the first row, n on the
+	// n-th row, and one past the number of rows once it moved past the last
+	// row.
+	curRow int64
+	// cur is the row the cursor is positioned on, if any.
+	cur tree.Datums
+}
+
+// Types returns the result columns of the cursor's query.
+func (c *sqlCursor) Types() colinfo.ResultColumns {
+	return c.cols
+}
+
+// readRow reads the next row of the cursor's query and adds it to the buffer,
+// if any. It returns nil once all rows were read.
+func (c *sqlCursor) readRow(ctx context.Context) (tree.Datums, error) {
+	if c.rows == nil {
+		return nil, nil
+	}
+	more, err := c.rows.Next(ctx)
+	if err != nil || !more {
+		return nil, err
+	}
+	row := c.rows.Cur()
+	if c.buf != nil {
+		if err := c.buf.addRow(ctx, row); err != nil {
+			return nil, err
+		}
+	}
+	c.numRead++
+	return row, nil
+}
+
+// seek moves the cursor to the given position and returns whether it is
+// positioned on a row. Positions before the first row and past the last row
+// move the cursor before the first row and after the last row respectively.
+func (c *sqlCursor) seek(ctx context.Context, pos int64) (bool, error) {
+	if pos == c.curRow {
+		return c.cur != nil, nil
+	}
+	if pos < c.curRow && !c.scroll {
+		return false, errBackwardScan
+	}
+	if pos <= 0 {
+		c.curRow, c.cur = 0, nil
+		return false, nil
+	}
+	for c.numRead < pos {
+		row, err := c.readRow(ctx)
+		if err != nil {
+			return false, err
+		}
+		if row == nil {
+			c.curRow, c.cur = c.numRead+1, nil
+			return false, nil
+		}
+		if c.numRead == pos {
+			c.curRow, c.cur = pos, row
+			return true, nil
+		}
+	}
+	// The row was already read, so it is in the buffer: only buffered cursors
+	// can move to a row before the last one read.
+	row, err := c.buf.getRow(ctx, pos-1)
+	if err != nil {
+		return false, err
+	}
+	c.curRow, c.cur = pos, row
+	return true, nil
+}
+
+// seekFromEnd moves the cursor to the given position relative to the end of
+// the rows, where -1 is the last row, and returns whether it is positioned on a
+// row.
+func (c *sqlCursor) seekFromEnd(ctx context.Context, offset int64) (bool, error) {
+	for {
+		row, err := c.readRow(ctx)
+		if err != nil {
+			return false, err
+		}
+		if row == nil {
+			break
+		}
+	}
+	return c.seek(ctx, c.numRead+1+offset)
 }
 
-// Next implements the InternalRows interface.
-func (s *sqlCursor) Next(ctx context.Context) (bool, error) {
-	more, err := s.InternalRows.Next(ctx)
-	if err == nil {
-		s.curRow++
+// materialize reads the remaining rows of a WITH HOLD cursor into its buffer,
+// so that the cursor no longer depends on its transaction. It must be called
+// before the transaction commits.
+func (c *sqlCursor) materialize(ctx context.Context) error {
+	// Like FETCH, read at the sequence number at which the cursor was declared.
+	origTxnSeqNum := c.txn.GetLeafTxnInputState(ctx).ReadSeqNum
+	if err := c.txn.SetReadSeqNum(c.readSeqNum); err != nil {
+		return err
 	}
-	return more, err
+	for {
+		row, err := c.readRow(ctx)
+		if err != nil {
+			return err
+		}
+		if row == nil {
+			break
+		}
+	}
+	if err := c.txn.SetReadSeqNum(origTxnSeqNum); err != nil {
+		return err
+	}
+	err := c.rows.Close()
+	c.rows = nil
+	c.txn = nil
+	return err
+}
+
+// Close releases the resources of the cursor.
+func (c *sqlCursor) Close(ctx context.Context) error {
+	var err error
+	if c.rows != nil {
+		err = c.rows.Close()
+		c.rows = nil
+	}
+	if c.buf != nil {
+		c.buf.close(ctx)
+		c.buf = nil
+	}
+	return err
+}
+
+// cursorBuffer is a disk-backed buffer of the rows read by a SCROLL or WITH
+// HOLD cursor, which supports random access. Its memory is accounted for
+// against the session, since WITH HOLD cursors outlive their transaction.
+type cursorBuffer struct {
+	memMonitor  *mon.BytesMonitor
+	diskMonitor *mon.BytesMonitor
+	rows        *rowcontainer.DiskBackedIndexedRowContainer
+	scratch     rowenc.EncDatumRow
+	numCols     int
+	// appended is set when rows were added since the last getRow, in which
+	// case the disk iterator of rows needs to be reset to see them.
+	appended bool
+}
+
+func (b *cursorBuffer) init(
+	ctx context.Context, cols colinfo.ResultColumns, evalCtx *extendedEvalContext,
+) {
+	distSQLCfg := &evalCtx.DistSQLPlanner.distSQLSrv.ServerConfig
+	b.memMonitor = execinfra.NewLimitedMonitorNoFlowCtx(
+		ctx, evalCtx.SessionMon, distSQLCfg, evalCtx.SessionData(), "sql-cursor-limited",
+	)
+	b.diskMonitor = execinfra.NewMonitor(ctx, distSQLCfg.ParentDiskMonitor, "sql-cursor-disk")
+	typs := make([]*types.T, len(cols))
+	for i := range cols {
+		typs[i] = cols[i].Typ
+	}
+	b.rows = rowcontainer.NewDiskBackedIndexedRowContainer(
+		colinfo.NoOrdering, typs, &evalCtx.EvalContext,
+		distSQLCfg.TempStorage, b.memMonitor, b.diskMonitor,
+	)
+	b.scratch = make(rowenc.EncDatumRow, len(typs))
+	b.numCols = len(typs)
+}
+
+func (b *cursorBuffer) addRow(ctx context.Context, row tree.Datums) error {
+	for i := range row {
+		b.scratch[i].Datum = row[i]
+	}
+	b.appended = true
+	return b.rows.AddRow(ctx, b.scratch)
+}
+
+// getRow returns the row at the given zero-based index.
+func (b *cursorBuffer) getRow(ctx context.Context, idx int64) (tree.Datums, error) {
+	if b.appended {
+		b.rows.ResetIterator()
+		b.appended = false
+	}
+	row, err := b.rows.GetRow(ctx, int(idx))
+	if err != nil {
+		return nil, err
+	}
+	return row.GetDatums(0, b.numCols)
+}
+
+func (b *cursorBuffer) close(ctx context.Context) {
+	b.rows.Close(ctx)
+	b.memMonitor.Stop(ctx)
+	b.diskMonitor.Stop(ctx)
 }
 
 // sqlCursors contains a set of active cursors for a session.
 type sqlCursors interface {
 	// closeAll closes all cursors in the set.
-	closeAll()
+	closeAll(context.Context)
 	// closeCursor closes the named cursor, returning an error if that cursor
 	// didn't exist in the set.
-	closeCursor(string) error
+	closeCursor(context.Context, string) error
 	// getCursor returns the named cursor, returning an error if that cursor
 	// didn't exist in the set.
 	getCursor(string) (*sqlCursor, error)
@@ -288,23 +509,51 @@ type cursorMap struct {
 	cursors map[string]*sqlCursor
 }
 
-func (c *cursorMap) closeAll() {
+func (c *cursorMap) closeAll(ctx context.Context) {
 	for _, c := range c.cursors {
-		_ = c.Close()
+		_ = c.Close(ctx)
 	}
 	c.cursors = nil
 }
 
-func (c *cursorMap) closeCursor(s string) error {
+func (c *cursorMap) closeCursor(ctx context.Context, s string) error {
 	cursor, ok := c.cursors[s]
 	if !ok {
 		return pgerror.Newf(pgcode.InvalidCursorName, "cursor %q does not exist", s)
 	}
-	err := cursor.Close()
+	err := cursor.Close(ctx)
 	delete(c.cursors, s)
 	return err
 }
 
+// materializeHeld materializes the WITH HOLD cursors declared in the current
+// transaction. It must be called before the transaction commits.
+func (c *cursorMap) materializeHeld(ctx context.Context) error {
+	for _, cursor := range c.cursors {
+		if cursor.hold && cursor.rows != nil {
+			if err := cursor.materialize(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// finishTxn closes the cursors which do not outlive the transaction that just
+// finished: all of them except for the WITH HOLD cursors which were declared
+// in an earlier transaction, or which were materialized by this one if it
+// committed.
+func (c *cursorMap) finishTxn(ctx context.Context, committed bool) {
+	for name, cursor := range c.cursors {
+		if cursor.hold && cursor.rows == nil && (committed || cursor.committed) {
+			cursor.committed = true
+			continue
+		}
+		_ = cursor.Close(ctx)
+		delete(c.cursors, name)
+	}
+}
+
 func (c *cursorMap) getCursor(s string) (*sqlCursor, error) {
 	cursor, ok := c.cursors[s]
 	if !ok {
@@ -334,12 +583,12 @@ type connExCursorAccessor struct {
 	ex *connExecutor
 }
 
-func (c connExCursorAccessor) closeAll() {
-	c.ex.extraTxnState.sqlCursors.closeAll()
+func (c connExCursorAccessor) closeAll(ctx context.Context) {
+	c.ex.extraTxnState.sqlCursors.closeAll(ctx)
 }
 
-func (c connExCursorAccessor) closeCursor(s string) error {
-	return c.ex.extraTxnState.sqlCursors.closeCursor(s)
+func (c connExCursorAccessor) closeCursor(ctx context.Context, s string) error {
+	return c.ex.extraTxnState.sqlCursors.closeCursor(ctx, s)
 }
 
 func (c connExCursorAccessor) getCursor(s string) (*sqlCursor, error) {
@@ -363,9 +612,12 @@ func (p *planner) checkNoConflictingCursors(stmt tree.Statement) error {
 	// We could improve this by matching the memo metadata's list of dependent
 	// schema objects in each open cursor with the objects being changed in the
 	// schema change.
-	if len(p.sqlCursors.list()) > 0 {
-		return unimplemented.NewWithIssue(74608, "cannot run schema change "+
-			"in a transaction with open DECLARE cursors")
+	for _, c := range p.sqlCursors.list() {
+		// Materialized WITH HOLD cursors no longer read from the schema.
+		if c.rows != nil {
+			return unimplemented.NewWithIssue(74608, "cannot run schema change "+
+				"in a transaction with open DECLARE cursors")
+		}
 	}
 	return nil
 }
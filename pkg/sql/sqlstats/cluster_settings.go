@@ -68,6 +68,15 @@ var SampleLogicalPlans = settings.RegisterBoolSetting(
 	true,
 ).WithPublic()
 
+// SampleIndexRecommendations specifies whether we generate index
+// recommendations for each fingerprint when its logical plan is sampled.
+var SampleIndexRecommendations = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"sql.metrics.statement_details.index_recommendation_collection.enabled",
+	"generate index recommendations for each fingerprint when its logical plan is sampled",
+	true,
+).WithPublic()
+
 // LogicalPlanCollectionPeriod specifies the interval between collections of
 // logical plans for each fingerprint.
 var LogicalPlanCollectionPeriod = settings.RegisterDurationSetting(
@@ -290,6 +290,7 @@ func (s *innerStmtStats) jsonFields() jsonFields {
 		{"rowsWritten", (*numericStats)(&s.RowsWritten)},
 		{"nodes", (*int64Array)(&s.Nodes)},
 		{"planGists", (*stringArray)(&s.PlanGists)},
+		{"indexRecommendations", (*stringArray)(&s.IndexRecommendations)},
 	}
 }
 
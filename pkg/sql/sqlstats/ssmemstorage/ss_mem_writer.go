@@ -106,6 +106,8 @@ func (s *Container) RecordStatement(
 		stats.mu.data.SensitiveInfo.MostRecentPlanDescription = *value.Plan
 		stats.mu.data.SensitiveInfo.MostRecentPlanTimestamp = s.getTimeNow()
 		s.setLogicalPlanLastSampled(statementKey.sampledPlanKey, stats.mu.data.SensitiveInfo.MostRecentPlanTimestamp)
+		// Index recommendations are only generated when the plan is sampled.
+		stats.mu.data.IndexRecommendations = value.IndexRecommendations
 	}
 	if value.AutoRetryCount == 0 {
 		stats.mu.data.FirstAttemptCount++
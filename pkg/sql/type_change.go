@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/lease"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
@@ -97,6 +98,33 @@ func findTransitioningMembers(desc *typedesc.Mutable) ([][]byte, bool) {
 	return transitioningMembers, beingDropped
 }
 
+// findAddingDomainConstraints returns the names of the constraints of a
+// domain that are added in the current txn and need to be validated. The
+// constraints of a domain created in the current txn do not need validation,
+// as there cannot be any values of the domain yet.
+func findAddingDomainConstraints(desc *typedesc.Mutable) []string {
+	if desc.Kind != descpb.TypeDescriptor_DOMAIN || desc.IsNew() {
+		return nil
+	}
+	var adding []string
+	for _, c := range desc.DomainConstraints {
+		if c.Validity != descpb.ConstraintValidity_Validating {
+			continue
+		}
+		found := false
+		for _, clusterConstraint := range desc.ClusterVersion.DomainConstraints {
+			if c.Name == clusterConstraint.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			adding = append(adding, c.Name)
+		}
+	}
+	return adding
+}
+
 // writeTypeSchemaChange should be called on a mutated type descriptor to ensure that
 // the descriptor gets written to a batch, as well as ensuring that a job is
 // created to perform the schema change on the type.
@@ -106,11 +134,13 @@ func (p *planner) writeTypeSchemaChange(
 	// Check if there is a cached specification for this type, otherwise create one.
 	record, recordExists := p.extendedEvalCtx.SchemaChangeJobRecords[typeDesc.ID]
 	transitioningMembers, beingDropped := findTransitioningMembers(typeDesc)
+	addingDomainConstraints := findAddingDomainConstraints(typeDesc)
 	if recordExists {
 		// Update it.
 		newDetails := jobspb.TypeSchemaChangeDetails{
-			TypeID:               typeDesc.ID,
-			TransitioningMembers: transitioningMembers,
+			TypeID:                  typeDesc.ID,
+			TransitioningMembers:    transitioningMembers,
+			AddingDomainConstraints: addingDomainConstraints,
 		}
 		record.Details = newDetails
 		record.AppendDescription(jobDesc)
@@ -135,8 +165,9 @@ func (p *planner) writeTypeSchemaChange(
 			Username:      p.User(),
 			DescriptorIDs: descpb.IDs{typeDesc.ID},
 			Details: jobspb.TypeSchemaChangeDetails{
-				TypeID:               typeDesc.ID,
-				TransitioningMembers: transitioningMembers,
+				TypeID:                  typeDesc.ID,
+				TransitioningMembers:    transitioningMembers,
+				AddingDomainConstraints: addingDomainConstraints,
 			},
 			Progress: jobspb.TypeSchemaChangeProgress{},
 			// Type change jobs in general are not cancelable, unless they include
@@ -169,7 +200,11 @@ type typeSchemaChanger struct {
 	// for a typeSchemaChanger. This is used to group transitions together and
 	// ensure proper rollback semantics on job failure.
 	transitioningMembers [][]byte
-	execCfg              *ExecutorConfig
+	// addingDomainConstraints is a list of the names of the domain constraints
+	// that are added in the job created for a typeSchemaChanger. If validation
+	// fails, they are removed from the domain.
+	addingDomainConstraints []string
+	execCfg                 *ExecutorConfig
 }
 
 // TypeSchemaChangerTestingKnobs contains testing knobs for the typeSchemaChanger.
@@ -494,6 +529,20 @@ func (t *typeSchemaChanger) exec(ctx context.Context) error {
 		}
 	}
 
+	// Validate the existing values of a domain against the constraints being
+	// validated, and promote the constraints to VALIDATED.
+	if typeDesc.GetKind() == descpb.TypeDescriptor_DOMAIN && !typeDesc.Dropped() {
+		validated, err := t.validateDomainConstraints(ctx)
+		if err != nil {
+			return err
+		}
+		if validated {
+			if err := refreshTypeDescriptorLeases(ctx, leaseMgr, typeDesc); err != nil {
+				return err
+			}
+		}
+	}
+
 	// If the type is being dropped, remove the descriptor here.
 	if typeDesc.Dropped() {
 		if err := t.execCfg.DB.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
@@ -602,6 +651,163 @@ func (t *typeSchemaChanger) cleanupEnumValues(ctx context.Context) error {
 	return DescsTxn(ctx, t.execCfg, cleanup)
 }
 
+// validateDomainConstraints checks that the existing values of the domain
+// satisfy the constraints of the domain being validated, and promotes these
+// constraints to VALIDATED. It returns whether any constraint was promoted.
+func (t *typeSchemaChanger) validateDomainConstraints(ctx context.Context) (bool, error) {
+	// The validation is done in a separate txn to the one that mutates the
+	// descriptor, as it can take arbitrarily long.
+	var toPromote []string
+	validate := func(ctx context.Context, txn *kv.Txn, descsCol *descs.Collection) error {
+		toPromote = toPromote[:0]
+		typeDesc, err := descsCol.GetMutableTypeVersionByID(ctx, txn, t.typeID)
+		if err != nil {
+			return err
+		}
+		for i := range typeDesc.DomainConstraints {
+			c := &typeDesc.DomainConstraints[i]
+			if c.Validity != descpb.ConstraintValidity_Validating {
+				continue
+			}
+			if err := t.canValidateDomainConstraint(ctx, typeDesc, txn, c, descsCol); err != nil {
+				return err
+			}
+			toPromote = append(toPromote, c.Name)
+		}
+		return nil
+	}
+	if err := DescsTxn(ctx, t.execCfg, validate); err != nil {
+		return false, err
+	}
+	if len(toPromote) == 0 {
+		return false, nil
+	}
+
+	run := func(ctx context.Context, txn *kv.Txn, descsCol *descs.Collection) error {
+		typeDesc, err := descsCol.GetMutableTypeVersionByID(ctx, txn, t.typeID)
+		if err != nil {
+			return err
+		}
+		for _, name := range toPromote {
+			// The constraint may have been dropped concurrently.
+			if c := typeDesc.FindDomainConstraint(name); c != nil &&
+				c.Validity == descpb.ConstraintValidity_Validating {
+				c.Validity = descpb.ConstraintValidity_Validated
+			}
+		}
+		return descsCol.WriteDesc(ctx, true /* kvTrace */, typeDesc, txn)
+	}
+	return true, DescsTxn(ctx, t.execCfg, run)
+}
+
+// canValidateDomainConstraint checks that the values of all the columns of
+// the referencing tables that are of the given domain satisfy the given
+// constraint.
+func (t *typeSchemaChanger) canValidateDomainConstraint(
+	ctx context.Context,
+	typeDesc *typedesc.Mutable,
+	txn *kv.Txn,
+	c *descpb.TypeDescriptor_DomainConstraint,
+	descsCol *descs.Collection,
+) error {
+	constraintExpr, err := parser.ParseExpr(c.Expr)
+	if err != nil {
+		return err
+	}
+	const validationErr = "could not validate domain constraint %q"
+	for _, id := range typeDesc.ReferencingDescriptorIDs {
+		desc, err := descsCol.GetImmutableTableByID(ctx, txn, id, tree.ObjectLookupFlags{
+			CommonLookupFlags: tree.CommonLookupFlags{
+				AvoidLeased: true,
+				Required:    true,
+			},
+		})
+		if err != nil {
+			return errors.Wrapf(err, validationErr, c.Name)
+		}
+		if desc.IsView() {
+			continue
+		}
+		for _, col := range desc.PublicColumns() {
+			if !col.GetType().IsDomain() {
+				continue
+			}
+			tid, err := typedesc.GetUserDefinedTypeDescID(col.GetType())
+			if err != nil {
+				return err
+			}
+			if tid != typeDesc.ID {
+				continue
+			}
+
+			// Find a row whose value violates the constraint, which is the case
+			// only if the constraint evaluates to false.
+			colName := col.ColName()
+			pred, err := schemaexpr.ReplaceDomainValue(
+				constraintExpr, &tree.ColumnItem{ColumnName: colName},
+			)
+			if err != nil {
+				return err
+			}
+			query := fmt.Sprintf(
+				"SELECT 1 FROM [%d AS t] WHERE (%s) = false LIMIT 1",
+				desc.GetID(), tree.AsStringWithFlags(pred, tree.FmtSerializable),
+			)
+			override := sessiondata.InternalExecutorOverride{
+				User: security.RootUserName(),
+			}
+			row, err := t.execCfg.InternalExecutor.QueryRowEx(
+				ctx, "validate-domain-constraint", txn, override, query,
+			)
+			if err != nil {
+				return errors.Wrapf(err, validationErr, c.Name)
+			}
+			if len(row) > 0 {
+				return pgerror.Newf(pgcode.CheckViolation,
+					"column %q of table %q contains values that violate the new constraint",
+					colName, desc.GetName())
+			}
+		}
+	}
+	return nil
+}
+
+// cleanupDomainConstraints performs cleanup if the validation of the
+// constraints of a domain fails. In particular, constraints that were being
+// added in the job are removed, and the other constraints being validated are
+// reverted to UNVALIDATED.
+func (t *typeSchemaChanger) cleanupDomainConstraints(ctx context.Context) error {
+	cleanup := func(ctx context.Context, txn *kv.Txn, descsCol *descs.Collection) error {
+		typeDesc, err := descsCol.GetMutableTypeVersionByID(ctx, txn, t.typeID)
+		if err != nil {
+			return err
+		}
+		if typeDesc.Kind != descpb.TypeDescriptor_DOMAIN {
+			return nil
+		}
+		changed := false
+		for _, name := range t.addingDomainConstraints {
+			if c := typeDesc.FindDomainConstraint(name); c != nil &&
+				c.Validity == descpb.ConstraintValidity_Validating {
+				typeDesc.RemoveDomainConstraint(name)
+				changed = true
+			}
+		}
+		for i := range typeDesc.DomainConstraints {
+			if c := &typeDesc.DomainConstraints[i]; c.Validity == descpb.ConstraintValidity_Validating {
+				c.Validity = descpb.ConstraintValidity_Unvalidated
+				changed = true
+			}
+		}
+		// No cleanup required.
+		if !changed {
+			return nil
+		}
+		return descsCol.WriteDesc(ctx, true /* kvTrace */, typeDesc, txn)
+	}
+	return DescsTxn(ctx, t.execCfg, cleanup)
+}
+
 // convertToSQLStringRepresentation takes an array of bytes (the physical
 // representation of an enum) and converts it into a string that can be used
 // in a SQL predicate.
@@ -1009,7 +1215,7 @@ func findUsagesOfEnumValueInPartitioning(
 		if !typT.UserDefined() {
 			continue
 		}
-		id, err := typedesc.UserDefinedTypeOIDToID(typT.Oid())
+		id, err := typedesc.GetUserDefinedTypeDescID(typT)
 		if err != nil {
 			return false, errors.WithAssertionFailure(err)
 		}
@@ -1290,9 +1496,10 @@ func (t *typeChangeResumer) Resume(ctx context.Context, execCtx interface{}) err
 		}
 	}
 	tc := &typeSchemaChanger{
-		typeID:               t.job.Details().(jobspb.TypeSchemaChangeDetails).TypeID,
-		transitioningMembers: t.job.Details().(jobspb.TypeSchemaChangeDetails).TransitioningMembers,
-		execCfg:              p.ExecCfg(),
+		typeID:                  t.job.Details().(jobspb.TypeSchemaChangeDetails).TypeID,
+		transitioningMembers:    t.job.Details().(jobspb.TypeSchemaChangeDetails).TransitioningMembers,
+		addingDomainConstraints: t.job.Details().(jobspb.TypeSchemaChangeDetails).AddingDomainConstraints,
+		execCfg:                 p.ExecCfg(),
 	}
 	return tc.execWithRetry(ctx)
 }
@@ -1301,9 +1508,10 @@ func (t *typeChangeResumer) Resume(ctx context.Context, execCtx interface{}) err
 func (t *typeChangeResumer) OnFailOrCancel(ctx context.Context, execCtx interface{}) error {
 	// If the job failed, just try again to clean up any draining names.
 	tc := &typeSchemaChanger{
-		typeID:               t.job.Details().(jobspb.TypeSchemaChangeDetails).TypeID,
-		transitioningMembers: t.job.Details().(jobspb.TypeSchemaChangeDetails).TransitioningMembers,
-		execCfg:              execCtx.(JobExecContext).ExecCfg(),
+		typeID:                  t.job.Details().(jobspb.TypeSchemaChangeDetails).TypeID,
+		transitioningMembers:    t.job.Details().(jobspb.TypeSchemaChangeDetails).TransitioningMembers,
+		addingDomainConstraints: t.job.Details().(jobspb.TypeSchemaChangeDetails).AddingDomainConstraints,
+		execCfg:                 execCtx.(JobExecContext).ExecCfg(),
 	}
 
 	if rollbackErr := func() error {
@@ -1311,6 +1519,10 @@ func (t *typeChangeResumer) OnFailOrCancel(ctx context.Context, execCtx interfac
 			return err
 		}
 
+		if err := tc.cleanupDomainConstraints(ctx); err != nil {
+			return err
+		}
+
 		if err := drainNamesForDescriptor(
 			ctx, tc.typeID, tc.execCfg.CollectionFactory, tc.execCfg.DB,
 			tc.execCfg.InternalExecutor, tc.execCfg.Codec,
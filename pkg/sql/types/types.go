@@ -196,6 +196,9 @@ type UserDefinedTypeMetadata struct {
 
 	// enumData is non-nil iff the metadata is for an ENUM type.
 	EnumData *EnumMetadata
+
+	// DomainData is non-nil iff the metadata is for a DOMAIN type.
+	DomainData *DomainMetadata
 }
 
 // EnumMetadata is metadata about an ENUM needed for evaluation.
@@ -214,6 +217,28 @@ type EnumMetadata struct {
 	//  should occur, if at all.
 }
 
+// DomainMetadata is metadata about a DOMAIN needed to enforce the constraints
+// of the domain on values of the domain type.
+type DomainMetadata struct {
+	// DefaultExpr is the serialized default expression of the domain, or the
+	// empty string if the domain has no default.
+	DefaultExpr string
+	// Constraints are the constraints that values of the domain must satisfy.
+	Constraints []DomainConstraint
+}
+
+// DomainConstraint is a constraint on the values of a DOMAIN.
+type DomainConstraint struct {
+	// Name is the name of the constraint.
+	Name string
+	// Expr is the serialized boolean expression of the constraint, which refers
+	// to the value being checked as VALUE.
+	Expr string
+	// NotNull is true if the constraint is the NOT NULL constraint of the
+	// domain, in which case Expr is VALUE IS NOT NULL.
+	NotNull bool
+}
+
 func (e *EnumMetadata) debugString() string {
 	return fmt.Sprintf(
 		"PhysicalReps: %v; LogicalReps: %s",
@@ -454,6 +479,49 @@ var (
 		},
 	}
 
+	// Int4Range is the type of a range of 32-bit integers. Its bounds are
+	// canonicalized to the [lower,upper) form.
+	Int4Range = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_int4range, Locale: &emptyLocale}}
+
+	// Int8Range is the type of a range of 64-bit integers. Its bounds are
+	// canonicalized to the [lower,upper) form.
+	Int8Range = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_int8range, Locale: &emptyLocale}}
+
+	// NumRange is the type of a range of decimals.
+	NumRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_numrange, Locale: &emptyLocale}}
+
+	// TSRange is the type of a range of timestamps without time zone.
+	TSRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_tsrange, Locale: &emptyLocale}}
+
+	// TSTZRange is the type of a range of timestamps with time zone.
+	TSTZRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_tstzrange, Locale: &emptyLocale}}
+
+	// DateRange is the type of a range of dates. Its bounds are canonicalized
+	// to the [lower,upper) form.
+	DateRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_daterange, Locale: &emptyLocale}}
+
+	// TSVector is the type of a document that was normalized for full-text
+	// search, which is a sorted list of lexemes with their positions. For
+	// example:
+	//
+	//   'cat':3 'fat':2A,4
+	//
+	TSVector = &T{InternalType: InternalType{
+		Family: TSVectorFamily, Oid: oid.T_tsvector, Locale: &emptyLocale}}
+
+	// TSQuery is the type of a full-text search query. For example:
+	//
+	//   'fat' & ( 'rat' | 'cat':* )
+	//
+	TSQuery = &T{InternalType: InternalType{
+		Family: TSQueryFamily, Oid: oid.T_tsquery, Locale: &emptyLocale}}
+
 	// Void is the type representing void.
 	Void = &T{
 		InternalType: InternalType{
@@ -504,6 +572,16 @@ var (
 		VarBit,
 	}
 
+	// Ranges contains all the built-in range types.
+	Ranges = []*T{
+		Int4Range,
+		Int8Range,
+		NumRange,
+		TSRange,
+		TSTZRange,
+		DateRange,
+	}
+
 	// Any is a special type used only during static analysis as a wildcard type
 	// that matches any other type, including scalar, array, and tuple types.
 	// Execution-time values should never have this type. As an example of its
@@ -524,6 +602,12 @@ var (
 	AnyEnum = &T{InternalType: InternalType{
 		Family: EnumFamily, Locale: &emptyLocale, Oid: oid.T_anyenum}}
 
+	// AnyRange is a special type only used during static analysis as a wildcard
+	// type that matches any range type. Execution-time values should never have
+	// this type.
+	AnyRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Locale: &emptyLocale, Oid: oid.T_anyrange}}
+
 	// AnyTuple is a special type used only during static analysis as a wildcard
 	// type that matches a tuple with any number of fields of any type (including
 	// tuple types). Execution-time values should never have this type.
@@ -1097,6 +1181,30 @@ func MakeEnum(typeOID, arrayTypeOID oid.Oid) *T {
 	}}
 }
 
+// MakeComposite constructs a new instance of a composite type, which is a
+// TupleFamily type with the given field types and labels and the given stable
+// type ID. Note that it does not hydrate cached fields on the type.
+func MakeComposite(typeOID, arrayTypeOID oid.Oid, contents []*T, labels []string) *T {
+	typ := MakeLabeledTuple(contents, labels)
+	typ.InternalType.Oid = typeOID
+	typ.InternalType.UDTMetadata = &PersistentUserDefinedTypeMetadata{
+		ArrayTypeOID: arrayTypeOID,
+	}
+	return typ
+}
+
+// MakeDomain constructs a new instance of a domain type over the given base
+// type. The resulting type has the family, width, precision and OID of the
+// base type, and records the OID of the domain. Note that it does not hydrate
+// cached fields on the type.
+func MakeDomain(base *T, domainOID oid.Oid) *T {
+	internalType := base.InternalType
+	internalType.UDTMetadata = &PersistentUserDefinedTypeMetadata{
+		DomainTypeOID: domainOID,
+	}
+	return &T{InternalType: internalType}
+}
+
 // MakeArray constructs a new instance of an ArrayFamily type with the given
 // element type (which may itself be an ArrayFamily type).
 func MakeArray(typ *T) *T {
@@ -1326,6 +1434,29 @@ func (t *T) ArrayContents() *T {
 	return t.InternalType.ArrayContents
 }
 
+// RangeContents returns the type of the bounds of a range. This is nil for
+// types that are not in the RangeFamily, and for the AnyRange wildcard type.
+func (t *T) RangeContents() *T {
+	if t.Family() != RangeFamily {
+		return nil
+	}
+	switch t.Oid() {
+	case oid.T_int4range:
+		return Int4
+	case oid.T_int8range:
+		return Int
+	case oid.T_numrange:
+		return Decimal
+	case oid.T_tsrange:
+		return Timestamp
+	case oid.T_tstzrange:
+		return TimestampTZ
+	case oid.T_daterange:
+		return Date
+	}
+	return nil
+}
+
 // TupleContents returns a slice containing the type of each tuple field. This
 // is nil for non-TupleFamily types.
 func (t *T) TupleContents() []*T {
@@ -1350,12 +1481,51 @@ func (t *T) UserDefinedArrayOID() oid.Oid {
 	return t.InternalType.UDTMetadata.ArrayTypeOID
 }
 
+// DomainOID returns the OID of the domain type that t represents, or zero if t
+// is not a domain type.
+func (t *T) DomainOID() oid.Oid {
+	if t.InternalType.UDTMetadata == nil {
+		return 0
+	}
+	return t.InternalType.UDTMetadata.DomainTypeOID
+}
+
+// IsDomain returns whether or not t is a domain type. Values of a domain type
+// are values of its base type, so the family, width and OID of t are those of
+// the base type.
+func (t *T) IsDomain() bool {
+	return t.DomainOID() != 0
+}
+
+// IsComposite returns whether or not t is a composite type created by CREATE
+// TYPE ... AS (...). Unlike the implicit record types of tables, which are also
+// user defined tuple types, composite types have an array type.
+func (t *T) IsComposite() bool {
+	return t.Family() == TupleFamily && t.UserDefined() && t.UserDefinedArrayOID() != 0
+}
+
+// UserDefinedOID returns the OID of the user defined type descriptor that t
+// refers to. It is the OID of the domain for domain types, and the OID of the
+// type otherwise.
+func (t *T) UserDefinedOID() oid.Oid {
+	if t.IsDomain() {
+		return t.DomainOID()
+	}
+	return t.Oid()
+}
+
 // RemapUserDefinedTypeOIDs is used to remap OIDs stored within a types.T
 // that is a user defined type. The newArrayOID argument is ignored if the
 // input type is an Array type. It mutates the input types.T and should only
 // be used when type is known to not be shared. If the input oid values are
 // 0 then the RemapUserDefinedTypeOIDs has no effect.
 func RemapUserDefinedTypeOIDs(t *T, newOID, newArrayOID oid.Oid) {
+	if t.IsDomain() {
+		if newOID != 0 {
+			t.InternalType.UDTMetadata.DomainTypeOID = newOID
+		}
+		return
+	}
 	if newOID != 0 {
 		t.InternalType.Oid = newOID
 	}
@@ -1366,7 +1536,7 @@ func RemapUserDefinedTypeOIDs(t *T, newOID, newArrayOID oid.Oid) {
 
 // UserDefined returns whether or not t is a user defined type.
 func (t *T) UserDefined() bool {
-	return IsOIDUserDefinedType(t.Oid())
+	return IsOIDUserDefinedType(t.Oid()) || t.IsDomain()
 }
 
 // IsOIDUserDefinedType returns whether or not o corresponds to a user
@@ -1395,11 +1565,14 @@ var familyNames = map[Family]string{
 	IntervalFamily:       "interval",
 	JsonFamily:           "jsonb",
 	OidFamily:            "oid",
+	RangeFamily:          "range",
 	StringFamily:         "string",
 	TimeFamily:           "time",
 	TimestampFamily:      "timestamp",
 	TimestampTZFamily:    "timestamptz",
 	TimeTZFamily:         "timetz",
+	TSQueryFamily:        "tsquery",
+	TSVectorFamily:       "tsvector",
 	TupleFamily:          "tuple",
 	UnknownFamily:        "unknown",
 	UuidFamily:           "uuid",
@@ -1426,6 +1599,9 @@ func (f Family) Name() string {
 //
 // TODO(andyk): Should these be changed to be the same as SQLStandardName?
 func (t *T) Name() string {
+	if t.IsDomain() && t.TypeMeta.Name != nil {
+		return t.TypeMeta.Name.Basename()
+	}
 	switch fam := t.Family(); fam {
 	case AnyFamily:
 		return "anyelement"
@@ -1486,6 +1662,9 @@ func (t *T) Name() string {
 		}
 		panic(errors.AssertionFailedf("unexpected OID: %d", t.Oid()))
 
+	case RangeFamily:
+		return t.SQLStandardName()
+
 	case TupleFamily:
 		return t.SQLStandardName()
 
@@ -1516,6 +1695,9 @@ func (t *T) Name() string {
 //   int4[]       _int4
 //
 func (t *T) PGName() string {
+	if t.IsDomain() && t.TypeMeta.Name != nil {
+		return t.TypeMeta.Name.Basename()
+	}
 	name, ok := oidext.TypeName(t.Oid())
 	if ok {
 		return strings.ToLower(name)
@@ -1662,6 +1844,12 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 		default:
 			panic(errors.AssertionFailedf("unexpected Oid: %v", errors.Safe(t.Oid())))
 		}
+	case RangeFamily:
+		name, ok := oidext.TypeName(t.Oid())
+		if !ok {
+			panic(errors.AssertionFailedf("unexpected Oid: %v", errors.Safe(t.Oid())))
+		}
+		return strings.ToLower(name)
 	case StringFamily, CollatedStringFamily:
 		switch t.Oid() {
 		case oid.T_text:
@@ -1719,6 +1907,10 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 			return "timestamp with time zone"
 		}
 		return fmt.Sprintf("timestamp(%d) with time zone", typmod)
+	case TSQueryFamily:
+		return "tsquery"
+	case TSVectorFamily:
+		return "tsvector"
 	case TupleFamily:
 		if t.UserDefined() {
 			// If we have a user-defined tuple type, use its user-defined name.
@@ -1757,6 +1949,9 @@ func (t *T) InformationSchemaName() string {
 // reproduce the type via parsing the string as a type. It is used in error
 // messages and also to produce the output of SHOW CREATE.
 func (t *T) SQLString() string {
+	if t.IsDomain() && t.TypeMeta.Name != nil {
+		return t.TypeMeta.Name.FQName()
+	}
 	switch t.Family() {
 	case BitFamily:
 		o := t.Oid()
@@ -1851,6 +2046,10 @@ func (t *T) SQLString() string {
 			return "anyenum"
 		}
 		return t.TypeMeta.Name.FQName()
+	case TupleFamily:
+		if t.IsComposite() && t.TypeMeta.Name != nil {
+			return t.TypeMeta.Name.FQName()
+		}
 	}
 	return strings.ToUpper(t.Name())
 }
@@ -1905,6 +2104,16 @@ func (t *T) Equivalent(other *T) bool {
 			return false
 		}
 
+	case RangeFamily:
+		// If one of the types is anyrange, then allow the comparison to go
+		// through -- anyrange is used when matching overloads.
+		if t.Oid() == oid.T_anyrange || other.Oid() == oid.T_anyrange {
+			return true
+		}
+		if !t.RangeContents().Equivalent(other.RangeContents()) {
+			return false
+		}
+
 	case EnumFamily:
 		// If one of the types is anyenum, then allow the comparison to
 		// go through -- anyenum is used when matching overloads.
@@ -2063,6 +2272,9 @@ func (t *InternalType) Identical(other *InternalType) bool {
 		if t.UDTMetadata.ArrayTypeOID != other.UDTMetadata.ArrayTypeOID {
 			return false
 		}
+		if t.UDTMetadata.DomainTypeOID != other.UDTMetadata.DomainTypeOID {
+			return false
+		}
 	} else if t.UDTMetadata != nil {
 		return false
 	} else if other.UDTMetadata != nil {
@@ -2497,6 +2709,8 @@ func (t *T) IsAmbiguous() bool {
 		return t.ArrayContents().IsAmbiguous()
 	case EnumFamily:
 		return t.Oid() == oid.T_anyenum
+	case RangeFamily:
+		return t.Oid() == oid.T_anyrange
 	}
 	return false
 }
@@ -2789,8 +3003,6 @@ var postgresPredefinedTypeIssues = map[string]int{
 	"money":         41578,
 	"path":          21286,
 	"pg_lsn":        -1,
-	"tsquery":       7821,
-	"tsvector":      7821,
 	"txid_snapshot": -1,
 	"xml":           43355,
 }
@@ -344,6 +344,8 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&alterDatabaseDropSuperRegion{}):     "alter database alter super region",
 	reflect.TypeOf(&alterDatabaseAlterSuperRegion{}):    "alter database drop super region",
 	reflect.TypeOf(&alterDefaultPrivilegesNode{}):       "alter default privileges",
+	reflect.TypeOf(&alterDomainNode{}):                  "alter domain",
+	reflect.TypeOf(&alterFunctionNode{}):                "alter function",
 	reflect.TypeOf(&alterIndexNode{}):                   "alter index",
 	reflect.TypeOf(&alterSequenceNode{}):                "alter sequence",
 	reflect.TypeOf(&alterSchemaNode{}):                  "alter schema",
@@ -370,11 +372,14 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&controlSchedulesNode{}):             "control schedules",
 	reflect.TypeOf(&createDatabaseNode{}):               "create database",
 	reflect.TypeOf(&createExtensionNode{}):              "create extension",
+	reflect.TypeOf(&createFunctionNode{}):               "create function",
 	reflect.TypeOf(&createIndexNode{}):                  "create index",
+	reflect.TypeOf(&createPolicyNode{}):                 "create policy",
 	reflect.TypeOf(&createSequenceNode{}):               "create sequence",
 	reflect.TypeOf(&createSchemaNode{}):                 "create schema",
 	reflect.TypeOf(&createStatsNode{}):                  "create statistics",
 	reflect.TypeOf(&createTableNode{}):                  "create table",
+	reflect.TypeOf(&createTriggerNode{}):                "create trigger",
 	reflect.TypeOf(&createTypeNode{}):                   "create type",
 	reflect.TypeOf(&CreateRoleNode{}):                   "create user/role",
 	reflect.TypeOf(&createViewNode{}):                   "create view",
@@ -383,10 +388,13 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&deleteRangeNode{}):                  "delete range",
 	reflect.TypeOf(&distinctNode{}):                     "distinct",
 	reflect.TypeOf(&dropDatabaseNode{}):                 "drop database",
+	reflect.TypeOf(&dropFunctionNode{}):                 "drop function",
 	reflect.TypeOf(&dropIndexNode{}):                    "drop index",
+	reflect.TypeOf(&dropPolicyNode{}):                   "drop policy",
 	reflect.TypeOf(&dropSequenceNode{}):                 "drop sequence",
 	reflect.TypeOf(&dropSchemaNode{}):                   "drop schema",
 	reflect.TypeOf(&dropTableNode{}):                    "drop table",
+	reflect.TypeOf(&dropTriggerNode{}):                  "drop trigger",
 	reflect.TypeOf(&dropTypeNode{}):                     "drop type",
 	reflect.TypeOf(&DropRoleNode{}):                     "drop user/role",
 	reflect.TypeOf(&dropViewNode{}):                     "drop view",
@@ -407,8 +415,10 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&invertedJoinNode{}):                 "inverted join",
 	reflect.TypeOf(&joinNode{}):                         "join",
 	reflect.TypeOf(&limitNode{}):                        "limit",
+	reflect.TypeOf(&listenNode{}):                       "listen",
 	reflect.TypeOf(&lookupJoinNode{}):                   "lookup join",
 	reflect.TypeOf(&max1RowNode{}):                      "max1row",
+	reflect.TypeOf(&notifyNode{}):                       "notify",
 	reflect.TypeOf(&ordinalityNode{}):                   "ordinality",
 	reflect.TypeOf(&projectSetNode{}):                   "project set",
 	reflect.TypeOf(&reassignOwnedByNode{}):              "reassign owned by",
@@ -435,6 +445,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&sequenceSelectNode{}):               "sequence select",
 	reflect.TypeOf(&serializeNode{}):                    "run",
 	reflect.TypeOf(&setClusterSettingNode{}):            "set cluster setting",
+	reflect.TypeOf(&setConstraintsNode{}):               "set constraints",
 	reflect.TypeOf(&setVarNode{}):                       "set",
 	reflect.TypeOf(&setZoneConfigNode{}):                "configure zone",
 	reflect.TypeOf(&showFingerprintsNode{}):             "show fingerprints",
@@ -450,6 +461,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&truncateNode{}):                     "truncate",
 	reflect.TypeOf(&unaryNode{}):                        "emptyrow",
 	reflect.TypeOf(&unionNode{}):                        "union",
+	reflect.TypeOf(&unlistenNode{}):                     "unlisten",
 	reflect.TypeOf(&updateNode{}):                       "update",
 	reflect.TypeOf(&upsertNode{}):                       "upsert",
 	reflect.TypeOf(&valuesNode{}):                       "values",
@@ -278,6 +278,8 @@ func toMessage(typ string) interface{} {
 		return &pgproto3.CopyDone{}
 	case "CopyInResponse":
 		return &pgproto3.CopyInResponse{}
+	case "CopyOutResponse":
+		return &pgproto3.CopyOutResponse{}
 	case "DataRow":
 		return &pgproto3.DataRow{}
 	case "Describe":
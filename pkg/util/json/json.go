@@ -863,6 +863,65 @@ func EncodeContainedInvertedIndexSpans(
 	return invertedExpr, nil
 }
 
+// EncodeExistsInvertedIndexSpans takes in a key prefix and returns the spans
+// that must be scanned in the inverted index to evaluate an exists (?)
+// predicate with the given key (i.e., find the objects in the index that have
+// the key at the top level, the arrays that contain the key as a top-level
+// string element, and the string scalars equal to the key).
+//
+// The spans are returned in an inverted.SpanExpression, which represents the
+// set operations that must be applied on the spans read during execution. See
+// comments in the SpanExpression definition for details.
+//
+// The input inKey is prefixed to the keys in all returned spans.
+func EncodeExistsInvertedIndexSpans(b []byte, key string) (inverted.Expression, error) {
+	prefix := encoding.EncodeJSONAscending(b)
+	prefix = prefix[:len(prefix):len(prefix)]
+
+	// An object has the key at the top level if it has an inverted index key
+	// with the encoded object key as a prefix. Values that are scalars or empty
+	// objects or arrays are encoded after a path terminator, and values that are
+	// non-empty objects or arrays are encoded after an object key terminator.
+	//
+	// These spans can have duplicate PKs, since the value of the key can be an
+	// object or array with many inverted index keys, so unique=false.
+	var invertedExpr inverted.Expression = &inverted.SpanExpression{Tight: true}
+	leafPrefix := encoding.AddJSONPathTerminator(
+		encoding.EncodeJSONKeyStringAscending(prefix, key, true /* end */),
+	)
+	containerPrefix := encoding.EncodeJSONKeyStringAscending(prefix, key, false /* end */)
+	for _, p := range [][]byte{leafPrefix, containerPrefix} {
+		invertedExpr = inverted.Or(invertedExpr, inverted.ExprForSpan(inverted.Span{
+			Start: inverted.EncVal(p),
+			End:   inverted.EncVal(keysbase.PrefixEnd(p)),
+		}, true /* tight */))
+	}
+
+	// Arrays that contain the key as a top-level element and string scalars
+	// equal to the key have a single inverted index key each.
+	str := jsonString(key)
+	arrKeys, err := str.encodeInvertedIndexKeys(encoding.EncodeArrayAscending(prefix))
+	if err != nil {
+		return nil, err
+	}
+	strKeys, err := str.encodeInvertedIndexKeys(prefix)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range append(arrKeys, strKeys...) {
+		invertedExpr = inverted.Or(invertedExpr, inverted.ExprForSpan(
+			inverted.MakeSingleValSpan(inverted.EncVal(k)), true, /* tight */
+		))
+	}
+
+	// The encoding of the empty key is a prefix of the encoding of every scalar
+	// at the top level, so the spans are not tight in that case.
+	if key == "" {
+		invertedExpr.SetNotTight()
+	}
+	return invertedExpr, nil
+}
+
 func (j jsonNull) encodeInvertedIndexKeys(b []byte) ([][]byte, error) {
 	b = encoding.AddJSONPathTerminator(b)
 	return [][]byte{encoding.EncodeNullAscending(b)}, nil
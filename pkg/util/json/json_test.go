@@ -2488,3 +2488,134 @@ func TestToDecimal(t *testing.T) {
 		})
 	}
 }
+
+func TestEncodeExistsJSONInvertedIndexSpans(t *testing.T) {
+	testCases := []struct {
+		indexedValue string
+		key          string
+		expected     bool
+		tight        bool
+	}{
+		// This test uses EncodeInvertedIndexKeys and
+		// EncodeExistsInvertedIndexSpans to determine whether the JSON value has
+		// the given key. If indexedValue ? key, expected is true. Otherwise
+		// expected is false. If the spans produced for exists are tight, tight is
+		// true. Otherwise tight is false.
+		{`{"a": 1}`, `a`, true, true},
+		{`{"a": null}`, `a`, true, true},
+		{`{"a": {}}`, `a`, true, true},
+		{`{"a": []}`, `a`, true, true},
+		{`{"a": {"b": "c"}}`, `a`, true, true},
+		{`{"a": [1, 2]}`, `a`, true, true},
+		{`{"b": 1, "a": 2}`, `a`, true, true},
+		{`{"ab": 1}`, `a`, false, true},
+		{`{"b": "a"}`, `a`, false, true},
+		{`{"b": {"a": 1}}`, `a`, false, true},
+		{`["a", "b"]`, `a`, true, true},
+		{`["ab"]`, `a`, false, true},
+		{`[["a"]]`, `a`, false, true},
+		{`[{"a": 1}]`, `a`, false, true},
+		{`"a"`, `a`, true, true},
+		{`"ab"`, `a`, false, true},
+		{`1`, `a`, false, true},
+		{`null`, `a`, false, true},
+		{`{}`, `a`, false, true},
+		{`[]`, `a`, false, true},
+		{`{"": 1}`, ``, true, false},
+		{`[""]`, ``, true, false},
+		{`""`, ``, true, false},
+		{`1`, ``, false, false},
+	}
+
+	// runTest checks that evaluating `value ? key` using keys from
+	// EncodeInvertedIndexKeys and spans from EncodeExistsInvertedIndexSpans
+	// produces the expected result. It returns tight=true if the spans from
+	// EncodeExistsInvertedIndexSpans were tight, and tight=false otherwise.
+	runTest := func(value JSON, key string, expected bool) (tight bool) {
+		keys, err := EncodeInvertedIndexKeys(nil, value)
+		require.NoError(t, err)
+
+		invertedExpr, err := EncodeExistsInvertedIndexSpans(nil, key)
+		require.NoError(t, err)
+
+		spanExpr, ok := invertedExpr.(*inverted.SpanExpression)
+		if !ok {
+			t.Fatalf("invertedExpr %v is not a SpanExpression", invertedExpr)
+		}
+
+		if spanExpr.Unique {
+			t.Errorf("For %q, expected unique=false, but got true", key)
+		}
+
+		actual, err := spanExpr.ContainsKeys(keys)
+		require.NoError(t, err)
+
+		// There may be some false positives, so filter those out.
+		if actual && !spanExpr.Tight {
+			actual, err = value.Exists(key)
+			require.NoError(t, err)
+		}
+
+		if actual != expected {
+			if expected {
+				t.Errorf("expected %s to have key %q but it did not", value.String(), key)
+			} else {
+				t.Errorf("expected %s not to have key %q but it did", value.String(), key)
+			}
+		}
+
+		return spanExpr.Tight
+	}
+
+	// Run pre-defined test cases from above.
+	for _, c := range testCases {
+		value := jsonTestShorthand(c.indexedValue)
+
+		// First check that evaluating `indexedValue ? key` matches the expected
+		// result.
+		res, err := value.Exists(c.key)
+		require.NoError(t, err)
+		if res != c.expected {
+			t.Fatalf(
+				"expected value of %s ? %q did not match actual value. Expected: %v. Got: %v",
+				c.indexedValue, c.key, c.expected, res,
+			)
+		}
+
+		// Now check that we get the same result with the inverted index spans.
+		tight := runTest(value, c.key, c.expected)
+
+		// And check that the tightness matches the expected value.
+		if tight != c.tight {
+			if c.tight {
+				t.Errorf("expected spans for %q to be tight but they were not", c.key)
+			} else {
+				t.Errorf("expected spans for %q not to be tight but they were", c.key)
+			}
+		}
+	}
+
+	// Run a set of randomly generated test cases, using the top-level object
+	// keys of random JSONs as keys.
+	rng, _ := randutil.NewTestRand()
+	for i := 0; i < 100; i++ {
+		value, err := Random(20, rng)
+		require.NoError(t, err)
+		other, err := Random(20, rng)
+		require.NoError(t, err)
+
+		keys := []string{"a", ""}
+		for _, j := range []JSON{value, other} {
+			it, err := j.ObjectIter()
+			require.NoError(t, err)
+			for it != nil && it.Next() {
+				keys = append(keys, it.Key())
+			}
+		}
+		for _, key := range keys {
+			res, err := value.Exists(key)
+			require.NoError(t, err)
+			runTest(value, key, res)
+		}
+	}
+}
@@ -168,6 +168,75 @@ func (f *Frontier) Forward(span roachpb.Span, ts hlc.Timestamp) (bool, error) {
 	return prevFrontier.Less(f.Frontier()), nil
 }
 
+// AddSpansAt adds the spans to the tracked span set, at timestamp startAt. Any
+// part of the spans which is already tracked keeps its timestamp. Note that the
+// frontier regresses if startAt is less than it.
+func (f *Frontier) AddSpansAt(startAt hlc.Timestamp, spans ...roachpb.Span) error {
+	for _, s := range spans {
+		var untracked roachpb.SpanGroup
+		untracked.Add(s)
+		f.SpanEntries(s, func(tracked roachpb.Span, _ hlc.Timestamp) OpResult {
+			untracked.Sub(tracked)
+			return ContinueMatch
+		})
+		for _, sp := range untracked.Slice() {
+			if err := f.addEntry(sp, startAt); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RemoveSpans removes the spans from the tracked span set. Any part of the
+// spans which isn't tracked is ignored. True is returned if the frontier
+// advanced as a result.
+func (f *Frontier) RemoveSpans(spans ...roachpb.Span) (bool, error) {
+	prevFrontier := f.Frontier()
+	for _, s := range spans {
+		var overlaps []*frontierEntry
+		f.tree.DoMatching(func(i interval.Interface) bool {
+			overlaps = append(overlaps, i.(*frontierEntry))
+			return ContinueMatch.asBool()
+		}, s.AsRange())
+		for _, e := range overlaps {
+			if err := f.tree.Delete(e, false /* fast */); err != nil {
+				return false, err
+			}
+			heap.Remove(&f.minHeap, e.index)
+			// Keep tracking the parts of the entry outside of the span.
+			if e.span.Key.Compare(s.Key) < 0 {
+				if err := f.addEntry(roachpb.Span{Key: e.span.Key, EndKey: s.Key}, e.ts); err != nil {
+					return false, err
+				}
+			}
+			if s.EndKey.Compare(e.span.EndKey) < 0 {
+				if err := f.addEntry(roachpb.Span{Key: s.EndKey, EndKey: e.span.EndKey}, e.ts); err != nil {
+					return false, err
+				}
+			}
+		}
+	}
+	return prevFrontier.Less(f.Frontier()), nil
+}
+
+// addEntry adds an entry for a span which doesn't overlap the tracked span set.
+func (f *Frontier) addEntry(s roachpb.Span, ts hlc.Timestamp) error {
+	span := makeSpan(s.AsRange())
+	e := &frontierEntry{
+		id:   f.idAlloc,
+		keys: span.AsRange(),
+		span: span,
+		ts:   ts,
+	}
+	f.idAlloc++
+	if err := f.tree.Insert(e, false /* fast */); err != nil {
+		return err
+	}
+	heap.Push(&f.minHeap, e)
+	return nil
+}
+
 // extendRangeToTheLeft extends the range to the left of the range, provided those
 // ranges all have specified timestamp.
 // Updates provided range with the new starting position.
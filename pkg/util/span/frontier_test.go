@@ -239,6 +239,60 @@ func TestSpanFrontierDisjointSpans(t *testing.T) {
 		expectEntries(`{a-b}@4 {c-e}@4`)
 }
 
+func TestSpanFrontierAddRemoveSpans(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	keyA, keyB, keyC := roachpb.Key("a"), roachpb.Key("b"), roachpb.Key("c")
+	keyD, keyE, keyF := roachpb.Key("d"), roachpb.Key("e"), roachpb.Key("f")
+	spAB := roachpb.Span{Key: keyA, EndKey: keyB}
+	spBE := roachpb.Span{Key: keyB, EndKey: keyE}
+	spCD := roachpb.Span{Key: keyC, EndKey: keyD}
+	spCF := roachpb.Span{Key: keyC, EndKey: keyF}
+	spEF := roachpb.Span{Key: keyE, EndKey: keyF}
+
+	f, err := MakeFrontier(spAB)
+	require.NoError(t, err)
+	forwardFrontier := makeFrontierForwarded(t, f)
+	forwardFrontier(spAB, 3).
+		expectedAdvanced(true).
+		expectFrontier(3).
+		expectEntries(`{a-b}@3`)
+
+	// Added spans are tracked from the given timestamp.
+	require.NoError(t, f.AddSpansAt(hlc.Timestamp{WallTime: 2}, spCD))
+	require.Equal(t, hlc.Timestamp{WallTime: 2}, f.Frontier())
+	require.Equal(t, `{a-b}@3 {c-d}@2`, f.entriesStr())
+
+	// Tracked parts of added spans keep their timestamp.
+	require.NoError(t, f.AddSpansAt(hlc.Timestamp{WallTime: 4}, spBE))
+	require.Equal(t, hlc.Timestamp{WallTime: 2}, f.Frontier())
+	require.Equal(t, `{a-b}@3 {b-c}@4 {c-d}@2 {d-e}@4`, f.entriesStr())
+
+	forwardFrontier(spCF, 5).
+		expectedAdvanced(true).
+		expectFrontier(3).
+		expectEntries(`{a-b}@3 {b-c}@4 {c-e}@5`)
+
+	// Removing the spans at the frontier advances it.
+	advanced, err := f.RemoveSpans(spAB)
+	require.NoError(t, err)
+	require.True(t, advanced)
+	require.Equal(t, hlc.Timestamp{WallTime: 4}, f.Frontier())
+	require.Equal(t, `{b-c}@4 {c-e}@5`, f.entriesStr())
+
+	// Parts of entries outside of removed spans are still tracked, and untracked
+	// parts of removed spans are ignored.
+	advanced, err = f.RemoveSpans(spCD, spEF)
+	require.NoError(t, err)
+	require.False(t, advanced)
+	require.Equal(t, hlc.Timestamp{WallTime: 4}, f.Frontier())
+	require.Equal(t, `{b-c}@4 {d-e}@5`, f.entriesStr())
+
+	forwardFrontier(spBE, 6).
+		expectedAdvanced(true).
+		expectFrontier(6).
+		expectEntries(`{b-c}@6 {d-e}@6`)
+}
+
 func TestSpanFrontierHeap(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -0,0 +1,219 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package trigram implements the trigram operations of the pg_trgm Postgres
+// extension. A string is split into words made of alphanumeric characters,
+// each word is lowercased and padded with two spaces at its start and one
+// space at its end, and the trigrams of the string are the groups of three
+// consecutive characters of its padded words.
+package trigram
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// isWordChar returns whether r is part of a word. Any other character
+// separates words.
+func isWordChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// words returns the lowercased words of s.
+func words(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !isWordChar(r)
+	})
+}
+
+// appendWordTrigrams appends the trigrams of a single lowercased word to ret,
+// in the order in which they appear. padLeft and padRight indicate whether the
+// word starts and ends at a word boundary, in which case it is padded.
+func appendWordTrigrams(ret []string, word string, padLeft, padRight bool) []string {
+	var b strings.Builder
+	if padLeft {
+		b.WriteString("  ")
+	}
+	b.WriteString(word)
+	if padRight {
+		b.WriteByte(' ')
+	}
+	runes := []rune(b.String())
+	for i := 0; i+3 <= len(runes); i++ {
+		ret = append(ret, string(runes[i:i+3]))
+	}
+	return ret
+}
+
+// orderedTrigrams returns the trigrams of s in the order in which they
+// appear, including duplicates.
+func orderedTrigrams(s string) []string {
+	var ret []string
+	for _, w := range words(s) {
+		ret = appendWordTrigrams(ret, w, true /* padLeft */, true /* padRight */)
+	}
+	return ret
+}
+
+// uniqueSorted sorts the given trigrams and removes duplicates.
+func uniqueSorted(trigrams []string) []string {
+	if len(trigrams) == 0 {
+		return nil
+	}
+	sort.Strings(trigrams)
+	ret := trigrams[:1]
+	for _, t := range trigrams[1:] {
+		if t != ret[len(ret)-1] {
+			ret = append(ret, t)
+		}
+	}
+	return ret
+}
+
+// MakeTrigrams returns the sorted, deduplicated trigrams of s. This is the
+// output of the show_trgm builtin, and the set of keys under which s is stored
+// in a trigram inverted index.
+func MakeTrigrams(s string) []string {
+	return uniqueSorted(orderedTrigrams(s))
+}
+
+// MakeTrigramsForLikePattern returns the sorted, deduplicated trigrams that
+// any string matching the given LIKE or ILIKE pattern must contain. Since
+// trigrams are lowercased, the result is the same for LIKE and ILIKE. Words
+// adjacent to a wildcard are not padded on that side, since they may be part
+// of a longer word. An empty result means that the pattern does not constrain
+// the trigrams of matching strings.
+func MakeTrigramsForLikePattern(pattern string) []string {
+	var ret []string
+	var word strings.Builder
+	// wildcardBefore indicates whether the word being built follows a
+	// wildcard.
+	wildcardBefore := false
+	finishWord := func(wildcardAfter bool) {
+		if word.Len() > 0 {
+			ret = appendWordTrigrams(ret, strings.ToLower(word.String()), !wildcardBefore, !wildcardAfter)
+			word.Reset()
+		}
+	}
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '%' || r == '_':
+			finishWord(true /* wildcardAfter */)
+			wildcardBefore = true
+			continue
+		case r == '\\' && i+1 < len(runes):
+			i++
+			r = runes[i]
+		}
+		if isWordChar(r) {
+			word.WriteRune(r)
+			continue
+		}
+		finishWord(false /* wildcardAfter */)
+		wildcardBefore = false
+	}
+	finishWord(false /* wildcardAfter */)
+	return uniqueSorted(ret)
+}
+
+// similarity returns the similarity of two sorted, deduplicated sets of
+// trigrams: the number of shared trigrams divided by the number of trigrams
+// in either set.
+func similarity(l, r []string) float64 {
+	if len(l) == 0 || len(r) == 0 {
+		return 0
+	}
+	shared := 0
+	for i, j := 0, 0; i < len(l) && j < len(r); {
+		switch {
+		case l[i] < r[j]:
+			i++
+		case l[i] > r[j]:
+			j++
+		default:
+			shared++
+			i++
+			j++
+		}
+	}
+	return float64(shared) / float64(len(l)+len(r)-shared)
+}
+
+// Similarity returns a number between 0 and 1 that indicates how similar the
+// two strings are, based on the number of trigrams they share. This is the
+// similarity builtin of pg_trgm.
+func Similarity(l, r string) float64 {
+	return similarity(MakeTrigrams(l), MakeTrigrams(r))
+}
+
+// WordSimilarity returns the greatest similarity between the set of trigrams
+// in query and any continuous extent of the ordered trigrams in s. This is the
+// word_similarity builtin of pg_trgm.
+func WordSimilarity(query, s string) float64 {
+	queryTrigrams := MakeTrigrams(query)
+	if len(queryTrigrams) == 0 {
+		return 0
+	}
+	inQuery := make(map[string]struct{}, len(queryTrigrams))
+	for _, t := range queryTrigrams {
+		inQuery[t] = struct{}{}
+	}
+	trigrams := orderedTrigrams(s)
+	best := 0.0
+	// Trimming a trigram that is not in the query from either end of an extent
+	// never lowers its similarity, so only extents that start and end with a
+	// trigram in the query need to be considered.
+	for start := range trigrams {
+		if _, ok := inQuery[trigrams[start]]; !ok {
+			continue
+		}
+		extent := make(map[string]struct{})
+		shared := 0
+		for end := start; end < len(trigrams); end++ {
+			t := trigrams[end]
+			if _, ok := extent[t]; ok {
+				continue
+			}
+			extent[t] = struct{}{}
+			if _, ok := inQuery[t]; !ok {
+				continue
+			}
+			shared++
+			sim := float64(shared) / float64(len(queryTrigrams)+len(extent)-shared)
+			if sim > best {
+				best = sim
+			}
+		}
+	}
+	return best
+}
+
+// StrictWordSimilarity is like WordSimilarity, but the extents of s must
+// consist of whole words. This is the strict_word_similarity builtin of
+// pg_trgm.
+func StrictWordSimilarity(query, s string) float64 {
+	queryTrigrams := MakeTrigrams(query)
+	ws := words(s)
+	best := 0.0
+	for start := range ws {
+		var extent []string
+		for end := start; end < len(ws); end++ {
+			extent = appendWordTrigrams(extent, ws[end], true /* padLeft */, true /* padRight */)
+			extent = uniqueSorted(extent)
+			if sim := similarity(queryTrigrams, extent); sim > best {
+				best = sim
+			}
+		}
+	}
+	return best
+}
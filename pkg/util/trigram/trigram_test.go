@@ -0,0 +1,106 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package trigram
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeTrigrams(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected []string
+	}{
+		{s: "", expected: nil},
+		{s: "a", expected: []string{"  a", " a "}},
+		{s: "cat", expected: []string{"  c", " ca", "at ", "cat"}},
+		{s: "Cat cAT", expected: []string{"  c", " ca", "at ", "cat"}},
+		{s: "foo|bar", expected: []string{"  b", "  f", " ba", " fo", "ar ", "bar", "foo", "oo "}},
+		{s: "%$!", expected: nil},
+		{s: "été", expected: []string{"  é", " ét", "té ", "été"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.s, func(t *testing.T) {
+			assert.Equal(t, tc.expected, MakeTrigrams(tc.s))
+		})
+	}
+}
+
+func TestMakeTrigramsForLikePattern(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		expected []string
+	}{
+		{pattern: "%", expected: nil},
+		{pattern: "%ab%", expected: nil},
+		{pattern: "%foo%", expected: []string{"foo"}},
+		{pattern: "%Foob%", expected: []string{"foo", "oob"}},
+		{pattern: "foo%", expected: []string{"  f", " fo", "foo"}},
+		{pattern: "%foo", expected: []string{"foo", "oo "}},
+		{pattern: "foo", expected: []string{"  f", " fo", "foo", "oo "}},
+		{pattern: "%foo bar%", expected: []string{"  b", " ba", "bar", "foo", "oo "}},
+		{pattern: "fo_bar", expected: []string{"  f", " fo", "ar ", "bar"}},
+		{pattern: `%a\bc%`, expected: []string{"abc"}},
+		{pattern: `%a\%bc%`, expected: []string{"  b", " bc"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.pattern, func(t *testing.T) {
+			assert.Equal(t, tc.expected, MakeTrigramsForLikePattern(tc.pattern))
+		})
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		l, r     string
+		expected string
+	}{
+		{l: "", r: "", expected: "0"},
+		{l: "word", r: "word", expected: "1"},
+		{l: "word", r: "two words", expected: "0.363636"},
+		{l: "word", r: "bird", expected: "0.111111"},
+		{l: "abc", r: "xyz", expected: "0"},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%s/%s", tc.l, tc.r), func(t *testing.T) {
+			assert.Equal(t, tc.expected, fmt.Sprintf("%g", round(Similarity(tc.l, tc.r))))
+		})
+	}
+}
+
+func TestWordSimilarity(t *testing.T) {
+	tests := []struct {
+		query, s       string
+		expected       string
+		expectedStrict string
+	}{
+		{query: "word", s: "two words", expected: "0.8", expectedStrict: "0.571429"},
+		{query: "word", s: "word", expected: "1", expectedStrict: "1"},
+		{query: "", s: "word", expected: "0", expectedStrict: "0"},
+		{query: "word", s: "", expected: "0", expectedStrict: "0"},
+		{query: "two words", s: "two and some words", expected: "0.6", expectedStrict: "0.6"},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%s/%s", tc.query, tc.s), func(t *testing.T) {
+			assert.Equal(t, tc.expected, fmt.Sprintf("%g", round(WordSimilarity(tc.query, tc.s))))
+			assert.Equal(t, tc.expectedStrict, fmt.Sprintf("%g", round(StrictWordSimilarity(tc.query, tc.s))))
+		})
+	}
+}
+
+func round(f float64) float64 {
+	var ret float64
+	_, _ = fmt.Sscanf(fmt.Sprintf("%.6f", f), "%g", &ret)
+	return ret
+}
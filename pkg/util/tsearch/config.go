@@ -0,0 +1,244 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// Config is a text search configuration, which determines how documents and
+// queries are split into words and how those words are normalized into
+// lexemes.
+type Config struct {
+	name      string
+	stopwords map[string]struct{}
+	stem      func(string) string
+}
+
+// Name returns the name of the configuration.
+func (c *Config) Name() string {
+	return c.name
+}
+
+var configs = map[string]*Config{
+	"simple": {name: "simple"},
+	"english": {
+		name:      "english",
+		stopwords: englishStopwords,
+		stem:      stemEnglish,
+	},
+}
+
+// DefaultConfigName is the name of the configuration that is used when none is
+// specified.
+const DefaultConfigName = "english"
+
+// GetConfig returns the text search configuration with the given name. The
+// name may be qualified with the pg_catalog schema.
+func GetConfig(name string) (*Config, error) {
+	if c, ok := configs[strings.TrimPrefix(strings.ToLower(name), "pg_catalog.")]; ok {
+		return c, nil
+	}
+	return nil, pgerror.Newf(pgcode.UndefinedObject,
+		"text search configuration %q does not exist", name)
+}
+
+// token is a word of a document. start and end are the byte offsets of the
+// word in the document.
+type token struct {
+	text       string
+	start, end int
+}
+
+// tokenize splits a document into words, which are runs of letters and
+// digits. All other characters are separators.
+func tokenize(doc string) []token {
+	var ret []token
+	start := -1
+	for i, r := range doc {
+		isWordRune := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if isWordRune && start < 0 {
+			start = i
+		} else if !isWordRune && start >= 0 {
+			ret = append(ret, token{text: doc[start:i], start: start, end: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		ret = append(ret, token{text: doc[start:], start: start, end: len(doc)})
+	}
+	return ret
+}
+
+// normalize returns the lexeme of a word. stop is set if the word is a
+// stopword, in which case it has no lexeme. Only words made of letters are
+// stemmed; numbers and words with digits are only lowercased.
+func (c *Config) normalize(word string) (lexeme string, stop bool) {
+	lexeme = strings.ToLower(word)
+	if _, ok := c.stopwords[lexeme]; ok {
+		return "", true
+	}
+	if c.stem == nil {
+		return lexeme, false
+	}
+	for _, r := range lexeme {
+		if !unicode.IsLetter(r) {
+			return lexeme, false
+		}
+	}
+	return c.stem(lexeme), false
+}
+
+// ToTSVector returns the tsvector of a document. The position of each lexeme
+// is the position of its word in the document, counting stopwords.
+func (c *Config) ToTSVector(doc string) TSVector {
+	tokens := tokenize(doc)
+	terms := make([]Term, 0, len(tokens))
+	for i, t := range tokens {
+		lexeme, stop := c.normalize(t.text)
+		if stop {
+			continue
+		}
+		pos := i + 1
+		if pos > MaxPosition {
+			pos = MaxPosition
+		}
+		terms = append(terms, Term{Lexeme: lexeme, Positions: []Position{{Pos: uint16(pos)}}})
+	}
+	return NewTSVector(terms)
+}
+
+// ToTSQuery parses a query like ParseTSQuery, and normalizes its operands
+// with the configuration. Operands that consist of several words are replaced
+// by a phrase of their lexemes, and stopwords are removed from the query.
+func (c *Config) ToTSQuery(input string) (TSQuery, error) {
+	q, err := ParseTSQuery(input)
+	if err != nil {
+		return TSQuery{}, err
+	}
+	var normalize func(n *Node) *Node
+	normalize = func(n *Node) *Node {
+		if n.Op != Invalid {
+			ret := *n
+			ret.Left = normalize(n.Left)
+			if n.Right != nil {
+				ret.Right = normalize(n.Right)
+			}
+			return &ret
+		}
+		var ret *Node
+		for _, t := range tokenize(n.Lexeme) {
+			leaf := &Node{Prefix: n.Prefix, Weights: n.Weights}
+			leaf.Lexeme, _ = c.normalize(t.text)
+			if ret == nil {
+				ret = leaf
+			} else {
+				ret = &Node{Op: FollowedBy, Distance: 1, Left: ret, Right: leaf}
+			}
+		}
+		if ret == nil {
+			// The operand has no words at all, so treat it as a stopword.
+			return &Node{}
+		}
+		return ret
+	}
+	if q.Root == nil {
+		return q, nil
+	}
+	return TSQuery{Root: cleanStopwords(normalize(q.Root))}, nil
+}
+
+// PlainToTSQuery returns the query that matches documents that contain all
+// the lexemes of the input.
+func (c *Config) PlainToTSQuery(input string) TSQuery {
+	var q TSQuery
+	for _, t := range tokenize(input) {
+		if lexeme, stop := c.normalize(t.text); !stop {
+			q = q.And(TSQuery{Root: &Node{Lexeme: lexeme}})
+		}
+	}
+	return q
+}
+
+// PhraseToTSQuery returns the query that matches documents that contain the
+// lexemes of the input in the same order. Stopwords are removed, but the
+// distances between the remaining lexemes take them into account.
+func (c *Config) PhraseToTSQuery(input string) TSQuery {
+	var root *Node
+	for _, t := range tokenize(input) {
+		leaf := &Node{}
+		leaf.Lexeme, _ = c.normalize(t.text)
+		if root == nil {
+			root = leaf
+		} else {
+			root = &Node{Op: FollowedBy, Distance: 1, Left: root, Right: leaf}
+		}
+	}
+	if root == nil {
+		return TSQuery{}
+	}
+	return TSQuery{Root: cleanStopwords(root)}
+}
+
+// cleanStopwords removes the stopwords, which are operands with an empty
+// lexeme, from a query. It returns nil if the whole query is removed.
+func cleanStopwords(n *Node) *Node {
+	ret, _, _ := cleanStopwordsRec(n)
+	return ret
+}
+
+// cleanStopwordsRec removes the stopwords from a query, following
+// clean_stopword_intree in Postgres. leftAdd and rightAdd are the distances
+// that the removed operands on the left and on the right of the returned node
+// occupied, which are added to the distance of the phrase operators above.
+func cleanStopwordsRec(n *Node) (ret *Node, leftAdd, rightAdd int) {
+	switch n.Op {
+	case Invalid:
+		if n.Lexeme == "" {
+			return nil, 0, 0
+		}
+		return n, 0, 0
+	case Not:
+		left, leftAdd, rightAdd := cleanStopwordsRec(n.Left)
+		if left == nil {
+			return nil, leftAdd, rightAdd
+		}
+		n.Left = left
+		return n, leftAdd, rightAdd
+	}
+	distance := 0
+	if n.Op == FollowedBy {
+		distance = n.Distance
+	}
+	left, llAdd, lrAdd := cleanStopwordsRec(n.Left)
+	right, rlAdd, rrAdd := cleanStopwordsRec(n.Right)
+	switch {
+	case left == nil && right == nil:
+		add := llAdd + distance + rlAdd
+		return nil, add, add
+	case left == nil:
+		return right, llAdd + distance + rlAdd, rrAdd
+	case right == nil:
+		return left, llAdd, lrAdd + distance + rlAdd
+	}
+	n.Left, n.Right = left, right
+	if n.Op == FollowedBy {
+		n.Distance += lrAdd + rlAdd
+		if n.Distance > MaxPosition {
+			n.Distance = MaxPosition
+		}
+	}
+	return n, llAdd, rrAdd
+}
@@ -0,0 +1,138 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStemEnglish(t *testing.T) {
+	for word, expected := range map[string]string{
+		"a":           "a",
+		"cats":        "cat",
+		"caresses":    "caress",
+		"ponies":      "poni",
+		"ties":        "tie",
+		"cries":       "cri",
+		"running":     "run",
+		"hopping":     "hop",
+		"hoping":      "hope",
+		"jumped":      "jump",
+		"agreed":      "agre",
+		"plastered":   "plaster",
+		"foxes":       "fox",
+		"lazy":        "lazi",
+		"happiness":   "happi",
+		"generously":  "generous",
+		"consignment": "consign",
+		"consolation": "consol",
+		"kneeling":    "kneel",
+		"knightly":    "knight",
+		"relational":  "relat",
+		"conditional": "condit",
+		"supernovae":  "supernova",
+		"skies":       "sky",
+		"news":        "news",
+		"inning":      "inning",
+		"yelling":     "yell",
+		"sayings":     "say",
+	} {
+		require.Equal(t, expected, stemEnglish(word), word)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	english, err := GetConfig("english")
+	require.NoError(t, err)
+	simple, err := GetConfig("pg_catalog.simple")
+	require.NoError(t, err)
+	_, err = GetConfig("klingon")
+	require.EqualError(t, err, `text search configuration "klingon" does not exist`)
+
+	require.Equal(t,
+		`'brown':3 'dog':9 'fox':4 'jump':5 'lazi':8 'quick':2`,
+		english.ToTSVector("The quick brown foxes jumped over the lazy dogs").String())
+	require.Equal(t,
+		`'brown':3 'dogs':9 'foxes':4 'jumped':5 'lazy':8 'over':6 'quick':2 'the':1,7`,
+		simple.ToTSVector("The quick brown foxes jumped over the lazy dogs").String())
+	require.Equal(t, `'2022':3 'r2d2':1 'robot':2`, english.ToTSVector("R2D2 robots, 2022").String())
+
+	for _, tc := range []struct {
+		input    string
+		expected string
+	}{
+		{`supernovae & stars`, `'supernova' & 'star'`},
+		{`Fat:ab & Cats:*`, `'fat':AB & 'cat':*`},
+		{`cat <-> the <-> rat`, `'cat' <2> 'rat'`},
+		{`the <-> cat`, `'cat'`},
+		{`the & cat`, `'cat'`},
+		{`!the`, ``},
+		{`'fat rats'`, `'fat' <-> 'rat'`},
+	} {
+		q, err := english.ToTSQuery(tc.input)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, q.String(), tc.input)
+	}
+
+	require.Equal(t, `'fat' & 'rat'`, english.PlainToTSQuery("The Fat Rats").String())
+	require.Equal(t, `'fat' <-> 'rat'`, english.PhraseToTSQuery("The Fat Rats").String())
+	require.Equal(t, `'fat' <2> 'rat'`, english.PhraseToTSQuery("fat the rats").String())
+	require.Equal(t, ``, english.PlainToTSQuery("the").String())
+
+	v := english.ToTSVector("a fat cat sat on a mat and ate a fat rat")
+	require.True(t, Match(v, english.PhraseToTSQuery("fat rats")))
+	require.False(t, Match(v, english.PhraseToTSQuery("fat cats ate")))
+}
+
+func TestRank(t *testing.T) {
+	english, err := GetConfig("english")
+	require.NoError(t, err)
+	v := english.ToTSVector("This is an example of document")
+	rank := func(query string, method int) float32 {
+		q, err := english.ToTSQuery(query)
+		require.NoError(t, err)
+		return Rank(DefaultRankWeights, v, q, method)
+	}
+	require.InDelta(t, 0.0607927, rank("example", 0), 1e-7)
+	require.InDelta(t, 0.0985009, rank("example & document", 0), 1e-7)
+	require.InDelta(t, 0.0607927, rank("example | document", 0), 1e-7)
+	require.InDelta(t, 0.0607927/2, rank("example", RankNormLength), 1e-7)
+	require.Zero(t, rank("missing", 0))
+}
+
+func TestHeadline(t *testing.T) {
+	english, err := GetConfig("english")
+	require.NoError(t, err)
+	headline := func(doc, query, options string) string {
+		q, err := english.ToTSQuery(query)
+		require.NoError(t, err)
+		opts, err := ParseHeadlineOptions(options)
+		require.NoError(t, err)
+		return english.Headline(doc, q, opts)
+	}
+	require.Equal(t, "The fat <b>cat</b> ate the <b>rat</b>.",
+		headline("The fat cat ate the rat.", "cats | rat", ""))
+	require.Equal(t, "The fat [cat] ate the rat.",
+		headline("The fat cat ate the rat.", "cat", "StartSel=[, StopSel=]"))
+	require.Equal(t, "<b>cat</b> ate the",
+		headline("The fat cat ate the rat.", "cat", "MaxWords=3, MinWords=1, ShortWord=0"))
+	require.Equal(t, "<b>cat</b>",
+		headline("The fat cat ate the rat.", "cat", "MaxWords=4, MinWords=1"))
+	require.Equal(t, "The fat",
+		headline("The fat cat ate the rat.", "dog", "MaxWords=3, MinWords=2, ShortWord=0"))
+
+	_, err = ParseHeadlineOptions("MaxWords=5, MinWords=10")
+	require.EqualError(t, err, "MinWords should be less than MaxWords")
+	_, err = ParseHeadlineOptions("Color=red")
+	require.EqualError(t, err, `unrecognized headline parameter: "color"`)
+}
@@ -0,0 +1,247 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/cockroachdb/errors"
+)
+
+// The encodings of this file are the binary send and receive formats of
+// Postgres, which are used both by pgwire and to store the values.
+
+// EncodeTSVector appends the binary encoding of the tsvector to the given
+// byte slice: the number of lexemes, then for each lexeme the null-terminated
+// lexeme, the number of positions and the positions, with the weight in the
+// two highest bits.
+func EncodeTSVector(appendTo []byte, v TSVector) []byte {
+	appendTo = appendUint32(appendTo, uint32(len(v)))
+	for _, t := range v {
+		appendTo = append(appendTo, t.Lexeme...)
+		appendTo = append(appendTo, 0)
+		appendTo = appendUint16(appendTo, uint16(len(t.Positions)))
+		for _, p := range t.Positions {
+			appendTo = appendUint16(appendTo, uint16(p.Weight)<<14|p.Pos)
+		}
+	}
+	return appendTo
+}
+
+// DecodeTSVector decodes a tsvector encoded with EncodeTSVector.
+func DecodeTSVector(b []byte) (TSVector, error) {
+	d := decoder{b: b}
+	n := d.uint32()
+	terms := make([]Term, 0, n)
+	for i := uint32(0); i < n && d.err == nil; i++ {
+		t := Term{Lexeme: d.cstring()}
+		numPositions := d.uint16()
+		for j := uint16(0); j < numPositions && d.err == nil; j++ {
+			p := d.uint16()
+			t.Positions = append(t.Positions, Position{Pos: p & MaxPosition, Weight: Weight(p >> 14)})
+		}
+		terms = append(terms, t)
+	}
+	if err := d.finish(); err != nil {
+		return nil, errors.Wrap(err, "decoding tsvector")
+	}
+	return NewTSVector(terms), nil
+}
+
+// The item types and operators of the binary encoding of tsqueries.
+const (
+	queryItemValue    = 1
+	queryItemOperator = 2
+
+	queryOpNot    = 1
+	queryOpAnd    = 2
+	queryOpOr     = 3
+	queryOpPhrase = 4
+)
+
+// EncodeTSQuery appends the binary encoding of the tsquery to the given byte
+// slice: the number of nodes, then the nodes in prefix order, with the right
+// operand of each operator before its left operand.
+func EncodeTSQuery(appendTo []byte, q TSQuery) []byte {
+	var nodes []*Node
+	var walk func(n *Node)
+	walk = func(n *Node) {
+		nodes = append(nodes, n)
+		if n.Right != nil {
+			walk(n.Right)
+		}
+		if n.Left != nil {
+			walk(n.Left)
+		}
+	}
+	if q.Root != nil {
+		walk(q.Root)
+	}
+	appendTo = appendUint32(appendTo, uint32(len(nodes)))
+	for _, n := range nodes {
+		switch n.Op {
+		case Invalid:
+			appendTo = append(appendTo, queryItemValue, n.Weights)
+			if n.Prefix {
+				appendTo = append(appendTo, 1)
+			} else {
+				appendTo = append(appendTo, 0)
+			}
+			appendTo = append(appendTo, n.Lexeme...)
+			appendTo = append(appendTo, 0)
+		case Not:
+			appendTo = append(appendTo, queryItemOperator, queryOpNot)
+		case And:
+			appendTo = append(appendTo, queryItemOperator, queryOpAnd)
+		case Or:
+			appendTo = append(appendTo, queryItemOperator, queryOpOr)
+		case FollowedBy:
+			appendTo = append(appendTo, queryItemOperator, queryOpPhrase)
+			appendTo = appendUint16(appendTo, uint16(n.Distance))
+		}
+	}
+	return appendTo
+}
+
+// DecodeTSQuery decodes a tsquery encoded with EncodeTSQuery.
+func DecodeTSQuery(b []byte) (TSQuery, error) {
+	d := decoder{b: b}
+	count := d.uint32()
+	remaining := int(count)
+	var decode func() *Node
+	decode = func() *Node {
+		if d.err != nil {
+			return nil
+		}
+		if remaining == 0 {
+			d.err = errors.New("not enough nodes")
+			return nil
+		}
+		remaining--
+		var n Node
+		switch typ := d.byte(); typ {
+		case queryItemValue:
+			n.Weights = d.byte()
+			n.Prefix = d.byte() != 0
+			n.Lexeme = d.cstring()
+			return &n
+		case queryItemOperator:
+			switch op := d.byte(); op {
+			case queryOpNot:
+				n.Op = Not
+				n.Left = decode()
+				return &n
+			case queryOpAnd:
+				n.Op = And
+			case queryOpOr:
+				n.Op = Or
+			case queryOpPhrase:
+				n.Op = FollowedBy
+				n.Distance = int(d.uint16())
+			default:
+				d.err = errors.Newf("unknown operator %d", op)
+				return nil
+			}
+			n.Right = decode()
+			n.Left = decode()
+			return &n
+		default:
+			d.err = errors.Newf("unknown item type %d", typ)
+			return nil
+		}
+	}
+	var q TSQuery
+	if count > 0 {
+		q.Root = decode()
+	}
+	if d.err == nil && remaining != 0 {
+		d.err = errors.New("too many nodes")
+	}
+	if err := d.finish(); err != nil {
+		return TSQuery{}, errors.Wrap(err, "decoding tsquery")
+	}
+	return q, nil
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func appendUint16(b []byte, v uint16) []byte {
+	var buf [2]byte
+	binary.BigEndian.PutUint16(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+// decoder reads the binary encodings. The first error is kept in err, after
+// which all reads return zero values.
+type decoder struct {
+	b   []byte
+	err error
+}
+
+func (d *decoder) read(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if len(d.b) < n {
+		d.err = errors.New("unexpected end of input")
+		return nil
+	}
+	ret := d.b[:n]
+	d.b = d.b[n:]
+	return ret
+}
+
+func (d *decoder) byte() byte {
+	if b := d.read(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (d *decoder) uint16() uint16 {
+	if b := d.read(2); b != nil {
+		return binary.BigEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (d *decoder) uint32() uint32 {
+	if b := d.read(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (d *decoder) cstring() string {
+	if d.err != nil {
+		return ""
+	}
+	i := bytes.IndexByte(d.b, 0)
+	if i < 0 {
+		d.err = errors.New("unterminated string")
+		return ""
+	}
+	ret := string(d.b[:i])
+	d.b = d.b[i+1:]
+	return ret
+}
+
+func (d *decoder) finish() error {
+	if d.err == nil && len(d.b) > 0 {
+		d.err = errors.New("trailing bytes")
+	}
+	return d.err
+}
@@ -0,0 +1,209 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import "sort"
+
+// Match returns whether the tsvector matches the tsquery, which is the result
+// of the @@ operator. An empty query matches nothing.
+func Match(v TSVector, q TSQuery) bool {
+	if q.Root == nil {
+		return false
+	}
+	return matchNode(v, q.Root)
+}
+
+func matchNode(v TSVector, n *Node) bool {
+	switch n.Op {
+	case Invalid:
+		res := operandPositions(v, n)
+		return res.all || len(res.positions) > 0
+	case And:
+		return matchNode(v, n.Left) && matchNode(v, n.Right)
+	case Or:
+		return matchNode(v, n.Left) || matchNode(v, n.Right)
+	case Not:
+		return !matchNode(v, n.Left)
+	case FollowedBy:
+		res := phraseMatch(v, n)
+		return res.all || res.negate || len(res.positions) > 0
+	}
+	return false
+}
+
+// phraseResult is the result of evaluating a node inside a phrase. positions
+// holds the sorted positions at which the node ends; if negate is set, the
+// node matches at all positions except those instead. width is the number of
+// positions between the start and the end of a match. all is set if the
+// positions are unknown, for example because the tsvector was stripped, in
+// which case the node is assumed to match at all positions.
+type phraseResult struct {
+	positions []int
+	negate    bool
+	width     int
+	all       bool
+}
+
+// operandPositions returns the positions of the lexemes that match the
+// operand, filtered by the weights of the operand.
+func operandPositions(v TSVector, n *Node) phraseResult {
+	start, end := 0, 0
+	if n.Prefix {
+		start, end = v.findPrefix(n.Lexeme)
+	} else if i, ok := v.find(n.Lexeme); ok {
+		start, end = i, i+1
+	}
+	var res phraseResult
+	for _, t := range v[start:end] {
+		if len(t.Positions) == 0 {
+			res.all = true
+			continue
+		}
+		for _, p := range t.Positions {
+			if n.Weights == 0 || n.Weights&(1<<p.Weight) != 0 {
+				res.positions = append(res.positions, int(p.Pos))
+			}
+		}
+	}
+	if end-start > 1 {
+		res.positions = sortedUnique(res.positions)
+	}
+	return res
+}
+
+// phraseMatch evaluates a node inside a phrase, following the semantics of
+// TS_phrase_execute in Postgres.
+func phraseMatch(v TSVector, n *Node) phraseResult {
+	switch n.Op {
+	case Invalid:
+		return operandPositions(v, n)
+	case Not:
+		res := phraseMatch(v, n.Left)
+		res.negate = !res.negate
+		return res
+	}
+	l, r := phraseMatch(v, n.Left), phraseMatch(v, n.Right)
+	if l.all || r.all {
+		return phraseResult{all: true, width: l.width + r.width + n.Distance}
+	}
+	switch n.Op {
+	case FollowedBy:
+		// A match of the phrase ends where a match of the right operand ends.
+		// The left operand must end offset positions before that.
+		offset := n.Distance + r.width
+		shifted := make([]int, len(l.positions))
+		for i, p := range l.positions {
+			shifted[i] = p + offset
+		}
+		res := phraseResult{width: l.width + r.width + n.Distance}
+		switch {
+		case !l.negate && !r.negate:
+			res.positions = intersect(shifted, r.positions)
+		case l.negate && !r.negate:
+			res.positions = subtract(r.positions, shifted)
+		case !l.negate && r.negate:
+			res.positions = subtract(shifted, r.positions)
+		default:
+			res.positions = union(shifted, r.positions)
+			res.negate = true
+		}
+		return res
+	case And:
+		res := phraseResult{width: maxInt(l.width, r.width)}
+		switch {
+		case !l.negate && !r.negate:
+			res.positions = intersect(l.positions, r.positions)
+		case l.negate && !r.negate:
+			res.positions = subtract(r.positions, l.positions)
+		case !l.negate && r.negate:
+			res.positions = subtract(l.positions, r.positions)
+		default:
+			res.positions = union(l.positions, r.positions)
+			res.negate = true
+		}
+		return res
+	case Or:
+		res := phraseResult{width: maxInt(l.width, r.width)}
+		switch {
+		case !l.negate && !r.negate:
+			res.positions = union(l.positions, r.positions)
+		case l.negate && !r.negate:
+			res.positions = subtract(l.positions, r.positions)
+			res.negate = true
+		case !l.negate && r.negate:
+			res.positions = subtract(r.positions, l.positions)
+			res.negate = true
+		default:
+			res.positions = intersect(l.positions, r.positions)
+			res.negate = true
+		}
+		return res
+	}
+	return phraseResult{}
+}
+
+func sortedUnique(s []int) []int {
+	sort.Ints(s)
+	ret := s[:0]
+	for i, p := range s {
+		if i == 0 || p != s[i-1] {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
+func intersect(a, b []int) []int {
+	var ret []int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			ret = append(ret, a[i])
+			i++
+			j++
+		}
+	}
+	return ret
+}
+
+func union(a, b []int) []int {
+	ret := make([]int, 0, len(a)+len(b))
+	ret = append(ret, a...)
+	ret = append(ret, b...)
+	return sortedUnique(ret)
+}
+
+// subtract returns the elements of a that are not in b.
+func subtract(a, b []int) []int {
+	var ret []int
+	j := 0
+	for _, p := range a {
+		for j < len(b) && b[j] < p {
+			j++
+		}
+		if j < len(b) && b[j] == p {
+			continue
+		}
+		ret = append(ret, p)
+	}
+	return ret
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,185 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// HeadlineOptions are the options of Headline.
+type HeadlineOptions struct {
+	// StartSel and StopSel are the strings that are written around the words
+	// that match the query.
+	StartSel, StopSel string
+	// MaxWords and MinWords are the maximum and minimum number of words of the
+	// headline.
+	MaxWords, MinWords int
+	// ShortWord is the length of the words that are dropped at the start and
+	// at the end of the headline, unless they match the query.
+	ShortWord int
+	// HighlightAll makes the headline be the whole document.
+	HighlightAll bool
+}
+
+// DefaultHeadlineOptions returns the default options of Headline.
+func DefaultHeadlineOptions() HeadlineOptions {
+	return HeadlineOptions{
+		StartSel:  "<b>",
+		StopSel:   "</b>",
+		MaxWords:  35,
+		MinWords:  15,
+		ShortWord: 3,
+	}
+}
+
+// ParseHeadlineOptions parses a comma-separated list of options of the form
+// name=value, for example "StartSel=<em>, StopSel=</em>, MaxWords=10". The
+// options that are not in the list keep their default value.
+func ParseHeadlineOptions(s string) (HeadlineOptions, error) {
+	opts := DefaultHeadlineOptions()
+	for _, opt := range strings.Split(s, ",") {
+		opt = strings.TrimSpace(opt)
+		if opt == "" {
+			continue
+		}
+		eq := strings.IndexByte(opt, '=')
+		if eq < 0 {
+			return opts, pgerror.Newf(pgcode.InvalidParameterValue,
+				"unrecognized headline parameter: %q", opt)
+		}
+		name := strings.ToLower(strings.TrimSpace(opt[:eq]))
+		value := strings.TrimSpace(opt[eq+1:])
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			value = value[1 : len(value)-1]
+		}
+		var err error
+		switch name {
+		case "startsel":
+			opts.StartSel = value
+		case "stopsel":
+			opts.StopSel = value
+		case "maxwords":
+			opts.MaxWords, err = strconv.Atoi(value)
+		case "minwords":
+			opts.MinWords, err = strconv.Atoi(value)
+		case "shortword":
+			opts.ShortWord, err = strconv.Atoi(value)
+		case "highlightall":
+			switch strings.ToLower(value) {
+			case "1", "on", "true", "t", "y", "yes":
+				opts.HighlightAll = true
+			default:
+				opts.HighlightAll = false
+			}
+		default:
+			return opts, pgerror.Newf(pgcode.InvalidParameterValue,
+				"unrecognized headline parameter: %q", name)
+		}
+		if err != nil {
+			return opts, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid value for headline parameter %q: %q", name, value)
+		}
+	}
+	if !opts.HighlightAll {
+		if opts.MinWords >= opts.MaxWords {
+			return opts, pgerror.New(pgcode.InvalidParameterValue,
+				"MinWords should be less than MaxWords")
+		}
+		if opts.MinWords <= 0 {
+			return opts, pgerror.New(pgcode.InvalidParameterValue,
+				"MinWords should be positive")
+		}
+		if opts.ShortWord < 0 {
+			return opts, pgerror.New(pgcode.InvalidParameterValue,
+				"ShortWord should be >= 0")
+		}
+	}
+	return opts, nil
+}
+
+// Headline returns an excerpt of a document in which the words that match the
+// query are highlighted. The whole document is returned if it has at most
+// MaxWords words or if HighlightAll is set. Otherwise, the excerpt starts
+// around the first word that matches the query and has up to MaxWords words,
+// or is made of the first MinWords words if no word matches.
+func (c *Config) Headline(doc string, q TSQuery, opts HeadlineOptions) string {
+	tokens := tokenize(doc)
+	operands := q.Operands()
+	matches := make([]bool, len(tokens))
+	firstMatch := -1
+	for i, t := range tokens {
+		lexeme, stop := c.normalize(t.text)
+		if stop {
+			continue
+		}
+		for _, o := range operands {
+			if lexeme == o.Lexeme || (o.Prefix && strings.HasPrefix(lexeme, o.Lexeme)) {
+				matches[i] = true
+				if firstMatch < 0 {
+					firstMatch = i
+				}
+				break
+			}
+		}
+	}
+
+	start, end := 0, len(tokens)-1
+	whole := opts.HighlightAll || len(tokens) <= opts.MaxWords
+	if !whole {
+		if firstMatch < 0 {
+			end = opts.MinWords - 1
+		} else {
+			start, end = firstMatch, firstMatch
+			for end-start+1 < opts.MaxWords && end+1 < len(tokens) {
+				end++
+			}
+			for end-start+1 < opts.MaxWords && start > 0 {
+				start--
+			}
+		}
+		isShort := func(i int) bool {
+			return !matches[i] && utf8.RuneCountInString(tokens[i].text) <= opts.ShortWord
+		}
+		for start < end && isShort(start) {
+			start++
+		}
+		for end > start && isShort(end) {
+			end--
+		}
+	}
+
+	var b strings.Builder
+	prev := 0
+	if !whole && len(tokens) > 0 {
+		prev = tokens[start].start
+	}
+	for i := start; i <= end && i < len(tokens); i++ {
+		t := tokens[i]
+		b.WriteString(doc[prev:t.start])
+		if matches[i] {
+			b.WriteString(opts.StartSel)
+			b.WriteString(t.text)
+			b.WriteString(opts.StopSel)
+		} else {
+			b.WriteString(t.text)
+		}
+		prev = t.end
+	}
+	if whole {
+		b.WriteString(doc[prev:])
+	}
+	return b.String()
+}
@@ -0,0 +1,208 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"math"
+	"sort"
+)
+
+// DefaultRankWeights are the weights of the positions that are used by Rank,
+// indexed by Weight: 0.1 for D, 0.2 for C, 0.4 for B and 1.0 for A.
+var DefaultRankWeights = [4]float32{0.1, 0.2, 0.4, 1.0}
+
+// The flags of the normalization argument of Rank, which are combined with a
+// bitwise OR.
+const (
+	// RankNormLogLength divides the rank by 1 + the logarithm of the length of
+	// the document.
+	RankNormLogLength = 1
+	// RankNormLength divides the rank by the length of the document.
+	RankNormLength = 2
+	// RankNormUniq divides the rank by the number of unique words in the
+	// document.
+	RankNormUniq = 8
+	// RankNormLogUniq divides the rank by 1 + the logarithm of the number of
+	// unique words in the document.
+	RankNormLogUniq = 16
+	// RankNormRDivRPlus1 divides the rank by itself + 1.
+	RankNormRDivRPlus1 = 32
+)
+
+// maxEntryPos is the distance that is used for positions that are unknown.
+const maxEntryPos = 1 << 14
+
+// Rank returns the rank of a document for a query, which is the ts_rank
+// function of Postgres. It is computed with single precision floats to return
+// the same results as Postgres.
+func Rank(weights [4]float32, v TSVector, q TSQuery, method int) float32 {
+	if len(v) == 0 || q.Root == nil {
+		return 0
+	}
+	var res float32
+	if q.Root.Op == And || q.Root.Op == FollowedBy {
+		res = rankAnd(weights, v, q)
+	} else {
+		res = rankOr(weights, v, q)
+	}
+	if res < 0 {
+		res = 1e-20
+	}
+	if method&RankNormLogLength != 0 {
+		res = float32(float64(res) / (math.Log(float64(v.length()+1)) / math.Log(2.0)))
+	}
+	if method&RankNormLength != 0 {
+		if l := v.length(); l > 0 {
+			res /= float32(l)
+		}
+	}
+	if method&RankNormUniq != 0 {
+		res /= float32(len(v))
+	}
+	if method&RankNormLogUniq != 0 {
+		res = float32(float64(res) / (math.Log(float64(len(v)+1)) / math.Log(2.0)))
+	}
+	if method&RankNormRDivRPlus1 != 0 {
+		res /= res + 1
+	}
+	return res
+}
+
+// length returns the number of words of the document of the tsvector, which
+// is the number of positions, counting lexemes without positions once.
+func (v TSVector) length() int {
+	ret := 0
+	for _, t := range v {
+		if len(t.Positions) == 0 {
+			ret++
+		} else {
+			ret += len(t.Positions)
+		}
+	}
+	return ret
+}
+
+// uniqueOperandLexemes returns the distinct operands of the query, sorted by
+// their lexeme.
+func uniqueOperandLexemes(q TSQuery) []*Node {
+	operands := q.Operands()
+	sort.SliceStable(operands, func(i, j int) bool {
+		return operands[i].Lexeme < operands[j].Lexeme
+	})
+	ret := operands[:0]
+	for i, o := range operands {
+		if i == 0 || o.Lexeme != operands[i-1].Lexeme {
+			ret = append(ret, o)
+		}
+	}
+	return ret
+}
+
+// operandTerms returns the terms of the tsvector that match an operand.
+func (v TSVector) operandTerms(n *Node) []Term {
+	if n.Prefix {
+		start, end := v.findPrefix(n.Lexeme)
+		return v[start:end]
+	}
+	if i, ok := v.find(n.Lexeme); ok {
+		return v[i : i+1]
+	}
+	return nil
+}
+
+// rankPositions returns the positions of a term, or a single position with
+// the given position and the default weight if the term has no positions.
+func rankPositions(t Term, nullPos uint16) []Position {
+	if len(t.Positions) == 0 {
+		return []Position{{Pos: nullPos}}
+	}
+	return t.Positions
+}
+
+// wordDistance returns the weight of the distance between two lexemes.
+func wordDistance(dist int) float32 {
+	if dist > 100 {
+		return 1e-30
+	}
+	return float32(1.0 / (1.005 + 0.05*math.Exp(float64(float32(dist)/1.5-2))))
+}
+
+// rankOr is calc_rank_or of Postgres, which ranks each lexeme of the query on
+// its own.
+func rankOr(weights [4]float32, v TSVector, q TSQuery) float32 {
+	operands := uniqueOperandLexemes(q)
+	var res float32
+	for _, o := range operands {
+		for _, t := range v.operandTerms(o) {
+			var resj float32
+			wjm := float32(-1)
+			jm := 0
+			for j, p := range rankPositions(t, 0) {
+				w := weights[p.Weight]
+				resj += w / float32((j+1)*(j+1))
+				if w > wjm {
+					wjm = w
+					jm = j
+				}
+			}
+			// The sum of 1/i^2 tends to pi^2/6.
+			res = float32(float64(res) + float64(wjm+resj-wjm/float32((jm+1)*(jm+1)))/1.64493406685)
+		}
+	}
+	if len(operands) > 0 {
+		res /= float32(len(operands))
+	}
+	return res
+}
+
+// rankAnd is calc_rank_and of Postgres, which ranks the distances between the
+// pairs of lexemes of the query.
+func rankAnd(weights [4]float32, v TSVector, q TSQuery) float32 {
+	operands := uniqueOperandLexemes(q)
+	if len(operands) < 2 {
+		return rankOr(weights, v, q)
+	}
+	positions := make([][]Position, len(operands))
+	isNull := make([]bool, len(operands))
+	res := float32(-1)
+	for i, o := range operands {
+		for _, t := range v.operandTerms(o) {
+			positions[i] = rankPositions(t, maxEntryPos-1)
+			isNull[i] = len(t.Positions) == 0
+			for k := 0; k < i; k++ {
+				if positions[k] == nil {
+					continue
+				}
+				for _, l := range positions[i] {
+					for _, p := range positions[k] {
+						dist := int(l.Pos) - int(p.Pos)
+						if dist < 0 {
+							dist = -dist
+						}
+						if dist == 0 && !isNull[i] && !isNull[k] {
+							continue
+						}
+						if dist == 0 {
+							dist = maxEntryPos
+						}
+						curw := float32(math.Sqrt(float64(weights[l.Weight] * weights[p.Weight] * wordDistance(dist))))
+						if res < 0 {
+							res = curw
+						} else {
+							res = 1 - (1-res)*(1-curw)
+						}
+					}
+				}
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,364 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import "strings"
+
+// stemEnglish returns the stem of a lowercase English word, using the Porter2
+// algorithm of the Snowball project, which is also used by the english
+// configuration of Postgres. See
+// https://snowballstem.org/algorithms/english/stemmer.html.
+func stemEnglish(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	if s, ok := englishExceptions[word]; ok {
+		return s
+	}
+	s := englishStemmer{w: []rune(word)}
+	s.markConsonantYs()
+	s.computeRegions()
+	s.step0()
+	s.step1a()
+	if _, ok := englishExceptionsAfterStep1a[string(s.w)]; ok {
+		return strings.ToLower(string(s.w))
+	}
+	s.step1b()
+	s.step1c()
+	s.step2()
+	s.step3()
+	s.step4()
+	s.step5()
+	return strings.ToLower(string(s.w))
+}
+
+// englishExceptions are words whose stem is not computed by the algorithm.
+var englishExceptions = map[string]string{
+	"skis":   "ski",
+	"skies":  "sky",
+	"dying":  "die",
+	"lying":  "lie",
+	"tying":  "tie",
+	"idly":   "idl",
+	"gently": "gentl",
+	"ugly":   "ugli",
+	"early":  "earli",
+	"only":   "onli",
+	"singly": "singl",
+	"sky":    "sky",
+	"news":   "news",
+	"howe":   "howe",
+	"atlas":  "atlas",
+	"cosmos": "cosmos",
+	"bias":   "bias",
+	"andes":  "andes",
+}
+
+// englishExceptionsAfterStep1a are words that are left as they are after step
+// 1a.
+var englishExceptionsAfterStep1a = map[string]struct{}{
+	"inning": {}, "outing": {}, "canning": {}, "herring": {}, "earring": {},
+	"proceed": {}, "exceed": {}, "succeed": {},
+}
+
+type englishStemmer struct {
+	w []rune
+	// r1 and r2 are the start of the R1 and R2 regions of the word.
+	r1, r2 int
+}
+
+func isEnglishVowel(r rune) bool {
+	switch r {
+	case 'a', 'e', 'i', 'o', 'u', 'y':
+		return true
+	}
+	return false
+}
+
+// markConsonantYs replaces the ys that are used as consonants, at the start of
+// the word or after a vowel, with Y.
+func (s *englishStemmer) markConsonantYs() {
+	for i, r := range s.w {
+		if r == 'y' && (i == 0 || isEnglishVowel(s.w[i-1])) {
+			s.w[i] = 'Y'
+		}
+	}
+}
+
+// regionAfter returns the start of the region after the first non-vowel that
+// follows a vowel, starting at the given index.
+func (s *englishStemmer) regionAfter(start int) int {
+	for i := start + 1; i < len(s.w); i++ {
+		if !isEnglishVowel(s.w[i]) && isEnglishVowel(s.w[i-1]) {
+			return i + 1
+		}
+	}
+	return len(s.w)
+}
+
+func (s *englishStemmer) computeRegions() {
+	s.r1 = -1
+	for _, prefix := range []string{"gener", "commun", "arsen"} {
+		if strings.HasPrefix(string(s.w), prefix) {
+			s.r1 = len(prefix)
+			break
+		}
+	}
+	if s.r1 < 0 {
+		s.r1 = s.regionAfter(0)
+	}
+	s.r2 = s.regionAfter(s.r1)
+}
+
+func (s *englishStemmer) hasSuffix(suffix string) bool {
+	return strings.HasSuffix(string(s.w), suffix)
+}
+
+// longestSuffix returns the longest of the given suffixes of the word, or the
+// empty string.
+func (s *englishStemmer) longestSuffix(suffixes ...string) string {
+	var ret string
+	for _, suffix := range suffixes {
+		if len(suffix) > len(ret) && s.hasSuffix(suffix) {
+			ret = suffix
+		}
+	}
+	return ret
+}
+
+// suffixStart returns the index at which the given suffix starts.
+func (s *englishStemmer) suffixStart(suffix string) int {
+	return len(s.w) - len([]rune(suffix))
+}
+
+func (s *englishStemmer) inR1(suffix string) bool {
+	return s.suffixStart(suffix) >= s.r1
+}
+
+func (s *englishStemmer) inR2(suffix string) bool {
+	return s.suffixStart(suffix) >= s.r2
+}
+
+func (s *englishStemmer) replace(suffix, replacement string) {
+	s.w = append(s.w[:s.suffixStart(suffix)], []rune(replacement)...)
+}
+
+// containsVowel returns whether the word contains a vowel before the given
+// index.
+func (s *englishStemmer) containsVowel(end int) bool {
+	for _, r := range s.w[:end] {
+		if isEnglishVowel(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// endsWithShortSyllable returns whether the word ends with a short syllable:
+// either a non-vowel, a vowel and a non-vowel other than w, x or Y, or a vowel
+// and a non-vowel at the start of the word.
+func (s *englishStemmer) endsWithShortSyllable() bool {
+	n := len(s.w)
+	if n == 2 {
+		return isEnglishVowel(s.w[0]) && !isEnglishVowel(s.w[1])
+	}
+	if n >= 3 {
+		last := s.w[n-1]
+		return !isEnglishVowel(s.w[n-3]) && isEnglishVowel(s.w[n-2]) &&
+			!isEnglishVowel(last) && last != 'w' && last != 'x' && last != 'Y'
+	}
+	return false
+}
+
+// isShort returns whether the word ends with a short syllable and R1 is
+// empty.
+func (s *englishStemmer) isShort() bool {
+	return s.r1 >= len(s.w) && s.endsWithShortSyllable()
+}
+
+func (s *englishStemmer) step0() {
+	if suffix := s.longestSuffix("'", "'s", "'s'"); suffix != "" {
+		s.replace(suffix, "")
+	}
+}
+
+func (s *englishStemmer) step1a() {
+	switch suffix := s.longestSuffix("sses", "ied", "ies", "s", "us", "ss"); suffix {
+	case "sses":
+		s.replace(suffix, "ss")
+	case "ied", "ies":
+		if s.suffixStart(suffix) > 1 {
+			s.replace(suffix, "i")
+		} else {
+			s.replace(suffix, "ie")
+		}
+	case "s":
+		// Delete the s if the preceding part contains a vowel that is not
+		// immediately before it.
+		if s.containsVowel(len(s.w) - 2) {
+			s.replace(suffix, "")
+		}
+	}
+}
+
+func (s *englishStemmer) step1b() {
+	switch suffix := s.longestSuffix("eed", "eedly", "ed", "edly", "ing", "ingly"); suffix {
+	case "eed", "eedly":
+		if s.inR1(suffix) {
+			s.replace(suffix, "ee")
+		}
+	case "ed", "edly", "ing", "ingly":
+		if !s.containsVowel(s.suffixStart(suffix)) {
+			return
+		}
+		s.replace(suffix, "")
+		switch {
+		case s.hasSuffix("at"), s.hasSuffix("bl"), s.hasSuffix("iz"):
+			s.w = append(s.w, 'e')
+		case s.endsWithDouble():
+			s.w = s.w[:len(s.w)-1]
+		case s.isShort():
+			s.w = append(s.w, 'e')
+		}
+	}
+}
+
+func (s *englishStemmer) endsWithDouble() bool {
+	for _, d := range []string{"bb", "dd", "ff", "gg", "mm", "nn", "pp", "rr", "tt"} {
+		if s.hasSuffix(d) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *englishStemmer) step1c() {
+	n := len(s.w)
+	if n > 2 && (s.w[n-1] == 'y' || s.w[n-1] == 'Y') && !isEnglishVowel(s.w[n-2]) {
+		s.w[n-1] = 'i'
+	}
+}
+
+var englishStep2Suffixes = map[string]string{
+	"tional":  "tion",
+	"enci":    "ence",
+	"anci":    "ance",
+	"abli":    "able",
+	"entli":   "ent",
+	"izer":    "ize",
+	"ization": "ize",
+	"ational": "ate",
+	"ation":   "ate",
+	"ator":    "ate",
+	"alism":   "al",
+	"aliti":   "al",
+	"alli":    "al",
+	"fulness": "ful",
+	"ousli":   "ous",
+	"ousness": "ous",
+	"iveness": "ive",
+	"iviti":   "ive",
+	"biliti":  "ble",
+	"bli":     "ble",
+	"ogi":     "og",
+	"fulli":   "ful",
+	"lessli":  "less",
+	"li":      "",
+}
+
+func (s *englishStemmer) step2() {
+	var suffix string
+	for candidate := range englishStep2Suffixes {
+		if len(candidate) > len(suffix) && s.hasSuffix(candidate) {
+			suffix = candidate
+		}
+	}
+	if suffix == "" || !s.inR1(suffix) {
+		return
+	}
+	start := s.suffixStart(suffix)
+	switch suffix {
+	case "ogi":
+		if start == 0 || s.w[start-1] != 'l' {
+			return
+		}
+	case "li":
+		if start == 0 || !strings.ContainsRune("cdeghkmnrt", s.w[start-1]) {
+			return
+		}
+	}
+	s.replace(suffix, englishStep2Suffixes[suffix])
+}
+
+var englishStep3Suffixes = map[string]string{
+	"tional":  "tion",
+	"ational": "ate",
+	"alize":   "al",
+	"icate":   "ic",
+	"iciti":   "ic",
+	"ical":    "ic",
+	"ful":     "",
+	"ness":    "",
+	"ative":   "",
+}
+
+func (s *englishStemmer) step3() {
+	var suffix string
+	for candidate := range englishStep3Suffixes {
+		if len(candidate) > len(suffix) && s.hasSuffix(candidate) {
+			suffix = candidate
+		}
+	}
+	if suffix == "" || !s.inR1(suffix) {
+		return
+	}
+	if suffix == "ative" && !s.inR2(suffix) {
+		return
+	}
+	s.replace(suffix, englishStep3Suffixes[suffix])
+}
+
+func (s *englishStemmer) step4() {
+	suffix := s.longestSuffix(
+		"al", "ance", "ence", "er", "ic", "able", "ible", "ant", "ement", "ment",
+		"ent", "ism", "ate", "iti", "ous", "ive", "ize", "ion",
+	)
+	if suffix == "" || !s.inR2(suffix) {
+		return
+	}
+	if suffix == "ion" {
+		start := s.suffixStart(suffix)
+		if start == 0 || (s.w[start-1] != 's' && s.w[start-1] != 't') {
+			return
+		}
+	}
+	s.replace(suffix, "")
+}
+
+func (s *englishStemmer) step5() {
+	switch {
+	case s.hasSuffix("e"):
+		if s.inR2("e") {
+			s.replace("e", "")
+		} else if s.inR1("e") {
+			s.w = s.w[:len(s.w)-1]
+			short := s.endsWithShortSyllable()
+			s.w = append(s.w, 'e')
+			if !short {
+				s.replace("e", "")
+			}
+		}
+	case s.hasSuffix("l"):
+		if s.inR2("l") && s.hasSuffix("ll") {
+			s.replace("l", "")
+		}
+	}
+}
@@ -0,0 +1,345 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+)
+
+// Operator is an operator of a tsquery.
+type Operator byte
+
+// The operators of a tsquery, ordered by their precedence from the lowest to
+// the highest. Invalid is the zero value, and is used for the lexeme nodes of
+// a query.
+const (
+	Invalid Operator = iota
+	Or
+	And
+	FollowedBy
+	Not
+)
+
+func (o Operator) precedence() int {
+	return int(o)
+}
+
+// Node is a node of a tsquery. A node is either an operand with a lexeme, or
+// an operator with one (for Not) or two operands.
+type Node struct {
+	// Op is the operator of the node, or Invalid for operands.
+	Op Operator
+	// Distance is the distance between the operands of a FollowedBy operator.
+	// It is 1 for the <-> operator.
+	Distance int
+
+	// Left and Right are the operands of an operator. Not only has a Left
+	// operand.
+	Left, Right *Node
+
+	// Lexeme is the lexeme of an operand.
+	Lexeme string
+	// Prefix is set if the operand matches all lexemes that start with Lexeme.
+	Prefix bool
+	// Weights is a bitmask of the weights of the positions that the operand
+	// matches, indexed by Weight. If it is zero, all positions match.
+	Weights byte
+}
+
+// TSQuery is a full-text search query, which is matched against tsvectors.
+// The root of an empty query is nil.
+type TSQuery struct {
+	Root *Node
+}
+
+// String returns the text representation of the query, for example
+// 'fat' & ( 'rat' | 'cat':* ).
+func (q TSQuery) String() string {
+	if q.Root == nil {
+		return ""
+	}
+	var b strings.Builder
+	q.Root.format(&b, 0 /* parentPrecedence */, false /* rightPhrase */)
+	return b.String()
+}
+
+// format writes the node. Operators are parenthesized like in Postgres: if
+// their precedence is lower than the one of their parent, or if they are
+// FollowedBy operators on the right of another FollowedBy operator.
+func (n *Node) format(b *strings.Builder, parentPrecedence int, rightPhrase bool) {
+	if n.Op == Invalid {
+		writeLexeme(b, n.Lexeme)
+		if n.Prefix || n.Weights != 0 {
+			b.WriteByte(':')
+		}
+		if n.Prefix {
+			b.WriteByte('*')
+		}
+		for w := WeightA; ; w-- {
+			if n.Weights&(1<<w) != 0 {
+				b.WriteString(w.String())
+			}
+			if w == WeightD {
+				break
+			}
+		}
+		return
+	}
+	precedence := n.Op.precedence()
+	paren := precedence < parentPrecedence || (n.Op == FollowedBy && rightPhrase)
+	if paren {
+		b.WriteString("( ")
+	}
+	if n.Op == Not {
+		b.WriteByte('!')
+		n.Left.format(b, precedence, false /* rightPhrase */)
+	} else {
+		n.Left.format(b, precedence, false /* rightPhrase */)
+		b.WriteByte(' ')
+		b.WriteString(n.operatorString())
+		b.WriteByte(' ')
+		n.Right.format(b, precedence, n.Op == FollowedBy)
+	}
+	if paren {
+		b.WriteString(" )")
+	}
+}
+
+func (n *Node) operatorString() string {
+	switch n.Op {
+	case Or:
+		return "|"
+	case And:
+		return "&"
+	case FollowedBy:
+		if n.Distance == 1 {
+			return "<->"
+		}
+		return "<" + strconv.Itoa(n.Distance) + ">"
+	case Not:
+		return "!"
+	}
+	panic(errors.AssertionFailedf("unknown tsquery operator %d", n.Op))
+}
+
+// Compare compares two tsqueries. The order is not meaningful, but it is
+// consistent with equality.
+func (q TSQuery) Compare(other TSQuery) int {
+	return strings.Compare(q.String(), other.String())
+}
+
+// Operands returns the operands of the query, in the order in which they
+// appear.
+func (q TSQuery) Operands() []*Node {
+	var ret []*Node
+	var walk func(n *Node)
+	walk = func(n *Node) {
+		if n == nil {
+			return
+		}
+		if n.Op == Invalid {
+			ret = append(ret, n)
+			return
+		}
+		walk(n.Left)
+		walk(n.Right)
+	}
+	walk(q.Root)
+	return ret
+}
+
+// And returns the conjunction of the two queries.
+func (q TSQuery) And(other TSQuery) TSQuery {
+	return combineQueries(And, q, other)
+}
+
+// Or returns the disjunction of the two queries.
+func (q TSQuery) Or(other TSQuery) TSQuery {
+	return combineQueries(Or, q, other)
+}
+
+// Not returns the negation of the query.
+func (q TSQuery) Not() TSQuery {
+	if q.Root == nil {
+		return q
+	}
+	return TSQuery{Root: &Node{Op: Not, Left: q.Root}}
+}
+
+func combineQueries(op Operator, left, right TSQuery) TSQuery {
+	if left.Root == nil {
+		return right
+	}
+	if right.Root == nil {
+		return left
+	}
+	return TSQuery{Root: &Node{Op: op, Left: left.Root, Right: right.Root}}
+}
+
+// ParseTSQuery parses the text representation of a tsquery. Operands are
+// lexemes, optionally followed by a colon, a * for prefix matching and the
+// weights to match. The operators are ! (NOT), <-> and <N> (FOLLOWED BY), &
+// (AND) and | (OR), from the highest to the lowest precedence, and
+// parentheses can be used for grouping. For example:
+//
+//   fat & (rat:AB | cat:*) & !'big dog'
+//
+func ParseTSQuery(s string) (TSQuery, error) {
+	p := tsQueryParser{tsLexer: tsLexer{input: s, kind: "tsquery"}}
+	p.skipSpace()
+	if p.done() {
+		return TSQuery{}, nil
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return TSQuery{}, err
+	}
+	p.skipSpace()
+	if !p.done() {
+		return TSQuery{}, p.syntaxError()
+	}
+	return TSQuery{Root: root}, nil
+}
+
+type tsQueryParser struct {
+	tsLexer
+}
+
+func (p *tsQueryParser) parseOr() (*Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpace()
+		if p.peek() != '|' {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Node{Op: Or, Left: left, Right: right}
+	}
+}
+
+func (p *tsQueryParser) parseAnd() (*Node, error) {
+	left, err := p.parseFollowedBy()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpace()
+		if p.peek() != '&' {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseFollowedBy()
+		if err != nil {
+			return nil, err
+		}
+		left = &Node{Op: And, Left: left, Right: right}
+	}
+}
+
+func (p *tsQueryParser) parseFollowedBy() (*Node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpace()
+		if p.peek() != '<' {
+			return left, nil
+		}
+		p.pos++
+		distance := 1
+		if p.peek() == '-' {
+			p.pos++
+		} else {
+			start := p.pos
+			for !p.done() && p.peek() >= '0' && p.peek() <= '9' {
+				p.pos++
+			}
+			if start == p.pos {
+				return nil, p.syntaxError()
+			}
+			distance, err = strconv.Atoi(p.input[start:p.pos])
+			if err != nil || distance > MaxPosition {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+					"distance in phrase operator must be an integer value between zero and %d inclusive",
+					MaxPosition)
+			}
+		}
+		if p.peek() != '>' {
+			return nil, p.syntaxError()
+		}
+		p.pos++
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &Node{Op: FollowedBy, Distance: distance, Left: left, Right: right}
+	}
+}
+
+func (p *tsQueryParser) parseNot() (*Node, error) {
+	p.skipSpace()
+	switch p.peek() {
+	case '!':
+		p.pos++
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &Node{Op: Not, Left: operand}, nil
+	case '(':
+		p.pos++
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.peek() != ')' {
+			return nil, p.syntaxError()
+		}
+		p.pos++
+		return n, nil
+	}
+	if p.done() || isQueryOperator(p.peek()) {
+		return nil, p.syntaxError()
+	}
+	lexeme, err := p.lexeme(true /* inQuery */)
+	if err != nil {
+		return nil, err
+	}
+	n := &Node{Lexeme: lexeme}
+	if p.peek() == ':' {
+		p.pos++
+		for !p.done() {
+			if p.peek() == '*' {
+				n.Prefix = true
+			} else if w, ok := parseWeight(p.peek()); ok {
+				n.Weights |= 1 << w
+			} else {
+				break
+			}
+			p.pos++
+		}
+	}
+	return n, nil
+}
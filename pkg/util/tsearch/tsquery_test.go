@@ -0,0 +1,108 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTSQuery(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{``, ``},
+		{`fat & rat`, `'fat' & 'rat'`},
+		{`fat & (rat | cat)`, `'fat' & ( 'rat' | 'cat' )`},
+		{`fat & rat & ! cat`, `'fat' & 'rat' & !'cat'`},
+		{`fat | rat & cat`, `'fat' | 'rat' & 'cat'`},
+		{`fat:ab & cat`, `'fat':AB & 'cat'`},
+		{`super:*`, `'super':*`},
+		{`super:*a`, `'super':*A`},
+		{`'big dog' | 'Joe''s'`, `'big dog' | 'Joe''s'`},
+		{`a <-> b`, `'a' <-> 'b'`},
+		{`a <2> b`, `'a' <2> 'b'`},
+		{`a <-> (b <-> c)`, `'a' <-> ( 'b' <-> 'c' )`},
+		{`(a <-> b) <-> c`, `'a' <-> 'b' <-> 'c'`},
+		{`a <-> (b | c)`, `'a' <-> ( 'b' | 'c' )`},
+		{`!(a | b)`, `!( 'a' | 'b' )`},
+		{`!!a`, `!!'a'`},
+		{`!a <-> b`, `!'a' <-> 'b'`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			q, err := ParseTSQuery(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, q.String())
+
+			roundTripped, err := ParseTSQuery(q.String())
+			require.NoError(t, err)
+			require.Equal(t, q.String(), roundTripped.String())
+
+			decoded, err := DecodeTSQuery(EncodeTSQuery(nil, q))
+			require.NoError(t, err)
+			require.Equal(t, q.String(), decoded.String())
+		})
+	}
+
+	for _, input := range []string{`&`, `a &`, `a | | b`, `(a`, `a)`, `a <x> b`, `a <99999> b`, `a b`, `!`} {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseTSQuery(input)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		vector   string
+		query    string
+		expected bool
+	}{
+		{`a b c`, ``, false},
+		{`fat:2 cat:3`, `cat`, true},
+		{`fat:2 cat:3`, `rat`, false},
+		{`fat:2 cat:3`, `fat & cat`, true},
+		{`fat:2 cat:3`, `fat & rat`, false},
+		{`fat:2 cat:3`, `rat | cat`, true},
+		{`fat:2 cat:3`, `!rat`, true},
+		{`fat:2 cat:3`, `fat & !cat`, false},
+		{`supernova:1`, `super:*`, true},
+		{`supernova:1`, `supper:*`, false},
+		{`a:1A b:2`, `a:A`, true},
+		{`a:1A b:2`, `a:B`, false},
+		{`a:1A b:2`, `b:D`, true},
+		{`a:1 b:2`, `a <-> b`, true},
+		{`a:1 b:2`, `b <-> a`, false},
+		{`a:1 b:3`, `a <-> b`, false},
+		{`a:1 b:3`, `a <2> b`, true},
+		{`a:1 b:2 c:3`, `a <-> b <-> c`, true},
+		{`a:1 b:2 c:3`, `a <-> (b <-> c)`, true},
+		{`a:1 b:2 c:4`, `a <-> b <-> c`, false},
+		{`a:1 b:2 c:3`, `a <-> !c`, true},
+		{`a:1 c:2`, `a <-> !c`, false},
+		{`a:1 b:2 c:5`, `a <-> (b | c)`, true},
+		{`a:1 b:4 c:2`, `a <-> (b & c)`, false},
+		{`a:1 b:2`, `a:A <-> b`, false},
+		{`a b`, `a <-> b`, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.vector+" @@ "+tc.query, func(t *testing.T) {
+			v, err := ParseTSVector(tc.vector)
+			require.NoError(t, err)
+			q, err := ParseTSQuery(tc.query)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, Match(v, q))
+		})
+	}
+}
@@ -0,0 +1,432 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package tsearch implements the full-text search types tsvector and tsquery,
+// the text search configurations used to produce them from documents, and the
+// functions that match and rank documents against queries.
+package tsearch
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// Weight is the weight of a lexeme position in a tsvector. Weights are used to
+// mark positions that come from different parts of a document, for example
+// the title and the body, and to rank matches in those parts differently.
+type Weight byte
+
+// The weights of a position, from the least to the most important. D is the
+// default weight, and is not printed.
+const (
+	WeightD Weight = iota
+	WeightC
+	WeightB
+	WeightA
+)
+
+// String returns the letter of the weight.
+func (w Weight) String() string {
+	return string(rune('D' - w))
+}
+
+func parseWeight(r rune) (Weight, bool) {
+	switch unicode.ToUpper(r) {
+	case 'A':
+		return WeightA, true
+	case 'B':
+		return WeightB, true
+	case 'C':
+		return WeightC, true
+	case 'D':
+		return WeightD, true
+	}
+	return 0, false
+}
+
+// ParseWeight parses a weight, which is one of the letters A to D.
+func ParseWeight(s string) (Weight, error) {
+	if r := []rune(s); len(r) == 1 {
+		if w, ok := parseWeight(r[0]); ok {
+			return w, nil
+		}
+	}
+	return 0, pgerror.Newf(pgcode.InvalidParameterValue, "unrecognized weight: %q", s)
+}
+
+const (
+	// MaxPosition is the largest position that can be stored in a tsvector.
+	// Larger positions are silently clamped to it, like in Postgres.
+	MaxPosition = 1<<14 - 1
+	// maxPositionsPerLexeme is the maximum number of positions that are stored
+	// for a single lexeme. Further positions are dropped.
+	maxPositionsPerLexeme = 256
+)
+
+// Position is the position of a lexeme in a document, with its weight.
+type Position struct {
+	Pos    uint16
+	Weight Weight
+}
+
+// Term is a lexeme of a tsvector, with the positions at which it appears in
+// the document. Positions may be empty, for example for tsvectors that were
+// written without positions.
+type Term struct {
+	Lexeme    string
+	Positions []Position
+}
+
+// TSVector is a sorted list of distinct lexemes, each with its sorted list of
+// distinct positions. It is the result of normalizing a document with a text
+// search configuration.
+type TSVector []Term
+
+// NewTSVector returns the tsvector that contains the given terms. The terms
+// are sorted, and the positions of duplicate lexemes are merged.
+func NewTSVector(terms []Term) TSVector {
+	if len(terms) == 0 {
+		return TSVector{}
+	}
+	sort.SliceStable(terms, func(i, j int) bool {
+		return terms[i].Lexeme < terms[j].Lexeme
+	})
+	ret := make(TSVector, 0, len(terms))
+	for _, t := range terms {
+		if n := len(ret); n > 0 && ret[n-1].Lexeme == t.Lexeme {
+			ret[n-1].Positions = append(ret[n-1].Positions, t.Positions...)
+			continue
+		}
+		ret = append(ret, Term{Lexeme: t.Lexeme, Positions: append([]Position(nil), t.Positions...)})
+	}
+	for i := range ret {
+		ret[i].Positions = normalizePositions(ret[i].Positions)
+	}
+	return ret
+}
+
+// normalizePositions sorts the given positions and removes duplicates. If the
+// same position appears with different weights, the highest weight is kept.
+func normalizePositions(positions []Position) []Position {
+	if len(positions) == 0 {
+		return nil
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		return positions[i].Pos < positions[j].Pos
+	})
+	ret := positions[:1]
+	for _, p := range positions[1:] {
+		last := &ret[len(ret)-1]
+		if p.Pos == last.Pos {
+			if p.Weight > last.Weight {
+				last.Weight = p.Weight
+			}
+			continue
+		}
+		ret = append(ret, p)
+	}
+	if len(ret) > maxPositionsPerLexeme {
+		ret = ret[:maxPositionsPerLexeme]
+	}
+	return ret
+}
+
+// find returns the index of the given lexeme in the tsvector, and whether it
+// was found.
+func (v TSVector) find(lexeme string) (int, bool) {
+	i := sort.Search(len(v), func(i int) bool {
+		return v[i].Lexeme >= lexeme
+	})
+	return i, i < len(v) && v[i].Lexeme == lexeme
+}
+
+// findPrefix returns the range [start, end) of the terms whose lexeme starts
+// with the given prefix.
+func (v TSVector) findPrefix(prefix string) (start, end int) {
+	start = sort.Search(len(v), func(i int) bool {
+		return v[i].Lexeme >= prefix
+	})
+	end = start
+	for end < len(v) && strings.HasPrefix(v[end].Lexeme, prefix) {
+		end++
+	}
+	return start, end
+}
+
+// Concat returns the concatenation of two tsvectors. The positions of the
+// right tsvector are shifted by the largest position of the left one, like in
+// Postgres.
+func (v TSVector) Concat(other TSVector) TSVector {
+	var maxPos uint16
+	for _, t := range v {
+		for _, p := range t.Positions {
+			if p.Pos > maxPos {
+				maxPos = p.Pos
+			}
+		}
+	}
+	terms := make([]Term, 0, len(v)+len(other))
+	terms = append(terms, v...)
+	for _, t := range other {
+		shifted := Term{Lexeme: t.Lexeme, Positions: make([]Position, len(t.Positions))}
+		for i, p := range t.Positions {
+			pos := int(p.Pos) + int(maxPos)
+			if pos > MaxPosition {
+				pos = MaxPosition
+			}
+			shifted.Positions[i] = Position{Pos: uint16(pos), Weight: p.Weight}
+		}
+		terms = append(terms, shifted)
+	}
+	return NewTSVector(terms)
+}
+
+// Strip returns a copy of the tsvector without positions.
+func (v TSVector) Strip() TSVector {
+	ret := make(TSVector, len(v))
+	for i, t := range v {
+		ret[i] = Term{Lexeme: t.Lexeme}
+	}
+	return ret
+}
+
+// SetWeight returns a copy of the tsvector in which all positions have the
+// given weight.
+func (v TSVector) SetWeight(w Weight) TSVector {
+	ret := make(TSVector, len(v))
+	for i, t := range v {
+		ret[i] = Term{Lexeme: t.Lexeme, Positions: make([]Position, len(t.Positions))}
+		for j, p := range t.Positions {
+			ret[i].Positions[j] = Position{Pos: p.Pos, Weight: w}
+		}
+	}
+	return ret
+}
+
+// Lexemes returns the lexemes of the tsvector.
+func (v TSVector) Lexemes() []string {
+	ret := make([]string, len(v))
+	for i, t := range v {
+		ret[i] = t.Lexeme
+	}
+	return ret
+}
+
+// Compare compares two tsvectors. The order is not meaningful, but it is
+// consistent with equality.
+func (v TSVector) Compare(other TSVector) int {
+	return strings.Compare(v.String(), other.String())
+}
+
+// String returns the text representation of the tsvector, for example
+// 'cat':3 'fat':2A,4.
+func (v TSVector) String() string {
+	var b strings.Builder
+	for i, t := range v {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		writeLexeme(&b, t.Lexeme)
+		for j, p := range t.Positions {
+			if j == 0 {
+				b.WriteByte(':')
+			} else {
+				b.WriteByte(',')
+			}
+			b.WriteString(strconv.Itoa(int(p.Pos)))
+			if p.Weight != WeightD {
+				b.WriteString(p.Weight.String())
+			}
+		}
+	}
+	return b.String()
+}
+
+// writeLexeme writes a quoted lexeme. Quotes and backslashes in the lexeme are
+// doubled.
+func writeLexeme(b *strings.Builder, lexeme string) {
+	b.WriteByte('\'')
+	for _, r := range lexeme {
+		if r == '\'' || r == '\\' {
+			b.WriteRune(r)
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('\'')
+}
+
+// ParseTSVector parses the text representation of a tsvector. Lexemes are
+// separated by whitespace and may be quoted, and each lexeme may be followed
+// by a colon and a comma-separated list of positions, each with an optional
+// weight. For example:
+//
+//   a fat 'cat sat':3 on:4A,5
+//
+func ParseTSVector(s string) (TSVector, error) {
+	p := tsLexer{input: s, kind: "tsvector"}
+	var terms []Term
+	for {
+		p.skipSpace()
+		if p.done() {
+			break
+		}
+		lexeme, err := p.lexeme(false /* inQuery */)
+		if err != nil {
+			return nil, err
+		}
+		term := Term{Lexeme: lexeme}
+		if p.peek() == ':' {
+			p.pos++
+			if term.Positions, err = p.positions(); err != nil {
+				return nil, err
+			}
+		}
+		terms = append(terms, term)
+	}
+	return NewTSVector(terms), nil
+}
+
+// tsLexer splits the text representation of tsvectors and tsqueries into
+// tokens.
+type tsLexer struct {
+	input string
+	pos   int
+	// kind is the name of the parsed type, used in errors.
+	kind string
+}
+
+func (p *tsLexer) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *tsLexer) peek() rune {
+	if p.done() {
+		return 0
+	}
+	r, _ := utf8.DecodeRuneInString(p.input[p.pos:])
+	return r
+}
+
+func (p *tsLexer) next() rune {
+	r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+	p.pos += size
+	return r
+}
+
+func (p *tsLexer) skipSpace() {
+	for !p.done() && unicode.IsSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+// isQueryOperator returns whether the rune ends an unquoted lexeme in a
+// tsquery.
+func isQueryOperator(r rune) bool {
+	switch r {
+	case '&', '|', '!', '(', ')', '<':
+		return true
+	}
+	return false
+}
+
+// lexeme reads a quoted or unquoted lexeme. Unquoted lexemes end at
+// whitespace or at a colon, and in queries also at operators. Backslash
+// escapes the next character in both forms, and in quoted lexemes a quote can
+// also be written as two quotes.
+func (p *tsLexer) lexeme(inQuery bool) (string, error) {
+	var b strings.Builder
+	if p.peek() == '\'' {
+		p.pos++
+		for {
+			if p.done() {
+				return "", p.syntaxError()
+			}
+			r := p.next()
+			switch r {
+			case '\\':
+				if p.done() {
+					return "", p.syntaxError()
+				}
+				b.WriteRune(p.next())
+			case '\'':
+				if p.peek() == '\'' {
+					p.pos++
+					b.WriteRune(r)
+					continue
+				}
+				if b.Len() == 0 {
+					return "", p.syntaxError()
+				}
+				return b.String(), nil
+			default:
+				b.WriteRune(r)
+			}
+		}
+	}
+	for !p.done() {
+		r := p.peek()
+		if unicode.IsSpace(r) || r == ':' || (inQuery && isQueryOperator(r)) {
+			break
+		}
+		p.pos += utf8.RuneLen(r)
+		if r == '\\' {
+			if p.done() {
+				return "", p.syntaxError()
+			}
+			r = p.next()
+		}
+		b.WriteRune(r)
+	}
+	if b.Len() == 0 {
+		return "", p.syntaxError()
+	}
+	return b.String(), nil
+}
+
+// positions reads a comma-separated list of positions with optional weights.
+func (p *tsLexer) positions() ([]Position, error) {
+	var ret []Position
+	for {
+		start := p.pos
+		for !p.done() && p.peek() >= '0' && p.peek() <= '9' {
+			p.pos++
+		}
+		if start == p.pos {
+			return nil, p.syntaxError()
+		}
+		n, err := strconv.Atoi(p.input[start:p.pos])
+		if err != nil || n > MaxPosition {
+			n = MaxPosition
+		}
+		if n == 0 {
+			return nil, pgerror.Newf(pgcode.Syntax, "wrong position info in tsvector: \"%s\"", p.input)
+		}
+		pos := Position{Pos: uint16(n)}
+		if w, ok := parseWeight(p.peek()); ok {
+			p.pos++
+			pos.Weight = w
+		}
+		ret = append(ret, pos)
+		if p.peek() != ',' {
+			return ret, nil
+		}
+		p.pos++
+	}
+}
+
+func (p *tsLexer) syntaxError() error {
+	return pgerror.Newf(pgcode.Syntax, "syntax error in %s: \"%s\"", p.kind, p.input)
+}
@@ -0,0 +1,78 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTSVector(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{``, ``},
+		{`a fat cat sat on a mat and ate a fat rat`, `'a' 'and' 'ate' 'cat' 'fat' 'mat' 'on' 'rat' 'sat'`},
+		{`a:1 fat:2 cat:3`, `'a':1 'cat':3 'fat':2`},
+		{`a:1A fat:2B,4C cat:5D`, `'a':1A 'cat':5 'fat':2B,4C`},
+		{`a:3,1,3a,2`, `'a':1,2,3A`},
+		{`a:1 a:2`, `'a':1,2`},
+		{`a:99999`, `'a':16383`},
+		{`the lexeme '    ' contains spaces`, `'    ' 'contains' 'lexeme' 'spaces' 'the'`},
+		{`the lexeme 'Joe''s' contains a quote`, `'Joe''s' 'a' 'contains' 'lexeme' 'quote' 'the'`},
+		{`back\\slash 'it\'s'`, `'back\\slash' 'it''s'`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			v, err := ParseTSVector(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, v.String())
+
+			// The text representation must parse back to the same tsvector.
+			roundTripped, err := ParseTSVector(v.String())
+			require.NoError(t, err)
+			require.Equal(t, v.String(), roundTripped.String())
+		})
+	}
+
+	for _, input := range []string{`'unterminated`, `''`, `a:`, `a:0`, `a:x`, `a\`} {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseTSVector(input)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestTSVectorOperations(t *testing.T) {
+	parse := func(s string) TSVector {
+		v, err := ParseTSVector(s)
+		require.NoError(t, err)
+		return v
+	}
+	require.Equal(t, `'a':1 'b':2,7 'c':4 'd':5`, parse(`a:1 b:2 c:4`).Concat(parse(`d:1 b:3`)).String())
+	require.Equal(t, `'a' 'b'`, parse(`a:1A b:2,3`).Strip().String())
+	require.Equal(t, `'a':1B 'b':2B,3B 'c'`, parse(`a:1A b:2,3 c`).SetWeight(WeightB).String())
+	require.Equal(t, []string{"a", "b"}, parse(`b:2 a:1`).Lexemes())
+}
+
+func TestEncodeTSVector(t *testing.T) {
+	for _, input := range []string{``, `a`, `a:1A fat:2B,4C cat:5D 'Joe''s':16383`} {
+		v, err := ParseTSVector(input)
+		require.NoError(t, err)
+		decoded, err := DecodeTSVector(EncodeTSVector(nil, v))
+		require.NoError(t, err)
+		require.Equal(t, v.String(), decoded.String())
+	}
+	_, err := DecodeTSVector([]byte{0, 0, 0, 1, 'a'})
+	require.Error(t, err)
+}